@@ -229,25 +229,26 @@ func SimplifyCreditRecharge(one *entity.CreditRecharge) *CreditRecharge {
 }
 
 type CreditTransaction struct {
-	Id                  int64  `json:"id"                 description:"Id"`                                                                                                                                                          // Id
-	UserId              uint64 `json:"userId"             description:"user_id"`                                                                                                                                                     // user_id
-	CreditId            uint64 `json:"creditId"           description:"id of credit account"`                                                                                                                                        // id of credit account
-	Currency            string `json:"currency"           description:"currency"`                                                                                                                                                    // currency
-	TransactionId       string `json:"transactionId"      description:"unique id for timeline"`                                                                                                                                      // unique id for timeline
-	TransactionType     int    `json:"transactionType"    description:"transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out"`              // transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out
-	CreditAmountAfter   int64  `json:"creditAmountAfter"  description:"the credit amount after transaction,cent"`                                                                                                                    // the credit amount after transaction,cent
-	CreditAmountBefore  int64  `json:"creditAmountBefore" description:"the credit amount before transaction,cent"`                                                                                                                   // the credit amount before transaction,cent
-	DeltaAmount         int64  `json:"deltaAmount"        description:"delta amount,cent"`                                                                                                                                           // delta amount,cent
-	DeltaCurrencyAmount int64  `json:"deltaCurrencyAmount"     description:"delta currency amount, in cent"`                                                                                                                         // currency amount,cent
-	ExchangeRate        int64  `json:"exchangeRate"          description:"ExchangeRate for transaction, keep two decimal places，multiply by 100 saved, 1 currency = 1 credit * (exchange_rate/100), main account fixed rate to 100"` // keep two decimal places，multiply by 100 saved, 1 currency = 1 credit * (exchange_rate/100), main account fixed rate to 100
-	BizId               string `json:"bizId"              description:"business id"`                                                                                                                                                 // bisness id
-	Name                string `json:"name"               description:"recharge transaction title"`                                                                                                                                  // recharge transaction title
-	Description         string `json:"description"        description:"recharge transaction description"`                                                                                                                            // recharge transaction description 	// update time
-	CreateTime          int64  `json:"createTime"         description:"create utc time"`                                                                                                                                             // create utc time
-	MerchantId          uint64 `json:"merchantId"         description:"merchant id"`                                                                                                                                                 // merchant id
-	InvoiceId           string `json:"invoiceId"         description:"invoice_id"`                                                                                                                                                   // invoice_id
-	AccountType         int    `json:"accountType"       description:"type of credit account, 1-main recharge account, 2-promo credit account"`                                                                                      // type of credit account, 1-main recharge account, 2-promo credit account
-	By                  string `json:"by"  dc:"" `
+	Id                  int64              `json:"id"                 description:"Id"`                                                                                                                                                          // Id
+	UserId              uint64             `json:"userId"             description:"user_id"`                                                                                                                                                     // user_id
+	CreditId            uint64             `json:"creditId"           description:"id of credit account"`                                                                                                                                        // id of credit account
+	Currency            string             `json:"currency"           description:"currency"`                                                                                                                                                    // currency
+	TransactionId       string             `json:"transactionId"      description:"unique id for timeline"`                                                                                                                                      // unique id for timeline
+	TransactionType     int                `json:"transactionType"    description:"transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out，8-expired"`    // transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out，8-expired
+	CreditAmountAfter   int64              `json:"creditAmountAfter"  description:"the credit amount after transaction,cent"`                                                                                                                    // the credit amount after transaction,cent
+	CreditAmountBefore  int64              `json:"creditAmountBefore" description:"the credit amount before transaction,cent"`                                                                                                                   // the credit amount before transaction,cent
+	DeltaAmount         int64              `json:"deltaAmount"        description:"delta amount,cent"`                                                                                                                                           // delta amount,cent
+	DeltaCurrencyAmount int64              `json:"deltaCurrencyAmount"     description:"delta currency amount, in cent"`                                                                                                                         // currency amount,cent
+	ExchangeRate        int64              `json:"exchangeRate"          description:"ExchangeRate for transaction, keep two decimal places，multiply by 100 saved, 1 currency = 1 credit * (exchange_rate/100), main account fixed rate to 100"` // keep two decimal places，multiply by 100 saved, 1 currency = 1 credit * (exchange_rate/100), main account fixed rate to 100
+	BizId               string             `json:"bizId"              description:"business id"`                                                                                                                                                 // bisness id
+	Name                string             `json:"name"               description:"recharge transaction title"`                                                                                                                                  // recharge transaction title
+	Description         string             `json:"description"        description:"recharge transaction description"`                                                                                                                            // recharge transaction description 	// update time
+	CreateTime          int64              `json:"createTime"         description:"create utc time"`                                                                                                                                             // create utc time
+	MerchantId          uint64             `json:"merchantId"         description:"merchant id"`                                                                                                                                                 // merchant id
+	InvoiceId           string             `json:"invoiceId"         description:"invoice_id"`                                                                                                                                                   // invoice_id
+	AccountType         int                `json:"accountType"       description:"type of credit account, 1-main recharge account, 2-promo credit account"`                                                                                      // type of credit account, 1-main recharge account, 2-promo credit account
+	By                  string             `json:"by"  dc:"" `
+	GrantDetails        []*CreditGrantItem `json:"grantDetails" description:"the credit grants increased or consumed by transaction"`
 }
 
 func ConvertTransactionCreditAmountToCurrency(ctx context.Context, merchantId uint64, creditType int, currency string, creditAmount int64, transactionExchangeRate int64) (currencyAmount int64, exchangeRate int64) {
@@ -327,6 +328,7 @@ func SimplifyCreditTransaction(ctx context.Context, one *entity.CreditTransactio
 		InvoiceId:           one.InvoiceId,
 		AccountType:         one.AccountType,
 		By:                  by,
+		GrantDetails:        ConvertCreditGrantItems(one.GrantDetail),
 	}
 }
 
@@ -421,3 +423,67 @@ func SimplifyCreditRefund(one *entity.CreditRefund) *CreditRefund {
 		AccountType:            one.AccountType,
 	}
 }
+
+type CreditGrant struct {
+	Id              uint64 `json:"id"              description:"Id"`
+	UserId          uint64 `json:"userId"          description:"user id"`
+	CreditId        uint64 `json:"creditId"        description:"id of credit account"`
+	Currency        string `json:"currency"        description:"currency"`
+	AccountType     int    `json:"accountType"     description:"type of credit account, 1-main recharge account, 2-promo credit account"`
+	GrantId         string `json:"grantId"         description:"grant id"`
+	Source          string `json:"source"          description:"source of grant, recharge|admin|refund"`
+	BizId           string `json:"bizId"           description:"business id of the source"`
+	Amount          int64  `json:"amount"          description:"granted credit amount"`
+	RemainingAmount int64  `json:"remainingAmount" description:"remaining credit amount of grant"`
+	ExpireTime      int64  `json:"expireTime"      description:"utc time the remaining amount expired, 0-never expire"`
+	Status          int    `json:"status"          description:"status, 1-active, 2-used up, 3-expired"`
+	Name            string `json:"name"            description:"name"`
+	Description     string `json:"description"     description:"description"`
+	CreateTime      int64  `json:"createTime"      description:"create utc time"`
+}
+
+func SimplifyCreditGrant(one *entity.CreditGrant) *CreditGrant {
+	if one == nil {
+		return nil
+	}
+	return &CreditGrant{
+		Id:              one.Id,
+		UserId:          one.UserId,
+		CreditId:        one.CreditId,
+		Currency:        one.Currency,
+		AccountType:     one.AccountType,
+		GrantId:         one.GrantId,
+		Source:          one.Source,
+		BizId:           one.BizId,
+		Amount:          one.Amount,
+		RemainingAmount: one.RemainingAmount,
+		ExpireTime:      one.ExpireTime,
+		Status:          one.Status,
+		Name:            one.Name,
+		Description:     one.Description,
+		CreateTime:      one.CreateTime,
+	}
+}
+
+func SimplifyCreditGrantList(list []*entity.CreditGrant) []*CreditGrant {
+	var result = make([]*CreditGrant, 0)
+	for _, one := range list {
+		result = append(result, SimplifyCreditGrant(one))
+	}
+	return result
+}
+
+// CreditGrantItem is the amount one transaction increased into or consumed from the credit grant
+type CreditGrantItem struct {
+	GrantId    string `json:"grantId"    description:"grant id"`
+	Amount     int64  `json:"amount"     description:"credit amount increased or consumed"`
+	ExpireTime int64  `json:"expireTime" description:"utc time the grant expired, 0-never expire"`
+}
+
+func ConvertCreditGrantItems(grantDetail string) []*CreditGrantItem {
+	var list = make([]*CreditGrantItem, 0)
+	if len(grantDetail) > 0 {
+		_ = utility.UnmarshalFromJsonString(grantDetail, &list)
+	}
+	return list
+}
@@ -51,26 +51,27 @@ type CreditRechargeDetail struct {
 }
 
 type CreditTransactionDetail struct {
-	Id                  int64                `json:"id"                 description:"Id"` // Id
-	User                *bean.UserAccount    `json:"user"`
-	CreditAccount       *bean.CreditAccount  `json:"creditAccount"`
-	Currency            string               `json:"currency"           description:"currency"`                                                                                                                                                    // currency
-	TransactionId       string               `json:"transactionId"      description:"unique id for timeline"`                                                                                                                                      // unique id for timeline
-	TransactionType     int                  `json:"transactionType"    description:"transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out"`              // transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out
-	CreditAmountAfter   int64                `json:"creditAmountAfter"  description:"the credit amount after transaction,cent"`                                                                                                                    // the credit amount after transaction,cent
-	CreditAmountBefore  int64                `json:"creditAmountBefore" description:"the credit amount before transaction,cent"`                                                                                                                   // the credit amount before transaction,cent
-	DeltaAmount         int64                `json:"deltaAmount"        description:"delta amount,cent"`                                                                                                                                           // delta amount,cent
-	DeltaCurrencyAmount int64                `json:"deltaCurrencyAmount"     description:"delta currency amount, in cent"`                                                                                                                         // currency amount,cent
-	ExchangeRate        int64                `json:"exchangeRate"          description:"ExchangeRate for transaction, keep two decimal places，multiply by 100 saved, 1 currency = 1 credit * (exchange_rate/100), main account fixed rate to 100"` // keep two decimal places，multiply by 100 saved, 1 currency = 1 credit * (exchange_rate/100), main account fixed rate to 100
-	BizId               string               `json:"bizId"              description:"business id"`                                                                                                                                                 // bisness id
-	Name                string               `json:"name"               description:"recharge transaction title"`                                                                                                                                  // recharge transaction title
-	Description         string               `json:"description"        description:"recharge transaction description"`                                                                                                                            // recharge transaction description 	// update time
-	CreateTime          int64                `json:"createTime"         description:"create utc time"`                                                                                                                                             // create utc time
-	MerchantId          uint64               `json:"merchantId"         description:"merchant id"`                                                                                                                                                 // merchant id
-	InvoiceId           string               `json:"invoiceId"         description:"invoice_id"`                                                                                                                                                   // invoice_id
-	AccountType         int                  `json:"accountType"       description:"type of credit account, 1-main recharge account, 2-promo credit account"`                                                                                      // type of credit account, 1-main recharge account, 2-promo credit account
-	AdminMember         *bean.MerchantMember `json:"adminMember"       description:"admin member"`
-	By                  string               `json:"by"  dc:"" `
+	Id                  int64                   `json:"id"                 description:"Id"` // Id
+	User                *bean.UserAccount       `json:"user"`
+	CreditAccount       *bean.CreditAccount     `json:"creditAccount"`
+	Currency            string                  `json:"currency"           description:"currency"`                                                                                                                                                    // currency
+	TransactionId       string                  `json:"transactionId"      description:"unique id for timeline"`                                                                                                                                      // unique id for timeline
+	TransactionType     int                     `json:"transactionType"    description:"transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out，8-expired"`    // transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out，8-expired
+	CreditAmountAfter   int64                   `json:"creditAmountAfter"  description:"the credit amount after transaction,cent"`                                                                                                                    // the credit amount after transaction,cent
+	CreditAmountBefore  int64                   `json:"creditAmountBefore" description:"the credit amount before transaction,cent"`                                                                                                                   // the credit amount before transaction,cent
+	DeltaAmount         int64                   `json:"deltaAmount"        description:"delta amount,cent"`                                                                                                                                           // delta amount,cent
+	DeltaCurrencyAmount int64                   `json:"deltaCurrencyAmount"     description:"delta currency amount, in cent"`                                                                                                                         // currency amount,cent
+	ExchangeRate        int64                   `json:"exchangeRate"          description:"ExchangeRate for transaction, keep two decimal places，multiply by 100 saved, 1 currency = 1 credit * (exchange_rate/100), main account fixed rate to 100"` // keep two decimal places，multiply by 100 saved, 1 currency = 1 credit * (exchange_rate/100), main account fixed rate to 100
+	BizId               string                  `json:"bizId"              description:"business id"`                                                                                                                                                 // bisness id
+	Name                string                  `json:"name"               description:"recharge transaction title"`                                                                                                                                  // recharge transaction title
+	Description         string                  `json:"description"        description:"recharge transaction description"`                                                                                                                            // recharge transaction description 	// update time
+	CreateTime          int64                   `json:"createTime"         description:"create utc time"`                                                                                                                                             // create utc time
+	MerchantId          uint64                  `json:"merchantId"         description:"merchant id"`                                                                                                                                                 // merchant id
+	InvoiceId           string                  `json:"invoiceId"         description:"invoice_id"`                                                                                                                                                   // invoice_id
+	AccountType         int                     `json:"accountType"       description:"type of credit account, 1-main recharge account, 2-promo credit account"`                                                                                      // type of credit account, 1-main recharge account, 2-promo credit account
+	AdminMember         *bean.MerchantMember    `json:"adminMember"       description:"admin member"`
+	By                  string                  `json:"by"  dc:"" `
+	GrantDetails        []*bean.CreditGrantItem `json:"grantDetails" description:"the credit grants increased or consumed by transaction"`
 }
 
 func ConvertToCreditTransactionDetail(ctx context.Context, one *entity.CreditTransaction) *CreditTransactionDetail {
@@ -112,5 +113,6 @@ func ConvertToCreditTransactionDetail(ctx context.Context, one *entity.CreditTra
 		AccountType:         one.AccountType,
 		AdminMember:         bean.SimplifyMerchantMember(query.GetMerchantMemberById(ctx, one.AdminMemberId)),
 		By:                  by,
+		GrantDetails:        bean.ConvertCreditGrantItems(one.GrantDetail),
 	}
 }
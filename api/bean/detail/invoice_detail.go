@@ -22,6 +22,7 @@ type InvoiceDetail struct {
 	InvoiceName                    string                                  `json:"invoiceName"                    description:"InvoiceName"`
 	ProductName                    string                                  `json:"productName"`
 	InvoiceId                      string                                  `json:"invoiceId"                      description:"InvoiceId"`
+	InvoiceNumber                  string                                  `json:"invoiceNumber"                  description:"legal invoice number, sequential per merchant, assigned when finalized"`
 	GatewayPaymentType             string                                  `json:"gatewayPaymentType"               description:"GatewayPaymentType"`
 	UniqueId                       string                                  `json:"uniqueId"                       description:"UniqueId"`
 	GmtCreate                      *gtime.Time                             `json:"gmtCreate"                      description:"GmtCreate"`
@@ -150,6 +151,7 @@ func ConvertInvoiceToDetail(ctx context.Context, invoice *entity.Invoice) *Invoi
 		MerchantId:                     invoice.MerchantId,
 		SubscriptionId:                 invoice.SubscriptionId,
 		InvoiceId:                      invoice.InvoiceId,
+		InvoiceNumber:                  invoice.InvoiceNumber,
 		InvoiceName:                    invoice.InvoiceName,
 		ProductName:                    invoice.ProductName,
 		GmtCreate:                      invoice.GmtCreate,
@@ -310,6 +312,7 @@ type CreditNoteDetail struct {
 	InvoiceName                    string                      `json:"invoiceName"                    description:"InvoiceName"`
 	ProductName                    string                      `json:"productName"`
 	InvoiceId                      string                      `json:"invoiceId"                      description:"InvoiceId"`
+	InvoiceNumber                  string                      `json:"invoiceNumber"                  description:"legal invoice number, sequential per merchant, assigned when finalized"`
 	OriginAmount                   int64                       `json:"originAmount"                   description:"OriginAmount,Cents"`
 	TotalAmount                    int64                       `json:"totalAmount"                    description:"TotalAmount,Cents"`
 	DiscountCode                   string                      `json:"discountCode"`
@@ -412,6 +415,7 @@ func ConvertInvoiceToCreditNoteDetail(ctx context.Context, invoice *entity.Invoi
 		MerchantId:                     invoice.MerchantId,
 		SubscriptionId:                 invoice.SubscriptionId,
 		InvoiceId:                      invoice.InvoiceId,
+		InvoiceNumber:                  invoice.InvoiceNumber,
 		InvoiceName:                    invoice.InvoiceName,
 		ProductName:                    invoice.ProductName,
 		OriginAmount:                   invoice.TotalAmount + invoice.DiscountAmount + invoice.PromoCreditDiscountAmount,
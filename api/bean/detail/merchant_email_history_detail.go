@@ -12,15 +12,16 @@ type MerchantEmailHistoryStatistics struct {
 }
 
 type MerchantEmailHistoryDetail struct {
-	Id         uint64 `json:"id"         description:"Id"`                            // Id
-	MerchantId uint64 `json:"merchantId" description:"merchantId"`                    // merchantId
-	Email      string `json:"email"      description:"Email address"`                 // Email address
-	Title      string `json:"title"      description:"Email title"`                   // Email title
-	Content    string `json:"content"    description:"Email content"`                 // Email content
-	AttachFile string `json:"attachFile" description:"Attachment file"`               // Attachment file
-	Response   string `json:"response"   description:"Email response"`                // Email response
-	CreateTime int64  `json:"createTime" description:"create utc time"`               // create utc time
-	Status     int    `json:"status"     description:"0-pending,1-success,2-failure"` // 0-pending,1-success,2-failure
+	Id          uint64 `json:"id"         description:"Id"`                                  // Id
+	MerchantId  uint64 `json:"merchantId" description:"merchantId"`                          // merchantId
+	Email       string `json:"email"      description:"Email address"`                       // Email address
+	Title       string `json:"title"      description:"Email title"`                         // Email title
+	Content     string `json:"content"    description:"Email content"`                       // Email content
+	AttachFile  string `json:"attachFile" description:"Attachment file"`                     // Attachment file
+	Response    string `json:"response"   description:"Email response"`                      // Email response
+	CreateTime  int64  `json:"createTime" description:"create utc time"`                     // create utc time
+	Status      int    `json:"status"     description:"0-pending,1-success,2-failure"`       // 0-pending,1-success,2-failure
+	GatewayName string `json:"gatewayName" description:"name of email gateway delivered by"` // name of email gateway delivered by
 }
 
 func ConvertMerchantEmailHistoryDetail(ctx context.Context, one *entity.MerchantEmailHistory) *MerchantEmailHistoryDetail {
@@ -29,14 +30,15 @@ func ConvertMerchantEmailHistoryDetail(ctx context.Context, one *entity.Merchant
 	}
 
 	return &MerchantEmailHistoryDetail{
-		Id:         one.Id,
-		MerchantId: one.MerchantId,
-		Email:      one.Email,
-		Title:      one.Title,
-		Content:    one.Content,
-		AttachFile: one.AttachFile,
-		Response:   one.Response,
-		CreateTime: one.CreateTime,
-		Status:     one.Status,
+		Id:          one.Id,
+		MerchantId:  one.MerchantId,
+		Email:       one.Email,
+		Title:       one.Title,
+		Content:     one.Content,
+		AttachFile:  one.AttachFile,
+		Response:    one.Response,
+		CreateTime:  one.CreateTime,
+		Status:      one.Status,
+		GatewayName: one.GatewayName,
 	}
 }
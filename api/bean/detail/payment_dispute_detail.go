@@ -0,0 +1,10 @@
+package detail
+
+import "unibee/api/bean"
+
+type PaymentDisputeDetail struct {
+	User    *bean.UserAccount    `json:"user" dc:"user"`
+	Payment *bean.Payment        `json:"payment" dc:"Payment"`
+	Invoice *bean.Invoice        `json:"invoice" dc:"Invoice"`
+	Dispute *bean.PaymentDispute `json:"dispute" dc:"Dispute"`
+}
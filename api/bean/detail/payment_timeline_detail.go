@@ -20,7 +20,7 @@ type PaymentTimelineDetail struct {
 	TransactionId         string        `json:"transactionId"      description:"TransactionId"`                        // TransactionId
 	PaymentId             string        `json:"paymentId"      description:"PaymentId"`                                // PaymentId
 	Status                int           `json:"status"         description:"0-pending, 1-success, 2-failure，3-cancel"` // 0-pending, 1-success, 2-failure, 3-cancel
-	TimelineType          int           `json:"timelineType"   description:"0-pay, 1-refund"`                          // 0-pay, 1-refund
+	TimelineType          int           `json:"timelineType"   description:"0-pay, 1-refund, 2-gateway attempt"`       // 0-pay, 1-refund, 2-gateway attempt
 	CreateTime            int64         `json:"createTime"     description:"create utc time"`                          // create utc time
 	RefundId              string        `json:"refundId"       description:"refund id"`                                // refund id
 	FullRefund            int           `json:"fullRefund"     description:"0-no, 1-yes"`                              // 0-no, 1-yes
@@ -28,6 +28,7 @@ type PaymentTimelineDetail struct {
 	Refund                *bean.Refund  `json:"refund" dc:"Refund"`
 	ExternalTransactionId string        `json:"externalTransactionId"      description:"ExternalTransactionId"` // ExternalTransactionId
 	AutoCharge            bool          `json:"autoCharge"                      description:""`
+	Reason                string        `json:"reason"         description:"reason of the timeline, e.g. the failure of gateway attempt"`
 }
 
 func ConvertPaymentTimeline(ctx context.Context, one *entity.PaymentTimeline) *PaymentTimelineDetail {
@@ -69,6 +70,7 @@ func ConvertPaymentTimeline(ctx context.Context, one *entity.PaymentTimeline) *P
 		CreateTime:            one.CreateTime,
 		RefundId:              one.RefundId,
 		FullRefund:            one.FullRefund,
+		Reason:                one.Reason,
 		Payment:               payment,
 		Refund:                refund,
 		AutoCharge:            payment.AutoCharge,
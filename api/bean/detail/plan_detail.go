@@ -21,10 +21,11 @@ type PlanDetail struct {
 type MerchantMetricPlanChargeDetail struct {
 	MetricId           uint64                                `json:"metricId" dc:"MetricId" v:"required"`
 	Metric             *bean.MerchantMetric                  `json:"merchantMetric"    description:"MerchantMetric"` // metricId
-	ChargeType         int                                   `json:"chargeType" dc:"ChargeType,0-standard pricing 1-graduated pricing"`
-	StandardAmount     int64                                 `json:"standardAmount" dc:"StandardAmount, used for standard pricing,cent"`
-	StandardStartValue int64                                 `json:"standardStartValue" dc:"StandardStartValue, used for standard pricing"`
-	GraduatedAmounts   []*bean.MetricPlanChargeGraduatedStep `json:"graduatedAmounts" dc:"GraduatedAmounts"`
+	ChargeType         int                                   `json:"chargeType" dc:"ChargeType,0-standard pricing 1-graduated pricing 2-volume pricing 3-package pricing"`
+	StandardAmount     int64                                 `json:"standardAmount" dc:"StandardAmount, used for standard pricing, and as the amount per package for package pricing,cent"`
+	StandardStartValue int64                                 `json:"standardStartValue" dc:"StandardStartValue, used for standard pricing, and as the free units for package pricing"`
+	GraduatedAmounts   []*bean.MetricPlanChargeGraduatedStep `json:"graduatedAmounts" dc:"GraduatedAmounts, used for graduated and volume pricing"`
+	PackageSize        int64                                 `json:"packageSize" dc:"PackageSize, units of each package, used for package pricing"`
 }
 
 func ConvertMetricPlanChargeDetailArrayFromParam(ctx context.Context, params []*bean.PlanMetricMeteredChargeParam) []*MerchantMetricPlanChargeDetail {
@@ -37,6 +38,7 @@ func ConvertMetricPlanChargeDetailArrayFromParam(ctx context.Context, params []*
 			StandardAmount:     param.StandardAmount,
 			StandardStartValue: param.StandardStartValue,
 			GraduatedAmounts:   param.GraduatedAmounts,
+			PackageSize:        param.PackageSize,
 		})
 	}
 	return list
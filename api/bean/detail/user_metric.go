@@ -17,6 +17,8 @@ type UserMerchantMetricChargeStat struct {
 	TotalChargeAmount int64                               `json:"totalChargeAmount" dc:"TotalChargeAmount"`
 	GraduatedStep     *bean.MetricPlanChargeGraduatedStep `json:"graduatedStep" dc:"GraduatedStep"`
 	Lines             []*bean.MetricPlanChargeLine        `json:"lines" dc:"Lines"`
+	ProrationAmount   int64                               `json:"prorationAmount" dc:"ProrationAmount, the prorated charge of recurring metric increased in current period"`
+	ProrationLines    []*bean.MetricPlanChargeLine        `json:"prorationLines" dc:"ProrationLines"`
 }
 
 type UserMetric struct {
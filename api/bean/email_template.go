@@ -31,6 +31,9 @@ type EmailTemplateVariable struct {
 	BIC                   string      `json:"BIC" key:"WireTransferBIC" group:"Company Information"`
 	IBAN                  string      `json:"IBAN" key:"WireTransferIBAN" group:"Company Information"`
 	BankData              string      `json:"Bank Data" key:"WireTransferBankData" group:"Company Information"`
+	PreviousPrice         string      `json:"Previous Price" key:"PreviousPrice" group:"Subscription Information"`
+	NewPrice              string      `json:"New Price" key:"NewPrice" group:"Subscription Information"`
+	EffectiveDate         *gtime.Time `json:"Effective Date" key:"EffectiveDate" layout:"2006-01-02" group:"Subscription Information"`
 }
 
 type MerchantEmailTemplate struct {
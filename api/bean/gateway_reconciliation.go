@@ -0,0 +1,103 @@
+package bean
+
+import (
+	entity "unibee/internal/model/entity/default"
+)
+
+type GatewayReconciliation struct {
+	Id               uint64 `json:"id"               description:"id"`
+	MerchantId       uint64 `json:"merchantId"       description:"merchant id"`
+	GatewayId        uint64 `json:"gatewayId"        description:"gateway id"`
+	GatewayName      string `json:"gatewayName"      description:"gateway name"`
+	WindowStart      int64  `json:"windowStart"      description:"start of window, utc time, payments created in window reconciled"`
+	WindowEnd        int64  `json:"windowEnd"        description:"end of window, utc time"`
+	Status           int    `json:"status"           description:"status, 1-running,2-finished,3-failed"`
+	Trigger          string `json:"trigger"          description:"trigger, schedule|manual"`
+	PaymentCount     int64  `json:"paymentCount"     description:"count of payments checked"`
+	RefundCount      int64  `json:"refundCount"      description:"count of refunds checked"`
+	DiscrepancyCount int64  `json:"discrepancyCount" description:"count of discrepancies found"`
+	FixedCount       int64  `json:"fixedCount"       description:"count of discrepancies fixed automatically"`
+	FailureReason    string `json:"failureReason"    description:"failure reason"`
+	StartTime        int64  `json:"startTime"        description:"start time, utc time"`
+	FinishTime       int64  `json:"finishTime"       description:"finish time, utc time"`
+}
+
+type GatewayDiscrepancy struct {
+	Id               uint64 `json:"id"               description:"id"`
+	MerchantId       uint64 `json:"merchantId"       description:"merchant id"`
+	ReconciliationId uint64 `json:"reconciliationId" description:"id of reconciliation found the discrepancy"`
+	GatewayId        uint64 `json:"gatewayId"        description:"gateway id"`
+	GatewayName      string `json:"gatewayName"      description:"gateway name"`
+	SourceType       string `json:"sourceType"       description:"source type, payment|refund"`
+	DiscrepancyType  string `json:"discrepancyType"  description:"discrepancy type, missing_local|missing_gateway|amount_drift|status_drift|lookup_failed"`
+	PaymentId        string `json:"paymentId"        description:"payment id"`
+	RefundId         string `json:"refundId"         description:"refund id"`
+	GatewayPaymentId string `json:"gatewayPaymentId" description:"gateway payment id"`
+	GatewayRefundId  string `json:"gatewayRefundId"  description:"gateway refund id"`
+	UserId           uint64 `json:"userId"           description:"user id"`
+	InvoiceId        string `json:"invoiceId"        description:"invoice id"`
+	Currency         string `json:"currency"         description:"currency"`
+	LocalAmount      int64  `json:"localAmount"      description:"local amount, cent"`
+	GatewayAmount    int64  `json:"gatewayAmount"    description:"gateway amount, cent"`
+	LocalStatus      int    `json:"localStatus"      description:"local status, 10-pending，20-success，30-failure, 40-cancel, 50-reverse(refund only)"`
+	GatewayStatus    int    `json:"gatewayStatus"    description:"gateway status, 10-pending，20-success，30-failure, 40-cancel, 50-reverse(refund only)"`
+	AutoFixed        bool   `json:"autoFixed"        description:"true if local status fixed from gateway automatically"`
+	ResolveStatus    int    `json:"resolveStatus"    description:"resolve status, 0-open,1-resolved,2-ignored"`
+	ResolveNote      string `json:"resolveNote"      description:"resolve note"`
+	Detail           string `json:"detail"           description:"detail"`
+	CreateTime       int64  `json:"createTime"       description:"create utc time"`
+}
+
+func SimplifyGatewayReconciliation(one *entity.MerchantGatewayReconciliation) *GatewayReconciliation {
+	if one == nil {
+		return nil
+	}
+	return &GatewayReconciliation{
+		Id:               one.Id,
+		MerchantId:       one.MerchantId,
+		GatewayId:        one.GatewayId,
+		GatewayName:      one.GatewayName,
+		WindowStart:      one.WindowStart,
+		WindowEnd:        one.WindowEnd,
+		Status:           one.Status,
+		Trigger:          one.Trigger,
+		PaymentCount:     one.PaymentCount,
+		RefundCount:      one.RefundCount,
+		DiscrepancyCount: one.DiscrepancyCount,
+		FixedCount:       one.FixedCount,
+		FailureReason:    one.FailureReason,
+		StartTime:        one.StartTime,
+		FinishTime:       one.FinishTime,
+	}
+}
+
+func SimplifyGatewayDiscrepancy(one *entity.MerchantGatewayDiscrepancy) *GatewayDiscrepancy {
+	if one == nil {
+		return nil
+	}
+	return &GatewayDiscrepancy{
+		Id:               one.Id,
+		MerchantId:       one.MerchantId,
+		ReconciliationId: one.ReconciliationId,
+		GatewayId:        one.GatewayId,
+		GatewayName:      one.GatewayName,
+		SourceType:       one.SourceType,
+		DiscrepancyType:  one.DiscrepancyType,
+		PaymentId:        one.PaymentId,
+		RefundId:         one.RefundId,
+		GatewayPaymentId: one.GatewayPaymentId,
+		GatewayRefundId:  one.GatewayRefundId,
+		UserId:           one.UserId,
+		InvoiceId:        one.InvoiceId,
+		Currency:         one.Currency,
+		LocalAmount:      one.LocalAmount,
+		GatewayAmount:    one.GatewayAmount,
+		LocalStatus:      one.LocalStatus,
+		GatewayStatus:    one.GatewayStatus,
+		AutoFixed:        one.AutoFixed == 1,
+		ResolveStatus:    one.ResolveStatus,
+		ResolveNote:      one.ResolveNote,
+		Detail:           one.Detail,
+		CreateTime:       one.CreateTime,
+	}
+}
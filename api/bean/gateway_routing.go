@@ -0,0 +1,18 @@
+package bean
+
+type GatewayRoutingRule struct {
+	Name         string   `json:"name"         description:"name of the rule"`
+	Currencies   []string `json:"currencies"   description:"currencies the rule matches, empty matches all"`
+	CountryCodes []string `json:"countryCodes" description:"country codes of the payment the rule matches, empty matches all"`
+	MinAmount    int64    `json:"minAmount"    description:"min amount of the payment the rule matches, cent, 0 for no limit"`
+	MaxAmount    int64    `json:"maxAmount"    description:"max amount of the payment the rule matches, cent, 0 for no limit"`
+	PaymentTypes []int64  `json:"paymentTypes" description:"gateway types of the payment the rule matches, 1-Card｜4-Paypal..., empty matches all"`
+	GatewayIds   []uint64 `json:"gatewayIds"   description:"gateways used in order when the rule matched"`
+}
+
+type GatewayRouting struct {
+	Enable      bool                  `json:"enable"      description:"enable the routing rules and failover"`
+	Failover    bool                  `json:"failover"    description:"retry the payment on the next eligible gateway when the gateway failed"`
+	MaxAttempts int                   `json:"maxAttempts" description:"max gateways attempted for each payment, include the first one"`
+	Rules       []*GatewayRoutingRule `json:"rules"       description:"rules in priority, the first matched rule chooses the gateways"`
+}
@@ -13,6 +13,7 @@ type Invoice struct {
 	Id                             uint64                             `json:"id"                             description:""`
 	UserId                         uint64                             `json:"userId"                         description:"UserId"`
 	InvoiceId                      string                             `json:"invoiceId"`
+	InvoiceNumber                  string                             `json:"invoiceNumber"                  description:"legal invoice number, sequential per merchant, assigned when finalized"`
 	InvoiceName                    string                             `json:"invoiceName"`
 	ProductName                    string                             `json:"productName"`
 	DiscountCode                   string                             `json:"discountCode"`
@@ -23,6 +24,7 @@ type Invoice struct {
 	Currency                       string                             `json:"currency"`
 	TaxAmount                      int64                              `json:"taxAmount"`
 	TaxPercentage                  int64                              `json:"taxPercentage"                  description:"TaxPercentage，1000 = 10%"`
+	TaxLines                       []*InvoiceTaxLine                  `json:"taxLines"                       description:"tax lines by jurisdiction, from sales tax engine"`
 	SubscriptionAmount             int64                              `json:"subscriptionAmount"`
 	SubscriptionAmountExcludingTax int64                              `json:"subscriptionAmountExcludingTax"`
 	Lines                          []*InvoiceItemSimplify             `json:"lines"`
@@ -63,6 +65,7 @@ type Invoice struct {
 	PaymentType                    string                             `json:"paymentType"               description:""`
 	PaymentMethodId                string                             `json:"PaymentMethodId"               description:""`
 	PlanSnapshot                   *InvoicePlanSnapshot               `json:"planSnapshot" description:"Snapshot of the plan and addons at the time of billing. Includes both the current and previous plans when applicable (e.g., upgrade or downgrade)."`
+	DunningAttempt                 int                                `json:"dunningAttempt"               description:"dunning payment retry attempt count"`
 }
 
 type InvoiceItemSimplify struct {
@@ -115,6 +118,10 @@ func SimplifyInvoice(one *entity.Invoice) *Invoice {
 	if err != nil {
 		return nil
 	}
+	var taxLines = make([]*InvoiceTaxLine, 0)
+	if len(one.TaxLines) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.TaxLines, &taxLines)
+	}
 	var metadata = make(map[string]interface{})
 	if len(one.MetaData) > 0 {
 		err = gjson.Unmarshal([]byte(one.MetaData), &metadata)
@@ -136,6 +143,7 @@ func SimplifyInvoice(one *entity.Invoice) *Invoice {
 		InvoiceName:                    one.InvoiceName,
 		ProductName:                    one.ProductName,
 		InvoiceId:                      one.InvoiceId,
+		InvoiceNumber:                  one.InvoiceNumber,
 		OriginAmount:                   one.TotalAmount + one.DiscountAmount + one.PromoCreditDiscountAmount,
 		TotalAmount:                    one.TotalAmount,
 		DiscountCode:                   one.DiscountCode,
@@ -162,6 +170,7 @@ func SimplifyInvoice(one *entity.Invoice) *Invoice {
 		SendStatus:                     one.SendStatus,
 		DayUtilDue:                     one.DayUtilDue,
 		TaxPercentage:                  one.TaxPercentage,
+		TaxLines:                       taxLines,
 		TrialEnd:                       one.TrialEnd,
 		BillingCycleAnchor:             one.BillingCycleAnchor,
 		CreateFrom:                     one.CreateFrom,
@@ -174,5 +183,15 @@ func SimplifyInvoice(one *entity.Invoice) *Invoice {
 		PartialCreditPaidAmount:        one.PartialCreditPaidAmount,
 		UserMetricChargeForInvoice:     userMetricChargeForInvoice,
 		PaymentType:                    one.GatewayInvoiceId,
+		DunningAttempt:                 one.DunningAttempt,
 	}
 }
+
+type InvoiceNumberConfig struct {
+	Enable                bool   `json:"enable"                description:"true-legal sequential number assigned to the invoices created after enabled and finalized"`
+	InvoicePattern        string `json:"invoicePattern"        description:"number pattern of invoice, {YYYY} or {YY} for year and the counter reset yearly, {NNNNNN} for the counter zero-padded to the count of N, default INV-{YYYY}-{NNNNNN}"`
+	CreditNotePattern     string `json:"creditNotePattern"     description:"number pattern of credit note, a separate series, default CN-{YYYY}-{NNNNNN}"`
+	StartNumber           int64  `json:"startNumber"           description:"the next counter of invoice series in current period, to continue the numbers issued by previous system, should greater than the last number assigned, 0 keeps the counter"`
+	CreditNoteStartNumber int64  `json:"creditNoteStartNumber" description:"the next counter of credit note series in current period, 0 keeps the counter"`
+	EnableTime            int64  `json:"enableTime"            description:"the utc time enabled, invoices created before keep their invoice id as number"`
+}
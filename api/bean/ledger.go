@@ -0,0 +1,60 @@
+package bean
+
+import (
+	entity "unibee/internal/model/entity/default"
+)
+
+type LedgerAccountCodes struct {
+	AccountsReceivable string            `json:"accountsReceivable" description:"account code of accounts receivable, default 1100"`
+	Revenue            string            `json:"revenue"            description:"account code of revenue, default 4000"`
+	DeferredRevenue    string            `json:"deferredRevenue"    description:"account code of deferred revenue, default 2400"`
+	TaxPayable         string            `json:"taxPayable"         description:"account code of tax payable, default 2200"`
+	PromoCredit        string            `json:"promoCredit"        description:"account code of promo credit expense, default 6100"`
+	Cash               string            `json:"cash"               description:"account code of cash, default 1000"`
+	GatewayCash        map[string]string `json:"gatewayCash"        description:"account code of cash by gateway name, cash used if gateway not mapped"`
+}
+
+type LedgerEntry struct {
+	Id             uint64 `json:"id"             description:"id"`
+	MerchantId     uint64 `json:"merchantId"     description:"merchant id"`
+	JournalId      string `json:"journalId"      description:"journal id, lines of one journal balanced"`
+	EntryType      string `json:"entryType"      description:"entry type, invoice_finalized|promo_credit_used|payment_success|credit_note_finalized|refund_success|invoice_voided|revenue_recognition"`
+	SourceId       string `json:"sourceId"       description:"source id, invoice id, payment id or refund id"`
+	LineNo         int    `json:"lineNo"         description:"line no of journal"`
+	Account        string `json:"account"        description:"account, ar|revenue|deferred_revenue|tax_payable|cash|promo_credit"`
+	Debit          int64  `json:"debit"          description:"debit amount, cent"`
+	Credit         int64  `json:"credit"         description:"credit amount, cent"`
+	Currency       string `json:"currency"       description:"currency"`
+	GatewayId      uint64 `json:"gatewayId"      description:"gateway id of cash account"`
+	GatewayName    string `json:"gatewayName"    description:"gateway name of cash account"`
+	UserId         uint64 `json:"userId"         description:"user id"`
+	SubscriptionId string `json:"subscriptionId" description:"subscription id"`
+	InvoiceId      string `json:"invoiceId"      description:"invoice id"`
+	Description    string `json:"description"    description:"description"`
+	EntryTime      int64  `json:"entryTime"      description:"utc time of entry"`
+}
+
+func SimplifyLedgerEntry(one *entity.MerchantLedgerEntry) *LedgerEntry {
+	if one == nil {
+		return nil
+	}
+	return &LedgerEntry{
+		Id:             one.Id,
+		MerchantId:     one.MerchantId,
+		JournalId:      one.JournalId,
+		EntryType:      one.EntryType,
+		SourceId:       one.SourceId,
+		LineNo:         one.LineNo,
+		Account:        one.Account,
+		Debit:          one.Debit,
+		Credit:         one.Credit,
+		Currency:       one.Currency,
+		GatewayId:      one.GatewayId,
+		GatewayName:    one.GatewayName,
+		UserId:         one.UserId,
+		SubscriptionId: one.SubscriptionId,
+		InvoiceId:      one.InvoiceId,
+		Description:    one.Description,
+		EntryTime:      one.EntryTime,
+	}
+}
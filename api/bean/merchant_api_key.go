@@ -0,0 +1,59 @@
+package bean
+
+import (
+	"strings"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+type MerchantApiKey struct {
+	Id                 uint64   `json:"id"                 description:"id"`
+	MerchantId         uint64   `json:"merchantId"         description:"merchant id"`
+	Name               string   `json:"name"               description:"name"`
+	MaskedApiKey       string   `json:"maskedApiKey"       description:"masked api key, the full key only returned when created or rotated"`
+	Scopes             []string `json:"scopes"             description:"permission scopes, read|write for all groups, {group}:read|{group}:write for the permission group"`
+	ValidIps           []string `json:"validIps"           description:"ip allowlist, ip or cidr, all allowed if empty"`
+	ExpireTime         int64    `json:"expireTime"         description:"expire utc time, 0 if never expire"`
+	Status             int      `json:"status"             description:"status, 1-active,2-revoked"`
+	PreviousExpireTime int64    `json:"previousExpireTime" description:"expire utc time of the api key before rotation, 0 if not rotated"`
+	LastUsedTime       int64    `json:"lastUsedTime"       description:"last used utc time"`
+	LastUsedIp         string   `json:"lastUsedIp"         description:"last used ip"`
+	CreateTime         int64    `json:"createTime"         description:"create utc time"`
+}
+
+func MaskApiKey(apiKey string) string {
+	if len(apiKey) <= 12 {
+		return strings.Repeat("*", len(apiKey))
+	}
+	return apiKey[:8] + "****" + apiKey[len(apiKey)-4:]
+}
+
+func SimplifyMerchantApiKey(one *entity.MerchantApiKey) *MerchantApiKey {
+	if one == nil {
+		return nil
+	}
+	var scopes = make([]string, 0)
+	if len(one.Scopes) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.Scopes, &scopes)
+	}
+	var validIps = make([]string, 0)
+	for _, ip := range strings.Split(one.ValidIps, ",") {
+		if len(ip) > 0 {
+			validIps = append(validIps, ip)
+		}
+	}
+	return &MerchantApiKey{
+		Id:                 one.Id,
+		MerchantId:         one.MerchantId,
+		Name:               one.Name,
+		MaskedApiKey:       MaskApiKey(one.ApiKey),
+		Scopes:             scopes,
+		ValidIps:           validIps,
+		ExpireTime:         one.ExpireTime,
+		Status:             one.Status,
+		PreviousExpireTime: one.PreviousExpireTime,
+		LastUsedTime:       one.LastUsedTime,
+		LastUsedIp:         one.LastUsedIp,
+		CreateTime:         one.CreateTime,
+	}
+}
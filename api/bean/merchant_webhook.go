@@ -3,26 +3,41 @@ package bean
 import entity "unibee/internal/model/entity/default"
 
 type MerchantWebhookEndpoint struct {
-	Id            uint64   `json:"id"            description:"id"`                       // id
-	MerchantId    uint64   `json:"merchantId"    description:"webhook url"`              // webhook url
-	WebhookUrl    string   `json:"webhookUrl"    description:"webhook url"`              // webhook url
-	WebhookEvents []string `json:"webhookEvents" description:"webhook_events,split dot"` // webhook_events,split dot
-	UpdateTime    int64    `json:"gmtModify"     description:"update time"`              // update time
-	CreateTime    int64    `json:"createTime"    description:"create utc time"`          // create utc time
+	Id                  uint64   `json:"id"                  description:"id"`                                                // id
+	MerchantId          uint64   `json:"merchantId"          description:"webhook url"`                                       // webhook url
+	WebhookUrl          string   `json:"webhookUrl"          description:"webhook url"`                                       // webhook url
+	WebhookEvents       []string `json:"webhookEvents"       description:"webhook_events,split dot"`                          // webhook_events,split dot
+	UpdateTime          int64    `json:"gmtModify"           description:"update time"`                                       // update time
+	CreateTime          int64    `json:"createTime"          description:"create utc time"`                                   // create utc time
+	ConsecutiveFailures int      `json:"consecutiveFailures" description:"consecutive failed delivery attempts"`              // consecutive failed delivery attempts
+	LastSuccessTime     int64    `json:"lastSuccessTime"     description:"utc time of last success delivery"`                 // utc time of last success delivery
+	LastFailureTime     int64    `json:"lastFailureTime"     description:"utc time of last failed delivery"`                  // utc time of last failed delivery
+	DisableTime         int64    `json:"disableTime"         description:"utc time endpoint disabled by failures, 0-enabled"` // utc time endpoint disabled by failures, 0-enabled
+}
+
+type MerchantWebhookEndpointSecret struct {
+	Secret                   string `json:"secret"                   description:"secret to sign webhook of endpoint"`
+	PreviousSecret           string `json:"previousSecret"           description:"rotated secret, still valid before previousSecretExpireTime"`
+	PreviousSecretExpireTime int64  `json:"previousSecretExpireTime" description:"utc time the rotated secret expires"`
 }
 
 type MerchantWebhookLog struct {
-	Id             uint64 `json:"id"             description:"id"`              // id
-	MerchantId     uint64 `json:"merchantId"     description:"webhook url"`     // webhook url
-	EndpointId     int64  `json:"endpointId"     description:""`                //
-	ReconsumeCount int    `json:"reconsumeCount" description:""`                //
-	WebhookUrl     string `json:"webhookUrl"     description:"webhook url"`     // webhook url
-	WebhookEvent   string `json:"webhookEvent"   description:"webhook_event"`   // webhook_event
-	RequestId      string `json:"requestId"      description:"request_id"`      // request_id
-	Body           string `json:"body"           description:"body(json)"`      // body(json)
-	Response       string `json:"response"       description:"response"`        // response
-	Mamo           string `json:"mamo"           description:"mamo"`            // mamo
-	CreateTime     int64  `json:"createTime"     description:"create utc time"` // create utc time
+	Id              uint64 `json:"id"              description:"id"`                                     // id
+	MerchantId      uint64 `json:"merchantId"      description:"webhook url"`                            // webhook url
+	EndpointId      int64  `json:"endpointId"      description:""`                                       //
+	ReconsumeCount  int    `json:"reconsumeCount"  description:""`                                       //
+	WebhookUrl      string `json:"webhookUrl"      description:"webhook url"`                            // webhook url
+	WebhookEvent    string `json:"webhookEvent"    description:"webhook_event"`                          // webhook_event
+	RequestId       string `json:"requestId"       description:"request_id"`                             // request_id
+	Body            string `json:"body"            description:"body(json)"`                             // body(json)
+	Response        string `json:"response"        description:"response"`                               // response
+	Mamo            string `json:"mamo"            description:"mamo"`                                   // mamo
+	CreateTime      int64  `json:"createTime"      description:"create utc time"`                        // create utc time
+	Status          int    `json:"status"          description:"0-legacy,1-success,2-retrying,3-failed"` // 0-legacy,1-success,2-retrying,3-failed
+	AttemptCount    int    `json:"attemptCount"    description:"delivery attempt count"`                 // delivery attempt count
+	NextRetryTime   int64  `json:"nextRetryTime"   description:"utc time of next redelivery"`            // utc time of next redelivery
+	ResponseStatus  int    `json:"responseStatus"  description:"http status of last attempt"`            // http status of last attempt
+	LastAttemptTime int64  `json:"lastAttemptTime" description:"utc time of last attempt"`               // utc time of last attempt
 }
 
 func SimplifyMerchantWebhookLog(one *entity.MerchantWebhookLog) *MerchantWebhookLog {
@@ -30,16 +45,56 @@ func SimplifyMerchantWebhookLog(one *entity.MerchantWebhookLog) *MerchantWebhook
 		return nil
 	}
 	return &MerchantWebhookLog{
+		Id:              one.Id,
+		MerchantId:      one.MerchantId,
+		EndpointId:      one.EndpointId,
+		ReconsumeCount:  one.ReconsumeCount,
+		WebhookUrl:      one.WebhookUrl,
+		WebhookEvent:    one.WebhookEvent,
+		RequestId:       one.RequestId,
+		Body:            one.Body,
+		Response:        one.Response,
+		Mamo:            one.Mamo,
+		CreateTime:      one.CreateTime,
+		Status:          one.Status,
+		AttemptCount:    one.AttemptCount,
+		NextRetryTime:   one.NextRetryTime,
+		ResponseStatus:  one.ResponseStatus,
+		LastAttemptTime: one.LastAttemptTime,
+	}
+}
+
+func SimplifyMerchantWebhookAttempt(one *entity.MerchantWebhookAttempt) *MerchantWebhookAttempt {
+	if one == nil {
+		return nil
+	}
+	return &MerchantWebhookAttempt{
 		Id:             one.Id,
-		MerchantId:     one.MerchantId,
-		EndpointId:     one.EndpointId,
-		ReconsumeCount: one.ReconsumeCount,
-		WebhookUrl:     one.WebhookUrl,
-		WebhookEvent:   one.WebhookEvent,
-		RequestId:      one.RequestId,
-		Body:           one.Body,
+		LogId:          one.LogId,
+		Attempt:        one.Attempt,
+		ResponseStatus: one.ResponseStatus,
 		Response:       one.Response,
-		Mamo:           one.Mamo,
+		Success:        one.Success == 1,
+		Duration:       one.Duration,
 		CreateTime:     one.CreateTime,
 	}
 }
+
+type WebhookRetryPolicy struct {
+	MaxAttempts            int   `json:"maxAttempts"            description:"max delivery attempts of each webhook, include the first one"`
+	InitialIntervalSeconds int64 `json:"initialIntervalSeconds" description:"seconds before the first redelivery"`
+	BackoffMultiplier      int64 `json:"backoffMultiplier"      description:"multiplier of interval for each following redelivery"`
+	MaxIntervalSeconds     int64 `json:"maxIntervalSeconds"     description:"max seconds between redeliveries"`
+	DisableAfterFailures   int   `json:"disableAfterFailures"   description:"endpoint is disabled after consecutive failed attempts, 0 to never disable"`
+}
+
+type MerchantWebhookAttempt struct {
+	Id             uint64 `json:"id"             description:"id"`                               // id
+	LogId          uint64 `json:"logId"          description:"webhook log id"`                   // webhook log id
+	Attempt        int    `json:"attempt"        description:"attempt number, start with 1"`     // attempt number, start with 1
+	ResponseStatus int    `json:"responseStatus" description:"http status, 0 if request failed"` // http status, 0 if request failed
+	Response       string `json:"response"       description:"response"`                         // response
+	Success        bool   `json:"success"        description:"success"`                          // success
+	Duration       int64  `json:"duration"       description:"request duration, millisecond"`    // request duration, millisecond
+	CreateTime     int64  `json:"createTime"     description:"create utc time"`                  // create utc time
+}
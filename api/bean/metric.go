@@ -10,12 +10,20 @@ type PlanMetricLimitParam struct {
 	MetricLimit uint64 `json:"metricLimit" dc:"MetricLimit" v:"required"`
 }
 
+const (
+	MetricChargeTypeStandard  = 0
+	MetricChargeTypeGraduated = 1
+	MetricChargeTypeVolume    = 2
+	MetricChargeTypePackage   = 3
+)
+
 type PlanMetricMeteredChargeParam struct {
 	MetricId           uint64                           `json:"metricId" dc:"MetricId"`
-	ChargeType         int                              `json:"chargeType" dc:"ChargeType,0-standard pricing 1-graduated pricing"`
-	StandardAmount     int64                            `json:"standardAmount" dc:"StandardAmount, cent, used for standard pricing,cent"`
-	StandardStartValue int64                            `json:"standardStartValue" dc:"StandardStartValue, used for standard pricing"`
-	GraduatedAmounts   []*MetricPlanChargeGraduatedStep `json:"graduatedAmounts" dc:"GraduatedAmounts, used for graduated pricing"`
+	ChargeType         int                              `json:"chargeType" dc:"ChargeType,0-standard pricing 1-graduated pricing 2-volume pricing 3-package pricing"`
+	StandardAmount     int64                            `json:"standardAmount" dc:"StandardAmount, cent, used for standard pricing, and as the amount per package for package pricing,cent"`
+	StandardStartValue int64                            `json:"standardStartValue" dc:"StandardStartValue, used for standard pricing, and as the free units for package pricing"`
+	GraduatedAmounts   []*MetricPlanChargeGraduatedStep `json:"graduatedAmounts" dc:"GraduatedAmounts, used for graduated and volume pricing"`
+	PackageSize        int64                            `json:"packageSize" dc:"PackageSize, units of each package, used for package pricing"`
 }
 
 type MetricPlanChargeGraduatedStep struct {
@@ -52,7 +60,7 @@ func ConvertMetricPlanBindingListFromPlan(one *entity.Plan) []*PlanMetricMetered
 	if len(one.MetricCharge) > 0 {
 		_ = utility.UnmarshalFromJsonString(one.MetricCharge, &metricPlanCharge)
 	}
-	var list = metricPlanCharge.MetricMeteredCharge
+	var list = make([]*PlanMetricMeteredChargeParam, 0)
 	for _, met := range metricPlanCharge.MetricMeteredCharge {
 		list = append(list, met)
 	}
@@ -87,4 +95,6 @@ type UserMetricChargeInvoiceItem struct {
 	Name              string                        `json:"name" dc:"Name"`
 	Description       string                        `json:"description" dc:"Description"`
 	Lines             []*MetricPlanChargeLine       `json:"lines" dc:"Lines"`
+	ProrationAmount   int64                         `json:"prorationAmount" dc:"ProrationAmount, the prorated charge of recurring metric increased in current period"`
+	ProrationLines    []*MetricPlanChargeLine       `json:"prorationLines" dc:"ProrationLines"`
 }
@@ -0,0 +1,87 @@
+package bean
+
+import (
+	"fmt"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+type PaymentDispute struct {
+	MerchantId       uint64                 `json:"merchantId"       description:"merchant id"`                                            // merchant id
+	UserId           uint64                 `json:"userId"           description:"user id"`                                                // user id
+	DisputeId        string                 `json:"disputeId"        description:"dispute id"`                                             // dispute id
+	GatewayId        uint64                 `json:"gatewayId"        description:"gateway id"`                                             // gateway id
+	GatewayDisputeId string                 `json:"gatewayDisputeId" description:"gateway dispute id"`                                     // gateway dispute id
+	PaymentId        string                 `json:"paymentId"        description:"payment id"`                                             // payment id
+	InvoiceId        string                 `json:"invoiceId"        description:"invoice id"`                                             // invoice id
+	SubscriptionId   string                 `json:"subscriptionId"   description:"subscription id"`                                        // subscription id
+	Currency         string                 `json:"currency"         description:"currency"`                                               // currency
+	DisputeAmount    int64                  `json:"disputeAmount"    description:"dispute amount, cent"`                                   // dispute amount, cent
+	Status           int                    `json:"status"           description:"status, 10-opened, 20-evidence needed, 30-won, 40-lost"` // status, 10-opened, 20-evidence needed, 30-won, 40-lost
+	GatewayStatus    string                 `json:"gatewayStatus"    description:"status of dispute in gateway"`                           // status of dispute in gateway
+	Reason           string                 `json:"reason"           description:"reason of dispute"`                                      // reason of dispute
+	EvidenceDueTime  int64                  `json:"evidenceDueTime"  description:"utc time the evidence should be submitted before"`       // utc time the evidence should be submitted before
+	Evidences        []*DisputeEvidence     `json:"evidences"        description:"evidence files"`                                         // evidence files
+	CloseTime        int64                  `json:"closeTime"        description:"utc time the dispute won or lost"`                       // utc time the dispute won or lost
+	CreateTime       int64                  `json:"createTime"       description:"create utc time"`                                        // create utc time
+	Metadata         map[string]interface{} `json:"metadata"         description:""`
+}
+
+type DisputeEvidence struct {
+	FileName    string `json:"fileName"    description:"file name of evidence in oss"`
+	Url         string `json:"url"         description:"url of evidence file"`
+	Description string `json:"description" description:"description of evidence"`
+	UploadTime  int64  `json:"uploadTime"  description:"upload utc time"`
+}
+
+type DisputeConfig struct {
+	SuspendSubscriptionOnLost bool `json:"suspendSubscriptionOnLost" description:"suspend the subscription of payment automatically when dispute lost"`
+}
+
+func SimplifyPaymentDispute(one *entity.PaymentDispute) *PaymentDispute {
+	if one == nil {
+		return nil
+	}
+	var metadata = make(map[string]interface{})
+	if len(one.MetaData) > 0 {
+		err := gjson.Unmarshal([]byte(one.MetaData), &metadata)
+		if err != nil {
+			fmt.Printf("SimplifyPaymentDispute Unmarshal Metadata error:%s", err.Error())
+		}
+	}
+	var evidences = make([]*DisputeEvidence, 0)
+	if len(one.Evidence) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.Evidence, &evidences)
+	}
+	return &PaymentDispute{
+		MerchantId:       one.MerchantId,
+		UserId:           one.UserId,
+		DisputeId:        one.DisputeId,
+		GatewayId:        one.GatewayId,
+		GatewayDisputeId: one.GatewayDisputeId,
+		PaymentId:        one.PaymentId,
+		InvoiceId:        one.InvoiceId,
+		SubscriptionId:   one.SubscriptionId,
+		Currency:         one.Currency,
+		DisputeAmount:    one.DisputeAmount,
+		Status:           one.Status,
+		GatewayStatus:    one.GatewayStatus,
+		Reason:           one.Reason,
+		EvidenceDueTime:  one.EvidenceDueTime,
+		Evidences:        evidences,
+		CloseTime:        one.CloseTime,
+		CreateTime:       one.CreateTime,
+		Metadata:         metadata,
+	}
+}
+
+func SimplifyPaymentDisputeList(ones []*entity.PaymentDispute) (list []*PaymentDispute) {
+	if len(ones) == 0 {
+		return make([]*PaymentDispute, 0)
+	}
+	for _, one := range ones {
+		list = append(list, SimplifyPaymentDispute(one))
+	}
+	return list
+}
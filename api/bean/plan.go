@@ -40,6 +40,7 @@ type Plan struct {
 	ExternalPlanId         string                          `json:"externalPlanId"            description:"external_user_id"`                                                                                                // external_user_id
 	ProductId              int64                           `json:"productId"                 description:"product id"`                                                                                                      // product id
 	DisableAutoCharge      int                             `json:"disableAutoCharge"         description:"disable auto-charge, 0-false,1-true"`                                                                             // disable auto-charge, 0-false,1-true
+	PriceVersion           int                             `json:"priceVersion"              description:"current price version of plan"`                                                                                   // current price version of plan
 	MetricLimits           []*PlanMetricLimitParam         `json:"metricLimits"  dc:"Plan's MetricLimit List" `
 	MetricMeteredCharge    []*PlanMetricMeteredChargeParam `json:"metricMeteredCharge"  dc:"Plan's MetricMeteredCharge" `
 	MetricRecurringCharge  []*PlanMetricMeteredChargeParam `json:"metricRecurringCharge"  dc:"Plan's MetricRecurringCharge" `
@@ -151,6 +152,7 @@ func SimplifyPlan(one *entity.Plan) *Plan {
 		ExternalPlanId:         one.ExternalPlanId,
 		ProductId:              one.ProductId,
 		DisableAutoCharge:      one.DisableAutoCharge,
+		PriceVersion:           utility.MaxInt(one.PriceVersion, 1),
 		MetricLimits:           metricPlanCharge.MetricLimits,
 		MetricMeteredCharge:    metricPlanCharge.MetricMeteredCharge,
 		MetricRecurringCharge:  metricPlanCharge.MetricRecurringCharge,
@@ -0,0 +1,91 @@
+package bean
+
+import (
+	entity "unibee/internal/model/entity/default"
+)
+
+type PlanPriceVersion struct {
+	PlanId      uint64 `json:"planId"      description:"plan id"`                              // plan id
+	Version     int    `json:"version"     description:"price version of plan"`                // price version of plan
+	Amount      int64  `json:"amount"      description:"amount of plan in this version, cent"` // amount of plan in this version, cent
+	Currency    string `json:"currency"    description:"currency"`                             // currency
+	Description string `json:"description" description:"description"`                          // description
+	Current     bool   `json:"current"     description:"whether the current price of plan"`
+	CreateTime  int64  `json:"createTime"  description:"create utc time"` // create utc time
+}
+
+type PlanPriceMigration struct {
+	MerchantId    uint64 `json:"merchantId"    description:"merchant id"`                                                    // merchant id
+	PlanId        uint64 `json:"planId"        description:"plan id"`                                                        // plan id
+	MigrationId   string `json:"migrationId"   description:"migration id"`                                                   // migration id
+	FromVersion   int    `json:"fromVersion"   description:"price version migrate from"`                                     // price version migrate from
+	ToVersion     int    `json:"toVersion"     description:"price version migrate to"`                                       // price version migrate to
+	Mode          int    `json:"mode"          description:"migration mode, 1-next renewal, 2-immediate with proration"`     // migration mode, 1-next renewal, 2-immediate with proration
+	Status        int    `json:"status"        description:"status, 10-scheduled, 20-processing, 30-finished, 40-cancelled"` // status, 10-scheduled, 20-processing, 30-finished, 40-cancelled
+	EffectTime    int64  `json:"effectTime"    description:"utc time the migration executed"`                                // utc time the migration executed
+	AffectedCount int    `json:"affectedCount" description:"count of subscriptions affected when scheduled"`                 // count of subscriptions affected when scheduled
+	MigratedCount int    `json:"migratedCount" description:"count of subscriptions migrated"`                                // count of subscriptions migrated
+	FailedCount   int    `json:"failedCount"   description:"count of subscriptions failed to migrate"`                       // count of subscriptions failed to migrate
+	Message       string `json:"message"       description:"message"`                                                        // message
+	CreateTime    int64  `json:"createTime"    description:"create utc time"`                                                // create utc time
+}
+
+type PlanPriceMigrationReportItem struct {
+	SubscriptionId   string `json:"subscriptionId"   description:"subscription id"`
+	UserId           uint64 `json:"userId"           description:"user id"`
+	Email            string `json:"email"            description:"email of user"`
+	Status           int    `json:"status"           description:"status of subscription"`
+	Currency         string `json:"currency"         description:"currency of subscription"`
+	Quantity         int64  `json:"quantity"         description:"quantity of plan"`
+	CurrentAmount    int64  `json:"currentAmount"    description:"plan amount of subscription in from version, cent, without tax"`
+	NewAmount        int64  `json:"newAmount"        description:"plan amount of subscription in to version, cent, without tax"`
+	ProrationAmount  int64  `json:"prorationAmount"  description:"proration amount charged at effect time for immediate mode, cent, with tax"`
+	CurrentPeriodEnd int64  `json:"currentPeriodEnd" description:"current period end of subscription, the new price applied to renewal after"`
+	Message          string `json:"message"          description:"the reason if the subscription can not be migrated"`
+}
+
+func SimplifyPlanPriceVersion(one *entity.PlanPriceVersion, currentVersion int) *PlanPriceVersion {
+	if one == nil {
+		return nil
+	}
+	return &PlanPriceVersion{
+		PlanId:      one.PlanId,
+		Version:     one.Version,
+		Amount:      one.Amount,
+		Currency:    one.Currency,
+		Description: one.Description,
+		Current:     one.Version == currentVersion,
+		CreateTime:  one.CreateTime,
+	}
+}
+
+func SimplifyPlanPriceMigration(one *entity.PlanPriceMigration) *PlanPriceMigration {
+	if one == nil {
+		return nil
+	}
+	return &PlanPriceMigration{
+		MerchantId:    one.MerchantId,
+		PlanId:        one.PlanId,
+		MigrationId:   one.MigrationId,
+		FromVersion:   one.FromVersion,
+		ToVersion:     one.ToVersion,
+		Mode:          one.Mode,
+		Status:        one.Status,
+		EffectTime:    one.EffectTime,
+		AffectedCount: one.AffectedCount,
+		MigratedCount: one.MigratedCount,
+		FailedCount:   one.FailedCount,
+		Message:       one.Message,
+		CreateTime:    one.CreateTime,
+	}
+}
+
+func SimplifyPlanPriceMigrationList(ones []*entity.PlanPriceMigration) (list []*PlanPriceMigration) {
+	if len(ones) == 0 {
+		return make([]*PlanPriceMigration, 0)
+	}
+	for _, one := range ones {
+		list = append(list, SimplifyPlanPriceMigration(one))
+	}
+	return list
+}
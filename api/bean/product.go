@@ -17,6 +17,7 @@ type Product struct {
 	IsDeleted   int    `json:"isDeleted"   description:"0-UnDeleted，1-Deleted"`                      // 0-UnDeleted，1-Deleted
 	CreateTime  int64  `json:"createTime"  description:"create utc time"`                            // create utc time
 	MetaData    string `json:"metaData"    description:"meta_data(json)"`                            // meta_data(json)
+	TaxCode     string `json:"taxCode"     description:"tax code of product, general|digital|exempt"`
 }
 
 func SimplifyProduct(one *entity.Product) *Product {
@@ -41,6 +42,7 @@ func SimplifyProduct(one *entity.Product) *Product {
 		IsDeleted:   one.IsDeleted,
 		CreateTime:  one.CreateTime,
 		MetaData:    one.MetaData,
+		TaxCode:     one.TaxCode,
 	}
 }
 
@@ -0,0 +1,73 @@
+package bean
+
+type RevenueMrrPoint struct {
+	Date                     string `json:"date"                     description:"utc day, yyyy-MM-dd"`
+	Time                     int64  `json:"time"                     description:"utc start time of day"`
+	Mrr                      int64  `json:"mrr"                      description:"monthly recurring revenue at end of day, cent"`
+	Arr                      int64  `json:"arr"                      description:"annual recurring revenue at end of day, cent"`
+	Arpu                     int64  `json:"arpu"                     description:"average mrr per active subscription, cent"`
+	ActiveSubscriptions      int64  `json:"activeSubscriptions"      description:"count of subscriptions contributing mrr at end of day"`
+	NewMrr                   int64  `json:"newMrr"                   description:"mrr from new subscriptions, cent"`
+	ExpansionMrr             int64  `json:"expansionMrr"             description:"mrr increased by upgrade, cent"`
+	ContractionMrr           int64  `json:"contractionMrr"           description:"mrr decreased by downgrade, cent"`
+	ChurnMrr                 int64  `json:"churnMrr"                 description:"mrr lost by churned subscriptions, cent"`
+	ReactivationMrr          int64  `json:"reactivationMrr"          description:"mrr from reactivated subscriptions, cent"`
+	NetNewMrr                int64  `json:"netNewMrr"                description:"new + expansion + reactivation - contraction - churn, cent"`
+	NewSubscriptions         int64  `json:"newSubscriptions"         description:"count of new subscriptions"`
+	ChurnedSubscriptions     int64  `json:"churnedSubscriptions"     description:"count of churned subscriptions"`
+	ReactivatedSubscriptions int64  `json:"reactivatedSubscriptions" description:"count of reactivated subscriptions"`
+	Revenue                  int64  `json:"revenue"                  description:"paid invoice amount, cent"`
+	RefundAmount             int64  `json:"refundAmount"             description:"refund amount, cent"`
+}
+
+type RevenueMrrSummary struct {
+	ReportingCurrency     string   `json:"reportingCurrency"     description:"currency all amounts normalized to"`
+	StartMrr              int64    `json:"startMrr"              description:"mrr at start of range, cent"`
+	EndMrr                int64    `json:"endMrr"                description:"mrr at end of range, cent"`
+	Arr                   int64    `json:"arr"                   description:"arr at end of range, cent"`
+	Arpu                  int64    `json:"arpu"                  description:"average mrr per active subscription at end of range, cent"`
+	ActiveSubscriptions   int64    `json:"activeSubscriptions"   description:"count of active subscriptions at end of range"`
+	NewMrr                int64    `json:"newMrr"                description:"mrr from new subscriptions, cent"`
+	ExpansionMrr          int64    `json:"expansionMrr"          description:"mrr increased by upgrade, cent"`
+	ContractionMrr        int64    `json:"contractionMrr"        description:"mrr decreased by downgrade, cent"`
+	ChurnMrr              int64    `json:"churnMrr"              description:"mrr lost by churned subscriptions, cent"`
+	ReactivationMrr       int64    `json:"reactivationMrr"       description:"mrr from reactivated subscriptions, cent"`
+	NetNewMrr             int64    `json:"netNewMrr"             description:"net new mrr, cent"`
+	Revenue               int64    `json:"revenue"               description:"paid invoice amount, cent"`
+	RefundAmount          int64    `json:"refundAmount"          description:"refund amount, cent"`
+	UnconvertedCurrencies []string `json:"unconvertedCurrencies" description:"currencies excluded as no exchange rate to reporting currency found"`
+}
+
+type RevenueChurnMetrics struct {
+	ReportingCurrency        string   `json:"reportingCurrency"        description:"currency all amounts normalized to"`
+	StartMrr                 int64    `json:"startMrr"                 description:"mrr at start of range, cent"`
+	StartActiveSubscriptions int64    `json:"startActiveSubscriptions" description:"count of active subscriptions at start of range"`
+	ChurnedSubscriptions     int64    `json:"churnedSubscriptions"     description:"count of churned subscriptions in range"`
+	ChurnMrr                 int64    `json:"churnMrr"                 description:"mrr lost by churned subscriptions, cent"`
+	ContractionMrr           int64    `json:"contractionMrr"           description:"mrr decreased by downgrade, cent"`
+	ExpansionMrr             int64    `json:"expansionMrr"             description:"mrr increased by upgrade, cent"`
+	ReactivationMrr          int64    `json:"reactivationMrr"          description:"mrr from reactivated subscriptions, cent"`
+	LogoChurnRate            float64  `json:"logoChurnRate"            description:"churned subscriptions / active subscriptions at start, 0.05 means 5%"`
+	MonthlyLogoChurnRate     float64  `json:"monthlyLogoChurnRate"     description:"logo churn rate normalized to 30 days"`
+	RevenueChurnRate         float64  `json:"revenueChurnRate"         description:"(churn + contraction) / mrr at start"`
+	NetRevenueChurnRate      float64  `json:"netRevenueChurnRate"      description:"(churn + contraction - expansion - reactivation) / mrr at start, negative means net expansion"`
+	Arpu                     int64    `json:"arpu"                     description:"average mrr per active subscription at end of range, cent"`
+	Ltv                      int64    `json:"ltv"                      description:"customer lifetime value, arpu / monthly logo churn rate, 0 if no churn, cent"`
+	UnconvertedCurrencies    []string `json:"unconvertedCurrencies"    description:"currencies excluded as no exchange rate to reporting currency found"`
+}
+
+type RevenueCohort struct {
+	Cohort        string                    `json:"cohort"        description:"cohort month of first paid, yyyy-MM"`
+	CohortTime    int64                     `json:"cohortTime"    description:"utc start time of cohort month"`
+	Subscriptions int64                     `json:"subscriptions" description:"count of subscriptions first paid in the month"`
+	InitialMrr    int64                     `json:"initialMrr"    description:"mrr of cohort at end of cohort month, cent"`
+	Retention     []*RevenueCohortRetention `json:"retention"     description:"retention of each month since cohort month"`
+}
+
+type RevenueCohortRetention struct {
+	MonthOffset          int     `json:"monthOffset"          description:"months since cohort month, 0 is the cohort month"`
+	ActiveSubscriptions  int64   `json:"activeSubscriptions"  description:"count of subscriptions contributing mrr at end of month"`
+	Mrr                  int64   `json:"mrr"                  description:"mrr of cohort at end of month, cent"`
+	LogoRetentionRate    float64 `json:"logoRetentionRate"    description:"active subscriptions / cohort subscriptions"`
+	RevenueRetentionRate float64 `json:"revenueRetentionRate" description:"mrr / initial mrr"`
+}
@@ -0,0 +1,28 @@
+package bean
+
+type SalesTaxConfig struct {
+	Enable       bool                `json:"enable"       description:"true-resolve tax by billing address and product tax code for the countries supported by provider, others keep vat rates"`
+	Provider     string              `json:"provider"     description:"sales tax provider, rate_table, default rate_table"`
+	NexusRegions []string            `json:"nexusRegions" description:"regions merchant registered to collect tax, country code or country-state code, em. US-CA, CA. tax of supported country outside nexus is 0, no region collected if empty"`
+	Rules        []*SalesTaxRateRule `json:"rules"        description:"custom rules of rate table, the state rule replaces the build-in state rate, the zip prefix rule adds a local tax"`
+}
+
+type SalesTaxRateRule struct {
+	CountryCode      string `json:"countryCode"      description:"country code, em. US"`
+	State            string `json:"state"            description:"state or province code, em. CA"`
+	ZipPrefix        string `json:"zipPrefix"        description:"zip prefix of local tax, em. 900, the longest prefix matched"`
+	TaxCode          string `json:"taxCode"          description:"tax code the rule applied to, general|digital|exempt, all tax codes if blank"`
+	TaxName          string `json:"taxName"          description:"tax name shown on invoice, em. Los Angeles County Tax"`
+	TaxPercentage    int64  `json:"taxPercentage"    description:"TaxPercentage，1000 = 10%"`
+	JurisdictionType string `json:"jurisdictionType" description:"country|state|local, default state for state rule and local for zip prefix rule"`
+}
+
+type InvoiceTaxLine struct {
+	Jurisdiction        string `json:"jurisdiction"        description:"jurisdiction, em. US-CA"`
+	JurisdictionType    string `json:"jurisdictionType"    description:"country|state|local"`
+	TaxName             string `json:"taxName"             description:"tax name, em. GST, State Sales Tax"`
+	TaxPercentage       int64  `json:"taxPercentage"       description:"TaxPercentage，1000 = 10%"`
+	TaxableAmount       int64  `json:"taxableAmount"       description:"taxable amount, cent"`
+	TaxAmount           int64  `json:"taxAmount"           description:"tax amount, cent"`
+	ExemptCertificateId string `json:"exemptCertificateId" description:"exemption certificate id, tax exempt customer only"`
+}
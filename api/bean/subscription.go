@@ -14,15 +14,27 @@ import (
 )
 
 type SubscriptionConfig struct {
-	DowngradeEffectImmediately         bool   `json:"downgradeEffectImmediately" dc:"DowngradeEffectImmediately, whether subscription update should effect immediately or at period end, default at period end"`
-	UpgradeProration                   bool   `json:"upgradeProration" dc:"UpgradeProration, whether subscription update generation proration invoice or not, default yes"`
-	IncompleteExpireTime               int64  `json:"incompleteExpireTime" dc:"IncompleteExpireTime, em.. default 1day for plan of month type"`
-	InvoiceEmail                       bool   `json:"invoiceEmail" dc:"InvoiceEmail, whether to send invoice email to user, default yes"`
-	InvoicePdfGenerate                 bool   `json:"invoicePdfGenerate" dc:"InvoicePdfGenerate, whether to generate invoice pdf to user, default yes"`
-	TryAutomaticPaymentBeforePeriodEnd int64  `json:"tryAutomaticPaymentBeforePeriodEnd" dc:"TryAutomaticPaymentBeforePeriodEnd, default 30 min"`
-	GatewayVATRule                     string `json:"gatewayVATRule" dc:""`
-	ShowZeroInvoice                    bool   `json:"showZeroInvoice" dc:"ShowZeroInvoice, show zero invoice or not, default no"`
-	FiatExchangeApiKey                 string `json:"fiatExchangeApiKey" dc:""`
+	DowngradeEffectImmediately         bool           `json:"downgradeEffectImmediately" dc:"DowngradeEffectImmediately, whether subscription update should effect immediately or at period end, default at period end"`
+	UpgradeProration                   bool           `json:"upgradeProration" dc:"UpgradeProration, whether subscription update generation proration invoice or not, default yes"`
+	IncompleteExpireTime               int64          `json:"incompleteExpireTime" dc:"IncompleteExpireTime, em.. default 1day for plan of month type"`
+	InvoiceEmail                       bool           `json:"invoiceEmail" dc:"InvoiceEmail, whether to send invoice email to user, default yes"`
+	InvoicePdfGenerate                 bool           `json:"invoicePdfGenerate" dc:"InvoicePdfGenerate, whether to generate invoice pdf to user, default yes"`
+	TryAutomaticPaymentBeforePeriodEnd int64          `json:"tryAutomaticPaymentBeforePeriodEnd" dc:"TryAutomaticPaymentBeforePeriodEnd, default 30 min"`
+	GatewayVATRule                     string         `json:"gatewayVATRule" dc:""`
+	ShowZeroInvoice                    bool           `json:"showZeroInvoice" dc:"ShowZeroInvoice, show zero invoice or not, default no"`
+	FiatExchangeApiKey                 string         `json:"fiatExchangeApiKey" dc:""`
+	PauseResumeBehavior                string         `json:"pauseResumeBehavior" dc:"PauseResumeBehavior, how a paused subscription resumes, shift-period end moves forward by the paused duration, prorate-billing date kept and a prorated period charged, default shift"`
+	DunningPolicy                      *DunningPolicy `json:"dunningPolicy" dc:"DunningPolicy, payment retry schedule of unpaid renewal invoice after period end, subscription expires after IncompleteExpireTime if not set"`
+}
+
+type DunningPolicy struct {
+	Attempts    []*DunningAttempt `json:"attempts" dc:"Attempts, retry schedule after period end, in ascending order of day"`
+	FinalAction string            `json:"finalAction" dc:"FinalAction, action after all attempts failed, cancel|suspend|mark_unpaid, default cancel"`
+}
+
+type DunningAttempt struct {
+	Day           int64  `json:"day" dc:"Day, days after period end to retry the automatic payment"`
+	EmailTemplate string `json:"emailTemplate" dc:"EmailTemplate, email template sent to user on this attempt, the default invoice email will use if not specified"`
 }
 
 type Subscription struct {
@@ -67,6 +79,11 @@ type Subscription struct {
 	DefaultPaymentMethodId string                 `json:"defaultPaymentMethodId"    description:""`
 	ProductId              int64                  `json:"productId"                 description:"product id"`                                                         // product id
 	CurrentPeriodPaid      int64                  `json:"currentPeriodPaid"           description:"current period paid or not, 1-paid, other-the utc time to expire"` // current period paid or not, 1-paid, other-the utc time to expire
+	PauseAtPeriodEnd       int                    `json:"pauseAtPeriodEnd"            description:"whether pause at period end，0-false | 1-true"`                     // whether pause at period end，0-false | 1-true
+	PausedTime             int64                  `json:"pausedTime"                  description:"paused utc time, 0 if not paused"`                                 // paused utc time, 0 if not paused
+	AutoResumeTime         int64                  `json:"autoResumeTime"              description:"auto resume utc time, 0 if resume manually"`                       // auto resume utc time, 0 if resume manually
+	PauseSource            string                 `json:"pauseSource"                 description:"pause source, manual|dunning|dispute, blank if not paused"`        // pause source, manual|dunning|dispute, blank if not paused
+	PlanPriceVersion       int                    `json:"planPriceVersion"            description:"grandfathered price version of plan, 0-follow the current price of plan"`
 }
 
 func SimplifySubscription(ctx context.Context, one *entity.Subscription) *Subscription {
@@ -156,6 +173,11 @@ func SimplifySubscription(ctx context.Context, one *entity.Subscription) *Subscr
 		ProductId:              productId,
 		CancelOrExpireTime:     cancelOrExpireTime,
 		CurrentPeriodPaid:      one.CurrentPeriodPaid,
+		PauseAtPeriodEnd:       one.PauseAtPeriodEnd,
+		PausedTime:             one.PausedTime,
+		AutoResumeTime:         one.AutoResumeTime,
+		PauseSource:            one.PauseSource,
+		PlanPriceVersion:       one.PlanPriceVersion,
 	}
 }
 
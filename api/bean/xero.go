@@ -0,0 +1,14 @@
+package bean
+
+type XeroMapping struct {
+	SalesAccountCode           string                `json:"salesAccountCode"           description:"account code of the invoice and credit note lines, default 200"`
+	PaymentAccountCode         string                `json:"paymentAccountCode"         description:"bank account code the payments and refunds recorded to, default 090"`
+	GatewayPaymentAccountCodes map[string]string     `json:"gatewayPaymentAccountCodes" description:"bank account code by gateway name, paymentAccountCode used if gateway not mapped"`
+	TaxTypes                   []*XeroTaxTypeMapping `json:"taxTypes"                   description:"xero tax type of the lines by country and tax percentage, the account default tax type used if not mapped"`
+}
+
+type XeroTaxTypeMapping struct {
+	CountryCode   string `json:"countryCode"   description:"country code of invoice, match all countries if empty"`
+	TaxPercentage int64  `json:"taxPercentage" description:"tax percentage of invoice line, 1000 = 10%"`
+	TaxType       string `json:"taxType"       description:"xero tax type, like OUTPUT2|EXEMPTOUTPUT|NONE"`
+}
@@ -0,0 +1,59 @@
+package apikey
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"unibee/api/bean"
+)
+
+type ListReq struct {
+	g.Meta `path:"/list" tags:"ApiKey" method:"get,post" summary:"Scoped ApiKey List" dc:"The scoped api keys of merchant, the key masked"`
+	Status []int `json:"status" dc:"Filter Status, 1-active,2-revoked, Default All"`
+	Page   int   `json:"page"  dc:"Page, Start 0" `
+	Count  int   `json:"count"  dc:"Count Of Per Page" `
+}
+type ListRes struct {
+	ApiKeys []*bean.MerchantApiKey `json:"apiKeys" dc:"ApiKeys"`
+	Total   int                    `json:"total" dc:"Total"`
+}
+
+type NewReq struct {
+	g.Meta     `path:"/new" tags:"ApiKey" method:"post" summary:"New Scoped ApiKey" dc:"Create named api key with permission scopes, the full key only returned once"`
+	Name       string   `json:"name" dc:"The name of api key" v:"required"`
+	Scopes     []string `json:"scopes" dc:"The permission scopes, read|write for all groups, {group}:read|{group}:write for the permission group, plan|billable-metric|discount-code|subscription|invoice|transaction|user|admin|my-account|report|configuration|activity-logs|analytics, write grants read, the group scope grants read to its dependency groups" v:"required"`
+	ValidIps   []string `json:"validIps" dc:"The ip allowlist, ip or cidr, all allowed if empty"`
+	ExpireTime int64    `json:"expireTime" dc:"The utc expire time, never expire if not set"`
+}
+type NewRes struct {
+	ApiKey    string               `json:"apiKey" dc:"The full api key, only returned once"`
+	ApiKeyObj *bean.MerchantApiKey `json:"apiKeyObj" dc:"ApiKey"`
+}
+
+type EditReq struct {
+	g.Meta     `path:"/edit" tags:"ApiKey" method:"post" summary:"Edit Scoped ApiKey"`
+	Id         uint64    `json:"id" dc:"The id of api key" v:"required"`
+	Name       *string   `json:"name" dc:"The name of api key"`
+	Scopes     []string  `json:"scopes" dc:"The permission scopes, read|write|{group}:read|{group}:write, not changed if not set"`
+	ValidIps   *[]string `json:"validIps" dc:"The ip allowlist, ip or cidr, all allowed if empty, not changed if not set"`
+	ExpireTime *int64    `json:"expireTime" dc:"The utc expire time, 0 for never expire, not changed if not set"`
+}
+type EditRes struct {
+	ApiKeyObj *bean.MerchantApiKey `json:"apiKeyObj" dc:"ApiKey"`
+}
+
+type RotateReq struct {
+	g.Meta         `path:"/rotate" tags:"ApiKey" method:"post" summary:"Rotate Scoped ApiKey" dc:"Generate new key for the api key, the old one valid in overlap window for integrations to switch"`
+	Id             uint64 `json:"id" dc:"The id of api key" v:"required"`
+	OverlapSeconds *int64 `json:"overlapSeconds" dc:"The seconds old key valid after rotation, default 86400, max 604800, 0 for expiring old key immediately"`
+}
+type RotateRes struct {
+	ApiKey    string               `json:"apiKey" dc:"The new full api key, only returned once"`
+	ApiKeyObj *bean.MerchantApiKey `json:"apiKeyObj" dc:"ApiKey"`
+}
+
+type RevokeReq struct {
+	g.Meta `path:"/revoke" tags:"ApiKey" method:"post" summary:"Revoke Scoped ApiKey" dc:"Revoke the api key and the old one in overlap window immediately"`
+	Id     uint64 `json:"id" dc:"The id of api key" v:"required"`
+}
+type RevokeRes struct {
+	ApiKeyObj *bean.MerchantApiKey `json:"apiKeyObj" dc:"ApiKey"`
+}
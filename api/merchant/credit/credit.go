@@ -104,6 +104,7 @@ type DetailReq struct {
 type DetailRes struct {
 	CreditAccount      *detail.CreditAccountDetail `json:"creditAccount" dc:"Credit Account Object"`
 	CreditTransactions []*bean.CreditTransaction   `json:"creditTransactions" dc:"Credit Transaction List"`
+	CreditGrants       []*bean.CreditGrant         `json:"creditGrants" dc:"Credit Grant List, the credit is consumed from the soonest expiring grant first"`
 }
 
 type NewCreditRechargeReq struct {
@@ -156,7 +157,7 @@ type CreditTransactionListReq struct {
 	Currency         string `json:"currency"  description:"filter currency of account"`
 	SortField        string `json:"sortField" dc:"Sort Field，gmt_create|gmt_modify，Default gmt_modify" `
 	SortType         string `json:"sortType" dc:"Sort Type，asc|desc，Default desc" `
-	TransactionTypes []int  `json:"transactionTypes" dc:"transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out" `
+	TransactionTypes []int  `json:"transactionTypes" dc:"transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out，8-expired" `
 	Page             int    `json:"page"  dc:"Page, Start 0" `
 	Count            int    `json:"count"  dc:"Count Of Per Page" `
 	CreateTimeStart  int64  `json:"createTimeStart" dc:"CreateTimeStart，UTC timestamp，seconds" `
@@ -175,6 +176,7 @@ type PromoCreditIncrementReq struct {
 	Amount      uint64 `json:"amount" dc:"The amount to increase, should greater than 0"  v:"required"`
 	Name        string `json:"name" description:"name of increase action"`
 	Description string `json:"description"  description:"description of increase action"`
+	ExpireTime  int64  `json:"expireTime" dc:"UTC timestamp the increased credit expires, the unused amount is deducted once expired, 0 means never expire"`
 }
 
 type PromoCreditIncrementRes struct {
@@ -8,8 +8,8 @@ import (
 
 type GatewaySetupReq struct {
 	g.Meta      `path:"/gateway_setup" tags:"Email" method:"post" summary:"Email Gateway Setup"`
-	GatewayName string `json:"gatewayName"  dc:"The name of email gateway, 'sendgrid' or other for future updates" v:"required"`
-	Data        string `json:"data" dc:"The setup data of email gateway" v:"required"`
+	GatewayName string `json:"gatewayName"  dc:"The name of email gateway, sendgrid|smtp|mailgun|file" v:"required"`
+	Data        string `json:"data" dc:"The setup data of email gateway, api key for sendgrid; json {\"host\",\"port\",\"username\",\"password\",\"security\":\"starttls|tls|none\"} for smtp; json {\"domain\",\"apiKey\",\"baseUrl\"} for mailgun; any non-blank data for file, which writes .eml files to the server outbox instead of delivering, not available in prod" v:"required"`
 	IsDefault   bool   `json:"IsDefault" d:"true" dc:"Whether setup the gateway as default or not, default is true" `
 }
 
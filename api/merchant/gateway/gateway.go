@@ -1,6 +1,7 @@
 package gateway
 
 import (
+	"unibee/api/bean"
 	"unibee/api/bean/detail"
 
 	"github.com/gogf/gf/v2/frame/g"
@@ -150,3 +151,18 @@ type SetupExchangeApiReq struct {
 type SetupExchangeApiRes struct {
 	Data string `json:"data"  dc:"The hide star key of exchange rate api"`
 }
+
+type RoutingReq struct {
+	g.Meta `path:"/routing" tags:"Gateway" method:"get" summary:"Get Gateway Routing" dc:"Get the routing rules and failover setting of merchant gateways"`
+}
+type RoutingRes struct {
+	Routing *bean.GatewayRouting `json:"routing" dc:"Gateway Routing"`
+}
+
+type RoutingUpdateReq struct {
+	g.Meta  `path:"/routing/update" tags:"Gateway" method:"post" summary:"Update Gateway Routing" dc:"The first matched rule chooses the gateways of new payment by currency, country, amount range and payment type, when failover enabled, payment failed with gateway error is retried on the next eligible gateway ordered by gateway sort"`
+	Routing *bean.GatewayRouting `json:"routing" dc:"Gateway Routing" v:"required"`
+}
+type RoutingUpdateRes struct {
+	Routing *bean.GatewayRouting `json:"routing" dc:"Gateway Routing"`
+}
@@ -0,0 +1,50 @@
+package integration
+
+import (
+	"unibee/api/bean"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type ConnectionXeroReq struct {
+	g.Meta        `path:"/xero/get_authorization_url" tags:"Integrations" method:"get" summary:"Get Xero Authorization Connection URL"`
+	ReturnUrl     string `json:"returnUrl" dc:"ReturnUrl"`
+	ClientId      string `json:"clientId" dc:"The client id of merchant's own xero app, the UniBee app used if not specified"`
+	ClientSecret  string `json:"clientSecret" dc:"The client secret of merchant's own xero app, required with clientId"`
+	MockServerUrl string `json:"mockServerUrl" dc:"For testing only and not available in prod, the url of local mock server all xero apis sent to, clientId and clientSecret required with it"`
+}
+
+type ConnectionXeroRes struct {
+	AuthorizationURL string `json:"authorizationUrl" dc:"Authorization URL"`
+}
+
+type DisconnectionXeroReq struct {
+	g.Meta `path:"/xero/disconnection" tags:"Integrations" method:"get" summary:"Disconnection Xero"`
+}
+
+type DisconnectionXeroRes struct {
+}
+
+type XeroMappingReq struct {
+	g.Meta `path:"/xero/mapping" tags:"Integrations" method:"get" summary:"Get Xero Account Code And Tax Rate Mapping"`
+}
+
+type XeroMappingRes struct {
+	TenantName    string            `json:"tenantName" dc:"The xero organisation connected"`
+	Connected     bool              `json:"connected" dc:"Whether xero connected or not"`
+	LastSyncTime  int64             `json:"lastSyncTime" dc:"The time of last invoice pushed"`
+	LastSyncError string            `json:"lastSyncError" dc:"The error of last invoice pushed"`
+	Mapping       *bean.XeroMapping `json:"mapping" dc:"Mapping"`
+}
+
+type XeroMappingSetupReq struct {
+	g.Meta                     `path:"/xero/mapping_setup" tags:"Integrations" method:"post" summary:"Setup Xero Account Code And Tax Rate Mapping"`
+	SalesAccountCode           string                     `json:"salesAccountCode" dc:"Account code of the invoice and credit note lines, default 200"`
+	PaymentAccountCode         string                     `json:"paymentAccountCode" dc:"Bank account code the payments and refunds recorded to, default 090"`
+	GatewayPaymentAccountCodes map[string]string          `json:"gatewayPaymentAccountCodes" dc:"Bank account code by gateway name, paymentAccountCode used if gateway not mapped"`
+	TaxTypes                   []*bean.XeroTaxTypeMapping `json:"taxTypes" dc:"Xero tax type by country and tax percentage, the one with country preferred, the account default tax type used if not mapped"`
+}
+
+type XeroMappingSetupRes struct {
+	Mapping *bean.XeroMapping `json:"mapping" dc:"Mapping"`
+}
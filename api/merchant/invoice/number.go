@@ -0,0 +1,27 @@
+package invoice
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"unibee/api/bean"
+)
+
+type NumberConfigReq struct {
+	g.Meta `path:"/number_config" tags:"Invoice" method:"get" summary:"Get Invoice Number Config" dc:"Get the legal sequential numbering config and the next numbers of invoice and credit note"`
+}
+type NumberConfigRes struct {
+	Config               *bean.InvoiceNumberConfig `json:"config" dc:"Invoice Number Config"`
+	NextInvoiceNumber    string                    `json:"nextInvoiceNumber" dc:"The number the next finalized invoice would get"`
+	NextCreditNoteNumber string                    `json:"nextCreditNoteNumber" dc:"The number the next finalized credit note would get"`
+}
+
+type NumberConfigSetupReq struct {
+	g.Meta                `path:"/number_config_setup" tags:"Invoice" method:"post" summary:"Setup Invoice Number Config" dc:"Setup legal sequential numbering, the number assigned when invoice finalized and never reused. To switch over, set startNumber to continue the previous numbers, invoices created before enabled keep their invoice id"`
+	Enable                bool   `json:"enable" dc:"true-enable legal numbering for the invoices created from now"`
+	InvoicePattern        string `json:"invoicePattern" dc:"Number pattern of invoice, {YYYY} or {YY} for year and the counter reset yearly, {NNNNNN} for the counter zero-padded, default INV-{YYYY}-{NNNNNN}"`
+	CreditNotePattern     string `json:"creditNotePattern" dc:"Number pattern of credit note, default CN-{YYYY}-{NNNNNN}"`
+	StartNumber           int64  `json:"startNumber" dc:"The next counter of invoice series in current period, should greater than the last number assigned, 0 keeps the counter"`
+	CreditNoteStartNumber int64  `json:"creditNoteStartNumber" dc:"The next counter of credit note series in current period, 0 keeps the counter"`
+}
+type NumberConfigSetupRes struct {
+	Config *bean.InvoiceNumberConfig `json:"config" dc:"Invoice Number Config"`
+}
@@ -0,0 +1,37 @@
+package ledger
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"unibee/api/bean"
+)
+
+type AccountCodesReq struct {
+	g.Meta `path:"/account_codes" tags:"Ledger" method:"get" summary:"Get Ledger Account Codes" dc:"Get the account codes of ledger export, the default codes used if not configured"`
+}
+type AccountCodesRes struct {
+	AccountCodes *bean.LedgerAccountCodes `json:"accountCodes" dc:"Account Codes"`
+}
+
+type AccountCodesSetupReq struct {
+	g.Meta       `path:"/account_codes_setup" tags:"Ledger" method:"post" summary:"Setup Ledger Account Codes" dc:"Setup the account codes of ledger export, apply to the journals exported after"`
+	AccountCodes *bean.LedgerAccountCodes `json:"accountCodes" dc:"Account Codes" v:"required"`
+}
+type AccountCodesSetupRes struct {
+	AccountCodes *bean.LedgerAccountCodes `json:"accountCodes" dc:"Account Codes"`
+}
+
+type EntryListReq struct {
+	g.Meta         `path:"/entry_list" tags:"Ledger" method:"get,post" summary:"Ledger Entry List" dc:"The journal lines of invoices, payments, refunds, credit notes, promo credits and revenue recognition, lines of one journal balanced. Use batch export task LedgerExport for CSV/XLSX"`
+	EntryTypes     []string `json:"entryTypes" dc:"Filter EntryTypes, invoice_finalized|promo_credit_used|payment_success|credit_note_finalized|refund_success|invoice_voided|revenue_recognition, Default All"`
+	Accounts       []string `json:"accounts" dc:"Filter Accounts, ar|revenue|deferred_revenue|tax_payable|cash|promo_credit, Default All"`
+	Currency       string   `json:"currency" dc:"Filter Currency"`
+	InvoiceId      string   `json:"invoiceId" dc:"Filter InvoiceId"`
+	EntryTimeStart int64    `json:"entryTimeStart" dc:"The utc start time of entry"`
+	EntryTimeEnd   int64    `json:"entryTimeEnd" dc:"The utc end time of entry"`
+	Page           int      `json:"page"  dc:"Page, Start 0" `
+	Count          int      `json:"count"  dc:"Count Of Per Page" `
+}
+type EntryListRes struct {
+	Entries []*bean.LedgerEntry `json:"entries" dc:"Ledger Entries"`
+	Total   int                 `json:"total" dc:"Total"`
+}
@@ -7,6 +7,7 @@ package merchant
 import (
 	"context"
 
+	"unibee/api/merchant/apikey"
 	"unibee/api/merchant/auth"
 	"unibee/api/merchant/checkout"
 	"unibee/api/merchant/credit"
@@ -15,6 +16,7 @@ import (
 	"unibee/api/merchant/gateway"
 	"unibee/api/merchant/integration"
 	"unibee/api/merchant/invoice"
+	"unibee/api/merchant/ledger"
 	"unibee/api/merchant/member"
 	"unibee/api/merchant/metric"
 	"unibee/api/merchant/oss"
@@ -22,6 +24,8 @@ import (
 	"unibee/api/merchant/plan"
 	"unibee/api/merchant/product"
 	"unibee/api/merchant/profile"
+	"unibee/api/merchant/reconciliation"
+	"unibee/api/merchant/revenue"
 	"unibee/api/merchant/role"
 	"unibee/api/merchant/search"
 	"unibee/api/merchant/session"
@@ -36,6 +40,14 @@ import (
 	_scenario "unibee/api/merchant/scenario"
 )
 
+type IMerchantApikey interface {
+	List(ctx context.Context, req *apikey.ListReq) (res *apikey.ListRes, err error)
+	New(ctx context.Context, req *apikey.NewReq) (res *apikey.NewRes, err error)
+	Edit(ctx context.Context, req *apikey.EditReq) (res *apikey.EditRes, err error)
+	Rotate(ctx context.Context, req *apikey.RotateReq) (res *apikey.RotateRes, err error)
+	Revoke(ctx context.Context, req *apikey.RevokeReq) (res *apikey.RevokeRes, err error)
+}
+
 type IMerchantAuth interface {
 	Login(ctx context.Context, req *auth.LoginReq) (res *auth.LoginRes, err error)
 	LoginOAuth(ctx context.Context, req *auth.LoginOAuthReq) (res *auth.LoginOAuthRes, err error)
@@ -126,11 +138,17 @@ type IMerchantGateway interface {
 	WireTransferSetup(ctx context.Context, req *gateway.WireTransferSetupReq) (res *gateway.WireTransferSetupRes, err error)
 	WireTransferEdit(ctx context.Context, req *gateway.WireTransferEditReq) (res *gateway.WireTransferEditRes, err error)
 	SetupExchangeApi(ctx context.Context, req *gateway.SetupExchangeApiReq) (res *gateway.SetupExchangeApiRes, err error)
+	Routing(ctx context.Context, req *gateway.RoutingReq) (res *gateway.RoutingRes, err error)
+	RoutingUpdate(ctx context.Context, req *gateway.RoutingUpdateReq) (res *gateway.RoutingUpdateRes, err error)
 }
 
 type IMerchantIntegration interface {
 	ConnectionQuickBooks(ctx context.Context, req *integration.ConnectionQuickBooksReq) (res *integration.ConnectionQuickBooksRes, err error)
 	DisconnectionQuickBooks(ctx context.Context, req *integration.DisconnectionQuickBooksReq) (res *integration.DisconnectionQuickBooksRes, err error)
+	ConnectionXero(ctx context.Context, req *integration.ConnectionXeroReq) (res *integration.ConnectionXeroRes, err error)
+	DisconnectionXero(ctx context.Context, req *integration.DisconnectionXeroReq) (res *integration.DisconnectionXeroRes, err error)
+	XeroMapping(ctx context.Context, req *integration.XeroMappingReq) (res *integration.XeroMappingRes, err error)
+	XeroMappingSetup(ctx context.Context, req *integration.XeroMappingSetupReq) (res *integration.XeroMappingSetupRes, err error)
 }
 
 type IMerchantInvoice interface {
@@ -151,6 +169,14 @@ type IMerchantInvoice interface {
 	MarkRefund(ctx context.Context, req *invoice.MarkRefundReq) (res *invoice.MarkRefundRes, err error)
 	MarkWireTransferSuccess(ctx context.Context, req *invoice.MarkWireTransferSuccessReq) (res *invoice.MarkWireTransferSuccessRes, err error)
 	MarkRefundInvoiceSuccess(ctx context.Context, req *invoice.MarkRefundInvoiceSuccessReq) (res *invoice.MarkRefundInvoiceSuccessRes, err error)
+	NumberConfig(ctx context.Context, req *invoice.NumberConfigReq) (res *invoice.NumberConfigRes, err error)
+	NumberConfigSetup(ctx context.Context, req *invoice.NumberConfigSetupReq) (res *invoice.NumberConfigSetupRes, err error)
+}
+
+type IMerchantLedger interface {
+	AccountCodes(ctx context.Context, req *ledger.AccountCodesReq) (res *ledger.AccountCodesRes, err error)
+	AccountCodesSetup(ctx context.Context, req *ledger.AccountCodesSetupReq) (res *ledger.AccountCodesSetupRes, err error)
+	EntryList(ctx context.Context, req *ledger.EntryListReq) (res *ledger.EntryListRes, err error)
 }
 
 type IMerchantMember interface {
@@ -192,6 +218,7 @@ type IMerchantMetric interface {
 
 type IMerchantOss interface {
 	FileUpload(ctx context.Context, req *oss.FileUploadReq) (res *oss.FileUploadRes, err error)
+	FileSignedUrl(ctx context.Context, req *oss.FileSignedUrlReq) (res *oss.FileSignedUrlRes, err error)
 }
 
 type IMerchantPayment interface {
@@ -210,6 +237,11 @@ type IMerchantPayment interface {
 	RefundDetail(ctx context.Context, req *payment.RefundDetailReq) (res *payment.RefundDetailRes, err error)
 	RefundList(ctx context.Context, req *payment.RefundListReq) (res *payment.RefundListRes, err error)
 	TimeLineList(ctx context.Context, req *payment.TimeLineListReq) (res *payment.TimeLineListRes, err error)
+	DisputeList(ctx context.Context, req *payment.DisputeListReq) (res *payment.DisputeListRes, err error)
+	DisputeDetail(ctx context.Context, req *payment.DisputeDetailReq) (res *payment.DisputeDetailRes, err error)
+	DisputeEvidenceUpload(ctx context.Context, req *payment.DisputeEvidenceUploadReq) (res *payment.DisputeEvidenceUploadRes, err error)
+	DisputeConfig(ctx context.Context, req *payment.DisputeConfigReq) (res *payment.DisputeConfigRes, err error)
+	DisputeConfigUpdate(ctx context.Context, req *payment.DisputeConfigUpdateReq) (res *payment.DisputeConfigUpdateRes, err error)
 }
 
 type IMerchantPlan interface {
@@ -224,6 +256,12 @@ type IMerchantPlan interface {
 	Detail(ctx context.Context, req *plan.DetailReq) (res *plan.DetailRes, err error)
 	Archive(ctx context.Context, req *plan.ArchiveReq) (res *plan.ArchiveRes, err error)
 	Delete(ctx context.Context, req *plan.DeleteReq) (res *plan.DeleteRes, err error)
+	PriceVersionNew(ctx context.Context, req *plan.PriceVersionNewReq) (res *plan.PriceVersionNewRes, err error)
+	PriceVersionList(ctx context.Context, req *plan.PriceVersionListReq) (res *plan.PriceVersionListRes, err error)
+	PriceMigrationPreview(ctx context.Context, req *plan.PriceMigrationPreviewReq) (res *plan.PriceMigrationPreviewRes, err error)
+	PriceMigrationSchedule(ctx context.Context, req *plan.PriceMigrationScheduleReq) (res *plan.PriceMigrationScheduleRes, err error)
+	PriceMigrationCancel(ctx context.Context, req *plan.PriceMigrationCancelReq) (res *plan.PriceMigrationCancelRes, err error)
+	PriceMigrationList(ctx context.Context, req *plan.PriceMigrationListReq) (res *plan.PriceMigrationListRes, err error)
 }
 
 type IMerchantProduct interface {
@@ -250,6 +288,20 @@ type IMerchantProfile interface {
 	AmountMultiCurrenciesExchange(ctx context.Context, req *profile.AmountMultiCurrenciesExchangeReq) (res *profile.AmountMultiCurrenciesExchangeRes, err error)
 }
 
+type IMerchantReconciliation interface {
+	Run(ctx context.Context, req *reconciliation.RunReq) (res *reconciliation.RunRes, err error)
+	List(ctx context.Context, req *reconciliation.ListReq) (res *reconciliation.ListRes, err error)
+	DiscrepancyList(ctx context.Context, req *reconciliation.DiscrepancyListReq) (res *reconciliation.DiscrepancyListRes, err error)
+	DiscrepancyResolve(ctx context.Context, req *reconciliation.DiscrepancyResolveReq) (res *reconciliation.DiscrepancyResolveRes, err error)
+}
+
+type IMerchantRevenue interface {
+	Mrr(ctx context.Context, req *revenue.MrrReq) (res *revenue.MrrRes, err error)
+	Churn(ctx context.Context, req *revenue.ChurnReq) (res *revenue.ChurnRes, err error)
+	Cohort(ctx context.Context, req *revenue.CohortReq) (res *revenue.CohortRes, err error)
+	SnapshotRebuild(ctx context.Context, req *revenue.SnapshotRebuildReq) (res *revenue.SnapshotRebuildRes, err error)
+}
+
 type IMerchantRole interface {
 	List(ctx context.Context, req *role.ListReq) (res *role.ListRes, err error)
 	New(ctx context.Context, req *role.NewReq) (res *role.NewRes, err error)
@@ -285,6 +337,8 @@ type IMerchantSubscription interface {
 	Cancel(ctx context.Context, req *subscription.CancelReq) (res *subscription.CancelRes, err error)
 	CancelAtPeriodEnd(ctx context.Context, req *subscription.CancelAtPeriodEndReq) (res *subscription.CancelAtPeriodEndRes, err error)
 	CancelLastCancelAtPeriodEnd(ctx context.Context, req *subscription.CancelLastCancelAtPeriodEndReq) (res *subscription.CancelLastCancelAtPeriodEndRes, err error)
+	Pause(ctx context.Context, req *subscription.PauseReq) (res *subscription.PauseRes, err error)
+	Resume(ctx context.Context, req *subscription.ResumeReq) (res *subscription.ResumeRes, err error)
 	ChangeGateway(ctx context.Context, req *subscription.ChangeGatewayReq) (res *subscription.ChangeGatewayRes, err error)
 	AddNewTrialStart(ctx context.Context, req *subscription.AddNewTrialStartReq) (res *subscription.AddNewTrialStartRes, err error)
 	CreatePreview(ctx context.Context, req *subscription.CreatePreviewReq) (res *subscription.CreatePreviewRes, err error)
@@ -329,6 +383,8 @@ type IMerchantUser interface {
 	ClearAutoChargeMethod(ctx context.Context, req *user.ClearAutoChargeMethodReq) (res *user.ClearAutoChargeMethodRes, err error)
 	NewAdminNote(ctx context.Context, req *user.NewAdminNoteReq) (res *user.NewAdminNoteRes, err error)
 	AdminNoteList(ctx context.Context, req *user.AdminNoteListReq) (res *user.AdminNoteListRes, err error)
+	DataExport(ctx context.Context, req *user.DataExportReq) (res *user.DataExportRes, err error)
+	Anonymize(ctx context.Context, req *user.AnonymizeReq) (res *user.AnonymizeRes, err error)
 }
 
 type IMerchantVat interface {
@@ -339,6 +395,9 @@ type IMerchantVat interface {
 	NumberValidateHistory(ctx context.Context, req *vat.NumberValidateHistoryReq) (res *vat.NumberValidateHistoryRes, err error)
 	NumberValidateHistoryActivate(ctx context.Context, req *vat.NumberValidateHistoryActivateReq) (res *vat.NumberValidateHistoryActivateRes, err error)
 	NumberValidateHistoryDeactivate(ctx context.Context, req *vat.NumberValidateHistoryDeactivateReq) (res *vat.NumberValidateHistoryDeactivateRes, err error)
+	SalesTaxConfig(ctx context.Context, req *vat.SalesTaxConfigReq) (res *vat.SalesTaxConfigRes, err error)
+	SalesTaxConfigSetup(ctx context.Context, req *vat.SalesTaxConfigSetupReq) (res *vat.SalesTaxConfigSetupRes, err error)
+	SalesTaxPreview(ctx context.Context, req *vat.SalesTaxPreviewReq) (res *vat.SalesTaxPreviewRes, err error)
 }
 
 type IMerchantWebhook interface {
@@ -350,6 +409,13 @@ type IMerchantWebhook interface {
 	NewEndpoint(ctx context.Context, req *webhook.NewEndpointReq) (res *webhook.NewEndpointRes, err error)
 	UpdateEndpoint(ctx context.Context, req *webhook.UpdateEndpointReq) (res *webhook.UpdateEndpointRes, err error)
 	DeleteEndpoint(ctx context.Context, req *webhook.DeleteEndpointReq) (res *webhook.DeleteEndpointRes, err error)
+	EndpointSecret(ctx context.Context, req *webhook.EndpointSecretReq) (res *webhook.EndpointSecretRes, err error)
+	RotateEndpointSecret(ctx context.Context, req *webhook.RotateEndpointSecretReq) (res *webhook.RotateEndpointSecretRes, err error)
+	EndpointLogAttemptList(ctx context.Context, req *webhook.EndpointLogAttemptListReq) (res *webhook.EndpointLogAttemptListRes, err error)
+	EnableEndpoint(ctx context.Context, req *webhook.EnableEndpointReq) (res *webhook.EnableEndpointRes, err error)
+	ReplayFailed(ctx context.Context, req *webhook.ReplayFailedReq) (res *webhook.ReplayFailedRes, err error)
+	RetryPolicy(ctx context.Context, req *webhook.RetryPolicyReq) (res *webhook.RetryPolicyRes, err error)
+	RetryPolicyUpdate(ctx context.Context, req *webhook.RetryPolicyUpdateReq) (res *webhook.RetryPolicyUpdateRes, err error)
 }
 
 type IMerchantTelegram interface {
@@ -369,6 +435,7 @@ type IMerchantScenario interface {
 	List(ctx context.Context, req *_scenario.ListReq) (res *_scenario.ListRes, err error)
 	Detail(ctx context.Context, req *_scenario.DetailReq) (res *_scenario.DetailRes, err error)
 	TestRun(ctx context.Context, req *_scenario.TestRunReq) (res *_scenario.TestRunRes, err error)
+	Run(ctx context.Context, req *_scenario.RunReq) (res *_scenario.RunRes, err error)
 	ExecutionList(ctx context.Context, req *_scenario.ExecutionListReq) (res *_scenario.ExecutionListRes, err error)
 	ExecutionDetail(ctx context.Context, req *_scenario.ExecutionDetailReq) (res *_scenario.ExecutionDetailRes, err error)
 	ActionList(ctx context.Context, req *_scenario.ActionListReq) (res *_scenario.ActionListRes, err error)
@@ -12,3 +12,11 @@ type FileUploadReq struct {
 type FileUploadRes struct {
 	Url string `json:"url"  dc:"URL Of File Or Image"`
 }
+
+type FileSignedUrlReq struct {
+	g.Meta `path:"/file_signed_url" method:"get" tags:"File" summary:"Get File Signed Url" dc:"Get the expiring download url of file saved in local or s3 storage, file saved in database returns its url"`
+	Url    string `json:"url" dc:"URL Of File, or the file name" v:"required"`
+}
+type FileSignedUrlRes struct {
+	SignedUrl string `json:"signedUrl" dc:"Expiring Download URL"`
+}
@@ -0,0 +1,56 @@
+package payment
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"unibee/api/bean"
+	"unibee/api/bean/detail"
+)
+
+type DisputeListReq struct {
+	g.Meta         `path:"/dispute/list" tags:"Payment" method:"get,post" summary:"Get Payment Dispute List"`
+	UserId         uint64 `json:"userId" dc:"Filter UserId, Default All"`
+	PaymentId      string `json:"paymentId" dc:"Filter PaymentId"`
+	InvoiceId      string `json:"invoiceId" dc:"Filter InvoiceId"`
+	SubscriptionId string `json:"subscriptionId" dc:"Filter SubscriptionId"`
+	Status         []int  `json:"status" dc:"Filter Status, 10-opened, 20-evidence needed, 30-won, 40-lost"`
+	Page           int    `json:"page"  dc:"Page, Start With 0"`
+	Count          int    `json:"count" dc:"Count Of Page"`
+}
+type DisputeListRes struct {
+	Disputes []*bean.PaymentDispute `json:"disputes" dc:"Dispute Object List"`
+	Total    int                    `json:"total" dc:"Total"`
+}
+
+type DisputeDetailReq struct {
+	g.Meta    `path:"/dispute/detail" tags:"Payment" method:"get" summary:"Get Payment Dispute Detail"`
+	DisputeId string `json:"disputeId" dc:"DisputeId" v:"required"`
+}
+type DisputeDetailRes struct {
+	DisputeDetail *detail.PaymentDisputeDetail `json:"disputeDetail" dc:"Dispute Detail Object"`
+}
+
+type DisputeEvidenceUploadReq struct {
+	g.Meta      `path:"/dispute/evidence/upload" method:"post" mime:"multipart/form-data" tags:"Payment" summary:"Upload Payment Dispute Evidence" dc:"Upload the evidence file of dispute, the file is saved to the file storage and attached to the dispute, submit it to gateway from the dashboard of gateway"`
+	DisputeId   string            `json:"disputeId" dc:"DisputeId" v:"required"`
+	File        *ghttp.UploadFile `json:"file" type:"file" dc:"Evidence File To Upload"`
+	Description string            `json:"description" dc:"Description of the evidence"`
+}
+type DisputeEvidenceUploadRes struct {
+	Dispute *bean.PaymentDispute `json:"dispute" dc:"Dispute Object"`
+}
+
+type DisputeConfigReq struct {
+	g.Meta `path:"/dispute/config" tags:"Payment" method:"get" summary:"Get Payment Dispute Config"`
+}
+type DisputeConfigRes struct {
+	Config *bean.DisputeConfig `json:"config" dc:"Dispute Config"`
+}
+
+type DisputeConfigUpdateReq struct {
+	g.Meta                    `path:"/dispute/config/update" tags:"Payment" method:"post" summary:"Update Payment Dispute Config"`
+	SuspendSubscriptionOnLost *bool `json:"suspendSubscriptionOnLost" dc:"Suspend the subscription of payment once the dispute is lost" v:"required"`
+}
+type DisputeConfigUpdateRes struct {
+	Config *bean.DisputeConfig `json:"config" dc:"Dispute Config"`
+}
@@ -45,7 +45,7 @@ type EditReq struct {
 	ExternalPlanId        *string                               `json:"externalPlanId" dc:"ExternalPlanId"`
 	PlanName              *string                               `json:"planName" dc:"Name of plan" `
 	InternalName          *string                               `json:"internalName"              description:""`
-	Amount                *int64                                `json:"amount"   dc:"CaptureAmount of plan, not editable when plan is active" `
+	Amount                *int64                                `json:"amount"   dc:"CaptureAmount of plan, not editable when plan is active, use /price_version_new to change the price of active plan" `
 	Currency              *string                               `json:"currency"   dc:"Currency of plan, not editable when plan is active"`
 	IntervalUnit          *string                               `json:"intervalUnit" dc:"Interval unit of plan，em: day|month|year|week, not editable when plan is active"`
 	IntervalCount         *int                                  `json:"intervalCount"  dc:"Number,intervalUnit of plan, not editable when plan is active" `
@@ -160,3 +160,65 @@ type DeleteReq struct {
 }
 type DeleteRes struct {
 }
+
+type PriceVersionNewReq struct {
+	g.Meta      `path:"/price_version_new" tags:"Plan" method:"post" summary:"New Plan Price Version" dc:"Change the price of active plan by creating a new price version, existing subscriptions are grandfathered on their current price until migrated"`
+	PlanId      uint64 `json:"planId" dc:"The Id of plan" v:"required"`
+	Amount      int64  `json:"amount" dc:"New amount of plan, cent" v:"required"`
+	Description string `json:"description" dc:"Description of price change"`
+}
+type PriceVersionNewRes struct {
+	PriceVersion *bean.PlanPriceVersion `json:"priceVersion" dc:"Price Version"`
+}
+
+type PriceVersionListReq struct {
+	g.Meta `path:"/price_version_list" tags:"Plan" method:"get,post" summary:"Plan Price Version List"`
+	PlanId uint64 `json:"planId" dc:"The Id of plan" v:"required"`
+}
+type PriceVersionListRes struct {
+	PriceVersions []*bean.PlanPriceVersion `json:"priceVersions" dc:"Price Version List, newest first"`
+}
+
+type PriceMigrationPreviewReq struct {
+	g.Meta      `path:"/price_migration_preview" tags:"Plan" method:"post" summary:"Preview Plan Price Migration" dc:"Dry run of price migration, report the subscriptions affected and their price change"`
+	PlanId      uint64 `json:"planId" dc:"The Id of plan" v:"required"`
+	FromVersion int    `json:"fromVersion" dc:"The price version subscriptions migrate from" v:"required"`
+	ToVersion   int    `json:"toVersion" dc:"The price version subscriptions migrate to" v:"required"`
+	Mode        int    `json:"mode" dc:"Mode of migration, 1-new price effects at next renewal(default), 2-immediately with proration charged for the rest of current period"`
+	EffectTime  int64  `json:"effectTime" dc:"The utc time migration effects, at least 7 days later as users notified of price change, default 7 days later"`
+}
+type PriceMigrationPreviewRes struct {
+	Report []*bean.PlanPriceMigrationReportItem `json:"report" dc:"Subscriptions affected"`
+}
+
+type PriceMigrationScheduleReq struct {
+	g.Meta      `path:"/price_migration_schedule" tags:"Plan" method:"post" summary:"Schedule Plan Price Migration" dc:"Schedule price migration of subscriptions, affected users notified by email immediately"`
+	PlanId      uint64 `json:"planId" dc:"The Id of plan" v:"required"`
+	FromVersion int    `json:"fromVersion" dc:"The price version subscriptions migrate from" v:"required"`
+	ToVersion   int    `json:"toVersion" dc:"The price version subscriptions migrate to" v:"required"`
+	Mode        int    `json:"mode" dc:"Mode of migration, 1-new price effects at next renewal(default), 2-immediately with proration charged for the rest of current period"`
+	EffectTime  int64  `json:"effectTime" dc:"The utc time migration effects, at least 7 days later as users notified of price change, default 7 days later"`
+}
+type PriceMigrationScheduleRes struct {
+	Migration *bean.PlanPriceMigration             `json:"migration" dc:"Price Migration"`
+	Report    []*bean.PlanPriceMigrationReportItem `json:"report" dc:"Subscriptions affected"`
+}
+
+type PriceMigrationCancelReq struct {
+	g.Meta      `path:"/price_migration_cancel" tags:"Plan" method:"post" summary:"Cancel Plan Price Migration" dc:"Cancel the scheduled price migration"`
+	MigrationId string `json:"migrationId" dc:"The Id of migration" v:"required"`
+}
+type PriceMigrationCancelRes struct {
+}
+
+type PriceMigrationListReq struct {
+	g.Meta `path:"/price_migration_list" tags:"Plan" method:"get,post" summary:"Plan Price Migration List"`
+	PlanId uint64 `json:"planId" dc:"Filter PlanId, Default All"`
+	Status []int  `json:"status" dc:"Filter Status, 10-scheduled, 20-processing, 30-finished, 40-cancelled"`
+	Page   int    `json:"page"  dc:"Page, Start 0" `
+	Count  int    `json:"count"  dc:"Count Of Per Page" `
+}
+type PriceMigrationListRes struct {
+	Migrations []*bean.PlanPriceMigration `json:"migrations" dc:"Price Migration List"`
+	Total      int                        `json:"total" dc:"Total"`
+}
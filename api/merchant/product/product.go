@@ -13,6 +13,7 @@ type NewReq struct {
 	HomeUrl     string                  `json:"homeUrl"     description:"home_url"`                                   // home_url
 	Status      int                     `json:"status"      description:"status，1-active，2-inactive, default active"` // status，1-active，2-inactive, default active
 	Metadata    *map[string]interface{} `json:"metadata" dc:"Metadata，Map"`
+	TaxCode     string                  `json:"taxCode" dc:"Tax code used by sales tax engine, general|digital|exempt, default general"`
 }
 type NewRes struct {
 	Product *bean.Product `json:"product" dc:"Product Object"`
@@ -27,6 +28,7 @@ type EditReq struct {
 	HomeUrl     *string                 `json:"homeUrl"     description:"home_url"`                                   // home_url
 	Status      *int                    `json:"status"      description:"status，1-active，2-inactive, default active"` // status，1-active，2-inactive, default active
 	Metadata    *map[string]interface{} `json:"metadata" dc:"Metadata，Map"`
+	TaxCode     *string                 `json:"taxCode" dc:"Tax code used by sales tax engine, general|digital|exempt"`
 }
 type EditRes struct {
 	Product *bean.Product `json:"product" dc:"Product Object"`
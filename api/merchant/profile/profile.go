@@ -55,6 +55,8 @@ type GetRes struct {
 	QuickBooksCompanyName        string                              `json:"quickBooksCompanyName" description:"QuickBooksCompanyName" `
 	QuickBooksLastSynchronized   string                              `json:"quickBooksLastSynchronized" description:"QuickBooksLastSynchronized" `
 	QuickBooksLastSyncError      string                              `json:"quickBooksLastSyncError" description:"QuickBooksLastSyncError" `
+	XeroTenantName               string                              `json:"xeroTenantName" description:"The xero organisation connected, empty if not connected" `
+	XeroLastSyncError            string                              `json:"xeroLastSyncError" description:"The error of last invoice pushed to xero" `
 	IsOwner                      bool                                `json:"isOwner" description:"Check Member is Owner" `
 	MemberRoles                  []*bean.MerchantRole                `json:"MemberRoles" description:"The member role list'" `
 	CloudFeatureAnalyticsEnabled bool                                `json:"cloudFeatureAnalyticsEnabled" description:"Analytics Feature Enabled For Cloud Version"`
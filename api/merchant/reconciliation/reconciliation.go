@@ -0,0 +1,54 @@
+package reconciliation
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"unibee/api/bean"
+)
+
+type RunReq struct {
+	g.Meta      `path:"/run" tags:"Reconciliation" method:"post" summary:"Run Gateway Reconciliation" dc:"Reconcile the payments created in window and their refunds with the gateway in background, the pending payments and refunds finished at gateway fixed automatically, the other discrepancies reported for review. The gateways reconciled daily for the latest 3 days"`
+	GatewayId   uint64 `json:"gatewayId" dc:"The id of gateway" v:"required"`
+	WindowStart int64  `json:"windowStart" dc:"The utc start time of window, default 3 days before"`
+	WindowEnd   int64  `json:"windowEnd" dc:"The utc end time of window, default now, the window should not exceed 31 days"`
+}
+type RunRes struct {
+	Reconciliation *bean.GatewayReconciliation `json:"reconciliation" dc:"Reconciliation"`
+}
+
+type ListReq struct {
+	g.Meta    `path:"/list" tags:"Reconciliation" method:"get,post" summary:"Gateway Reconciliation List"`
+	GatewayId uint64 `json:"gatewayId" dc:"Filter GatewayId, Default All"`
+	Page      int    `json:"page"  dc:"Page, Start 0" `
+	Count     int    `json:"count"  dc:"Count Of Per Page" `
+}
+type ListRes struct {
+	Reconciliations []*bean.GatewayReconciliation `json:"reconciliations" dc:"Reconciliations"`
+	Total           int                           `json:"total" dc:"Total"`
+}
+
+type DiscrepancyListReq struct {
+	g.Meta           `path:"/discrepancy_list" tags:"Reconciliation" method:"get,post" summary:"Gateway Discrepancy List" dc:"The discrepancy report of reconciliations, one discrepancy kept per gateway object and type. Use batch export task GatewayDiscrepancyExport for CSV/XLSX"`
+	ReconciliationId uint64   `json:"reconciliationId" dc:"Filter ReconciliationId, the discrepancies found again by later run moved to the later one"`
+	GatewayId        uint64   `json:"gatewayId" dc:"Filter GatewayId, Default All"`
+	SourceType       string   `json:"sourceType" dc:"Filter SourceType, payment|refund, Default All"`
+	DiscrepancyTypes []string `json:"discrepancyTypes" dc:"Filter DiscrepancyTypes, missing_local|missing_gateway|amount_drift|status_drift|lookup_failed, Default All"`
+	ResolveStatus    []int    `json:"resolveStatus" dc:"Filter ResolveStatus, 0-open,1-resolved,2-ignored, Default All"`
+	CreateTimeStart  int64    `json:"createTimeStart" dc:"CreateTimeStart"`
+	CreateTimeEnd    int64    `json:"createTimeEnd" dc:"CreateTimeEnd"`
+	Page             int      `json:"page"  dc:"Page, Start 0" `
+	Count            int      `json:"count"  dc:"Count Of Per Page" `
+}
+type DiscrepancyListRes struct {
+	Discrepancies []*bean.GatewayDiscrepancy `json:"discrepancies" dc:"Discrepancies"`
+	Total         int                        `json:"total" dc:"Total"`
+}
+
+type DiscrepancyResolveReq struct {
+	g.Meta        `path:"/discrepancy_resolve" tags:"Reconciliation" method:"post" summary:"Resolve Gateway Discrepancy" dc:"Mark the discrepancy reviewed, the ones reviewed not reopened by later reconciliations"`
+	Id            uint64 `json:"id" dc:"The id of discrepancy" v:"required"`
+	ResolveStatus int    `json:"resolveStatus" dc:"1-resolved,2-ignored" v:"required"`
+	ResolveNote   string `json:"resolveNote" dc:"The note of review"`
+}
+type DiscrepancyResolveRes struct {
+	Discrepancy *bean.GatewayDiscrepancy `json:"discrepancy" dc:"Discrepancy"`
+}
@@ -0,0 +1,52 @@
+package revenue
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"unibee/api/bean"
+)
+
+type MrrReq struct {
+	g.Meta            `path:"/mrr" tags:"Revenue" method:"get,post" summary:"MRR Metrics" dc:"Daily MRR, ARR, ARPU and MRR movements(new, expansion, contraction, churn, reactivation) from revenue snapshots"`
+	StartTime         int64    `json:"startTime" dc:"The utc start time of range, default 30 days before endTime"`
+	EndTime           int64    `json:"endTime" dc:"The utc end time of range, default now"`
+	PlanIds           []uint64 `json:"planIds" dc:"Filter PlanIds, Default All"`
+	Currency          string   `json:"currency" dc:"Filter Currency of subscription, Default All"`
+	ReportingCurrency string   `json:"reportingCurrency" dc:"The currency amounts normalized to using merchant exchange rates, default currency of latest plan"`
+}
+type MrrRes struct {
+	Summary *bean.RevenueMrrSummary `json:"summary" dc:"Summary of range"`
+	Points  []*bean.RevenueMrrPoint `json:"points" dc:"Daily points"`
+}
+
+type ChurnReq struct {
+	g.Meta            `path:"/churn" tags:"Revenue" method:"get,post" summary:"Churn Metrics" dc:"Logo churn, revenue churn and LTV in range"`
+	StartTime         int64    `json:"startTime" dc:"The utc start time of range, default 30 days before endTime"`
+	EndTime           int64    `json:"endTime" dc:"The utc end time of range, default now"`
+	PlanIds           []uint64 `json:"planIds" dc:"Filter PlanIds, Default All"`
+	Currency          string   `json:"currency" dc:"Filter Currency of subscription, Default All"`
+	ReportingCurrency string   `json:"reportingCurrency" dc:"The currency amounts normalized to using merchant exchange rates, default currency of latest plan"`
+}
+type ChurnRes struct {
+	Churn *bean.RevenueChurnMetrics `json:"churn" dc:"Churn Metrics"`
+}
+
+type CohortReq struct {
+	g.Meta            `path:"/cohort" tags:"Revenue" method:"get,post" summary:"Cohort Retention" dc:"Monthly cohorts of subscriptions by first paid time with logo and revenue retention of each month after"`
+	StartTime         int64    `json:"startTime" dc:"The utc time in the first cohort month, default 30 days before endTime"`
+	EndTime           int64    `json:"endTime" dc:"The utc time in the last cohort month, default now"`
+	PlanIds           []uint64 `json:"planIds" dc:"Filter PlanIds, Default All"`
+	Currency          string   `json:"currency" dc:"Filter Currency of subscription, Default All"`
+	ReportingCurrency string   `json:"reportingCurrency" dc:"The currency amounts normalized to using merchant exchange rates, default currency of latest plan"`
+}
+type CohortRes struct {
+	Cohorts               []*bean.RevenueCohort `json:"cohorts" dc:"Cohorts"`
+	UnconvertedCurrencies []string              `json:"unconvertedCurrencies" dc:"Currencies excluded as no exchange rate to reporting currency found"`
+}
+
+type SnapshotRebuildReq struct {
+	g.Meta    `path:"/snapshot_rebuild" tags:"Revenue" method:"post" summary:"Rebuild Revenue Snapshots" dc:"Rebuild the daily revenue snapshots of range in background, snapshots built daily for the previous day automatically"`
+	StartTime int64 `json:"startTime" dc:"The utc start time of range" v:"required"`
+	EndTime   int64 `json:"endTime" dc:"The utc end time of range, default now"`
+}
+type SnapshotRebuildRes struct {
+}
@@ -80,6 +80,23 @@ type TestRunRes struct {
 	ExecutionId uint64 `json:"executionId" dc:"Execution ID"`
 }
 
+// Run — start a manual scenario for a user or subscription
+type RunReq struct {
+	g.Meta         `path:"/run" tags:"Scenario" method:"post" summary:"Run Manual Scenario" dc:"Start an enabled scenario with trigger type 'manual'. Input is checked against the scenario 'inputs' schema. Set waitSeconds to wait for completion synchronously"`
+	ScenarioId     uint64                 `json:"scenarioId" dc:"Scenario ID" v:"required"`
+	UserId         uint64                 `json:"userId" dc:"UserId the scenario runs for (optional)"`
+	SubscriptionId string                 `json:"subscriptionId" dc:"SubscriptionId the scenario runs for (optional), its user is used when userId is empty"`
+	Input          map[string]interface{} `json:"input" dc:"Input variables, validated against the scenario inputs schema"`
+	WaitSeconds    int                    `json:"waitSeconds" dc:"Seconds to wait for the execution to finish, 0 returns immediately, max 30"`
+}
+type RunRes struct {
+	ExecutionId  uint64            `json:"executionId" dc:"Execution ID"`
+	Status       string            `json:"status" dc:"Execution status: running, completed, failed, waiting"`
+	Finished     bool              `json:"finished" dc:"Whether the execution completed or failed within waitSeconds"`
+	ErrorMessage string            `json:"errorMessage" dc:"Error message if failed"`
+	Variables    map[string]string `json:"variables" dc:"Execution variables, returned when finished"`
+}
+
 // ExecutionList — list execution history
 type ExecutionListReq struct {
 	g.Meta     `path:"/execution_list" tags:"Scenario" method:"get" summary:"List Scenario Executions"`
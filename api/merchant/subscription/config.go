@@ -21,6 +21,8 @@ type ConfigUpdateReq struct {
 	TryAutomaticPaymentBeforePeriodEnd *int64                  `json:"tryAutomaticPaymentBeforePeriodEnd" dc:"TryAutomaticPaymentBeforePeriodEnd, Auto-charge Start Before Period End （Time Difference for Auto-Payment Activation Before Period End）"`
 	GatewayVATRule                     []*bean.MerchantVatRule `json:"gatewayVATRule" dc:""`
 	ShowZeroInvoice                    *bool                   `json:"showZeroInvoice" dc:"ShowZeroInvoice, Display Invoices With Zero Amount (Invoice With Zero Amount will hidden in list by default)"`
+	PauseResumeBehavior                *string                 `json:"pauseResumeBehavior" dc:"PauseResumeBehavior, shift|prorate, Resume Of Paused Subscription (shift moves the period end forward by the paused duration, prorate keeps the billing date and charges a prorated period, shift by default)"`
+	DunningPolicy                      *bean.DunningPolicy     `json:"dunningPolicy" dc:"DunningPolicy, Payment Retry Schedule After Period End (retry automatic payment and email user on each attempt, then cancel, suspend or mark unpaid the subscription, policy with empty attempts will disable it)"`
 }
 
 type ConfigUpdateRes struct {
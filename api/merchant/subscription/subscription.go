@@ -87,6 +87,27 @@ type CancelLastCancelAtPeriodEndReq struct {
 type CancelLastCancelAtPeriodEndRes struct {
 }
 
+type PauseReq struct {
+	g.Meta         `path:"/pause" tags:"Subscription" method:"post" summary:"Pause Subscription" dc:"Pause an active subscription immediately or at period end, the subscription will turn to 'suspended', no invoice will generate and no metric event will accept while paused"`
+	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId, id of subscription, either SubscriptionId or UserId needed, The only one active subscription of userId will effect"`
+	UserId         uint64 `json:"userId" dc:"UserId, either SubscriptionId or UserId needed, The only one active subscription will effect if userId provide instead of subscriptionId"`
+	ProductId      int64  `json:"productId" dc:"Id of product" dc:"default product will use if productId not specified and subscriptionId is blank"`
+	AtPeriodEnd    bool   `json:"atPeriodEnd" dc:"AtPeriodEnd, pause at period end instead of immediately, the flag 'pauseAtPeriodEnd' of subscription will be enabled"`
+	AutoResumeTime int64  `json:"autoResumeTime" dc:"AutoResumeTime, utc time to resume subscription automatically, resume manually if not specified"`
+	Reason         string `json:"reason" dc:"Reason"`
+}
+type PauseRes struct {
+}
+
+type ResumeReq struct {
+	g.Meta         `path:"/resume" tags:"Subscription" method:"post" summary:"Resume Subscription" dc:"Resume a paused subscription, the period is shifted by paused duration or prorated to the billing anchor depends on merchant's PauseResumeBehavior config. If subscription's flag 'pauseAtPeriodEnd' is enabled, this action will disable it"`
+	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId, id of subscription, either SubscriptionId or UserId needed, The latest subscription of userId will effect"`
+	UserId         uint64 `json:"userId" dc:"UserId, either SubscriptionId or UserId needed, The latest subscription will effect if userId provide instead of subscriptionId"`
+	ProductId      int64  `json:"productId" dc:"Id of product" dc:"default product will use if productId not specified and subscriptionId is blank"`
+}
+type ResumeRes struct {
+}
+
 type ChangeGatewayReq struct {
 	g.Meta          `path:"/change_gateway" tags:"Subscription" method:"post" summary:"Change Subscription Gateway" `
 	SubscriptionId  string `json:"subscriptionId" dc:"SubscriptionId" v:"required"`
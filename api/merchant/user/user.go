@@ -92,34 +92,37 @@ type SearchRes struct {
 }
 
 type UpdateReq struct {
-	g.Meta             `path:"/update" tags:"User" method:"post" summary:"Update User Profile"`
-	UserId             *uint64                 `json:"userId" dc:"The id of user, either Email or UserId needed"`
-	Email              *string                 `json:"email" dc:"The email of user, either Email or UserId needed"`
-	FirstName          *string                 `json:"firstName" dc:"First name"`
-	LastName           *string                 `json:"lastName" dc:"Last Name"`
-	Address            *string                 `json:"address" dc:"Billing Address"`
-	CompanyName        *string                 `json:"companyName" dc:"Company Name"`
-	VATNumber          *string                 `json:"vATNumber" dc:"VAT Number"`
-	RegistrationNumber *string                 `json:"registrationNumber" dc:"RegistrationNumber"`
-	Phone              *string                 `json:"phone" dc:"Phone"`
-	Telegram           *string                 `json:"telegram" dc:"Telegram"`
-	WhatsApp           *string                 `json:"whatsApp" dc:"WhatsApp"`
-	WeChat             *string                 `json:"weChat" dc:"WeChat"`
-	LinkedIn           *string                 `json:"LinkedIn" dc:"LinkedIn"`
-	Facebook           *string                 `json:"facebook" dc:"Facebook"`
-	TikTok             *string                 `json:"tiktok" dc:"Tiktok"`
-	OtherSocialInfo    *string                 `json:"otherSocialInfo" dc:"Other Social Info"`
-	CountryCode        *string                 `json:"countryCode" dc:"Country Code"`
-	CountryName        *string                 `json:"countryName" dc:"Country Name"`
-	Type               *int64                  `json:"type" dc:"User type, 1-Individual|2-Business"`
-	GatewayId          *uint64                 `json:"gatewayId" dc:"GatewayId"`
-	GatewayPaymentType *string                 `json:"gatewayPaymentType" dc:"Gateway Payment Type"`
-	PaymentMethodId    *string                 `json:"paymentMethodId" dc:"PaymentMethodId of gateway, available for card type gateway, payment automatic will enable if set" `
-	City               *string                 `json:"city" dc:"city"`
-	ZipCode            *string                 `json:"zipCode" dc:"zip_code"`
-	Language           *string                 `json:"language" dc:"User Language, en|ru|cn|vi|bp"`
-	ExternalUserId     *string                 `json:"externalUserId" dc:"ExternalUserId"`
-	Metadata           *map[string]interface{} `json:"metadata" dc:"Metadata，Map"`
+	g.Meta                 `path:"/update" tags:"User" method:"post" summary:"Update User Profile"`
+	UserId                 *uint64                 `json:"userId" dc:"The id of user, either Email or UserId needed"`
+	Email                  *string                 `json:"email" dc:"The email of user, either Email or UserId needed"`
+	FirstName              *string                 `json:"firstName" dc:"First name"`
+	LastName               *string                 `json:"lastName" dc:"Last Name"`
+	Address                *string                 `json:"address" dc:"Billing Address"`
+	CompanyName            *string                 `json:"companyName" dc:"Company Name"`
+	VATNumber              *string                 `json:"vATNumber" dc:"VAT Number"`
+	RegistrationNumber     *string                 `json:"registrationNumber" dc:"RegistrationNumber"`
+	Phone                  *string                 `json:"phone" dc:"Phone"`
+	Telegram               *string                 `json:"telegram" dc:"Telegram"`
+	WhatsApp               *string                 `json:"whatsApp" dc:"WhatsApp"`
+	WeChat                 *string                 `json:"weChat" dc:"WeChat"`
+	LinkedIn               *string                 `json:"LinkedIn" dc:"LinkedIn"`
+	Facebook               *string                 `json:"facebook" dc:"Facebook"`
+	TikTok                 *string                 `json:"tiktok" dc:"Tiktok"`
+	OtherSocialInfo        *string                 `json:"otherSocialInfo" dc:"Other Social Info"`
+	CountryCode            *string                 `json:"countryCode" dc:"Country Code"`
+	CountryName            *string                 `json:"countryName" dc:"Country Name"`
+	Type                   *int64                  `json:"type" dc:"User type, 1-Individual|2-Business"`
+	GatewayId              *uint64                 `json:"gatewayId" dc:"GatewayId"`
+	GatewayPaymentType     *string                 `json:"gatewayPaymentType" dc:"Gateway Payment Type"`
+	PaymentMethodId        *string                 `json:"paymentMethodId" dc:"PaymentMethodId of gateway, available for card type gateway, payment automatic will enable if set" `
+	City                   *string                 `json:"city" dc:"city"`
+	ZipCode                *string                 `json:"zipCode" dc:"zip_code"`
+	State                  *string                 `json:"state" dc:"State or province code of billing address, em. CA, used by sales tax"`
+	TaxExempt              *int                    `json:"taxExempt" dc:"0-taxable, 1-tax exempt, taxExemptCertificateId required for exempt"`
+	TaxExemptCertificateId *string                 `json:"taxExemptCertificateId" dc:"Tax exemption certificate id"`
+	Language               *string                 `json:"language" dc:"User Language, en|ru|cn|vi|bp"`
+	ExternalUserId         *string                 `json:"externalUserId" dc:"ExternalUserId"`
+	Metadata               *map[string]interface{} `json:"metadata" dc:"Metadata，Map"`
 }
 
 type UpdateRes struct {
@@ -174,3 +177,21 @@ type AdminNoteListReq struct {
 type AdminNoteListRes struct {
 	NoteLists []*detail.UserAdminNoteDetail `json:"noteLists"   description:""`
 }
+
+type DataExportReq struct {
+	g.Meta `path:"/data_export" tags:"User" method:"post" summary:"Export User Data" dc:"Build a zip archive of all data held about the user, includes profile, subscriptions, invoices, payments, refunds, credit transactions, email history and metric events"`
+	UserId uint64 `json:"userId" dc:"The id of user" v:"required"`
+}
+
+type DataExportRes struct {
+	FileName    string `json:"fileName" dc:"The file name of archive"`
+	DownloadUrl string `json:"downloadUrl" dc:"The signed download url of archive, expires in one hour by default, the archive deleted after expired"`
+}
+
+type AnonymizeReq struct {
+	g.Meta `path:"/anonymize" tags:"User" method:"post" summary:"Anonymize User" dc:"Scrub the personal data of user across profile, subscriptions, invoices, payments, refunds, credit transactions, email history and metric events, the amounts and invoice numbers are kept. User should have no active subscription, can not be undone"`
+	UserId uint64 `json:"userId" dc:"The id of user" v:"required"`
+}
+
+type AnonymizeRes struct {
+}
@@ -71,3 +71,35 @@ type NumberValidateHistoryDeactivateReq struct {
 }
 type NumberValidateHistoryDeactivateRes struct {
 }
+
+type SalesTaxConfigReq struct {
+	g.Meta `path:"/sales_tax_config" tags:"Vat Gateway" method:"get" summary:"Get Sales Tax Config"`
+}
+type SalesTaxConfigRes struct {
+	Config *bean.SalesTaxConfig `json:"config" dc:"Sales Tax Config"`
+}
+
+type SalesTaxConfigSetupReq struct {
+	g.Meta       `path:"/sales_tax_config_setup" tags:"Vat Gateway" method:"post" summary:"Setup Sales Tax Config" dc:"Setup sales tax engine, tax of the countries supported by provider (US and CA for rate_table) resolved by billing address state and zip and product tax code, others keep vat rates. Vat gateway setup required"`
+	Enable       bool                     `json:"enable" dc:"true-enable sales tax engine"`
+	Provider     string                   `json:"provider" dc:"Sales tax provider, rate_table, default rate_table"`
+	NexusRegions []string                 `json:"nexusRegions" dc:"Regions registered to collect tax, country code or country-state code, em. [\"US-CA\",\"CA\"], no region collected if empty"`
+	Rules        []*bean.SalesTaxRateRule `json:"rules" dc:"Custom rules, the state rule replaces the build-in state rate, the zip prefix rule adds a local tax"`
+}
+type SalesTaxConfigSetupRes struct {
+	Config *bean.SalesTaxConfig `json:"config" dc:"Sales Tax Config"`
+}
+
+type SalesTaxPreviewReq struct {
+	g.Meta        `path:"/sales_tax_preview" tags:"Vat Gateway" method:"post" summary:"Preview Sales Tax" dc:"Preview the tax lines resolved by current sales tax config for the address and tax code"`
+	CountryCode   string `json:"countryCode" dc:"Country Code" v:"required"`
+	State         string `json:"state" dc:"State or province code" v:"required"`
+	ZipCode       string `json:"zipCode" dc:"Zip Code"`
+	TaxCode       string `json:"taxCode" dc:"Tax code, general|digital|exempt, default general"`
+	TaxableAmount int64  `json:"taxableAmount" dc:"Taxable amount, cent, default 10000"`
+}
+type SalesTaxPreviewRes struct {
+	TaxPercentage int64                  `json:"taxPercentage" dc:"TaxPercentage，1000 = 10%"`
+	InNexus       bool                   `json:"inNexus" dc:"Whether the region in nexus regions, tax is 0 outside nexus"`
+	TaxLines      []*bean.InvoiceTaxLine `json:"taxLines" dc:"Tax lines by jurisdiction"`
+}
@@ -76,3 +76,67 @@ type DeleteEndpointReq struct {
 
 type DeleteEndpointRes struct {
 }
+
+type EndpointSecretReq struct {
+	g.Meta     `path:"/endpoint_secret" tags:"Webhook" method:"get" summary:"Get Webhook Endpoint Secret" dc:"Get the secret signing webhook of endpoint, the Webhook-Signature header is t=<timestamp>,v1=<base64 hmac-sha256 of '<timestamp>.<body>'>, the secret is generated when endpoint created, X-Signature signed by api key is deprecated and kept for compatibility only"`
+	EndpointId uint64 `json:"endpointId" dc:"EndpointId" v:"required"`
+}
+
+type EndpointSecretRes struct {
+	EndpointSecret *bean.MerchantWebhookEndpointSecret `json:"endpointSecret" dc:"EndpointSecret"`
+}
+
+type RotateEndpointSecretReq struct {
+	g.Meta         `path:"/rotate_endpoint_secret" tags:"Webhook" method:"post" summary:"Rotate Webhook Endpoint Secret" dc:"Generate a new secret for endpoint, the first rotation opts endpoint in Webhook-Signature, webhook carries signatures of both new and previous secret until the previous one expires"`
+	EndpointId     uint64 `json:"endpointId" dc:"EndpointId" v:"required"`
+	OverlapSeconds int64  `json:"overlapSeconds" dc:"Seconds the previous secret stays valid, default 86400, max 604800"`
+}
+
+type RotateEndpointSecretRes struct {
+	EndpointSecret *bean.MerchantWebhookEndpointSecret `json:"endpointSecret" dc:"EndpointSecret"`
+}
+
+type EndpointLogAttemptListReq struct {
+	g.Meta `path:"/endpoint_log_attempt_list" tags:"Webhook" method:"get" summary:"Get Webhook Endpoint Log Attempt List" dc:"Get the delivery attempts of webhook log"`
+	LogId  uint64 `json:"logId" dc:"LogId" v:"required"`
+}
+
+type EndpointLogAttemptListRes struct {
+	AttemptList []*bean.MerchantWebhookAttempt `json:"attemptList" dc:"AttemptList"`
+}
+
+type EnableEndpointReq struct {
+	g.Meta     `path:"/enable_endpoint" tags:"Webhook" method:"post" summary:"Enable Webhook Endpoint" dc:"Enable the endpoint disabled after consecutive failed deliveries"`
+	EndpointId uint64 `json:"endpointId" dc:"EndpointId" v:"required"`
+}
+
+type EnableEndpointRes struct {
+}
+
+type ReplayFailedReq struct {
+	g.Meta     `path:"/replay_failed" tags:"Webhook" method:"post" summary:"Replay Failed Webhooks" dc:"Redeliver all failed or retrying webhooks of endpoint created within the time range, attempts of retry policy restarted"`
+	EndpointId uint64 `json:"endpointId" dc:"EndpointId" v:"required"`
+	StartTime  int64  `json:"startTime" dc:"StartTime, utc seconds" v:"required"`
+	EndTime    int64  `json:"endTime" dc:"EndTime, utc seconds" v:"required"`
+}
+
+type ReplayFailedRes struct {
+	Count int64 `json:"count" dc:"Count of webhooks scheduled to redeliver"`
+}
+
+type RetryPolicyReq struct {
+	g.Meta `path:"/retry_policy" tags:"Webhook" method:"get" summary:"Get Webhook Retry Policy"`
+}
+
+type RetryPolicyRes struct {
+	RetryPolicy *bean.WebhookRetryPolicy `json:"retryPolicy" dc:"RetryPolicy"`
+}
+
+type RetryPolicyUpdateReq struct {
+	g.Meta      `path:"/retry_policy/update" tags:"Webhook" method:"post" summary:"Update Webhook Retry Policy" dc:"Failed webhook is redelivered after initialIntervalSeconds*backoffMultiplier^(n-1) seconds, limited by maxIntervalSeconds, until maxAttempts reached"`
+	RetryPolicy *bean.WebhookRetryPolicy `json:"retryPolicy" dc:"RetryPolicy" v:"required"`
+}
+
+type RetryPolicyUpdateRes struct {
+	RetryPolicy *bean.WebhookRetryPolicy `json:"retryPolicy" dc:"RetryPolicy"`
+}
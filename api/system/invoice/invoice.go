@@ -5,14 +5,14 @@ import (
 )
 
 type BulkChannelSyncReq struct {
-	g.Meta     `path:"/invoice_bulk_sync" tags:"System-Admin" method:"post" summary:"Admin Bulk Sync Invoice From Gateway (Experimental）"`
+	g.Meta     `path:"/invoice_bulk_sync" tags:"System-Admin" method:"post" summary:"Admin Bulk Sync Invoice From Gateway (Experimental）" description:"Reconcile the payments of the latest 31 days of merchant gateways in background, the discrepancies reported in merchant reconciliation"`
 	MerchantId string `json:"merchantId" dc:"merchantId" v:"required#Require merchantId"`
 }
 type BulkChannelSyncRes struct {
 }
 
 type ChannelSyncReq struct {
-	g.Meta     `path:"/invoice_sync" tags:"System-Admin" method:"post" summary:"Admin Sync Invoice From Gateway (Experimental）"`
+	g.Meta     `path:"/invoice_sync" tags:"System-Admin" method:"post" summary:"Admin Sync Invoice From Gateway (Experimental）" description:"Reconcile the payment and refunds of invoice with gateway, the pending ones finished at gateway fixed"`
 	MerchantId string `json:"merchantId" dc:"merchantId" v:"required#Require merchantId"`
 	InvoiceId  string `json:"invoiceId" dc:"invoiceId" v:"required#Require invoiceId"`
 }
@@ -70,3 +70,12 @@ type ChangeGatewayReq struct {
 }
 type ChangeGatewayRes struct {
 }
+
+type DataExportReq struct {
+	g.Meta `path:"/data_export" tags:"User-Profile" method:"post" summary:"Export My Data" dc:"Build a zip archive of all data held about the user"`
+}
+
+type DataExportRes struct {
+	FileName    string `json:"fileName" dc:"The file name of archive"`
+	DownloadUrl string `json:"downloadUrl" dc:"The signed download url of archive, expires in one hour by default, the archive deleted after expired"`
+}
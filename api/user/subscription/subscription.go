@@ -214,8 +214,18 @@ type SuspendReq struct {
 type SuspendRes struct {
 }
 
+type PauseReq struct {
+	g.Meta         `path:"/pause" tags:"User-Subscription" method:"post" summary:"User Edit Subscription-Pause"`
+	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId" v:"required"`
+	AtPeriodEnd    bool   `json:"atPeriodEnd" dc:"AtPeriodEnd, pause at period end instead of immediately"`
+	AutoResumeTime int64  `json:"autoResumeTime" dc:"AutoResumeTime, utc time to resume subscription automatically, resume manually if not specified"`
+	Reason         string `json:"reason" dc:"Reason"`
+}
+type PauseRes struct {
+}
+
 type ResumeReq struct {
-	g.Meta         `path:"/resume" tags:"User-Subscription" method:"post" summary:"User Edit Subscription-Resume"  deprecated:"true"`
+	g.Meta         `path:"/resume" tags:"User-Subscription" method:"post" summary:"User Edit Subscription-Resume" dc:"Resume the subscription paused by user or merchant, the suspension by dunning or lost dispute only resumed by merchant"`
 	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId" v:"required"`
 }
 type ResumeRes struct {
@@ -28,6 +28,7 @@ import (
 	"unibee/internal/logic/gateway/webhook"
 	"unibee/internal/logic/member"
 	merchant2 "unibee/internal/logic/merchant"
+	merchantWebhook "unibee/internal/logic/webhook"
 	"unibee/internal/logic/scenario"
 	_ "unibee/internal/logic/scenario/actions"
 	"unibee/internal/query"
@@ -234,6 +235,26 @@ var (
 						merchant.NewCredit(),
 					)
 				})
+				group.Group("/ledger", func(group *ghttp.RouterGroup) {
+					group.Bind(
+						merchant.NewLedger(),
+					)
+				})
+				group.Group("/reconciliation", func(group *ghttp.RouterGroup) {
+					group.Bind(
+						merchant.NewReconciliation(),
+					)
+				})
+				group.Group("/apikey", func(group *ghttp.RouterGroup) {
+					group.Bind(
+						merchant.NewApikey(),
+					)
+				})
+				group.Group("/revenue", func(group *ghttp.RouterGroup) {
+					group.Bind(
+						merchant.NewRevenue(),
+					)
+				})
 				group.Group("/task", func(group *ghttp.RouterGroup) {
 					group.Bind(
 						merchant.NewTask(),
@@ -443,11 +464,13 @@ var (
 			s.BindHandler("GET:/in/{invoiceId}", invoice.LinkEntry)
 			s.BindHandler("GET:/in/pdf/{invoiceId}", invoice.LinkPdfEntry)
 			s.BindHandler("GET:/oss/file/{filename}", oss.FileEntry)
+			s.BindHandler("GET:/oss/signed/*key", oss.SignedFileEntry)
 			s.BindHandler("GET:/export/{taskId}", export.LinkExportEntry)
 			s.BindHandler("GET:/import/template/{task}", _import.LinkImportTemplateEntry)
 			s.BindHandler("GET:/pay/{paymentId}", payment.LinkEntry)
 			// Integration Link
 			s.BindHandler("GET:/integrate/quickbooks/auth_back", integrations.QuickBooksAuthorizationEntry)
+			s.BindHandler("GET:/integrate/xero/auth_back", integrations.XeroAuthorizationEntry)
 			// Gateway Payment Redirect
 			s.BindHandler("GET:/payment/redirect/{gatewayId}/forward", gateway_webhook_entry.GatewayRedirectEntrance)
 			// Gateway Payment Method Redirect
@@ -500,6 +523,7 @@ var (
 				//SetupAllWebhooks
 				if !config.GetConfigInstance().IsLocal() {
 					webhook.SetupAllWebhooksBackground()
+					merchantWebhook.BackfillMerchantWebhookEndpointSecretsBackground()
 				}
 			}
 
@@ -530,9 +554,10 @@ var (
 				}
 			}
 
-			// Start scenario engine: bot polling + delayed task worker
+			// Start scenario engine: bot polling + delayed task worker + schedule worker
 			{
 				scenario.StartDelayedTaskWorker(ctx)
+				scenario.StartScheduleWorker(ctx)
 				scenario.InitAllBotPolling(ctx)
 			}
 
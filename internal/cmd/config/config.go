@@ -13,6 +13,7 @@ type Config struct {
 	Mode        string      `json:"mode" yaml:"mode"`
 	RedisConfig RedisConfig `json:"redis" yaml:"redis"`
 	MinioConfig MinioConfig `json:"minio" yaml:"minio"`
+	Storage     Storage     `json:"storage" yaml:"storage"`
 	Server      Server      `json:"server" yaml:"server"`
 	Auth        Auth        `json:"auth" yaml:"auth"`
 	OAuth       OAuth       `json:"oauth" yaml:"oauth"`
@@ -28,6 +29,7 @@ type Server struct {
 	JwtKey                      string `json:"jwtKey" yaml:"jwtKey"`
 	HostedPagePath              string `json:"hostedPagePath" yaml:"hostedPagePath"`
 	DisableHostedPaymentChecker bool   `json:"disableHostedPaymentChecker" yaml:"disableHostedPaymentChecker"`
+	TrustedProxies              string `json:"trustedProxies" yaml:"trustedProxies"` // ip or cidr of the reverse proxies separated by comma, X-Forwarded-For only trusted from them
 }
 
 func (s *Server) GetDomainScheme() string {
@@ -82,6 +84,16 @@ type MinioConfig struct {
 	Domain     string `json:"domain" yaml:"domain"`
 }
 
+// Storage chooses where the uploaded files saved, database by default, s3 backend uses the endpoint, bucket and keys of MinioConfig
+type Storage struct {
+	Type            string `json:"type" yaml:"type"`                       // database|local|s3
+	LocalPath       string `json:"localPath" yaml:"localPath"`             // root directory of local backend
+	Region          string `json:"region" yaml:"region"`                   // region of s3 backend, default us-east-1
+	VirtualHosted   bool   `json:"virtualHosted" yaml:"virtualHosted"`     // s3 backend addresses bucket by host instead of path
+	SignedUrlExpire int64  `json:"signedUrlExpire" yaml:"signedUrlExpire"` // seconds, default 3600
+	SignSecret      string `json:"signSecret" yaml:"signSecret"`           // secret signing the url of local backend, signed url disabled if blank
+}
+
 type VatConfig struct {
 	NumberUnExemptionCountryCodes string `json:"numberUnExemptionCountryCodes" yaml:"numberUnExemptionCountryCodes"`
 }
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"context"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcmd"
+	oss2 "unibee/internal/logic/oss"
+)
+
+var (
+	OssMigrate = gcmd.Command{
+		Name:  "oss_migrate",
+		Usage: "oss_migrate",
+		Brief: "move the uploaded files saved in database to the storage of config",
+		Func: func(ctx context.Context, parser *gcmd.Parser) (err error) {
+			count, err := oss2.MigrateFilesToStorage(ctx, 100)
+			g.Log().Infof(ctx, "oss_migrate migrated:%d", count)
+			return err
+		},
+	}
+)
+
+func init() {
+	_ = Main.AddCommand(&OssMigrate)
+}
@@ -19,6 +19,7 @@ var (
 	TopicRefundChecker                    = redismq.MQTopicEnum{Topic: "unibee_refund", Tag: "refund_checker", Description: "refund status checker"}
 	TopicRefundSuccess                    = redismq.MQTopicEnum{Topic: "unibee_refund", Tag: "refund_success", Description: "refund success"}
 	TopicRefundFailed                     = redismq.MQTopicEnum{Topic: "unibee_refund", Tag: "refund_failed", Description: "refund success"}
+	TopicDisputeLost                      = redismq.MQTopicEnum{Topic: "unibee_dispute", Tag: "dispute_lost", Description: "dispute lost"}
 	TopicSubscriptionCancel               = redismq.MQTopicEnum{Topic: "unibee_subscription", Tag: "subscription_cancelled", Description: "subscription cancelled"}
 	TopicSubscriptionExpire               = redismq.MQTopicEnum{Topic: "unibee_subscription", Tag: "subscription_expired", Description: "subscription expired"}
 	TopicSubscriptionFailed               = redismq.MQTopicEnum{Topic: "unibee_subscription", Tag: "subscription_failed", Description: "subscription failed"}
@@ -30,6 +31,8 @@ var (
 	TopicSubscriptionPaymentSuccess       = redismq.MQTopicEnum{Topic: "unibee_subscription", Tag: "subscription_payment_success", Description: "subscription payment success"}
 	TopicSubscriptionAutoRenewSuccess     = redismq.MQTopicEnum{Topic: "unibee_subscription", Tag: "subscription_auto_renew_success", Description: "subscription auto renew success"}
 	TopicSubscriptionAutoRenewFailure     = redismq.MQTopicEnum{Topic: "unibee_subscription", Tag: "subscription_auto_renew_failure", Description: "subscription auto renew failure"}
+	TopicSubscriptionPaused               = redismq.MQTopicEnum{Topic: "unibee_subscription", Tag: "subscription_paused", Description: "subscription paused"}
+	TopicSubscriptionResumed              = redismq.MQTopicEnum{Topic: "unibee_subscription", Tag: "subscription_resumed", Description: "subscription resumed"}
 	TopicMerchantWebhook                  = redismq.MQTopicEnum{Topic: "unibee_merchant_webhook", Tag: "webhook", Description: "merchant webhook"}
 	TopicInternalWebhook                  = redismq.MQTopicEnum{Topic: "unibee_internal_webhook", Tag: "webhook", Description: "internal webhook"}
 	TopicInvoiceCreated                   = redismq.MQTopicEnum{Topic: "unibee_invoice", Tag: "invoice_created", Description: "invoice created"}
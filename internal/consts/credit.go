@@ -5,6 +5,18 @@ const (
 	CreditAccountTypePromo = 2
 )
 
+const (
+	CreditGrantActive  = 1
+	CreditGrantUsedUp  = 2
+	CreditGrantExpired = 3
+)
+
+const (
+	CreditGrantSourceRecharge = "recharge"
+	CreditGrantSourceAdmin    = "admin"
+	CreditGrantSourceRefund   = "refund"
+)
+
 const (
 	CreditRechargeCreated = 10
 	CreditRechargeSuccess = 20
@@ -14,7 +26,7 @@ const (
 type TransactionTypeEnum int
 
 const (
-	// Transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out
+	// Transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out，8-expired
 	CreditTransactionRechargeIncome       = 1
 	CreditTransactionPayout               = 2
 	CreditTransactionRefundIncome         = 3
@@ -22,6 +34,7 @@ const (
 	CreditTransactionWithdrawFailedIncome = 5
 	CreditTransactionAdminChange          = 6
 	CreditTransactionRechargeRefundOut    = 7
+	CreditTransactionExpired              = 8
 )
 
 func (transactionType TransactionTypeEnum) Description() string {
@@ -38,6 +51,8 @@ func (transactionType TransactionTypeEnum) Description() string {
 		return "WithdrawFailedIncome"
 	case CreditTransactionRechargeRefundOut:
 		return "RechargeRefundOut"
+	case CreditTransactionExpired:
+		return "Expired"
 	default:
 		return "AdminChange"
 	}
@@ -57,6 +72,8 @@ func (transactionType TransactionTypeEnum) ExportDescription(amount int64) strin
 		return "WithdrawFailedIncome"
 	case CreditTransactionRechargeRefundOut:
 		return "RechargeRefundOut"
+	case CreditTransactionExpired:
+		return "Expired"
 	default:
 		if amount > 0 {
 			return "Added by admin"
@@ -80,6 +97,8 @@ func CreditTransactionTypeToEnum(transactionType int) TransactionTypeEnum {
 		return CreditTransactionWithdrawFailedIncome
 	case CreditTransactionRechargeRefundOut:
 		return CreditTransactionRechargeRefundOut
+	case CreditTransactionExpired:
+		return CreditTransactionExpired
 	default:
 		return CreditTransactionAdminChange
 	}
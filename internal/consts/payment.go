@@ -36,15 +36,16 @@ func (action PaymentStatusEnum) Description() string {
 type RefundStatusEnum int
 
 const (
-	RefundCreated       = 10
-	RefundSuccess       = 20
-	RefundFailed        = 30
-	RefundCancelled     = 40
-	RefundReverse       = 50
-	RefundTypeGateway   = 1
-	RefundTypeMarked    = 2
-	TimelineTypePayment = 0
-	TimelineTypeRefund  = 1
+	RefundCreated              = 10
+	RefundSuccess              = 20
+	RefundFailed               = 30
+	RefundCancelled            = 40
+	RefundReverse              = 50
+	RefundTypeGateway          = 1
+	RefundTypeMarked           = 2
+	TimelineTypePayment        = 0
+	TimelineTypeRefund         = 1
+	TimelineTypeGatewayAttempt = 2
 )
 
 func (action RefundStatusEnum) Description() string {
@@ -63,3 +64,27 @@ func (action RefundStatusEnum) Description() string {
 		return "REFUND_CREATED"
 	}
 }
+
+type DisputeStatusEnum int
+
+const (
+	DisputeOpened         = 10
+	DisputeEvidenceNeeded = 20
+	DisputeWon            = 30
+	DisputeLost           = 40
+)
+
+func (status DisputeStatusEnum) Description() string {
+	switch status {
+	case DisputeOpened:
+		return "DISPUTE_OPENED"
+	case DisputeEvidenceNeeded:
+		return "DISPUTE_EVIDENCE_NEEDED"
+	case DisputeWon:
+		return "DISPUTE_WON"
+	case DisputeLost:
+		return "DISPUTE_LOST"
+	default:
+		return "DISPUTE_OPENED"
+	}
+}
@@ -112,3 +112,45 @@ func (status GatewayPlanStatusEnum) Description() string {
 		return "GatewayPlanStatusInit"
 	}
 }
+
+type PlanPriceMigrationMode int
+
+const (
+	PlanPriceMigrationNextRenewal = 1
+	PlanPriceMigrationImmediate   = 2
+)
+
+func (mode PlanPriceMigrationMode) Description() string {
+	switch mode {
+	case PlanPriceMigrationNextRenewal:
+		return "NextRenewal"
+	case PlanPriceMigrationImmediate:
+		return "Immediate"
+	default:
+		return "NextRenewal"
+	}
+}
+
+type PlanPriceMigrationStatusEnum int
+
+const (
+	PlanPriceMigrationScheduled  = 10
+	PlanPriceMigrationProcessing = 20
+	PlanPriceMigrationFinished   = 30
+	PlanPriceMigrationCancelled  = 40
+)
+
+func (status PlanPriceMigrationStatusEnum) Description() string {
+	switch status {
+	case PlanPriceMigrationScheduled:
+		return "Scheduled"
+	case PlanPriceMigrationProcessing:
+		return "Processing"
+	case PlanPriceMigrationFinished:
+		return "Finished"
+	case PlanPriceMigrationCancelled:
+		return "Cancelled"
+	default:
+		return "Scheduled"
+	}
+}
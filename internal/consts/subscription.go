@@ -6,6 +6,13 @@ const (
 	SubPendingTimeout                   = 3 * 24 * 60 * 60
 )
 
+// the source of subscription pause, the system suspensions only lifted by merchant
+const (
+	SubPauseSourceManual  = "manual"
+	SubPauseSourceDunning = "dunning"
+	SubPauseSourceDispute = "dispute"
+)
+
 type SubscriptionStatusEnum int
 
 const (
@@ -84,4 +91,5 @@ const (
 	SubTimeLineStatusCancelled  = 3
 	SubTimeLineStatusExpired    = 4
 	SubTimeLineStatusFailed     = 5
+	SubTimeLineStatusPaused     = 6
 )
@@ -9,6 +9,7 @@ import (
 	"unibee/internal/consts"
 	"unibee/internal/consumer/webhook/event"
 	"unibee/internal/consumer/webhook/invoice"
+	"unibee/internal/logic/analysis/ledger"
 	discount2 "unibee/internal/logic/invoice/discount"
 	"unibee/internal/query"
 	"unibee/utility"
@@ -31,6 +32,10 @@ func (t InvoiceFailedListener) Consume(ctx context.Context, message *redismq.Mes
 	g.Log().Infof(ctx, "InvoiceFailedListener Receive Message:%s", utility.MarshalToJsonString(message))
 	one := query.GetInvoiceByInvoiceId(ctx, message.Body)
 	if one != nil {
+		if err := ledger.RecordInvoiceVoided(ctx, one); err != nil {
+			g.Log().Errorf(ctx, "InvoiceFailedListener RecordInvoiceVoided invoiceId:%s error:%s", one.InvoiceId, err.Error())
+			return redismq.ReconsumeLater
+		}
 		one.Status = consts.InvoiceStatusFailed
 		invoice.SendMerchantInvoiceWebhookBackground(one, event.UNIBEE_WEBHOOK_EVENT_INVOICE_FAILED, message.CustomData)
 		err := discount2.InvoiceRollbackAllDiscountsFromInvoice(ctx, one.InvoiceId)
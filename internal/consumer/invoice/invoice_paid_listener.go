@@ -10,8 +10,11 @@ import (
 	"unibee/internal/consts"
 	"unibee/internal/consumer/webhook/event"
 	"unibee/internal/consumer/webhook/invoice"
+	"unibee/internal/logic/analysis/ledger"
 	"unibee/internal/logic/analysis/quickbooks"
+	"unibee/internal/logic/analysis/xero"
 	"unibee/internal/logic/discount"
+	"unibee/internal/logic/invoice/numbering"
 	"unibee/internal/logic/metric_event"
 	"unibee/internal/logic/subscription/service/next"
 	"unibee/internal/query"
@@ -35,10 +38,16 @@ func (t InvoicePaidListener) Consume(ctx context.Context, message *redismq.Messa
 	g.Log().Debugf(ctx, "InvoicePaidListener Receive Message:%s", utility.MarshalToJsonString(message))
 	one := query.GetInvoiceByInvoiceId(ctx, message.Body)
 	if one != nil {
+		// journals written before other effects, the message reconsumed when failed
+		if err := ledger.RecordInvoicePaid(ctx, one); err != nil {
+			g.Log().Errorf(ctx, "InvoicePaidListener RecordInvoicePaid invoiceId:%s error:%s", one.InvoiceId, err.Error())
+			return redismq.ReconsumeLater
+		}
 		if len(one.DiscountCode) > 0 {
 			discount.UpdateUserDiscountPaymentIdWhenInvoicePaid(ctx, one.InvoiceId, one.PaymentId)
 		}
 		one.Status = consts.InvoiceStatusPaid
+		_, _ = numbering.AssignInvoiceNumber(ctx, one)
 		go func() {
 			time.Sleep(300 * time.Millisecond)
 			invoice.SendMerchantInvoiceWebhookBackground(one, event.UNIBEE_WEBHOOK_EVENT_INVOICE_PAID, message.CustomData)
@@ -48,6 +57,7 @@ func (t InvoicePaidListener) Consume(ctx context.Context, message *redismq.Messa
 			next.ClearSubscriptionNextInvoiceData(ctx, one.SubscriptionId, one.InvoiceId)
 		}
 		quickbooks.UploadPaidInvoice(ctx, one.InvoiceId)
+		xero.UploadPaidInvoice(ctx, one.InvoiceId)
 	}
 	return redismq.CommitMessage
 }
@@ -9,6 +9,8 @@ import (
 	"unibee/internal/consts"
 	"unibee/internal/consumer/webhook/event"
 	"unibee/internal/consumer/webhook/invoice"
+	"unibee/internal/logic/analysis/ledger"
+	"unibee/internal/logic/invoice/numbering"
 	"unibee/internal/query"
 	"unibee/utility"
 )
@@ -30,7 +32,12 @@ func (t InvoiceProcessListener) Consume(ctx context.Context, message *redismq.Me
 	g.Log().Debugf(ctx, "InvoiceProcessListener Receive Message:%s", utility.MarshalToJsonString(message))
 	one := query.GetInvoiceByInvoiceId(ctx, message.Body)
 	if one != nil {
+		if err := ledger.RecordInvoiceFinalized(ctx, one); err != nil {
+			g.Log().Errorf(ctx, "InvoiceProcessListener RecordInvoiceFinalized invoiceId:%s error:%s", one.InvoiceId, err.Error())
+			return redismq.ReconsumeLater
+		}
 		one.Status = consts.InvoiceStatusProcessing
+		_, _ = numbering.AssignInvoiceNumber(ctx, one)
 		invoice.SendMerchantInvoiceWebhookBackground(one, event.UNIBEE_WEBHOOK_EVENT_INVOICE_PROCESS, message.CustomData)
 	}
 	return redismq.CommitMessage
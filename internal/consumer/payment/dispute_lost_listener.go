@@ -0,0 +1,56 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"github.com/gogf/gf/v2/frame/g"
+	redismq "github.com/jackyang-hk/go-redismq"
+	redismq2 "unibee/internal/cmd/redismq"
+	"unibee/internal/consts"
+	"unibee/internal/logic/payment/dispute"
+	"unibee/internal/logic/subscription/service"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+type DisputeLostListener struct {
+}
+
+func (t DisputeLostListener) GetTopic() string {
+	return redismq2.TopicDisputeLost.Topic
+}
+
+func (t DisputeLostListener) GetTag() string {
+	return redismq2.TopicDisputeLost.Tag
+}
+
+func (t DisputeLostListener) Consume(ctx context.Context, message *redismq.Message) redismq.Action {
+	utility.Assert(len(message.Body) > 0, "body is nil")
+	utility.Assert(len(message.Body) != 0, "body length is 0")
+	g.Log().Infof(ctx, "DisputeLostListener Receive Message:%s", utility.MarshalToJsonString(message))
+	one := query.GetPaymentDisputeByDisputeId(ctx, message.Body)
+	if one == nil || one.Status != consts.DisputeLost || len(one.SubscriptionId) == 0 {
+		return redismq.CommitMessage
+	}
+	if !dispute.GetMerchantDisputeConfig(ctx, one.MerchantId).SuspendSubscriptionOnLost {
+		return redismq.CommitMessage
+	}
+	sub := query.GetSubscriptionBySubscriptionId(ctx, one.SubscriptionId)
+	if sub != nil && (sub.Status == consts.SubStatusActive || sub.Status == consts.SubStatusIncomplete) {
+		err := service.SubscriptionSuspendByDispute(ctx, sub)
+		if err != nil {
+			g.Log().Errorf(ctx, "DisputeLostListener SubscriptionSuspendByDispute disputeId:%s subscriptionId:%s error:%s", one.DisputeId, sub.SubscriptionId, err.Error())
+			return redismq.ReconsumeLater
+		}
+	}
+	return redismq.CommitMessage
+}
+
+func init() {
+	redismq.RegisterListener(NewDisputeLostListener())
+	fmt.Println("DisputeLostListener RegisterListener")
+}
+
+func NewDisputeLostListener() *DisputeLostListener {
+	return &DisputeLostListener{}
+}
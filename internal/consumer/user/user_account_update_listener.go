@@ -9,6 +9,7 @@ import (
 	redismq2 "unibee/internal/cmd/redismq"
 	"unibee/internal/consumer/webhook/event"
 	"unibee/internal/consumer/webhook/user"
+	"unibee/internal/logic/analysis/xero"
 	"unibee/utility"
 )
 
@@ -31,6 +32,7 @@ func (t UserAccountUpdateListener) Consume(ctx context.Context, message *redismq
 		userId, _ := strconv.ParseUint(message.Body, 10, 64)
 		if userId > 0 {
 			user.SendMerchantUserWebhookBackground(userId, event.UNIBEE_WEBHOOK_EVENT_USER_UPDATED)
+			xero.SyncUserContact(ctx, userId)
 		}
 	}
 
@@ -19,6 +19,11 @@ const (
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_INVOICE_TRACK             = "subscription.latest_invoice.track"      // pending every day at 3 days before period end
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_EXPIRED                   = "subscription.expired"
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_FAILED                    = "subscription.failed"
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PAUSED                    = "subscription.paused"
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_RESUMED                   = "subscription.resumed"
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_DUNNING_ATTEMPT           = "subscription.dunning.attempt"        // each payment retry of merchant's dunning policy
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PRICE_CHANGE_SCHEDULED    = "subscription.price_change.scheduled" // plan price migration scheduled, sent before the new price effect
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PRICE_CHANGED             = "subscription.price_changed"
 
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_UPDATE_CREATE    = "subscription.pending_update.create"
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_UPDATE_SUCCESS   = "subscription.pending_update.success"
@@ -44,6 +49,10 @@ const (
 	UNIBEE_WEBHOOK_EVENT_REFUND_FAILURE         = "refund.failure"
 	UNIBEE_WEBHOOK_EVENT_REFUND_CANCELLED       = "refund.cancelled"
 	UNIBEE_WEBHOOK_EVENT_REFUND_REVERSED        = "refund.reversed"
+	UNIBEE_WEBHOOK_EVENT_DISPUTE_CREATED        = "dispute.created"
+	UNIBEE_WEBHOOK_EVENT_DISPUTE_EVIDENCE_NEED  = "dispute.evidence.need"
+	UNIBEE_WEBHOOK_EVENT_DISPUTE_WON            = "dispute.won"
+	UNIBEE_WEBHOOK_EVENT_DISPUTE_LOST           = "dispute.lost"
 
 	UNIBEE_WEBHOOK_EVENT_INVOICE_CREATED   = "invoice.created"
 	UNIBEE_WEBHOOK_EVENT_INVOICE_PAID      = "invoice.paid"
@@ -51,6 +60,8 @@ const (
 	UNIBEE_WEBHOOK_EVENT_INVOICE_CANCELLED = "invoice.cancelled"
 	UNIBEE_WEBHOOK_EVENT_INVOICE_FAILED    = "invoice.failed"
 	UNIBEE_WEBHOOK_EVENT_INVOICE_REVERSED  = "invoice.reversed"
+
+	UNIBEE_WEBHOOK_EVENT_WEBHOOK_ENDPOINT_DISABLED = "webhook.endpoint.disabled" // endpoint disabled after consecutive failed deliveries
 )
 
 var ListeningEventList = []string{
@@ -70,6 +81,11 @@ var ListeningEventList = []string{
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_INVOICE_TRACK,
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_EXPIRED,
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_FAILED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PAUSED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_RESUMED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_DUNNING_ATTEMPT,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PRICE_CHANGE_SCHEDULED,
+	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PRICE_CHANGED,
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_UPDATE_CREATE,
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_UPDATE_SUCCESS,
 	UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PENDING_UPDATE_CANCELLED,
@@ -91,12 +107,17 @@ var ListeningEventList = []string{
 	UNIBEE_WEBHOOK_EVENT_REFUND_FAILURE,
 	UNIBEE_WEBHOOK_EVENT_REFUND_CANCELLED,
 	UNIBEE_WEBHOOK_EVENT_REFUND_REVERSED,
+	UNIBEE_WEBHOOK_EVENT_DISPUTE_CREATED,
+	UNIBEE_WEBHOOK_EVENT_DISPUTE_EVIDENCE_NEED,
+	UNIBEE_WEBHOOK_EVENT_DISPUTE_WON,
+	UNIBEE_WEBHOOK_EVENT_DISPUTE_LOST,
 	UNIBEE_WEBHOOK_EVENT_INVOICE_CREATED,
 	UNIBEE_WEBHOOK_EVENT_INVOICE_PAID,
 	UNIBEE_WEBHOOK_EVENT_INVOICE_PROCESS,
 	UNIBEE_WEBHOOK_EVENT_INVOICE_CANCELLED,
 	UNIBEE_WEBHOOK_EVENT_INVOICE_FAILED,
 	UNIBEE_WEBHOOK_EVENT_INVOICE_REVERSED,
+	UNIBEE_WEBHOOK_EVENT_WEBHOOK_ENDPOINT_DISABLED,
 }
 
 func WebhookEventInListeningEvents(target WebhookEvent) bool {
@@ -0,0 +1,308 @@
+package message
+
+import (
+	"context"
+	"fmt"
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"sync"
+	"time"
+	"unibee/api/bean"
+	event2 "unibee/internal/consumer/webhook/event"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/webhook"
+	"unibee/internal/logic/webhook/config"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+const (
+	WebhookLogStatusLegacy   = 0
+	WebhookLogStatusSuccess  = 1
+	WebhookLogStatusRetrying = 2
+	WebhookLogStatusFailed   = 3
+)
+
+const (
+	webhookRequestTimeout    = 30 * time.Second
+	webhookRedeliveryLease   = 10 * 60 // seconds the claimed redelivery stays invisible to other workers
+	webhookAttemptSuccess    = 1
+	webhookAttemptNotSuccess = 0
+)
+
+// webhookHeaders signs the timestamped Webhook-Signature with the secret of endpoint, the delivery backfills the secret
+// of endpoint created without one before signing, the X-Signature and Authorization carrying the api key of merchant
+// are deprecated and kept for compatibility only
+func webhookHeaders(merchant *entity.Merchant, endpoint *entity.MerchantWebhook, msgId string, event string, eventId string, body string) map[string]string {
+	signature, algorithm := SignHMACWebhook(body, merchant.ApiKey)
+	headers := map[string]string{
+		"Content-Type":          "application/json",
+		"Msg-id":                msgId,
+		"Datetime":              getCurrentDateTime(),
+		"EventType":             event,
+		"EventId":               eventId,
+		"Authorization":         fmt.Sprintf("Bearer %s", merchant.ApiKey),
+		"X-Signature-Algorithm": algorithm,
+		"X-Signature":           signature,
+	}
+	timestamp := gtime.Now().Timestamp()
+	if secrets := signingSecrets(endpoint, timestamp); len(secrets) > 0 {
+		headers[WebhookSignatureHeader] = SignWebhookPayload(body, timestamp, secrets...)
+	}
+	return headers
+}
+
+func endpointAvailable(endpoint *entity.MerchantWebhook) bool {
+	return endpoint != nil && endpoint.IsDeleted == 0 && endpoint.DisableTime == 0
+}
+
+// deliverWebhookLog posts the body of webhook log to its endpoint, records the attempt and updates the log and the health of endpoint,
+// failed delivery is scheduled with the backoff of retry policy until max attempts reached, manual one never changes the schedule
+func deliverWebhookLog(ctx context.Context, merchant *entity.Merchant, endpoint *entity.MerchantWebhook, one *entity.MerchantWebhookLog, policy *bean.WebhookRetryPolicy, manual bool) bool {
+	attempt := one.AttemptCount + 1
+	if ensured, err := webhook.EnsureMerchantWebhookEndpointSecret(ctx, endpoint); err == nil {
+		endpoint = ensured
+	}
+	headers := webhookHeaders(merchant, endpoint, one.RequestId, one.WebhookEvent, one.WebhookEventId, one.Body)
+	g.Log().Debugf(ctx, "Webhook_Start %s %s attempt:%d %s\n", "POST", one.WebhookUrl, attempt, one.Body)
+	start := time.Now()
+	status, res, err := utility.SendRequestWithStatus(one.WebhookUrl, "POST", []byte(one.Body), headers, webhookRequestTimeout)
+	duration := time.Since(start).Milliseconds()
+	var response = string(res)
+	if err != nil {
+		response = utility.MarshalToJsonString(err)
+		g.Log().Infof(ctx, "Webhook_End %s %s status:%d attempt:%d error %s\n", "POST", one.WebhookUrl, status, attempt, err.Error())
+	} else {
+		g.Log().Infof(ctx, "Webhook_End %s %s status:%d attempt:%d\n", "POST", one.WebhookUrl, status, attempt)
+	}
+	success := err == nil
+	timeNow := gtime.Now().Timestamp()
+	var attemptSuccess = webhookAttemptNotSuccess
+	if success {
+		attemptSuccess = webhookAttemptSuccess
+	}
+	_, saveErr := dao.MerchantWebhookAttempt.Ctx(ctx).Data(&entity.MerchantWebhookAttempt{
+		MerchantId:     one.MerchantId,
+		EndpointId:     uint64(one.EndpointId),
+		LogId:          one.Id,
+		Attempt:        attempt,
+		ResponseStatus: status,
+		Response:       response,
+		Success:        attemptSuccess,
+		Duration:       duration,
+		CreateTime:     timeNow,
+	}).OmitNil().Insert()
+	if saveErr != nil {
+		g.Log().Errorf(ctx, "Webhook_SaveAttempt error %s\n", saveErr.Error())
+	}
+
+	var data = g.Map{
+		dao.MerchantWebhookLog.Columns().AttemptCount:    attempt,
+		dao.MerchantWebhookLog.Columns().ResponseStatus:  status,
+		dao.MerchantWebhookLog.Columns().Response:        response,
+		dao.MerchantWebhookLog.Columns().LastAttemptTime: timeNow,
+		dao.MerchantWebhookLog.Columns().GmtModify:       gtime.Now(),
+	}
+	if success {
+		data[dao.MerchantWebhookLog.Columns().Status] = WebhookLogStatusSuccess
+		data[dao.MerchantWebhookLog.Columns().NextRetryTime] = 0
+	} else if !manual && attempt < policy.MaxAttempts {
+		data[dao.MerchantWebhookLog.Columns().Status] = WebhookLogStatusRetrying
+		data[dao.MerchantWebhookLog.Columns().NextRetryTime] = timeNow + config.NextRetryInterval(policy, attempt)
+	} else if !manual || one.Status != WebhookLogStatusRetrying {
+		data[dao.MerchantWebhookLog.Columns().Status] = WebhookLogStatusFailed
+		data[dao.MerchantWebhookLog.Columns().NextRetryTime] = 0
+	}
+	_, saveErr = dao.MerchantWebhookLog.Ctx(ctx).Data(data).Where(dao.MerchantWebhookLog.Columns().Id, one.Id).Update()
+	if saveErr != nil {
+		g.Log().Errorf(ctx, "Webhook_SaveLog error %s\n", saveErr.Error())
+	}
+	if endpoint != nil {
+		updateEndpointHealth(ctx, endpoint.Id, success, timeNow, policy)
+	}
+	return success
+}
+
+// updateEndpointHealth resets the consecutive failures of endpoint on success, otherwise increases it and disables the endpoint
+// once it reaches the threshold of retry policy
+func updateEndpointHealth(ctx context.Context, endpointId uint64, success bool, timeNow int64, policy *bean.WebhookRetryPolicy) {
+	if success {
+		_, err := dao.MerchantWebhook.Ctx(ctx).Data(g.Map{
+			dao.MerchantWebhook.Columns().ConsecutiveFailures: 0,
+			dao.MerchantWebhook.Columns().LastSuccessTime:     timeNow,
+		}).Where(dao.MerchantWebhook.Columns().Id, endpointId).Update()
+		if err != nil {
+			g.Log().Errorf(ctx, "updateEndpointHealth endpoint:%d error %s\n", endpointId, err.Error())
+		}
+		return
+	}
+	_, err := dao.MerchantWebhook.Ctx(ctx).Data(g.Map{
+		dao.MerchantWebhook.Columns().ConsecutiveFailures: gdb.Raw(fmt.Sprintf("%s+1", dao.MerchantWebhook.Columns().ConsecutiveFailures)),
+		dao.MerchantWebhook.Columns().LastFailureTime:     timeNow,
+	}).Where(dao.MerchantWebhook.Columns().Id, endpointId).Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "updateEndpointHealth endpoint:%d error %s\n", endpointId, err.Error())
+		return
+	}
+	if policy.DisableAfterFailures <= 0 {
+		return
+	}
+	endpoint := query.GetMerchantWebhook(ctx, endpointId)
+	if endpoint == nil || endpoint.DisableTime > 0 || endpoint.ConsecutiveFailures < policy.DisableAfterFailures {
+		return
+	}
+	update, err := dao.MerchantWebhook.Ctx(ctx).Data(g.Map{
+		dao.MerchantWebhook.Columns().DisableTime: timeNow,
+		dao.MerchantWebhook.Columns().GmtModify:   gtime.Now(),
+	}).Where(dao.MerchantWebhook.Columns().Id, endpointId).Where(dao.MerchantWebhook.Columns().DisableTime, 0).Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "updateEndpointHealth disable endpoint:%d error %s\n", endpointId, err.Error())
+		return
+	}
+	affected, err := update.RowsAffected()
+	if err != nil || affected != 1 {
+		// disabled by other worker
+		return
+	}
+	reason := fmt.Sprintf("%d consecutive failed deliveries", endpoint.ConsecutiveFailures)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     endpoint.MerchantId,
+		Target:         fmt.Sprintf("WebhookEndpoint(%v)", endpoint.Id),
+		Content:        fmt.Sprintf("Disable(%s)", reason),
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, nil)
+	SendWebhookMessage(ctx, event2.UNIBEE_WEBHOOK_EVENT_WEBHOOK_ENDPOINT_DISABLED, endpoint.MerchantId, gjson.New(g.Map{
+		"endpointId":  endpoint.Id,
+		"webhookUrl":  endpoint.WebhookUrl,
+		"description": endpoint.WebhookUrl,
+		"reason":      reason,
+		"disableTime": timeNow,
+	}), "", "", nil)
+}
+
+// RedeliverDueWebhooks redelivers the retrying webhook logs reached their next retry time, each log is claimed before delivery
+// so that concurrent workers never deliver the same attempt twice, at most concurrency deliveries run in parallel
+func RedeliverDueWebhooks(ctx context.Context, limit int, concurrency int) (count int) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	timeNow := gtime.Now().Timestamp()
+	var list []*entity.MerchantWebhookLog
+	err := dao.MerchantWebhookLog.Ctx(ctx).
+		Where(dao.MerchantWebhookLog.Columns().Status, WebhookLogStatusRetrying).
+		WhereGT(dao.MerchantWebhookLog.Columns().NextRetryTime, 0).
+		WhereLTE(dao.MerchantWebhookLog.Columns().NextRetryTime, timeNow).
+		OrderAsc(dao.MerchantWebhookLog.Columns().NextRetryTime).
+		Limit(limit).
+		Scan(&list)
+	if err != nil {
+		g.Log().Errorf(ctx, "RedeliverDueWebhooks error:%s", err.Error())
+		return 0
+	}
+	var policies = make(map[uint64]*bean.WebhookRetryPolicy)
+	var wg sync.WaitGroup
+	var slots = make(chan struct{}, concurrency)
+	for _, one := range list {
+		if !webhookLogDue(one, timeNow) {
+			continue
+		}
+		update, err := dao.MerchantWebhookLog.Ctx(ctx).Data(g.Map{
+			dao.MerchantWebhookLog.Columns().NextRetryTime: timeNow + webhookRedeliveryLease,
+		}).Where(dao.MerchantWebhookLog.Columns().Id, one.Id).
+			Where(dao.MerchantWebhookLog.Columns().Status, WebhookLogStatusRetrying).
+			Where(dao.MerchantWebhookLog.Columns().AttemptCount, one.AttemptCount).
+			Where(dao.MerchantWebhookLog.Columns().NextRetryTime, one.NextRetryTime).
+			Update()
+		if err != nil {
+			g.Log().Errorf(ctx, "RedeliverDueWebhooks claim log:%d error:%s", one.Id, err.Error())
+			continue
+		}
+		if affected, err := update.RowsAffected(); err != nil || affected != 1 {
+			// claimed by other worker
+			continue
+		}
+		merchant := query.GetMerchantById(ctx, one.MerchantId)
+		endpoint := query.GetMerchantWebhook(ctx, uint64(one.EndpointId))
+		if merchant == nil || !endpointAvailable(endpoint) {
+			// endpoint deleted or disabled, stop retrying, can be replayed after endpoint enabled
+			_, _ = dao.MerchantWebhookLog.Ctx(ctx).Data(g.Map{
+				dao.MerchantWebhookLog.Columns().Status:        WebhookLogStatusFailed,
+				dao.MerchantWebhookLog.Columns().NextRetryTime: 0,
+			}).Where(dao.MerchantWebhookLog.Columns().Id, one.Id).Update()
+			continue
+		}
+		policy, ok := policies[one.MerchantId]
+		if !ok {
+			policy = config.GetMerchantWebhookRetryPolicy(ctx, one.MerchantId)
+			policies[one.MerchantId] = policy
+		}
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(one *entity.MerchantWebhookLog, merchant *entity.Merchant, endpoint *entity.MerchantWebhook, policy *bean.WebhookRetryPolicy) {
+			defer wg.Done()
+			defer func() {
+				<-slots
+				if exception := recover(); exception != nil {
+					g.Log().Errorf(ctx, "RedeliverDueWebhooks log:%d panic:%v", one.Id, exception)
+				}
+			}()
+			deliverWebhookLog(ctx, merchant, endpoint, one, policy, false)
+		}(one, merchant, endpoint, policy)
+		count++
+	}
+	wg.Wait()
+	return count
+}
+
+// webhookLogDue reports whether the log is due for redelivery at timeNow, the same condition RedeliverDueWebhooks queries by
+func webhookLogDue(one *entity.MerchantWebhookLog, timeNow int64) bool {
+	return one != nil && one.Status == WebhookLogStatusRetrying && one.NextRetryTime > 0 && one.NextRetryTime <= timeNow
+}
+
+// ReplayFailedWebhooks reschedules the failed or retrying webhook logs of endpoint created within the time range for immediate
+// redelivery, with attempts of retry policy restarted
+func ReplayFailedWebhooks(ctx context.Context, merchantId uint64, endpointId uint64, startTime int64, endTime int64) (int64, error) {
+	utility.Assert(merchantId > 0, "invalid merchantId")
+	utility.Assert(endpointId > 0, "invalid endpointId")
+	utility.Assert(startTime > 0 && endTime > startTime, "invalid time range")
+	endpoint := query.GetMerchantWebhook(ctx, endpointId)
+	utility.Assert(endpoint != nil && endpoint.MerchantId == merchantId && endpoint.IsDeleted == 0, "endpoint not found")
+	utility.Assert(endpoint.DisableTime == 0, "endpoint is disabled, enable it before replay")
+	update, err := dao.MerchantWebhookLog.Ctx(ctx).Data(g.Map{
+		dao.MerchantWebhookLog.Columns().Status:        WebhookLogStatusRetrying,
+		dao.MerchantWebhookLog.Columns().AttemptCount:  0,
+		dao.MerchantWebhookLog.Columns().NextRetryTime: gtime.Now().Timestamp(),
+		dao.MerchantWebhookLog.Columns().GmtModify:     gtime.Now(),
+	}).Where(dao.MerchantWebhookLog.Columns().MerchantId, merchantId).
+		Where(dao.MerchantWebhookLog.Columns().EndpointId, endpointId).
+		WhereIn(dao.MerchantWebhookLog.Columns().Status, []int{WebhookLogStatusRetrying, WebhookLogStatusFailed}).
+		WhereGTE(dao.MerchantWebhookLog.Columns().CreateTime, startTime).
+		WhereLTE(dao.MerchantWebhookLog.Columns().CreateTime, endTime).
+		Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "ReplayFailedWebhooks error:%s", err.Error())
+		return 0, gerror.NewCode(gcode.New(500, "server error", nil))
+	}
+	count, _ := update.RowsAffected()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     merchantId,
+		Target:         fmt.Sprintf("WebhookEndpoint(%v)", endpointId),
+		Content:        fmt.Sprintf("ReplayFailed(%d-%d,%d)", startTime, endTime, count),
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	return count, nil
+}
@@ -0,0 +1,28 @@
+package message
+
+import (
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestWebhookLogDue(t *testing.T) {
+	timeNow := gtime.Now().Timestamp()
+	one := newWebhookLog(&WebhookMessage{MerchantId: 1, EndpointId: 1, Url: "http://localhost/webhook"}, generateMsgId(), "{}", 0, timeNow)
+	t.Run("Test for Fresh Log Not Attempted", func(t *testing.T) {
+		require.Equal(t, WebhookLogStatusRetrying, one.Status)
+		require.Equal(t, 0, one.AttemptCount)
+		require.False(t, webhookLogDue(one, timeNow))
+		require.False(t, webhookLogDue(one, timeNow+webhookRedeliveryLease-1))
+	})
+	t.Run("Test for Fresh Log Selected After Lease Expired", func(t *testing.T) {
+		require.True(t, webhookLogDue(one, timeNow+webhookRedeliveryLease))
+		require.True(t, webhookLogDue(one, timeNow+webhookRedeliveryLease+60))
+	})
+	t.Run("Test for Finished Log", func(t *testing.T) {
+		finished := *one
+		finished.Status = WebhookLogStatusSuccess
+		finished.NextRetryTime = 0
+		require.False(t, webhookLogDue(&finished, timeNow+webhookRedeliveryLease))
+	})
+}
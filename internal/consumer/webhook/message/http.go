@@ -5,10 +5,10 @@ import (
 	"fmt"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gtime"
-	"strings"
 	"time"
 	dao "unibee/internal/dao/default"
 	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/webhook/config"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
 	"unibee/utility"
@@ -27,18 +27,6 @@ func ResentWebhook(ctx context.Context, logId uint64) bool {
 		g.Log().Errorf(ctx, "Webhook_Resend %s %s merchant not found\n", "POST", one.WebhookUrl)
 		return false
 	}
-	datetime := getCurrentDateTime()
-	g.Log().Debugf(ctx, "Webhook_Start %s %s %s\n", "POST", one.WebhookUrl, one.Body)
-	headers := map[string]string{
-		"Content-Type":  "application/json",
-		"Msg-id":        one.RequestId,
-		"Datetime":      datetime,
-		"EventType":     one.WebhookEvent,
-		"EventId":       one.WebhookEventId,
-		"Authorization": fmt.Sprintf("Bearer %s", merchant.ApiKey),
-	}
-	body := []byte(one.Body)
-	res, err := utility.SendRequest(one.WebhookUrl, "POST", body, headers)
 	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
 		MerchantId:     merchant.Id,
 		Target:         fmt.Sprintf("WebhookEndpointLog(%v)", one.Id),
@@ -49,24 +37,20 @@ func ResentWebhook(ctx context.Context, logId uint64) bool {
 		PlanId:         0,
 		DiscountCode:   "",
 	}, nil)
-	var response = string(res)
-	if err != nil {
-		response = utility.MarshalToJsonString(err)
-		g.Log().Infof(ctx, "ResentWebhook %s %s response: %s error %s\n", "POST", one.WebhookUrl, response, err.Error())
-	} else {
-		g.Log().Infof(ctx, "ResentWebhook %s %s response: %s \n", "POST", one.WebhookUrl, response)
-	}
-	return true
+	// fresh signature with current timestamp, the original one may be out of tolerance
+	return deliverWebhookLog(ctx, merchant, query.GetMerchantWebhook(ctx, uint64(one.EndpointId)), one, config.GetMerchantWebhookRetryPolicy(ctx, merchant.Id), true)
 }
 
-func SendWebhookRequest(ctx context.Context, webhookMessage *WebhookMessage, reconsumeTimes int) bool {
+// SendWebhookRequest saves the webhook log of message and makes the first delivery attempt, failed one is redelivered by
+// RedeliverDueWebhooks with the backoff of merchant's retry policy, error returns only if the log is not saved
+func SendWebhookRequest(ctx context.Context, webhookMessage *WebhookMessage, reconsumeTimes int) (bool, error) {
 	utility.Assert(webhookMessage.Data != nil, "param is nil")
 	datetime := getCurrentDateTime()
 	msgId := generateMsgId()
 	err := webhookMessage.Data.Set("eventType", webhookMessage.Event)
 	if err != nil {
 		g.Log().Errorf(ctx, "Webhook_Send %s %s error %s\n", "POST", webhookMessage.Url, err.Error())
-		return false
+		return false, nil
 	}
 	_ = webhookMessage.Data.Set("eventId", webhookMessage.EventId)
 	_ = webhookMessage.Data.Set("msgId", msgId)
@@ -74,59 +58,57 @@ func SendWebhookRequest(ctx context.Context, webhookMessage *WebhookMessage, rec
 	merchant := query.GetMerchantById(ctx, webhookMessage.MerchantId)
 	if merchant == nil {
 		g.Log().Errorf(ctx, "Webhook_Send %s %s merchant not found\n", "POST", webhookMessage.Url)
-		return false
+		return false, nil
+	}
+	endpoint := query.GetMerchantWebhook(ctx, webhookMessage.EndpointId)
+	if !endpointAvailable(endpoint) {
+		g.Log().Infof(ctx, "Webhook_Send %s %s endpoint deleted or disabled\n", "POST", webhookMessage.Url)
+		return false, nil
 	}
 	jsonString, err := webhookMessage.Data.ToJsonString()
 	utility.Assert(err == nil, fmt.Sprintf("json format error %s param %s", err, webhookMessage.Data))
-	g.Log().Debugf(ctx, "Webhook_Start %s %s %s\n", "POST", webhookMessage.Url, jsonString)
-	body := []byte(jsonString)
-	webhookSecret := merchant.WebhookSecret
-	if webhookSecret == "" {
-		webhookSecret = merchant.ApiKey
-	}
-	signature, algorithm := SignHMACWebhook(jsonString, merchant.ApiKey)
-	headers := map[string]string{
-		"Content-Type":          "application/json",
-		"Msg-id":                msgId,
-		"Datetime":              datetime,
-		"EventType":             string(webhookMessage.Event),
-		"EventId":               webhookMessage.EventId,
-		"Authorization":         fmt.Sprintf("Bearer %s", merchant.ApiKey),
-		"X-Signature-Algorithm": algorithm,
-		"X-Signature":           signature,
-	}
-	res, err := utility.SendRequest(webhookMessage.Url, "POST", body, headers)
 	g.Log().Infof(ctx, "SendWebhookRequest event:%v", webhookMessage.Event)
-	var response = string(res)
-	var responseMessage = "not success"
-	if strings.Compare(response, "success") == 0 {
-		responseMessage = response
-	}
-	if err != nil {
-		response = utility.MarshalToJsonString(err)
-		g.Log().Debugf(ctx, "Webhook_End %s %s response: %s error\n", "POST", webhookMessage.Url, responseMessage)
-	} else {
-		g.Log().Debugf(ctx, "Webhook_End %s %s response: %s \n", "POST", webhookMessage.Url, responseMessage)
+	one := newWebhookLog(webhookMessage, msgId, jsonString, reconsumeTimes, gtime.Now().Timestamp())
+	result, saveErr := dao.MerchantWebhookLog.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	if saveErr != nil {
+		g.Log().Errorf(ctx, "Webhook_SaveLog error %s\n", saveErr.Error())
+		return false, saveErr
 	}
-	one := &entity.MerchantWebhookLog{
+	id, _ := result.LastInsertId()
+	one.Id = uint64(id)
+	return deliverWebhookLog(ctx, merchant, endpoint, one, config.GetMerchantWebhookRetryPolicy(ctx, merchant.Id), false), nil
+}
+
+// newWebhookLog builds the retrying log of the first attempt, scheduled for redelivery after the lease so that the attempt
+// unfinished by crash or restart is picked up by RedeliverDueWebhooks, the delivery result overrides the schedule
+func newWebhookLog(webhookMessage *WebhookMessage, msgId string, body string, reconsumeTimes int, timeNow int64) *entity.MerchantWebhookLog {
+	return &entity.MerchantWebhookLog{
 		MerchantId:     webhookMessage.MerchantId,
 		EndpointId:     int64(webhookMessage.EndpointId),
 		WebhookUrl:     webhookMessage.Url,
 		WebhookEvent:   string(webhookMessage.Event),
 		RequestId:      msgId,
-		Body:           jsonString,
+		Body:           body,
 		ReconsumeCount: reconsumeTimes,
-		Response:       response,
 		WebhookEventId: webhookMessage.EventId,
-		CreateTime:     gtime.Now().Timestamp(),
+		Status:         WebhookLogStatusRetrying,
+		NextRetryTime:  timeNow + webhookRedeliveryLease,
+		CreateTime:     timeNow,
 		Mamo:           webhookMessage.MetaData,
 	}
-	_, saveErr := dao.MerchantWebhookLog.Ctx(ctx).Data(one).OmitNil().Insert(one)
-	if saveErr != nil {
-		g.Log().Errorf(ctx, "Webhook_SaveLog error %s\n", saveErr.Error())
+}
+
+// signingSecrets returns the secrets to sign the Webhook-Signature of endpoint, the rotated secret is included within its overlap window,
+// nil if the endpoint has no secret yet
+func signingSecrets(endpoint *entity.MerchantWebhook, timeNow int64) []string {
+	if endpoint == nil || len(endpoint.WebhookSecret) == 0 {
+		return nil
+	}
+	var secrets = []string{endpoint.WebhookSecret}
+	if len(endpoint.PreviousWebhookSecret) > 0 && endpoint.PreviousSecretExpireTime > timeNow {
+		secrets = append(secrets, endpoint.PreviousWebhookSecret)
 	}
-	response = strings.Trim(response, " ")
-	return err == nil && strings.Compare(response, "success") == 0
+	return secrets
 }
 
 func generateMsgId() (msgId string) {
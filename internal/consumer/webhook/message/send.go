@@ -95,6 +95,10 @@ func SendWebhookMessage(ctx context.Context, event event2.WebhookEvent, merchant
 	list := query.GetMerchantWebhooksByMerchantId(ctx, merchantId)
 	if list != nil {
 		for _, merchantWebhook := range list {
+			if merchantWebhook.DisableTime > 0 {
+				// disabled by consecutive failures, enable it to receive events again
+				continue
+			}
 			eventList := strings.Split(merchantWebhook.WebhookEvents, ",")
 			if in(eventList, string(event)) {
 				send, err := redismq.Send(&redismq.Message{
@@ -5,6 +5,9 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 )
 
 // VerifyHMACSignature Verify HMAC-SHA256 Signature（Base64）
@@ -33,5 +36,71 @@ func SignHMACWebhook(body string, secret string) (signature string, algorithm st
 	mac := hmac.New(sha256.New, []byte(secret))
 	mac.Write([]byte(body))
 	signature = base64.StdEncoding.EncodeToString(mac.Sum(nil))
-	return signature, "hmac"
+	return signature, WebhookSignatureAlgorithm
+}
+
+const (
+	WebhookSignatureAlgorithm = "hmac"
+	// WebhookSignatureHeader carries the timestamped signature of endpoint with dedicated secret, X-Signature keeps the legacy one
+	WebhookSignatureHeader = "Webhook-Signature"
+	// WebhookSignatureTolerance is the max seconds between the signed timestamp and the receiving time
+	WebhookSignatureTolerance = 300
+)
+
+func signTimestampPayload(body string, timestamp int64, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.%s", timestamp, body)))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// SignWebhookPayload signs timestamp and body with each secret, returns the Webhook-Signature header as t=<timestamp>,v1=<signature>,
+// one v1 for each secret, the receiver should accept the webhook if any of them matches
+func SignWebhookPayload(body string, timestamp int64, secrets ...string) string {
+	var parts = []string{fmt.Sprintf("t=%d", timestamp)}
+	for _, secret := range secrets {
+		if len(secret) > 0 {
+			parts = append(parts, fmt.Sprintf("v1=%s", signTimestampPayload(body, timestamp, secret)))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// VerifyWebhookSignature Verify Webhook-Signature header made by SignWebhookPayload, the timestamp out of tolerance is rejected against replay
+func VerifyWebhookSignature(body string, secret string, header string, tolerance int64, timeNow int64) error {
+	if header == "" {
+		return errors.New("missing Webhook-Signature header")
+	}
+	if secret == "" {
+		return errors.New("missing secret key")
+	}
+	var timestamp int64 = 0
+	var signatures = make([]string, 0)
+	for _, part := range strings.Split(header, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		if key == "t" {
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return errors.New("invalid signature timestamp")
+			}
+			timestamp = parsed
+		} else if key == "v1" {
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp <= 0 {
+		return errors.New("missing signature timestamp")
+	}
+	if timeNow-timestamp > tolerance || timestamp-timeNow > tolerance {
+		return errors.New("signature timestamp out of tolerance")
+	}
+	expected := signTimestampPayload(body, timestamp, secret)
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(expected), []byte(signature)) {
+			return nil
+		}
+	}
+	return errors.New("invalid HMAC signatureTarget")
 }
@@ -1,8 +1,10 @@
 package message
 
 import (
+	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/stretchr/testify/require"
 	"testing"
+	entity "unibee/internal/model/entity/default"
 )
 
 func TestSignature(t *testing.T) {
@@ -14,3 +16,42 @@ func TestSignature(t *testing.T) {
 		require.Equal(t, nil, VerifyHMACSignature(body, secret, sign))
 	})
 }
+
+func TestWebhookPayloadSignature(t *testing.T) {
+	body := "{\"eventType\":\"subscription.created\"}"
+	var timestamp int64 = 1700000000
+	header := SignWebhookPayload(body, timestamp, "ubwk_new", "ubwk_old")
+	t.Run("Test for Rotated Secrets", func(t *testing.T) {
+		require.Nil(t, VerifyWebhookSignature(body, "ubwk_new", header, WebhookSignatureTolerance, timestamp+10))
+		require.Nil(t, VerifyWebhookSignature(body, "ubwk_old", header, WebhookSignatureTolerance, timestamp+10))
+		require.NotNil(t, VerifyWebhookSignature(body, "ubwk_other", header, WebhookSignatureTolerance, timestamp+10))
+		require.NotNil(t, VerifyWebhookSignature(body+" ", "ubwk_new", header, WebhookSignatureTolerance, timestamp+10))
+	})
+	t.Run("Test for Replay", func(t *testing.T) {
+		require.NotNil(t, VerifyWebhookSignature(body, "ubwk_new", header, WebhookSignatureTolerance, timestamp+WebhookSignatureTolerance+1))
+		require.NotNil(t, VerifyWebhookSignature(body, "ubwk_new", SignWebhookPayload(body, 0, "ubwk_new"), WebhookSignatureTolerance, timestamp))
+	})
+	t.Run("Test for Signing Secrets", func(t *testing.T) {
+		require.Nil(t, signingSecrets(nil, timestamp))
+		require.Nil(t, signingSecrets(&entity.MerchantWebhook{}, timestamp))
+		endpoint := &entity.MerchantWebhook{WebhookSecret: "ubwk_new", PreviousWebhookSecret: "ubwk_old", PreviousSecretExpireTime: timestamp + 1}
+		require.Equal(t, []string{"ubwk_new", "ubwk_old"}, signingSecrets(endpoint, timestamp))
+		require.Equal(t, []string{"ubwk_new"}, signingSecrets(endpoint, timestamp+1))
+	})
+}
+
+func TestWebhookHeaders(t *testing.T) {
+	body := "{\"eventType\":\"subscription.created\"}"
+	merchant := &entity.Merchant{ApiKey: "ub_key"}
+	legacy, _ := SignHMACWebhook(body, merchant.ApiKey)
+	t.Run("Test for Endpoint Secret", func(t *testing.T) {
+		headers := webhookHeaders(merchant, &entity.MerchantWebhook{WebhookSecret: "ubwk_new"}, "msgId", "subscription.created", "eventId", body)
+		require.Nil(t, VerifyWebhookSignature(body, "ubwk_new", headers[WebhookSignatureHeader], WebhookSignatureTolerance, gtime.Now().Timestamp()))
+		require.NotNil(t, VerifyWebhookSignature(body, merchant.ApiKey, headers[WebhookSignatureHeader], WebhookSignatureTolerance, gtime.Now().Timestamp()))
+	})
+	t.Run("Test for Deprecated Legacy Signature", func(t *testing.T) {
+		headers := webhookHeaders(merchant, &entity.MerchantWebhook{WebhookSecret: "ubwk_new"}, "msgId", "subscription.created", "eventId", body)
+		require.Equal(t, legacy, headers["X-Signature"])
+		require.Nil(t, VerifyHMACSignature(body, merchant.ApiKey, headers["X-Signature"]))
+	})
+}
@@ -35,16 +35,20 @@ func (t MerchantWebhookListener) Consume(ctx context.Context, message *redismq.M
 		return redismq.ReconsumeLater
 	}
 
-	if SendWebhookRequest(ctx, webhookMessage, message.ReconsumeTimes) {
+	sent, err := SendWebhookRequest(ctx, webhookMessage, message.ReconsumeTimes)
+	if err != nil {
+		g.Log().Errorf(ctx, "Webhook_Subscription NewMerchantWebhookListener_Resume By SendWebhookRequest Error:%s", err.Error())
+		return redismq.ReconsumeLater
+	}
+	if sent {
 		// todo mark limit the dependencyKey
 		if webhookMessage.SequenceKey != "" {
 			_, _ = g.Redis().Set(ctx, webhookMessage.SequenceKey, "Sent")
 			_, _ = g.Redis().Expire(ctx, webhookMessage.SequenceKey, 24*60*60)
 		}
-		return redismq.CommitMessage
 	}
-
-	return redismq.ReconsumeLater
+	// failed delivery is redelivered from webhook log with the retry policy of merchant
+	return redismq.CommitMessage
 }
 
 func init() {
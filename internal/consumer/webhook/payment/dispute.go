@@ -0,0 +1,41 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"unibee/internal/consumer/webhook/event"
+	"unibee/internal/consumer/webhook/log"
+	"unibee/internal/consumer/webhook/message"
+	"unibee/internal/logic/payment/detail"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+func SendDisputeWebhookBackground(disputeId string, event event.WebhookEvent) {
+	go func() {
+		ctx := context.Background()
+		var err error
+		defer func() {
+			if exception := recover(); exception != nil {
+				if v, ok := exception.(error); ok && gerror.HasStack(v) {
+					err = v
+				} else {
+					err = gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception)
+				}
+				log.PrintPanic(ctx, err)
+				return
+			}
+		}()
+		one := query.GetPaymentDisputeByDisputeId(ctx, disputeId)
+		if one != nil {
+			disputeDetail := detail.GetPaymentDisputeDetail(ctx, one.MerchantId, one.DisputeId)
+			utility.Assert(disputeDetail != nil, "SendDisputeWebhookBackground Error")
+			key := fmt.Sprintf("webhook_dispute_lock_%s_%s_%d", one.DisputeId, event, one.Status)
+			if utility.TryLock(ctx, key, 60) {
+				message.SendWebhookMessage(ctx, event, one.MerchantId, utility.FormatToGJson(disputeDetail), "", "", nil)
+			}
+		}
+	}()
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/gorilla/websocket"
 	"time"
+	"unibee/internal/cmd/config"
 	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/merchant_api_key"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
 	"unibee/utility"
@@ -29,6 +31,13 @@ func MerchantWebSocketMessageEntry(r *ghttp.Request) {
 		r.Exit()
 	}
 	merchant := query.GetMerchantByApiKey(r.Context(), merchantApiKey)
+	if merchant == nil {
+		// scoped api key, the events of all groups pushed, read scope of all groups required
+		apiKey, err := merchant_api_key.Authenticate(r.Context(), merchantApiKey, merchant_api_key.ClientIp(r.Request, config.GetConfigInstance().Server.TrustedProxies))
+		if err == nil && apiKey != nil && merchant_api_key.IsFullReadAllowed(merchant_api_key.GetApiKeyScopes(apiKey)) {
+			merchant = query.GetMerchantById(r.Context(), apiKey.MerchantId)
+		}
+	}
 	if merchant == nil {
 		glog.Error(r.Context(), gerror.New("MerchantWebSocketMessage merchantApiKey invalid"))
 		r.Exit()
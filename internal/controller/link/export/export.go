@@ -8,9 +8,9 @@ import (
 	"os"
 	dao "unibee/internal/dao/default"
 	"unibee/internal/logic/jwt"
+	"unibee/internal/logic/oss"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
-	"unibee/utility"
 )
 
 func LinkExportEntry(r *ghttp.Request) {
@@ -65,7 +65,7 @@ func LinkExportEntry(r *ghttp.Request) {
 		return
 	}
 
-	fileName := utility.DownloadFile(one.DownloadUrl)
+	fileName := oss.DownloadFile(r.Context(), one.DownloadUrl)
 	if len(fileName) == 0 {
 		g.Log().Errorf(r.Context(), "LinkEntry file download or generate error")
 		r.Response.WriteHeader(http.StatusBadRequest)
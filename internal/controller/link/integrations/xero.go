@@ -0,0 +1,42 @@
+package integrations
+
+import (
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"net/http"
+	"unibee/internal/logic/analysis/xero"
+)
+
+func XeroAuthorizationEntry(r *ghttp.Request) {
+	code := r.GetQuery("code")
+	state := r.GetQuery("state")
+	if code == nil || state == nil || len(code.String()) == 0 || len(state.String()) == 0 {
+		g.Log().Errorf(r.Context(), "XeroAuthorizationEntry no code or state")
+		r.Response.Status = http.StatusForbidden
+		r.Exit()
+		return
+	}
+	g.Log().Infof(r.Context(), "XeroAuthorizationEntry code:%s, state:%s", code, state)
+	merchantId, _, err := xero.ParseAuthorizationState(state.String())
+	if err != nil {
+		g.Log().Errorf(r.Context(), "XeroAuthorizationEntry invalid state")
+		r.Response.Status = http.StatusForbidden
+		r.Exit()
+		return
+	}
+	returnUrl := xero.GetMerchantXeroConfig(r.Context(), merchantId).SetupReturnUrl
+	err = xero.SetupMerchantXeroConfig(r.Context(), state.String(), code.String())
+	if err != nil {
+		g.Log().Errorf(r.Context(), "SetupMerchantXeroConfig err:%s", err.Error())
+		r.Response.Status = http.StatusForbidden
+		r.Exit()
+		return
+	}
+	r.Response.Status = http.StatusOK
+	if len(returnUrl) > 0 {
+		r.Response.RedirectTo(returnUrl)
+	} else {
+		r.Response.Write("success")
+	}
+	return
+}
@@ -11,6 +11,10 @@ func GetQuickbooksAuthorizationLink() string {
 	return fmt.Sprintf("%s/integrate/quickbooks/auth_back", config.GetConfigInstance().Server.GetServerPath())
 }
 
+func GetXeroAuthorizationLink() string {
+	return fmt.Sprintf("%s/integrate/xero/auth_back", config.GetConfigInstance().Server.GetServerPath())
+}
+
 func GetInvoiceLink(invoiceId string, st string) string {
 	if len(invoiceId) == 0 {
 		return ""
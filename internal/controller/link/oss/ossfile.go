@@ -3,12 +3,17 @@ package oss
 import (
 	"bytes"
 	"fmt"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gtime"
 	"io"
 	"net/http"
+	"path"
 	"path/filepath"
 	"strings"
+	oss2 "unibee/internal/logic/oss"
+	"unibee/internal/logic/oss/storage"
 	"unibee/internal/query"
 )
 
@@ -30,6 +35,52 @@ func FileEntry(r *ghttp.Request) {
 		r.Response.Writeln("filename not found")
 		return
 	}
+	if !oss2.IsPublicFile(one) {
+		r.Response.WriteHeader(http.StatusForbidden)
+		r.Response.Writeln("signed url required")
+		return
+	}
+	data, err := oss2.GetFileData(r.Context(), one)
+	if err != nil {
+		g.Log().Errorf(r.Context(), "FileEntry filename:%s error:%s", filename, err.Error())
+		r.Response.Writeln("file not found")
+		return
+	}
+	writeFile(r, filename, data)
+}
+
+// SignedFileEntry serves the file of local storage or database by the signed url, see storage.LocalStorage
+func SignedFileEntry(r *ghttp.Request) {
+	r.Response.Header().Add("Access-Control-Allow-Origin", "*")
+	key := strings.TrimPrefix(r.URL.Path, "/oss/signed/")
+	if !storage.Signer().VerifySignedUrl(key, r.Get("expires").String(), r.Get("signature").String(), gtime.Now().Timestamp()) {
+		r.Response.WriteHeader(http.StatusForbidden)
+		r.Response.Writeln("invalid or expired signature")
+		return
+	}
+	var data []byte
+	var err error
+	if strings.HasPrefix(key, storage.DatabaseKeyPrefix) {
+		one := query.GetOssFileByFileName(r.Context(), strings.TrimPrefix(key, storage.DatabaseKeyPrefix))
+		if one != nil && one.StorageType == storage.TypeDatabase {
+			data = one.Data
+		} else {
+			err = gerror.New("file not found")
+		}
+	} else if backend := storage.GetStorage(storage.TypeLocal); backend != nil {
+		data, err = backend.Get(r.Context(), key)
+	} else {
+		err = gerror.New("local storage not setup")
+	}
+	if err != nil {
+		r.Response.WriteHeader(http.StatusNotFound)
+		r.Response.Writeln("file not found")
+		return
+	}
+	writeFile(r, path.Base(key), data)
+}
+
+func writeFile(r *ghttp.Request, filename string, data []byte) {
 	extension := filepath.Ext(filename)
 	var download bool
 	if extension == ".jpg" || extension == ".jpeg" || extension == ".png" || extension == ".svg" {
@@ -37,7 +88,7 @@ func FileEntry(r *ghttp.Request) {
 	} else {
 		download = true
 	}
-	r.Response.Header().Add("Content-Length", fmt.Sprintf("%d", len(one.Data)))
+	r.Response.Header().Add("Content-Length", fmt.Sprintf("%d", len(data)))
 	if download {
 		r.Response.Header().Add("Content-type", "application/octet-stream")
 		r.Response.Header().Add("content-disposition", "attachment; filename=\""+filename+"\"")
@@ -46,7 +97,7 @@ func FileEntry(r *ghttp.Request) {
 	} else {
 		r.Response.Header().Add("Content-type", "image/"+strings.ReplaceAll(extension, ".", ""))
 	}
-	_, err := io.Copy(r.Response.ResponseWriter, bytes.NewReader(one.Data))
+	_, err := io.Copy(r.Response.ResponseWriter, bytes.NewReader(data))
 	if err != nil {
 		g.Log().Errorf(r.Context(), "LinkEntry error:%s", err.Error())
 		r.Response.WriteHeader(http.StatusBadRequest)
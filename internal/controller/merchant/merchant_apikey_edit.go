@@ -0,0 +1,24 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/apikey"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/merchant_api_key"
+)
+
+func (c *ControllerApikey) Edit(ctx context.Context, req *apikey.EditReq) (res *apikey.EditRes, err error) {
+	one, err := merchant_api_key.EditApiKey(ctx, &merchant_api_key.EditApiKeyInternalReq{
+		MerchantId: _interface.GetMerchantId(ctx),
+		Id:         req.Id,
+		Name:       req.Name,
+		Scopes:     req.Scopes,
+		ValidIps:   req.ValidIps,
+		ExpireTime: req.ExpireTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &apikey.EditRes{ApiKeyObj: bean.SimplifyMerchantApiKey(one)}, nil
+}
@@ -0,0 +1,26 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/apikey"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/merchant_api_key"
+)
+
+func (c *ControllerApikey) List(ctx context.Context, req *apikey.ListReq) (res *apikey.ListRes, err error) {
+	list, total, err := merchant_api_key.ApiKeyList(ctx, &merchant_api_key.ApiKeyListInternalReq{
+		MerchantId: _interface.GetMerchantId(ctx),
+		Status:     req.Status,
+		Page:       req.Page,
+		Count:      req.Count,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var apiKeys = make([]*bean.MerchantApiKey, 0)
+	for _, one := range list {
+		apiKeys = append(apiKeys, bean.SimplifyMerchantApiKey(one))
+	}
+	return &apikey.ListRes{ApiKeys: apiKeys, Total: total}, nil
+}
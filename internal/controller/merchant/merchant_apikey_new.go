@@ -0,0 +1,23 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/apikey"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/merchant_api_key"
+)
+
+func (c *ControllerApikey) New(ctx context.Context, req *apikey.NewReq) (res *apikey.NewRes, err error) {
+	one, key, err := merchant_api_key.NewApiKey(ctx, &merchant_api_key.NewApiKeyInternalReq{
+		MerchantId: _interface.GetMerchantId(ctx),
+		Name:       req.Name,
+		Scopes:     req.Scopes,
+		ValidIps:   req.ValidIps,
+		ExpireTime: req.ExpireTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &apikey.NewRes{ApiKey: key, ApiKeyObj: bean.SimplifyMerchantApiKey(one)}, nil
+}
@@ -0,0 +1,17 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/apikey"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/merchant_api_key"
+)
+
+func (c *ControllerApikey) Revoke(ctx context.Context, req *apikey.RevokeReq) (res *apikey.RevokeRes, err error) {
+	one, err := merchant_api_key.RevokeApiKey(ctx, _interface.GetMerchantId(ctx), req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &apikey.RevokeRes{ApiKeyObj: bean.SimplifyMerchantApiKey(one)}, nil
+}
@@ -0,0 +1,21 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/apikey"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/merchant_api_key"
+)
+
+func (c *ControllerApikey) Rotate(ctx context.Context, req *apikey.RotateReq) (res *apikey.RotateRes, err error) {
+	var overlapSeconds int64 = merchant_api_key.DefaultRotateOverlapSeconds
+	if req.OverlapSeconds != nil {
+		overlapSeconds = *req.OverlapSeconds
+	}
+	one, key, err := merchant_api_key.RotateApiKey(ctx, _interface.GetMerchantId(ctx), req.Id, overlapSeconds)
+	if err != nil {
+		return nil, err
+	}
+	return &apikey.RotateRes{ApiKey: key, ApiKeyObj: bean.SimplifyMerchantApiKey(one)}, nil
+}
@@ -6,6 +6,8 @@ import (
 	"unibee/api/bean/detail"
 	dao "unibee/internal/dao/default"
 	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/credit/grant"
+	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
 	"unibee/utility"
 
@@ -20,12 +22,17 @@ func (c *ControllerCredit) Detail(ctx context.Context, req *credit.DetailReq) (r
 	} else {
 		return &credit.DetailRes{}, nil
 	}
-	var transactions []*bean.CreditTransaction
+	var list []*entity.CreditTransaction
 	_ = dao.CreditTransaction.Ctx(ctx).
 		Where(dao.CreditTransaction.Columns().CreditId, req.Id).
-		OmitEmpty().Scan(&transactions, true)
+		OmitEmpty().Scan(&list, true)
+	var transactions = make([]*bean.CreditTransaction, 0)
+	for _, transaction := range list {
+		transactions = append(transactions, bean.SimplifyCreditTransaction(ctx, transaction))
+	}
 	return &credit.DetailRes{
 		CreditAccount:      detail.ConvertToCreditAccountDetail(ctx, one),
 		CreditTransactions: transactions,
+		CreditGrants:       bean.SimplifyCreditGrantList(grant.GetCreditGrantList(ctx, one.Id)),
 	}, nil
 }
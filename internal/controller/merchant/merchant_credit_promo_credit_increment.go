@@ -26,6 +26,7 @@ func (c *ControllerCredit) PromoCreditIncrement(ctx context.Context, req *credit
 		Name:          req.Name,
 		Description:   req.Description,
 		AdminMemberId: adminMemberId,
+		ExpireTime:    req.ExpireTime,
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,13 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/gateway/routing"
+
+	"unibee/api/merchant/gateway"
+)
+
+func (c *ControllerGateway) Routing(ctx context.Context, req *gateway.RoutingReq) (res *gateway.RoutingRes, err error) {
+	return &gateway.RoutingRes{Routing: routing.GetMerchantGatewayRouting(ctx, _interface.GetMerchantId(ctx))}, nil
+}
@@ -0,0 +1,20 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/gateway/routing"
+	"unibee/internal/logic/merchant_config/update"
+	"unibee/utility"
+
+	"unibee/api/merchant/gateway"
+)
+
+func (c *ControllerGateway) RoutingUpdate(ctx context.Context, req *gateway.RoutingUpdateReq) (res *gateway.RoutingUpdateRes, err error) {
+	routing.CheckGatewayRouting(ctx, _interface.GetMerchantId(ctx), req.Routing)
+	err = update.SetMerchantConfig(ctx, _interface.GetMerchantId(ctx), routing.GatewayRouting, utility.MarshalToJsonString(req.Routing))
+	if err != nil {
+		return nil, err
+	}
+	return &gateway.RoutingUpdateRes{Routing: routing.GetMerchantGatewayRouting(ctx, _interface.GetMerchantId(ctx))}, nil
+}
@@ -0,0 +1,22 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/analysis/xero"
+	"unibee/utility"
+
+	"unibee/api/merchant/integration"
+)
+
+func (c *ControllerIntegration) ConnectionXero(ctx context.Context, req *integration.ConnectionXeroReq) (res *integration.ConnectionXeroRes, err error) {
+	url, err := xero.GetXeroAuthorizationUrl(ctx, &xero.ConnectionXeroInternalReq{
+		MerchantId:    _interface.GetMerchantId(ctx),
+		ReturnUrl:     req.ReturnUrl,
+		ClientId:      req.ClientId,
+		ClientSecret:  req.ClientSecret,
+		MockServerUrl: req.MockServerUrl,
+	})
+	utility.AssertError(err, "Get Xero Authorization URL Error")
+	return &integration.ConnectionXeroRes{AuthorizationURL: url}, nil
+}
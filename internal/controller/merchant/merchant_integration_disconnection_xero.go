@@ -0,0 +1,17 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/analysis/xero"
+
+	"unibee/api/merchant/integration"
+)
+
+func (c *ControllerIntegration) DisconnectionXero(ctx context.Context, req *integration.DisconnectionXeroReq) (res *integration.DisconnectionXeroRes, err error) {
+	err = xero.DisconnectMerchantXero(ctx, _interface.GetMerchantId(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &integration.DisconnectionXeroRes{}, nil
+}
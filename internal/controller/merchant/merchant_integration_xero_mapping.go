@@ -0,0 +1,20 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/analysis/xero"
+
+	"unibee/api/merchant/integration"
+)
+
+func (c *ControllerIntegration) XeroMapping(ctx context.Context, req *integration.XeroMappingReq) (res *integration.XeroMappingRes, err error) {
+	config := xero.GetMerchantXeroConfig(ctx, _interface.GetMerchantId(ctx))
+	return &integration.XeroMappingRes{
+		TenantName:    config.TenantName,
+		Connected:     config.Connected(),
+		LastSyncTime:  config.LastSyncTime,
+		LastSyncError: config.LastSyncError,
+		Mapping:       config.Mapping,
+	}, nil
+}
@@ -0,0 +1,24 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/analysis/xero"
+
+	"unibee/api/merchant/integration"
+)
+
+func (c *ControllerIntegration) XeroMappingSetup(ctx context.Context, req *integration.XeroMappingSetupReq) (res *integration.XeroMappingSetupRes, err error) {
+	mapping := &bean.XeroMapping{
+		SalesAccountCode:           req.SalesAccountCode,
+		PaymentAccountCode:         req.PaymentAccountCode,
+		GatewayPaymentAccountCodes: req.GatewayPaymentAccountCodes,
+		TaxTypes:                   req.TaxTypes,
+	}
+	err = xero.SetupMerchantXeroMapping(ctx, _interface.GetMerchantId(ctx), mapping)
+	if err != nil {
+		return nil, err
+	}
+	return &integration.XeroMappingSetupRes{Mapping: mapping}, nil
+}
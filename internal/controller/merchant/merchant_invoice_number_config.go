@@ -0,0 +1,19 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/numbering"
+
+	"unibee/api/merchant/invoice"
+)
+
+func (c *ControllerInvoice) NumberConfig(ctx context.Context, req *invoice.NumberConfigReq) (res *invoice.NumberConfigRes, err error) {
+	merchantId := _interface.GetMerchantId(ctx)
+	nextInvoiceNumber, nextCreditNoteNumber := numbering.NextNumbers(ctx, merchantId)
+	return &invoice.NumberConfigRes{
+		Config:               numbering.GetMerchantInvoiceNumberConfig(ctx, merchantId),
+		NextInvoiceNumber:    nextInvoiceNumber,
+		NextCreditNoteNumber: nextCreditNoteNumber,
+	}, nil
+}
@@ -0,0 +1,24 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/invoice/numbering"
+
+	"unibee/api/merchant/invoice"
+)
+
+func (c *ControllerInvoice) NumberConfigSetup(ctx context.Context, req *invoice.NumberConfigSetupReq) (res *invoice.NumberConfigSetupRes, err error) {
+	config, err := numbering.SetupMerchantInvoiceNumberConfig(ctx, _interface.GetMerchantId(ctx), &bean.InvoiceNumberConfig{
+		Enable:                req.Enable,
+		InvoicePattern:        req.InvoicePattern,
+		CreditNotePattern:     req.CreditNotePattern,
+		StartNumber:           req.StartNumber,
+		CreditNoteStartNumber: req.CreditNoteStartNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.NumberConfigSetupRes{Config: config}, nil
+}
@@ -0,0 +1,12 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/ledger"
+	_interface "unibee/internal/interface/context"
+	ledger2 "unibee/internal/logic/analysis/ledger"
+)
+
+func (c *ControllerLedger) AccountCodes(ctx context.Context, req *ledger.AccountCodesReq) (res *ledger.AccountCodesRes, err error) {
+	return &ledger.AccountCodesRes{AccountCodes: ledger2.GetMerchantLedgerAccountCodes(ctx, _interface.GetMerchantId(ctx))}, nil
+}
@@ -0,0 +1,16 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/ledger"
+	_interface "unibee/internal/interface/context"
+	ledger2 "unibee/internal/logic/analysis/ledger"
+)
+
+func (c *ControllerLedger) AccountCodesSetup(ctx context.Context, req *ledger.AccountCodesSetupReq) (res *ledger.AccountCodesSetupRes, err error) {
+	err = ledger2.SetupMerchantLedgerAccountCodes(ctx, _interface.GetMerchantId(ctx), req.AccountCodes)
+	if err != nil {
+		return nil, err
+	}
+	return &ledger.AccountCodesSetupRes{AccountCodes: ledger2.GetMerchantLedgerAccountCodes(ctx, _interface.GetMerchantId(ctx))}, nil
+}
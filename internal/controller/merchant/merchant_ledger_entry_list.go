@@ -0,0 +1,31 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/ledger"
+	_interface "unibee/internal/interface/context"
+	ledger2 "unibee/internal/logic/analysis/ledger"
+)
+
+func (c *ControllerLedger) EntryList(ctx context.Context, req *ledger.EntryListReq) (res *ledger.EntryListRes, err error) {
+	list, total, err := ledger2.EntryList(ctx, &ledger2.EntryListInternalReq{
+		MerchantId:     _interface.GetMerchantId(ctx),
+		EntryTypes:     req.EntryTypes,
+		Accounts:       req.Accounts,
+		Currency:       req.Currency,
+		InvoiceId:      req.InvoiceId,
+		EntryTimeStart: req.EntryTimeStart,
+		EntryTimeEnd:   req.EntryTimeEnd,
+		Page:           req.Page,
+		Count:          req.Count,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var entries = make([]*bean.LedgerEntry, 0)
+	for _, one := range list {
+		entries = append(entries, bean.SimplifyLedgerEntry(one))
+	}
+	return &ledger.EntryListRes{Entries: entries, Total: total}, nil
+}
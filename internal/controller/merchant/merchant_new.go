@@ -152,6 +152,30 @@ func NewIntegration() merchant.IMerchantIntegration {
 	return &ControllerIntegration{}
 }
 
+type ControllerLedger struct{}
+
+func NewLedger() merchant.IMerchantLedger {
+	return &ControllerLedger{}
+}
+
+type ControllerApikey struct{}
+
+func NewApikey() merchant.IMerchantApikey {
+	return &ControllerApikey{}
+}
+
+type ControllerReconciliation struct{}
+
+func NewReconciliation() merchant.IMerchantReconciliation {
+	return &ControllerReconciliation{}
+}
+
+type ControllerRevenue struct{}
+
+func NewRevenue() merchant.IMerchantRevenue {
+	return &ControllerRevenue{}
+}
+
 type ControllerTelegram struct{}
 
 func NewTelegram() merchant.IMerchantTelegram {
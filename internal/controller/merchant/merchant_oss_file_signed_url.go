@@ -0,0 +1,22 @@
+package merchant
+
+import (
+	"context"
+	"path"
+	_interface "unibee/internal/interface/context"
+	ossService "unibee/internal/logic/oss"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"unibee/api/merchant/oss"
+)
+
+func (c *ControllerOss) FileSignedUrl(ctx context.Context, req *oss.FileSignedUrlReq) (res *oss.FileSignedUrlRes, err error) {
+	one := query.GetOssFileByFileName(ctx, path.Base(req.Url))
+	utility.Assert(one != nil && one.MerchantId == _interface.GetMerchantId(ctx), "file not found")
+	signedUrl, err := ossService.GetFileSignedUrl(ctx, one)
+	if err != nil {
+		return nil, err
+	}
+	return &oss.FileSignedUrlRes{SignedUrl: signedUrl}, nil
+}
@@ -2,6 +2,7 @@ package merchant
 
 import (
 	"context"
+	_interface "unibee/internal/interface/context"
 	ossService "unibee/internal/logic/oss"
 
 	"github.com/gogf/gf/v2/errors/gcode"
@@ -17,6 +18,7 @@ func (c *ControllerOss) FileUpload(ctx context.Context, req *oss.FileUploadReq)
 	result, err := ossService.Upload(ctx, ossService.FileUploadInput{
 		File:       req.File,
 		RandomName: true,
+		MerchantId: _interface.GetMerchantId(ctx),
 	})
 	if err != nil {
 		return nil, err
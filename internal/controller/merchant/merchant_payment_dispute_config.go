@@ -0,0 +1,13 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/payment/dispute"
+
+	"unibee/api/merchant/payment"
+)
+
+func (c *ControllerPayment) DisputeConfig(ctx context.Context, req *payment.DisputeConfigReq) (res *payment.DisputeConfigRes, err error) {
+	return &payment.DisputeConfigRes{Config: dispute.GetMerchantDisputeConfig(ctx, _interface.GetMerchantId(ctx))}, nil
+}
@@ -0,0 +1,23 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/merchant_config/update"
+	"unibee/internal/logic/payment/dispute"
+	"unibee/utility"
+
+	"unibee/api/merchant/payment"
+)
+
+func (c *ControllerPayment) DisputeConfigUpdate(ctx context.Context, req *payment.DisputeConfigUpdateReq) (res *payment.DisputeConfigUpdateRes, err error) {
+	utility.Assert(req.SuspendSubscriptionOnLost != nil, "suspendSubscriptionOnLost is nil")
+	err = update.SetMerchantConfig(ctx, _interface.GetMerchantId(ctx), dispute.DisputeConfig, utility.MarshalToJsonString(&bean.DisputeConfig{
+		SuspendSubscriptionOnLost: *req.SuspendSubscriptionOnLost,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return &payment.DisputeConfigUpdateRes{Config: dispute.GetMerchantDisputeConfig(ctx, _interface.GetMerchantId(ctx))}, nil
+}
@@ -0,0 +1,13 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/payment/detail"
+
+	"unibee/api/merchant/payment"
+)
+
+func (c *ControllerPayment) DisputeDetail(ctx context.Context, req *payment.DisputeDetailReq) (res *payment.DisputeDetailRes, err error) {
+	return &payment.DisputeDetailRes{DisputeDetail: detail.GetPaymentDisputeDetail(ctx, _interface.GetMerchantId(ctx), req.DisputeId)}, nil
+}
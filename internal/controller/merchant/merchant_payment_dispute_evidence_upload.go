@@ -0,0 +1,43 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	_interface "unibee/internal/interface/context"
+	ossService "unibee/internal/logic/oss"
+	"unibee/internal/logic/payment/dispute"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"unibee/api/merchant/payment"
+)
+
+func (c *ControllerPayment) DisputeEvidenceUpload(ctx context.Context, req *payment.DisputeEvidenceUploadReq) (res *payment.DisputeEvidenceUploadRes, err error) {
+	if req.File == nil {
+		return nil, gerror.NewCode(gcode.CodeMissingParameter, "Please Specify The File")
+	}
+	one := query.GetPaymentDisputeByDisputeId(ctx, req.DisputeId)
+	utility.Assert(one != nil, "dispute not found")
+	utility.Assert(one.MerchantId == _interface.GetMerchantId(ctx), "merchant not match")
+	result, err := ossService.Upload(ctx, ossService.FileUploadInput{
+		File:       req.File,
+		Path:       "dispute",
+		RandomName: true,
+		MerchantId: _interface.GetMerchantId(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	one, err = dispute.AppendDisputeEvidence(ctx, _interface.GetMerchantId(ctx), req.DisputeId, &bean.DisputeEvidence{
+		FileName:    req.File.Filename,
+		Url:         result.Url,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &payment.DisputeEvidenceUploadRes{Dispute: bean.SimplifyPaymentDispute(one)}, nil
+}
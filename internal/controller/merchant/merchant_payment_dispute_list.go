@@ -0,0 +1,24 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/payment/dispute"
+
+	"unibee/api/merchant/payment"
+)
+
+func (c *ControllerPayment) DisputeList(ctx context.Context, req *payment.DisputeListReq) (res *payment.DisputeListRes, err error) {
+	list, total := dispute.DisputeList(ctx, &dispute.DisputeListInternalReq{
+		MerchantId:     _interface.GetMerchantId(ctx),
+		UserId:         req.UserId,
+		PaymentId:      req.PaymentId,
+		InvoiceId:      req.InvoiceId,
+		SubscriptionId: req.SubscriptionId,
+		Status:         req.Status,
+		Page:           req.Page,
+		Count:          req.Count,
+	})
+	return &payment.DisputeListRes{Disputes: bean.SimplifyPaymentDisputeList(list), Total: total}, nil
+}
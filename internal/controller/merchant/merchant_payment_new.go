@@ -218,6 +218,7 @@ func (c *ControllerPayment) New(ctx context.Context, req *payment.NewReq) (res *
 		Metadata:           req.Metadata,
 		Invoice:            invoice,
 		GatewayPaymentType: req.GatewayPaymentType,
+		GatewayChosen:      true,
 	})
 	utility.AssertError(err, "Create Gateway Payment Error:")
 	res = &payment.NewRes{
@@ -0,0 +1,16 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/plan"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/plan/price/migration"
+)
+
+func (c *ControllerPlan) PriceMigrationCancel(ctx context.Context, req *plan.PriceMigrationCancelReq) (res *plan.PriceMigrationCancelRes, err error) {
+	err = migration.CancelPlanPriceMigration(ctx, _interface.GetMerchantId(ctx), req.MigrationId)
+	if err != nil {
+		return nil, err
+	}
+	return &plan.PriceMigrationCancelRes{}, nil
+}
@@ -0,0 +1,19 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/plan"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/plan/price/migration"
+)
+
+func (c *ControllerPlan) PriceMigrationList(ctx context.Context, req *plan.PriceMigrationListReq) (res *plan.PriceMigrationListRes, err error) {
+	list, total := migration.PlanPriceMigrationList(ctx, &migration.PlanPriceMigrationListInternalReq{
+		MerchantId: _interface.GetMerchantId(ctx),
+		PlanId:     req.PlanId,
+		Status:     req.Status,
+		Page:       req.Page,
+		Count:      req.Count,
+	})
+	return &plan.PriceMigrationListRes{Migrations: list, Total: total}, nil
+}
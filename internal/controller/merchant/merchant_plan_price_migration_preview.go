@@ -0,0 +1,20 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/plan"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/plan/price/migration"
+)
+
+func (c *ControllerPlan) PriceMigrationPreview(ctx context.Context, req *plan.PriceMigrationPreviewReq) (res *plan.PriceMigrationPreviewRes, err error) {
+	report := migration.PlanPriceMigrationPreview(ctx, &migration.PlanPriceMigrationInternalReq{
+		MerchantId:  _interface.GetMerchantId(ctx),
+		PlanId:      req.PlanId,
+		FromVersion: req.FromVersion,
+		ToVersion:   req.ToVersion,
+		Mode:        req.Mode,
+		EffectTime:  req.EffectTime,
+	})
+	return &plan.PriceMigrationPreviewRes{Report: report}, nil
+}
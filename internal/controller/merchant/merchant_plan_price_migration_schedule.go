@@ -0,0 +1,29 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/plan"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/plan/price/migration"
+)
+
+func (c *ControllerPlan) PriceMigrationSchedule(ctx context.Context, req *plan.PriceMigrationScheduleReq) (res *plan.PriceMigrationScheduleRes, err error) {
+	var adminMemberId uint64 = 0
+	if _interface.Context().Get(ctx).IsAdminPortalCall {
+		adminMemberId = _interface.Context().Get(ctx).MerchantMember.Id
+	}
+	one, report, err := migration.SchedulePlanPriceMigration(ctx, &migration.PlanPriceMigrationInternalReq{
+		MerchantId:    _interface.GetMerchantId(ctx),
+		PlanId:        req.PlanId,
+		FromVersion:   req.FromVersion,
+		ToVersion:     req.ToVersion,
+		Mode:          req.Mode,
+		EffectTime:    req.EffectTime,
+		AdminMemberId: adminMemberId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &plan.PriceMigrationScheduleRes{Migration: bean.SimplifyPlanPriceMigration(one), Report: report}, nil
+}
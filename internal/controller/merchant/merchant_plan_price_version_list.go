@@ -0,0 +1,23 @@
+package merchant
+
+import (
+	"context"
+	"fmt"
+	"unibee/api/bean"
+	"unibee/api/merchant/plan"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/plan/price"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+func (c *ControllerPlan) PriceVersionList(ctx context.Context, req *plan.PriceVersionListReq) (res *plan.PriceVersionListRes, err error) {
+	one := query.GetPlanById(ctx, req.PlanId)
+	utility.Assert(one != nil, fmt.Sprintf("plan not found, id:%d", req.PlanId))
+	utility.Assert(one.MerchantId == _interface.GetMerchantId(ctx), "Merchant not match")
+	var list = make([]*bean.PlanPriceVersion, 0)
+	for _, version := range price.GetPlanPriceVersionList(ctx, one) {
+		list = append(list, bean.SimplifyPlanPriceVersion(version, price.CurrentPriceVersion(one)))
+	}
+	return &plan.PriceVersionListRes{PriceVersions: list}, nil
+}
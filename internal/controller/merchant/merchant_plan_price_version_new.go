@@ -0,0 +1,27 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/plan"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/plan/price"
+)
+
+func (c *ControllerPlan) PriceVersionNew(ctx context.Context, req *plan.PriceVersionNewReq) (res *plan.PriceVersionNewRes, err error) {
+	var adminMemberId uint64 = 0
+	if _interface.Context().Get(ctx).IsAdminPortalCall {
+		adminMemberId = _interface.Context().Get(ctx).MerchantMember.Id
+	}
+	one, err := price.NewPlanPriceVersion(ctx, &price.NewPlanPriceVersionInternalReq{
+		MerchantId:    _interface.GetMerchantId(ctx),
+		PlanId:        req.PlanId,
+		Amount:        req.Amount,
+		Description:   req.Description,
+		AdminMemberId: adminMemberId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &plan.PriceVersionNewRes{PriceVersion: bean.SimplifyPlanPriceVersion(one, one.Version)}, nil
+}
@@ -19,6 +19,7 @@ func (c *ControllerProduct) Edit(ctx context.Context, req *product.EditReq) (res
 		HomeUrl:     req.HomeUrl,
 		Status:      req.Status,
 		Metadata:    req.Metadata,
+		TaxCode:     req.TaxCode,
 	})
 	if err != nil {
 		return nil, err
@@ -18,6 +18,7 @@ func (c *ControllerProduct) New(ctx context.Context, req *product.NewReq) (res *
 		HomeUrl:     req.HomeUrl,
 		Status:      req.Status,
 		Metadata:    req.Metadata,
+		TaxCode:     req.TaxCode,
 	})
 	if err != nil {
 		return nil, err
@@ -10,9 +10,11 @@ import (
 	_interface "unibee/internal/interface/context"
 	"unibee/internal/logic/analysis/quickbooks"
 	"unibee/internal/logic/analysis/segment"
+	"unibee/internal/logic/analysis/xero"
 	"unibee/internal/logic/currency"
 	"unibee/internal/logic/email"
 	member2 "unibee/internal/logic/member"
+	"unibee/internal/logic/merchant_api_key"
 	"unibee/internal/logic/merchant_config"
 	"unibee/internal/logic/multi_currencies"
 	"unibee/internal/logic/multi_currencies/currency_exchange"
@@ -56,10 +58,11 @@ func (c *ControllerProfile) Get(ctx context.Context, req *profile.GetReq) (res *
 	if exchangeApiKeyConfig != nil {
 		exchangeApiKey = exchangeApiKeyConfig.ConfigValue
 	}
-	apikey := merchant.ApiKey
-	if config.GetConfigInstance().IsProd() {
-		apikey = utility.HideStar(merchant.ApiKey)
+	var scopedApiKeyId uint64 = 0
+	if _interface.Context().Get(ctx) != nil {
+		scopedApiKeyId = _interface.Context().Get(ctx).OpenApiKeyId
 	}
+	apikey := merchant_api_key.MasterApiKeyDisplay(merchant.ApiKey, scopedApiKeyId, config.GetConfigInstance().IsProd())
 	session := ""
 	if member != nil {
 		session, _ = member2.NewMemberSession(ctx, int64(member.Id), "")
@@ -80,6 +83,11 @@ func (c *ControllerProfile) Get(ctx context.Context, req *profile.GetReq) (res *
 	if qbConfig != nil && len(qbConfig.CompanyName) > 0 && qbConfig.BearerToken != nil && qbConfig.BearerToken.AccessToken != "" {
 		qbCompanyName = qbConfig.CompanyName
 	}
+	var xeroTenantName = ""
+	xeroConfig := xero.GetMerchantXeroConfig(ctx, merchant.Id)
+	if xeroConfig.Connected() {
+		xeroTenantName = xeroConfig.TenantName
+	}
 	analyticsHost := fmt.Sprintf("%s/analytics?session=%s", config.GetConfigInstance().Server.GetServerPath(), session)
 	if len(config.GetConfigInstance().Server.AnalyticsPath) > 0 {
 		analyticsHost = fmt.Sprintf("%s?session=%s", config.GetConfigInstance().Server.AnalyticsPath, session)
@@ -112,6 +120,8 @@ func (c *ControllerProfile) Get(ctx context.Context, req *profile.GetReq) (res *
 		QuickBooksCompanyName:        qbCompanyName,
 		QuickBooksLastSynchronized:   qbLastSynchronized,
 		QuickBooksLastSyncError:      qbLastSyncError,
+		XeroTenantName:               xeroTenantName,
+		XeroLastSyncError:            xeroConfig.LastSyncError,
 		IsOwner:                      isOwner,
 		MemberRoles:                  memberRoles,
 		AnalyticsHost:                analyticsHost,
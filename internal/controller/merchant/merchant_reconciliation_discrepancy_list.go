@@ -0,0 +1,32 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/reconciliation"
+	_interface "unibee/internal/interface/context"
+	reconciliation2 "unibee/internal/logic/payment/reconciliation"
+)
+
+func (c *ControllerReconciliation) DiscrepancyList(ctx context.Context, req *reconciliation.DiscrepancyListReq) (res *reconciliation.DiscrepancyListRes, err error) {
+	list, total, err := reconciliation2.DiscrepancyList(ctx, &reconciliation2.DiscrepancyListInternalReq{
+		MerchantId:       _interface.GetMerchantId(ctx),
+		ReconciliationId: req.ReconciliationId,
+		GatewayId:        req.GatewayId,
+		SourceType:       req.SourceType,
+		DiscrepancyTypes: req.DiscrepancyTypes,
+		ResolveStatus:    req.ResolveStatus,
+		CreateTimeStart:  req.CreateTimeStart,
+		CreateTimeEnd:    req.CreateTimeEnd,
+		Page:             req.Page,
+		Count:            req.Count,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var discrepancies = make([]*bean.GatewayDiscrepancy, 0)
+	for _, one := range list {
+		discrepancies = append(discrepancies, bean.SimplifyGatewayDiscrepancy(one))
+	}
+	return &reconciliation.DiscrepancyListRes{Discrepancies: discrepancies, Total: total}, nil
+}
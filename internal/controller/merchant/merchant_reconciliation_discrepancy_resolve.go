@@ -0,0 +1,17 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/reconciliation"
+	_interface "unibee/internal/interface/context"
+	reconciliation2 "unibee/internal/logic/payment/reconciliation"
+)
+
+func (c *ControllerReconciliation) DiscrepancyResolve(ctx context.Context, req *reconciliation.DiscrepancyResolveReq) (res *reconciliation.DiscrepancyResolveRes, err error) {
+	one, err := reconciliation2.ResolveDiscrepancy(ctx, _interface.GetMerchantId(ctx), req.Id, req.ResolveStatus, req.ResolveNote)
+	if err != nil {
+		return nil, err
+	}
+	return &reconciliation.DiscrepancyResolveRes{Discrepancy: bean.SimplifyGatewayDiscrepancy(one)}, nil
+}
@@ -0,0 +1,26 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/reconciliation"
+	_interface "unibee/internal/interface/context"
+	reconciliation2 "unibee/internal/logic/payment/reconciliation"
+)
+
+func (c *ControllerReconciliation) List(ctx context.Context, req *reconciliation.ListReq) (res *reconciliation.ListRes, err error) {
+	list, total, err := reconciliation2.ReconciliationList(ctx, &reconciliation2.ReconciliationListInternalReq{
+		MerchantId: _interface.GetMerchantId(ctx),
+		GatewayId:  req.GatewayId,
+		Page:       req.Page,
+		Count:      req.Count,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var reconciliations = make([]*bean.GatewayReconciliation, 0)
+	for _, one := range list {
+		reconciliations = append(reconciliations, bean.SimplifyGatewayReconciliation(one))
+	}
+	return &reconciliation.ListRes{Reconciliations: reconciliations, Total: total}, nil
+}
@@ -0,0 +1,31 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/merchant/reconciliation"
+	_interface "unibee/internal/interface/context"
+	reconciliation2 "unibee/internal/logic/payment/reconciliation"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+func (c *ControllerReconciliation) Run(ctx context.Context, req *reconciliation.RunReq) (res *reconciliation.RunRes, err error) {
+	gateway := query.GetGatewayById(ctx, req.GatewayId)
+	utility.Assert(gateway != nil && gateway.MerchantId == _interface.GetMerchantId(ctx), "gateway not found")
+	windowEnd := req.WindowEnd
+	if windowEnd <= 0 {
+		windowEnd = gtime.Now().Timestamp()
+	}
+	windowStart := req.WindowStart
+	if windowStart <= 0 {
+		windowStart = windowEnd - reconciliation2.DefaultWindowDays*86400
+	}
+	one, err := reconciliation2.StartReconciliation(ctx, gateway, windowStart, windowEnd, reconciliation2.TriggerManual)
+	if err != nil {
+		return nil, err
+	}
+	return &reconciliation.RunRes{Reconciliation: bean.SimplifyGatewayReconciliation(one)}, nil
+}
@@ -0,0 +1,23 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/revenue"
+	_interface "unibee/internal/interface/context"
+	revenue2 "unibee/internal/logic/analysis/revenue"
+)
+
+func (c *ControllerRevenue) Churn(ctx context.Context, req *revenue.ChurnReq) (res *revenue.ChurnRes, err error) {
+	churn, err := revenue2.GetChurnMetrics(ctx, &revenue2.RevenueQueryInternalReq{
+		MerchantId:        _interface.GetMerchantId(ctx),
+		StartTime:         req.StartTime,
+		EndTime:           req.EndTime,
+		PlanIds:           req.PlanIds,
+		Currency:          req.Currency,
+		ReportingCurrency: req.ReportingCurrency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &revenue.ChurnRes{Churn: churn}, nil
+}
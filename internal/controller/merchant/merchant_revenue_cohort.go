@@ -0,0 +1,23 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/revenue"
+	_interface "unibee/internal/interface/context"
+	revenue2 "unibee/internal/logic/analysis/revenue"
+)
+
+func (c *ControllerRevenue) Cohort(ctx context.Context, req *revenue.CohortReq) (res *revenue.CohortRes, err error) {
+	cohorts, unconverted, err := revenue2.GetCohortRetention(ctx, &revenue2.RevenueQueryInternalReq{
+		MerchantId:        _interface.GetMerchantId(ctx),
+		StartTime:         req.StartTime,
+		EndTime:           req.EndTime,
+		PlanIds:           req.PlanIds,
+		Currency:          req.Currency,
+		ReportingCurrency: req.ReportingCurrency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &revenue.CohortRes{Cohorts: cohorts, UnconvertedCurrencies: unconverted}, nil
+}
@@ -0,0 +1,23 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/revenue"
+	_interface "unibee/internal/interface/context"
+	revenue2 "unibee/internal/logic/analysis/revenue"
+)
+
+func (c *ControllerRevenue) Mrr(ctx context.Context, req *revenue.MrrReq) (res *revenue.MrrRes, err error) {
+	points, summary, err := revenue2.GetMrrMetrics(ctx, &revenue2.RevenueQueryInternalReq{
+		MerchantId:        _interface.GetMerchantId(ctx),
+		StartTime:         req.StartTime,
+		EndTime:           req.EndTime,
+		PlanIds:           req.PlanIds,
+		Currency:          req.Currency,
+		ReportingCurrency: req.ReportingCurrency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &revenue.MrrRes{Summary: summary, Points: points}, nil
+}
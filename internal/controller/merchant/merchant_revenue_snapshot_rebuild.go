@@ -0,0 +1,20 @@
+package merchant
+
+import (
+	"context"
+	"github.com/gogf/gf/v2/os/gtime"
+	"unibee/api/merchant/revenue"
+	_interface "unibee/internal/interface/context"
+	revenue2 "unibee/internal/logic/analysis/revenue"
+	"unibee/utility"
+)
+
+func (c *ControllerRevenue) SnapshotRebuild(ctx context.Context, req *revenue.SnapshotRebuildReq) (res *revenue.SnapshotRebuildRes, err error) {
+	if req.EndTime <= 0 || req.EndTime > gtime.Now().Timestamp() {
+		req.EndTime = gtime.Now().Timestamp()
+	}
+	utility.Assert(req.StartTime > 0 && req.StartTime <= req.EndTime, "invalid startTime")
+	utility.Assert(req.EndTime-req.StartTime <= 731*86400, "time range should be within 2 years")
+	revenue2.RebuildMerchantRevenueSnapshotsBackground(_interface.GetMerchantId(ctx), req.StartTime, req.EndTime)
+	return &revenue.SnapshotRebuildRes{}, nil
+}
@@ -0,0 +1,49 @@
+package merchant
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	_scenario "unibee/api/merchant/scenario"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/scenario"
+)
+
+const maxScenarioRunWaitSeconds = 30
+
+func (c *ControllerScenario) Run(ctx context.Context, req *_scenario.RunReq) (res *_scenario.RunRes, err error) {
+	merchantId := _interface.GetMerchantId(ctx)
+
+	execId, err := scenario.StartManualScenario(ctx, &scenario.ManualRunRequest{
+		MerchantId:     merchantId,
+		ScenarioId:     req.ScenarioId,
+		UserId:         req.UserId,
+		SubscriptionId: req.SubscriptionId,
+		Input:          req.Input,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res = &_scenario.RunRes{ExecutionId: execId, Status: scenario.StatusRunning}
+	if req.WaitSeconds <= 0 {
+		return res, nil
+	}
+	wait := req.WaitSeconds
+	if wait > maxScenarioRunWaitSeconds {
+		wait = maxScenarioRunWaitSeconds
+	}
+
+	exec, err := scenario.WaitExecution(ctx, execId, time.Duration(wait)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	res.Status = exec.Status
+	res.ErrorMessage = exec.ErrorMessage
+	res.Finished = exec.Status == scenario.StatusCompleted || exec.Status == scenario.StatusFailed
+	if res.Finished && exec.Variables != "" {
+		_ = json.Unmarshal([]byte(exec.Variables), &res.Variables)
+	}
+	return res, nil
+}
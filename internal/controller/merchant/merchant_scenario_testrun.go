@@ -28,10 +28,11 @@ func (c *ControllerScenario) TestRun(ctx context.Context, req *_scenario.TestRun
 		}
 	}
 
-	// Run in background
-	go scenario.RunScenarioByIds(ctx, merchantId, sc.Id, sc.ScenarioJson, triggerData)
+	// Steps run in background, the execution record is created right away
+	execId, err := scenario.StartScenarioExecution(ctx, merchantId, sc.Id, sc.ScenarioJson, triggerData)
+	if err != nil {
+		return nil, err
+	}
 
-	// We return a placeholder execId — the real one will be created asynchronously.
-	// In a future version, we can make this synchronous.
-	return &_scenario.TestRunRes{ExecutionId: 0}, nil
+	return &_scenario.TestRunRes{ExecutionId: execId}, nil
 }
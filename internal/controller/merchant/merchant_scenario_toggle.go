@@ -22,6 +22,10 @@ func (c *ControllerScenario) Toggle(ctx context.Context, req *_scenario.ToggleRe
 			_ = scenario.StartBotPolling(ctx, merchantId)
 		}
 	}
+	// A re-enabled schedule starts from now instead of reporting the disabled period as missed runs
+	if sc != nil && sc.TriggerType == scenario.TriggerSchedule && req.Enabled {
+		_ = scenario.DeleteScheduleState(ctx, req.ScenarioId)
+	}
 
 	return &_scenario.ToggleRes{}, nil
 }
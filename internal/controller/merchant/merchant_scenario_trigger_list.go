@@ -26,12 +26,12 @@ func (c *ControllerScenario) TriggerList(ctx context.Context, req *_scenario.Tri
 		{
 			Type:        "schedule",
 			Name:        "Schedule (Cron)",
-			Description: "Triggered on a cron schedule (e.g. every day at 9:00)",
+			Description: "Triggered on a cron schedule in the merchant time zone (e.g. \"0 9 * * *\" every day at 9:00). Optional timezone, missedRuns (skip|catch_up) and maxCatchUp",
 		},
 		{
 			Type:        "manual",
 			Name:        "Manual",
-			Description: "Triggered manually from the admin panel",
+			Description: "Triggered from the admin panel or the /merchant/scenario/run API for a user or subscription, with typed inputs declared in \"inputs\"",
 		},
 	}
 
@@ -94,7 +94,7 @@ func (c *ControllerSubscription) ActiveSubscriptionImport(ctx context.Context, r
 	}
 	taxPercentage := user.TaxPercentage
 	if vat_gateway.GetDefaultVatGateway(ctx, user.MerchantId).VatRatesEnabled() {
-		taxPercentage, _ = vat_gateway.ComputeMerchantVatPercentage(ctx, merchantId, countryCode, gatewayId, vatNumber)
+		taxPercentage, _ = vat_gateway.ComputeUserTaxPercentage(ctx, merchantId, user, countryCode, gatewayId, vatNumber, plan.Id)
 	} else {
 		taxPercentage = req.TaxPercentage
 	}
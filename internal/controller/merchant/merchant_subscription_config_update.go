@@ -56,6 +56,24 @@ func (c *ControllerSubscription) ConfigUpdate(ctx context.Context, req *subscrip
 			return nil, err
 		}
 	}
+	if req.PauseResumeBehavior != nil {
+		utility.Assert(*req.PauseResumeBehavior == config.PauseResumeBehaviorShift || *req.PauseResumeBehavior == config.PauseResumeBehaviorProrate, "Value should be shift or prorate")
+		err = update.SetMerchantConfig(ctx, _interface.GetMerchantId(ctx), config.PauseResumeBehavior, *req.PauseResumeBehavior)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.DunningPolicy != nil {
+		var value = ""
+		if len(req.DunningPolicy.Attempts) > 0 {
+			config.CheckDunningPolicy(req.DunningPolicy)
+			value = utility.MarshalToJsonString(req.DunningPolicy)
+		}
+		err = update.SetMerchantConfig(ctx, _interface.GetMerchantId(ctx), config.DunningPolicy, value)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return &subscription.ConfigUpdateRes{Config: config.GetMerchantSubscriptionConfig(ctx, _interface.GetMerchantId(ctx))}, nil
 }
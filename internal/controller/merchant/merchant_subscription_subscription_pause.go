@@ -0,0 +1,31 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/service"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+func (c *ControllerSubscription) Pause(ctx context.Context, req *subscription.PauseReq) (res *subscription.PauseRes, err error) {
+	if len(req.SubscriptionId) == 0 {
+		utility.Assert(req.UserId > 0, "one of SubscriptionId and UserId should provide")
+		one := query.GetLatestActiveOrIncompleteSubscriptionByUserId(ctx, req.UserId, _interface.GetMerchantId(ctx), req.ProductId)
+		utility.Assert(one != nil, "no active or incomplete subscription found")
+		req.SubscriptionId = one.SubscriptionId
+	}
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil && sub.MerchantId == _interface.GetMerchantId(ctx), "subscription not found")
+	err = service.SubscriptionPause(ctx, &service.PauseInternalReq{
+		SubscriptionId: req.SubscriptionId,
+		AtPeriodEnd:    req.AtPeriodEnd,
+		AutoResumeTime: req.AutoResumeTime,
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.PauseRes{}, nil
+}
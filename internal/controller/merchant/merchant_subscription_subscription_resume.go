@@ -0,0 +1,26 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/merchant/subscription"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/service"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+func (c *ControllerSubscription) Resume(ctx context.Context, req *subscription.ResumeReq) (res *subscription.ResumeRes, err error) {
+	if len(req.SubscriptionId) == 0 {
+		utility.Assert(req.UserId > 0, "one of SubscriptionId and UserId should provide")
+		one := query.GetLatestSubscriptionByUserId(ctx, req.UserId, _interface.GetMerchantId(ctx), req.ProductId)
+		utility.Assert(one != nil, "no subscription found")
+		req.SubscriptionId = one.SubscriptionId
+	}
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil && sub.MerchantId == _interface.GetMerchantId(ctx), "subscription not found")
+	err = service.SubscriptionResume(ctx, req.SubscriptionId, "")
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.ResumeRes{}, nil
+}
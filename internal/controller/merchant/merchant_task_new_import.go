@@ -23,6 +23,7 @@ func (c *ControllerTask) NewImport(ctx context.Context, req *task.NewImportReq)
 	result, err := ossService.Upload(ctx, ossService.FileUploadInput{
 		File:       req.File,
 		RandomName: true,
+		MerchantId: _interface.GetMerchantId(ctx),
 	})
 	if err != nil {
 		return nil, err
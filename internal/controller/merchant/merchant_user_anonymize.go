@@ -0,0 +1,17 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/user/gdpr"
+
+	"unibee/api/merchant/user"
+)
+
+func (c *ControllerUser) Anonymize(ctx context.Context, req *user.AnonymizeReq) (res *user.AnonymizeRes, err error) {
+	err = gdpr.AnonymizeUser(ctx, _interface.GetMerchantId(ctx), req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &user.AnonymizeRes{}, nil
+}
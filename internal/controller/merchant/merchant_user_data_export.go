@@ -0,0 +1,20 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/user/gdpr"
+
+	"unibee/api/merchant/user"
+)
+
+func (c *ControllerUser) DataExport(ctx context.Context, req *user.DataExportReq) (res *user.DataExportRes, err error) {
+	result, err := gdpr.ExportUserData(ctx, &gdpr.UserDataExportInternalReq{
+		MerchantId: _interface.GetMerchantId(ctx),
+		UserId:     req.UserId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user.DataExportRes{FileName: result.FileName, DownloadUrl: result.DownloadUrl}, nil
+}
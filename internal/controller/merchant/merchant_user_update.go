@@ -7,6 +7,7 @@ import (
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gtime"
 	redismq "github.com/jackyang-hk/go-redismq"
+	"strings"
 	"unibee/api/bean/detail"
 	"unibee/api/merchant/user"
 	"unibee/internal/cmd/i18n"
@@ -85,24 +86,37 @@ func (c *ControllerUser) Update(ctx context.Context, req *user.UpdateReq) (res *
 	if req.Type != nil {
 		utility.Assert(*req.Type == 1 || *req.Type == 2, "invalid Type, 1-Individual|2-Business")
 	}
+	if req.State != nil {
+		req.State = unibee.String(strings.ToUpper(strings.TrimSpace(*req.State)))
+	}
+	if req.TaxExempt != nil {
+		utility.Assert(*req.TaxExempt == 0 || *req.TaxExempt == 1, "invalid taxExempt, 0-taxable|1-tax exempt")
+		if *req.TaxExempt == 1 {
+			utility.Assert((req.TaxExemptCertificateId != nil && len(*req.TaxExemptCertificateId) > 0) ||
+				(req.TaxExemptCertificateId == nil && len(one.TaxExemptCertificateId) > 0), "taxExemptCertificateId required for tax exempt user")
+		}
+	}
 	_, err = dao.UserAccount.Ctx(ctx).Data(g.Map{
-		dao.UserAccount.Columns().Type:            req.Type,
-		dao.UserAccount.Columns().LastName:        req.LastName,
-		dao.UserAccount.Columns().FirstName:       req.FirstName,
-		dao.UserAccount.Columns().Address:         req.Address,
-		dao.UserAccount.Columns().CompanyName:     req.CompanyName,
-		dao.UserAccount.Columns().VATNumber:       req.VATNumber,
-		dao.UserAccount.Columns().Phone:           req.Phone,
-		dao.UserAccount.Columns().Telegram:        req.Telegram,
-		dao.UserAccount.Columns().WhatsAPP:        req.WhatsApp,
-		dao.UserAccount.Columns().WeChat:          req.WeChat,
-		dao.UserAccount.Columns().LinkedIn:        req.LinkedIn,
-		dao.UserAccount.Columns().Facebook:        req.Facebook,
-		dao.UserAccount.Columns().TikTok:          req.TikTok,
-		dao.UserAccount.Columns().OtherSocialInfo: req.OtherSocialInfo,
-		dao.UserAccount.Columns().City:            req.City,
-		dao.UserAccount.Columns().ZipCode:         req.ZipCode,
-		dao.UserAccount.Columns().Language:        req.Language,
+		dao.UserAccount.Columns().Type:                   req.Type,
+		dao.UserAccount.Columns().LastName:               req.LastName,
+		dao.UserAccount.Columns().FirstName:              req.FirstName,
+		dao.UserAccount.Columns().Address:                req.Address,
+		dao.UserAccount.Columns().CompanyName:            req.CompanyName,
+		dao.UserAccount.Columns().VATNumber:              req.VATNumber,
+		dao.UserAccount.Columns().Phone:                  req.Phone,
+		dao.UserAccount.Columns().Telegram:               req.Telegram,
+		dao.UserAccount.Columns().WhatsAPP:               req.WhatsApp,
+		dao.UserAccount.Columns().WeChat:                 req.WeChat,
+		dao.UserAccount.Columns().LinkedIn:               req.LinkedIn,
+		dao.UserAccount.Columns().Facebook:               req.Facebook,
+		dao.UserAccount.Columns().TikTok:                 req.TikTok,
+		dao.UserAccount.Columns().OtherSocialInfo:        req.OtherSocialInfo,
+		dao.UserAccount.Columns().City:                   req.City,
+		dao.UserAccount.Columns().ZipCode:                req.ZipCode,
+		dao.UserAccount.Columns().State:                  req.State,
+		dao.UserAccount.Columns().TaxExempt:              req.TaxExempt,
+		dao.UserAccount.Columns().TaxExemptCertificateId: req.TaxExemptCertificateId,
+		dao.UserAccount.Columns().Language:               req.Language,
 		//dao.UserAccount.Columns().ReMark:             req.GatewayPaymentType,
 		dao.UserAccount.Columns().RegistrationNumber: req.RegistrationNumber,
 		dao.UserAccount.Columns().GmtModify:          gtime.Now(),
@@ -132,6 +146,9 @@ func (c *ControllerUser) Update(ctx context.Context, req *user.UpdateReq) (res *
 			dao.UserAccount.Columns().MetaData: utility.MarshalToJsonString(metadata),
 		}).Where(dao.UserAccount.Columns().Id, req.UserId).OmitNil().Update()
 	}
+	if req.State != nil || req.ZipCode != nil || req.TaxExempt != nil {
+		sub_update.RefreshUserTaxPercentage(ctx, *req.UserId)
+	}
 	one = query.GetUserAccountById(ctx, *req.UserId)
 	_, _ = redismq.Send(&redismq.Message{
 		Topic:      redismq2.TopicUserAccountUpdate.Topic,
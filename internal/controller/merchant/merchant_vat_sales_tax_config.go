@@ -0,0 +1,13 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/vat_gateway"
+
+	"unibee/api/merchant/vat"
+)
+
+func (c *ControllerVat) SalesTaxConfig(ctx context.Context, req *vat.SalesTaxConfigReq) (res *vat.SalesTaxConfigRes, err error) {
+	return &vat.SalesTaxConfigRes{Config: vat_gateway.GetMerchantSalesTaxConfig(ctx, _interface.GetMerchantId(ctx))}, nil
+}
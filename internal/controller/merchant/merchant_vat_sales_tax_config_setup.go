@@ -0,0 +1,23 @@
+package merchant
+
+import (
+	"context"
+	"unibee/api/bean"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/vat_gateway/setup"
+
+	"unibee/api/merchant/vat"
+)
+
+func (c *ControllerVat) SalesTaxConfigSetup(ctx context.Context, req *vat.SalesTaxConfigSetupReq) (res *vat.SalesTaxConfigSetupRes, err error) {
+	config, err := setup.SetupMerchantSalesTaxConfig(ctx, _interface.GetMerchantId(ctx), &bean.SalesTaxConfig{
+		Enable:       req.Enable,
+		Provider:     req.Provider,
+		NexusRegions: req.NexusRegions,
+		Rules:        req.Rules,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &vat.SalesTaxConfigSetupRes{Config: config}, nil
+}
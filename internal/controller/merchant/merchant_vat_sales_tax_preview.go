@@ -0,0 +1,41 @@
+package merchant
+
+import (
+	"context"
+	"math"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/vat_gateway"
+	"unibee/internal/logic/vat_gateway/sales_tax"
+	"unibee/utility"
+
+	"unibee/api/merchant/vat"
+)
+
+func (c *ControllerVat) SalesTaxPreview(ctx context.Context, req *vat.SalesTaxPreviewReq) (res *vat.SalesTaxPreviewRes, err error) {
+	utility.Assert(sales_tax.IsValidTaxCode(req.TaxCode), "taxCode should be "+sales_tax.TAX_CODES)
+	config := vat_gateway.GetMerchantSalesTaxConfig(ctx, _interface.GetMerchantId(ctx))
+	provider := vat_gateway.GetSalesTaxProvider(config.Provider)
+	utility.Assert(provider != nil, "sales tax provider not support:"+config.Provider)
+	utility.Assert(provider.SupportCountry(req.CountryCode), "country not supported by sales tax provider:"+req.CountryCode)
+	result, err := provider.ResolveTax(ctx, &sales_tax.TaxResolveReq{
+		Address: &sales_tax.TaxAddress{
+			CountryCode: req.CountryCode,
+			State:       req.State,
+			ZipCode:     req.ZipCode,
+		},
+		TaxCode: req.TaxCode,
+		Rules:   config.Rules,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if req.TaxableAmount <= 0 {
+		req.TaxableAmount = 10000
+	}
+	taxAmount := int64(math.Round(float64(req.TaxableAmount) * utility.ConvertTaxPercentageToInternalFloat(result.TaxPercentage)))
+	return &vat.SalesTaxPreviewRes{
+		TaxPercentage: result.TaxPercentage,
+		InNexus:       sales_tax.InNexus(config.NexusRegions, req.CountryCode, req.State),
+		TaxLines:      sales_tax.SplitTaxLines(result, result.TaxPercentage, req.TaxableAmount, taxAmount),
+	}, nil
+}
@@ -0,0 +1,17 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	_webhook "unibee/internal/logic/webhook"
+
+	"unibee/api/merchant/webhook"
+)
+
+func (c *ControllerWebhook) EnableEndpoint(ctx context.Context, req *webhook.EnableEndpointReq) (res *webhook.EnableEndpointRes, err error) {
+	err = _webhook.EnableMerchantWebhookEndpoint(ctx, _interface.GetMerchantId(ctx), req.EndpointId)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook.EnableEndpointRes{}, nil
+}
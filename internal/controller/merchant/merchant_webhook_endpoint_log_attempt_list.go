@@ -0,0 +1,13 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	_webhook "unibee/internal/logic/webhook"
+
+	"unibee/api/merchant/webhook"
+)
+
+func (c *ControllerWebhook) EndpointLogAttemptList(ctx context.Context, req *webhook.EndpointLogAttemptListReq) (res *webhook.EndpointLogAttemptListRes, err error) {
+	return &webhook.EndpointLogAttemptListRes{AttemptList: _webhook.MerchantWebhookEndpointLogAttemptList(ctx, _interface.GetMerchantId(ctx), req.LogId)}, nil
+}
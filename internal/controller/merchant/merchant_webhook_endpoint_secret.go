@@ -0,0 +1,13 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	_webhook "unibee/internal/logic/webhook"
+
+	"unibee/api/merchant/webhook"
+)
+
+func (c *ControllerWebhook) EndpointSecret(ctx context.Context, req *webhook.EndpointSecretReq) (res *webhook.EndpointSecretRes, err error) {
+	return &webhook.EndpointSecretRes{EndpointSecret: _webhook.GetMerchantWebhookEndpointSecret(ctx, _interface.GetMerchantId(ctx), req.EndpointId)}, nil
+}
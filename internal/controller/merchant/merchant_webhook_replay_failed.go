@@ -0,0 +1,17 @@
+package merchant
+
+import (
+	"context"
+	"unibee/internal/consumer/webhook/message"
+	_interface "unibee/internal/interface/context"
+
+	"unibee/api/merchant/webhook"
+)
+
+func (c *ControllerWebhook) ReplayFailed(ctx context.Context, req *webhook.ReplayFailedReq) (res *webhook.ReplayFailedRes, err error) {
+	count, err := message.ReplayFailedWebhooks(ctx, _interface.GetMerchantId(ctx), req.EndpointId, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook.ReplayFailedRes{Count: count}, nil
+}
@@ -0,0 +1,13 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/webhook/config"
+
+	"unibee/api/merchant/webhook"
+)
+
+func (c *ControllerWebhook) RetryPolicy(ctx context.Context, req *webhook.RetryPolicyReq) (res *webhook.RetryPolicyRes, err error) {
+	return &webhook.RetryPolicyRes{RetryPolicy: config.GetMerchantWebhookRetryPolicy(ctx, _interface.GetMerchantId(ctx))}, nil
+}
@@ -0,0 +1,20 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/merchant_config/update"
+	"unibee/internal/logic/webhook/config"
+	"unibee/utility"
+
+	"unibee/api/merchant/webhook"
+)
+
+func (c *ControllerWebhook) RetryPolicyUpdate(ctx context.Context, req *webhook.RetryPolicyUpdateReq) (res *webhook.RetryPolicyUpdateRes, err error) {
+	config.CheckWebhookRetryPolicy(req.RetryPolicy)
+	err = update.SetMerchantConfig(ctx, _interface.GetMerchantId(ctx), config.WebhookRetryPolicy, utility.MarshalToJsonString(req.RetryPolicy))
+	if err != nil {
+		return nil, err
+	}
+	return &webhook.RetryPolicyUpdateRes{RetryPolicy: config.GetMerchantWebhookRetryPolicy(ctx, _interface.GetMerchantId(ctx))}, nil
+}
@@ -0,0 +1,17 @@
+package merchant
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	_webhook "unibee/internal/logic/webhook"
+
+	"unibee/api/merchant/webhook"
+)
+
+func (c *ControllerWebhook) RotateEndpointSecret(ctx context.Context, req *webhook.RotateEndpointSecretReq) (res *webhook.RotateEndpointSecretRes, err error) {
+	secret, err := _webhook.RotateMerchantWebhookEndpointSecret(ctx, _interface.GetMerchantId(ctx), req.EndpointId, req.OverlapSeconds)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook.RotateEndpointSecretRes{EndpointSecret: secret}, nil
+}
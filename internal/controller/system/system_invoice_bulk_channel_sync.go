@@ -2,11 +2,29 @@ package system
 
 import (
 	"context"
-	"github.com/gogf/gf/v2/errors/gcode"
-	"github.com/gogf/gf/v2/errors/gerror"
+	"strconv"
 	"unibee/api/system/invoice"
+	"unibee/internal/logic/payment/reconciliation"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
 )
 
 func (c *ControllerInvoice) BulkChannelSync(ctx context.Context, req *invoice.BulkChannelSyncReq) (res *invoice.BulkChannelSyncRes, err error) {
-	return nil, gerror.NewCode(gcode.CodeNotImplemented)
+	merchantId, err := strconv.ParseUint(req.MerchantId, 10, 64)
+	utility.Assert(err == nil && merchantId > 0, "merchantId invalid")
+	windowEnd := gtime.Now().Timestamp()
+	windowStart := windowEnd - reconciliation.MaxWindowDays*86400
+	for _, gateway := range query.GetMerchantGatewayList(ctx, merchantId, nil) {
+		if !reconciliation.SupportReconciliation(gateway) {
+			continue
+		}
+		_, err = reconciliation.StartReconciliation(ctx, gateway, windowStart, windowEnd, reconciliation.TriggerManual)
+		if err != nil {
+			g.Log().Errorf(ctx, "BulkChannelSync merchantId:%d gatewayId:%d error:%s", merchantId, gateway.Id, err.Error())
+		}
+	}
+	return &invoice.BulkChannelSyncRes{}, nil
 }
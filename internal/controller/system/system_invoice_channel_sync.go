@@ -2,11 +2,22 @@ package system
 
 import (
 	"context"
-	"github.com/gogf/gf/v2/errors/gcode"
-	"github.com/gogf/gf/v2/errors/gerror"
+	"strconv"
 	"unibee/api/system/invoice"
+	"unibee/internal/logic/payment/reconciliation"
+	"unibee/internal/query"
+	"unibee/utility"
 )
 
 func (c *ControllerInvoice) ChannelSync(ctx context.Context, req *invoice.ChannelSyncReq) (res *invoice.ChannelSyncRes, err error) {
-	return nil, gerror.NewCode(gcode.CodeNotImplemented)
+	merchantId, err := strconv.ParseUint(req.MerchantId, 10, 64)
+	utility.Assert(err == nil && merchantId > 0, "merchantId invalid")
+	one := query.GetInvoiceByInvoiceId(ctx, req.InvoiceId)
+	utility.Assert(one != nil && one.MerchantId == merchantId, "invoice not found")
+	utility.Assert(len(one.PaymentId) > 0, "invoice has no payment")
+	_, err = reconciliation.ReconcilePayment(ctx, query.GetPaymentByPaymentId(ctx, one.PaymentId))
+	if err != nil {
+		return nil, err
+	}
+	return &invoice.ChannelSyncRes{}, nil
 }
@@ -0,0 +1,23 @@
+package user
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/user/gdpr"
+	"unibee/utility"
+
+	"unibee/api/user/profile"
+)
+
+func (c *ControllerProfile) DataExport(ctx context.Context, req *profile.DataExportReq) (res *profile.DataExportRes, err error) {
+	utility.Assert(_interface.Context().Get(ctx).User != nil, "User Not Found")
+	result, err := gdpr.ExportUserData(ctx, &gdpr.UserDataExportInternalReq{
+		MerchantId: _interface.GetMerchantId(ctx),
+		UserId:     _interface.Context().Get(ctx).User.Id,
+		ByUser:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &profile.DataExportRes{FileName: result.FileName, DownloadUrl: result.DownloadUrl}, nil
+}
@@ -0,0 +1,28 @@
+package user
+
+import (
+	"context"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/service"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"unibee/api/user/subscription"
+)
+
+func (c *ControllerSubscription) Pause(ctx context.Context, req *subscription.PauseReq) (res *subscription.PauseRes, err error) {
+	utility.Assert(_interface.Context().Get(ctx).User != nil, "auth failure,not login")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.UserId == _interface.Context().Get(ctx).User.Id, "userId not match")
+	err = service.SubscriptionPause(ctx, &service.PauseInternalReq{
+		SubscriptionId: req.SubscriptionId,
+		AtPeriodEnd:    req.AtPeriodEnd,
+		AutoResumeTime: req.AutoResumeTime,
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.PauseRes{}, nil
+}
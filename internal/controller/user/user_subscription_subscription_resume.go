@@ -2,13 +2,23 @@ package user
 
 import (
 	"context"
-
-	"github.com/gogf/gf/v2/errors/gcode"
-	"github.com/gogf/gf/v2/errors/gerror"
+	_interface "unibee/internal/interface/context"
+	"unibee/internal/logic/subscription/service"
+	"unibee/internal/query"
+	"unibee/utility"
 
 	"unibee/api/user/subscription"
 )
 
 func (c *ControllerSubscription) Resume(ctx context.Context, req *subscription.ResumeReq) (res *subscription.ResumeRes, err error) {
-	return nil, gerror.NewCode(gcode.CodeNotImplemented)
+	utility.Assert(_interface.Context().Get(ctx).User != nil, "auth failure,not login")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.UserId == _interface.Context().Get(ctx).User.Id, "userId not match")
+	utility.Assert(service.IsUserResumable(sub), "subscription suspended by merchant, please contact merchant to resume")
+	err = service.SubscriptionResume(ctx, req.SubscriptionId, "")
+	if err != nil {
+		return nil, err
+	}
+	return &subscription.ResumeRes{}, nil
 }
@@ -0,0 +1,40 @@
+package credit
+
+import (
+	"context"
+	"fmt"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/credit/grant"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+func TaskForExpireCreditGrants(ctx context.Context) {
+	var list []*entity.CreditGrant
+	err := dao.CreditGrant.Ctx(ctx).
+		Where(dao.CreditGrant.Columns().Status, consts.CreditGrantActive).
+		Where(dao.CreditGrant.Columns().IsDeleted, 0).
+		WhereGT(dao.CreditGrant.Columns().ExpireTime, 0).
+		WhereLTE(dao.CreditGrant.Columns().ExpireTime, gtime.Now().Timestamp()).
+		OrderAsc(dao.CreditGrant.Columns().ExpireTime).
+		Limit(0, 500).
+		Scan(&list)
+	if err != nil {
+		g.Log().Errorf(ctx, "TaskForExpireCreditGrants error:%s", err.Error())
+		return
+	}
+	for _, one := range list {
+		key := fmt.Sprintf("TaskForExpireCreditGrants-%s", one.GrantId)
+		if !utility.TryLock(ctx, key, 60) {
+			continue
+		}
+		err = grant.ExpireCreditGrant(ctx, one.GrantId)
+		if err != nil {
+			g.Log().Errorf(ctx, "TaskForExpireCreditGrants grantId:%s error:%s", one.GrantId, err.Error())
+		}
+		utility.ReleaseLock(ctx, key)
+	}
+}
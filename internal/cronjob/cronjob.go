@@ -7,14 +7,19 @@ import (
 	"github.com/gogf/gf/v2/os/gctx"
 	"unibee/internal/cmd/config"
 	"unibee/internal/cronjob/batch"
+	"unibee/internal/cronjob/credit"
 	"unibee/internal/cronjob/discount"
 	"unibee/internal/cronjob/email"
 	"unibee/internal/cronjob/gateway_log"
 	"unibee/internal/cronjob/invoice"
 	"unibee/internal/cronjob/multi_currency"
+	"unibee/internal/cronjob/payment"
+	"unibee/internal/cronjob/plan"
 	"unibee/internal/cronjob/statistics"
 	"unibee/internal/cronjob/sub"
+	"unibee/internal/cronjob/user"
 	"unibee/internal/cronjob/vat"
+	"unibee/internal/cronjob/webhook"
 	"unibee/internal/logic/member"
 	"unibee/internal/logic/merchant"
 )
@@ -45,13 +50,32 @@ func StartCronJobs() {
 	var other1MinTask = "Job1MinTask"
 	_, err = gcron.Add(ctx, "@every 1m", func(ctx context.Context) {
 		discount.TaskForExpireDiscounts(ctx)
+		credit.TaskForExpireCreditGrants(ctx)
 		invoice.TaskForExpireInvoices(ctx)
 		//payment.TaskForCancelExpiredPayment(ctx)
 		batch.TaskForExpireBatchTasks(ctx)
+		user.TaskForExpireUserDataExports(ctx)
+		sub.TaskForSubscriptionAutoResume(ctx, other1MinTask)
 	}, other1MinTask)
 	if err != nil {
 		g.Log().Errorf(ctx, "StartCronJobs Name:%s Err:%s\n", other1MinTask, err.Error())
 	}
+	// every 1 min, singleton as one migration may run longer than the interval
+	var planPriceMigrationTask = "JobPlanPriceMigration"
+	_, err = gcron.AddSingleton(ctx, "@every 1m", func(ctx context.Context) {
+		plan.TaskForExecutePlanPriceMigrations(ctx)
+	}, planPriceMigrationTask)
+	if err != nil {
+		g.Log().Errorf(ctx, "StartCronJobs Name:%s Err:%s\n", planPriceMigrationTask, err.Error())
+	}
+	// every 1 min, singleton as one round may run longer than the interval
+	var webhookRedeliveryTask = "JobWebhookRedelivery"
+	_, err = gcron.AddSingleton(ctx, "@every 1m", func(ctx context.Context) {
+		webhook.TaskForWebhookRedelivery(ctx)
+	}, webhookRedeliveryTask)
+	if err != nil {
+		g.Log().Errorf(ctx, "StartCronJobs Name:%s Err:%s\n", webhookRedeliveryTask, err.Error())
+	}
 
 	// every 10 min
 	var other10MinTask = "Job10MinTask"
@@ -88,9 +112,12 @@ func StartCronJobs() {
 	_, err = gcron.Add(ctx, "@daily", func(ctx context.Context) {
 		invoice.TaskForCompensateSubUpDownInvoices(ctx)
 		multi_currency.TaskForSyncMerchantsMultiCurrencyConfigs(ctx)
+		statistics.TaskForBuildAllMerchantRevenueSnapshots(ctx)
+		statistics.TaskForRecognizeDeferredRevenue(ctx)
 		if !config.GetConfigInstance().IsProd() {
 			statistics.TaskForUpdateAllMerchantStatistics(ctx)
 		}
+		payment.TaskForReconcileGateways(ctx)
 	}, dailyTask)
 	if err != nil {
 		g.Log().Errorf(ctx, "StartCronJobs Name:%s Err:%s\n", dailyTask, err.Error())
@@ -36,6 +36,10 @@ func TaskForDeleteWebhookLog(ctx context.Context) {
 	if err != nil {
 		g.Log().Errorf(ctx, "TaskForDeleteWebhookLog error:%s", err.Error())
 	}
+	_, err = dao.MerchantWebhookAttempt.Ctx(ctx).WhereLT(dao.MerchantWebhookAttempt.Columns().GmtCreate, gtime.Now().AddDate(0, 0, -60)).Delete()
+	if err != nil {
+		g.Log().Errorf(ctx, "TaskForDeleteWebhookLog attempt error:%s", err.Error())
+	}
 }
 
 func TaskForDeleteOperationLog(ctx context.Context) {
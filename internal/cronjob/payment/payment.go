@@ -7,6 +7,7 @@ import (
 	"github.com/gogf/gf/v2/os/gtime"
 	"unibee/internal/consts"
 	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/payment/reconciliation"
 	"unibee/internal/logic/payment/service"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
@@ -49,3 +50,9 @@ func TaskForCancelExpiredPayment(ctx context.Context) {
 		}
 	}
 }
+
+func TaskForReconcileGateways(ctx context.Context) {
+	g.Log().Infof(ctx, "TaskForReconcileGateways start")
+	reconciliation.ReconcileAllMerchantGateways(ctx)
+	g.Log().Infof(ctx, "TaskForReconcileGateways end")
+}
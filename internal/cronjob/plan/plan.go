@@ -0,0 +1,16 @@
+package plan
+
+import (
+	"context"
+	"unibee/internal/logic/plan/price/migration"
+	"unibee/utility"
+)
+
+func TaskForExecutePlanPriceMigrations(ctx context.Context) {
+	key := "TaskForExecutePlanPriceMigrations"
+	if !utility.TryLock(ctx, key, 300) {
+		return
+	}
+	defer utility.ReleaseLock(ctx, key)
+	migration.ExecuteDuePlanPriceMigrations(ctx)
+}
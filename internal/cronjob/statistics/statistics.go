@@ -3,7 +3,11 @@ package statistics
 import (
 	"context"
 	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"unibee/internal/logic/analysis/ledger"
+	"unibee/internal/logic/analysis/revenue"
 	"unibee/internal/logic/analysis/statistics"
+	"unibee/utility"
 )
 
 func TaskForUpdateAllMerchantStatistics(ctx context.Context) {
@@ -11,3 +15,26 @@ func TaskForUpdateAllMerchantStatistics(ctx context.Context) {
 	statistics.UpdateMerchantStatsCron(ctx)
 	g.Log().Infof(ctx, "TaskForUpdateAllMerchantStatistics end")
 }
+
+func TaskForBuildAllMerchantRevenueSnapshots(ctx context.Context) {
+	key := "TaskForBuildAllMerchantRevenueSnapshots"
+	if !utility.TryLock(ctx, key, 3600) {
+		return
+	}
+	defer utility.ReleaseLock(ctx, key)
+	g.Log().Infof(ctx, "TaskForBuildAllMerchantRevenueSnapshots start")
+	// the previous day finished
+	revenue.BuildAllMerchantRevenueSnapshots(ctx, gtime.Now().Timestamp()-86400)
+	g.Log().Infof(ctx, "TaskForBuildAllMerchantRevenueSnapshots end")
+}
+
+func TaskForRecognizeDeferredRevenue(ctx context.Context) {
+	key := "TaskForRecognizeDeferredRevenue"
+	if !utility.TryLock(ctx, key, 3600) {
+		return
+	}
+	defer utility.ReleaseLock(ctx, key)
+	g.Log().Infof(ctx, "TaskForRecognizeDeferredRevenue start")
+	ledger.RecognizeDeferredRevenue(ctx, gtime.Now().Timestamp())
+	g.Log().Infof(ctx, "TaskForRecognizeDeferredRevenue end")
+}
@@ -120,6 +120,40 @@ func TaskForSubscriptionInitFailed(ctx context.Context, taskName string) {
 	}
 }
 
+func TaskForSubscriptionAutoResume(ctx context.Context, taskName string) {
+	g.Log().Debugf(ctx, "%s:%s", taskName, "TaskForSubscriptionAutoResume Start......")
+	var timeNow = gtime.Now().Timestamp()
+
+	var subs []*entity.Subscription
+	// query paused sub which autoResumeTime reached
+	q := dao.Subscription.Ctx(ctx).
+		Where(dao.Subscription.Columns().IsDeleted, 0).
+		WhereGT(dao.Subscription.Columns().AutoResumeTime, 0).
+		WhereLT(dao.Subscription.Columns().AutoResumeTime, timeNow). //  autoResume < now
+		Where(dao.Subscription.Columns().Type, consts.SubTypeUniBeeControl).
+		Where(dao.Subscription.Columns().Status, consts.SubStatusSuspended)
+	if !config.GetConfigInstance().IsProd() {
+		// Test Clock Not Enable For Prod Env
+		q = q.Where(dao.Subscription.Columns().TestClock, 0)
+	}
+	err := q.Limit(0, 10).
+		OmitEmpty().Scan(&subs)
+	if err != nil {
+		g.Log().Errorf(ctx, "%s Error:%s", taskName, err.Error())
+		return
+	}
+
+	for _, sub := range subs {
+		err = service.SubscriptionResume(ctx, sub.SubscriptionId, "AutoResumeBySystem")
+		if err != nil {
+			g.Log().Errorf(ctx, "TaskForSubscriptionAutoResume subId:%s error:%s", sub.SubscriptionId, err.Error())
+		} else {
+			g.Log().Debugf(ctx, "TaskForSubscriptionAutoResume subId:%s", sub.SubscriptionId)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
 func TaskForUserSubCompensate(ctx context.Context, taskName string) {
 	g.Log().Debugf(ctx, "%s:%s", taskName, "TaskForUserSubCompensate Start......")
 
@@ -0,0 +1,16 @@
+package user
+
+import (
+	"context"
+	"unibee/internal/logic/user/gdpr"
+	"unibee/utility"
+)
+
+func TaskForExpireUserDataExports(ctx context.Context) {
+	key := "TaskForExpireUserDataExports"
+	if !utility.TryLock(ctx, key, 300) {
+		return
+	}
+	defer utility.ReleaseLock(ctx, key)
+	gdpr.ExpireUserDataExports(ctx)
+}
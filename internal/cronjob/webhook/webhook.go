@@ -0,0 +1,19 @@
+package webhook
+
+import (
+	"context"
+	"github.com/gogf/gf/v2/frame/g"
+	"unibee/internal/consumer/webhook/message"
+)
+
+const (
+	redeliveryBatchSize   = 200
+	redeliveryConcurrency = 10
+)
+
+func TaskForWebhookRedelivery(ctx context.Context) {
+	count := message.RedeliverDueWebhooks(ctx, redeliveryBatchSize, redeliveryConcurrency)
+	if count > 0 {
+		g.Log().Infof(ctx, "TaskForWebhookRedelivery redelivered:%d", count)
+	}
+}
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalCreditGrantDao is internal type for wrapping internal DAO implements.
+type internalCreditGrantDao = *internal.CreditGrantDao
+
+// creditGrantDao is the data access object for table credit_grant.
+// You can define custom methods on it to extend its functionality as you wish.
+type creditGrantDao struct {
+	internalCreditGrantDao
+}
+
+var (
+	// CreditGrant is globally public accessible object for table credit_grant operations.
+	CreditGrant = creditGrantDao{
+		internal.NewCreditGrantDao(),
+	}
+)
+
+// Fill with you ideas below.
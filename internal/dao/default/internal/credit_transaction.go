@@ -40,6 +40,7 @@ type CreditTransactionColumns struct {
 	AccountType        string // type of credit account, 1-main recharge account, 2-promo credit account
 	AdminMemberId      string // admin_member_id
 	ExchangeRate       string // keep two decimal places，multiply by 100 saved, 1 currency = 1 credit * (exchange_rate/100), main account fixed rate to 100
+	GrantDetail        string // the credit grants increased or consumed by transaction, json
 }
 
 // creditTransactionColumns holds the columns for table credit_transaction.
@@ -64,6 +65,7 @@ var creditTransactionColumns = CreditTransactionColumns{
 	AccountType:        "account_type",
 	AdminMemberId:      "admin_member_id",
 	ExchangeRate:       "exchange_rate",
+	GrantDetail:        "grant_detail",
 }
 
 // NewCreditTransactionDao creates and returns a new DAO object for table data access.
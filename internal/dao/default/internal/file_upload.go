@@ -20,30 +20,38 @@ type FileUploadDao struct {
 
 // FileUploadColumns defines and stores column names for table file_upload.
 type FileUploadColumns struct {
-	Id         string //
-	UserId     string //
-	Url        string //
-	FileName   string //
-	Tag        string //
-	GmtCreate  string // create time
-	GmtModify  string //
-	IsDeleted  string // 0-UnDeleted，1-Deleted
-	CreateTime string // create utc time
-	Data       string // file data
+	Id          string //
+	UserId      string //
+	Url         string //
+	FileName    string //
+	Tag         string //
+	GmtCreate   string // create time
+	GmtModify   string //
+	IsDeleted   string // 0-UnDeleted，1-Deleted
+	CreateTime  string // create utc time
+	Data        string // file data
+	StorageType string // blank-database,local,s3
+	StorageKey  string // object key in storage
+	MerchantId  string // merchant id
+	Migrated    string // 1-migrated from database, served by its url without signature
 }
 
 // fileUploadColumns holds the columns for table file_upload.
 var fileUploadColumns = FileUploadColumns{
-	Id:         "id",
-	UserId:     "user_id",
-	Url:        "url",
-	FileName:   "file_name",
-	Tag:        "tag",
-	GmtCreate:  "gmt_create",
-	GmtModify:  "gmt_modify",
-	IsDeleted:  "is_deleted",
-	CreateTime: "create_time",
-	Data:       "data",
+	Id:          "id",
+	UserId:      "user_id",
+	Url:         "url",
+	FileName:    "file_name",
+	Tag:         "tag",
+	GmtCreate:   "gmt_create",
+	GmtModify:   "gmt_modify",
+	IsDeleted:   "is_deleted",
+	CreateTime:  "create_time",
+	Data:        "data",
+	StorageType: "storage_type",
+	StorageKey:  "storage_key",
+	MerchantId:  "merchant_id",
+	Migrated:    "migrated",
 }
 
 // NewFileUploadDao creates and returns a new DAO object for table data access.
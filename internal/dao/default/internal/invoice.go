@@ -79,6 +79,9 @@ type InvoiceColumns struct {
 	PromoCreditDiscountAmount      string // promo credit discount amount
 	PartialCreditPaidAmount        string // partial credit paid amount
 	MetricCharge                   string // invoice metric charge data
+	DunningAttempt                 string // dunning payment retry attempt count
+	InvoiceNumber                  string // legal invoice number, sequential per merchant, assigned when finalized
+	TaxLines                       string // tax lines by jurisdiction (json)
 }
 
 // invoiceColumns holds the columns for table invoice.
@@ -142,6 +145,9 @@ var invoiceColumns = InvoiceColumns{
 	PromoCreditDiscountAmount:      "promo_credit_discount_amount",
 	PartialCreditPaidAmount:        "partial_credit_paid_amount",
 	MetricCharge:                   "metric_charge",
+	DunningAttempt:                 "dunning_attempt",
+	InvoiceNumber:                  "invoice_number",
+	TaxLines:                       "tax_lines",
 }
 
 // NewInvoiceDao creates and returns a new DAO object for table data access.
@@ -20,32 +20,34 @@ type MerchantEmailHistoryDao struct {
 
 // MerchantEmailHistoryColumns defines and stores column names for table merchant_email_history.
 type MerchantEmailHistoryColumns struct {
-	Id         string //
-	MerchantId string //
-	Email      string //
-	Title      string //
-	Content    string //
-	AttachFile string //
-	GmtCreate  string // create time
-	GmtModify  string // update time
-	Response   string //
-	CreateTime string // create utc time
-	Status     string // 0-pending,1-success,2-failure
+	Id          string //
+	MerchantId  string //
+	Email       string //
+	Title       string //
+	Content     string //
+	AttachFile  string //
+	GmtCreate   string // create time
+	GmtModify   string // update time
+	Response    string //
+	CreateTime  string // create utc time
+	Status      string // 0-pending,1-success,2-failure
+	GatewayName string // name of email gateway delivered by
 }
 
 // merchantEmailHistoryColumns holds the columns for table merchant_email_history.
 var merchantEmailHistoryColumns = MerchantEmailHistoryColumns{
-	Id:         "id",
-	MerchantId: "merchant_id",
-	Email:      "email",
-	Title:      "title",
-	Content:    "content",
-	AttachFile: "attach_file",
-	GmtCreate:  "gmt_create",
-	GmtModify:  "gmt_modify",
-	Response:   "response",
-	CreateTime: "create_time",
-	Status:     "status",
+	Id:          "id",
+	MerchantId:  "merchant_id",
+	Email:       "email",
+	Title:       "title",
+	Content:     "content",
+	AttachFile:  "attach_file",
+	GmtCreate:   "gmt_create",
+	GmtModify:   "gmt_modify",
+	Response:    "response",
+	CreateTime:  "create_time",
+	Status:      "status",
+	GatewayName: "gateway_name",
 }
 
 // NewMerchantEmailHistoryDao creates and returns a new DAO object for table data access.
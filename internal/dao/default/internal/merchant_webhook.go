@@ -20,26 +20,40 @@ type MerchantWebhookDao struct {
 
 // MerchantWebhookColumns defines and stores column names for table merchant_webhook.
 type MerchantWebhookColumns struct {
-	Id            string // id
-	MerchantId    string // webhook url
-	WebhookUrl    string // webhook url
-	WebhookEvents string // webhook_events,split dot
-	GmtCreate     string // create time
-	GmtModify     string // update time
-	CreateTime    string // create utc time
-	IsDeleted     string // 0-UnDeleted，1-Deleted
+	Id                       string // id
+	MerchantId               string // webhook url
+	WebhookUrl               string // webhook url
+	WebhookEvents            string // webhook_events,split dot
+	GmtCreate                string // create time
+	GmtModify                string // update time
+	CreateTime               string // create utc time
+	IsDeleted                string // 0-UnDeleted，1-Deleted
+	WebhookSecret            string // secret to sign webhook
+	PreviousWebhookSecret    string // rotated secret, still valid until previous_secret_expire_time
+	PreviousSecretExpireTime string // utc time the rotated secret expires
+	ConsecutiveFailures      string // consecutive failed delivery attempts
+	LastSuccessTime          string // utc time of last success delivery
+	LastFailureTime          string // utc time of last failed delivery
+	DisableTime              string // utc time endpoint disabled by failures, 0-enabled
 }
 
 // merchantWebhookColumns holds the columns for table merchant_webhook.
 var merchantWebhookColumns = MerchantWebhookColumns{
-	Id:            "id",
-	MerchantId:    "merchant_id",
-	WebhookUrl:    "webhook_url",
-	WebhookEvents: "webhook_events",
-	GmtCreate:     "gmt_create",
-	GmtModify:     "gmt_modify",
-	CreateTime:    "create_time",
-	IsDeleted:     "is_deleted",
+	Id:                       "id",
+	MerchantId:               "merchant_id",
+	WebhookUrl:               "webhook_url",
+	WebhookEvents:            "webhook_events",
+	GmtCreate:                "gmt_create",
+	GmtModify:                "gmt_modify",
+	CreateTime:               "create_time",
+	IsDeleted:                "is_deleted",
+	WebhookSecret:            "webhook_secret",
+	PreviousWebhookSecret:    "previous_webhook_secret",
+	PreviousSecretExpireTime: "previous_secret_expire_time",
+	ConsecutiveFailures:      "consecutive_failures",
+	LastSuccessTime:          "last_success_time",
+	LastFailureTime:          "last_failure_time",
+	DisableTime:              "disable_time",
 }
 
 // NewMerchantWebhookDao creates and returns a new DAO object for table data access.
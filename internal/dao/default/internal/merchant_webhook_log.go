@@ -20,38 +20,48 @@ type MerchantWebhookLogDao struct {
 
 // MerchantWebhookLogColumns defines and stores column names for table merchant_webhook_log.
 type MerchantWebhookLogColumns struct {
-	Id             string // id
-	MerchantId     string // webhook url
-	EndpointId     string //
-	ReconsumeCount string //
-	WebhookUrl     string // webhook url
-	WebhookEvent   string // webhook_event
-	RequestId      string // request_id
-	Body           string // body(json)
-	Response       string // response
-	Mamo           string // mamo
-	GmtCreate      string // create time
-	GmtModify      string // update time
-	CreateTime     string // create utc time
-	WebhookEventId string // webhook_event_id
+	Id              string // id
+	MerchantId      string // webhook url
+	EndpointId      string //
+	ReconsumeCount  string //
+	WebhookUrl      string // webhook url
+	WebhookEvent    string // webhook_event
+	RequestId       string // request_id
+	Body            string // body(json)
+	Response        string // response
+	Mamo            string // mamo
+	GmtCreate       string // create time
+	GmtModify       string // update time
+	CreateTime      string // create utc time
+	WebhookEventId  string // webhook_event_id
+	Status          string // 0-legacy,1-success,2-retrying,3-failed
+	AttemptCount    string // delivery attempt count
+	NextRetryTime   string // utc time of next redelivery
+	ResponseStatus  string // http status of last attempt
+	LastAttemptTime string // utc time of last attempt
 }
 
 // merchantWebhookLogColumns holds the columns for table merchant_webhook_log.
 var merchantWebhookLogColumns = MerchantWebhookLogColumns{
-	Id:             "id",
-	MerchantId:     "merchant_id",
-	EndpointId:     "endpoint_id",
-	ReconsumeCount: "reconsume_count",
-	WebhookUrl:     "webhook_url",
-	WebhookEvent:   "webhook_event",
-	RequestId:      "request_id",
-	Body:           "body",
-	Response:       "response",
-	Mamo:           "mamo",
-	GmtCreate:      "gmt_create",
-	GmtModify:      "gmt_modify",
-	CreateTime:     "create_time",
-	WebhookEventId: "webhook_event_id",
+	Id:              "id",
+	MerchantId:      "merchant_id",
+	EndpointId:      "endpoint_id",
+	ReconsumeCount:  "reconsume_count",
+	WebhookUrl:      "webhook_url",
+	WebhookEvent:    "webhook_event",
+	RequestId:       "request_id",
+	Body:            "body",
+	Response:        "response",
+	Mamo:            "mamo",
+	GmtCreate:       "gmt_create",
+	GmtModify:       "gmt_modify",
+	CreateTime:      "create_time",
+	WebhookEventId:  "webhook_event_id",
+	Status:          "status",
+	AttemptCount:    "attempt_count",
+	NextRetryTime:   "next_retry_time",
+	ResponseStatus:  "response_status",
+	LastAttemptTime: "last_attempt_time",
 }
 
 // NewMerchantWebhookLogDao creates and returns a new DAO object for table data access.
@@ -34,10 +34,11 @@ type PaymentTimelineColumns struct {
 	IsDeleted      string // 0-UnDeleted，1-Deleted
 	PaymentId      string // PaymentId
 	Status         string // 0-pending, 1-success, 2-failure
-	TimelineType   string // 0-pay, 1-refund
+	TimelineType   string // 0-pay, 1-refund, 2-gateway attempt
 	CreateTime     string // create utc time
 	RefundId       string // refund id
 	FullRefund     string // 0-no, 1-yes
+	Reason         string // reason of the timeline, e.g. the failure of gateway attempt
 }
 
 // paymentTimelineColumns holds the columns for table payment_timeline.
@@ -60,6 +61,7 @@ var paymentTimelineColumns = PaymentTimelineColumns{
 	CreateTime:     "create_time",
 	RefundId:       "refund_id",
 	FullRefund:     "full_refund",
+	Reason:         "reason",
 }
 
 // NewPaymentTimelineDao creates and returns a new DAO object for table data access.
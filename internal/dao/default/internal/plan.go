@@ -56,6 +56,7 @@ type PlanColumns struct {
 	DisableAutoCharge         string // disable auto-charge, 0-false,1-true
 	MetricCharge              string // metric charge(json)
 	InternalName              string //
+	PriceVersion              string // current price version of plan, 0 or 1 is the initial price
 }
 
 // planColumns holds the columns for table plan.
@@ -96,6 +97,7 @@ var planColumns = PlanColumns{
 	DisableAutoCharge:         "disable_auto_charge",
 	MetricCharge:              "metric_charge",
 	InternalName:              "internal_name",
+	PriceVersion:              "price_version",
 }
 
 // NewPlanDao creates and returns a new DAO object for table data access.
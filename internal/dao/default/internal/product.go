@@ -33,6 +33,7 @@ type ProductColumns struct {
 	IsDeleted   string // 0-UnDeleted，1-Deleted
 	CreateTime  string // create utc time
 	MetaData    string // meta_data(json)
+	TaxCode     string // tax code of product, general|digital|exempt
 }
 
 // productColumns holds the columns for table product.
@@ -50,6 +51,7 @@ var productColumns = ProductColumns{
 	IsDeleted:   "is_deleted",
 	CreateTime:  "create_time",
 	MetaData:    "meta_data",
+	TaxCode:     "tax_code",
 }
 
 // NewProductDao creates and returns a new DAO object for table data access.
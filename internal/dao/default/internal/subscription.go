@@ -72,6 +72,11 @@ type SubscriptionColumns struct {
 	LastTrackTime               string // last subscription track time
 	ExternalSubscriptionId      string // external_subscription_id
 	NextInvoiceData             string // next_invoice_data
+	PauseAtPeriodEnd            string // whether pause at period end，0-false | 1-true
+	PausedTime                  string // paused utc time, 0 if not paused
+	AutoResumeTime              string // auto resume utc time, 0 if resume manually
+	PauseSource                 string // pause source, manual|dunning|dispute, blank if not paused
+	PlanPriceVersion            string // grandfathered price version of plan, 0-follow the current price of plan
 }
 
 // subscriptionColumns holds the columns for table subscription.
@@ -128,6 +133,11 @@ var subscriptionColumns = SubscriptionColumns{
 	LastTrackTime:               "last_track_time",
 	ExternalSubscriptionId:      "external_subscription_id",
 	NextInvoiceData:             "next_invoice_data",
+	PauseAtPeriodEnd:            "pause_at_period_end",
+	PausedTime:                  "paused_time",
+	AutoResumeTime:              "auto_resume_time",
+	PauseSource:                 "pause_source",
+	PlanPriceVersion:            "plan_price_version",
 }
 
 // NewSubscriptionDao creates and returns a new DAO object for table data access.
@@ -20,118 +20,124 @@ type UserAccountDao struct {
 
 // UserAccountColumns defines and stores column names for table user_account.
 type UserAccountColumns struct {
-	Id                 string // userId
-	ExternalUserId     string // external_user_id
-	Email              string // email
-	GatewayId          string // gateway_id
-	PaymentMethod      string //
-	CountryCode        string // country_code
-	CountryName        string // country_name
-	VATNumber          string // vat number
-	TaxPercentage      string // taxPercentage，1000 = 10%
-	Type               string // User type, 1-Individual|2-organization
-	MerchantId         string // merchant_id
-	GmtCreate          string // create time
-	GmtModify          string // update time
-	IsDeleted          string // 0-UnDeleted，1-Deleted
-	Password           string // password , encrypt
-	UserName           string // user name
-	Mobile             string // mobile
-	Gender             string // gender
-	AvatarUrl          string // avator url
-	ReMark             string // note
-	IsSpecial          string // is special account（0.no，1.yes）- deperated
-	Birthday           string // brithday
-	Profession         string // profession
-	School             string // school
-	Custom             string // custom
-	LastLoginAt        string // last login time, utc time
-	IsRisk             string // is risk account (deperated)
-	Version            string // version
-	Phone              string // phone
-	Address            string // address
-	FirstName          string // first name
-	LastName           string // last name
-	CompanyName        string // company name
-	Telegram           string // telegram
-	WhatsAPP           string // whats app
-	WeChat             string // wechat
-	TikTok             string // tictok
-	LinkedIn           string // linkedin
-	Facebook           string // facebook
-	OtherSocialInfo    string //
-	SubscriptionName   string // subscription name
-	PlanId             string // PlanId
-	SubscriptionId     string // subscription id
-	SubscriptionStatus string // sub status，0-Init | 1-Pending｜2-Active｜3-PendingInActive | 4-Cancel | 5-Expire | 6- Suspend| 7-Incomplete
-	RecurringAmount    string // total recurring amount, cent
-	BillingType        string // 1-recurring,2-one-time
-	TimeZone           string //
-	CreateTime         string // create utc time
-	Status             string // 0-Active, 2-Suspend
-	City               string // city
-	ZipCode            string // zip_code
-	Language           string // language
-	MetaData           string // meta_data(json)
-	RegistrationNumber string // registration number
+	Id                     string // userId
+	ExternalUserId         string // external_user_id
+	Email                  string // email
+	GatewayId              string // gateway_id
+	PaymentMethod          string //
+	CountryCode            string // country_code
+	CountryName            string // country_name
+	VATNumber              string // vat number
+	TaxPercentage          string // taxPercentage，1000 = 10%
+	Type                   string // User type, 1-Individual|2-organization
+	MerchantId             string // merchant_id
+	GmtCreate              string // create time
+	GmtModify              string // update time
+	IsDeleted              string // 0-UnDeleted，1-Deleted
+	Password               string // password , encrypt
+	UserName               string // user name
+	Mobile                 string // mobile
+	Gender                 string // gender
+	AvatarUrl              string // avator url
+	ReMark                 string // note
+	IsSpecial              string // is special account（0.no，1.yes）- deperated
+	Birthday               string // brithday
+	Profession             string // profession
+	School                 string // school
+	Custom                 string // custom
+	LastLoginAt            string // last login time, utc time
+	IsRisk                 string // is risk account (deperated)
+	Version                string // version
+	Phone                  string // phone
+	Address                string // address
+	FirstName              string // first name
+	LastName               string // last name
+	CompanyName            string // company name
+	Telegram               string // telegram
+	WhatsAPP               string // whats app
+	WeChat                 string // wechat
+	TikTok                 string // tictok
+	LinkedIn               string // linkedin
+	Facebook               string // facebook
+	OtherSocialInfo        string //
+	SubscriptionName       string // subscription name
+	PlanId                 string // PlanId
+	SubscriptionId         string // subscription id
+	SubscriptionStatus     string // sub status，0-Init | 1-Pending｜2-Active｜3-PendingInActive | 4-Cancel | 5-Expire | 6- Suspend| 7-Incomplete
+	RecurringAmount        string // total recurring amount, cent
+	BillingType            string // 1-recurring,2-one-time
+	TimeZone               string //
+	CreateTime             string // create utc time
+	Status                 string // 0-Active, 2-Suspend
+	City                   string // city
+	ZipCode                string // zip_code
+	Language               string // language
+	MetaData               string // meta_data(json)
+	RegistrationNumber     string // registration number
+	State                  string // state or province code of billing address, used by sales tax
+	TaxExempt              string // 0-taxable,1-tax exempt
+	TaxExemptCertificateId string // tax exemption certificate id
 }
 
 // userAccountColumns holds the columns for table user_account.
 var userAccountColumns = UserAccountColumns{
-	Id:                 "id",
-	ExternalUserId:     "external_user_id",
-	Email:              "email",
-	GatewayId:          "gateway_id",
-	PaymentMethod:      "payment_method",
-	CountryCode:        "country_code",
-	CountryName:        "country_name",
-	VATNumber:          "VAT_number",
-	TaxPercentage:      "tax_percentage",
-	Type:               "type",
-	MerchantId:         "merchant_id",
-	GmtCreate:          "gmt_create",
-	GmtModify:          "gmt_modify",
-	IsDeleted:          "is_deleted",
-	Password:           "password",
-	UserName:           "user_name",
-	Mobile:             "mobile",
-	Gender:             "gender",
-	AvatarUrl:          "avatar_url",
-	ReMark:             "re_mark",
-	IsSpecial:          "is_special",
-	Birthday:           "birthday",
-	Profession:         "profession",
-	School:             "school",
-	Custom:             "custom",
-	LastLoginAt:        "last_login_at",
-	IsRisk:             "is_risk",
-	Version:            "version",
-	Phone:              "phone",
-	Address:            "address",
-	FirstName:          "first_name",
-	LastName:           "last_name",
-	CompanyName:        "company_name",
-	Telegram:           "Telegram",
-	WhatsAPP:           "WhatsAPP",
-	WeChat:             "WeChat",
-	TikTok:             "TikTok",
-	LinkedIn:           "LinkedIn",
-	Facebook:           "Facebook",
-	OtherSocialInfo:    "other_social_info",
-	SubscriptionName:   "subscription_name",
-	PlanId:             "plan_id",
-	SubscriptionId:     "subscription_id",
-	SubscriptionStatus: "subscription_status",
-	RecurringAmount:    "recurring_amount",
-	BillingType:        "billing_type",
-	TimeZone:           "time_zone",
-	CreateTime:         "create_time",
-	Status:             "status",
-	City:               "city",
-	ZipCode:            "zip_code",
-	Language:           "language",
-	MetaData:           "meta_data",
-	RegistrationNumber: "registration_number",
+	Id:                     "id",
+	ExternalUserId:         "external_user_id",
+	Email:                  "email",
+	GatewayId:              "gateway_id",
+	PaymentMethod:          "payment_method",
+	CountryCode:            "country_code",
+	CountryName:            "country_name",
+	VATNumber:              "VAT_number",
+	TaxPercentage:          "tax_percentage",
+	Type:                   "type",
+	MerchantId:             "merchant_id",
+	GmtCreate:              "gmt_create",
+	GmtModify:              "gmt_modify",
+	IsDeleted:              "is_deleted",
+	Password:               "password",
+	UserName:               "user_name",
+	Mobile:                 "mobile",
+	Gender:                 "gender",
+	AvatarUrl:              "avatar_url",
+	ReMark:                 "re_mark",
+	IsSpecial:              "is_special",
+	Birthday:               "birthday",
+	Profession:             "profession",
+	School:                 "school",
+	Custom:                 "custom",
+	LastLoginAt:            "last_login_at",
+	IsRisk:                 "is_risk",
+	Version:                "version",
+	Phone:                  "phone",
+	Address:                "address",
+	FirstName:              "first_name",
+	LastName:               "last_name",
+	CompanyName:            "company_name",
+	Telegram:               "Telegram",
+	WhatsAPP:               "WhatsAPP",
+	WeChat:                 "WeChat",
+	TikTok:                 "TikTok",
+	LinkedIn:               "LinkedIn",
+	Facebook:               "Facebook",
+	OtherSocialInfo:        "other_social_info",
+	SubscriptionName:       "subscription_name",
+	PlanId:                 "plan_id",
+	SubscriptionId:         "subscription_id",
+	SubscriptionStatus:     "subscription_status",
+	RecurringAmount:        "recurring_amount",
+	BillingType:            "billing_type",
+	TimeZone:               "time_zone",
+	CreateTime:             "create_time",
+	Status:                 "status",
+	City:                   "city",
+	ZipCode:                "zip_code",
+	Language:               "language",
+	MetaData:               "meta_data",
+	RegistrationNumber:     "registration_number",
+	State:                  "state",
+	TaxExempt:              "tax_exempt",
+	TaxExemptCertificateId: "tax_exempt_certificate_id",
 }
 
 // NewUserAccountDao creates and returns a new DAO object for table data access.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalMerchantApiKeyDao is internal type for wrapping internal DAO implements.
+type internalMerchantApiKeyDao = *internal.MerchantApiKeyDao
+
+// merchantApiKeyDao is the data access object for table merchant_api_key.
+// You can define custom methods on it to extend its functionality as you wish.
+type merchantApiKeyDao struct {
+	internalMerchantApiKeyDao
+}
+
+var (
+	// MerchantApiKey is globally public accessible object for table merchant_api_key operations.
+	MerchantApiKey = merchantApiKeyDao{
+		internal.NewMerchantApiKeyDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalMerchantGatewayDiscrepancyDao is internal type for wrapping internal DAO implements.
+type internalMerchantGatewayDiscrepancyDao = *internal.MerchantGatewayDiscrepancyDao
+
+// merchantGatewayDiscrepancyDao is the data access object for table merchant_gateway_discrepancy.
+// You can define custom methods on it to extend its functionality as you wish.
+type merchantGatewayDiscrepancyDao struct {
+	internalMerchantGatewayDiscrepancyDao
+}
+
+var (
+	// MerchantGatewayDiscrepancy is globally public accessible object for table merchant_gateway_discrepancy operations.
+	MerchantGatewayDiscrepancy = merchantGatewayDiscrepancyDao{
+		internal.NewMerchantGatewayDiscrepancyDao(),
+	}
+)
+
+// Fill with you ideas below.
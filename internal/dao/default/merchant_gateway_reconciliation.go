@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalMerchantGatewayReconciliationDao is internal type for wrapping internal DAO implements.
+type internalMerchantGatewayReconciliationDao = *internal.MerchantGatewayReconciliationDao
+
+// merchantGatewayReconciliationDao is the data access object for table merchant_gateway_reconciliation.
+// You can define custom methods on it to extend its functionality as you wish.
+type merchantGatewayReconciliationDao struct {
+	internalMerchantGatewayReconciliationDao
+}
+
+var (
+	// MerchantGatewayReconciliation is globally public accessible object for table merchant_gateway_reconciliation operations.
+	MerchantGatewayReconciliation = merchantGatewayReconciliationDao{
+		internal.NewMerchantGatewayReconciliationDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalMerchantLedgerEntryDao is internal type for wrapping internal DAO implements.
+type internalMerchantLedgerEntryDao = *internal.MerchantLedgerEntryDao
+
+// merchantLedgerEntryDao is the data access object for table merchant_ledger_entry.
+// You can define custom methods on it to extend its functionality as you wish.
+type merchantLedgerEntryDao struct {
+	internalMerchantLedgerEntryDao
+}
+
+var (
+	// MerchantLedgerEntry is globally public accessible object for table merchant_ledger_entry operations.
+	MerchantLedgerEntry = merchantLedgerEntryDao{
+		internal.NewMerchantLedgerEntryDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalMerchantRevenueSnapshotDao is internal type for wrapping internal DAO implements.
+type internalMerchantRevenueSnapshotDao = *internal.MerchantRevenueSnapshotDao
+
+// merchantRevenueSnapshotDao is the data access object for table merchant_revenue_snapshot.
+// You can define custom methods on it to extend its functionality as you wish.
+type merchantRevenueSnapshotDao struct {
+	internalMerchantRevenueSnapshotDao
+}
+
+var (
+	// MerchantRevenueSnapshot is globally public accessible object for table merchant_revenue_snapshot operations.
+	MerchantRevenueSnapshot = merchantRevenueSnapshotDao{
+		internal.NewMerchantRevenueSnapshotDao(),
+	}
+)
+
+// Fill with you ideas below.
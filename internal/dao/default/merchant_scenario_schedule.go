@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalMerchantScenarioScheduleDao is internal type for wrapping internal DAO implements.
+type internalMerchantScenarioScheduleDao = *internal.MerchantScenarioScheduleDao
+
+// merchantScenarioScheduleDao is the data access object for table merchant_scenario_schedule.
+// You can define custom methods on it to extend its functionality as you wish.
+type merchantScenarioScheduleDao struct {
+	internalMerchantScenarioScheduleDao
+}
+
+var (
+	// MerchantScenarioSchedule is globally public accessible object for table merchant_scenario_schedule operations.
+	MerchantScenarioSchedule = merchantScenarioScheduleDao{
+		internal.NewMerchantScenarioScheduleDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalMerchantWebhookAttemptDao is internal type for wrapping internal DAO implements.
+type internalMerchantWebhookAttemptDao = *internal.MerchantWebhookAttemptDao
+
+// merchantWebhookAttemptDao is the data access object for table merchant_webhook_attempt.
+// You can define custom methods on it to extend its functionality as you wish.
+type merchantWebhookAttemptDao struct {
+	internalMerchantWebhookAttemptDao
+}
+
+var (
+	// MerchantWebhookAttempt is globally public accessible object for table merchant_webhook_attempt operations.
+	MerchantWebhookAttempt = merchantWebhookAttemptDao{
+		internal.NewMerchantWebhookAttemptDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalPaymentDisputeDao is internal type for wrapping internal DAO implements.
+type internalPaymentDisputeDao = *internal.PaymentDisputeDao
+
+// paymentDisputeDao is the data access object for table payment_dispute.
+// You can define custom methods on it to extend its functionality as you wish.
+type paymentDisputeDao struct {
+	internalPaymentDisputeDao
+}
+
+var (
+	// PaymentDispute is globally public accessible object for table payment_dispute operations.
+	PaymentDispute = paymentDisputeDao{
+		internal.NewPaymentDisputeDao(),
+	}
+)
+
+// Fill with you ideas below.
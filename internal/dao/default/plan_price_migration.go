@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalPlanPriceMigrationDao is internal type for wrapping internal DAO implements.
+type internalPlanPriceMigrationDao = *internal.PlanPriceMigrationDao
+
+// planPriceMigrationDao is the data access object for table plan_price_migration.
+// You can define custom methods on it to extend its functionality as you wish.
+type planPriceMigrationDao struct {
+	internalPlanPriceMigrationDao
+}
+
+var (
+	// PlanPriceMigration is globally public accessible object for table plan_price_migration operations.
+	PlanPriceMigration = planPriceMigrationDao{
+		internal.NewPlanPriceMigrationDao(),
+	}
+)
+
+// Fill with you ideas below.
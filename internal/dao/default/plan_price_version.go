@@ -0,0 +1,27 @@
+// =================================================================================
+// This is auto-generated by GoFrame CLI tool only once. Fill this file as you wish.
+// =================================================================================
+
+package dao
+
+import (
+	"unibee/internal/dao/default/internal"
+)
+
+// internalPlanPriceVersionDao is internal type for wrapping internal DAO implements.
+type internalPlanPriceVersionDao = *internal.PlanPriceVersionDao
+
+// planPriceVersionDao is the data access object for table plan_price_version.
+// You can define custom methods on it to extend its functionality as you wish.
+type planPriceVersionDao struct {
+	internalPlanPriceVersionDao
+}
+
+var (
+	// PlanPriceVersion is globally public accessible object for table plan_price_version operations.
+	PlanPriceVersion = planPriceVersionDao{
+		internal.NewPlanPriceVersionDao(),
+	}
+)
+
+// Fill with you ideas below.
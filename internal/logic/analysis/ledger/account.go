@@ -0,0 +1,80 @@
+package ledger
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/internal/logic/merchant_config"
+	"unibee/internal/logic/merchant_config/update"
+	"unibee/internal/logic/operation_log"
+	"unibee/utility"
+)
+
+const KeyMerchantLedgerAccountCodes = "KeyMerchantLedgerAccountCodes"
+
+var defaultAccountCodes = map[string]string{
+	AccountReceivable:      "1100",
+	AccountRevenue:         "4000",
+	AccountDeferredRevenue: "2400",
+	AccountTaxPayable:      "2200",
+	AccountPromoCredit:     "6100",
+	AccountCash:            "1000",
+}
+
+func GetMerchantLedgerAccountCodes(ctx context.Context, merchantId uint64) *bean.LedgerAccountCodes {
+	config := merchant_config.GetMerchantConfig(ctx, merchantId, KeyMerchantLedgerAccountCodes)
+	if config != nil && len(config.ConfigValue) > 0 {
+		var one *bean.LedgerAccountCodes
+		_ = utility.UnmarshalFromJsonString(config.ConfigValue, &one)
+		if one != nil {
+			return one
+		}
+	}
+	return &bean.LedgerAccountCodes{}
+}
+
+func SetupMerchantLedgerAccountCodes(ctx context.Context, merchantId uint64, codes *bean.LedgerAccountCodes) error {
+	utility.Assert(merchantId > 0, "invalid merchantId")
+	utility.Assert(codes != nil, "invalid account codes")
+	err := update.SetMerchantConfig(ctx, merchantId, KeyMerchantLedgerAccountCodes, utility.MarshalToJsonString(codes))
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     merchantId,
+		Target:         "Ledger",
+		Content:        "SetupLedgerAccountCodes",
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+		Data:           utility.MarshalToJsonString(codes),
+	}, err)
+	return err
+}
+
+// AccountCode returns the merchant code of account, the cash code of gateway preferred, the default code used if not configured
+func AccountCode(codes *bean.LedgerAccountCodes, account string, gatewayName string) string {
+	var code = ""
+	if codes != nil {
+		switch account {
+		case AccountReceivable:
+			code = codes.AccountsReceivable
+		case AccountRevenue:
+			code = codes.Revenue
+		case AccountDeferredRevenue:
+			code = codes.DeferredRevenue
+		case AccountTaxPayable:
+			code = codes.TaxPayable
+		case AccountPromoCredit:
+			code = codes.PromoCredit
+		case AccountCash:
+			if gatewayCode, ok := codes.GatewayCash[gatewayName]; ok && len(gatewayCode) > 0 {
+				code = gatewayCode
+			} else {
+				code = codes.Cash
+			}
+		}
+	}
+	if len(code) == 0 {
+		code = defaultAccountCodes[account]
+	}
+	return code
+}
@@ -0,0 +1,231 @@
+package ledger
+
+import (
+	"fmt"
+	entity "unibee/internal/model/entity/default"
+)
+
+const (
+	AccountReceivable      = "ar"
+	AccountRevenue         = "revenue"
+	AccountDeferredRevenue = "deferred_revenue"
+	AccountTaxPayable      = "tax_payable"
+	AccountCash            = "cash"
+	AccountPromoCredit     = "promo_credit"
+)
+
+const (
+	EntryTypeInvoiceFinalized    = "invoice_finalized"
+	EntryTypePromoCreditUsed     = "promo_credit_used"
+	EntryTypePaymentSuccess      = "payment_success"
+	EntryTypeCreditNoteFinalized = "credit_note_finalized"
+	EntryTypeRefundSuccess       = "refund_success"
+	EntryTypeInvoiceVoided       = "invoice_voided"
+	EntryTypeRevenueRecognition  = "revenue_recognition"
+)
+
+// Journal is one balanced entry, the sum of debit equals the sum of credit
+type Journal struct {
+	JournalId      string
+	EntryType      string
+	SourceId       string
+	MerchantId     uint64
+	UserId         uint64
+	SubscriptionId string
+	InvoiceId      string
+	Currency       string
+	GatewayId      uint64
+	GatewayName    string
+	Description    string
+	EntryTime      int64
+	Lines          []*JournalLine
+}
+
+type JournalLine struct {
+	Account string
+	Debit   int64
+	Credit  int64
+}
+
+func JournalId(entryType string, sourceId string) string {
+	return fmt.Sprintf("%s_%s", entryType, sourceId)
+}
+
+func newInvoiceJournal(entryType string, sourceId string, invoice *entity.Invoice, entryTime int64) *Journal {
+	return &Journal{
+		JournalId:      JournalId(entryType, sourceId),
+		EntryType:      entryType,
+		SourceId:       sourceId,
+		MerchantId:     invoice.MerchantId,
+		UserId:         invoice.UserId,
+		SubscriptionId: invoice.SubscriptionId,
+		InvoiceId:      invoice.InvoiceId,
+		Currency:       invoice.Currency,
+		GatewayId:      invoice.GatewayId,
+		Description:    invoice.InvoiceName,
+		EntryTime:      entryTime,
+		Lines:          make([]*JournalLine, 0),
+	}
+}
+
+// debit adds debit line, zero amount skipped and negative amount put on credit side
+func (j *Journal) debit(account string, amount int64) {
+	if amount > 0 {
+		j.Lines = append(j.Lines, &JournalLine{Account: account, Debit: amount})
+	} else if amount < 0 {
+		j.Lines = append(j.Lines, &JournalLine{Account: account, Credit: -amount})
+	}
+}
+
+func (j *Journal) credit(account string, amount int64) {
+	j.debit(account, -amount)
+}
+
+func (j *Journal) TotalDebit() (total int64) {
+	for _, line := range j.Lines {
+		total = total + line.Debit
+	}
+	return
+}
+
+func (j *Journal) TotalCredit() (total int64) {
+	for _, line := range j.Lines {
+		total = total + line.Credit
+	}
+	return
+}
+
+func (j *Journal) Balanced() bool {
+	return j.TotalDebit() == j.TotalCredit()
+}
+
+func (j *Journal) Empty() bool {
+	return len(j.Lines) == 0
+}
+
+func abs(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// IsDeferred returns whether the revenue of invoice deferred over the subscription period not ended at entry time
+func IsDeferred(invoice *entity.Invoice, entryTime int64) bool {
+	return len(invoice.SubscriptionId) > 0 && invoice.PeriodEnd > invoice.PeriodStart && invoice.PeriodEnd > entryTime
+}
+
+// splitDeferred splits amount to the deferred part limited by the deferred balance, and the revenue part
+func splitDeferred(amount int64, deferredBalance int64) (deferred int64, revenue int64) {
+	if deferredBalance <= 0 {
+		return 0, amount
+	}
+	if deferredBalance >= amount {
+		return amount, 0
+	}
+	return deferredBalance, amount - deferredBalance
+}
+
+// BuildInvoiceFinalizedJournal Dr AR, Cr revenue or deferred revenue, Cr tax payable, the receivable includes promo credit deducted
+func BuildInvoiceFinalizedJournal(invoice *entity.Invoice, entryTime int64) *Journal {
+	j := newInvoiceJournal(EntryTypeInvoiceFinalized, invoice.InvoiceId, invoice, entryTime)
+	receivable := invoice.TotalAmount + invoice.PromoCreditDiscountAmount
+	j.debit(AccountReceivable, receivable)
+	if IsDeferred(invoice, entryTime) {
+		j.credit(AccountDeferredRevenue, receivable-invoice.TaxAmount)
+	} else {
+		j.credit(AccountRevenue, receivable-invoice.TaxAmount)
+	}
+	j.credit(AccountTaxPayable, invoice.TaxAmount)
+	return j
+}
+
+// BuildPromoCreditJournal Dr promo credit, Cr AR, the promo credit deducted from invoice
+func BuildPromoCreditJournal(invoice *entity.Invoice, entryTime int64) *Journal {
+	j := newInvoiceJournal(EntryTypePromoCreditUsed, invoice.InvoiceId, invoice, entryTime)
+	j.debit(AccountPromoCredit, invoice.PromoCreditDiscountAmount)
+	j.credit(AccountReceivable, invoice.PromoCreditDiscountAmount)
+	return j
+}
+
+// BuildPaymentJournal Dr cash of gateway, Cr AR
+func BuildPaymentJournal(invoice *entity.Invoice, gatewayName string, entryTime int64) *Journal {
+	sourceId := invoice.PaymentId
+	if len(sourceId) == 0 {
+		sourceId = invoice.InvoiceId
+	}
+	j := newInvoiceJournal(EntryTypePaymentSuccess, sourceId, invoice, entryTime)
+	j.GatewayName = gatewayName
+	j.debit(AccountCash, invoice.TotalAmount)
+	j.credit(AccountReceivable, invoice.TotalAmount)
+	return j
+}
+
+// BuildCreditNoteJournal Dr deferred revenue up to the balance of original invoice and revenue for the rest, Dr tax payable, Cr AR,
+// the journal applies to the original invoice if known, so the deferred revenue of it reduced
+func BuildCreditNoteJournal(creditNote *entity.Invoice, originalInvoiceId string, deferredBalance int64, entryTime int64) *Journal {
+	j := newInvoiceJournal(EntryTypeCreditNoteFinalized, creditNote.InvoiceId, creditNote, entryTime)
+	if len(originalInvoiceId) > 0 {
+		j.InvoiceId = originalInvoiceId
+	}
+	total := abs(creditNote.TotalAmount)
+	tax := abs(creditNote.TaxAmount)
+	deferred, revenue := splitDeferred(total-tax, deferredBalance)
+	j.debit(AccountDeferredRevenue, deferred)
+	j.debit(AccountRevenue, revenue)
+	j.debit(AccountTaxPayable, tax)
+	j.credit(AccountReceivable, total)
+	return j
+}
+
+// BuildRefundJournal Dr AR, Cr cash of gateway
+func BuildRefundJournal(creditNote *entity.Invoice, gatewayName string, entryTime int64) *Journal {
+	sourceId := creditNote.RefundId
+	if len(sourceId) == 0 {
+		sourceId = creditNote.InvoiceId
+	}
+	j := newInvoiceJournal(EntryTypeRefundSuccess, sourceId, creditNote, entryTime)
+	j.GatewayName = gatewayName
+	j.debit(AccountReceivable, abs(creditNote.TotalAmount))
+	j.credit(AccountCash, abs(creditNote.TotalAmount))
+	return j
+}
+
+// BuildInvoiceVoidJournal reverses the finalization and promo credit of the invoice cancelled or failed before paid
+func BuildInvoiceVoidJournal(invoice *entity.Invoice, deferredBalance int64, entryTime int64) *Journal {
+	j := newInvoiceJournal(EntryTypeInvoiceVoided, invoice.InvoiceId, invoice, entryTime)
+	deferred, revenue := splitDeferred(invoice.TotalAmount+invoice.PromoCreditDiscountAmount-invoice.TaxAmount, deferredBalance)
+	j.debit(AccountDeferredRevenue, deferred)
+	j.debit(AccountRevenue, revenue)
+	j.debit(AccountTaxPayable, invoice.TaxAmount)
+	j.credit(AccountPromoCredit, invoice.PromoCreditDiscountAmount)
+	j.credit(AccountReceivable, invoice.TotalAmount)
+	return j
+}
+
+// BuildRevenueRecognitionJournal Dr deferred revenue, Cr revenue, one journal of invoice per day
+func BuildRevenueRecognitionJournal(invoice *entity.Invoice, amount int64, entryTime int64, day string) *Journal {
+	j := newInvoiceJournal(EntryTypeRevenueRecognition, fmt.Sprintf("%s_%s", invoice.InvoiceId, day), invoice, entryTime)
+	j.debit(AccountDeferredRevenue, amount)
+	j.credit(AccountRevenue, amount)
+	return j
+}
+
+// RecognizableAmount returns the part of deferred balance earned from the last recognition to now, straight-line over the remaining period,
+// the whole balance recognized once the period ended
+func RecognizableAmount(balance int64, periodStart int64, periodEnd int64, lastRecognizedAt int64, now int64) int64 {
+	if balance <= 0 {
+		return 0
+	}
+	from := periodStart
+	if lastRecognizedAt > from {
+		from = lastRecognizedAt
+	}
+	if now >= periodEnd || from >= periodEnd {
+		return balance
+	}
+	if now <= from {
+		return 0
+	}
+	return balance * (now - from) / (periodEnd - from)
+}
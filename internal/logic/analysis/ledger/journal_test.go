@@ -0,0 +1,106 @@
+package ledger
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"unibee/api/bean"
+	entity "unibee/internal/model/entity/default"
+)
+
+func amountOf(j *Journal, account string) (debit int64, credit int64) {
+	for _, line := range j.Lines {
+		if line.Account == account {
+			debit = debit + line.Debit
+			credit = credit + line.Credit
+		}
+	}
+	return
+}
+
+func TestJournal(t *testing.T) {
+	invoice := &entity.Invoice{
+		MerchantId:                1,
+		InvoiceId:                 "81720000000000001",
+		SubscriptionId:            "sub1",
+		Currency:                  "USD",
+		TotalAmount:               1100,
+		TaxAmount:                 100,
+		PromoCreditDiscountAmount: 200,
+		PeriodStart:               1000,
+		PeriodEnd:                 2000,
+		PaymentId:                 "pay1",
+	}
+	t.Run("Test for InvoiceFinalized", func(t *testing.T) {
+		j := BuildInvoiceFinalizedJournal(invoice, 1500)
+		require.True(t, j.Balanced())
+		debit, _ := amountOf(j, AccountReceivable)
+		require.Equal(t, int64(1300), debit)
+		_, credit := amountOf(j, AccountDeferredRevenue)
+		require.Equal(t, int64(1200), credit)
+		_, credit = amountOf(j, AccountTaxPayable)
+		require.Equal(t, int64(100), credit)
+		j = BuildInvoiceFinalizedJournal(invoice, 2000)
+		_, credit = amountOf(j, AccountRevenue)
+		require.Equal(t, int64(1200), credit)
+		require.Equal(t, "invoice_finalized_81720000000000001", j.JournalId)
+	})
+	t.Run("Test for PromoCreditAndPayment", func(t *testing.T) {
+		promo := BuildPromoCreditJournal(invoice, 1500)
+		require.True(t, promo.Balanced())
+		_, credit := amountOf(promo, AccountReceivable)
+		require.Equal(t, int64(200), credit)
+		payment := BuildPaymentJournal(invoice, "stripe", 1500)
+		require.True(t, payment.Balanced())
+		require.Equal(t, "payment_success_pay1", payment.JournalId)
+		debit, _ := amountOf(payment, AccountCash)
+		require.Equal(t, int64(1100), debit)
+		// receivable settled
+		finalized := BuildInvoiceFinalizedJournal(invoice, 1500)
+		arDebit, arCredit := amountOf(finalized, AccountReceivable)
+		_, promoCredit := amountOf(promo, AccountReceivable)
+		_, paymentCredit := amountOf(payment, AccountReceivable)
+		require.Equal(t, arDebit-arCredit, promoCredit+paymentCredit)
+	})
+	t.Run("Test for CreditNoteAndRefund", func(t *testing.T) {
+		creditNote := &entity.Invoice{MerchantId: 1, InvoiceId: "cn1", Currency: "USD", TotalAmount: -550, TaxAmount: -50, RefundId: "re1", PaymentId: "pay1"}
+		j := BuildCreditNoteJournal(creditNote, invoice.InvoiceId, 300, 1600)
+		require.True(t, j.Balanced())
+		require.Equal(t, invoice.InvoiceId, j.InvoiceId)
+		debit, _ := amountOf(j, AccountDeferredRevenue)
+		require.Equal(t, int64(300), debit)
+		debit, _ = amountOf(j, AccountRevenue)
+		require.Equal(t, int64(200), debit)
+		refund := BuildRefundJournal(creditNote, "stripe", 1600)
+		require.True(t, refund.Balanced())
+		require.Equal(t, "refund_success_re1", refund.JournalId)
+		_, credit := amountOf(refund, AccountCash)
+		require.Equal(t, int64(550), credit)
+	})
+	t.Run("Test for InvoiceVoided", func(t *testing.T) {
+		j := BuildInvoiceVoidJournal(invoice, 1200, 1500)
+		require.True(t, j.Balanced())
+		debit, _ := amountOf(j, AccountDeferredRevenue)
+		require.Equal(t, int64(1200), debit)
+		_, credit := amountOf(j, AccountPromoCredit)
+		require.Equal(t, int64(200), credit)
+		require.True(t, BuildInvoiceVoidJournal(&entity.Invoice{InvoiceId: "zero"}, 0, 1500).Empty())
+	})
+	t.Run("Test for RecognizableAmount", func(t *testing.T) {
+		require.Equal(t, int64(0), RecognizableAmount(1000, 1000, 2000, 0, 900))
+		require.Equal(t, int64(500), RecognizableAmount(1000, 1000, 2000, 0, 1500))
+		require.Equal(t, int64(250), RecognizableAmount(500, 1000, 2000, 1500, 1750))
+		require.Equal(t, int64(250), RecognizableAmount(250, 1000, 2000, 1750, 2500))
+		require.Equal(t, int64(0), RecognizableAmount(0, 1000, 2000, 0, 2500))
+		j := BuildRevenueRecognitionJournal(invoice, 500, 1500, "20260101")
+		require.True(t, j.Balanced())
+		require.Equal(t, "revenue_recognition_81720000000000001_20260101", j.JournalId)
+	})
+	t.Run("Test for AccountCode", func(t *testing.T) {
+		require.Equal(t, "1100", AccountCode(nil, AccountReceivable, ""))
+		codes := &bean.LedgerAccountCodes{Cash: "1010", Revenue: "4100", GatewayCash: map[string]string{"stripe": "1020"}}
+		require.Equal(t, "4100", AccountCode(codes, AccountRevenue, ""))
+		require.Equal(t, "1020", AccountCode(codes, AccountCash, "stripe"))
+		require.Equal(t, "1010", AccountCode(codes, AccountCash, "paypal"))
+		require.Equal(t, "2200", AccountCode(codes, AccountTaxPayable, ""))
+	})
+}
@@ -0,0 +1,65 @@
+package ledger
+
+import (
+	"context"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+type EntryListInternalReq struct {
+	MerchantId     uint64   `json:"merchantId"`
+	EntryTypes     []string `json:"entryTypes"`
+	Accounts       []string `json:"accounts"`
+	Currency       string   `json:"currency"`
+	InvoiceId      string   `json:"invoiceId"`
+	EntryTimeStart int64    `json:"entryTimeStart"`
+	EntryTimeEnd   int64    `json:"entryTimeEnd"`
+	Page           int      `json:"page"`
+	Count          int      `json:"count"`
+	SkipTotal      bool     `json:"skipTotal"`
+}
+
+// EntryList returns the lines ordered by entry time and journal, lines of one journal kept together
+func EntryList(ctx context.Context, req *EntryListInternalReq) (list []*entity.MerchantLedgerEntry, total int, err error) {
+	utility.Assert(req.MerchantId > 0, "invalid merchantId")
+	if req.Count <= 0 {
+		req.Count = 20
+	}
+	if req.Page < 0 {
+		req.Page = 0
+	}
+	columns := dao.MerchantLedgerEntry.Columns()
+	q := dao.MerchantLedgerEntry.Ctx(ctx).
+		Where(columns.MerchantId, req.MerchantId).
+		Where(columns.IsDeleted, 0)
+	if len(req.EntryTypes) > 0 {
+		q = q.WhereIn(columns.EntryType, req.EntryTypes)
+	}
+	if len(req.Accounts) > 0 {
+		q = q.WhereIn(columns.Account, req.Accounts)
+	}
+	if len(req.Currency) > 0 {
+		q = q.Where(columns.Currency, req.Currency)
+	}
+	if len(req.InvoiceId) > 0 {
+		q = q.Where(columns.InvoiceId, req.InvoiceId)
+	}
+	if req.EntryTimeStart > 0 {
+		q = q.WhereGTE(columns.EntryTime, req.EntryTimeStart)
+	}
+	if req.EntryTimeEnd > 0 {
+		q = q.WhereLTE(columns.EntryTime, req.EntryTimeEnd)
+	}
+	q = q.Order(columns.EntryTime).Order(columns.JournalId).Order(columns.LineNo).
+		Limit(req.Page*req.Count, req.Count)
+	if req.SkipTotal {
+		err = q.Scan(&list)
+	} else {
+		err = q.ScanAndCount(&list, &total, true)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
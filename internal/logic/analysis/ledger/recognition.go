@@ -0,0 +1,95 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const recognitionBatchSize = 200
+
+type deferredInvoice struct {
+	InvoiceId string `json:"invoiceId"`
+	Balance   int64  `json:"balance"`
+}
+
+// RecognizeDeferredRevenue moves the earned part of deferred revenue to revenue for all invoices with deferred balance,
+// one recognition journal of invoice per day, safe to run again on the same day,
+// each invoice recognized under the same lock with the invoice listeners, see recordWithLock
+func RecognizeDeferredRevenue(ctx context.Context, now int64) {
+	day := gtime.NewFromTimeStamp(now).Format("Ymd")
+	columns := dao.MerchantLedgerEntry.Columns()
+	var lastInvoiceId = ""
+	for {
+		var list []*deferredInvoice
+		err := dao.MerchantLedgerEntry.Ctx(ctx).
+			Fields(fmt.Sprintf("%s AS invoice_id, SUM(%s)-SUM(%s) AS balance", columns.InvoiceId, columns.Credit, columns.Debit)).
+			Where(columns.Account, AccountDeferredRevenue).
+			WhereGT(columns.InvoiceId, lastInvoiceId).
+			Group(columns.InvoiceId).
+			Having("balance > 0").
+			Order(columns.InvoiceId).
+			Limit(recognitionBatchSize).
+			Scan(&list)
+		if err != nil {
+			g.Log().Errorf(ctx, "RecognizeDeferredRevenue query err:%s", err.Error())
+			return
+		}
+		for _, one := range list {
+			lastInvoiceId = one.InvoiceId
+			invoice := query.GetInvoiceByInvoiceId(ctx, one.InvoiceId)
+			if invoice == nil {
+				continue
+			}
+			err = recordWithLock(ctx, invoice.InvoiceId, func(ctx context.Context) error {
+				return saveRevenueRecognition(ctx, invoice, now, day)
+			})
+			if err != nil {
+				g.Log().Errorf(ctx, "RecognizeDeferredRevenue invoice %s err:%s", one.InvoiceId, err.Error())
+			}
+		}
+		if len(list) < recognitionBatchSize {
+			break
+		}
+	}
+}
+
+// saveRevenueRecognition re-reads the deferred balance of invoice inside the invoice lock,
+// the credit note or void journal written before not recognized again
+func saveRevenueRecognition(ctx context.Context, invoice *entity.Invoice, now int64, day string) error {
+	balance, err := deferredBalance(ctx, invoice.MerchantId, invoice.InvoiceId)
+	if err != nil || balance <= 0 {
+		return err
+	}
+	lastRecognizedAt, err := lastRecognitionTime(ctx, invoice.MerchantId, invoice.InvoiceId)
+	if err != nil {
+		return err
+	}
+	amount := RecognizableAmount(balance, invoice.PeriodStart, invoice.PeriodEnd, lastRecognizedAt, now)
+	if amount <= 0 {
+		return nil
+	}
+	return saveJournal(ctx, BuildRevenueRecognitionJournal(invoice, amount, now, day))
+}
+
+func lastRecognitionTime(ctx context.Context, merchantId uint64, invoiceId string) (int64, error) {
+	var result struct {
+		LastRecognizedAt int64 `json:"lastRecognizedAt"`
+	}
+	err := dao.MerchantLedgerEntry.Ctx(ctx).
+		Fields(fmt.Sprintf("IFNULL(MAX(%s),0) AS last_recognized_at", dao.MerchantLedgerEntry.Columns().EntryTime)).
+		Where(dao.MerchantLedgerEntry.Columns().MerchantId, merchantId).
+		Where(dao.MerchantLedgerEntry.Columns().InvoiceId, invoiceId).
+		Where(dao.MerchantLedgerEntry.Columns().Account, AccountDeferredRevenue).
+		Where(dao.MerchantLedgerEntry.Columns().EntryType, EntryTypeRevenueRecognition).
+		Scan(&result)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastRecognizedAt, nil
+}
@@ -0,0 +1,218 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// RecordInvoiceFinalized writes the receivable and promo credit journals of the invoice sent to user,
+// the credit note recorded when refund succeeded
+func RecordInvoiceFinalized(ctx context.Context, invoice *entity.Invoice) error {
+	if invoice == nil || invoice.TotalAmount < 0 {
+		return nil
+	}
+	return recordWithLock(ctx, invoice.InvoiceId, func(ctx context.Context) error {
+		return saveInvoiceFinalized(ctx, invoice, gtime.Now().Timestamp())
+	})
+}
+
+// RecordInvoicePaid writes the payment journal of invoice, or the credit note and refund journals of refund invoice
+func RecordInvoicePaid(ctx context.Context, invoice *entity.Invoice) error {
+	if invoice == nil {
+		return nil
+	}
+	return recordWithLock(ctx, invoice.InvoiceId, func(ctx context.Context) error {
+		if invoice.TotalAmount < 0 {
+			return saveCreditNoteRefunded(ctx, invoice)
+		}
+		return saveInvoicePaid(ctx, invoice)
+	})
+}
+
+// RecordInvoiceVoided reverses the journals of the finalized invoice cancelled or failed without payment
+func RecordInvoiceVoided(ctx context.Context, invoice *entity.Invoice) error {
+	if invoice == nil || invoice.TotalAmount < 0 {
+		return nil
+	}
+	return recordWithLock(ctx, invoice.InvoiceId, func(ctx context.Context) error {
+		finalized, err := journalExist(ctx, invoice.MerchantId, JournalId(EntryTypeInvoiceFinalized, invoice.InvoiceId))
+		if err != nil || !finalized {
+			return err
+		}
+		paidCount, err := dao.MerchantLedgerEntry.Ctx(ctx).
+			Where(dao.MerchantLedgerEntry.Columns().MerchantId, invoice.MerchantId).
+			Where(dao.MerchantLedgerEntry.Columns().InvoiceId, invoice.InvoiceId).
+			Where(dao.MerchantLedgerEntry.Columns().EntryType, EntryTypePaymentSuccess).
+			Count()
+		if err != nil || paidCount > 0 {
+			return err
+		}
+		balance, err := deferredBalance(ctx, invoice.MerchantId, invoice.InvoiceId)
+		if err != nil {
+			return err
+		}
+		return saveJournal(ctx, BuildInvoiceVoidJournal(invoice, balance, gtime.Now().Timestamp()))
+	})
+}
+
+// recordWithLock writes the journals of one invoice one by one, the error returned to the invoice listener to reconsume the message,
+// the journal already written is skipped by its journal id, see saveJournal
+func recordWithLock(ctx context.Context, invoiceId string, record func(ctx context.Context) error) error {
+	lockKey := fmt.Sprintf("Ledger_Invoice_%s", invoiceId)
+	for i := 0; !utility.TryLock(ctx, lockKey, 60); i++ {
+		if i >= 20 {
+			return gerror.Newf("ledger record invoice %s lock timeout", invoiceId)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	defer utility.ReleaseLock(ctx, lockKey)
+	err := record(ctx)
+	if err != nil {
+		g.Log().Errorf(ctx, "Ledger record invoice %s err:%s", invoiceId, err.Error())
+	}
+	return err
+}
+
+func saveInvoiceFinalized(ctx context.Context, invoice *entity.Invoice, entryTime int64) error {
+	err := saveJournal(ctx, BuildInvoiceFinalizedJournal(invoice, entryTime))
+	if err != nil {
+		return err
+	}
+	return saveJournal(ctx, BuildPromoCreditJournal(invoice, entryTime))
+}
+
+func saveInvoicePaid(ctx context.Context, invoice *entity.Invoice) error {
+	var paidTime = gtime.Now().Timestamp()
+	payment := query.GetPaymentByPaymentId(ctx, invoice.PaymentId)
+	if payment != nil && payment.PaidTime > 0 {
+		paidTime = payment.PaidTime
+	}
+	// the invoice paid without processing finalized here
+	err := saveInvoiceFinalized(ctx, invoice, paidTime)
+	if err != nil {
+		return err
+	}
+	return saveJournal(ctx, BuildPaymentJournal(invoice, gatewayName(ctx, invoice.GatewayId), paidTime))
+}
+
+func saveCreditNoteRefunded(ctx context.Context, creditNote *entity.Invoice) error {
+	if len(creditNote.RefundId) == 0 {
+		return nil
+	}
+	var refundTime = gtime.Now().Timestamp()
+	refund := query.GetRefundByRefundId(ctx, creditNote.RefundId)
+	if refund == nil || refund.Status != consts.RefundSuccess {
+		return nil
+	}
+	if refund.RefundTime > 0 {
+		refundTime = refund.RefundTime
+	}
+	var originalInvoiceId = ""
+	payment := query.GetPaymentByPaymentId(ctx, creditNote.PaymentId)
+	if payment != nil {
+		originalInvoiceId = payment.InvoiceId
+	}
+	var balance int64 = 0
+	if len(originalInvoiceId) > 0 {
+		var err error
+		balance, err = deferredBalance(ctx, creditNote.MerchantId, originalInvoiceId)
+		if err != nil {
+			return err
+		}
+	}
+	err := saveJournal(ctx, BuildCreditNoteJournal(creditNote, originalInvoiceId, balance, refundTime))
+	if err != nil {
+		return err
+	}
+	refundJournal := BuildRefundJournal(creditNote, gatewayName(ctx, refund.GatewayId), refundTime)
+	refundJournal.GatewayId = refund.GatewayId
+	return saveJournal(ctx, refundJournal)
+}
+
+func gatewayName(ctx context.Context, gatewayId uint64) string {
+	gateway := query.GetGatewayById(ctx, gatewayId)
+	if gateway != nil {
+		return gateway.GatewayName
+	}
+	return ""
+}
+
+func journalExist(ctx context.Context, merchantId uint64, journalId string) (bool, error) {
+	count, err := dao.MerchantLedgerEntry.Ctx(ctx).
+		Where(dao.MerchantLedgerEntry.Columns().MerchantId, merchantId).
+		Where(dao.MerchantLedgerEntry.Columns().JournalId, journalId).
+		Count()
+	return count > 0, err
+}
+
+func deferredBalance(ctx context.Context, merchantId uint64, invoiceId string) (int64, error) {
+	var result struct {
+		Balance int64 `json:"balance"`
+	}
+	err := dao.MerchantLedgerEntry.Ctx(ctx).
+		Fields(fmt.Sprintf("IFNULL(SUM(%s)-SUM(%s),0) AS balance", dao.MerchantLedgerEntry.Columns().Credit, dao.MerchantLedgerEntry.Columns().Debit)).
+		Where(dao.MerchantLedgerEntry.Columns().MerchantId, merchantId).
+		Where(dao.MerchantLedgerEntry.Columns().InvoiceId, invoiceId).
+		Where(dao.MerchantLedgerEntry.Columns().Account, AccountDeferredRevenue).
+		Scan(&result)
+	if err != nil {
+		return 0, err
+	}
+	return result.Balance, nil
+}
+
+// saveJournal writes the lines of journal in one transaction, the journal already written or without line skipped
+func saveJournal(ctx context.Context, journal *Journal) error {
+	if journal == nil || journal.Empty() {
+		return nil
+	}
+	if !journal.Balanced() {
+		return gerror.Newf("journal %s not balanced, debit:%d credit:%d", journal.JournalId, journal.TotalDebit(), journal.TotalCredit())
+	}
+	exist, err := journalExist(ctx, journal.MerchantId, journal.JournalId)
+	if err != nil || exist {
+		return err
+	}
+	return dao.MerchantLedgerEntry.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		for i, line := range journal.Lines {
+			one := &entity.MerchantLedgerEntry{
+				MerchantId:     journal.MerchantId,
+				JournalId:      journal.JournalId,
+				EntryType:      journal.EntryType,
+				SourceId:       journal.SourceId,
+				LineNo:         i + 1,
+				Account:        line.Account,
+				Debit:          line.Debit,
+				Credit:         line.Credit,
+				Currency:       journal.Currency,
+				UserId:         journal.UserId,
+				SubscriptionId: journal.SubscriptionId,
+				InvoiceId:      journal.InvoiceId,
+				Description:    journal.Description,
+				EntryTime:      journal.EntryTime,
+				UniqueKey:      fmt.Sprintf("%d_%s_%d", journal.MerchantId, journal.JournalId, i+1),
+				CreateTime:     gtime.Now().Timestamp(),
+			}
+			if line.Account == AccountCash {
+				one.GatewayId = journal.GatewayId
+				one.GatewayName = journal.GatewayName
+			}
+			_, err := dao.MerchantLedgerEntry.Ctx(ctx).Data(one).OmitNil().Insert(one)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,92 @@
+package revenue
+
+import (
+	"context"
+	"strings"
+	"unibee/internal/logic/multi_currencies/currency_exchange"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// currencyConverter normalizes amounts to the reporting currency using the merchant exchange rates,
+// rates resolved once during one query
+type currencyConverter struct {
+	ctx               context.Context
+	merchantId        uint64
+	reportingCurrency string
+	configs           []*entity.MerchantMultiCurrencyConfig
+	rates             map[string]float64
+	unconverted       map[string]bool
+}
+
+func newCurrencyConverter(ctx context.Context, merchantId uint64, reportingCurrency string) *currencyConverter {
+	return &currencyConverter{
+		ctx:               ctx,
+		merchantId:        merchantId,
+		reportingCurrency: strings.ToUpper(reportingCurrency),
+		configs:           entity.GetMerchantMultiCurrencyConfig(ctx, merchantId),
+		rates:             make(map[string]float64),
+		unconverted:       make(map[string]bool),
+	}
+}
+
+func (c *currencyConverter) rate(currency string) (rate float64) {
+	currency = strings.ToUpper(currency)
+	if currency == c.reportingCurrency {
+		return 1
+	}
+	if one, ok := c.rates[currency]; ok {
+		return one
+	}
+	defer func() {
+		c.rates[currency] = rate
+	}()
+	for _, config := range c.configs {
+		if strings.ToUpper(config.DefaultCurrency) == currency {
+			for _, one := range config.MultiCurrencies {
+				if strings.ToUpper(one.Currency) == c.reportingCurrency && one.ExchangeRate > 0 {
+					return one.ExchangeRate
+				}
+			}
+		}
+	}
+	for _, config := range c.configs {
+		if strings.ToUpper(config.DefaultCurrency) == c.reportingCurrency {
+			for _, one := range config.MultiCurrencies {
+				if strings.ToUpper(one.Currency) == currency && one.ExchangeRate > 0 {
+					return 1 / one.ExchangeRate
+				}
+			}
+		}
+	}
+	defer func() {
+		if exception := recover(); exception != nil {
+			g.Log().Errorf(c.ctx, "Revenue exchange rate not found merchantId:%d from:%s to:%s error:%v", c.merchantId, currency, c.reportingCurrency, exception)
+			rate = 0
+		}
+	}()
+	return currency_exchange.GetMerchantExchangeCurrencyRate(c.ctx, c.merchantId, currency, c.reportingCurrency)
+}
+
+// Convert returns the amount in reporting currency, the currency without exchange rate is recorded and converted to 0
+func (c *currencyConverter) Convert(amount int64, currency string) int64 {
+	if amount == 0 {
+		return 0
+	}
+	rate := c.rate(currency)
+	if rate <= 0 {
+		c.unconverted[strings.ToUpper(currency)] = true
+		return 0
+	}
+	return utility.ExchangeCurrencyConvert(amount, currency, c.reportingCurrency, rate)
+}
+
+func (c *currencyConverter) UnconvertedCurrencies() []string {
+	var list = make([]string, 0)
+	for currency := range c.unconverted {
+		list = append(list, currency)
+	}
+	return list
+}
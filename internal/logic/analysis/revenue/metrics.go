@@ -0,0 +1,253 @@
+package revenue
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+	"unibee/api/bean"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const maxRangeDays = 731
+
+type RevenueQueryInternalReq struct {
+	MerchantId        uint64   `json:"merchantId"`
+	StartTime         int64    `json:"startTime"`
+	EndTime           int64    `json:"endTime"`
+	PlanIds           []uint64 `json:"planIds"`
+	Currency          string   `json:"currency"`
+	ReportingCurrency string   `json:"reportingCurrency"`
+}
+
+func checkRevenueQueryReq(ctx context.Context, req *RevenueQueryInternalReq) {
+	utility.Assert(req.MerchantId > 0, "invalid merchantId")
+	if req.EndTime <= 0 {
+		req.EndTime = gtime.Now().Timestamp()
+	}
+	if req.StartTime <= 0 {
+		req.StartTime = req.EndTime - 30*86400
+	}
+	utility.Assert(req.StartTime <= req.EndTime, "startTime should be earlier than endTime")
+	utility.Assert((DayStart(req.EndTime)-DayStart(req.StartTime))/86400 < maxRangeDays, "time range should be within 2 years")
+	req.Currency = strings.ToUpper(req.Currency)
+	req.ReportingCurrency = strings.ToUpper(req.ReportingCurrency)
+	if len(req.ReportingCurrency) == 0 {
+		req.ReportingCurrency = defaultReportingCurrency(ctx, req.MerchantId)
+	}
+}
+
+// defaultReportingCurrency is the currency of merchant's latest main plan, USD if no plan
+func defaultReportingCurrency(ctx context.Context, merchantId uint64) string {
+	one := query.GetOneLatestMainPlanByMerchantId(ctx, merchantId)
+	if one != nil && len(one.Currency) > 0 {
+		return strings.ToUpper(one.Currency)
+	}
+	return "USD"
+}
+
+func rate(numerator int64, denominator int64) float64 {
+	if denominator <= 0 {
+		return 0
+	}
+	return math.Round(float64(numerator)/float64(denominator)*10000) / 10000
+}
+
+func arpu(mrr int64, active int64) int64 {
+	if active <= 0 {
+		return 0
+	}
+	return mrr / active
+}
+
+// getDailyPoints aggregates the snapshots of each day in the reporting currency
+func getDailyPoints(ctx context.Context, req *RevenueQueryInternalReq) ([]*bean.RevenueMrrPoint, *currencyConverter, error) {
+	converter := newCurrencyConverter(ctx, req.MerchantId, req.ReportingCurrency)
+	var list []*entity.MerchantRevenueSnapshot
+	q := dao.MerchantRevenueSnapshot.Ctx(ctx).
+		Where(dao.MerchantRevenueSnapshot.Columns().MerchantId, req.MerchantId).
+		Where(dao.MerchantRevenueSnapshot.Columns().IsDeleted, 0).
+		WhereGTE(dao.MerchantRevenueSnapshot.Columns().SnapshotTime, DayStart(req.StartTime)).
+		WhereLTE(dao.MerchantRevenueSnapshot.Columns().SnapshotTime, DayStart(req.EndTime))
+	if len(req.PlanIds) > 0 {
+		q = q.WhereIn(dao.MerchantRevenueSnapshot.Columns().PlanId, req.PlanIds)
+	}
+	if len(req.Currency) > 0 {
+		q = q.Where(dao.MerchantRevenueSnapshot.Columns().Currency, req.Currency)
+	}
+	err := q.OrderAsc(dao.MerchantRevenueSnapshot.Columns().SnapshotTime).Scan(&list)
+	if err != nil {
+		return nil, converter, err
+	}
+	var points = make([]*bean.RevenueMrrPoint, 0)
+	var pointMap = make(map[int64]*bean.RevenueMrrPoint)
+	for _, one := range list {
+		point, ok := pointMap[one.SnapshotTime]
+		if !ok {
+			point = &bean.RevenueMrrPoint{Date: one.SnapshotDate, Time: one.SnapshotTime}
+			pointMap[one.SnapshotTime] = point
+			points = append(points, point)
+		}
+		point.Mrr = point.Mrr + converter.Convert(one.Mrr, one.Currency)
+		point.NewMrr = point.NewMrr + converter.Convert(one.NewMrr, one.Currency)
+		point.ExpansionMrr = point.ExpansionMrr + converter.Convert(one.ExpansionMrr, one.Currency)
+		point.ContractionMrr = point.ContractionMrr + converter.Convert(one.ContractionMrr, one.Currency)
+		point.ChurnMrr = point.ChurnMrr + converter.Convert(one.ChurnMrr, one.Currency)
+		point.ReactivationMrr = point.ReactivationMrr + converter.Convert(one.ReactivationMrr, one.Currency)
+		point.Revenue = point.Revenue + converter.Convert(one.Revenue, one.Currency)
+		point.RefundAmount = point.RefundAmount + converter.Convert(one.RefundAmount, one.Currency)
+		point.ActiveSubscriptions = point.ActiveSubscriptions + one.ActiveSubscriptions
+		point.NewSubscriptions = point.NewSubscriptions + one.NewSubscriptions
+		point.ChurnedSubscriptions = point.ChurnedSubscriptions + one.ChurnedSubscriptions
+		point.ReactivatedSubscriptions = point.ReactivatedSubscriptions + one.ReactivatedSubscriptions
+	}
+	for _, point := range points {
+		point.Arr = point.Mrr * 12
+		point.Arpu = arpu(point.Mrr, point.ActiveSubscriptions)
+		point.NetNewMrr = point.NewMrr + point.ExpansionMrr + point.ReactivationMrr - point.ContractionMrr - point.ChurnMrr
+	}
+	return points, converter, nil
+}
+
+// GetMrrMetrics returns the daily mrr, arr, arpu and mrr movements in range
+func GetMrrMetrics(ctx context.Context, req *RevenueQueryInternalReq) ([]*bean.RevenueMrrPoint, *bean.RevenueMrrSummary, error) {
+	checkRevenueQueryReq(ctx, req)
+	points, converter, err := getDailyPoints(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	summary := &bean.RevenueMrrSummary{ReportingCurrency: req.ReportingCurrency}
+	for _, point := range points {
+		summary.NewMrr = summary.NewMrr + point.NewMrr
+		summary.ExpansionMrr = summary.ExpansionMrr + point.ExpansionMrr
+		summary.ContractionMrr = summary.ContractionMrr + point.ContractionMrr
+		summary.ChurnMrr = summary.ChurnMrr + point.ChurnMrr
+		summary.ReactivationMrr = summary.ReactivationMrr + point.ReactivationMrr
+		summary.NetNewMrr = summary.NetNewMrr + point.NetNewMrr
+		summary.Revenue = summary.Revenue + point.Revenue
+		summary.RefundAmount = summary.RefundAmount + point.RefundAmount
+	}
+	if len(points) > 0 {
+		first := points[0]
+		last := points[len(points)-1]
+		summary.StartMrr = first.Mrr - first.NetNewMrr
+		summary.EndMrr = last.Mrr
+		summary.Arr = last.Arr
+		summary.Arpu = last.Arpu
+		summary.ActiveSubscriptions = last.ActiveSubscriptions
+	}
+	summary.UnconvertedCurrencies = converter.UnconvertedCurrencies()
+	return points, summary, nil
+}
+
+// GetChurnMetrics returns the logo and revenue churn and ltv in range
+func GetChurnMetrics(ctx context.Context, req *RevenueQueryInternalReq) (*bean.RevenueChurnMetrics, error) {
+	checkRevenueQueryReq(ctx, req)
+	points, converter, err := getDailyPoints(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	metrics := &bean.RevenueChurnMetrics{ReportingCurrency: req.ReportingCurrency}
+	if len(points) == 0 {
+		metrics.UnconvertedCurrencies = converter.UnconvertedCurrencies()
+		return metrics, nil
+	}
+	first := points[0]
+	last := points[len(points)-1]
+	metrics.StartMrr = first.Mrr - first.NetNewMrr
+	metrics.StartActiveSubscriptions = first.ActiveSubscriptions - first.NewSubscriptions - first.ReactivatedSubscriptions + first.ChurnedSubscriptions
+	for _, point := range points {
+		metrics.ChurnedSubscriptions = metrics.ChurnedSubscriptions + point.ChurnedSubscriptions
+		metrics.ChurnMrr = metrics.ChurnMrr + point.ChurnMrr
+		metrics.ContractionMrr = metrics.ContractionMrr + point.ContractionMrr
+		metrics.ExpansionMrr = metrics.ExpansionMrr + point.ExpansionMrr
+		metrics.ReactivationMrr = metrics.ReactivationMrr + point.ReactivationMrr
+	}
+	days := (DayStart(req.EndTime)-DayStart(req.StartTime))/86400 + 1
+	metrics.LogoChurnRate = rate(metrics.ChurnedSubscriptions, metrics.StartActiveSubscriptions)
+	metrics.MonthlyLogoChurnRate = math.Round(metrics.LogoChurnRate*30/float64(days)*10000) / 10000
+	metrics.RevenueChurnRate = rate(metrics.ChurnMrr+metrics.ContractionMrr, metrics.StartMrr)
+	metrics.NetRevenueChurnRate = rate(metrics.ChurnMrr+metrics.ContractionMrr-metrics.ExpansionMrr-metrics.ReactivationMrr, metrics.StartMrr)
+	metrics.Arpu = last.Arpu
+	if metrics.MonthlyLogoChurnRate > 0 {
+		metrics.Ltv = int64(float64(metrics.Arpu) / metrics.MonthlyLogoChurnRate)
+	}
+	metrics.UnconvertedCurrencies = converter.UnconvertedCurrencies()
+	return metrics, nil
+}
+
+func monthStart(timestamp int64) time.Time {
+	t := time.Unix(timestamp, 0).UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// GetCohortRetention groups subscriptions by the month first paid and reports the retention of each month after
+func GetCohortRetention(ctx context.Context, req *RevenueQueryInternalReq) ([]*bean.RevenueCohort, []string, error) {
+	checkRevenueQueryReq(ctx, req)
+	now := gtime.Now().Timestamp()
+	firstMonth := monthStart(req.StartTime)
+	lastMonth := monthStart(req.EndTime)
+	utility.Assert(!firstMonth.AddDate(2, 0, 0).Before(lastMonth), "cohort range should be within 24 months")
+	converter := newCurrencyConverter(ctx, req.MerchantId, req.ReportingCurrency)
+	calculator := newMrrCalculator(ctx)
+	var cohorts = make([]*bean.RevenueCohort, 0)
+	for month := firstMonth; !month.After(lastMonth); month = month.AddDate(0, 1, 0) {
+		var subs []*entity.Subscription
+		q := dao.Subscription.Ctx(ctx).
+			Where(dao.Subscription.Columns().MerchantId, req.MerchantId).
+			WhereGTE(dao.Subscription.Columns().FirstPaidTime, month.Unix()).
+			WhereLT(dao.Subscription.Columns().FirstPaidTime, month.AddDate(0, 1, 0).Unix())
+		if len(req.PlanIds) > 0 {
+			q = q.WhereIn(dao.Subscription.Columns().PlanId, req.PlanIds)
+		}
+		if len(req.Currency) > 0 {
+			q = q.Where(dao.Subscription.Columns().Currency, req.Currency)
+		}
+		err := q.Scan(&subs)
+		if err != nil {
+			return nil, nil, err
+		}
+		cohort := &bean.RevenueCohort{
+			Cohort:        month.Format("2006-01"),
+			CohortTime:    month.Unix(),
+			Subscriptions: int64(len(subs)),
+			Retention:     make([]*bean.RevenueCohortRetention, 0),
+		}
+		cohorts = append(cohorts, cohort)
+		if len(subs) == 0 {
+			continue
+		}
+		var subIds []string
+		for _, sub := range subs {
+			subIds = append(subIds, sub.SubscriptionId)
+		}
+		subTimelines, err := getSubscriptionTimelines(ctx, req.MerchantId, subIds)
+		if err != nil {
+			return nil, nil, err
+		}
+		for offset := 0; month.AddDate(0, offset, 0).Unix() <= now; offset++ {
+			timeAt := utility.MinInt64(month.AddDate(0, offset+1, 0).Unix()-1, now)
+			retention := &bean.RevenueCohortRetention{MonthOffset: offset}
+			for _, sub := range subs {
+				timeline := TimelineAt(subTimelines[sub.SubscriptionId], timeAt)
+				mrr := calculator.TimelineMrr(sub, timeline, timeAt)
+				if mrr > 0 {
+					retention.ActiveSubscriptions++
+					retention.Mrr = retention.Mrr + converter.Convert(mrr, timeline.Currency)
+				}
+			}
+			if offset == 0 {
+				cohort.InitialMrr = retention.Mrr
+			}
+			retention.LogoRetentionRate = rate(retention.ActiveSubscriptions, cohort.Subscriptions)
+			retention.RevenueRetentionRate = rate(retention.Mrr, cohort.InitialMrr)
+			cohort.Retention = append(cohort.Retention, retention)
+		}
+	}
+	return cohorts, converter.UnconvertedCurrencies(), nil
+}
@@ -0,0 +1,213 @@
+package revenue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	"unibee/internal/logic/plan/price"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type MovementType int
+
+const (
+	MovementNone         MovementType = 0
+	MovementNew          MovementType = 1
+	MovementExpansion    MovementType = 2
+	MovementContraction  MovementType = 3
+	MovementChurn        MovementType = 4
+	MovementReactivation MovementType = 5
+)
+
+// MonthlyAmount normalizes the amount of one billing interval to a month
+func MonthlyAmount(amount int64, intervalUnit string, intervalCount int) int64 {
+	if intervalCount <= 0 {
+		intervalCount = 1
+	}
+	var monthly float64
+	switch strings.ToLower(intervalUnit) {
+	case "day":
+		monthly = float64(amount) * 365 / 12 / float64(intervalCount)
+	case "week":
+		monthly = float64(amount) * 52 / 12 / float64(intervalCount)
+	case "year":
+		monthly = float64(amount) / 12 / float64(intervalCount)
+	default:
+		monthly = float64(amount) / float64(intervalCount)
+	}
+	return int64(monthly + 0.5)
+}
+
+// ClassifyMovement classifies the mrr change of one subscription between two points of time,
+// hadMrrBefore tells whether the subscription contributed mrr in any period before
+func ClassifyMovement(prevMrr int64, currentMrr int64, hadMrrBefore bool) MovementType {
+	if prevMrr == currentMrr {
+		return MovementNone
+	}
+	if prevMrr <= 0 {
+		if hadMrrBefore {
+			return MovementReactivation
+		}
+		return MovementNew
+	}
+	if currentMrr <= 0 {
+		return MovementChurn
+	}
+	if currentMrr > prevMrr {
+		return MovementExpansion
+	}
+	return MovementContraction
+}
+
+func isMrrTimeline(one *entity.SubscriptionTimeline) bool {
+	return one.Status == consts.SubTimeLineStatusProcessing || one.Status == consts.SubTimeLineStatusFinished
+}
+
+// TimelineAt returns the paid period of subscription covering the time
+func TimelineAt(timelines []*entity.SubscriptionTimeline, timeAt int64) *entity.SubscriptionTimeline {
+	var target *entity.SubscriptionTimeline
+	for _, one := range timelines {
+		if isMrrTimeline(one) && one.PeriodStart <= timeAt && timeAt < one.PeriodEnd {
+			if target == nil || one.PeriodStart > target.PeriodStart {
+				target = one
+			}
+		}
+	}
+	return target
+}
+
+// hadMrrBefore checks whether the subscription has a paid period ended before the time
+func hadMrrBefore(timelines []*entity.SubscriptionTimeline, timeAt int64) bool {
+	for _, one := range timelines {
+		if isMrrTimeline(one) && one.PeriodEnd <= timeAt {
+			return true
+		}
+	}
+	return false
+}
+
+// PriceVersionAt returns the price version the subscription billed on at the time, version is the one billed on now,
+// 0 follows the price current at the time. The finished migrations after the time are rewound, latest effected first,
+// and the version created after the time falls back to the one current at the time, version 1 is the initial price of plan
+func PriceVersionAt(version int, versions []*entity.PlanPriceVersion, migrations []*entity.PlanPriceMigration, timeAt int64) int {
+	for _, one := range migrations {
+		if one.EffectTime <= timeAt {
+			break
+		}
+		if one.ToVersion == version {
+			version = one.FromVersion
+		}
+	}
+	var currentAt = 1
+	var createdAfter = version <= 0
+	for _, one := range versions {
+		if one.Version <= 1 {
+			continue
+		}
+		if one.CreateTime <= timeAt {
+			currentAt = utility.MaxInt(currentAt, one.Version)
+		} else if one.Version == version {
+			createdAfter = true
+		}
+	}
+	if createdAfter {
+		return currentAt
+	}
+	return version
+}
+
+// mrrCalculator computes the mrr of subscription periods in the currency of period, plans and price history cached during one calculation
+type mrrCalculator struct {
+	ctx        context.Context
+	plans      map[string]*entity.Plan
+	versions   map[uint64][]*entity.PlanPriceVersion
+	migrations map[uint64][]*entity.PlanPriceMigration
+}
+
+func newMrrCalculator(ctx context.Context) *mrrCalculator {
+	return &mrrCalculator{
+		ctx:        ctx,
+		plans:      make(map[string]*entity.Plan),
+		versions:   make(map[uint64][]*entity.PlanPriceVersion),
+		migrations: make(map[uint64][]*entity.PlanPriceMigration),
+	}
+}
+
+// priceVersionAt returns the price version of plan the subscription billed on at the time
+func (c *mrrCalculator) priceVersionAt(sub *entity.Subscription, planId uint64, timeAt int64) int {
+	plan := c.plan(planId, 0)
+	if plan == nil || plan.Type != consts.PlanTypeMain {
+		return 0
+	}
+	var version = 0
+	if sub != nil && sub.PlanId == planId {
+		version = price.SubscriptionPriceVersion(sub, plan)
+	}
+	if _, ok := c.versions[planId]; !ok {
+		c.versions[planId] = query.GetPlanPriceVersionList(c.ctx, planId)
+		c.migrations[planId] = query.GetFinishedPlanPriceMigrationList(c.ctx, planId)
+	}
+	if len(c.versions[planId]) == 0 {
+		// plan never changed price
+		return 0
+	}
+	return PriceVersionAt(version, c.versions[planId], c.migrations[planId], timeAt)
+}
+
+func (c *mrrCalculator) plan(planId uint64, priceVersion int) *entity.Plan {
+	key := fmt.Sprintf("%d_%d", planId, priceVersion)
+	if one, ok := c.plans[key]; ok {
+		return one
+	}
+	one := query.GetPlanById(c.ctx, planId)
+	if one != nil && priceVersion > 0 {
+		one = price.PlanAtPriceVersion(c.ctx, one, priceVersion)
+	}
+	c.plans[key] = one
+	return one
+}
+
+func (c *mrrCalculator) planMonthlyAmount(plan *entity.Plan, currency string, quantity int64) (amount int64) {
+	if plan == nil || quantity <= 0 {
+		return 0
+	}
+	defer func() {
+		if exception := recover(); exception != nil {
+			g.Log().Errorf(c.ctx, "Revenue planMonthlyAmount planId:%d currency:%s error:%v", plan.Id, currency, exception)
+			amount = 0
+		}
+	}()
+	return MonthlyAmount(plan.CurrencyAmount(c.ctx, currency)*quantity, plan.IntervalUnit, plan.IntervalCount)
+}
+
+// TimelineMrr returns the mrr of the subscription period at the list price in effect at the time, the trial period contributes nothing
+func (c *mrrCalculator) TimelineMrr(sub *entity.Subscription, one *entity.SubscriptionTimeline, timeAt int64) int64 {
+	if one == nil {
+		return 0
+	}
+	if sub != nil && sub.TrialEnd > timeAt {
+		return 0
+	}
+	mrr := c.planMonthlyAmount(c.plan(one.PlanId, c.priceVersionAt(sub, one.PlanId, timeAt)), one.Currency, utility.MaxInt64(one.Quantity, 1))
+	if len(one.AddonData) > 0 {
+		var addonParams []*bean.PlanAddonParam
+		err := utility.UnmarshalFromJsonString(one.AddonData, &addonParams)
+		if err != nil {
+			g.Log().Errorf(c.ctx, "Revenue TimelineMrr Unmarshal addon param:%s", err.Error())
+		}
+		for _, addonParam := range addonParams {
+			addon := c.plan(addonParam.AddonPlanId, 0)
+			if addon == nil || addon.Type != consts.PlanTypeRecurringAddon {
+				continue
+			}
+			mrr = mrr + c.planMonthlyAmount(addon, one.Currency, addonParam.Quantity)
+		}
+	}
+	return mrr
+}
@@ -0,0 +1,56 @@
+package revenue
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+)
+
+func TestMrr(t *testing.T) {
+	t.Run("Test for MonthlyAmount", func(t *testing.T) {
+		require.Equal(t, int64(1000), MonthlyAmount(1000, "month", 1))
+		require.Equal(t, int64(500), MonthlyAmount(1000, "month", 2))
+		require.Equal(t, int64(1000), MonthlyAmount(12000, "year", 1))
+		require.Equal(t, int64(433), MonthlyAmount(100, "week", 1))
+		require.Equal(t, int64(3042), MonthlyAmount(100, "day", 1))
+	})
+	t.Run("Test for ClassifyMovement", func(t *testing.T) {
+		require.Equal(t, MovementNone, ClassifyMovement(100, 100, true))
+		require.Equal(t, MovementNew, ClassifyMovement(0, 100, false))
+		require.Equal(t, MovementReactivation, ClassifyMovement(0, 100, true))
+		require.Equal(t, MovementChurn, ClassifyMovement(100, 0, true))
+		require.Equal(t, MovementExpansion, ClassifyMovement(100, 200, true))
+		require.Equal(t, MovementContraction, ClassifyMovement(200, 100, true))
+	})
+	t.Run("Test for TimelineAt", func(t *testing.T) {
+		timelines := []*entity.SubscriptionTimeline{
+			{Id: 1, Status: consts.SubTimeLineStatusFinished, PeriodStart: 100, PeriodEnd: 200},
+			{Id: 2, Status: consts.SubTimeLineStatusProcessing, PeriodStart: 200, PeriodEnd: 300},
+			{Id: 3, Status: consts.SubTimeLineStatusCancelled, PeriodStart: 300, PeriodEnd: 400},
+		}
+		require.Nil(t, TimelineAt(timelines, 50))
+		require.Equal(t, uint64(1), TimelineAt(timelines, 199).Id)
+		require.Equal(t, uint64(2), TimelineAt(timelines, 200).Id)
+		require.Nil(t, TimelineAt(timelines, 350))
+		require.False(t, hadMrrBefore(timelines, 150))
+		require.True(t, hadMrrBefore(timelines, 250))
+	})
+	t.Run("Test for PriceVersionAt", func(t *testing.T) {
+		versions := []*entity.PlanPriceVersion{
+			{Version: 3, CreateTime: 300},
+			{Version: 2, CreateTime: 200},
+			{Version: 1, CreateTime: 200},
+		}
+		migrations := []*entity.PlanPriceMigration{
+			{FromVersion: 1, ToVersion: 3, EffectTime: 350},
+		}
+		require.Equal(t, 1, PriceVersionAt(0, versions, migrations, 100))
+		require.Equal(t, 2, PriceVersionAt(0, versions, migrations, 250))
+		require.Equal(t, 3, PriceVersionAt(3, versions, migrations, 400))
+		require.Equal(t, 1, PriceVersionAt(3, versions, migrations, 320))
+		require.Equal(t, 2, PriceVersionAt(2, versions, migrations, 250))
+		require.Equal(t, 1, PriceVersionAt(2, versions, migrations, 150))
+		require.Equal(t, 1, PriceVersionAt(1, versions, migrations, 100))
+	})
+}
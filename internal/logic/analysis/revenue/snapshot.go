@@ -0,0 +1,352 @@
+package revenue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unibee/internal/consts"
+	"unibee/internal/consumer/webhook/log"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const snapshotBatchSize = 1000
+
+// DayStart returns the utc start time of the day
+func DayStart(timestamp int64) int64 {
+	t := time.Unix(timestamp, 0).UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix()
+}
+
+type snapshotKey struct {
+	PlanId   uint64
+	Currency string
+}
+
+type snapshotBuilder struct {
+	merchantId uint64
+	dayStart   int64
+	rows       map[snapshotKey]*entity.MerchantRevenueSnapshot
+}
+
+func (b *snapshotBuilder) row(planId uint64, currency string) *entity.MerchantRevenueSnapshot {
+	key := snapshotKey{PlanId: planId, Currency: strings.ToUpper(currency)}
+	if one, ok := b.rows[key]; ok {
+		return one
+	}
+	one := &entity.MerchantRevenueSnapshot{
+		MerchantId:   b.merchantId,
+		SnapshotTime: b.dayStart,
+		SnapshotDate: time.Unix(b.dayStart, 0).UTC().Format("2006-01-02"),
+		PlanId:       planId,
+		Currency:     key.Currency,
+		CreateTime:   gtime.Now().Timestamp(),
+	}
+	b.rows[key] = one
+	return one
+}
+
+// BuildMerchantRevenueSnapshot rebuilds the revenue snapshot of the utc day,
+// mrr movements compare the mrr of each subscription at the end of previous day and the end of the day
+func BuildMerchantRevenueSnapshot(ctx context.Context, merchantId uint64, day int64) error {
+	dayStart := DayStart(day)
+	dayEnd := dayStart + 86400
+	prevAt := dayStart - 1
+	currentAt := dayEnd - 1
+	builder := &snapshotBuilder{merchantId: merchantId, dayStart: dayStart, rows: make(map[snapshotKey]*entity.MerchantRevenueSnapshot)}
+	calculator := newMrrCalculator(ctx)
+
+	var lastId uint64 = 0
+	var handled = make(map[string]bool)
+	for {
+		var subIds []string
+		var timelines []*entity.SubscriptionTimeline
+		err := dao.SubscriptionTimeline.Ctx(ctx).
+			Where(dao.SubscriptionTimeline.Columns().MerchantId, merchantId).
+			WhereIn(dao.SubscriptionTimeline.Columns().Status, []int{consts.SubTimeLineStatusProcessing, consts.SubTimeLineStatusFinished}).
+			WhereLTE(dao.SubscriptionTimeline.Columns().PeriodStart, currentAt).
+			WhereGT(dao.SubscriptionTimeline.Columns().PeriodEnd, prevAt).
+			WhereGT(dao.SubscriptionTimeline.Columns().Id, lastId).
+			OrderAsc(dao.SubscriptionTimeline.Columns().Id).
+			Limit(0, snapshotBatchSize).
+			Scan(&timelines)
+		if err != nil {
+			return err
+		}
+		if len(timelines) == 0 {
+			break
+		}
+		for _, one := range timelines {
+			if !handled[one.SubscriptionId] {
+				subIds = append(subIds, one.SubscriptionId)
+			}
+			lastId = one.Id
+		}
+		subTimelines, err := getSubscriptionTimelines(ctx, merchantId, subIds)
+		if err != nil {
+			return err
+		}
+		subMap := getSubscriptionMap(ctx, subIds)
+		for _, one := range timelines {
+			if handled[one.SubscriptionId] {
+				continue
+			}
+			handled[one.SubscriptionId] = true
+			list := subTimelines[one.SubscriptionId]
+			builder.appendSubscription(calculator, subMap[one.SubscriptionId], list, prevAt, currentAt)
+		}
+		if len(timelines) < snapshotBatchSize {
+			break
+		}
+	}
+	if err := builder.appendRevenue(ctx, dayStart, dayEnd); err != nil {
+		return err
+	}
+
+	return dao.MerchantRevenueSnapshot.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		// upsert by the unique key (merchant_id, snapshot_time, plan_id, currency), concurrent builds of the same day never duplicate rows
+		columns := dao.MerchantRevenueSnapshot.Columns()
+		for _, one := range builder.rows {
+			_, err := dao.MerchantRevenueSnapshot.Ctx(ctx).Data(one).OmitNil().
+				OnDuplicate(columns.SnapshotDate, columns.Mrr, columns.NewMrr, columns.ExpansionMrr, columns.ContractionMrr,
+					columns.ChurnMrr, columns.ReactivationMrr, columns.ActiveSubscriptions, columns.NewSubscriptions,
+					columns.ChurnedSubscriptions, columns.ReactivatedSubscriptions, columns.Revenue, columns.RefundAmount).
+				Save()
+			if err != nil {
+				return err
+			}
+		}
+		// remove the rows of plan and currency no longer present in the day
+		var existing []*entity.MerchantRevenueSnapshot
+		err := dao.MerchantRevenueSnapshot.Ctx(ctx).
+			Fields(columns.Id, columns.PlanId, columns.Currency).
+			Where(columns.MerchantId, merchantId).
+			Where(columns.SnapshotTime, dayStart).
+			Scan(&existing)
+		if err != nil {
+			return err
+		}
+		var staleIds []uint64
+		for _, one := range existing {
+			if _, ok := builder.rows[snapshotKey{PlanId: one.PlanId, Currency: strings.ToUpper(one.Currency)}]; !ok {
+				staleIds = append(staleIds, one.Id)
+			}
+		}
+		if len(staleIds) > 0 {
+			_, err = dao.MerchantRevenueSnapshot.Ctx(ctx).WhereIn(columns.Id, staleIds).Delete()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (b *snapshotBuilder) appendSubscription(calculator *mrrCalculator, sub *entity.Subscription, timelines []*entity.SubscriptionTimeline, prevAt int64, currentAt int64) {
+	prevTimeline := TimelineAt(timelines, prevAt)
+	currentTimeline := TimelineAt(timelines, currentAt)
+	prevMrr := calculator.TimelineMrr(sub, prevTimeline, prevAt)
+	currentMrr := calculator.TimelineMrr(sub, currentTimeline, currentAt)
+	// movement attributed to the plan and currency of subscription at end of day
+	attributeTo := currentTimeline
+	if currentMrr <= 0 || attributeTo == nil {
+		attributeTo = prevTimeline
+	}
+	if attributeTo == nil {
+		return
+	}
+	if currentMrr > 0 {
+		row := b.row(currentTimeline.PlanId, currentTimeline.Currency)
+		row.Mrr = row.Mrr + currentMrr
+		row.ActiveSubscriptions++
+	}
+	if prevTimeline != nil && currentTimeline != nil && strings.ToUpper(prevTimeline.Currency) != strings.ToUpper(currentTimeline.Currency) {
+		// currency changed, churn in previous currency and new in current one
+		b.appendMovement(prevTimeline, prevMrr, 0, true)
+		b.appendMovement(currentTimeline, 0, currentMrr, true)
+		return
+	}
+	b.appendMovement(attributeTo, prevMrr, currentMrr, hadMrrBefore(timelines, prevAt))
+}
+
+func (b *snapshotBuilder) appendMovement(timeline *entity.SubscriptionTimeline, prevMrr int64, currentMrr int64, hadMrrBefore bool) {
+	movement := ClassifyMovement(prevMrr, currentMrr, hadMrrBefore)
+	if movement == MovementNone {
+		return
+	}
+	row := b.row(timeline.PlanId, timeline.Currency)
+	switch movement {
+	case MovementNew:
+		row.NewMrr = row.NewMrr + currentMrr
+		row.NewSubscriptions++
+	case MovementReactivation:
+		row.ReactivationMrr = row.ReactivationMrr + currentMrr
+		row.ReactivatedSubscriptions++
+	case MovementChurn:
+		row.ChurnMrr = row.ChurnMrr + prevMrr
+		row.ChurnedSubscriptions++
+	case MovementExpansion:
+		row.ExpansionMrr = row.ExpansionMrr + currentMrr - prevMrr
+	case MovementContraction:
+		row.ContractionMrr = row.ContractionMrr + prevMrr - currentMrr
+	}
+}
+
+// appendRevenue sums the paid invoices and successful refunds of the day
+func (b *snapshotBuilder) appendRevenue(ctx context.Context, dayStart int64, dayEnd int64) error {
+	var invoices []*entity.Invoice
+	err := dao.Invoice.Ctx(ctx).
+		Fields(dao.Invoice.Columns().SubscriptionId, dao.Invoice.Columns().Currency, dao.Invoice.Columns().TotalAmount).
+		Where(dao.Invoice.Columns().MerchantId, b.merchantId).
+		Where(dao.Invoice.Columns().Status, consts.InvoiceStatusPaid).
+		Where(dao.Invoice.Columns().IsDeleted, 0).
+		WhereGTE(dao.Invoice.Columns().FinishTime, dayStart).
+		WhereLT(dao.Invoice.Columns().FinishTime, dayEnd).
+		Scan(&invoices)
+	if err != nil {
+		return err
+	}
+	var refunds []*entity.Refund
+	err = dao.Refund.Ctx(ctx).
+		Fields(dao.Refund.Columns().SubscriptionId, dao.Refund.Columns().Currency, dao.Refund.Columns().RefundAmount).
+		Where(dao.Refund.Columns().MerchantId, b.merchantId).
+		Where(dao.Refund.Columns().Status, consts.RefundSuccess).
+		WhereGTE(dao.Refund.Columns().RefundTime, dayStart).
+		WhereLT(dao.Refund.Columns().RefundTime, dayEnd).
+		Scan(&refunds)
+	if err != nil {
+		return err
+	}
+	var subIds []string
+	for _, one := range invoices {
+		subIds = append(subIds, one.SubscriptionId)
+	}
+	for _, one := range refunds {
+		subIds = append(subIds, one.SubscriptionId)
+	}
+	subMap := getSubscriptionMap(ctx, subIds)
+	planIdOf := func(subscriptionId string) uint64 {
+		if sub, ok := subMap[subscriptionId]; ok && sub != nil {
+			return sub.PlanId
+		}
+		return 0
+	}
+	for _, one := range invoices {
+		row := b.row(planIdOf(one.SubscriptionId), one.Currency)
+		row.Revenue = row.Revenue + one.TotalAmount
+	}
+	for _, one := range refunds {
+		row := b.row(planIdOf(one.SubscriptionId), one.Currency)
+		row.RefundAmount = row.RefundAmount + one.RefundAmount
+	}
+	return nil
+}
+
+// getSubscriptionTimelines returns the paid periods of subscriptions grouped by subscription id
+func getSubscriptionTimelines(ctx context.Context, merchantId uint64, subIds []string) (map[string][]*entity.SubscriptionTimeline, error) {
+	var result = make(map[string][]*entity.SubscriptionTimeline)
+	if len(subIds) == 0 {
+		return result, nil
+	}
+	var list []*entity.SubscriptionTimeline
+	err := dao.SubscriptionTimeline.Ctx(ctx).
+		Where(dao.SubscriptionTimeline.Columns().MerchantId, merchantId).
+		WhereIn(dao.SubscriptionTimeline.Columns().SubscriptionId, uniqueStrings(subIds)).
+		WhereIn(dao.SubscriptionTimeline.Columns().Status, []int{consts.SubTimeLineStatusProcessing, consts.SubTimeLineStatusFinished}).
+		Scan(&list)
+	if err != nil {
+		return nil, err
+	}
+	for _, one := range list {
+		result[one.SubscriptionId] = append(result[one.SubscriptionId], one)
+	}
+	return result, nil
+}
+
+func getSubscriptionMap(ctx context.Context, subIds []string) map[string]*entity.Subscription {
+	var result = make(map[string]*entity.Subscription)
+	var filtered []string
+	for _, one := range uniqueStrings(subIds) {
+		if len(one) > 0 {
+			filtered = append(filtered, one)
+		}
+	}
+	if len(filtered) == 0 {
+		return result
+	}
+	var list []*entity.Subscription
+	err := dao.Subscription.Ctx(ctx).
+		WhereIn(dao.Subscription.Columns().SubscriptionId, filtered).
+		Scan(&list)
+	if err != nil {
+		g.Log().Errorf(ctx, "Revenue getSubscriptionMap error:%s", err.Error())
+		return result
+	}
+	for _, one := range list {
+		result[one.SubscriptionId] = one
+	}
+	return result
+}
+
+// BuildAllMerchantRevenueSnapshots builds the snapshot of the day for all merchants
+func BuildAllMerchantRevenueSnapshots(ctx context.Context, day int64) {
+	merchants, _ := query.GetMerchantList(ctx)
+	for _, merchant := range merchants {
+		err := BuildMerchantRevenueSnapshot(ctx, merchant.Id, day)
+		if err != nil {
+			g.Log().Errorf(ctx, "BuildAllMerchantRevenueSnapshots merchantId:%d error:%s", merchant.Id, err.Error())
+		}
+	}
+}
+
+// RebuildMerchantRevenueSnapshotsBackground rebuilds the snapshots of the days in range
+func RebuildMerchantRevenueSnapshotsBackground(merchantId uint64, startTime int64, endTime int64) {
+	go func() {
+		ctx := context.Background()
+		var err error
+		defer func() {
+			if exception := recover(); exception != nil {
+				if v, ok := exception.(error); ok && gerror.HasStack(v) {
+					err = v
+				} else {
+					err = gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception)
+				}
+				log.PrintPanic(ctx, err)
+				return
+			}
+		}()
+		key := fmt.Sprintf("RebuildMerchantRevenueSnapshots-%d", merchantId)
+		if !utility.TryLock(ctx, key, 3600) {
+			g.Log().Infof(ctx, "RebuildMerchantRevenueSnapshots merchantId:%d already running", merchantId)
+			return
+		}
+		defer utility.ReleaseLock(ctx, key)
+		for day := DayStart(startTime); day <= DayStart(endTime); day = day + 86400 {
+			err = BuildMerchantRevenueSnapshot(ctx, merchantId, day)
+			if err != nil {
+				g.Log().Errorf(ctx, "RebuildMerchantRevenueSnapshots merchantId:%d day:%d error:%s", merchantId, day, err.Error())
+			}
+		}
+	}()
+}
+
+func uniqueStrings(list []string) []string {
+	var result = make([]string, 0)
+	var exist = make(map[string]bool)
+	for _, one := range list {
+		if _, ok := exist[one]; !ok {
+			exist[one] = true
+			result = append(result, one)
+		}
+	}
+	return result
+}
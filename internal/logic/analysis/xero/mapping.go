@@ -0,0 +1,170 @@
+package xero
+
+import (
+	"fmt"
+	"strings"
+	"unibee/api/bean"
+	"unibee/api/bean/detail"
+	"unibee/internal/logic/analysis/xero/xerosdk"
+	"unibee/utility"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	DefaultSalesAccountCode   = "200"
+	DefaultPaymentAccountCode = "090"
+)
+
+func SalesAccountCode(mapping *bean.XeroMapping) string {
+	if mapping != nil && len(mapping.SalesAccountCode) > 0 {
+		return mapping.SalesAccountCode
+	}
+	return DefaultSalesAccountCode
+}
+
+func PaymentAccountCode(mapping *bean.XeroMapping, gatewayName string) string {
+	if mapping != nil {
+		if code, ok := mapping.GatewayPaymentAccountCodes[gatewayName]; ok && len(code) > 0 {
+			return code
+		}
+		if len(mapping.PaymentAccountCode) > 0 {
+			return mapping.PaymentAccountCode
+		}
+	}
+	return DefaultPaymentAccountCode
+}
+
+// TaxType returns the mapped tax type, the one of invoice country preferred to the one without country
+func TaxType(mapping *bean.XeroMapping, countryCode string, taxPercentage int64) string {
+	if mapping == nil {
+		return ""
+	}
+	var taxType = ""
+	for _, one := range mapping.TaxTypes {
+		if one == nil || one.TaxPercentage != taxPercentage {
+			continue
+		}
+		if len(one.CountryCode) > 0 && strings.EqualFold(one.CountryCode, countryCode) {
+			return one.TaxType
+		}
+		if len(one.CountryCode) == 0 && len(taxType) == 0 {
+			taxType = one.TaxType
+		}
+	}
+	return taxType
+}
+
+func ContactNumber(userId uint64) string {
+	return fmt.Sprintf("UniBee-%d", userId)
+}
+
+func ContactFromUser(user *bean.UserAccount) *xerosdk.Contact {
+	contact := &xerosdk.Contact{
+		ContactNumber: ContactNumber(user.Id),
+		Name:          user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		EmailAddress:  user.Email,
+		TaxNumber:     user.VATNumber,
+		IsCustomer:    true,
+	}
+	if len(user.CompanyName) > 0 {
+		contact.Name = fmt.Sprintf("%s (%s)", user.CompanyName, user.Email)
+	}
+	if len(user.Address) > 0 || len(user.City) > 0 || len(user.ZipCode) > 0 || len(user.CountryCode) > 0 {
+		contact.Addresses = []*xerosdk.Address{{
+			AddressType:  "POBOX",
+			AddressLine1: user.Address,
+			City:         user.City,
+			PostalCode:   user.ZipCode,
+			Country:      user.CountryCode,
+		}}
+	}
+	if len(user.Phone) > 0 {
+		contact.Phones = []*xerosdk.Phone{{PhoneType: "DEFAULT", PhoneNumber: user.Phone}}
+	}
+	return contact
+}
+
+func toAmount(cents int64) float64 {
+	return decimal.NewFromInt(cents).Div(decimal.NewFromInt(100)).InexactFloat64()
+}
+
+func abs(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// BuildLineItems converts invoice lines to xero lines with positive amounts, invoice and refund invoice both,
+// the promo credit not distributed to the lines put on a negative line and
+// the tax rounding difference of invoice put on the last invoice line to keep the total equal to invoice total
+func BuildLineItems(invoice *detail.InvoiceDetail, mapping *bean.XeroMapping) []*xerosdk.LineItem {
+	var list = make([]*xerosdk.LineItem, 0)
+	var total int64 = 0
+	for _, item := range invoice.Lines {
+		if item == nil {
+			continue
+		}
+		quantity := abs(item.Quantity)
+		if quantity == 0 {
+			quantity = 1
+		}
+		lineAmount := abs(item.AmountExcludingTax)
+		if lineAmount == 0 {
+			lineAmount = abs(item.UnitAmountExcludingTax) * quantity
+		}
+		unitAmount := abs(item.UnitAmountExcludingTax)
+		description := item.Name
+		if len(item.Description) > 0 && item.Description != item.Name {
+			description = fmt.Sprintf("%s - %s", item.Name, item.Description)
+		}
+		if unitAmount*quantity != lineAmount {
+			// discounted or prorated, as one line of the amount
+			description = fmt.Sprintf("%s x %d", description, quantity)
+			quantity = 1
+			unitAmount = lineAmount
+		}
+		taxPercentage := item.TaxPercentage
+		if taxPercentage == 0 {
+			taxPercentage = invoice.TaxPercentage
+		}
+		line := &xerosdk.LineItem{
+			Description: description,
+			Quantity:    float64(quantity),
+			UnitAmount:  toAmount(unitAmount),
+			LineAmount:  toAmount(lineAmount),
+			TaxAmount:   toAmount(abs(item.Tax)),
+			AccountCode: SalesAccountCode(mapping),
+			TaxType:     TaxType(mapping, invoice.CountryCode, taxPercentage),
+		}
+		total = total + lineAmount + abs(item.Tax)
+		list = append(list, line)
+	}
+	lastItemIndex := len(list) - 1
+	if len(list) > 0 && invoice.PromoCreditDiscountAmount > 0 && total > abs(invoice.TotalAmount) {
+		// promo credit not distributed to the lines, as one negative line
+		promoCreditAmount := utility.MinInt64(invoice.PromoCreditDiscountAmount, total-abs(invoice.TotalAmount))
+		list = append(list, &xerosdk.LineItem{
+			Description: "Promo Credit",
+			Quantity:    1,
+			UnitAmount:  toAmount(-promoCreditAmount),
+			LineAmount:  toAmount(-promoCreditAmount),
+			TaxAmount:   0,
+			AccountCode: SalesAccountCode(mapping),
+			TaxType:     TaxType(mapping, invoice.CountryCode, 0),
+		})
+		total = total - promoCreditAmount
+		lastItemIndex = len(list) - 2
+	}
+	if len(list) > 0 && total != abs(invoice.TotalAmount) {
+		last := list[lastItemIndex]
+		lastTax := decimal.NewFromFloat(last.TaxAmount).Mul(decimal.NewFromInt(100)).IntPart() + abs(invoice.TotalAmount) - total
+		if lastTax >= 0 {
+			last.TaxAmount = toAmount(lastTax)
+		}
+	}
+	return list
+}
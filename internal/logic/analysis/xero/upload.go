@@ -0,0 +1,222 @@
+package xero
+
+import (
+	"context"
+	"time"
+	"unibee/api/bean"
+	"unibee/api/bean/detail"
+	log2 "unibee/internal/consumer/webhook/log"
+	"unibee/internal/logic/analysis/xero/xerosdk"
+	detail2 "unibee/internal/logic/invoice/detail"
+	"unibee/internal/query"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// SyncUserContact creates or updates the xero contact of user, skipped if merchant not connected
+func SyncUserContact(ctx context.Context, userId uint64) {
+	user := query.GetUserAccountById(ctx, userId)
+	if user == nil {
+		return
+	}
+	client, _ := connectedClient(ctx, user.MerchantId)
+	if client == nil {
+		return
+	}
+	_, err := FindOrCreateContact(ctx, client, bean.SimplifyUserAccount(user))
+	if err != nil {
+		g.Log().Errorf(ctx, "Xero SyncUserContact userId:%d err:%s", userId, err.Error())
+	}
+}
+
+func FindOrCreateContact(ctx context.Context, client *xerosdk.Client, user *bean.UserAccount) (*xerosdk.Contact, error) {
+	if user == nil {
+		return nil, gerror.New("user not found")
+	}
+	contact := ContactFromUser(user)
+	exist, err := client.FindContactByNumber(ctx, contact.ContactNumber)
+	if err != nil {
+		return nil, err
+	}
+	if exist != nil {
+		contact.ContactID = exist.ContactID
+	}
+	return client.CreateOrUpdateContact(ctx, contact)
+}
+
+func UploadPaidInvoice(ctx context.Context, invoiceId string) {
+	if invoiceId == "" {
+		g.Log().Errorf(ctx, "Xero UploadPaidInvoice invoiceId is empty")
+		return
+	}
+	one := query.GetInvoiceByInvoiceId(ctx, invoiceId)
+	if one == nil {
+		g.Log().Errorf(ctx, "Xero UploadPaidInvoice invoice not found")
+		return
+	}
+	client, config := connectedClient(ctx, one.MerchantId)
+	if client == nil {
+		return
+	}
+	uploadPaidInvoiceBackground(one.MerchantId, invoiceId, client, config)
+}
+
+func uploadPaidInvoiceBackground(merchantId uint64, invoiceId string, client *xerosdk.Client, config *MerchantXeroConfig) {
+	go func() {
+		ctx := context.Background()
+		var err error
+		defer func() {
+			if exception := recover(); exception != nil {
+				if v, ok := exception.(error); ok && gerror.HasStack(v) {
+					err = v
+				} else {
+					err = gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception)
+				}
+				log2.PrintPanic(ctx, err)
+				return
+			}
+		}()
+		invoiceDetail := detail2.InvoiceDetail(ctx, invoiceId)
+		if invoiceDetail == nil {
+			g.Log().Errorf(ctx, "Xero uploadPaidInvoiceBackground invoiceDetail not found")
+			return
+		}
+		err = UploadInvoiceDetail(ctx, client, config.Mapping, invoiceDetail)
+		if err != nil {
+			g.Log().Errorf(ctx, "Xero uploadPaidInvoiceBackground invoice %s err:%s", invoiceId, err.Error())
+		}
+		recordSyncResult(ctx, merchantId, err)
+	}()
+}
+
+// UploadInvoiceDetail pushes the paid invoice as xero invoice with payment, or the refund invoice as credit note with refund payment,
+// invoices already paid in xero skipped, so it is safe to call again
+func UploadInvoiceDetail(ctx context.Context, client *xerosdk.Client, mapping *bean.XeroMapping, invoiceDetail *detail.InvoiceDetail) error {
+	if invoiceDetail.TotalAmount > 0 && invoiceDetail.Refund == nil {
+		contact, err := FindOrCreateContact(ctx, client, invoiceDetail.UserAccount)
+		if err != nil {
+			return err
+		}
+		return uploadInvoice(ctx, client, mapping, invoiceDetail, contact)
+	} else if invoiceDetail.TotalAmount < 0 && invoiceDetail.Refund != nil {
+		contact, err := FindOrCreateContact(ctx, client, invoiceDetail.UserAccount)
+		if err != nil {
+			return err
+		}
+		return uploadCreditNote(ctx, client, mapping, invoiceDetail, contact)
+	}
+	return nil
+}
+
+func gatewayName(invoiceDetail *detail.InvoiceDetail) string {
+	if invoiceDetail.Gateway != nil {
+		return invoiceDetail.Gateway.GatewayName
+	}
+	return ""
+}
+
+func txnDate(invoiceDetail *detail.InvoiceDetail) string {
+	paidTime := invoiceDetail.PaidTime
+	if paidTime == 0 {
+		paidTime = invoiceDetail.CreateTime
+	}
+	return time.Unix(paidTime, 0).UTC().Format("2006-01-02")
+}
+
+func uploadInvoice(ctx context.Context, client *xerosdk.Client, mapping *bean.XeroMapping, invoiceDetail *detail.InvoiceDetail, contact *xerosdk.Contact) error {
+	exist, err := client.FindInvoiceByNumber(ctx, invoiceDetail.InvoiceId)
+	if err != nil {
+		return err
+	}
+	if exist != nil && exist.Status == xerosdk.StatusPaid {
+		g.Log().Infof(ctx, "Xero uploadInvoice invoice %s already paid in xero as %s", invoiceDetail.InvoiceId, exist.InvoiceID)
+		return nil
+	}
+	one := &xerosdk.Invoice{
+		Type:            xerosdk.InvoiceTypeAccRec,
+		InvoiceNumber:   invoiceDetail.InvoiceId,
+		Reference:       invoiceDetail.SubscriptionId,
+		Contact:         &xerosdk.Contact{ContactID: contact.ContactID},
+		Date:            txnDate(invoiceDetail),
+		DueDate:         txnDate(invoiceDetail),
+		CurrencyCode:    invoiceDetail.Currency,
+		Status:          xerosdk.StatusAuthorised,
+		LineAmountTypes: xerosdk.LineAmountTypesExclusive,
+		LineItems:       BuildLineItems(invoiceDetail, mapping),
+	}
+	if exist != nil {
+		one.InvoiceID = exist.InvoiceID
+	}
+	created, err := client.CreateOrUpdateInvoice(ctx, one)
+	if err != nil {
+		return err
+	}
+	g.Log().Infof(ctx, "Xero uploadInvoice invoice %s submitted as Invoice ID: %s", invoiceDetail.InvoiceId, created.InvoiceID)
+	amount := toAmount(invoiceDetail.TotalAmount)
+	if created.AmountDue > 0 && created.AmountDue < amount {
+		amount = created.AmountDue
+	}
+	_, err = client.CreatePayment(ctx, &xerosdk.Payment{
+		Invoice:   &xerosdk.InvoiceRef{InvoiceID: created.InvoiceID},
+		Account:   &xerosdk.AccountRef{Code: PaymentAccountCode(mapping, gatewayName(invoiceDetail))},
+		Date:      txnDate(invoiceDetail),
+		Amount:    amount,
+		Reference: invoiceDetail.PaymentId,
+	})
+	if err != nil {
+		return gerror.Newf("create payment of invoice %s error:%s", invoiceDetail.InvoiceId, err.Error())
+	}
+	return nil
+}
+
+func uploadCreditNote(ctx context.Context, client *xerosdk.Client, mapping *bean.XeroMapping, invoiceDetail *detail.InvoiceDetail, contact *xerosdk.Contact) error {
+	exist, err := client.FindCreditNoteByNumber(ctx, invoiceDetail.InvoiceId)
+	if err != nil {
+		return err
+	}
+	if exist != nil && exist.Status == xerosdk.StatusPaid {
+		g.Log().Infof(ctx, "Xero uploadCreditNote invoice %s already refunded in xero as %s", invoiceDetail.InvoiceId, exist.CreditNoteID)
+		return nil
+	}
+	var reference = invoiceDetail.Refund.RefundId
+	if invoiceDetail.Payment != nil && len(invoiceDetail.Payment.InvoiceId) > 0 {
+		// the original invoice refunded
+		reference = invoiceDetail.Payment.InvoiceId
+	}
+	one := &xerosdk.CreditNote{
+		Type:             xerosdk.CreditNoteTypeAccRec,
+		CreditNoteNumber: invoiceDetail.InvoiceId,
+		Reference:        reference,
+		Contact:          &xerosdk.Contact{ContactID: contact.ContactID},
+		Date:             txnDate(invoiceDetail),
+		CurrencyCode:     invoiceDetail.Currency,
+		Status:           xerosdk.StatusAuthorised,
+		LineAmountTypes:  xerosdk.LineAmountTypesExclusive,
+		LineItems:        BuildLineItems(invoiceDetail, mapping),
+	}
+	if exist != nil {
+		one.CreditNoteID = exist.CreditNoteID
+	}
+	created, err := client.CreateOrUpdateCreditNote(ctx, one)
+	if err != nil {
+		return err
+	}
+	g.Log().Infof(ctx, "Xero uploadCreditNote invoice %s submitted as CreditNote ID: %s", invoiceDetail.InvoiceId, created.CreditNoteID)
+	amount := toAmount(abs(invoiceDetail.TotalAmount))
+	if created.RemainingCredit > 0 && created.RemainingCredit < amount {
+		amount = created.RemainingCredit
+	}
+	_, err = client.CreatePayment(ctx, &xerosdk.Payment{
+		CreditNote: &xerosdk.CreditNoteRef{CreditNoteID: created.CreditNoteID},
+		Account:    &xerosdk.AccountRef{Code: PaymentAccountCode(mapping, gatewayName(invoiceDetail))},
+		Date:       txnDate(invoiceDetail),
+		Amount:     amount,
+		Reference:  invoiceDetail.Refund.RefundId,
+	})
+	if err != nil {
+		return gerror.Newf("create refund payment of credit note %s error:%s", invoiceDetail.InvoiceId, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,335 @@
+package xero
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"unibee/api/bean"
+	config2 "unibee/internal/cmd/config"
+	"unibee/internal/controller/link"
+	"unibee/internal/logic/analysis/xero/xerosdk"
+	"unibee/internal/logic/merchant_config"
+	"unibee/internal/logic/merchant_config/update"
+	"unibee/internal/logic/operation_log"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	redismq "github.com/jackyang-hk/go-redismq"
+)
+
+type XeroAPIKeys struct {
+	ClientId     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+}
+
+func GetCloudXeroPartnerAPIKeys(ctx context.Context) (*XeroAPIKeys, error) {
+	apiKeyRes := redismq.Invoke(ctx, &redismq.InvoiceRequest{
+		Group:   "GID_UniBee_Cloud",
+		Method:  "GetXeroAPIKeys",
+		Request: nil,
+	}, 0)
+	if apiKeyRes == nil {
+		return nil, gerror.New("Server Error")
+	}
+	if !apiKeyRes.Status {
+		return nil, gerror.New(fmt.Sprintf("%v", apiKeyRes.Response))
+	}
+	if apiKeyRes.Response == nil {
+		return nil, gerror.New("xero key not found")
+	}
+	var apiKeys *XeroAPIKeys
+	err := redismq.UnmarshalFromJsonString(utility.MarshalToJsonString(apiKeyRes.Response), &apiKeys)
+	if err != nil {
+		return nil, err
+	}
+	if apiKeys == nil {
+		return nil, gerror.New("xero key not found")
+	}
+	return apiKeys, nil
+}
+
+type MerchantXeroConfig struct {
+	SetupReturnUrl string             `json:"setupReturnUrl"`
+	ClientId       string             `json:"clientId"`
+	ClientSecret   string             `json:"clientSecret"`
+	Endpoints      *xerosdk.Endpoints `json:"endpoints"`
+	TenantId       string             `json:"tenantId"`
+	TenantName     string             `json:"tenantName"`
+	Token          *xerosdk.Token     `json:"token"`
+	TokenExpired   bool               `json:"tokenExpired"`
+	Mapping        *bean.XeroMapping  `json:"mapping"`
+	LastSyncTime   int64              `json:"lastSyncTime"`
+	LastSyncError  string             `json:"lastSyncError"`
+	// AuthorizationState is the one-time nonce of the authorization url, the callback of other state rejected
+	AuthorizationState       string `json:"authorizationState"`
+	AuthorizationStateExpire int64  `json:"authorizationStateExpire"`
+}
+
+const KeyMerchantXeroConfig = "KeyMerchantXeroConfig"
+
+// authorizationStateTtl is the seconds the authorization url valid for the merchant to grant access
+const authorizationStateTtl = 1800
+
+func GetMerchantXeroConfig(ctx context.Context, merchantId uint64) *MerchantXeroConfig {
+	config := merchant_config.GetMerchantConfig(ctx, merchantId, KeyMerchantXeroConfig)
+	if config != nil && len(config.ConfigValue) > 0 {
+		var one *MerchantXeroConfig
+		_ = utility.UnmarshalFromJsonString(config.ConfigValue, &one)
+		if one != nil {
+			return one
+		}
+	}
+	return &MerchantXeroConfig{}
+}
+
+func (config *MerchantXeroConfig) Connected() bool {
+	return config != nil && len(config.TenantId) > 0 && config.Token != nil && len(config.Token.AccessToken) > 0 && !config.TokenExpired
+}
+
+func saveMerchantXeroConfig(ctx context.Context, merchantId uint64, config *MerchantXeroConfig) error {
+	return update.SetMerchantConfig(ctx, merchantId, KeyMerchantXeroConfig, utility.MarshalToJsonString(config))
+}
+
+// newClient creates client by the merchant's own xero app if setup, or the cloud partner app
+func newClient(ctx context.Context, config *MerchantXeroConfig) (*xerosdk.Client, error) {
+	clientId := config.ClientId
+	clientSecret := config.ClientSecret
+	if len(clientId) == 0 {
+		if config.Endpoints != nil {
+			// the partner app credential never sent to the endpoints other than xero
+			return nil, gerror.New("clientId required with mock server")
+		}
+		apiKeys, err := GetCloudXeroPartnerAPIKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+		clientId = apiKeys.ClientId
+		clientSecret = apiKeys.ClientSecret
+	}
+	return xerosdk.NewClient(clientId, clientSecret, config.Endpoints, config.TenantId, config.Token), nil
+}
+
+type ConnectionXeroInternalReq struct {
+	MerchantId    uint64 `json:"merchantId"`
+	ReturnUrl     string `json:"returnUrl"`
+	ClientId      string `json:"clientId"`
+	ClientSecret  string `json:"clientSecret"`
+	MockServerUrl string `json:"mockServerUrl"`
+}
+
+func GetXeroAuthorizationUrl(ctx context.Context, req *ConnectionXeroInternalReq) (string, error) {
+	utility.Assert(req.MerchantId > 0, "invalid merchantId")
+	utility.Assert(len(req.ClientId) == 0 || len(req.ClientSecret) > 0, "clientSecret required with clientId")
+	config := GetMerchantXeroConfig(ctx, req.MerchantId)
+	config.SetupReturnUrl = req.ReturnUrl
+	config.ClientId = req.ClientId
+	config.ClientSecret = req.ClientSecret
+	config.Endpoints = nil
+	if len(req.MockServerUrl) > 0 {
+		utility.Assert(!config2.GetConfigInstance().IsProd(), "mockServerUrl not available in prod")
+		config.Endpoints = xerosdk.MockEndpoints(req.MockServerUrl)
+	}
+	client, err := newClient(ctx, config)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := newAuthorizationNonce()
+	if err != nil {
+		return "", err
+	}
+	config.AuthorizationState = nonce
+	config.AuthorizationStateExpire = gtime.Now().Timestamp() + authorizationStateTtl
+	err = saveMerchantXeroConfig(ctx, req.MerchantId, config)
+	if err != nil {
+		return "", err
+	}
+	return client.AuthorizationUrl(AuthorizationState(req.MerchantId, nonce), link.GetXeroAuthorizationLink()), nil
+}
+
+func newAuthorizationNonce() (string, error) {
+	data := make([]byte, 24)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// AuthorizationState returns the oauth state of merchant, the merchant id locates the config and the nonce proves the callback
+// comes from the authorization url issued to the merchant
+func AuthorizationState(merchantId uint64, nonce string) string {
+	return fmt.Sprintf("%d_%s", merchantId, nonce)
+}
+
+// ParseAuthorizationState returns the merchant id and nonce of oauth state
+func ParseAuthorizationState(state string) (merchantId uint64, nonce string, err error) {
+	parts := strings.SplitN(state, "_", 2)
+	if len(parts) != 2 || len(parts[1]) == 0 {
+		return 0, "", gerror.New("invalid state")
+	}
+	merchantId, err = strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || merchantId == 0 {
+		return 0, "", gerror.New("invalid state")
+	}
+	return merchantId, parts[1], nil
+}
+
+// checkAuthorizationState checks the nonce of callback matches the unexpired one issued
+func checkAuthorizationState(config *MerchantXeroConfig, nonce string, now int64) bool {
+	if len(config.AuthorizationState) == 0 || config.AuthorizationStateExpire < now {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(config.AuthorizationState), []byte(nonce)) == 1
+}
+
+// SetupMerchantXeroConfig exchanges the code from xero callback to token, the first organisation authorized connected,
+// the state should match the one-time nonce issued with the authorization url
+func SetupMerchantXeroConfig(ctx context.Context, state string, code string) error {
+	merchantId, nonce, err := ParseAuthorizationState(state)
+	if err != nil || len(code) == 0 {
+		return gerror.New("state or code is invalid")
+	}
+	config := GetMerchantXeroConfig(ctx, merchantId)
+	if !checkAuthorizationState(config, nonce, gtime.Now().Timestamp()) {
+		return gerror.New("state not match")
+	}
+	// the nonce used once
+	config.AuthorizationState = ""
+	config.AuthorizationStateExpire = 0
+	err = saveMerchantXeroConfig(ctx, merchantId, config)
+	if err != nil {
+		return err
+	}
+	client, err := newClient(ctx, config)
+	if err != nil {
+		g.Log().Errorf(ctx, "SetupMerchantXeroConfig Get Xero API keys Error: %s", err.Error())
+		return err
+	}
+	token, err := client.RetrieveToken(ctx, code, link.GetXeroAuthorizationLink())
+	if err != nil {
+		g.Log().Errorf(ctx, "SetupMerchantXeroConfig RetrieveToken Error: %s", err.Error())
+		return err
+	}
+	connections, err := client.Connections(ctx)
+	if err != nil {
+		g.Log().Errorf(ctx, "SetupMerchantXeroConfig Connections Error: %s", err.Error())
+		return err
+	}
+	var connection *xerosdk.Connection
+	for _, one := range connections {
+		if one.TenantType == "ORGANISATION" {
+			connection = one
+			break
+		}
+	}
+	if connection == nil {
+		return gerror.New("no xero organisation authorized")
+	}
+	config.Token = token
+	config.TokenExpired = false
+	config.TenantId = connection.TenantId
+	config.TenantName = connection.TenantName
+	config.LastSyncError = ""
+	err = saveMerchantXeroConfig(ctx, merchantId, config)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     merchantId,
+		Target:         fmt.Sprintf("Xero(%s)", connection.TenantName),
+		Content:        "ConnectXero",
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	return err
+}
+
+// DisconnectMerchantXero revokes the token and clears the connection, the mapping kept for next connection
+func DisconnectMerchantXero(ctx context.Context, merchantId uint64) error {
+	config := GetMerchantXeroConfig(ctx, merchantId)
+	if config.Token != nil {
+		client, err := newClient(ctx, config)
+		if err == nil {
+			err = client.RevokeToken(ctx)
+		}
+		if err != nil {
+			g.Log().Errorf(ctx, "DisconnectMerchantXero RevokeToken merchantId:%d err:%s", merchantId, err.Error())
+		}
+	}
+	err := saveMerchantXeroConfig(ctx, merchantId, &MerchantXeroConfig{Mapping: config.Mapping})
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     merchantId,
+		Target:         fmt.Sprintf("Xero(%s)", config.TenantName),
+		Content:        "DisconnectXero",
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	return err
+}
+
+func SetupMerchantXeroMapping(ctx context.Context, merchantId uint64, mapping *bean.XeroMapping) error {
+	utility.Assert(mapping != nil, "invalid mapping")
+	for _, one := range mapping.TaxTypes {
+		utility.Assert(one != nil && len(one.TaxType) > 0, "taxType required in taxTypes")
+		utility.Assert(one.TaxPercentage >= 0 && one.TaxPercentage <= 10000, "invalid taxPercentage in taxTypes")
+	}
+	config := GetMerchantXeroConfig(ctx, merchantId)
+	config.Mapping = mapping
+	err := saveMerchantXeroConfig(ctx, merchantId, config)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     merchantId,
+		Target:         "Xero",
+		Content:        "SetupXeroMapping",
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+		Data:           utility.MarshalToJsonString(mapping),
+	}, err)
+	return err
+}
+
+// connectedClient returns the client of connected merchant, access token refreshed if expired
+func connectedClient(ctx context.Context, merchantId uint64) (*xerosdk.Client, *MerchantXeroConfig) {
+	config := GetMerchantXeroConfig(ctx, merchantId)
+	if !config.Connected() {
+		return nil, nil
+	}
+	client, err := newClient(ctx, config)
+	if err != nil {
+		g.Log().Errorf(ctx, "Xero connectedClient merchant:%d, newClient Error: %s", merchantId, err.Error())
+		return nil, nil
+	}
+	if config.Token.Expired(gtime.Now().Timestamp()) {
+		token, err := client.RefreshToken(ctx)
+		if err != nil {
+			g.Log().Errorf(ctx, "Xero connectedClient merchant:%d, RefreshToken Error: %s", merchantId, err.Error())
+			config.TokenExpired = true
+			config.LastSyncError = fmt.Sprintf("Refresh token error, please connect again: %s", err.Error())
+			_ = saveMerchantXeroConfig(ctx, merchantId, config)
+			return nil, nil
+		}
+		config.Token = token
+		_ = saveMerchantXeroConfig(ctx, merchantId, config)
+	}
+	return client, config
+}
+
+func recordSyncResult(ctx context.Context, merchantId uint64, err error) {
+	config := GetMerchantXeroConfig(ctx, merchantId)
+	config.LastSyncTime = gtime.Now().Timestamp()
+	config.LastSyncError = ""
+	if err != nil {
+		config.LastSyncError = err.Error()
+	}
+	_ = saveMerchantXeroConfig(ctx, merchantId, config)
+}
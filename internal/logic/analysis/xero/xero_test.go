@@ -0,0 +1,146 @@
+package xero
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"unibee/api/bean"
+	"unibee/api/bean/detail"
+	"unibee/internal/logic/analysis/xero/xerosdk"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestXero(t *testing.T) {
+	mapping := &bean.XeroMapping{
+		SalesAccountCode:           "201",
+		PaymentAccountCode:         "091",
+		GatewayPaymentAccountCodes: map[string]string{"stripe": "092"},
+		TaxTypes: []*bean.XeroTaxTypeMapping{
+			{TaxPercentage: 2000, TaxType: "OUTPUT2"},
+			{CountryCode: "DE", TaxPercentage: 2000, TaxType: "OUTPUTDE"},
+		},
+	}
+	user := &bean.UserAccount{Id: 10, Email: "user@example.com", FirstName: "Jane", CountryCode: "DE"}
+	invoice := &detail.InvoiceDetail{
+		InvoiceId:     "81234",
+		Currency:      "EUR",
+		TotalAmount:   2400,
+		TaxAmount:     400,
+		TaxPercentage: 2000,
+		CountryCode:   "DE",
+		PaidTime:      1700000000,
+		UserAccount:   user,
+		Gateway:       &detail.Gateway{GatewayName: "stripe"},
+		Lines: []*bean.InvoiceItemSimplify{
+			{Name: "Pro", Quantity: 2, UnitAmountExcludingTax: 1000, AmountExcludingTax: 2000, Tax: 399},
+		},
+	}
+	t.Run("Test for mapping", func(t *testing.T) {
+		require.Equal(t, "201", SalesAccountCode(mapping))
+		require.Equal(t, DefaultSalesAccountCode, SalesAccountCode(nil))
+		require.Equal(t, "092", PaymentAccountCode(mapping, "stripe"))
+		require.Equal(t, "091", PaymentAccountCode(mapping, "paypal"))
+		require.Equal(t, "OUTPUTDE", TaxType(mapping, "DE", 2000))
+		require.Equal(t, "OUTPUT2", TaxType(mapping, "FR", 2000))
+		require.Equal(t, "", TaxType(mapping, "FR", 1000))
+	})
+	t.Run("Test for line items", func(t *testing.T) {
+		lines := BuildLineItems(invoice, mapping)
+		require.Equal(t, 1, len(lines))
+		require.Equal(t, float64(2), lines[0].Quantity)
+		require.Equal(t, float64(10), lines[0].UnitAmount)
+		require.Equal(t, float64(20), lines[0].LineAmount)
+		// rounding difference put on the tax of last line
+		require.Equal(t, float64(4), lines[0].TaxAmount)
+		require.Equal(t, "OUTPUTDE", lines[0].TaxType)
+	})
+	t.Run("Test for line items with promo credit", func(t *testing.T) {
+		promoInvoice := &detail.InvoiceDetail{
+			InvoiceId:                 "81236",
+			Currency:                  "EUR",
+			TotalAmount:               1900,
+			PromoCreditDiscountAmount: 500,
+			TaxPercentage:             2000,
+			CountryCode:               "DE",
+			Lines: []*bean.InvoiceItemSimplify{
+				{Name: "Pro", Quantity: 2, UnitAmountExcludingTax: 1000, AmountExcludingTax: 2000, Tax: 400},
+			},
+		}
+		lines := BuildLineItems(promoInvoice, mapping)
+		require.Equal(t, 2, len(lines))
+		require.Equal(t, float64(20), lines[0].LineAmount)
+		require.Equal(t, float64(4), lines[0].TaxAmount)
+		require.Equal(t, float64(-5), lines[1].LineAmount)
+		require.Equal(t, float64(0), lines[1].TaxAmount)
+		var total float64 = 0
+		for _, one := range lines {
+			total = total + one.LineAmount + one.TaxAmount
+		}
+		// xero total equals to the invoice total
+		require.Equal(t, float64(19), total)
+		// promo credit already distributed to the lines, no extra line
+		promoInvoice.TotalAmount = 2400
+		require.Equal(t, 1, len(BuildLineItems(promoInvoice, mapping)))
+	})
+	t.Run("Test for authorization state", func(t *testing.T) {
+		nonce, err := newAuthorizationNonce()
+		require.Nil(t, err)
+		require.Equal(t, 48, len(nonce))
+		merchantId, parsed, err := ParseAuthorizationState(AuthorizationState(15, nonce))
+		require.Nil(t, err)
+		require.Equal(t, uint64(15), merchantId)
+		require.Equal(t, nonce, parsed)
+		_, _, err = ParseAuthorizationState("15")
+		require.NotNil(t, err)
+		_, _, err = ParseAuthorizationState("abc_" + nonce)
+		require.NotNil(t, err)
+		config := &MerchantXeroConfig{AuthorizationState: nonce, AuthorizationStateExpire: 2000}
+		require.True(t, checkAuthorizationState(config, nonce, 1000))
+		require.False(t, checkAuthorizationState(config, nonce, 3000))
+		require.False(t, checkAuthorizationState(config, "other", 1000))
+		require.False(t, checkAuthorizationState(&MerchantXeroConfig{}, "", 1000))
+	})
+	t.Run("Test for push to mock server", func(t *testing.T) {
+		ctx := context.Background()
+		mock := xerosdk.NewMockServer()
+		server := httptest.NewServer(mock)
+		defer server.Close()
+		client := xerosdk.NewClient("id", "secret", xerosdk.MockEndpoints(server.URL), "", nil)
+		_, err := client.RetrieveToken(ctx, "code", "http://localhost/integrate/xero/auth_back")
+		require.Nil(t, err)
+		connections, err := client.Connections(ctx)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(connections))
+		client.SetTenantId(connections[0].TenantId)
+
+		require.Nil(t, UploadInvoiceDetail(ctx, client, mapping, invoice))
+		require.Equal(t, 1, len(mock.Contacts))
+		require.Equal(t, ContactNumber(10), mock.Contacts[0].ContactNumber)
+		require.Equal(t, 1, len(mock.Invoices))
+		require.Equal(t, xerosdk.StatusPaid, mock.Invoices[0].Status)
+		require.Equal(t, "092", mock.Payments[0].Account.Code)
+		// pushed again, paid invoice skipped
+		require.Nil(t, UploadInvoiceDetail(ctx, client, mapping, invoice))
+		require.Equal(t, 1, len(mock.Contacts))
+		require.Equal(t, 1, len(mock.Payments))
+
+		refund := &detail.InvoiceDetail{
+			InvoiceId:   "81235",
+			Currency:    "EUR",
+			TotalAmount: -1200,
+			PaidTime:    1700000100,
+			UserAccount: user,
+			Refund:      &bean.Refund{RefundId: "re_1"},
+			Payment:     &bean.Payment{InvoiceId: "81234"},
+			Lines: []*bean.InvoiceItemSimplify{
+				{Name: "Pro", Quantity: -1, UnitAmountExcludingTax: -1000, AmountExcludingTax: -1000, Tax: -200},
+			},
+		}
+		require.Nil(t, UploadInvoiceDetail(ctx, client, mapping, refund))
+		require.Equal(t, 1, len(mock.CreditNotes))
+		require.Equal(t, "81234", mock.CreditNotes[0].Reference)
+		require.Equal(t, xerosdk.StatusPaid, mock.CreditNotes[0].Status)
+		require.Equal(t, 2, len(mock.Payments))
+	})
+}
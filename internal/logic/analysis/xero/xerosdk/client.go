@@ -0,0 +1,150 @@
+package xerosdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Endpoints are the base urls of xero, all of them could point to one local mock server for testing
+type Endpoints struct {
+	LoginUrl    string `json:"loginUrl"`
+	IdentityUrl string `json:"identityUrl"`
+	ApiUrl      string `json:"apiUrl"`
+}
+
+var ProductionEndpoints = &Endpoints{
+	LoginUrl:    "https://login.xero.com",
+	IdentityUrl: "https://identity.xero.com",
+	ApiUrl:      "https://api.xero.com",
+}
+
+// MockEndpoints routes all the apis to the single server
+func MockEndpoints(serverUrl string) *Endpoints {
+	serverUrl = strings.TrimSuffix(serverUrl, "/")
+	return &Endpoints{
+		LoginUrl:    serverUrl,
+		IdentityUrl: serverUrl,
+		ApiUrl:      serverUrl,
+	}
+}
+
+const DefaultScope = "offline_access accounting.transactions accounting.contacts accounting.settings"
+
+// Client is the handle to the xero accounting api of one tenant
+type Client struct {
+	HttpClient   *http.Client
+	endpoints    *Endpoints
+	clientId     string
+	clientSecret string
+	tenantId     string
+	token        *Token
+}
+
+func NewClient(clientId string, clientSecret string, endpoints *Endpoints, tenantId string, token *Token) *Client {
+	if endpoints == nil {
+		endpoints = ProductionEndpoints
+	}
+	return &Client{
+		HttpClient:   &http.Client{Timeout: 30 * time.Second},
+		endpoints:    endpoints,
+		clientId:     clientId,
+		clientSecret: clientSecret,
+		tenantId:     tenantId,
+		token:        token,
+	}
+}
+
+func (c *Client) Token() *Token {
+	return c.token
+}
+
+func (c *Client) SetTenantId(tenantId string) {
+	c.tenantId = tenantId
+}
+
+// Error is the failure response of xero, ValidationErrors listed for 400 responses
+type Error struct {
+	StatusCode int    `json:"-"`
+	Type       string `json:"Type"`
+	Title      string `json:"Title"`
+	Detail     string `json:"Detail"`
+	Message    string `json:"Message"`
+	Body       string `json:"-"`
+}
+
+func (e *Error) Error() string {
+	if len(e.Message) > 0 {
+		return fmt.Sprintf("xero error status:%d message:%s body:%s", e.StatusCode, e.Message, e.Body)
+	}
+	return fmt.Sprintf("xero error status:%d title:%s detail:%s body:%s", e.StatusCode, e.Title, e.Detail, e.Body)
+}
+
+func (c *Client) do(ctx context.Context, method string, endpoint string, query url.Values, payload interface{}, response interface{}) error {
+	if c.token == nil || len(c.token.AccessToken) == 0 {
+		return fmt.Errorf("xero token not setup")
+	}
+	target := c.endpoints.ApiUrl + endpoint
+	if len(query) > 0 {
+		target = target + "?" + query.Encode()
+	}
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal payload: %v", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token.AccessToken)
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if len(c.tenantId) > 0 {
+		req.Header.Set("xero-tenant-id", c.tenantId)
+	}
+	return c.send(req, response)
+}
+
+func (c *Client) send(req *http.Request, response interface{}) error {
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		one := &Error{}
+		_ = json.Unmarshal(data, one)
+		one.StatusCode = resp.StatusCode
+		one.Body = string(data)
+		return one
+	}
+	if response != nil && len(data) > 0 {
+		if err = json.Unmarshal(data, response); err != nil {
+			return fmt.Errorf("failed to unmarshal response into object: %v", err)
+		}
+	}
+	return nil
+}
+
+// whereEquals builds the where filter of xero, the quotes in value escaped
+func whereEquals(field string, value string) url.Values {
+	return url.Values{"where": {fmt.Sprintf("%s==\"%s\"", field, strings.ReplaceAll(value, "\"", "\\\""))}}
+}
@@ -0,0 +1,62 @@
+package xerosdk
+
+import (
+	"context"
+	"net/http"
+)
+
+type Address struct {
+	AddressType  string `json:"AddressType"`
+	AddressLine1 string `json:"AddressLine1,omitempty"`
+	City         string `json:"City,omitempty"`
+	PostalCode   string `json:"PostalCode,omitempty"`
+	Country      string `json:"Country,omitempty"`
+}
+
+type Phone struct {
+	PhoneType   string `json:"PhoneType"`
+	PhoneNumber string `json:"PhoneNumber,omitempty"`
+}
+
+type Contact struct {
+	ContactID     string     `json:"ContactID,omitempty"`
+	ContactNumber string     `json:"ContactNumber,omitempty"`
+	ContactStatus string     `json:"ContactStatus,omitempty"`
+	Name          string     `json:"Name,omitempty"`
+	FirstName     string     `json:"FirstName,omitempty"`
+	LastName      string     `json:"LastName,omitempty"`
+	EmailAddress  string     `json:"EmailAddress,omitempty"`
+	TaxNumber     string     `json:"TaxNumber,omitempty"`
+	IsCustomer    bool       `json:"IsCustomer,omitempty"`
+	Addresses     []*Address `json:"Addresses,omitempty"`
+	Phones        []*Phone   `json:"Phones,omitempty"`
+}
+
+type contacts struct {
+	Contacts []*Contact `json:"Contacts"`
+}
+
+func (c *Client) FindContactByNumber(ctx context.Context, contactNumber string) (*Contact, error) {
+	var result *contacts
+	err := c.do(ctx, http.MethodGet, "/api.xro/2.0/Contacts", whereEquals("ContactNumber", contactNumber), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || len(result.Contacts) == 0 {
+		return nil, nil
+	}
+	return result.Contacts[0], nil
+}
+
+// CreateOrUpdateContact creates contact, or updates the one ContactID specified
+func (c *Client) CreateOrUpdateContact(ctx context.Context, contact *Contact) (*Contact, error) {
+	var result *contacts
+	err := c.do(ctx, http.MethodPost, "/api.xro/2.0/Contacts", nil, &contacts{Contacts: []*Contact{contact}}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || len(result.Contacts) == 0 {
+		return nil, &Error{StatusCode: http.StatusOK, Message: "contact not returned"}
+	}
+	return result.Contacts[0], nil
+}
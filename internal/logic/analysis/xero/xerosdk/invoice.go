@@ -0,0 +1,114 @@
+package xerosdk
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+const (
+	InvoiceTypeAccRec        = "ACCREC"
+	CreditNoteTypeAccRec     = "ACCRECCREDIT"
+	StatusAuthorised         = "AUTHORISED"
+	StatusPaid               = "PAID"
+	LineAmountTypesExclusive = "Exclusive"
+)
+
+type LineItem struct {
+	Description string  `json:"Description"`
+	Quantity    float64 `json:"Quantity"`
+	UnitAmount  float64 `json:"UnitAmount"`
+	LineAmount  float64 `json:"LineAmount"`
+	TaxAmount   float64 `json:"TaxAmount"`
+	AccountCode string  `json:"AccountCode,omitempty"`
+	TaxType     string  `json:"TaxType,omitempty"`
+}
+
+type Invoice struct {
+	InvoiceID       string      `json:"InvoiceID,omitempty"`
+	Type            string      `json:"Type"`
+	InvoiceNumber   string      `json:"InvoiceNumber"`
+	Reference       string      `json:"Reference,omitempty"`
+	Contact         *Contact    `json:"Contact"`
+	Date            string      `json:"Date"`
+	DueDate         string      `json:"DueDate,omitempty"`
+	CurrencyCode    string      `json:"CurrencyCode"`
+	Status          string      `json:"Status"`
+	LineAmountTypes string      `json:"LineAmountTypes"`
+	LineItems       []*LineItem `json:"LineItems"`
+	Total           float64     `json:"Total,omitempty"`
+	AmountDue       float64     `json:"AmountDue,omitempty"`
+}
+
+type invoices struct {
+	Invoices []*Invoice `json:"Invoices"`
+}
+
+func (c *Client) FindInvoiceByNumber(ctx context.Context, invoiceNumber string) (*Invoice, error) {
+	var result *invoices
+	err := c.do(ctx, http.MethodGet, "/api.xro/2.0/Invoices", url.Values{"InvoiceNumbers": {invoiceNumber}}, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || len(result.Invoices) == 0 {
+		return nil, nil
+	}
+	return result.Invoices[0], nil
+}
+
+// CreateOrUpdateInvoice creates invoice, or updates the one InvoiceID specified
+func (c *Client) CreateOrUpdateInvoice(ctx context.Context, invoice *Invoice) (*Invoice, error) {
+	var result *invoices
+	err := c.do(ctx, http.MethodPost, "/api.xro/2.0/Invoices", nil, &invoices{Invoices: []*Invoice{invoice}}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || len(result.Invoices) == 0 {
+		return nil, &Error{StatusCode: http.StatusOK, Message: "invoice not returned"}
+	}
+	return result.Invoices[0], nil
+}
+
+type CreditNote struct {
+	CreditNoteID     string      `json:"CreditNoteID,omitempty"`
+	Type             string      `json:"Type"`
+	CreditNoteNumber string      `json:"CreditNoteNumber"`
+	Reference        string      `json:"Reference,omitempty"`
+	Contact          *Contact    `json:"Contact"`
+	Date             string      `json:"Date"`
+	CurrencyCode     string      `json:"CurrencyCode"`
+	Status           string      `json:"Status"`
+	LineAmountTypes  string      `json:"LineAmountTypes"`
+	LineItems        []*LineItem `json:"LineItems"`
+	Total            float64     `json:"Total,omitempty"`
+	RemainingCredit  float64     `json:"RemainingCredit,omitempty"`
+}
+
+type creditNotes struct {
+	CreditNotes []*CreditNote `json:"CreditNotes"`
+}
+
+func (c *Client) FindCreditNoteByNumber(ctx context.Context, creditNoteNumber string) (*CreditNote, error) {
+	var result *creditNotes
+	err := c.do(ctx, http.MethodGet, "/api.xro/2.0/CreditNotes", whereEquals("CreditNoteNumber", creditNoteNumber), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || len(result.CreditNotes) == 0 {
+		return nil, nil
+	}
+	return result.CreditNotes[0], nil
+}
+
+// CreateOrUpdateCreditNote creates credit note, or updates the one CreditNoteID specified
+func (c *Client) CreateOrUpdateCreditNote(ctx context.Context, creditNote *CreditNote) (*CreditNote, error) {
+	var result *creditNotes
+	err := c.do(ctx, http.MethodPost, "/api.xro/2.0/CreditNotes", nil, &creditNotes{CreditNotes: []*CreditNote{creditNote}}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || len(result.CreditNotes) == 0 {
+		return nil, &Error{StatusCode: http.StatusOK, Message: "credit note not returned"}
+	}
+	return result.CreditNotes[0], nil
+}
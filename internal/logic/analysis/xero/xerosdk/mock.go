@@ -0,0 +1,153 @@
+package xerosdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// MockServer is an in-memory xero server for local testing, serves oauth, connections, contacts, invoices, credit notes and payments
+//
+// Example: httptest.NewServer(xerosdk.NewMockServer()), then connect with xerosdk.MockEndpoints(server.URL)
+type MockServer struct {
+	lock        sync.Mutex
+	sequence    int
+	TenantId    string
+	TenantName  string
+	Contacts    []*Contact
+	Invoices    []*Invoice
+	CreditNotes []*CreditNote
+	Payments    []*Payment
+}
+
+func NewMockServer() *MockServer {
+	return &MockServer{TenantId: "mock-tenant", TenantName: "Mock Company"}
+}
+
+func (m *MockServer) nextId(prefix string) string {
+	m.sequence = m.sequence + 1
+	return fmt.Sprintf("%s-%d", prefix, m.sequence)
+}
+
+func (m *MockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	path := r.URL.Path
+	if path == "/connect/token" {
+		writeMockJson(w, &Token{AccessToken: m.nextId("access"), RefreshToken: m.nextId("refresh"), TokenType: "Bearer", ExpiresIn: 1800})
+		return
+	}
+	if path == "/connect/revocation" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case path == "/connections":
+		writeMockJson(w, []*Connection{{Id: "mock-connection", TenantId: m.TenantId, TenantType: "ORGANISATION", TenantName: m.TenantName}})
+	case path == "/api.xro/2.0/Contacts" && r.Method == http.MethodGet:
+		var list = make([]*Contact, 0)
+		for _, one := range m.Contacts {
+			if r.URL.Query().Get("where") == fmt.Sprintf("ContactNumber==\"%s\"", one.ContactNumber) {
+				list = append(list, one)
+			}
+		}
+		writeMockJson(w, &contacts{Contacts: list})
+	case path == "/api.xro/2.0/Contacts":
+		var req *contacts
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		for _, one := range req.Contacts {
+			m.Contacts = upsertMock(m.Contacts, one, func(a *Contact) bool { return len(one.ContactID) > 0 && a.ContactID == one.ContactID }, func() { one.ContactID = m.nextId("contact") })
+		}
+		writeMockJson(w, req)
+	case path == "/api.xro/2.0/Invoices" && r.Method == http.MethodGet:
+		var list = make([]*Invoice, 0)
+		for _, one := range m.Invoices {
+			if one.InvoiceNumber == r.URL.Query().Get("InvoiceNumbers") {
+				list = append(list, one)
+			}
+		}
+		writeMockJson(w, &invoices{Invoices: list})
+	case path == "/api.xro/2.0/Invoices":
+		var req *invoices
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		for _, one := range req.Invoices {
+			one.Total = totalOfLines(one.LineItems)
+			one.AmountDue = one.Total
+			m.Invoices = upsertMock(m.Invoices, one, func(a *Invoice) bool { return len(one.InvoiceID) > 0 && a.InvoiceID == one.InvoiceID }, func() { one.InvoiceID = m.nextId("invoice") })
+		}
+		writeMockJson(w, req)
+	case path == "/api.xro/2.0/CreditNotes" && r.Method == http.MethodGet:
+		var list = make([]*CreditNote, 0)
+		for _, one := range m.CreditNotes {
+			if r.URL.Query().Get("where") == fmt.Sprintf("CreditNoteNumber==\"%s\"", one.CreditNoteNumber) {
+				list = append(list, one)
+			}
+		}
+		writeMockJson(w, &creditNotes{CreditNotes: list})
+	case path == "/api.xro/2.0/CreditNotes":
+		var req *creditNotes
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		for _, one := range req.CreditNotes {
+			one.Total = totalOfLines(one.LineItems)
+			one.RemainingCredit = one.Total
+			m.CreditNotes = upsertMock(m.CreditNotes, one, func(a *CreditNote) bool { return len(one.CreditNoteID) > 0 && a.CreditNoteID == one.CreditNoteID }, func() { one.CreditNoteID = m.nextId("credit") })
+		}
+		writeMockJson(w, req)
+	case path == "/api.xro/2.0/Payments":
+		var req *payments
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		for _, one := range req.Payments {
+			one.PaymentID = m.nextId("payment")
+			for _, invoice := range m.Invoices {
+				if one.Invoice != nil && invoice.InvoiceID == one.Invoice.InvoiceID {
+					invoice.AmountDue = invoice.AmountDue - one.Amount
+					if invoice.AmountDue <= 0 {
+						invoice.Status = StatusPaid
+					}
+				}
+			}
+			for _, creditNote := range m.CreditNotes {
+				if one.CreditNote != nil && creditNote.CreditNoteID == one.CreditNote.CreditNoteID {
+					creditNote.RemainingCredit = creditNote.RemainingCredit - one.Amount
+					if creditNote.RemainingCredit <= 0 {
+						creditNote.Status = StatusPaid
+					}
+				}
+			}
+			m.Payments = append(m.Payments, one)
+		}
+		writeMockJson(w, req)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func upsertMock[T any](list []T, one T, match func(a T) bool, create func()) []T {
+	for i, a := range list {
+		if match(a) {
+			list[i] = one
+			return list
+		}
+	}
+	create()
+	return append(list, one)
+}
+
+func totalOfLines(lines []*LineItem) float64 {
+	var total float64
+	for _, line := range lines {
+		total = total + line.LineAmount + line.TaxAmount
+	}
+	return total
+}
+
+func writeMockJson(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,45 @@
+package xerosdk
+
+import (
+	"context"
+	"net/http"
+)
+
+type AccountRef struct {
+	Code string `json:"Code"`
+}
+
+type InvoiceRef struct {
+	InvoiceID string `json:"InvoiceID"`
+}
+
+type CreditNoteRef struct {
+	CreditNoteID string `json:"CreditNoteID"`
+}
+
+// Payment applies to either the invoice paid or the credit note refunded
+type Payment struct {
+	PaymentID  string         `json:"PaymentID,omitempty"`
+	Invoice    *InvoiceRef    `json:"Invoice,omitempty"`
+	CreditNote *CreditNoteRef `json:"CreditNote,omitempty"`
+	Account    *AccountRef    `json:"Account"`
+	Date       string         `json:"Date"`
+	Amount     float64        `json:"Amount"`
+	Reference  string         `json:"Reference,omitempty"`
+}
+
+type payments struct {
+	Payments []*Payment `json:"Payments"`
+}
+
+func (c *Client) CreatePayment(ctx context.Context, payment *Payment) (*Payment, error) {
+	var result *payments
+	err := c.do(ctx, http.MethodPut, "/api.xro/2.0/Payments", nil, &payments{Payments: []*Payment{payment}}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || len(result.Payments) == 0 {
+		return nil, &Error{StatusCode: http.StatusOK, Message: "payment not returned"}
+	}
+	return result.Payments[0], nil
+}
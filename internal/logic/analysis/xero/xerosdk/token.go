@@ -0,0 +1,103 @@
+package xerosdk
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	IdToken      string `json:"id_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	ExpiresAt    int64  `json:"expires_at"`
+}
+
+// Expired returns true if access token expired or about to expire in one minute
+func (t *Token) Expired(now int64) bool {
+	return t == nil || len(t.AccessToken) == 0 || (t.ExpiresAt > 0 && now >= t.ExpiresAt-60)
+}
+
+type Connection struct {
+	Id         string `json:"id"`
+	TenantId   string `json:"tenantId"`
+	TenantType string `json:"tenantType"`
+	TenantName string `json:"tenantName"`
+}
+
+// AuthorizationUrl compiles the url the merchant redirected to for granting access
+func (c *Client) AuthorizationUrl(state string, redirectUri string) string {
+	values := url.Values{}
+	values.Set("response_type", "code")
+	values.Set("client_id", c.clientId)
+	values.Set("redirect_uri", redirectUri)
+	values.Set("scope", DefaultScope)
+	values.Set("state", state)
+	return c.endpoints.LoginUrl + "/identity/connect/authorize?" + values.Encode()
+}
+
+// RetrieveToken exchanges the authorization code from xero callback to token
+func (c *Client) RetrieveToken(ctx context.Context, code string, redirectUri string) (*Token, error) {
+	values := url.Values{}
+	values.Set("grant_type", "authorization_code")
+	values.Set("code", code)
+	values.Set("redirect_uri", redirectUri)
+	return c.tokenRequest(ctx, values)
+}
+
+// RefreshToken generates new tokens, the refresh token rotated and the old one can not be used again
+func (c *Client) RefreshToken(ctx context.Context) (*Token, error) {
+	values := url.Values{}
+	values.Set("grant_type", "refresh_token")
+	if c.token != nil {
+		values.Set("refresh_token", c.token.RefreshToken)
+	}
+	return c.tokenRequest(ctx, values)
+}
+
+func (c *Client) tokenRequest(ctx context.Context, values url.Values) (*Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoints.IdentityUrl+"/connect/token", strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.clientId+":"+c.clientSecret)))
+	var token *Token
+	if err = c.send(req, &token); err != nil {
+		return nil, err
+	}
+	if token != nil && token.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Unix() + token.ExpiresIn
+	}
+	c.token = token
+	return token, nil
+}
+
+// RevokeToken revokes the refresh token, all connections of it removed
+func (c *Client) RevokeToken(ctx context.Context) error {
+	if c.token == nil || len(c.token.RefreshToken) == 0 {
+		return nil
+	}
+	values := url.Values{}
+	values.Set("token", c.token.RefreshToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoints.IdentityUrl+"/connect/revocation", strings.NewReader(values.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.clientId+":"+c.clientSecret)))
+	return c.send(req, nil)
+}
+
+// Connections lists the tenants the token authorized
+func (c *Client) Connections(ctx context.Context) ([]*Connection, error) {
+	var list []*Connection
+	err := c.do(ctx, http.MethodGet, "/connections", nil, nil, &list)
+	return list, err
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gtime"
+	"strings"
 	"unibee/api/bean"
 	"unibee/internal/consts"
 	"unibee/internal/logic/batch/export"
@@ -110,7 +111,8 @@ func (t TaskCreditTransactionExport) PageData(ctx context.Context, page int, cou
 				Name:       one.Name,
 				//Description:        one.Description,
 				//AccountType:        accountType,
-				TimeZone: timeZoneStr,
+				TimeZone:       timeZoneStr,
+				GrantBreakdown: exportGrantBreakdown(one.GrantDetails, one.Currency, one.AccountType, timeZone),
 			})
 		}
 	}
@@ -128,9 +130,22 @@ type ExportCreditTransactionEntity struct {
 	By       string `json:"By" comment:"The email of member" group:"Transaction"`
 	//CreditAmountBefore string      `json:"CreditAmountBefore" comment:"The amount before transaction" group:"Transaction"`
 	//CreditAmountAfter  string      `json:"CreditAmountAfter" comment:"The amount after transaction" group:"Transaction"`
-	CreateTime *gtime.Time `json:"CreateTime"  layout:"2006-01-02 15:04:05"   comment:"The create time of invoice" group:"Transaction"`
-	InvoiceId  string      `json:"InvoiceApplied"  comment:"The invoice id of transaction, pure digital" group:"Transaction"`
+	CreateTime     *gtime.Time `json:"CreateTime"  layout:"2006-01-02 15:04:05"   comment:"The create time of invoice" group:"Transaction"`
+	InvoiceId      string      `json:"InvoiceApplied"  comment:"The invoice id of transaction, pure digital" group:"Transaction"`
+	GrantBreakdown string      `json:"GrantBreakdown" comment:"The credit grants increased or consumed by transaction, with the expire time of grant" group:"Transaction"`
 	//Description        string      `json:"Description" comment:"The description of transaction"  group:"Transaction"`
 	//AccountType        string      `json:"AccountType" comment:"The type of transaction account"  group:"Transaction"`
 	TimeZone string `json:"TimeZone"         comment:"" group:"Transaction"`
 }
+
+func exportGrantBreakdown(items []*bean.CreditGrantItem, currency string, accountType int, timeZone int) string {
+	var breakdown = make([]string, 0)
+	for _, item := range items {
+		expire := "never expire"
+		if item.ExpireTime > 0 {
+			expire = "expire " + gtime.NewFromTimeStamp(item.ExpireTime+int64(timeZone*3600)).Format("Y-m-d H:i:s")
+		}
+		breakdown = append(breakdown, fmt.Sprintf("%s:%s(%s)", item.GrantId, utility.ConvertCreditAmountToDollarStr(item.Amount, currency, accountType), expire))
+	}
+	return strings.Join(breakdown, "; ")
+}
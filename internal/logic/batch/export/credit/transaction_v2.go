@@ -106,6 +106,7 @@ func (t TaskCreditTransactionV2Export) PageData(ctx context.Context, page int, c
 				CreateTime:      gtime.NewFromTimeStamp(one.CreateTime + int64(timeZone*3600)),
 				Name:            one.Name,
 				TimeZone:        timeZoneStr,
+				GrantBreakdown:  exportGrantBreakdown(bean.ConvertCreditGrantItems(one.GrantDetail), one.Currency, one.AccountType, timeZone),
 			})
 		}
 	}
@@ -186,7 +187,7 @@ type creditTransactionListInternalReq struct {
 	Email            string `json:"email"  description:"filter email of user"`
 	SortField        string `json:"sortField" dc:"Sort Field，gmt_create|gmt_modify，Default gmt_modify" `
 	SortType         string `json:"sortType" dc:"Sort Type，asc|desc，Default desc" `
-	TransactionTypes []int  `json:"transactionTypes" dc:"transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out" `
+	TransactionTypes []int  `json:"transactionTypes" dc:"transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out，8-expired" `
 	Page             int    `json:"page"  dc:"Page, Start 0" `
 	Count            int    `json:"count"  dc:"Count Of Per Page" `
 	CreateTimeStart  int64  `json:"createTimeStart" dc:"CreateTimeStart，UTC timestamp，seconds" `
@@ -222,6 +223,7 @@ type creditTransactionDetail struct {
 	AccountType         int                  `json:"accountType"       description:"type of credit account"`
 	AdminMember         *bean.MerchantMember `json:"adminMember"       description:"admin member"`
 	By                  string               `json:"by"  dc:"" `
+	GrantDetail         string               `json:"grantDetail"       description:"the credit grants increased or consumed by transaction, json"`
 }
 
 // creditTransactionList is a local copy of CreditTransactionList to avoid import cycles
@@ -364,5 +366,6 @@ func convertToCreditTransactionDetailWithPreload(ctx context.Context, one *entit
 		AccountType:         one.AccountType,
 		AdminMember:         adminMember,
 		By:                  by,
+		GrantDetail:         one.GrantDetail,
 	}
 }
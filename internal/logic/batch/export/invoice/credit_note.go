@@ -7,6 +7,7 @@ import (
 	"unibee/api/bean"
 	"unibee/internal/consts"
 	"unibee/internal/logic/batch/export"
+	"unibee/internal/logic/invoice/numbering"
 	"unibee/internal/logic/invoice/service"
 	entity "unibee/internal/model/entity/default"
 	"unibee/utility"
@@ -128,6 +129,7 @@ func (t TaskCreditNoteExport) PageData(ctx context.Context, page int, count int,
 			}
 			mainList = append(mainList, &ExportCreditNoteEntity{
 				CreditNoteId:        one.InvoiceId,
+				CreditNoteNumber:    numbering.DisplayInvoiceNumber(one.InvoiceNumber, one.InvoiceId, creditNoteGateway),
 				UserId:              fmt.Sprintf("%v", one.UserId),
 				Email:               one.UserSnapshot.Email,
 				FirstName:           one.UserSnapshot.FirstName,
@@ -156,6 +158,7 @@ func (t TaskCreditNoteExport) PageData(ctx context.Context, page int, count int,
 
 type ExportCreditNoteEntity struct {
 	CreditNoteId        string      `json:"CreditNoteId" comment:"The unique id of credit note" group:"Credit Note"`
+	CreditNoteNumber    string      `json:"CreditNoteNumber" comment:"The legal number of credit note, format: Gateway+CreditNoteId if legal numbering not enabled" group:"Credit Note"`
 	UserId              string      `json:"UserId" comment:"The unique id of user" group:"User Information"`
 	Email               string      `json:"Email" comment:"The email of user" group:"User Information"`
 	FirstName           string      `json:"FirstName" comment:"The first name of user" group:"User Information"`
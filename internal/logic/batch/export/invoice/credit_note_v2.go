@@ -7,6 +7,7 @@ import (
 	"unibee/api/bean"
 	"unibee/internal/consts"
 	"unibee/internal/logic/batch/export"
+	"unibee/internal/logic/invoice/numbering"
 	"unibee/internal/logic/invoice/service"
 	entity "unibee/internal/model/entity/default"
 	"unibee/utility"
@@ -163,6 +164,7 @@ func (t TaskCreditNoteV2Export) PageData(ctx context.Context, page int, count in
 			}
 			mainList = append(mainList, &ExportCreditNoteEntity{
 				CreditNoteId:        one.InvoiceId,
+				CreditNoteNumber:    numbering.DisplayInvoiceNumber(one.InvoiceNumber, one.InvoiceId, creditNoteGateway),
 				UserId:              fmt.Sprintf("%v", one.UserId),
 				Email:               one.UserSnapshot.Email,
 				FirstName:           one.UserSnapshot.FirstName,
@@ -8,7 +8,7 @@ import (
 	"unibee/api/bean"
 	"unibee/internal/consts"
 	"unibee/internal/logic/batch/export"
-	"unibee/internal/logic/gateway/api"
+	"unibee/internal/logic/invoice/numbering"
 	"unibee/internal/logic/invoice/service"
 	"unibee/internal/logic/vat_gateway"
 	entity "unibee/internal/model/entity/default"
@@ -214,7 +214,7 @@ func (t TaskInvoiceExport) PageData(ctx context.Context, page int, count int, ta
 			}
 			mainList = append(mainList, &ExportInvoiceEntity{
 				InvoiceId:                      one.InvoiceId,
-				InvoiceNumber:                  fmt.Sprintf("%s%s", api.GatewayShortNameMapping[invoiceGateway], one.InvoiceId),
+				InvoiceNumber:                  numbering.DisplayInvoiceNumber(one.InvoiceNumber, one.InvoiceId, invoiceGateway),
 				UserId:                         fmt.Sprintf("%v", one.UserId),
 				ExternalUserId:                 fmt.Sprintf("%v", one.UserAccount.ExternalUserId),
 				FirstName:                      one.UserSnapshot.FirstName,
@@ -279,7 +279,7 @@ func (t TaskInvoiceExport) PageData(ctx context.Context, page int, count int, ta
 
 type ExportInvoiceEntity struct {
 	InvoiceId                      string      `json:"InvoiceId"  comment:"The unique id of invoice, pure digital" group:"Invoice"`
-	InvoiceNumber                  string      `json:"InvoiceNumber" comment:"The unique number of invoice, format: Gateway+InvoiceId" group:"Invoice"`
+	InvoiceNumber                  string      `json:"InvoiceNumber" comment:"The legal number of invoice, format: Gateway+InvoiceId if legal numbering not enabled" group:"Invoice"`
 	UserId                         string      `json:"UserId"              comment:"The unique id of user" group:"User Information"`
 	ExternalUserId                 string      `json:"ExternalUserId"      comment:"The external unique id of user" group:"User Information"`
 	FirstName                      string      `json:"FirstName"           comment:"The first name of user" group:"User Information"`
@@ -9,7 +9,7 @@ import (
 	"unibee/internal/consts"
 	dao "unibee/internal/dao/default"
 	"unibee/internal/logic/batch/export"
-	"unibee/internal/logic/gateway/api"
+	"unibee/internal/logic/invoice/numbering"
 	"unibee/internal/logic/invoice/service"
 	preload2 "unibee/internal/logic/preload"
 	"unibee/internal/logic/subscription/config"
@@ -252,7 +252,7 @@ func (t TaskInvoiceV2Export) PageData(ctx context.Context, page int, count int,
 		}
 		mainList = append(mainList, &ExportInvoiceEntity{
 			InvoiceId:                      one.InvoiceId,
-			InvoiceNumber:                  fmt.Sprintf("%s%s", api.GatewayShortNameMapping[invoiceGateway], one.InvoiceId),
+			InvoiceNumber:                  numbering.DisplayInvoiceNumber(one.InvoiceNumber, one.InvoiceId, invoiceGateway),
 			UserId:                         fmt.Sprintf("%v", one.UserId),
 			ExternalUserId:                 fmt.Sprintf("%v", userAccount.ExternalUserId),
 			FirstName:                      userSnapshot.FirstName,
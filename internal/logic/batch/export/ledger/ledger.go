@@ -0,0 +1,120 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"unibee/internal/logic/analysis/ledger"
+	"unibee/internal/logic/batch/export"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type TaskLedgerExport struct {
+}
+
+func (t TaskLedgerExport) TaskName() string {
+	return "LedgerExport"
+}
+
+func (t TaskLedgerExport) Header() interface{} {
+	return ExportLedgerEntity{}
+}
+
+func (t TaskLedgerExport) PageData(ctx context.Context, page int, count int, task *entity.MerchantBatchTask) ([]interface{}, error) {
+	var mainList = make([]interface{}, 0)
+	if task == nil || task.MerchantId <= 0 {
+		return mainList, nil
+	}
+	var payload map[string]interface{}
+	err := utility.UnmarshalFromJsonString(task.Payload, &payload)
+	if err != nil {
+		g.Log().Errorf(ctx, "Download PageData error:%s", err.Error())
+		return mainList, nil
+	}
+	req := &ledger.EntryListInternalReq{
+		MerchantId: task.MerchantId,
+		Page:       page,
+		Count:      count,
+		SkipTotal:  true,
+	}
+	var timeZone int64 = 0
+	if payload != nil {
+		if value, ok := payload["timeZone"].(string); ok {
+			zone, err := export.GetUTCOffsetFromTimeZone(value)
+			if err == nil && zone > 0 {
+				timeZone = zone
+			}
+		}
+		if value, ok := payload["entryTypes"].([]interface{}); ok {
+			req.EntryTypes = jsonArrayToStrings(value)
+		}
+		if value, ok := payload["accounts"].([]interface{}); ok {
+			req.Accounts = jsonArrayToStrings(value)
+		}
+		if value, ok := payload["currency"].(string); ok {
+			req.Currency = value
+		}
+		if value, ok := payload["entryTimeStart"].(float64); ok {
+			req.EntryTimeStart = int64(value) - timeZone
+		}
+		if value, ok := payload["entryTimeEnd"].(float64); ok {
+			req.EntryTimeEnd = int64(value) - timeZone
+		}
+	}
+	list, _, err := ledger.EntryList(ctx, req)
+	if err != nil {
+		return mainList, err
+	}
+	codes := ledger.GetMerchantLedgerAccountCodes(ctx, task.MerchantId)
+	for _, one := range list {
+		mainList = append(mainList, &ExportLedgerEntity{
+			JournalId:      one.JournalId,
+			LineNo:         fmt.Sprintf("%d", one.LineNo),
+			EntryType:      one.EntryType,
+			EntryTime:      gtime.NewFromTimeStamp(one.EntryTime + timeZone),
+			AccountCode:    ledger.AccountCode(codes, one.Account, one.GatewayName),
+			Account:        one.Account,
+			Gateway:        one.GatewayName,
+			Currency:       one.Currency,
+			Debit:          utility.ConvertCentToDollarStr(one.Debit, one.Currency),
+			Credit:         utility.ConvertCentToDollarStr(one.Credit, one.Currency),
+			Description:    one.Description,
+			SourceId:       one.SourceId,
+			InvoiceId:      one.InvoiceId,
+			SubscriptionId: one.SubscriptionId,
+			UserId:         fmt.Sprintf("%d", one.UserId),
+		})
+	}
+	return mainList, nil
+}
+
+func jsonArrayToStrings(source []interface{}) []string {
+	list := make([]string, 0)
+	for _, v := range source {
+		if val, ok := v.(string); ok && len(val) > 0 {
+			list = append(list, val)
+		}
+	}
+	return list
+}
+
+type ExportLedgerEntity struct {
+	JournalId      string      `json:"JournalId" comment:"The id of journal, lines of one journal balanced" group:"Journal"`
+	LineNo         string      `json:"LineNo" comment:"The line no of journal" group:"Journal"`
+	EntryType      string      `json:"EntryType" comment:"The type of journal, invoice_finalized|promo_credit_used|payment_success|credit_note_finalized|refund_success|invoice_voided|revenue_recognition" group:"Journal"`
+	EntryTime      *gtime.Time `json:"EntryTime" layout:"2006-01-02 15:04:05" comment:"The time of journal" group:"Journal"`
+	AccountCode    string      `json:"AccountCode" comment:"The account code configured by merchant" group:"Account"`
+	Account        string      `json:"Account" comment:"The account, ar|revenue|deferred_revenue|tax_payable|cash|promo_credit" group:"Account"`
+	Gateway        string      `json:"Gateway" comment:"The gateway name of cash account" group:"Account"`
+	Currency       string      `json:"Currency" comment:"The currency of line" group:"Amount"`
+	Debit          string      `json:"Debit" comment:"The debit amount of line" group:"Amount"`
+	Credit         string      `json:"Credit" comment:"The credit amount of line" group:"Amount"`
+	Description    string      `json:"Description" comment:"The description of journal" group:"Journal"`
+	SourceId       string      `json:"SourceId" comment:"The invoice, payment or refund id the journal recorded from" group:"Reference"`
+	InvoiceId      string      `json:"InvoiceId" comment:"The invoice id the journal applies to" group:"Reference"`
+	SubscriptionId string      `json:"SubscriptionId" comment:"The subscription id connected to invoice" group:"Reference"`
+	UserId         string      `json:"UserId" comment:"The unique id of user" group:"Reference"`
+}
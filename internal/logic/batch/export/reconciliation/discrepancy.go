@@ -0,0 +1,150 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+	"unibee/internal/logic/batch/export"
+	reconciliation2 "unibee/internal/logic/payment/reconciliation"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type TaskGatewayDiscrepancyExport struct {
+}
+
+func (t TaskGatewayDiscrepancyExport) TaskName() string {
+	return "GatewayDiscrepancyExport"
+}
+
+func (t TaskGatewayDiscrepancyExport) Header() interface{} {
+	return ExportGatewayDiscrepancyEntity{}
+}
+
+func (t TaskGatewayDiscrepancyExport) PageData(ctx context.Context, page int, count int, task *entity.MerchantBatchTask) ([]interface{}, error) {
+	var mainList = make([]interface{}, 0)
+	if task == nil || task.MerchantId <= 0 {
+		return mainList, nil
+	}
+	var payload map[string]interface{}
+	err := utility.UnmarshalFromJsonString(task.Payload, &payload)
+	if err != nil {
+		g.Log().Errorf(ctx, "Download PageData error:%s", err.Error())
+		return mainList, nil
+	}
+	req := &reconciliation2.DiscrepancyListInternalReq{
+		MerchantId: task.MerchantId,
+		Page:       page,
+		Count:      count,
+		SkipTotal:  true,
+	}
+	var timeZone int64 = 0
+	if payload != nil {
+		if value, ok := payload["timeZone"].(string); ok {
+			zone, err := export.GetUTCOffsetFromTimeZone(value)
+			if err == nil && zone > 0 {
+				timeZone = zone
+			}
+		}
+		if value, ok := payload["reconciliationId"].(float64); ok {
+			req.ReconciliationId = uint64(value)
+		}
+		if value, ok := payload["gatewayId"].(float64); ok {
+			req.GatewayId = uint64(value)
+		}
+		if value, ok := payload["sourceType"].(string); ok {
+			req.SourceType = value
+		}
+		if value, ok := payload["discrepancyTypes"].([]interface{}); ok {
+			for _, v := range value {
+				if val, ok := v.(string); ok && len(val) > 0 {
+					req.DiscrepancyTypes = append(req.DiscrepancyTypes, val)
+				}
+			}
+		}
+		if value, ok := payload["resolveStatus"].([]interface{}); ok {
+			for _, v := range value {
+				if val, ok := v.(float64); ok {
+					req.ResolveStatus = append(req.ResolveStatus, int(val))
+				}
+			}
+		}
+		if value, ok := payload["createTimeStart"].(float64); ok {
+			req.CreateTimeStart = int64(value) - timeZone
+		}
+		if value, ok := payload["createTimeEnd"].(float64); ok {
+			req.CreateTimeEnd = int64(value) - timeZone
+		}
+	}
+	list, _, err := reconciliation2.DiscrepancyList(ctx, req)
+	if err != nil {
+		return mainList, err
+	}
+	for _, one := range list {
+		autoFixed := "No"
+		if one.AutoFixed == 1 {
+			autoFixed = "Yes"
+		}
+		mainList = append(mainList, &ExportGatewayDiscrepancyEntity{
+			Id:               fmt.Sprintf("%d", one.Id),
+			ReconciliationId: fmt.Sprintf("%d", one.ReconciliationId),
+			Gateway:          one.GatewayName,
+			SourceType:       one.SourceType,
+			DiscrepancyType:  one.DiscrepancyType,
+			PaymentId:        one.PaymentId,
+			RefundId:         one.RefundId,
+			GatewayPaymentId: one.GatewayPaymentId,
+			GatewayRefundId:  one.GatewayRefundId,
+			InvoiceId:        one.InvoiceId,
+			UserId:           fmt.Sprintf("%d", one.UserId),
+			Currency:         one.Currency,
+			LocalAmount:      utility.ConvertCentToDollarStr(one.LocalAmount, one.Currency),
+			GatewayAmount:    utility.ConvertCentToDollarStr(one.GatewayAmount, one.Currency),
+			LocalStatus:      fmt.Sprintf("%d", one.LocalStatus),
+			GatewayStatus:    fmt.Sprintf("%d", one.GatewayStatus),
+			AutoFixed:        autoFixed,
+			ResolveStatus:    resolveStatusName(one.ResolveStatus),
+			ResolveNote:      one.ResolveNote,
+			Detail:           one.Detail,
+			CreateTime:       gtime.NewFromTimeStamp(one.CreateTime + timeZone),
+		})
+	}
+	return mainList, nil
+}
+
+func resolveStatusName(status int) string {
+	switch status {
+	case reconciliation2.ResolveStatusResolved:
+		return "Resolved"
+	case reconciliation2.ResolveStatusIgnored:
+		return "Ignored"
+	default:
+		return "Open"
+	}
+}
+
+type ExportGatewayDiscrepancyEntity struct {
+	Id               string      `json:"Id" comment:"The id of discrepancy" group:"Discrepancy"`
+	ReconciliationId string      `json:"ReconciliationId" comment:"The id of reconciliation found the discrepancy" group:"Discrepancy"`
+	Gateway          string      `json:"Gateway" comment:"The gateway name" group:"Discrepancy"`
+	SourceType       string      `json:"SourceType" comment:"The source type, payment|refund" group:"Discrepancy"`
+	DiscrepancyType  string      `json:"DiscrepancyType" comment:"The discrepancy type, missing_local|missing_gateway|amount_drift|status_drift|lookup_failed" group:"Discrepancy"`
+	PaymentId        string      `json:"PaymentId" comment:"The local payment id" group:"Reference"`
+	RefundId         string      `json:"RefundId" comment:"The local refund id" group:"Reference"`
+	GatewayPaymentId string      `json:"GatewayPaymentId" comment:"The payment id of gateway" group:"Reference"`
+	GatewayRefundId  string      `json:"GatewayRefundId" comment:"The refund id of gateway" group:"Reference"`
+	InvoiceId        string      `json:"InvoiceId" comment:"The invoice id of payment" group:"Reference"`
+	UserId           string      `json:"UserId" comment:"The unique id of user" group:"Reference"`
+	Currency         string      `json:"Currency" comment:"The currency" group:"Amount"`
+	LocalAmount      string      `json:"LocalAmount" comment:"The local amount" group:"Amount"`
+	GatewayAmount    string      `json:"GatewayAmount" comment:"The gateway amount" group:"Amount"`
+	LocalStatus      string      `json:"LocalStatus" comment:"The local status, 10-pending，20-success，30-failure, 40-cancel, 50-reverse(refund only)" group:"Status"`
+	GatewayStatus    string      `json:"GatewayStatus" comment:"The gateway status, 10-pending，20-success，30-failure, 40-cancel, 50-reverse(refund only)" group:"Status"`
+	AutoFixed        string      `json:"AutoFixed" comment:"Yes if the local status fixed from gateway automatically" group:"Status"`
+	ResolveStatus    string      `json:"ResolveStatus" comment:"The resolve status, Open|Resolved|Ignored" group:"Review"`
+	ResolveNote      string      `json:"ResolveNote" comment:"The note of review" group:"Review"`
+	Detail           string      `json:"Detail" comment:"The detail of discrepancy" group:"Review"`
+	CreateTime       *gtime.Time `json:"CreateTime" layout:"2006-01-02 15:04:05" comment:"The time discrepancy found" group:"Review"`
+}
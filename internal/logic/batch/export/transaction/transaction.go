@@ -110,6 +110,8 @@ func (t TaskTransactionExport) PageData(ctx context.Context, page int, count int
 				if one.FullRefund == 1 {
 					fullRefund = "Yes"
 				}
+			} else if one.TimelineType == 2 {
+				transactionType = "gateway attempt"
 			}
 			var status = "Pending"
 			if one.Status == 1 {
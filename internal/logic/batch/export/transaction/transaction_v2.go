@@ -122,6 +122,8 @@ func (t TaskTransactionV2Export) PageData(ctx context.Context, page int, count i
 				if one.FullRefund == 1 {
 					fullRefund = "Yes"
 				}
+			} else if one.TimelineType == 2 {
+				transactionType = "gateway attempt"
 			}
 
 			var status = "Pending"
@@ -0,0 +1,99 @@
+package vat_return
+
+import (
+	"context"
+	"fmt"
+	"unibee/internal/logic/vat_gateway/vat_return"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+type TaskVatOSSReturnExport struct {
+}
+
+func (t TaskVatOSSReturnExport) TaskName() string {
+	return "VatOSSReturnExport"
+}
+
+func (t TaskVatOSSReturnExport) Header() interface{} {
+	return ExportVatOSSReturnEntity{}
+}
+
+func (t TaskVatOSSReturnExport) PageData(ctx context.Context, page int, count int, task *entity.MerchantBatchTask) ([]interface{}, error) {
+	var mainList = make([]interface{}, 0)
+	if task == nil || task.MerchantId <= 0 {
+		return mainList, nil
+	}
+	// the report aggregated at once, all lines returned in the first page
+	if page > 0 {
+		return nil, nil
+	}
+	var payload map[string]interface{}
+	err := utility.UnmarshalFromJsonString(task.Payload, &payload)
+	if err != nil {
+		g.Log().Errorf(ctx, "Download PageData error:%s", err.Error())
+		return mainList, nil
+	}
+	req := &vat_return.ReturnReportReq{
+		MerchantId:    task.MerchantId,
+		RateSource:    vat_return.RateSourceEcb,
+		ExchangeRates: make(map[string]float64),
+	}
+	if payload != nil {
+		if value, ok := payload["year"].(float64); ok {
+			req.Year = int(value)
+		}
+		if value, ok := payload["quarter"].(float64); ok {
+			req.Quarter = int(value)
+		}
+		if value, ok := payload["rateSource"].(string); ok && len(value) > 0 {
+			req.RateSource = value
+		}
+		if value, ok := payload["exchangeRates"].(map[string]interface{}); ok {
+			for currency, v := range value {
+				if rate, ok := v.(float64); ok {
+					req.ExchangeRates[currency] = rate
+				}
+			}
+		}
+	}
+	list, err := vat_return.BuildReturnReport(ctx, req)
+	if err != nil {
+		return mainList, err
+	}
+	period := fmt.Sprintf("%d-Q%d", req.Year, req.Quarter)
+	for _, one := range list {
+		mainList = append(mainList, &ExportVatOSSReturnEntity{
+			Period:           period,
+			Scheme:           one.Scheme,
+			CountryCode:      one.CountryCode,
+			TaxRate:          utility.ConvertTaxPercentageToPercentageString(one.TaxPercentage),
+			Currency:         one.Currency,
+			InvoiceCount:     fmt.Sprintf("%d", one.InvoiceCount),
+			CreditNoteCount:  fmt.Sprintf("%d", one.CreditNoteCount),
+			TaxableAmount:    utility.ConvertCentToDollarStr(one.TaxableAmount, one.Currency),
+			TaxAmount:        utility.ConvertCentToDollarStr(one.TaxAmount, one.Currency),
+			ExchangeRate:     fmt.Sprintf("%v", one.ExchangeRate),
+			TaxableAmountEUR: utility.ConvertCentToDollarStr(one.TaxableAmountEUR, vat_return.ReportCurrency),
+			TaxAmountEUR:     utility.ConvertCentToDollarStr(one.TaxAmountEUR, vat_return.ReportCurrency),
+		})
+	}
+	return mainList, nil
+}
+
+type ExportVatOSSReturnEntity struct {
+	Period           string `json:"Period" comment:"The quarter of return, 2024-Q1 for example" group:"Return"`
+	Scheme           string `json:"Scheme" comment:"The scheme reported in, oss|reverse_charge|domestic, reverse_charge for b2b invoices with vat number and no tax, domestic for the customers in merchant country" group:"Return"`
+	CountryCode      string `json:"CountryCode" comment:"The member state of consumption, the country code of customer" group:"Return"`
+	TaxRate          string `json:"TaxRate" comment:"The vat rate of invoices" group:"Return"`
+	Currency         string `json:"Currency" comment:"The currency of invoices" group:"Original Currency"`
+	InvoiceCount     string `json:"InvoiceCount" comment:"The count of invoices paid in quarter" group:"Original Currency"`
+	CreditNoteCount  string `json:"CreditNoteCount" comment:"The count of credit notes refunded in quarter" group:"Original Currency"`
+	TaxableAmount    string `json:"TaxableAmount" comment:"The taxable amount, credit notes deducted" group:"Original Currency"`
+	TaxAmount        string `json:"TaxAmount" comment:"The vat amount, credit notes deducted" group:"Original Currency"`
+	ExchangeRate     string `json:"ExchangeRate" comment:"The exchange rate of currency to EUR" group:"EUR"`
+	TaxableAmountEUR string `json:"TaxableAmountEUR" comment:"The taxable amount in EUR" group:"EUR"`
+	TaxAmountEUR     string `json:"TaxAmountEUR" comment:"The vat amount in EUR" group:"EUR"`
+}
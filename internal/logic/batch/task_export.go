@@ -14,10 +14,13 @@ import (
 	"unibee/internal/logic/batch/export/credit"
 	"unibee/internal/logic/batch/export/discount"
 	"unibee/internal/logic/batch/export/invoice"
+	"unibee/internal/logic/batch/export/ledger"
 	plan2 "unibee/internal/logic/batch/export/plan"
+	"unibee/internal/logic/batch/export/reconciliation"
 	"unibee/internal/logic/batch/export/subscription"
 	"unibee/internal/logic/batch/export/transaction"
 	"unibee/internal/logic/batch/export/user"
+	"unibee/internal/logic/batch/export/vat_return"
 	"unibee/internal/logic/oss"
 	entity "unibee/internal/model/entity/default"
 	"unibee/utility"
@@ -31,16 +34,19 @@ import (
 )
 
 var exportTaskMap = map[string]_interface.BatchExportTask{
-	"InvoiceExport":           &invoice.TaskInvoiceV2Export{},
-	"UserExport":              &user.TaskUserExport{},
-	"SubscriptionExport":      &subscription.TaskSubscriptionV2Export{},
-	"TransactionExport":       &transaction.TaskTransactionV2Export{},
-	"DiscountExport":          &discount.TaskDiscountExport{},
-	"PlanExport":              &plan2.TaskPlanExport{},
-	"UserDiscountExport":      &discount.TaskUserDiscountV2Export{},
-	"MultiUserDiscountExport": &discount.TaskMultiUserDiscountV2Export{},
-	"CreditTransactionExport": &credit.TaskCreditTransactionV2Export{},
-	"CreditNoteExport":        &invoice.TaskCreditNoteV2Export{},
+	"InvoiceExport":            &invoice.TaskInvoiceV2Export{},
+	"UserExport":               &user.TaskUserExport{},
+	"SubscriptionExport":       &subscription.TaskSubscriptionV2Export{},
+	"TransactionExport":        &transaction.TaskTransactionV2Export{},
+	"DiscountExport":           &discount.TaskDiscountExport{},
+	"PlanExport":               &plan2.TaskPlanExport{},
+	"UserDiscountExport":       &discount.TaskUserDiscountV2Export{},
+	"MultiUserDiscountExport":  &discount.TaskMultiUserDiscountV2Export{},
+	"CreditTransactionExport":  &credit.TaskCreditTransactionV2Export{},
+	"CreditNoteExport":         &invoice.TaskCreditNoteV2Export{},
+	"LedgerExport":             &ledger.TaskLedgerExport{},
+	"GatewayDiscrepancyExport": &reconciliation.TaskGatewayDiscrepancyExport{},
+	"VatOSSReturnExport":       &vat_return.TaskVatOSSReturnExport{},
 }
 
 func GetExportTaskImpl(task string) _interface.BatchExportTask {
@@ -288,7 +294,7 @@ func startRunExportTaskBackground(task *entity.MerchantBatchTask, taskImpl _inte
 		if format == "csv" {
 			fileName, err = convertXlsxFileToCSV(fileName, GeneralExportImportSheetName, fmt.Sprintf("Batch_export_%v_%s.csv", task.Id, timestamp))
 		}
-		upload, err := oss.UploadLocalFile(ctx, fileName, "batch_export", fileName, strconv.FormatUint(task.MemberId, 10))
+		upload, err := oss.UploadLocalFile(ctx, fileName, "batch_export", fileName, strconv.FormatUint(task.MemberId, 10), task.MerchantId)
 		if err != nil {
 			g.Log().Errorf(ctx, fmt.Sprintf("startRunExportTaskBackground UploadLocalFile error:%v", err))
 			failureTask(ctx, task.Id, err)
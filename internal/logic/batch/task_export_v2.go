@@ -232,7 +232,7 @@ func startRunExportTaskBackgroundV2(task *entity.MerchantBatchTask, taskImpl _in
 			}
 		}
 
-		upload, err := oss.UploadLocalFile(ctx, finalFileName, "batch_export", finalFileName, strconv.FormatUint(task.MemberId, 10))
+		upload, err := oss.UploadLocalFile(ctx, finalFileName, "batch_export", finalFileName, strconv.FormatUint(task.MemberId, 10), task.MerchantId)
 		if err != nil {
 			g.Log().Errorf(ctx, fmt.Sprintf("startRunExportTaskBackgroundV2 UploadLocalFile error:%v", err))
 			failureTask(ctx, task.Id, err)
@@ -147,7 +147,7 @@ func startRunImportTaskBackground(task *entity.MerchantBatchTask, taskImpl _inte
 			return
 		}
 		// Start Read Import File
-		importFile := utility.DownloadFile(task.UploadFileUrl)
+		importFile := oss.DownloadFile(ctx, task.UploadFileUrl)
 		if len(importFile) == 0 {
 			err = gerror.Newf("download url failed:%s", task.UploadFileUrl)
 			g.Log().Errorf(ctx, err.Error())
@@ -219,7 +219,7 @@ func startRunImportTaskBackground(task *entity.MerchantBatchTask, taskImpl _inte
 			failureTask(ctx, task.Id, err)
 			return
 		}
-		upload, err := oss.UploadLocalFile(ctx, fileName, "batch_import", fileName, strconv.FormatUint(task.MemberId, 10))
+		upload, err := oss.UploadLocalFile(ctx, fileName, "batch_import", fileName, strconv.FormatUint(task.MemberId, 10), task.MerchantId)
 		if err != nil {
 			g.Log().Errorf(ctx, fmt.Sprintf("startRunImportTaskBackground UploadLocalFile error:%v", err))
 			failureTask(ctx, task.Id, err)
@@ -13,6 +13,7 @@ import (
 	dao "unibee/internal/dao/default"
 	"unibee/internal/logic/credit/account"
 	"unibee/internal/logic/credit/config"
+	"unibee/internal/logic/credit/grant"
 	"unibee/internal/logic/operation_log"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
@@ -28,6 +29,7 @@ type CreditAccountAdminChangeInternalReq struct {
 	Name          string `json:"name"`
 	Description   string `json:"description"`
 	AdminMemberId uint64 `json:"adminMemberId"`
+	ExpireTime    int64  `json:"expireTime"`
 }
 
 type CreditAccountAdminChangeInternalRes struct {
@@ -42,6 +44,7 @@ func CreditAccountAdminChange(ctx context.Context, req *CreditAccountAdminChange
 	utility.Assert(req.MerchantId > 0, "invalid merchantId")
 	utility.Assert(req.Amount != 0, "invalid amount")
 	utility.Assert(req.Currency != "", "invalid currency")
+	utility.Assert(req.ExpireTime == 0 || req.ExpireTime > gtime.Now().Timestamp(), "expireTime should be later than now")
 	user := query.GetUserAccountById(ctx, req.UserId)
 	utility.Assert(user != nil, "user not found")
 	utility.Assert(user.MerchantId == req.MerchantId, "merchant not match")
@@ -50,12 +53,36 @@ func CreditAccountAdminChange(ctx context.Context, req *CreditAccountAdminChange
 	utility.Assert(creditAccount.RechargeEnable == 1, "Credit account editable disabled")
 	utility.AssertError(config.CheckCreditConfig(ctx, req.MerchantId, creditAccount.Type, req.Currency), "Invalid Credit Config")
 	if req.Amount < 0 {
+		grant.ExpireOverdueCreditGrants(ctx, creditAccount.Id)
+		creditAccount = query.GetCreditAccountById(ctx, creditAccount.Id)
 		utility.Assert(creditAccount.Amount >= -req.Amount, "no enough amount to decrement")
 	}
 	creditConfig := query.GetCreditConfig(ctx, req.MerchantId, creditAccount.Type, req.Currency)
 	err := dao.CreditRecharge.DB().Transaction(ctx, func(ctx context.Context, transaction gdb.TX) error {
 		creditAccount = query.GetCreditAccountById(ctx, creditAccount.Id)
 		transactionId := utility.CreateEventId()
+		var grantDetail string
+		if req.Amount > 0 {
+			one, err := grant.NewCreditGrant(ctx, &grant.NewCreditGrantInternalReq{
+				CreditAccount: creditAccount,
+				Source:        consts.CreditGrantSourceAdmin,
+				BizId:         transactionId,
+				Amount:        req.Amount,
+				ExpireTime:    req.ExpireTime,
+				Name:          req.Name,
+				Description:   req.Description,
+			})
+			if err != nil {
+				return err
+			}
+			grantDetail = grant.GrantDetailOf(one)
+		} else {
+			detail, err := grant.ConsumeCreditGrants(ctx, creditAccount.Id, -req.Amount)
+			if err != nil {
+				return err
+			}
+			grantDetail = detail
+		}
 		trans := &entity.CreditTransaction{
 			UserId:             req.UserId,
 			CreditId:           creditAccount.Id,
@@ -73,6 +100,7 @@ func CreditAccountAdminChange(ctx context.Context, req *CreditAccountAdminChange
 			AccountType:        creditAccount.Type,
 			ExchangeRate:       creditConfig.ExchangeRate,
 			AdminMemberId:      req.AdminMemberId,
+			GrantDetail:        grantDetail,
 		}
 		_, err := dao.CreditTransaction.Ctx(ctx).Data(trans).OmitNil().Insert(trans)
 		if err != nil {
@@ -0,0 +1,292 @@
+package grant
+
+import (
+	"context"
+	"fmt"
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"sort"
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/credit/credit_query"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+type NewCreditGrantInternalReq struct {
+	CreditAccount *entity.CreditAccount `json:"creditAccount"`
+	Source        string                `json:"source"`
+	BizId         string                `json:"bizId"`
+	Amount        int64                 `json:"amount"`
+	ExpireTime    int64                 `json:"expireTime"`
+	Name          string                `json:"name"`
+	Description   string                `json:"description"`
+}
+
+// NewCreditGrant records the increment of credit account as a grant, should be called within the transaction increasing the account amount
+func NewCreditGrant(ctx context.Context, req *NewCreditGrantInternalReq) (*entity.CreditGrant, error) {
+	if req == nil || req.CreditAccount == nil || req.Amount <= 0 {
+		return nil, gerror.New("invalid credit grant")
+	}
+	if req.ExpireTime < 0 {
+		req.ExpireTime = 0
+	}
+	one := &entity.CreditGrant{
+		MerchantId:      req.CreditAccount.MerchantId,
+		UserId:          req.CreditAccount.UserId,
+		CreditId:        req.CreditAccount.Id,
+		Currency:        req.CreditAccount.Currency,
+		AccountType:     req.CreditAccount.Type,
+		GrantId:         utility.CreateCreditGrantId(),
+		Source:          req.Source,
+		BizId:           req.BizId,
+		Amount:          req.Amount,
+		RemainingAmount: req.Amount,
+		ExpireTime:      req.ExpireTime,
+		Status:          consts.CreditGrantActive,
+		Name:            req.Name,
+		Description:     req.Description,
+		CreateTime:      gtime.Now().Timestamp(),
+	}
+	result, err := dao.CreditGrant.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := result.LastInsertId()
+	one.Id = uint64(id)
+	return one, nil
+}
+
+func GrantDetailOf(one *entity.CreditGrant) string {
+	if one == nil {
+		return ""
+	}
+	return utility.MarshalToJsonString([]*bean.CreditGrantItem{{GrantId: one.GrantId, Amount: one.Amount, ExpireTime: one.ExpireTime}})
+}
+
+// SortCreditGrantsForConsumption orders the grants the soonest expiring first, the grants never expire come last, ties in create order
+func SortCreditGrantsForConsumption(list []*entity.CreditGrant) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].ExpireTime != list[j].ExpireTime {
+			if list[i].ExpireTime == 0 {
+				return false
+			}
+			if list[j].ExpireTime == 0 {
+				return true
+			}
+			return list[i].ExpireTime < list[j].ExpireTime
+		}
+		return list[i].Id < list[j].Id
+	})
+}
+
+// AllocateCreditGrantConsumption draws the amount from the active grants FIFO by expiry, the amount exceeding the grants
+// is drawn from the balance not tracked by grant(the balance before grant introduced)
+func AllocateCreditGrantConsumption(list []*entity.CreditGrant, amount int64, timeNow int64) []*bean.CreditGrantItem {
+	var items = make([]*bean.CreditGrantItem, 0)
+	var candidates = make([]*entity.CreditGrant, 0)
+	for _, one := range list {
+		if one != nil && one.Status == consts.CreditGrantActive && one.RemainingAmount > 0 && (one.ExpireTime == 0 || one.ExpireTime > timeNow) {
+			candidates = append(candidates, one)
+		}
+	}
+	SortCreditGrantsForConsumption(candidates)
+	for _, one := range candidates {
+		if amount <= 0 {
+			break
+		}
+		consume := utility.MinInt64(one.RemainingAmount, amount)
+		items = append(items, &bean.CreditGrantItem{GrantId: one.GrantId, Amount: consume, ExpireTime: one.ExpireTime})
+		amount = amount - consume
+	}
+	return items
+}
+
+func GetActiveCreditGrantList(ctx context.Context, creditId uint64) (list []*entity.CreditGrant) {
+	err := dao.CreditGrant.Ctx(ctx).
+		Where(dao.CreditGrant.Columns().CreditId, creditId).
+		Where(dao.CreditGrant.Columns().Status, consts.CreditGrantActive).
+		Where(dao.CreditGrant.Columns().IsDeleted, 0).
+		WhereGT(dao.CreditGrant.Columns().RemainingAmount, 0).
+		OrderAsc(dao.CreditGrant.Columns().Id).
+		Scan(&list)
+	if err != nil {
+		g.Log().Errorf(ctx, "GetActiveCreditGrantList creditId:%d error:%s", creditId, err.Error())
+		return make([]*entity.CreditGrant, 0)
+	}
+	return list
+}
+
+func GetCreditGrantList(ctx context.Context, creditId uint64) (list []*entity.CreditGrant) {
+	err := dao.CreditGrant.Ctx(ctx).
+		Where(dao.CreditGrant.Columns().CreditId, creditId).
+		Where(dao.CreditGrant.Columns().IsDeleted, 0).
+		OrderDesc(dao.CreditGrant.Columns().Id).
+		Scan(&list)
+	if err != nil {
+		g.Log().Errorf(ctx, "GetCreditGrantList creditId:%d error:%s", creditId, err.Error())
+		return make([]*entity.CreditGrant, 0)
+	}
+	return list
+}
+
+// ConsumeCreditGrants decreases the remaining amount of grants by consumption, should be called within the transaction
+// decreasing the account amount
+func ConsumeCreditGrants(ctx context.Context, creditId uint64, amount int64) (string, error) {
+	items := AllocateCreditGrantConsumption(GetActiveCreditGrantList(ctx, creditId), amount, gtime.Now().Timestamp())
+	for _, item := range items {
+		update, err := dao.CreditGrant.Ctx(ctx).
+			Where(dao.CreditGrant.Columns().GrantId, item.GrantId).
+			Where(dao.CreditGrant.Columns().Status, consts.CreditGrantActive).
+			WhereGTE(dao.CreditGrant.Columns().RemainingAmount, item.Amount).
+			Decrement(dao.CreditGrant.Columns().RemainingAmount, item.Amount)
+		if err != nil {
+			return "", err
+		}
+		affected, err := update.RowsAffected()
+		if err != nil {
+			return "", err
+		}
+		if affected != 1 {
+			return "", gerror.Newf("consume credit grant failed, grantId:%s", item.GrantId)
+		}
+		_, err = dao.CreditGrant.Ctx(ctx).Data(g.Map{
+			dao.CreditGrant.Columns().Status:    consts.CreditGrantUsedUp,
+			dao.CreditGrant.Columns().GmtModify: gtime.Now(),
+		}).Where(dao.CreditGrant.Columns().GrantId, item.GrantId).
+			Where(dao.CreditGrant.Columns().RemainingAmount, 0).
+			Update()
+		if err != nil {
+			return "", err
+		}
+	}
+	return utility.MarshalToJsonString(items), nil
+}
+
+// RefundGrantExpireTime returns the expire time of the grant refunded credit goes back to, the latest expire time of the grants
+// the payment consumed, 0 if any part of payment consumed the credit never expire
+func RefundGrantExpireTime(ctx context.Context, creditPaymentId string) int64 {
+	var payout *entity.CreditTransaction
+	err := dao.CreditTransaction.Ctx(ctx).
+		Where(dao.CreditTransaction.Columns().BizId, creditPaymentId).
+		Where(dao.CreditTransaction.Columns().TransactionType, consts.CreditTransactionPayout).
+		Scan(&payout)
+	if err != nil || payout == nil {
+		return 0
+	}
+	var expireTime int64 = 0
+	var consumed int64 = 0
+	for _, item := range bean.ConvertCreditGrantItems(payout.GrantDetail) {
+		if item.ExpireTime == 0 {
+			return 0
+		}
+		consumed = consumed + item.Amount
+		expireTime = utility.MaxInt64(expireTime, item.ExpireTime)
+	}
+	if consumed < -payout.DeltaAmount {
+		return 0
+	}
+	return expireTime
+}
+
+// ExpireCreditGrant expires the remaining amount of the grant, decreases the account and records the expired transaction
+func ExpireCreditGrant(ctx context.Context, grantId string) error {
+	return dao.CreditTransaction.DB().Transaction(ctx, func(ctx context.Context, transaction gdb.TX) error {
+		var one *entity.CreditGrant
+		err := dao.CreditGrant.Ctx(ctx).Where(dao.CreditGrant.Columns().GrantId, grantId).Scan(&one)
+		if err != nil {
+			return err
+		}
+		if one == nil || one.Status != consts.CreditGrantActive || one.ExpireTime == 0 || one.ExpireTime > gtime.Now().Timestamp() {
+			return nil
+		}
+		creditAccount := credit_query.GetCreditAccountById(ctx, one.CreditId)
+		if creditAccount == nil {
+			return gerror.Newf("credit account not found, grantId:%s", grantId)
+		}
+		update, err := dao.CreditGrant.Ctx(ctx).Data(g.Map{
+			dao.CreditGrant.Columns().Status:          consts.CreditGrantExpired,
+			dao.CreditGrant.Columns().RemainingAmount: 0,
+			dao.CreditGrant.Columns().GmtModify:       gtime.Now(),
+		}).Where(dao.CreditGrant.Columns().Id, one.Id).
+			Where(dao.CreditGrant.Columns().Status, consts.CreditGrantActive).
+			Update()
+		if err != nil {
+			return err
+		}
+		affected, err := update.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected != 1 {
+			return gerror.Newf("expire credit grant failed, grantId:%s", grantId)
+		}
+		expireAmount := utility.MinInt64(one.RemainingAmount, creditAccount.Amount)
+		if expireAmount <= 0 {
+			return nil
+		}
+		var exchangeRate int64 = 0
+		creditConfig := credit_query.GetCreditConfig(ctx, creditAccount.MerchantId, creditAccount.Type, creditAccount.Currency)
+		if creditConfig != nil {
+			exchangeRate = creditConfig.ExchangeRate
+		}
+		trans := &entity.CreditTransaction{
+			UserId:             creditAccount.UserId,
+			CreditId:           creditAccount.Id,
+			Currency:           creditAccount.Currency,
+			TransactionId:      utility.CreateEventId(),
+			TransactionType:    consts.CreditTransactionExpired,
+			CreditAmountAfter:  creditAccount.Amount - expireAmount,
+			CreditAmountBefore: creditAccount.Amount,
+			DeltaAmount:        -expireAmount,
+			BizId:              one.GrantId,
+			Name:               "Credit Expired",
+			Description:        fmt.Sprintf("Credit Expired(%s)", one.Name),
+			CreateTime:         gtime.Now().Timestamp(),
+			MerchantId:         creditAccount.MerchantId,
+			AccountType:        creditAccount.Type,
+			ExchangeRate:       exchangeRate,
+			GrantDetail:        utility.MarshalToJsonString([]*bean.CreditGrantItem{{GrantId: one.GrantId, Amount: expireAmount, ExpireTime: one.ExpireTime}}),
+		}
+		_, err = dao.CreditTransaction.Ctx(ctx).Data(trans).OmitNil().Insert(trans)
+		if err != nil {
+			return err
+		}
+		update, err = dao.CreditAccount.Ctx(ctx).Where(dao.CreditAccount.Columns().Id, creditAccount.Id).Decrement(dao.CreditAccount.Columns().Amount, expireAmount)
+		if err != nil {
+			return err
+		}
+		affected, err = update.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected != 1 {
+			return gerror.New("update credit amount err")
+		}
+		return nil
+	})
+}
+
+// ExpireOverdueCreditGrants expires the overdue grants of account not yet handled by cronjob, called before consumption
+func ExpireOverdueCreditGrants(ctx context.Context, creditId uint64) {
+	var list []*entity.CreditGrant
+	err := dao.CreditGrant.Ctx(ctx).
+		Where(dao.CreditGrant.Columns().CreditId, creditId).
+		Where(dao.CreditGrant.Columns().Status, consts.CreditGrantActive).
+		WhereGT(dao.CreditGrant.Columns().ExpireTime, 0).
+		WhereLTE(dao.CreditGrant.Columns().ExpireTime, gtime.Now().Timestamp()).
+		Scan(&list)
+	if err != nil {
+		g.Log().Errorf(ctx, "ExpireOverdueCreditGrants creditId:%d error:%s", creditId, err.Error())
+		return
+	}
+	for _, one := range list {
+		err = ExpireCreditGrant(ctx, one.GrantId)
+		if err != nil {
+			g.Log().Errorf(ctx, "ExpireOverdueCreditGrants grantId:%s error:%s", one.GrantId, err.Error())
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package grant
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+)
+
+func TestAllocateCreditGrantConsumption(t *testing.T) {
+	var timeNow int64 = 1000
+	list := []*entity.CreditGrant{
+		{Id: 1, GrantId: "never", RemainingAmount: 100, ExpireTime: 0, Status: consts.CreditGrantActive},
+		{Id: 2, GrantId: "late", RemainingAmount: 50, ExpireTime: 3000, Status: consts.CreditGrantActive},
+		{Id: 3, GrantId: "soon", RemainingAmount: 30, ExpireTime: 2000, Status: consts.CreditGrantActive},
+		{Id: 4, GrantId: "expired", RemainingAmount: 80, ExpireTime: 900, Status: consts.CreditGrantActive},
+		{Id: 5, GrantId: "used", RemainingAmount: 0, ExpireTime: 1500, Status: consts.CreditGrantUsedUp},
+	}
+	t.Run("Test for Soonest Expiring First", func(t *testing.T) {
+		items := AllocateCreditGrantConsumption(list, 60, timeNow)
+		require.Equal(t, 2, len(items))
+		require.Equal(t, "soon", items[0].GrantId)
+		require.Equal(t, int64(30), items[0].Amount)
+		require.Equal(t, "late", items[1].GrantId)
+		require.Equal(t, int64(30), items[1].Amount)
+	})
+	t.Run("Test for Never Expire Last And Untracked Balance", func(t *testing.T) {
+		items := AllocateCreditGrantConsumption(list, 500, timeNow)
+		require.Equal(t, 3, len(items))
+		require.Equal(t, "never", items[2].GrantId)
+		require.Equal(t, int64(100), items[2].Amount)
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"unibee/internal/logic/credit/amount"
 	"unibee/internal/logic/credit/config"
 	"unibee/internal/logic/credit/credit_query"
+	"unibee/internal/logic/credit/grant"
 	"unibee/internal/logic/credit/refund"
 	currency2 "unibee/internal/logic/currency"
 	entity "unibee/internal/model/entity/default"
@@ -118,6 +119,8 @@ func NewCreditPayment(ctx context.Context, req *CreditPaymentInternalReq) (res *
 	utility.Assert(creditAccount != nil, "credit creditAccount failed")
 	utility.Assert(creditAccount.Type == req.CreditType, "invalid credit account type, should be main account")
 	utility.AssertError(config.CheckCreditConfigPayout(ctx, req.MerchantId, creditAccount.Type, req.Currency), "Credit Config Error")
+	grant.ExpireOverdueCreditGrants(ctx, creditAccount.Id)
+	creditAccount = credit_query.GetCreditAccountById(ctx, creditAccount.Id)
 	creditPaymentAmount, exchangeRate := amount.ConvertCurrencyAmountToCreditAmount(ctx, req.MerchantId, creditAccount.Type, req.Currency, req.CurrencyAmount)
 	if creditAccount.Amount < creditPaymentAmount {
 		return nil, gerror.New("credit amount is not enough")
@@ -170,6 +173,10 @@ func NewCreditPayment(ctx context.Context, req *CreditPaymentInternalReq) (res *
 			if _interface.Context() != nil && _interface.Context().Get(ctx) != nil && _interface.Context().Get(ctx).IsAdminPortalCall && _interface.Context().Get(ctx).MerchantMember != nil {
 				adminMemberId = _interface.Context().Get(ctx).MerchantMember.Id
 			}
+			grantDetail, err := grant.ConsumeCreditGrants(ctx, creditAccount.Id, one.TotalAmount)
+			if err != nil {
+				return err
+			}
 			trans := &entity.CreditTransaction{
 				UserId:             one.UserId,
 				CreditId:           one.CreditId,
@@ -188,6 +195,7 @@ func NewCreditPayment(ctx context.Context, req *CreditPaymentInternalReq) (res *
 				ExchangeRate:       exchangeRate,
 				AccountType:        creditAccount.Type,
 				AdminMemberId:      adminMemberId,
+				GrantDetail:        grantDetail,
 			}
 			_, err = dao.CreditTransaction.Ctx(ctx).Data(trans).OmitNil().Insert(trans)
 			if err != nil {
@@ -12,6 +12,7 @@ import (
 	"unibee/internal/consts"
 	dao "unibee/internal/dao/default"
 	"unibee/internal/logic/credit/account"
+	"unibee/internal/logic/credit/grant"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
 	"unibee/utility"
@@ -39,6 +40,17 @@ func HandleCreditRechargeSuccess(ctx context.Context, creditRechargeId string, i
 				return err
 			}
 			creditAccount = query.GetCreditAccountById(ctx, creditAccount.Id)
+			creditGrant, err := grant.NewCreditGrant(ctx, &grant.NewCreditGrantInternalReq{
+				CreditAccount: creditAccount,
+				Source:        consts.CreditGrantSourceRecharge,
+				BizId:         one.RechargeId,
+				Amount:        one.TotalAmount,
+				Name:          one.Name,
+				Description:   one.Description,
+			})
+			if err != nil {
+				return err
+			}
 			trans := &entity.CreditTransaction{
 				UserId:             one.UserId,
 				CreditId:           one.CreditId,
@@ -56,6 +68,7 @@ func HandleCreditRechargeSuccess(ctx context.Context, creditRechargeId string, i
 				MerchantId:         one.MerchantId,
 				ExchangeRate:       creditConfig.ExchangeRate,
 				AccountType:        creditAccount.Type,
+				GrantDetail:        grant.GrantDetailOf(creditGrant),
 			}
 			_, err = dao.CreditTransaction.Ctx(ctx).Data(trans).OmitNil().Insert(trans)
 			if err != nil {
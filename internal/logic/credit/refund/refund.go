@@ -13,6 +13,7 @@ import (
 	dao "unibee/internal/dao/default"
 	"unibee/internal/logic/credit/account"
 	"unibee/internal/logic/credit/credit_query"
+	"unibee/internal/logic/credit/grant"
 	entity "unibee/internal/model/entity/default"
 	"unibee/utility"
 )
@@ -101,6 +102,18 @@ func NewCreditRefund(ctx context.Context, req *CreditRefundInternalReq) (res *Cr
 				return gerror.New("no enough amount can refund")
 			}
 
+			creditGrant, err := grant.NewCreditGrant(ctx, &grant.NewCreditGrantInternalReq{
+				CreditAccount: creditAccount,
+				Source:        consts.CreditGrantSourceRefund,
+				BizId:         one.CreditRefundId,
+				Amount:        one.RefundAmount,
+				ExpireTime:    grant.RefundGrantExpireTime(ctx, payment.CreditPaymentId),
+				Name:          one.Name,
+				Description:   one.Description,
+			})
+			if err != nil {
+				return err
+			}
 			trans := &entity.CreditTransaction{
 				UserId:             one.UserId,
 				CreditId:           one.CreditId,
@@ -118,6 +131,7 @@ func NewCreditRefund(ctx context.Context, req *CreditRefundInternalReq) (res *Cr
 				MerchantId:         one.MerchantId,
 				ExchangeRate:       payment.ExchangeRate,
 				AccountType:        creditAccount.Type,
+				GrantDetail:        grant.GrantDetailOf(creditGrant),
 			}
 			_, err = dao.CreditTransaction.Ctx(ctx).Data(trans).OmitNil().Insert(trans)
 			if err != nil {
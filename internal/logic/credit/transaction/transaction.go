@@ -17,7 +17,7 @@ type CreditTransactionListInternalReq struct {
 	Email            string `json:"email"  description:"filter email of user"`
 	SortField        string `json:"sortField" dc:"Sort Field，gmt_create|gmt_modify，Default gmt_modify" `
 	SortType         string `json:"sortType" dc:"Sort Type，asc|desc，Default desc" `
-	TransactionTypes []int  `json:"transactionTypes" dc:"transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out" `
+	TransactionTypes []int  `json:"transactionTypes" dc:"transaction type。1-recharge income，2-payment out，3-refund income，4-withdraw out，5-withdraw failed income, 6-admin change，7-recharge refund out，8-expired" `
 	Page             int    `json:"page"  dc:"Page, Start 0" `
 	Count            int    `json:"count"  dc:"Count Of Per Page" `
 	CreateTimeStart  int64  `json:"createTimeStart" dc:"CreateTimeStart，UTC timestamp，seconds" `
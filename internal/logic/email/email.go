@@ -53,11 +53,12 @@ const (
 	TemplateInvoiceRefundCreated                            = "InvoiceRefundCreated"
 	TemplateInvoiceRefundPaid                               = "InvoiceRefundPaid"
 	TemplateMerchantMemberInvite                            = "MerchantMemberInvite"
+	TemplateSubscriptionPriceChange                         = "SubscriptionPriceChange"
 )
 
 const (
 	KeyMerchantEmailName   = "KEY_MERCHANT_DEFAULT_EMAIL_NAME"
-	IMPLEMENT_NAMES        = "sendgrid"
+	IMPLEMENT_NAMES        = "sendgrid|smtp|mailgun|file"
 	KeyMerchantEmailSender = "KEY_MERCHANT_EMAIL_SENDER"
 )
 
@@ -83,6 +84,7 @@ func GetDefaultMerchantEmailConfigWithClusterCloud(ctx context.Context, merchant
 		data = valueConfig.ConfigValue
 	}
 	if config.GetConfigInstance().Mode == "cloud" && len(data) == 0 {
+		name = gateway.GatewaySendgrid
 		data, _ = getDefaultMerchantEmailConfigFromClusterCloud(ctx, merchantId)
 	}
 	return
@@ -155,8 +157,12 @@ func SetupMerchantEmailSender(ctx context.Context, merchantId uint64, sender *se
 }
 
 func SetupMerchantEmailConfig(ctx context.Context, merchantId uint64, name string, data string, isDefault bool) error {
-	utility.Assert(strings.Contains(IMPLEMENT_NAMES, name), "gateway not support, should be "+IMPLEMENT_NAMES)
-	err := update.SetMerchantConfig(ctx, merchantId, name, data)
+	utility.Assert(len(name) > 0 && strings.Contains(IMPLEMENT_NAMES, name), "gateway not support, should be "+IMPLEMENT_NAMES)
+	_, err := gateway.GetEmailProvider(name, data)
+	if err != nil {
+		return err
+	}
+	err = update.SetMerchantConfig(ctx, merchantId, name, data)
 	if err != nil {
 		return err
 	}
@@ -234,7 +240,7 @@ func getEmailTemplateGroupVariables() []*bean.TemplateVariableGroup {
 
 func SendTemplateEmailByOpenApi(ctx context.Context, merchantId uint64, mailTo string, timezone string, language string, templateName string, pdfFilePath string, templateVariables *bean.EmailTemplateVariable, languageData *[]*bean.EmailLocalizationTemplate) (err error) {
 	mailTo = strings.ToLower(mailTo)
-	_, emailGatewayKey := GetDefaultMerchantEmailConfigWithClusterCloud(ctx, merchantId)
+	emailGatewayName, emailGatewayKey := GetDefaultMerchantEmailConfigWithClusterCloud(ctx, merchantId)
 	if len(emailGatewayKey) == 0 {
 		if strings.Compare(templateName, TemplateUserOTPLogin) == 0 || strings.Compare(templateName, TemplateUserRegistrationCodeVerify) == 0 {
 			utility.Assert(false, "Default Email Gateway Need Setup")
@@ -303,6 +309,7 @@ func SendTemplateEmailByOpenApi(ctx context.Context, merchantId uint64, mailTo s
 		Content:           content,
 		LocalFilePath:     pdfFilePath,
 		AttachName:        attachName + ".pdf",
+		GatewayName:       emailGatewayName,
 		APIKey:            emailGatewayKey,
 		VariableMap:       variableMap,
 		Language:          language,
@@ -313,7 +320,7 @@ func SendTemplateEmailByOpenApi(ctx context.Context, merchantId uint64, mailTo s
 // SendTemplateEmail template should convert by html tools like https://www.iamwawa.cn/text2html.html
 func SendTemplateEmail(superCtx context.Context, merchantId uint64, mailTo string, timezone string, language string, templateName string, pdfFilePath string, templateVariables *bean.EmailTemplateVariable) error {
 	mailTo = strings.ToLower(mailTo)
-	_, emailGatewayKey := GetDefaultMerchantEmailConfigWithClusterCloud(superCtx, merchantId)
+	emailGatewayName, emailGatewayKey := GetDefaultMerchantEmailConfigWithClusterCloud(superCtx, merchantId)
 	if len(emailGatewayKey) == 0 {
 		if strings.Compare(templateName, TemplateUserOTPLogin) == 0 || strings.Compare(templateName, TemplateUserRegistrationCodeVerify) == 0 {
 			utility.Assert(false, "Default Email Gateway Need Setup")
@@ -335,13 +342,13 @@ func SendTemplateEmail(superCtx context.Context, merchantId uint64, mailTo strin
 				return
 			}
 		}()
-		err = sendTemplateEmailInternal(backgroundCtx, merchantId, mailTo, timezone, language, templateName, pdfFilePath, templateVariables, emailGatewayKey)
+		err = sendTemplateEmailInternal(backgroundCtx, merchantId, mailTo, timezone, language, templateName, pdfFilePath, templateVariables, emailGatewayName, emailGatewayKey)
 		utility.AssertError(err, "sendTemplateEmailInternal")
 	}()
 	return nil
 }
 
-func sendTemplateEmailInternal(ctx context.Context, merchantId uint64, mailTo string, timezone string, language string, templateName string, pdfFilePath string, templateVariables *bean.EmailTemplateVariable, emailGatewayKey string) error {
+func sendTemplateEmailInternal(ctx context.Context, merchantId uint64, mailTo string, timezone string, language string, templateName string, pdfFilePath string, templateVariables *bean.EmailTemplateVariable, emailGatewayName string, emailGatewayKey string) error {
 	mailTo = strings.ToLower(mailTo)
 	var template *bean.MerchantEmailTemplate
 	if merchantId > 0 {
@@ -405,6 +412,7 @@ func sendTemplateEmailInternal(ctx context.Context, merchantId uint64, mailTo st
 		Content:           content,
 		LocalFilePath:     pdfFilePath,
 		AttachName:        attachName + ".pdf",
+		GatewayName:       emailGatewayName,
 		APIKey:            emailGatewayKey,
 		VariableMap:       variableMap,
 		Language:          language,
@@ -419,71 +427,68 @@ type SendgridEmailReq struct {
 	Content           string                 `json:"content"`
 	LocalFilePath     string                 `json:"localFilePath"`
 	AttachName        string                 `json:"attachName"`
-	APIKey            string                 `json:"apiKey"`
+	GatewayName       string                 `json:"gatewayName"`
+	APIKey            string                 `json:"apiKey"` // setup data of gateway, the api key of sendgrid, default gateway of merchant used if empty
 	VariableMap       map[string]interface{} `json:"variable_map"`
 	Language          string                 `json:"language"`
 	GatewayTemplateId string                 `json:"gatewayTemplateId"`
 }
 
 func Send(ctx context.Context, req *SendgridEmailReq) error {
-	var err error
-	var response string
+	if len(req.APIKey) == 0 {
+		req.GatewayName, req.APIKey = GetDefaultMerchantEmailConfigWithClusterCloud(ctx, req.MerchantId)
+	}
+	var attachName = ""
 	if len(req.LocalFilePath) > 0 {
-		md5 := utility.MD5(fmt.Sprintf("%s%s%s%s", req.MailTo, req.Subject, req.Content, req.AttachName))
-		if !utility.TryLock(ctx, md5, 10) {
-			utility.Assert(false, "duplicate email too fast")
-		}
-		if len(req.GatewayTemplateId) > 0 {
+		attachName = req.AttachName
+	}
+	md5 := utility.MD5(fmt.Sprintf("%s%s%s%s", req.MailTo, req.Subject, req.Content, attachName))
+	if !utility.TryLock(ctx, md5, 10) {
+		utility.Assert(false, "duplicate email too fast")
+	}
+	provider, err := gateway.GetEmailProvider(req.GatewayName, req.APIKey)
+	if err != nil {
+		SaveHistory(ctx, req.MerchantId, req.MailTo, req.Subject, req.Content, attachName, req.GatewayName, err.Error(), false)
+		return err
+	}
+	var response string
+	var historyContent = req.Content
+	if provider.GatewayName() == gateway.GatewaySendgrid && len(req.GatewayTemplateId) > 0 {
+		// template synced to sendgrid, rendered by sendgrid dynamic template
+		if len(req.LocalFilePath) > 0 {
 			response, err = gateway.SendSendgridDynamicTemplateWithAttachFileEmailToUser(GetMerchantEmailSender(ctx, req.MerchantId), req.APIKey, req.MailTo, req.Subject, req.GatewayTemplateId, req.VariableMap, req.Language, req.LocalFilePath, req.AttachName)
 		} else {
-			response, err = gateway.SendPdfAttachEmailToUser(GetMerchantEmailSender(ctx, req.MerchantId), req.APIKey, req.MailTo, req.Subject, req.Content, req.LocalFilePath, req.AttachName)
-		}
-		if err != nil {
-			if len(req.GatewayTemplateId) > 0 {
-				req.VariableMap["TemplateId"] = req.GatewayTemplateId
-				SaveHistory(ctx, req.MerchantId, req.MailTo, req.Subject, utility.MarshalToJsonString(req.VariableMap), req.AttachName, err.Error())
-			} else {
-				SaveHistory(ctx, req.MerchantId, req.MailTo, req.Subject, req.Content, req.AttachName, err.Error())
-			}
-		} else {
-			if len(req.GatewayTemplateId) > 0 {
-				req.VariableMap["TemplateId"] = req.GatewayTemplateId
-				SaveHistory(ctx, req.MerchantId, req.MailTo, req.Subject, utility.MarshalToJsonString(req.VariableMap), req.AttachName, response)
-			} else {
-				SaveHistory(ctx, req.MerchantId, req.MailTo, req.Subject, req.Content, req.AttachName, response)
-			}
+			response, err = gateway.SendSendgridDynamicTemplateEmailToUser(GetMerchantEmailSender(ctx, req.MerchantId), req.APIKey, req.MailTo, req.Subject, req.GatewayTemplateId, req.VariableMap, req.Language)
 		}
-		return err
+		req.VariableMap["TemplateId"] = req.GatewayTemplateId
+		historyContent = utility.MarshalToJsonString(req.VariableMap)
 	} else {
-		md5 := utility.MD5(fmt.Sprintf("%s%s%s", req.MailTo, req.Subject, req.Content))
-		if !utility.TryLock(ctx, md5, 10) {
-			utility.Assert(false, "duplicate email too fast")
+		message := &gateway.EmailMessage{
+			From:         GetMerchantEmailSender(ctx, req.MerchantId),
+			To:           req.MailTo,
+			Subject:      req.Subject,
+			HtmlContent:  "<div>" + req.Content + " </div>",
+			PlainContent: gateway.ConvertUniBeeTemplateToPlain(req.Content),
 		}
-		if len(req.GatewayTemplateId) > 0 {
-			response, err = gateway.SendSendgridDynamicTemplateEmailToUser(GetMerchantEmailSender(ctx, req.MerchantId), req.APIKey, req.MailTo, req.Subject, req.GatewayTemplateId, req.VariableMap, req.Language)
-		} else {
-			response, err = gateway.SendEmailToUser(GetMerchantEmailSender(ctx, req.MerchantId), req.APIKey, req.MailTo, req.Subject, req.Content)
-		}
-		if err != nil {
-			if len(req.GatewayTemplateId) > 0 {
-				req.VariableMap["TemplateId"] = req.GatewayTemplateId
-				SaveHistory(ctx, req.MerchantId, req.MailTo, req.Subject, utility.MarshalToJsonString(req.VariableMap), "", err.Error())
-			} else {
-				SaveHistory(ctx, req.MerchantId, req.MailTo, req.Subject, req.Content, "", err.Error())
-			}
-		} else {
-			if len(req.GatewayTemplateId) > 0 {
-				req.VariableMap["TemplateId"] = req.GatewayTemplateId
-				SaveHistory(ctx, req.MerchantId, req.MailTo, req.Subject, utility.MarshalToJsonString(req.VariableMap), "", response)
+		if len(req.LocalFilePath) > 0 {
+			attachment, attachErr := gateway.NewPdfAttachment(req.LocalFilePath, req.AttachName)
+			if attachErr != nil {
+				g.Log().Errorf(ctx, "Send email attachment error:%s", attachErr.Error())
 			} else {
-				SaveHistory(ctx, req.MerchantId, req.MailTo, req.Subject, req.Content, "", response)
+				message.Attachments = append(message.Attachments, attachment)
 			}
 		}
-		return err
+		response, err = provider.Send(ctx, message)
+	}
+	if err != nil {
+		SaveHistory(ctx, req.MerchantId, req.MailTo, req.Subject, historyContent, attachName, provider.GatewayName(), err.Error(), false)
+	} else {
+		SaveHistory(ctx, req.MerchantId, req.MailTo, req.Subject, historyContent, attachName, provider.GatewayName(), response, true)
 	}
+	return err
 }
 
-func SaveHistory(ctx context.Context, merchantId uint64, mailTo string, title string, content string, attachFilePath string, response string) {
+func SaveHistory(ctx context.Context, merchantId uint64, mailTo string, title string, content string, attachFilePath string, gatewayName string, response string, success bool) {
 	var err error
 	defer func() {
 		if exception := recover(); exception != nil {
@@ -496,21 +501,20 @@ func SaveHistory(ctx context.Context, merchantId uint64, mailTo string, title st
 			return
 		}
 	}()
-	status := 0
-	if strings.Contains(response, "202") {
+	status := 2
+	if success {
 		status = 1
-	} else {
-		status = 2
 	}
 	one := &entity.MerchantEmailHistory{
-		MerchantId: merchantId,
-		Email:      mailTo,
-		Title:      title,
-		Content:    content,
-		AttachFile: attachFilePath,
-		Response:   response,
-		Status:     status,
-		CreateTime: gtime.Now().Timestamp(),
+		MerchantId:  merchantId,
+		Email:       mailTo,
+		Title:       title,
+		Content:     content,
+		AttachFile:  attachFilePath,
+		Response:    response,
+		Status:      status,
+		GatewayName: gatewayName,
+		CreateTime:  gtime.Now().Timestamp(),
 	}
 	_, _ = dao.MerchantEmailHistory.Ctx(ctx).Data(one).OmitNil().Insert(one)
 }
@@ -0,0 +1,51 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+	"unibee/internal/cmd/config"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// FileOutboxDirectory is the local directory the .eml files written to, fixed on server side, never taken from the setup data of merchant
+const FileOutboxDirectory = "./resource/email_outbox"
+
+// FileProvider writes emails as .eml files to local directory instead of delivering, for testing outside prod only
+type FileProvider struct {
+	Directory string
+}
+
+func NewFileProvider(data string) (*FileProvider, error) {
+	if config.GetConfigInstance().IsProd() {
+		return nil, gerror.New("file email gateway not available in prod")
+	}
+	return &FileProvider{Directory: FileOutboxDirectory}, nil
+}
+
+func (f *FileProvider) GatewayName() string {
+	return GatewayFile
+}
+
+func (f *FileProvider) Send(ctx context.Context, message *EmailMessage) (string, error) {
+	if config.GetConfigInstance().IsProd() {
+		return "", gerror.New("file email gateway not available in prod")
+	}
+	data, err := BuildMimeMessage(message)
+	if err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(f.Directory, 0755); err != nil {
+		return "", gerror.Newf("create email directory error:%s", err.Error())
+	}
+	fileName := fmt.Sprintf("%s_%s.eml", time.Now().UTC().Format("20060102150405"), utility.MD5(fmt.Sprintf("%s%s%d", message.To, message.Subject, time.Now().UnixNano()))[:8])
+	path := filepath.Join(f.Directory, fileName)
+	if err = os.WriteFile(path, data, 0644); err != nil {
+		return "", gerror.Newf("write email file error:%s", err.Error())
+	}
+	return utility.MarshalToJsonString(map[string]interface{}{"file": path}), nil
+}
@@ -0,0 +1,99 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+type MailgunConfig struct {
+	Domain  string `json:"domain"  description:"sending domain"`
+	ApiKey  string `json:"apiKey"  description:"private api key"`
+	BaseUrl string `json:"baseUrl" description:"api base url, default https://api.mailgun.net, https://api.eu.mailgun.net for eu region"`
+}
+
+// MailgunProvider sends by the mailgun style http api, POST {baseUrl}/v3/{domain}/messages with basic auth api:{apiKey}
+type MailgunProvider struct {
+	Config *MailgunConfig
+}
+
+func NewMailgunProvider(data string) (*MailgunProvider, error) {
+	var config *MailgunConfig
+	err := utility.UnmarshalFromJsonString(data, &config)
+	if err != nil || config == nil {
+		return nil, gerror.New("invalid mailgun setup data, should be json like {\"domain\":\"\",\"apiKey\":\"\",\"baseUrl\":\"https://api.mailgun.net\"}")
+	}
+	if len(config.Domain) == 0 || len(config.ApiKey) == 0 {
+		return nil, gerror.New("invalid mailgun setup data, domain and apiKey are required")
+	}
+	if len(config.BaseUrl) == 0 {
+		config.BaseUrl = "https://api.mailgun.net"
+	}
+	config.BaseUrl = strings.TrimSuffix(config.BaseUrl, "/")
+	return &MailgunProvider{Config: config}, nil
+}
+
+func (m *MailgunProvider) GatewayName() string {
+	return GatewayMailgun
+}
+
+func (m *MailgunProvider) Send(ctx context.Context, message *EmailMessage) (string, error) {
+	f := messageFrom(message)
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	fields := [][2]string{
+		{"from", (&mailAddress{Name: f.Name, Address: f.Address}).String()},
+		{"to", message.To},
+		{"subject", message.Subject},
+		{"text", message.PlainContent},
+		{"html", message.HtmlContent},
+	}
+	for _, field := range fields {
+		if len(field[1]) == 0 {
+			continue
+		}
+		if err := writer.WriteField(field[0], field[1]); err != nil {
+			return "", err
+		}
+	}
+	for _, one := range message.Attachments {
+		w, err := writer.CreateFormFile("attachment", one.FileName)
+		if err != nil {
+			return "", err
+		}
+		if _, err = w.Write(one.Content); err != nil {
+			return "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/v3/%s/messages", m.Config.BaseUrl, m.Config.Domain), &body)
+	if err != nil {
+		return "", err
+	}
+	request.SetBasicAuth("api", m.Config.ApiKey)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	client := &http.Client{Timeout: 30 * time.Second}
+	response, err := client.Do(request)
+	if err != nil {
+		return "", gerror.Newf("mailgun request error:%s", err.Error())
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	responseBody, _ := io.ReadAll(response.Body)
+	result := utility.MarshalToJsonString(map[string]interface{}{"StatusCode": response.StatusCode, "Body": string(responseBody)})
+	if response.StatusCode >= 300 {
+		return result, gerror.Newf("mailgun response status:%d body:%s", response.StatusCode, string(responseBody))
+	}
+	return result, nil
+}
@@ -0,0 +1,99 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"time"
+)
+
+// BuildMimeMessage encodes the message as rfc 5322 email, html and plain content as alternatives with attachments mixed
+func BuildMimeMessage(message *EmailMessage) ([]byte, error) {
+	f := messageFrom(message)
+	var buf bytes.Buffer
+	mixed := multipart.NewWriter(&buf)
+	header := make(map[string]string)
+	header["From"] = (&mailAddress{Name: f.Name, Address: f.Address}).String()
+	header["To"] = message.To
+	header["Subject"] = mime.QEncoding.Encode("utf-8", message.Subject)
+	header["Date"] = time.Now().UTC().Format(time.RFC1123Z)
+	header["MIME-Version"] = "1.0"
+	header["Content-Type"] = fmt.Sprintf("multipart/mixed; boundary=%s", mixed.Boundary())
+	var head bytes.Buffer
+	for _, key := range []string{"From", "To", "Subject", "Date", "MIME-Version", "Content-Type"} {
+		head.WriteString(fmt.Sprintf("%s: %s\r\n", key, header[key]))
+	}
+	head.WriteString("\r\n")
+
+	alternativeBoundary := multipart.NewWriter(nil).Boundary()
+	part, err := mixed.CreatePart(textproto.MIMEHeader{"Content-Type": {fmt.Sprintf("multipart/alternative; boundary=%s", alternativeBoundary)}})
+	if err != nil {
+		return nil, err
+	}
+	alternative := multipart.NewWriter(part)
+	if err = alternative.SetBoundary(alternativeBoundary); err != nil {
+		return nil, err
+	}
+	for _, one := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", message.PlainContent},
+		{"text/html; charset=utf-8", message.HtmlContent},
+	} {
+		if len(one.content) == 0 {
+			continue
+		}
+		w, err := alternative.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {one.contentType},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		writeBase64Lines(w, []byte(one.content))
+	}
+	if err = alternative.Close(); err != nil {
+		return nil, err
+	}
+	for _, one := range message.Attachments {
+		w, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {fmt.Sprintf("%s; name=%q", one.ContentType, one.FileName)},
+			"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", one.FileName)},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		writeBase64Lines(w, one.Content)
+	}
+	if err = mixed.Close(); err != nil {
+		return nil, err
+	}
+	return append(head.Bytes(), buf.Bytes()...), nil
+}
+
+type mailAddress struct {
+	Name    string
+	Address string
+}
+
+func (a *mailAddress) String() string {
+	if len(a.Name) == 0 {
+		return fmt.Sprintf("<%s>", a.Address)
+	}
+	return fmt.Sprintf("%s <%s>", mime.QEncoding.Encode("utf-8", a.Name), a.Address)
+}
+
+func writeBase64Lines(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		_, _ = w.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	_, _ = w.Write([]byte(encoded + "\r\n"))
+}
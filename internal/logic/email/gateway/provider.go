@@ -0,0 +1,78 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"unibee/internal/logic/email/sender"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	GatewaySendgrid = "sendgrid"
+	GatewaySmtp     = "smtp"
+	GatewayMailgun  = "mailgun"
+	GatewayFile     = "file"
+)
+
+type EmailAttachment struct {
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	Content     []byte `json:"-"`
+}
+
+type EmailMessage struct {
+	From         *sender.Sender     `json:"from"`
+	To           string             `json:"to"`
+	Subject      string             `json:"subject"`
+	HtmlContent  string             `json:"htmlContent"`
+	PlainContent string             `json:"plainContent"`
+	Attachments  []*EmailAttachment `json:"attachments"`
+}
+
+// EmailProvider delivers the email rendered locally, the response is recorded in email history
+type EmailProvider interface {
+	GatewayName() string
+	Send(ctx context.Context, message *EmailMessage) (response string, err error)
+}
+
+// GetEmailProvider returns the provider of gateway, data is the api key of sendgrid or the json setup of other gateways
+func GetEmailProvider(gatewayName string, data string) (EmailProvider, error) {
+	if len(data) == 0 {
+		return nil, gerror.New("Default Email Gateway Need Setup")
+	}
+	switch strings.ToLower(gatewayName) {
+	case "", GatewaySendgrid:
+		return &SendgridProvider{ApiKey: data}, nil
+	case GatewaySmtp:
+		return NewSmtpProvider(data)
+	case GatewayMailgun:
+		return NewMailgunProvider(data)
+	case GatewayFile:
+		return NewFileProvider(data)
+	default:
+		return nil, gerror.Newf("email gateway not support:%s", gatewayName)
+	}
+}
+
+// NewPdfAttachment reads the local pdf file as attachment
+func NewPdfAttachment(filePath string, fileName string) (*EmailAttachment, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, gerror.New(fmt.Sprintf("read attachment error:%s", err.Error()))
+	}
+	return &EmailAttachment{
+		FileName:    fileName,
+		ContentType: "application/pdf",
+		Content:     data,
+	}, nil
+}
+
+func messageFrom(message *EmailMessage) *sender.Sender {
+	if message.From == nil || len(message.From.Address) == 0 {
+		return sender.GetDefaultSender()
+	}
+	return message.From
+}
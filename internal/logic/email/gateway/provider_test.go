@@ -0,0 +1,49 @@
+package gateway
+
+import (
+	"strings"
+	"testing"
+	"unibee/internal/cmd/config"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailProvider(t *testing.T) {
+	t.Run("Test for provider setup data", func(t *testing.T) {
+		provider, err := GetEmailProvider("", "SG.key")
+		require.Nil(t, err)
+		require.Equal(t, GatewaySendgrid, provider.GatewayName())
+		_, err = GetEmailProvider(GatewaySmtp, "{}")
+		require.NotNil(t, err)
+		provider, err = GetEmailProvider(GatewaySmtp, `{"host":"smtp.example.com","security":"tls"}`)
+		require.Nil(t, err)
+		require.Equal(t, 465, provider.(*SmtpProvider).Config.Port)
+		_, err = GetEmailProvider(GatewayMailgun, `{"domain":"mg.example.com"}`)
+		require.NotNil(t, err)
+		provider, err = GetEmailProvider(GatewayFile, "/tmp/outbox")
+		require.Nil(t, err)
+		require.Equal(t, FileOutboxDirectory, provider.(*FileProvider).Directory)
+		config.GetConfigInstance().Env = "prod"
+		_, err = GetEmailProvider(GatewayFile, "/tmp/outbox")
+		config.GetConfigInstance().Env = ""
+		require.NotNil(t, err)
+		_, err = GetEmailProvider("unknown", "data")
+		require.NotNil(t, err)
+	})
+	t.Run("Test for mime message", func(t *testing.T) {
+		data, err := BuildMimeMessage(&EmailMessage{
+			To:           "user@example.com",
+			Subject:      "Invoice",
+			HtmlContent:  "<div>Hello</div>",
+			PlainContent: "Hello",
+			Attachments:  []*EmailAttachment{{FileName: "invoice.pdf", ContentType: "application/pdf", Content: []byte("pdf")}},
+		})
+		require.Nil(t, err)
+		message := string(data)
+		require.True(t, strings.Contains(message, "To: user@example.com\r\n"))
+		require.True(t, strings.Contains(message, "multipart/alternative"))
+		require.True(t, strings.Contains(message, "text/plain; charset=utf-8"))
+		require.True(t, strings.Contains(message, "text/html; charset=utf-8"))
+		require.True(t, strings.Contains(message, `filename="invoice.pdf"`))
+	})
+}
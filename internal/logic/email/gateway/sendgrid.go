@@ -0,0 +1,45 @@
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/sendgrid/sendgrid-go"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+type SendgridProvider struct {
+	ApiKey string
+}
+
+func (s *SendgridProvider) GatewayName() string {
+	return GatewaySendgrid
+}
+
+func (s *SendgridProvider) Send(ctx context.Context, message *EmailMessage) (string, error) {
+	f := messageFrom(message)
+	from := mail.NewEmail(f.Name, f.Address)
+	to := mail.NewEmail(message.To, message.To)
+	mailMessage := mail.NewSingleEmail(from, message.Subject, to, message.PlainContent, message.HtmlContent)
+	for _, one := range message.Attachments {
+		attach := mail.NewAttachment()
+		attach.SetContent(base64.StdEncoding.EncodeToString(one.Content))
+		attach.SetType(one.ContentType)
+		attach.SetFilename(one.FileName)
+		attach.SetDisposition("attachment")
+		mailMessage.AddAttachment(attach)
+	}
+	client := sendgrid.NewSendClient(s.ApiKey)
+	response, err := client.Send(mailMessage)
+	if err != nil {
+		g.Log().Errorf(ctx, "SendgridProvider Send error:%s", err.Error())
+		return "", err
+	}
+	if response.StatusCode >= 300 {
+		return utility.MarshalToJsonString(response), gerror.Newf("sendgrid response status:%d body:%s", response.StatusCode, response.Body)
+	}
+	return utility.MarshalToJsonString(response), nil
+}
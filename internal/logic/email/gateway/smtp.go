@@ -0,0 +1,127 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	SmtpSecurityStartTls = "starttls"
+	SmtpSecurityTls      = "tls"
+	SmtpSecurityNone     = "none"
+)
+
+type SmtpConfig struct {
+	Host     string `json:"host"     description:"smtp server host"`
+	Port     int    `json:"port"     description:"smtp server port, default 587 for starttls, 465 for tls, 25 for none"`
+	Username string `json:"username" description:"username of smtp auth, auth skipped if empty"`
+	Password string `json:"password" description:"password of smtp auth"`
+	Security string `json:"security" description:"starttls|tls|none, default starttls"`
+}
+
+type SmtpProvider struct {
+	Config *SmtpConfig
+}
+
+func NewSmtpProvider(data string) (*SmtpProvider, error) {
+	var config *SmtpConfig
+	err := utility.UnmarshalFromJsonString(data, &config)
+	if err != nil || config == nil {
+		return nil, gerror.New("invalid smtp setup data, should be json like {\"host\":\"\",\"port\":587,\"username\":\"\",\"password\":\"\",\"security\":\"starttls\"}")
+	}
+	if len(config.Host) == 0 {
+		return nil, gerror.New("invalid smtp setup data, host is required")
+	}
+	config.Security = strings.ToLower(config.Security)
+	if len(config.Security) == 0 {
+		config.Security = SmtpSecurityStartTls
+	}
+	if config.Security != SmtpSecurityStartTls && config.Security != SmtpSecurityTls && config.Security != SmtpSecurityNone {
+		return nil, gerror.New("invalid smtp security, should be starttls|tls|none")
+	}
+	if config.Port <= 0 {
+		switch config.Security {
+		case SmtpSecurityTls:
+			config.Port = 465
+		case SmtpSecurityNone:
+			config.Port = 25
+		default:
+			config.Port = 587
+		}
+	}
+	return &SmtpProvider{Config: config}, nil
+}
+
+func (s *SmtpProvider) GatewayName() string {
+	return GatewaySmtp
+}
+
+func (s *SmtpProvider) Send(ctx context.Context, message *EmailMessage) (string, error) {
+	data, err := BuildMimeMessage(message)
+	if err != nil {
+		return "", err
+	}
+	address := net.JoinHostPort(s.Config.Host, fmt.Sprintf("%d", s.Config.Port))
+	tlsConfig := &tls.Config{ServerName: s.Config.Host}
+	var conn net.Conn
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	if s.Config.Security == SmtpSecurityTls {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return "", gerror.Newf("smtp connect error:%s", err.Error())
+	}
+	client, err := smtp.NewClient(conn, s.Config.Host)
+	if err != nil {
+		_ = conn.Close()
+		return "", gerror.Newf("smtp client error:%s", err.Error())
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	if s.Config.Security == SmtpSecurityStartTls {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return "", gerror.New("smtp server not support STARTTLS")
+		}
+		if err = client.StartTLS(tlsConfig); err != nil {
+			return "", gerror.Newf("smtp starttls error:%s", err.Error())
+		}
+	}
+	if len(s.Config.Username) > 0 {
+		// the credential configured never skipped, the server not support auth may be a spoofed one
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return "", gerror.New("smtp server not support AUTH")
+		}
+		if err = client.Auth(smtp.PlainAuth("", s.Config.Username, s.Config.Password, s.Config.Host)); err != nil {
+			return "", gerror.Newf("smtp auth error:%s", err.Error())
+		}
+	}
+	if err = client.Mail(messageFrom(message).Address); err != nil {
+		return "", gerror.Newf("smtp mail from error:%s", err.Error())
+	}
+	if err = client.Rcpt(message.To); err != nil {
+		return "", gerror.Newf("smtp rcpt to error:%s", err.Error())
+	}
+	w, err := client.Data()
+	if err != nil {
+		return "", gerror.Newf("smtp data error:%s", err.Error())
+	}
+	if _, err = w.Write(data); err != nil {
+		return "", gerror.Newf("smtp write error:%s", err.Error())
+	}
+	if err = w.Close(); err != nil {
+		return "", gerror.Newf("smtp data close error:%s", err.Error())
+	}
+	_ = client.Quit()
+	return utility.MarshalToJsonString(map[string]interface{}{"host": s.Config.Host, "port": s.Config.Port, "status": "accepted"}), nil
+}
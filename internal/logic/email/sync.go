@@ -21,10 +21,13 @@ func SyncMerchantEmailTemplateToGateway(ctx context.Context, id int64, versionEn
 	if err != nil {
 		return err
 	}
-	_, emailGatewayKey := GetDefaultMerchantEmailConfig(ctx, one.MerchantId)
+	emailGatewayName, emailGatewayKey := GetDefaultMerchantEmailConfig(ctx, one.MerchantId)
 	if len(emailGatewayKey) == 0 {
 		return gerror.New("Default Email Gateway Need Setup")
 	}
+	if len(emailGatewayName) > 0 && emailGatewayName != gateway.GatewaySendgrid {
+		return gerror.Newf("Template sync not support by email gateway %s, templates rendered by UniBee instead", emailGatewayName)
+	}
 	content := one.TemplateContent
 	if len(one.LanguageData) == 0 {
 		content = gateway.ConvertUniBeeTemplateToPlain(content)
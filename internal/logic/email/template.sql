@@ -22,7 +22,8 @@ VALUES
     ('3303', 'NewProcessingInvoiceForWireTransfer', 'Email providing wire transfer bank details for invoice payment.', 'Wire Transfer Details from {Merchant Product Name}', '<p>Hi,&nbsp;{User&nbsp;name}!</p>\n\n<p>Thank&nbsp;you&nbsp;for&nbsp;choosing&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;plan.&nbsp;You&nbsp;can&nbsp;view&nbsp;your&nbsp;invoice&nbsp;in&nbsp;your&nbsp;billing&nbsp;dashboard&nbsp;under&nbsp;the&nbsp;“Invoices”&nbsp;section.</p>\n\n<p>Here&nbsp;are&nbsp;our&nbsp;bank&nbsp;account&nbsp;details&nbsp;for&nbsp;the&nbsp;wire&nbsp;transfer:</p>\n\n<p>Account&nbsp;holder:&nbsp;{Account&nbsp;Holder}</p>\n\n<p>BIC:&nbsp;{BIC}</p>\n\n<p>IBAN:&nbsp;{IBAN}</p>\n\n<p>Wise&#39;s&nbsp;address:&nbsp;{Address}</p>\n\n<p>In&nbsp;case&nbsp;of&nbsp;any&nbsp;questions,&nbsp;do&nbsp;not&nbsp;ever&nbsp;hesitate&nbsp;to&nbsp;email&nbsp;us&nbsp;-&nbsp;{Merchant’s&nbsp;customer&nbsp;support&nbsp;email&nbsp;address}</p>\n\n<p>Important:&nbsp;do&nbsp;NOT&nbsp;reply&nbsp;to&nbsp;this&nbsp;email,&nbsp;use&nbsp;the&nbsp;contacts&nbsp;mentioned&nbsp;above&nbsp;instead.</p>\n\n<p>{Merchant&nbsp;Name}&nbsp;Team</p>', NULL, '2024-01-25 16:20:10', '2025-07-22 07:39:15', '0', NULL),
     ('3304', 'NewProcessingInvoiceForPaidTrial', 'Invoice email for a paid trial subscription, requiring payment.', 'Your Invoice for {Merchant Product Name} Trial', '<p>Hi,&nbsp;{User&nbsp;name}!&nbsp;</p>\n\n<p>Thank&nbsp;you&nbsp;for&nbsp;choosing&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;Trial.</p>\n\n<p>Please&nbsp;check&nbsp;the&nbsp;attached&nbsp;invoice&nbsp;and&nbsp;send&nbsp;the&nbsp;payment.&nbsp;Once&nbsp;we&nbsp;receive&nbsp;the&nbsp;payment,&nbsp;your&nbsp;trial&nbsp;will&nbsp;be&nbsp;activated.&nbsp;</p>\n\n<p>Please&nbsp;click&nbsp;the&nbsp;following&nbsp;link&nbsp;and&nbsp;process&nbsp;the&nbsp;payment:&nbsp;{Link}</p>\n\n<p>The&nbsp;invoice&nbsp;needs&nbsp;to&nbsp;be&nbsp;paid&nbsp;before&nbsp;the&nbsp;due&nbsp;date&nbsp;{PeriodEnd}&nbsp;to&nbsp;avoid&nbsp;possible&nbsp;interruptions&nbsp;while&nbsp;working&nbsp;with&nbsp;{Merchant&nbsp;Product&nbsp;Name}.&nbsp;</p>\n\n<p>In&nbsp;case&nbsp;of&nbsp;any&nbsp;questions,&nbsp;do&nbsp;not&nbsp;hesitate&nbsp;to&nbsp;contact&nbsp;us&nbsp;-&nbsp;{Merchant’s&nbsp;customer&nbsp;support&nbsp;email&nbsp;address}.</p>\n\n<p>Important:&nbsp;do&nbsp;NOT&nbsp;reply&nbsp;to&nbsp;this&nbsp;email,&nbsp;use&nbsp;the&nbsp;contact&nbsp;mentioned&nbsp;above&nbsp;instead.</p>\n\n<p>Thank&nbsp;you,</p>\n\n<p>{Merchant&nbsp;Name}</p>', NULL, '2024-01-25 16:20:10', '2025-07-22 07:39:16', '0', NULL),
     ('3305', 'SubscriptionTrialStart', 'Confirmation that a trial subscription has been successfully activated.', 'Your {Merchant Product Name} Trial is activated.', '<p>Hi,&nbsp;{User&nbsp;name}!&nbsp;</p>\n\n<p>Your&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;Trial&nbsp;has&nbsp;been&nbsp;activated.</p>\n\n<p>In&nbsp;case&nbsp;of&nbsp;any&nbsp;questions,&nbsp;do&nbsp;not&nbsp;hesitate&nbsp;to&nbsp;contact&nbsp;us&nbsp;-&nbsp;{Merchant’s&nbsp;customer&nbsp;support&nbsp;email&nbsp;address}.</p>\n\n<p>Important:&nbsp;do&nbsp;NOT&nbsp;reply&nbsp;to&nbsp;this&nbsp;email,&nbsp;use&nbsp;the&nbsp;contact&nbsp;mentioned&nbsp;above&nbsp;instead.</p>\n\n<p>Thank&nbsp;you,</p>\n\n<p>{Merchant&nbsp;Name}</p>', NULL, '2024-01-25 16:20:10', '2025-07-22 07:39:16', '0', NULL),
-    ('3306', 'NewProcessingInvoiceAfterTrial', 'Invoice email sent after a trial period ends, for continuing subscription.', 'Welcome to continue using {Merchant Product Name} ', '<p>Hi,&nbsp;{User&nbsp;name}!&nbsp;</p>\n\n<p>Your&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;Trial&nbsp;will&nbsp;be&nbsp;ended.&nbsp;</p>\n\n<p>Attached&nbsp;is&nbsp;the&nbsp;invoice&nbsp;for&nbsp;the&nbsp;subscription&nbsp;plan.&nbsp;Once&nbsp;we&nbsp;receive&nbsp;your&nbsp;payment,&nbsp;your&nbsp;subscription&nbsp;will&nbsp;continue&nbsp;activated.&nbsp;</p>\n\n<p>Please&nbsp;click&nbsp;the&nbsp;following&nbsp;link&nbsp;and&nbsp;process&nbsp;the&nbsp;payment:&nbsp;{Link}</p>\n\n<p>The&nbsp;invoice&nbsp;needs&nbsp;to&nbsp;be&nbsp;paid&nbsp;before&nbsp;the&nbsp;due&nbsp;date&nbsp;{PeriodEnd}&nbsp;to&nbsp;avoid&nbsp;possible&nbsp;interruptions&nbsp;while&nbsp;working&nbsp;with&nbsp;{Merchant&nbsp;Product&nbsp;Name}.&nbsp;</p>\n\n<p>In&nbsp;case&nbsp;of&nbsp;any&nbsp;questions,&nbsp;do&nbsp;not&nbsp;hesitate&nbsp;to&nbsp;contact&nbsp;us&nbsp;-&nbsp;{Merchant’s&nbsp;customer&nbsp;support&nbsp;email&nbsp;address}.</p>\n\n<p>Important:&nbsp;do&nbsp;NOT&nbsp;reply&nbsp;to&nbsp;this&nbsp;email,&nbsp;use&nbsp;the&nbsp;contact&nbsp;mentioned&nbsp;above&nbsp;instead.</p>\n\n<p>Thank&nbsp;you,</p>\n\n<p>{Merchant&nbsp;Name}</p>', NULL, '2024-01-25 16:20:10', '2025-07-22 07:39:16', '0', NULL);
+    ('3306', 'NewProcessingInvoiceAfterTrial', 'Invoice email sent after a trial period ends, for continuing subscription.', 'Welcome to continue using {Merchant Product Name} ', '<p>Hi,&nbsp;{User&nbsp;name}!&nbsp;</p>\n\n<p>Your&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;Trial&nbsp;will&nbsp;be&nbsp;ended.&nbsp;</p>\n\n<p>Attached&nbsp;is&nbsp;the&nbsp;invoice&nbsp;for&nbsp;the&nbsp;subscription&nbsp;plan.&nbsp;Once&nbsp;we&nbsp;receive&nbsp;your&nbsp;payment,&nbsp;your&nbsp;subscription&nbsp;will&nbsp;continue&nbsp;activated.&nbsp;</p>\n\n<p>Please&nbsp;click&nbsp;the&nbsp;following&nbsp;link&nbsp;and&nbsp;process&nbsp;the&nbsp;payment:&nbsp;{Link}</p>\n\n<p>The&nbsp;invoice&nbsp;needs&nbsp;to&nbsp;be&nbsp;paid&nbsp;before&nbsp;the&nbsp;due&nbsp;date&nbsp;{PeriodEnd}&nbsp;to&nbsp;avoid&nbsp;possible&nbsp;interruptions&nbsp;while&nbsp;working&nbsp;with&nbsp;{Merchant&nbsp;Product&nbsp;Name}.&nbsp;</p>\n\n<p>In&nbsp;case&nbsp;of&nbsp;any&nbsp;questions,&nbsp;do&nbsp;not&nbsp;hesitate&nbsp;to&nbsp;contact&nbsp;us&nbsp;-&nbsp;{Merchant’s&nbsp;customer&nbsp;support&nbsp;email&nbsp;address}.</p>\n\n<p>Important:&nbsp;do&nbsp;NOT&nbsp;reply&nbsp;to&nbsp;this&nbsp;email,&nbsp;use&nbsp;the&nbsp;contact&nbsp;mentioned&nbsp;above&nbsp;instead.</p>\n\n<p>Thank&nbsp;you,</p>\n\n<p>{Merchant&nbsp;Name}</p>', NULL, '2024-01-25 16:20:10', '2025-07-22 07:39:16', '0', NULL),
+    ('3307', 'SubscriptionPriceChange', 'Notice to user that the price of subscription plan will change.', 'Price Change of Your {Merchant Product Name} Subscription', '<p>Hi,&nbsp;{User&nbsp;name}!</p>\n\n<p>We&nbsp;are&nbsp;writing&nbsp;to&nbsp;let&nbsp;you&nbsp;know&nbsp;that&nbsp;the&nbsp;price&nbsp;of&nbsp;your&nbsp;{Merchant&nbsp;Product&nbsp;Name}&nbsp;subscription&nbsp;will&nbsp;change&nbsp;from&nbsp;{Previous&nbsp;Price}&nbsp;to&nbsp;{New&nbsp;Price}&nbsp;on&nbsp;{Effective&nbsp;Date}.</p>\n\n<p>No&nbsp;action&nbsp;is&nbsp;required&nbsp;from&nbsp;you.&nbsp;In&nbsp;case&nbsp;of&nbsp;any&nbsp;questions,&nbsp;do&nbsp;not&nbsp;hesitate&nbsp;to&nbsp;contact&nbsp;us&nbsp;-&nbsp;{Merchant’s&nbsp;customer&nbsp;support&nbsp;email&nbsp;address}.</p>\n\n<p>Important:&nbsp;do&nbsp;NOT&nbsp;reply&nbsp;to&nbsp;this&nbsp;email,&nbsp;use&nbsp;the&nbsp;contact&nbsp;mentioned&nbsp;above&nbsp;instead.</p>\n\n<p>Warm&nbsp;regards,</p>\n\n<p>{Merchant&nbsp;Name}</p>', NULL, '2026-10-18 00:00:00', '2026-10-18 00:00:00', '0', NULL);
 
 
 
@@ -8,6 +8,7 @@ import (
 	_interface "unibee/internal/interface"
 	context2 "unibee/internal/interface/context"
 	"unibee/internal/logic/gateway/api/credit"
+	"unibee/internal/logic/gateway/api/remote"
 	"unibee/internal/logic/gateway/gateway_bean"
 	"unibee/internal/logic/gateway/util"
 	entity "unibee/internal/model/entity/default"
@@ -41,6 +42,7 @@ var GatewayNameMapping = map[string]_interface.GatewayInterface{
 	"alikassa":        &AliKassa{},
 	"blockonomics":    &Blockonomics{},
 	"platega":         &Platega{},
+	"remote":          &remote.Remote{},
 	//"airwallex":       &Airwallex{},
 }
 
@@ -67,6 +69,7 @@ var GatewayShortNameMapping = map[string]string{
 	"blockonomics":    "BK",
 	"airwallex":       "AW",
 	"platega":         "PG",
+	"remote":          "RM",
 }
 
 // var ExportGatewaySetupListKeys = []string{"stripe", "changelly", "paypal", "unitpay", "payssion", "wire_transfer"}
@@ -87,6 +90,7 @@ var ExportGatewaySetupList = map[string]*_interface.GatewayInfo{
 	"alikassa":      AliKassa{}.GatewayInfo(context.Background()),
 	"blockonomics":  Blockonomics{}.GatewayInfo(context.Background()),
 	"platega":       Platega{}.GatewayInfo(context.Background()),
+	"remote":        remote.Remote{}.GatewayInfo(context.Background()),
 	//"cryptadium": Cryptadium{}.GatewayInfo(context.Background()),
 }
 
@@ -346,7 +350,9 @@ func (p GatewayProxy) GatewayNewPayment(ctx context.Context, gateway *entity.Mer
 	startTime := time.Now()
 	res, err = p.getRemoteGateway().GatewayNewPayment(ctx, gateway, createPayContext)
 	glog.Infof(ctx, "MeasureChannelFunction:GatewayNewPayment cost：%s \n", time.Now().Sub(startTime))
-	if err != nil {
+	if err != nil && util.IsGatewayUnavailable(err) {
+		err = gerror.NewCode(util.GatewayUnavailable, err.Error())
+	} else if err != nil {
 		err = gerror.NewCode(util.GatewayError, err.Error())
 	}
 	return res, err
@@ -215,6 +215,7 @@ func (p Paypal) GatewayNewPayment(ctx context.Context, gateway *entity.MerchantG
 					Value:    utility.ConvertCentToDollarStr(createPayContext.Pay.TotalAmount, createPayContext.Pay.Currency),
 					Currency: strings.ToUpper(createPayContext.Pay.Currency),
 				},
+				CustomID: createPayContext.Pay.PaymentId,
 			},
 		},
 		&paypal.CreateOrderPayer{},
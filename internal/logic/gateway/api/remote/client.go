@@ -0,0 +1,312 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+	"unibee/api/bean"
+	"unibee/internal/logic/gateway/api/log"
+	"unibee/internal/logic/gateway/gateway_bean"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const (
+	pluginRequestTimeout  = 30 * time.Second
+	pluginMaxResponseSize = 4 << 20
+)
+
+// AllowLocalEndpoint accepts the plugin endpoint of http, loopback or private network, only the conformance harness running
+// against a local plugin turns it on
+var AllowLocalEndpoint = false
+
+// pluginHttpClient checks the address resolved by dns before connecting, the endpoint is set up by the merchant and should never
+// reach the internal network of server, redirect is not followed either
+var pluginHttpClient = &http.Client{
+	Timeout: pluginRequestTimeout,
+	Transport: &http.Transport{
+		Proxy:               nil,
+		DialContext:         (&net.Dialer{Timeout: 10 * time.Second, Control: pluginDialControl}).DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+func isInternalIp(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified()
+}
+
+func pluginDialControl(network string, address string, c syscall.RawConn) error {
+	if AllowLocalEndpoint {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || isInternalIp(ip) {
+		return gerror.Newf("plugin endpoint address not allowed:%s", host)
+	}
+	return nil
+}
+
+// pluginGateway converts the gateway to the one of contract, the endpoint, signing secret and other credentials never sent
+func pluginGateway(gateway *entity.MerchantGateway) *Gateway {
+	if gateway == nil {
+		return nil
+	}
+	return &Gateway{
+		Id:          gateway.Id,
+		MerchantId:  gateway.MerchantId,
+		GatewayName: gateway.GatewayName,
+		Name:        gateway.Name,
+		GatewayType: gateway.GatewayType,
+		SubGateway:  gateway.SubGateway,
+		Currency:    gateway.Currency,
+	}
+}
+
+func pluginUser(user *entity.UserAccount) *User {
+	if user == nil {
+		return nil
+	}
+	return &User{
+		Id:             user.Id,
+		MerchantId:     user.MerchantId,
+		ExternalUserId: user.ExternalUserId,
+		Email:          user.Email,
+		FirstName:      user.FirstName,
+		LastName:       user.LastName,
+		CountryCode:    user.CountryCode,
+	}
+}
+
+func pluginPayment(payment *entity.Payment) *Payment {
+	if payment == nil {
+		return nil
+	}
+	return &Payment{
+		PaymentId:              payment.PaymentId,
+		MerchantId:             payment.MerchantId,
+		UserId:                 payment.UserId,
+		GatewayId:              payment.GatewayId,
+		InvoiceId:              payment.InvoiceId,
+		Currency:               payment.Currency,
+		TotalAmount:            payment.TotalAmount,
+		PaymentAmount:          payment.PaymentAmount,
+		RefundAmount:           payment.RefundAmount,
+		Status:                 payment.Status,
+		CountryCode:            payment.CountryCode,
+		ReturnUrl:              payment.ReturnUrl,
+		GatewayPaymentId:       payment.GatewayPaymentId,
+		GatewayPaymentIntentId: payment.GatewayPaymentIntentId,
+		GatewayPaymentMethod:   payment.GatewayPaymentMethod,
+		CreateTime:             payment.CreateTime,
+	}
+}
+
+func pluginRefund(refund *entity.Refund) *Refund {
+	if refund == nil {
+		return nil
+	}
+	return &Refund{
+		RefundId:        refund.RefundId,
+		PaymentId:       refund.PaymentId,
+		MerchantId:      refund.MerchantId,
+		UserId:          refund.UserId,
+		GatewayId:       refund.GatewayId,
+		Currency:        refund.Currency,
+		RefundAmount:    refund.RefundAmount,
+		RefundComment:   refund.RefundComment,
+		Status:          refund.Status,
+		GatewayRefundId: refund.GatewayRefundId,
+		CreateTime:      refund.CreateTime,
+	}
+}
+
+func pluginInvoice(invoice *bean.Invoice) *Invoice {
+	if invoice == nil {
+		return nil
+	}
+	one := &Invoice{
+		InvoiceId:   invoice.InvoiceId,
+		InvoiceName: invoice.InvoiceName,
+		Currency:    invoice.Currency,
+		TotalAmount: invoice.TotalAmount,
+		TaxAmount:   invoice.TaxAmount,
+		Lines:       make([]*InvoiceLine, 0),
+	}
+	for _, line := range invoice.Lines {
+		if line == nil {
+			continue
+		}
+		one.Lines = append(one.Lines, &InvoiceLine{
+			Name:                   line.Name,
+			Description:            line.Description,
+			Quantity:               line.Quantity,
+			UnitAmountExcludingTax: line.UnitAmountExcludingTax,
+			AmountExcludingTax:     line.AmountExcludingTax,
+			Tax:                    line.Tax,
+			Amount:                 line.Amount,
+		})
+	}
+	return one
+}
+
+func pluginNewPaymentReq(req *gateway_bean.GatewayNewPaymentReq) *NewPaymentReq {
+	if req == nil {
+		return nil
+	}
+	return &NewPaymentReq{
+		Pay:                     pluginPayment(req.Pay),
+		Invoice:                 pluginInvoice(req.Invoice),
+		CheckoutMode:            req.CheckoutMode,
+		PaymentUIMode:           req.PaymentUIMode,
+		ExternalUserId:          req.ExternalUserId,
+		Email:                   req.Email,
+		Metadata:                req.Metadata,
+		DaysUtilDue:             req.DaysUtilDue,
+		GatewayPaymentMethod:    req.GatewayPaymentMethod,
+		GatewayPaymentType:      req.GatewayPaymentType,
+		PayImmediate:            req.PayImmediate,
+		GatewayCurrencyExchange: req.GatewayCurrencyExchange,
+		ExchangeAmount:          req.ExchangeAmount,
+		ExchangeCurrency:        req.ExchangeCurrency,
+	}
+}
+
+func pluginNewRefundReq(req *gateway_bean.GatewayNewPaymentRefundReq) *NewRefundReq {
+	if req == nil {
+		return nil
+	}
+	return &NewRefundReq{
+		Payment:                 pluginPayment(req.Payment),
+		Refund:                  pluginRefund(req.Refund),
+		GatewayCurrencyExchange: req.GatewayCurrencyExchange,
+		ExchangeRefundAmount:    req.ExchangeRefundAmount,
+		ExchangeRefundCurrency:  req.ExchangeRefundCurrency,
+	}
+}
+
+func pluginEndpoint(gateway *entity.MerchantGateway) (string, error) {
+	endpoint := strings.TrimSuffix(strings.TrimSpace(gateway.GatewayKey), "/")
+	parsed, err := url.Parse(endpoint)
+	if err != nil || len(parsed.Hostname()) == 0 {
+		return "", gerror.Newf("invalid plugin endpoint:%s", endpoint)
+	}
+	if parsed.Scheme != "https" && !(AllowLocalEndpoint && parsed.Scheme == "http") {
+		return "", gerror.Newf("plugin endpoint should be https:%s", endpoint)
+	}
+	if len(gateway.GatewaySecret) == 0 {
+		return "", gerror.New("plugin signing secret not set")
+	}
+	return endpoint, nil
+}
+
+// Call sends the signed request of method to the plugin of gateway and decodes the data of response into data
+func Call(ctx context.Context, gateway *entity.MerchantGateway, method string, req *Request, data interface{}) error {
+	if gateway == nil {
+		return gerror.New("gateway not found")
+	}
+	endpoint, err := pluginEndpoint(gateway)
+	if err != nil {
+		return err
+	}
+	if req == nil {
+		req = &Request{}
+	}
+	req.Gateway = pluginGateway(gateway)
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	requestUrl := fmt.Sprintf("%s/%s/%s", endpoint, ContractVersion, method)
+	headers := map[string]string{
+		"Content-Type":  "application/json",
+		HeaderVersion:   ContractVersion,
+		HeaderSignature: Sign(body, gtime.Now().Timestamp(), gateway.GatewaySecret),
+	}
+	status, responseBody, err := postPlugin(ctx, requestUrl, body, headers)
+	var response *Response
+	if err == nil {
+		if err = json.Unmarshal(responseBody, &response); err != nil || response == nil {
+			err = gerror.Newf("invalid plugin response of %s, status:%d", method, status)
+		}
+	}
+	// the response of plugin may carry the data of provider, only the summary logged
+	var summary = map[string]interface{}{"status": status, "size": len(responseBody)}
+	if response != nil {
+		summary["code"] = response.Code
+		summary["message"] = response.Message
+	}
+	log.SaveChannelHttpLog(requestUrl, req, summary, err, fmt.Sprintf("%s-%d", gateway.GatewayName, gateway.Id), nil, gateway)
+	if err != nil {
+		return err
+	}
+	if response.Code != CodeSuccess {
+		return gerror.Newf("plugin error, code:%d message:%s", response.Code, response.Message)
+	}
+	if data != nil && len(response.Data) > 0 && string(response.Data) != "null" {
+		if err = json.Unmarshal(response.Data, data); err != nil {
+			return gerror.Newf("invalid plugin response data of %s:%s", method, err.Error())
+		}
+	}
+	return nil
+}
+
+func postPlugin(ctx context.Context, requestUrl string, body []byte, headers map[string]string) (int, []byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, requestUrl, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	response, err := pluginHttpClient.Do(request)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, pluginMaxResponseSize))
+	if err != nil {
+		return response.StatusCode, nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		// the http status carried as error code, 429 and 503 of plugin classified as gateway unavailable to fail over
+		return response.StatusCode, responseBody, gerror.NewCodef(gcode.New(response.StatusCode, http.StatusText(response.StatusCode), nil), "plugin http status:%d", response.StatusCode)
+	}
+	return response.StatusCode, responseBody, nil
+}
+
+// NewHttpRequest captures the inbound webhook or redirect request of the gateway to forward to the plugin
+func NewHttpRequest(r *ghttp.Request) *HttpRequest {
+	var headers = make(map[string]string)
+	for key := range r.Header {
+		headers[key] = r.Header.Get(key)
+	}
+	return &HttpRequest{
+		Method:  r.Method,
+		Path:    r.URL.Path,
+		Query:   r.URL.RawQuery,
+		Headers: headers,
+		Body:    r.GetBody(),
+	}
+}
@@ -0,0 +1,319 @@
+// Package conformance is the test harness of the remote gateway contract, plugin authors run it against their plugin
+// service to check every call of GatewayInterface and GatewayWebhookInterface is served as the contract requires
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"unibee/internal/consts"
+	_interface "unibee/internal/interface"
+	"unibee/internal/logic/gateway/api/remote"
+	"unibee/internal/logic/gateway/gateway_bean"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Options struct {
+	Endpoint string
+	Secret   string
+	// Unsupported lists the methods the plugin declines, they are still called and should fail with error
+	Unsupported []string
+	// Notification builds the provider callback completing the payment or refund(eventType payment|refund) with the gateway id,
+	// which is forwarded through gateway_webhook, nil skips the webhook checks
+	Notification func(eventType string, gatewayId string) *remote.HttpRequest
+}
+
+type harness struct {
+	options *Options
+	ctx     context.Context
+	gateway *entity.MerchantGateway
+	plugin  remote.Remote
+}
+
+// supported checks the result of method, false returns if the plugin declines the method as the options tells
+func (h *harness) supported(t *testing.T, method string, err error) bool {
+	for _, one := range h.options.Unsupported {
+		if one == method {
+			require.Error(t, err, "unsupported method %s should fail", method)
+			return false
+		}
+	}
+	require.NoError(t, err, "method %s", method)
+	return true
+}
+
+func (h *harness) newPayment(t *testing.T, paymentId string, gatewayPaymentMethod string) (*entity.Payment, *gateway_bean.GatewayNewPaymentResp) {
+	pay := &entity.Payment{
+		MerchantId:    h.gateway.MerchantId,
+		UserId:        1001,
+		GatewayId:     h.gateway.Id,
+		Currency:      "USD",
+		PaymentId:     paymentId,
+		TotalAmount:   1000,
+		PaymentAmount: 1000,
+		ReturnUrl:     "https://merchant.unibee.dev/return",
+	}
+	res, err := h.plugin.GatewayNewPayment(h.ctx, h.gateway, &gateway_bean.GatewayNewPaymentReq{
+		Merchant:             &entity.Merchant{Id: h.gateway.MerchantId},
+		Pay:                  pay,
+		Gateway:              h.gateway,
+		Email:                "conformance@unibee.dev",
+		Invoice:              nil,
+		GatewayPaymentMethod: gatewayPaymentMethod,
+		PayImmediate:         len(gatewayPaymentMethod) > 0,
+	})
+	if !h.supported(t, remote.MethodGatewayNewPayment, err) {
+		return nil, nil
+	}
+	require.NotNil(t, res)
+	require.NotEmpty(t, res.GatewayPaymentId)
+	require.Contains(t, []consts.PaymentStatusEnum{consts.PaymentCreated, consts.PaymentSuccess, consts.PaymentFailed}, res.Status)
+	require.Equal(t, pay, res.Payment)
+	pay.GatewayPaymentId = res.GatewayPaymentId
+	return pay, res
+}
+
+func (h *harness) webhook(t *testing.T, eventType string, gatewayId string) *remote.WebhookResp {
+	var res *remote.WebhookResp
+	err := remote.Call(h.ctx, h.gateway, remote.MethodGatewayWebhook, &remote.Request{HttpRequest: h.options.Notification(eventType, gatewayId)}, &res)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.NotEmpty(t, res.Events)
+	for _, event := range res.Events {
+		require.Contains(t, []string{remote.WebhookEventPayment, remote.WebhookEventRefund}, event.Type)
+	}
+	return res
+}
+
+// Run calls every method of the contract on the plugin, the plugin should keep the state between the calls
+func Run(t *testing.T, options *Options) {
+	h := &harness{
+		options: options,
+		ctx:     context.Background(),
+		gateway: &entity.MerchantGateway{
+			Id:            1,
+			MerchantId:    1,
+			GatewayName:   "remote",
+			GatewayType:   consts.GatewayTypeCard,
+			GatewayKey:    options.Endpoint,
+			GatewaySecret: options.Secret,
+		},
+	}
+	user := &entity.UserAccount{Id: 1001, MerchantId: 1, Email: "conformance@unibee.dev"}
+	var gatewayPaymentMethod = ""
+	var successPayment *entity.Payment
+
+	t.Run("Test for Signature", func(t *testing.T) {
+		gateway := *h.gateway
+		gateway.GatewaySecret = "invalid_" + options.Secret
+		err := remote.Call(h.ctx, &gateway, remote.MethodGatewayMerchantBalancesQuery, nil, nil)
+		require.Error(t, err, "request signed with other secret should be rejected")
+		err = remote.Call(h.ctx, h.gateway, "gateway_not_exist", nil, nil)
+		require.Error(t, err, "unknown method should fail")
+	})
+	t.Run("Test for Gateway Test And Webhook Setup", func(t *testing.T) {
+		_, gatewayType, err := h.plugin.GatewayTest(h.ctx, &_interface.GatewayTestReq{Key: options.Endpoint, Secret: options.Secret})
+		if h.supported(t, remote.MethodGatewayTest, err) {
+			require.Greater(t, gatewayType, int64(0))
+		}
+		err = remote.Call(h.ctx, h.gateway, remote.MethodGatewayCheckAndSetupWebhook, &remote.Request{WebhookUrl: fmt.Sprintf("https://api.unibee.dev/payment/gateway_webhook_entry/%d/notifications", h.gateway.Id)}, nil)
+		h.supported(t, remote.MethodGatewayCheckAndSetupWebhook, err)
+	})
+	t.Run("Test for User", func(t *testing.T) {
+		created, err := h.plugin.GatewayUserCreate(h.ctx, h.gateway, user)
+		if !h.supported(t, remote.MethodGatewayUserCreate, err) {
+			return
+		}
+		require.NotNil(t, created)
+		require.NotEmpty(t, created.GatewayUserId)
+		detail, err := h.plugin.GatewayUserDetailQuery(h.ctx, h.gateway, created.GatewayUserId)
+		if h.supported(t, remote.MethodGatewayUserDetailQuery, err) {
+			require.NotNil(t, detail)
+			require.Equal(t, created.GatewayUserId, detail.GatewayUserId)
+		}
+	})
+	t.Run("Test for Payment Method", func(t *testing.T) {
+		bind, err := h.plugin.GatewayUserCreateAndBindPaymentMethod(h.ctx, h.gateway, user.Id, "USD", map[string]interface{}{"source": "conformance"})
+		if !h.supported(t, remote.MethodGatewayUserCreateAndBindPaymentMethod, err) {
+			return
+		}
+		require.NotNil(t, bind)
+		require.True(t, bind.PaymentMethod != nil || len(bind.Url) > 0)
+		if bind.PaymentMethod == nil {
+			return
+		}
+		require.NotEmpty(t, bind.PaymentMethod.Id)
+		_, err = h.plugin.GatewayUserDeAttachPaymentMethodQuery(h.ctx, h.gateway, user.Id, bind.PaymentMethod.Id)
+		h.supported(t, remote.MethodGatewayUserDeAttachPaymentMethodQuery, err)
+		_, err = h.plugin.GatewayUserAttachPaymentMethodQuery(h.ctx, h.gateway, user.Id, bind.PaymentMethod.Id)
+		h.supported(t, remote.MethodGatewayUserAttachPaymentMethodQuery, err)
+		list, err := h.plugin.GatewayUserPaymentMethodListQuery(h.ctx, h.gateway, &gateway_bean.GatewayUserPaymentMethodReq{UserId: user.Id})
+		if h.supported(t, remote.MethodGatewayUserPaymentMethodListQuery, err) {
+			require.NotNil(t, list)
+			var found = false
+			for _, one := range list.PaymentMethods {
+				if one.Id == bind.PaymentMethod.Id {
+					found = true
+				}
+			}
+			require.True(t, found, "bound payment method should be listed")
+		}
+		gatewayPaymentMethod = bind.PaymentMethod.Id
+	})
+	t.Run("Test for Checkout Payment", func(t *testing.T) {
+		pay, _ := h.newPayment(t, "conformance_checkout", "")
+		if pay == nil {
+			return
+		}
+		detail, err := h.plugin.GatewayPaymentDetail(h.ctx, h.gateway, pay.GatewayPaymentId, pay)
+		if h.supported(t, remote.MethodGatewayPaymentDetail, err) {
+			require.NotNil(t, detail)
+			require.Equal(t, pay.GatewayPaymentId, detail.GatewayPaymentId)
+			require.Equal(t, pay.Currency, detail.Currency)
+		}
+		list, err := h.plugin.GatewayPaymentList(h.ctx, h.gateway, &gateway_bean.GatewayPaymentListReq{UserId: pay.UserId})
+		if h.supported(t, remote.MethodGatewayPaymentList, err) {
+			var found = false
+			for _, one := range list {
+				if one.GatewayPaymentId == pay.GatewayPaymentId {
+					found = true
+				}
+			}
+			require.True(t, found, "new payment should be listed")
+		}
+		var redirect *remote.RedirectResp
+		err = remote.Call(h.ctx, h.gateway, remote.MethodGatewayRedirect, &remote.Request{HttpRequest: &remote.HttpRequest{
+			Method: http.MethodGet,
+			Path:   fmt.Sprintf("/payment/redirect/%d/forward", h.gateway.Id),
+			Query:  fmt.Sprintf("paymentId=%s&success=true", pay.PaymentId),
+		}}, &redirect)
+		if h.supported(t, remote.MethodGatewayRedirect, err) {
+			require.NotNil(t, redirect)
+			require.True(t, len(redirect.PaymentId) == 0 || redirect.PaymentId == pay.PaymentId)
+		}
+		if options.Notification == nil {
+			return
+		}
+		res := h.webhook(t, remote.WebhookEventPayment, pay.GatewayPaymentId)
+		require.Equal(t, pay.PaymentId, res.Events[0].PaymentId)
+		require.Equal(t, pay.GatewayPaymentId, res.Events[0].GatewayPaymentId)
+		detail, err = h.plugin.GatewayPaymentDetail(h.ctx, h.gateway, pay.GatewayPaymentId, pay)
+		require.NoError(t, err)
+		require.Equal(t, consts.PaymentSuccess, detail.Status)
+		successPayment = pay
+	})
+	t.Run("Test for Auto Charge Payment", func(t *testing.T) {
+		if len(gatewayPaymentMethod) == 0 {
+			t.Skip("no payment method bound")
+		}
+		pay, res := h.newPayment(t, "conformance_auto_charge", gatewayPaymentMethod)
+		if pay == nil {
+			return
+		}
+		capture, err := h.plugin.GatewayCapture(h.ctx, h.gateway, pay)
+		if h.supported(t, remote.MethodGatewayCapture, err) {
+			require.NotNil(t, capture)
+		}
+		if res.Status == consts.PaymentSuccess {
+			successPayment = pay
+		}
+	})
+	t.Run("Test for Cancel Payment", func(t *testing.T) {
+		pay, _ := h.newPayment(t, "conformance_cancel", "")
+		if pay == nil {
+			return
+		}
+		res, err := h.plugin.GatewayCancel(h.ctx, h.gateway, pay)
+		if h.supported(t, remote.MethodGatewayCancel, err) {
+			require.NotNil(t, res)
+			require.Equal(t, consts.PaymentStatusEnum(consts.PaymentCancelled), res.Status)
+		}
+	})
+	t.Run("Test for Refund", func(t *testing.T) {
+		if successPayment == nil {
+			t.Skip("no succeeded payment to refund")
+		}
+		newRefund := func(refundId string) *gateway_bean.GatewayPaymentRefundResp {
+			res, err := h.plugin.GatewayRefund(h.ctx, h.gateway, &gateway_bean.GatewayNewPaymentRefundReq{
+				Payment: successPayment,
+				Refund: &entity.Refund{
+					MerchantId:    successPayment.MerchantId,
+					UserId:        successPayment.UserId,
+					GatewayId:     h.gateway.Id,
+					Currency:      successPayment.Currency,
+					PaymentId:     successPayment.PaymentId,
+					RefundId:      refundId,
+					RefundAmount:  100,
+					RefundComment: "conformance",
+				},
+				Gateway: h.gateway,
+			})
+			if !h.supported(t, remote.MethodGatewayRefund, err) {
+				return nil
+			}
+			require.NotNil(t, res)
+			require.NotEmpty(t, res.GatewayRefundId)
+			require.Contains(t, []consts.RefundStatusEnum{consts.RefundCreated, consts.RefundSuccess, consts.RefundFailed}, res.Status)
+			return res
+		}
+		cancelled := newRefund("conformance_refund_cancel")
+		if cancelled == nil {
+			return
+		}
+		_, err := h.plugin.GatewayRefundCancel(h.ctx, h.gateway, successPayment, &entity.Refund{RefundId: "conformance_refund_cancel", GatewayRefundId: cancelled.GatewayRefundId})
+		h.supported(t, remote.MethodGatewayRefundCancel, err)
+
+		refund := newRefund("conformance_refund")
+		detail, err := h.plugin.GatewayRefundDetail(h.ctx, h.gateway, refund.GatewayRefundId, &entity.Refund{RefundId: "conformance_refund", GatewayRefundId: refund.GatewayRefundId})
+		if h.supported(t, remote.MethodGatewayRefundDetail, err) {
+			require.NotNil(t, detail)
+			require.Equal(t, refund.GatewayRefundId, detail.GatewayRefundId)
+		}
+		list, err := h.plugin.GatewayRefundList(h.ctx, h.gateway, successPayment.GatewayPaymentId)
+		if h.supported(t, remote.MethodGatewayRefundList, err) {
+			var found = false
+			for _, one := range list {
+				if one.GatewayRefundId == refund.GatewayRefundId {
+					found = true
+				}
+			}
+			require.True(t, found, "new refund should be listed")
+		}
+		if options.Notification == nil || refund.Status != consts.RefundCreated {
+			return
+		}
+		res := h.webhook(t, remote.WebhookEventRefund, refund.GatewayRefundId)
+		require.Equal(t, refund.GatewayRefundId, res.Events[0].GatewayRefundId)
+		detail, err = h.plugin.GatewayRefundDetail(h.ctx, h.gateway, refund.GatewayRefundId, nil)
+		require.NoError(t, err)
+		require.Equal(t, consts.RefundStatusEnum(consts.RefundSuccess), detail.Status)
+	})
+	t.Run("Test for Balance And Crypto", func(t *testing.T) {
+		balance, err := h.plugin.GatewayMerchantBalancesQuery(h.ctx, h.gateway)
+		if h.supported(t, remote.MethodGatewayMerchantBalancesQuery, err) {
+			require.NotNil(t, balance)
+		}
+		to, err := h.plugin.GatewayCryptoFiatTrans(h.ctx, &gateway_bean.GatewayCryptoFromCurrencyAmountDetailReq{
+			Amount:         1000,
+			Currency:       "USD",
+			CryptoCurrency: "USDT",
+			CountryCode:    "US",
+			Gateway:        h.gateway,
+		})
+		if h.supported(t, remote.MethodGatewayCryptoFiatTrans, err) {
+			require.NotNil(t, to)
+			require.Equal(t, "USDT", to.CryptoCurrency)
+		}
+	})
+	t.Run("Test for New Payment Method Redirect", func(t *testing.T) {
+		err := remote.Call(h.ctx, h.gateway, remote.MethodGatewayNewPaymentMethodRedirect, &remote.Request{HttpRequest: &remote.HttpRequest{
+			Method: http.MethodGet,
+			Path:   fmt.Sprintf("/payment/method/redirect/%d/forward", h.gateway.Id),
+			Query:  "success=true&subId=conformance",
+		}}, nil)
+		h.supported(t, remote.MethodGatewayNewPaymentMethodRedirect, err)
+	})
+}
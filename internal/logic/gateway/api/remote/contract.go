@@ -0,0 +1,243 @@
+// Package remote implements the "remote" gateway kind, every GatewayInterface and GatewayWebhookInterface call is
+// forwarded to an out-of-process plugin service configured by the merchant.
+//
+// Contract v1:
+//
+//   - The merchant sets up the remote gateway with the plugin endpoint (e.g. https://psp-plugin.example.com) as the
+//     public key and a shared signing secret as the private secret
+//   - Every call is sent as POST <endpoint>/v1/<method> with the JSON encoded Request as body, the methods are listed below
+//   - The request is signed with header UniBee-Plugin-Signature: t=<unix timestamp>,v1=<signature>, signature is
+//     base64(HMAC-SHA256(secret, "<timestamp>.<body>")), the plugin should reject the request out of 300 seconds tolerance
+//   - The plugin responds HTTP 200 with JSON Response{code, message, data}, code 0 means success and data carries
+//     the method result, other code fails the call with message
+//   - The gateway, user, payment and refund are sent as the minimal Gateway, User, Payment and Refund of the contract,
+//     the secrets of gateway and the account data of user never sent to the plugin
+//   - The inbound webhook/redirect of the gateway is forwarded as HttpRequest, body encoded in base64
+package remote
+
+import (
+	"encoding/json"
+	_interface "unibee/internal/interface"
+	"unibee/internal/logic/gateway/gateway_bean"
+)
+
+const (
+	ContractVersion    = "v1"
+	HeaderVersion      = "UniBee-Plugin-Version"
+	HeaderSignature    = "UniBee-Plugin-Signature"
+	SignatureTolerance = 300
+	CodeSuccess        = 0
+)
+
+const (
+	MethodGatewayTest                           = "gateway_test"
+	MethodGatewayUserCreate                     = "gateway_user_create"
+	MethodGatewayUserDetailQuery                = "gateway_user_detail_query"
+	MethodGatewayMerchantBalancesQuery          = "gateway_merchant_balances_query"
+	MethodGatewayUserAttachPaymentMethodQuery   = "gateway_user_attach_payment_method_query"
+	MethodGatewayUserDeAttachPaymentMethodQuery = "gateway_user_deattach_payment_method_query"
+	MethodGatewayUserPaymentMethodListQuery     = "gateway_user_payment_method_list_query"
+	MethodGatewayUserCreateAndBindPaymentMethod = "gateway_user_create_and_bind_payment_method"
+	MethodGatewayNewPayment                     = "gateway_new_payment"
+	MethodGatewayCapture                        = "gateway_capture"
+	MethodGatewayCancel                         = "gateway_cancel"
+	MethodGatewayCryptoFiatTrans                = "gateway_crypto_fiat_trans"
+	MethodGatewayPaymentList                    = "gateway_payment_list"
+	MethodGatewayPaymentDetail                  = "gateway_payment_detail"
+	MethodGatewayRefundList                     = "gateway_refund_list"
+	MethodGatewayRefundDetail                   = "gateway_refund_detail"
+	MethodGatewayRefund                         = "gateway_refund"
+	MethodGatewayRefundCancel                   = "gateway_refund_cancel"
+	MethodGatewayCheckAndSetupWebhook           = "gateway_check_and_setup_webhook"
+	MethodGatewayWebhook                        = "gateway_webhook"
+	MethodGatewayRedirect                       = "gateway_redirect"
+	MethodGatewayNewPaymentMethodRedirect       = "gateway_new_payment_method_redirect"
+)
+
+const (
+	WebhookEventPayment = "payment"
+	WebhookEventRefund  = "refund"
+)
+
+// Request is the body of every plugin call, only the fields of the called method are set
+type Request struct {
+	Gateway              *Gateway                                  `json:"gateway,omitempty"`
+	TestReq              *_interface.GatewayTestReq                `json:"testReq,omitempty"`
+	User                 *User                                     `json:"user,omitempty"`
+	UserId               uint64                                    `json:"userId,omitempty"`
+	GatewayUserId        string                                    `json:"gatewayUserId,omitempty"`
+	GatewayPaymentMethod string                                    `json:"gatewayPaymentMethod,omitempty"`
+	Currency             string                                    `json:"currency,omitempty"`
+	Metadata             map[string]interface{}                    `json:"metadata,omitempty"`
+	PaymentMethodReq     *gateway_bean.GatewayUserPaymentMethodReq `json:"paymentMethodReq,omitempty"`
+	NewPaymentReq        *NewPaymentReq                            `json:"newPaymentReq,omitempty"`
+	NewRefundReq         *NewRefundReq                             `json:"newRefundReq,omitempty"`
+	CryptoReq            *CryptoReq                                `json:"cryptoReq,omitempty"`
+	PaymentListReq       *gateway_bean.GatewayPaymentListReq       `json:"paymentListReq,omitempty"`
+	Payment              *Payment                                  `json:"payment,omitempty"`
+	Refund               *Refund                                   `json:"refund,omitempty"`
+	GatewayPaymentId     string                                    `json:"gatewayPaymentId,omitempty"`
+	GatewayRefundId      string                                    `json:"gatewayRefundId,omitempty"`
+	WebhookUrl           string                                    `json:"webhookUrl,omitempty"`
+	HttpRequest          *HttpRequest                              `json:"httpRequest,omitempty"`
+}
+
+// Gateway is the merchant gateway the call is made for
+type Gateway struct {
+	Id          uint64 `json:"id"`
+	MerchantId  uint64 `json:"merchantId"`
+	GatewayName string `json:"gatewayName"`
+	Name        string `json:"name"`
+	GatewayType int64  `json:"gatewayType"`
+	SubGateway  string `json:"subGateway"`
+	Currency    string `json:"currency"`
+}
+
+// User is the customer created on the provider
+type User struct {
+	Id             uint64 `json:"id"`
+	MerchantId     uint64 `json:"merchantId"`
+	ExternalUserId string `json:"externalUserId"`
+	Email          string `json:"email"`
+	FirstName      string `json:"firstName"`
+	LastName       string `json:"lastName"`
+	CountryCode    string `json:"countryCode"`
+}
+
+// Payment is the payment of UniBee, GatewayPaymentId set once the provider created it
+type Payment struct {
+	PaymentId              string `json:"paymentId"`
+	MerchantId             uint64 `json:"merchantId"`
+	UserId                 uint64 `json:"userId"`
+	GatewayId              uint64 `json:"gatewayId"`
+	InvoiceId              string `json:"invoiceId"`
+	Currency               string `json:"currency"`
+	TotalAmount            int64  `json:"totalAmount"`
+	PaymentAmount          int64  `json:"paymentAmount"`
+	RefundAmount           int64  `json:"refundAmount"`
+	Status                 int    `json:"status"`
+	CountryCode            string `json:"countryCode"`
+	ReturnUrl              string `json:"returnUrl"`
+	GatewayPaymentId       string `json:"gatewayPaymentId"`
+	GatewayPaymentIntentId string `json:"gatewayPaymentIntentId"`
+	GatewayPaymentMethod   string `json:"gatewayPaymentMethod"`
+	CreateTime             int64  `json:"createTime"`
+}
+
+// Refund is the refund of UniBee, GatewayRefundId set once the provider created it
+type Refund struct {
+	RefundId        string `json:"refundId"`
+	PaymentId       string `json:"paymentId"`
+	MerchantId      uint64 `json:"merchantId"`
+	UserId          uint64 `json:"userId"`
+	GatewayId       uint64 `json:"gatewayId"`
+	Currency        string `json:"currency"`
+	RefundAmount    int64  `json:"refundAmount"`
+	RefundComment   string `json:"refundComment"`
+	Status          int    `json:"status"`
+	GatewayRefundId string `json:"gatewayRefundId"`
+	CreateTime      int64  `json:"createTime"`
+}
+
+// Invoice is the invoice charged by the payment, the lines for the product name and description shown by the provider
+type Invoice struct {
+	InvoiceId   string         `json:"invoiceId"`
+	InvoiceName string         `json:"invoiceName"`
+	Currency    string         `json:"currency"`
+	TotalAmount int64          `json:"totalAmount"`
+	TaxAmount   int64          `json:"taxAmount"`
+	Lines       []*InvoiceLine `json:"lines"`
+}
+
+type InvoiceLine struct {
+	Name                   string `json:"name"`
+	Description            string `json:"description"`
+	Quantity               int64  `json:"quantity"`
+	UnitAmountExcludingTax int64  `json:"unitAmountExcludingTax"`
+	AmountExcludingTax     int64  `json:"amountExcludingTax"`
+	Tax                    int64  `json:"tax"`
+	Amount                 int64  `json:"amount"`
+}
+
+type NewPaymentReq struct {
+	Pay                     *Payment                              `json:"pay"`
+	Invoice                 *Invoice                              `json:"invoice"`
+	CheckoutMode            bool                                  `json:"checkoutMode"`
+	PaymentUIMode           string                                `json:"paymentUIMode"`
+	ExternalUserId          string                                `json:"externalUserId"`
+	Email                   string                                `json:"email"`
+	Metadata                map[string]interface{}                `json:"metadata"`
+	DaysUtilDue             int                                   `json:"daysUtilDue"`
+	GatewayPaymentMethod    string                                `json:"gatewayPaymentMethod"`
+	GatewayPaymentType      string                                `json:"gatewayPaymentType"`
+	PayImmediate            bool                                  `json:"payImmediate"`
+	GatewayCurrencyExchange *gateway_bean.GatewayCurrencyExchange `json:"gatewayCurrencyExchange"`
+	ExchangeAmount          int64                                 `json:"exchangeAmount"`
+	ExchangeCurrency        string                                `json:"exchangeCurrency"`
+}
+
+type NewRefundReq struct {
+	Payment                 *Payment                              `json:"payment"`
+	Refund                  *Refund                               `json:"refund"`
+	GatewayCurrencyExchange *gateway_bean.GatewayCurrencyExchange `json:"gatewayCurrencyExchange"`
+	ExchangeRefundAmount    int64                                 `json:"exchangeRefundAmount"`
+	ExchangeRefundCurrency  string                                `json:"exchangeRefundCurrency"`
+}
+
+type CryptoReq struct {
+	Amount         int64  `json:"amount"`
+	Currency       string `json:"currency"`
+	CryptoCurrency string `json:"cryptoCurrency"`
+	CountryCode    string `json:"countryCode"`
+}
+
+// Response is the body the plugin responds to every call
+type Response struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// HttpRequest is the inbound webhook or redirect request of the gateway forwarded to the plugin
+type HttpRequest struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Query   string            `json:"query"`
+	Headers map[string]string `json:"headers"`
+	Body    []byte            `json:"body"`
+}
+
+// HttpResponse is what the plugin wants to write back to the gateway for the webhook
+type HttpResponse struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType"`
+	Body        string `json:"body"`
+}
+
+type TestResp struct {
+	Icon        string `json:"icon"`
+	GatewayType int64  `json:"gatewayType"`
+}
+
+// WebhookEvent tells the payment(type payment, with the paymentId of UniBee) or refund(type refund) changed by the webhook,
+// UniBee queries the detail with gateway_payment_detail or gateway_refund_detail and handles it as other gateways
+type WebhookEvent struct {
+	Type             string `json:"type"`
+	PaymentId        string `json:"paymentId"`
+	GatewayPaymentId string `json:"gatewayPaymentId"`
+	GatewayRefundId  string `json:"gatewayRefundId"`
+}
+
+type WebhookResp struct {
+	Events   []*WebhookEvent `json:"events"`
+	Response *HttpResponse   `json:"response"`
+}
+
+type RedirectResp struct {
+	PaymentId string `json:"paymentId"`
+	Status    bool   `json:"status"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+	ReturnUrl string `json:"returnUrl"`
+	QueryPath string `json:"queryPath"`
+}
@@ -0,0 +1,425 @@
+// Package reference is the reference plugin of the remote gateway contract, it keeps users, payment methods, payments
+// and refunds in memory as a fake payment provider. Payment without payment method and refund stay pending until the
+// provider callback {"type":"payment|refund","id":"<gateway id>","status":"success|failed"} is forwarded by gateway_webhook.
+package reference
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unibee/internal/consts"
+	"unibee/internal/logic/gateway/api/remote"
+	"unibee/internal/logic/gateway/gateway_bean"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const (
+	CodeError    = 1
+	CheckoutHost = "https://reference.plugin.unibee.dev"
+)
+
+type Notification struct {
+	Type   string `json:"type"`
+	Id     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type payment struct {
+	PaymentId string
+	UserId    uint64
+	Detail    *gateway_bean.GatewayPaymentRo
+}
+
+type Plugin struct {
+	secret         string
+	lock           sync.Mutex
+	sequence       int64
+	webhookUrl     string
+	users          map[string]string
+	paymentMethods map[uint64][]*gateway_bean.PaymentMethod
+	payments       map[string]*payment
+	refunds        map[string]*gateway_bean.GatewayPaymentRefundResp
+}
+
+func NewPlugin(secret string) *Plugin {
+	return &Plugin{
+		secret:         secret,
+		users:          make(map[string]string),
+		paymentMethods: make(map[uint64][]*gateway_bean.PaymentMethod),
+		payments:       make(map[string]*payment),
+		refunds:        make(map[string]*gateway_bean.GatewayPaymentRefundResp),
+	}
+}
+
+func (p *Plugin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	prefix := "/" + remote.ContractVersion + "/"
+	if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, prefix) {
+		http.NotFound(w, r)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = remote.VerifySignature(body, p.secret, r.Header.Get(remote.HeaderSignature), remote.SignatureTolerance, time.Now().Unix())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	var req *remote.Request
+	if err = json.Unmarshal(body, &req); err != nil || req == nil || req.Gateway == nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	p.lock.Lock()
+	data, err := p.handle(strings.TrimPrefix(r.URL.Path, prefix), req)
+	p.lock.Unlock()
+	var response = &remote.Response{Code: remote.CodeSuccess}
+	if err != nil {
+		response.Code = CodeError
+		response.Message = err.Error()
+	} else {
+		response.Data, _ = json.Marshal(data)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (p *Plugin) nextId(prefix string) string {
+	p.sequence = p.sequence + 1
+	return fmt.Sprintf("%s_%d", prefix, p.sequence)
+}
+
+func (p *Plugin) handle(method string, req *remote.Request) (interface{}, error) {
+	switch method {
+	case remote.MethodGatewayTest:
+		return &remote.TestResp{GatewayType: consts.GatewayTypeCard}, nil
+	case remote.MethodGatewayCheckAndSetupWebhook:
+		if len(req.WebhookUrl) == 0 {
+			return nil, gerror.New("webhookUrl required")
+		}
+		p.webhookUrl = req.WebhookUrl
+		return nil, nil
+	case remote.MethodGatewayUserCreate:
+		if req.User == nil {
+			return nil, gerror.New("user required")
+		}
+		gatewayUserId := fmt.Sprintf("ref_user_%d", req.User.Id)
+		p.users[gatewayUserId] = req.User.Email
+		return &gateway_bean.GatewayUserCreateResp{GatewayUserId: gatewayUserId}, nil
+	case remote.MethodGatewayUserDetailQuery:
+		email, ok := p.users[req.GatewayUserId]
+		if !ok {
+			return nil, gerror.Newf("user not found:%s", req.GatewayUserId)
+		}
+		return &gateway_bean.GatewayUserDetailQueryResp{GatewayUserId: req.GatewayUserId, Email: email}, nil
+	case remote.MethodGatewayMerchantBalancesQuery:
+		return p.balances(), nil
+	case remote.MethodGatewayUserCreateAndBindPaymentMethod:
+		method := &gateway_bean.PaymentMethod{
+			Id:        p.nextId("ref_pm"),
+			Type:      "card",
+			IsDefault: len(p.paymentMethods[req.UserId]) == 0,
+		}
+		p.paymentMethods[req.UserId] = append(p.paymentMethods[req.UserId], method)
+		return &gateway_bean.GatewayUserPaymentMethodCreateAndBindResp{PaymentMethod: method}, nil
+	case remote.MethodGatewayUserAttachPaymentMethodQuery:
+		if p.findPaymentMethod(req.UserId, req.GatewayPaymentMethod) < 0 {
+			p.paymentMethods[req.UserId] = append(p.paymentMethods[req.UserId], &gateway_bean.PaymentMethod{Id: req.GatewayPaymentMethod, Type: "card"})
+		}
+		return &gateway_bean.GatewayUserAttachPaymentMethodResp{}, nil
+	case remote.MethodGatewayUserDeAttachPaymentMethodQuery:
+		index := p.findPaymentMethod(req.UserId, req.GatewayPaymentMethod)
+		if index < 0 {
+			return nil, gerror.Newf("payment method not found:%s", req.GatewayPaymentMethod)
+		}
+		list := p.paymentMethods[req.UserId]
+		p.paymentMethods[req.UserId] = append(list[:index], list[index+1:]...)
+		return &gateway_bean.GatewayUserDeAttachPaymentMethodResp{}, nil
+	case remote.MethodGatewayUserPaymentMethodListQuery:
+		if req.PaymentMethodReq == nil {
+			return nil, gerror.New("paymentMethodReq required")
+		}
+		return &gateway_bean.GatewayUserPaymentMethodListResp{PaymentMethods: p.paymentMethods[req.PaymentMethodReq.UserId]}, nil
+	case remote.MethodGatewayNewPayment:
+		return p.newPayment(req)
+	case remote.MethodGatewayCapture:
+		one, err := p.getPayment(req.Payment)
+		if err != nil {
+			return nil, err
+		}
+		return &gateway_bean.GatewayPaymentCaptureResp{
+			MerchantId:       one.Detail.MerchantId,
+			GatewayCaptureId: one.Detail.GatewayPaymentId,
+			Amount:           one.Detail.PaymentAmount,
+			Currency:         one.Detail.Currency,
+			Status:           "captured",
+		}, nil
+	case remote.MethodGatewayCancel:
+		one, err := p.getPayment(req.Payment)
+		if err != nil {
+			return nil, err
+		}
+		if one.Detail.Status != consts.PaymentCreated {
+			return nil, gerror.Newf("payment can not cancel in status:%d", one.Detail.Status)
+		}
+		one.Detail.Status = consts.PaymentCancelled
+		one.Detail.CancelTime = gtime.Now()
+		return &gateway_bean.GatewayPaymentCancelResp{
+			GatewayCancelId: one.Detail.GatewayPaymentId,
+			PaymentId:       one.PaymentId,
+			Status:          consts.PaymentCancelled,
+		}, nil
+	case remote.MethodGatewayCryptoFiatTrans:
+		if req.CryptoReq == nil {
+			return nil, gerror.New("cryptoReq required")
+		}
+		return &gateway_bean.GatewayCryptoToCurrencyAmountDetailRes{
+			Amount:         req.CryptoReq.Amount,
+			Currency:       req.CryptoReq.Currency,
+			CountryCode:    req.CryptoReq.CountryCode,
+			CryptoAmount:   req.CryptoReq.Amount,
+			CryptoCurrency: req.CryptoReq.CryptoCurrency,
+			Rate:           1,
+		}, nil
+	case remote.MethodGatewayPaymentList:
+		var list = make([]*gateway_bean.GatewayPaymentRo, 0)
+		for _, one := range p.payments {
+			if req.PaymentListReq == nil || req.PaymentListReq.UserId == 0 || req.PaymentListReq.UserId == one.UserId {
+				list = append(list, one.Detail)
+			}
+		}
+		return list, nil
+	case remote.MethodGatewayPaymentDetail:
+		one, ok := p.payments[req.GatewayPaymentId]
+		if !ok {
+			return nil, gerror.Newf("payment not found:%s", req.GatewayPaymentId)
+		}
+		return one.Detail, nil
+	case remote.MethodGatewayRefund:
+		return p.newRefund(req)
+	case remote.MethodGatewayRefundList:
+		var list = make([]*gateway_bean.GatewayPaymentRefundResp, 0)
+		for _, one := range p.refunds {
+			if one.GatewayPaymentId == req.GatewayPaymentId {
+				list = append(list, one)
+			}
+		}
+		return list, nil
+	case remote.MethodGatewayRefundDetail:
+		one, ok := p.refunds[req.GatewayRefundId]
+		if !ok {
+			return nil, gerror.Newf("refund not found:%s", req.GatewayRefundId)
+		}
+		return one, nil
+	case remote.MethodGatewayRefundCancel:
+		if req.Refund == nil {
+			return nil, gerror.New("refund required")
+		}
+		one, ok := p.refunds[req.Refund.GatewayRefundId]
+		if !ok {
+			return nil, gerror.Newf("refund not found:%s", req.Refund.GatewayRefundId)
+		}
+		if one.Status != consts.RefundCreated {
+			return nil, gerror.Newf("refund can not cancel in status:%d", one.Status)
+		}
+		one.Status = consts.RefundCancelled
+		return one, nil
+	case remote.MethodGatewayWebhook:
+		return p.webhook(req)
+	case remote.MethodGatewayRedirect:
+		if req.HttpRequest == nil {
+			return nil, gerror.New("httpRequest required")
+		}
+		query, _ := url.ParseQuery(req.HttpRequest.Query)
+		for _, one := range p.payments {
+			if one.PaymentId == query.Get("paymentId") {
+				return &remote.RedirectResp{
+					PaymentId: one.PaymentId,
+					Status:    true,
+					Success:   one.Detail.Status == consts.PaymentSuccess,
+					Message:   "Payment redirect",
+				}, nil
+			}
+		}
+		return nil, gerror.Newf("payment not found:%s", query.Get("paymentId"))
+	case remote.MethodGatewayNewPaymentMethodRedirect:
+		if req.HttpRequest == nil {
+			return nil, gerror.New("httpRequest required")
+		}
+		return nil, nil
+	default:
+		return nil, gerror.Newf("method not support:%s", method)
+	}
+}
+
+func (p *Plugin) findPaymentMethod(userId uint64, gatewayPaymentMethod string) int {
+	for i, one := range p.paymentMethods[userId] {
+		if one.Id == gatewayPaymentMethod {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *Plugin) getPayment(pay *remote.Payment) (*payment, error) {
+	if pay == nil {
+		return nil, gerror.New("payment required")
+	}
+	one, ok := p.payments[pay.GatewayPaymentId]
+	if !ok {
+		return nil, gerror.Newf("payment not found:%s", pay.GatewayPaymentId)
+	}
+	return one, nil
+}
+
+// newPayment charges immediately with the payment method, otherwise returns the checkout link and waits for the callback
+func (p *Plugin) newPayment(req *remote.Request) (interface{}, error) {
+	if req.NewPaymentReq == nil || req.NewPaymentReq.Pay == nil {
+		return nil, gerror.New("newPaymentReq required")
+	}
+	pay := req.NewPaymentReq.Pay
+	gatewayPaymentId := p.nextId("ref_pay")
+	detail := &gateway_bean.GatewayPaymentRo{
+		MerchantId:           pay.MerchantId,
+		Status:               consts.PaymentCreated,
+		Currency:             pay.Currency,
+		TotalAmount:          pay.TotalAmount,
+		PaymentAmount:        pay.TotalAmount,
+		CreateTime:           gtime.Now(),
+		GatewayId:            req.Gateway.Id,
+		GatewayPaymentId:     gatewayPaymentId,
+		GatewayPaymentMethod: req.NewPaymentReq.GatewayPaymentMethod,
+		Link:                 fmt.Sprintf("%s/checkout/%s", CheckoutHost, gatewayPaymentId),
+	}
+	if len(req.NewPaymentReq.GatewayPaymentMethod) > 0 {
+		if p.findPaymentMethod(pay.UserId, req.NewPaymentReq.GatewayPaymentMethod) < 0 {
+			return nil, gerror.Newf("payment method not found:%s", req.NewPaymentReq.GatewayPaymentMethod)
+		}
+		detail.Status = consts.PaymentSuccess
+		detail.PaidTime = gtime.Now()
+	}
+	p.payments[gatewayPaymentId] = &payment{PaymentId: pay.PaymentId, UserId: pay.UserId, Detail: detail}
+	return &gateway_bean.GatewayNewPaymentResp{
+		Status:                 consts.PaymentStatusEnum(detail.Status),
+		GatewayPaymentId:       gatewayPaymentId,
+		GatewayPaymentIntentId: gatewayPaymentId,
+		GatewayPaymentMethod:   detail.GatewayPaymentMethod,
+		Link:                   detail.Link,
+	}, nil
+}
+
+func (p *Plugin) newRefund(req *remote.Request) (interface{}, error) {
+	if req.NewRefundReq == nil || req.NewRefundReq.Payment == nil || req.NewRefundReq.Refund == nil {
+		return nil, gerror.New("newRefundReq required")
+	}
+	one, err := p.getPayment(req.NewRefundReq.Payment)
+	if err != nil {
+		return nil, err
+	}
+	if one.Detail.Status != consts.PaymentSuccess {
+		return nil, gerror.Newf("payment can not refund in status:%d", one.Detail.Status)
+	}
+	if one.Detail.RefundAmount+req.NewRefundReq.Refund.RefundAmount > one.Detail.PaymentAmount {
+		return nil, gerror.New("refund amount exceeds the payment")
+	}
+	one.Detail.RefundAmount = one.Detail.RefundAmount + req.NewRefundReq.Refund.RefundAmount
+	one.Detail.RefundSequence = one.Detail.RefundSequence + 1
+	sequence := one.Detail.RefundSequence
+	refund := &gateway_bean.GatewayPaymentRefundResp{
+		GatewayRefundId:  p.nextId("ref_refund"),
+		GatewayPaymentId: one.Detail.GatewayPaymentId,
+		Status:           consts.RefundCreated,
+		Reason:           req.NewRefundReq.Refund.RefundComment,
+		RefundAmount:     req.NewRefundReq.Refund.RefundAmount,
+		Currency:         req.NewRefundReq.Refund.Currency,
+		Type:             consts.RefundTypeGateway,
+		RefundSequence:   &sequence,
+	}
+	p.refunds[refund.GatewayRefundId] = refund
+	return refund, nil
+}
+
+// webhook applies the provider callback and tells UniBee the changed payment or refund
+func (p *Plugin) webhook(req *remote.Request) (interface{}, error) {
+	if req.HttpRequest == nil {
+		return nil, gerror.New("httpRequest required")
+	}
+	var notification *Notification
+	if err := json.Unmarshal(req.HttpRequest.Body, &notification); err != nil || notification == nil {
+		return nil, gerror.New("invalid notification")
+	}
+	var event = &remote.WebhookEvent{Type: notification.Type}
+	if notification.Type == remote.WebhookEventPayment {
+		one, ok := p.payments[notification.Id]
+		if !ok {
+			return nil, gerror.Newf("payment not found:%s", notification.Id)
+		}
+		if one.Detail.Status == consts.PaymentCreated {
+			if notification.Status == "success" {
+				one.Detail.Status = consts.PaymentSuccess
+				one.Detail.PaidTime = gtime.Now()
+			} else {
+				one.Detail.Status = consts.PaymentFailed
+				one.Detail.LastError = notification.Status
+			}
+		}
+		event.PaymentId = one.PaymentId
+		event.GatewayPaymentId = notification.Id
+	} else if notification.Type == remote.WebhookEventRefund {
+		one, ok := p.refunds[notification.Id]
+		if !ok {
+			return nil, gerror.Newf("refund not found:%s", notification.Id)
+		}
+		if one.Status == consts.RefundCreated {
+			if notification.Status == "success" {
+				one.Status = consts.RefundSuccess
+				one.RefundTime = gtime.Now()
+			} else {
+				one.Status = consts.RefundFailed
+				if pay, ok := p.payments[one.GatewayPaymentId]; ok {
+					pay.Detail.RefundAmount = pay.Detail.RefundAmount - one.RefundAmount
+				}
+			}
+		}
+		event.GatewayPaymentId = one.GatewayPaymentId
+		event.GatewayRefundId = notification.Id
+	} else {
+		return nil, gerror.Newf("notification type not support:%s", notification.Type)
+	}
+	return &remote.WebhookResp{
+		Events:   []*remote.WebhookEvent{event},
+		Response: &remote.HttpResponse{Status: http.StatusOK, ContentType: "application/json", Body: `{"received":true}`},
+	}, nil
+}
+
+func (p *Plugin) balances() *gateway_bean.GatewayMerchantBalanceQueryResp {
+	var available = make(map[string]int64)
+	var pending = make(map[string]int64)
+	for _, one := range p.payments {
+		if one.Detail.Status == consts.PaymentSuccess {
+			available[one.Detail.Currency] = available[one.Detail.Currency] + one.Detail.PaymentAmount - one.Detail.RefundAmount
+		} else if one.Detail.Status == consts.PaymentCreated {
+			pending[one.Detail.Currency] = pending[one.Detail.Currency] + one.Detail.PaymentAmount
+		}
+	}
+	var res = &gateway_bean.GatewayMerchantBalanceQueryResp{
+		AvailableBalance: make([]*gateway_bean.GatewayBalance, 0),
+		PendingBalance:   make([]*gateway_bean.GatewayBalance, 0),
+	}
+	for currency, amount := range available {
+		res.AvailableBalance = append(res.AvailableBalance, &gateway_bean.GatewayBalance{Amount: amount, Currency: currency})
+	}
+	for currency, amount := range pending {
+		res.PendingBalance = append(res.PendingBalance, &gateway_bean.GatewayBalance{Amount: amount, Currency: currency})
+	}
+	return res
+}
@@ -0,0 +1,153 @@
+package remote
+
+import (
+	"context"
+	"unibee/internal/consts"
+	_interface "unibee/internal/interface"
+	"unibee/internal/logic/gateway/gateway_bean"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Remote forwards every call of GatewayInterface to the plugin service of the gateway
+type Remote struct {
+}
+
+func (r Remote) GatewayInfo(ctx context.Context) *_interface.GatewayInfo {
+	return &_interface.GatewayInfo{
+		Name:               "Remote Plugin",
+		Description:        "Use this method to connect the payment provider integrated by the out-of-process gateway plugin",
+		DisplayName:        "Remote Plugin",
+		GatewayWebsiteLink: "",
+		GatewayLogo:        "https://unibee.dev/wp-content/uploads/2024/05/logo-white.svg?ver=1718007070",
+		GatewayIcons:       []string{"https://unibee.dev/wp-content/uploads/2024/05/logo-white.svg?ver=1718007070"},
+		GatewayType:        consts.GatewayTypeCard,
+		Sort:               300,
+		PublicKeyName:      "Plugin Endpoint",
+		PrivateSecretName:  "Signing Secret",
+	}
+}
+
+func (r Remote) GatewayTest(ctx context.Context, req *_interface.GatewayTestReq) (icon string, gatewayType int64, err error) {
+	gateway := &entity.MerchantGateway{
+		GatewayName:   "remote",
+		GatewayKey:    req.Key,
+		GatewaySecret: req.Secret,
+		SubGateway:    req.SubGateway,
+	}
+	var res *TestResp
+	err = Call(ctx, gateway, MethodGatewayTest, &Request{TestReq: &_interface.GatewayTestReq{
+		SubGateway:          req.SubGateway,
+		GatewayPaymentTypes: req.GatewayPaymentTypes,
+	}}, &res)
+	if err != nil {
+		return "", consts.GatewayTypeCard, err
+	}
+	if res == nil {
+		return "", consts.GatewayTypeCard, gerror.New("invalid plugin response of gateway test")
+	}
+	return res.Icon, res.GatewayType, nil
+}
+
+func (r Remote) GatewayUserCreate(ctx context.Context, gateway *entity.MerchantGateway, user *entity.UserAccount) (res *gateway_bean.GatewayUserCreateResp, err error) {
+	err = Call(ctx, gateway, MethodGatewayUserCreate, &Request{User: pluginUser(user)}, &res)
+	return res, err
+}
+
+func (r Remote) GatewayUserDetailQuery(ctx context.Context, gateway *entity.MerchantGateway, gatewayUserId string) (res *gateway_bean.GatewayUserDetailQueryResp, err error) {
+	err = Call(ctx, gateway, MethodGatewayUserDetailQuery, &Request{GatewayUserId: gatewayUserId}, &res)
+	return res, err
+}
+
+func (r Remote) GatewayMerchantBalancesQuery(ctx context.Context, gateway *entity.MerchantGateway) (res *gateway_bean.GatewayMerchantBalanceQueryResp, err error) {
+	err = Call(ctx, gateway, MethodGatewayMerchantBalancesQuery, &Request{}, &res)
+	return res, err
+}
+
+func (r Remote) GatewayUserAttachPaymentMethodQuery(ctx context.Context, gateway *entity.MerchantGateway, userId uint64, gatewayPaymentMethod string) (res *gateway_bean.GatewayUserAttachPaymentMethodResp, err error) {
+	res = &gateway_bean.GatewayUserAttachPaymentMethodResp{}
+	err = Call(ctx, gateway, MethodGatewayUserAttachPaymentMethodQuery, &Request{UserId: userId, GatewayPaymentMethod: gatewayPaymentMethod}, res)
+	return res, err
+}
+
+func (r Remote) GatewayUserDeAttachPaymentMethodQuery(ctx context.Context, gateway *entity.MerchantGateway, userId uint64, gatewayPaymentMethod string) (res *gateway_bean.GatewayUserDeAttachPaymentMethodResp, err error) {
+	res = &gateway_bean.GatewayUserDeAttachPaymentMethodResp{}
+	err = Call(ctx, gateway, MethodGatewayUserDeAttachPaymentMethodQuery, &Request{UserId: userId, GatewayPaymentMethod: gatewayPaymentMethod}, res)
+	return res, err
+}
+
+func (r Remote) GatewayUserPaymentMethodListQuery(ctx context.Context, gateway *entity.MerchantGateway, req *gateway_bean.GatewayUserPaymentMethodReq) (res *gateway_bean.GatewayUserPaymentMethodListResp, err error) {
+	err = Call(ctx, gateway, MethodGatewayUserPaymentMethodListQuery, &Request{PaymentMethodReq: req}, &res)
+	return res, err
+}
+
+func (r Remote) GatewayUserCreateAndBindPaymentMethod(ctx context.Context, gateway *entity.MerchantGateway, userId uint64, currency string, metadata map[string]interface{}) (res *gateway_bean.GatewayUserPaymentMethodCreateAndBindResp, err error) {
+	err = Call(ctx, gateway, MethodGatewayUserCreateAndBindPaymentMethod, &Request{UserId: userId, Currency: currency, Metadata: metadata}, &res)
+	return res, err
+}
+
+func (r Remote) GatewayNewPayment(ctx context.Context, gateway *entity.MerchantGateway, createPayContext *gateway_bean.GatewayNewPaymentReq) (res *gateway_bean.GatewayNewPaymentResp, err error) {
+	err = Call(ctx, gateway, MethodGatewayNewPayment, &Request{NewPaymentReq: pluginNewPaymentReq(createPayContext)}, &res)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, gerror.New("invalid plugin response of new payment")
+	}
+	res.Payment = createPayContext.Pay
+	return res, nil
+}
+
+func (r Remote) GatewayCapture(ctx context.Context, gateway *entity.MerchantGateway, payment *entity.Payment) (res *gateway_bean.GatewayPaymentCaptureResp, err error) {
+	err = Call(ctx, gateway, MethodGatewayCapture, &Request{Payment: pluginPayment(payment)}, &res)
+	return res, err
+}
+
+func (r Remote) GatewayCancel(ctx context.Context, gateway *entity.MerchantGateway, payment *entity.Payment) (res *gateway_bean.GatewayPaymentCancelResp, err error) {
+	err = Call(ctx, gateway, MethodGatewayCancel, &Request{Payment: pluginPayment(payment)}, &res)
+	return res, err
+}
+
+func (r Remote) GatewayCryptoFiatTrans(ctx context.Context, from *gateway_bean.GatewayCryptoFromCurrencyAmountDetailReq) (to *gateway_bean.GatewayCryptoToCurrencyAmountDetailRes, err error) {
+	if from == nil || from.Gateway == nil {
+		return nil, gerror.New("gateway not found")
+	}
+	err = Call(ctx, from.Gateway, MethodGatewayCryptoFiatTrans, &Request{CryptoReq: &CryptoReq{
+		Amount:         from.Amount,
+		Currency:       from.Currency,
+		CryptoCurrency: from.CryptoCurrency,
+		CountryCode:    from.CountryCode,
+	}}, &to)
+	return to, err
+}
+
+func (r Remote) GatewayPaymentList(ctx context.Context, gateway *entity.MerchantGateway, listReq *gateway_bean.GatewayPaymentListReq) (res []*gateway_bean.GatewayPaymentRo, err error) {
+	err = Call(ctx, gateway, MethodGatewayPaymentList, &Request{PaymentListReq: listReq}, &res)
+	return res, err
+}
+
+func (r Remote) GatewayPaymentDetail(ctx context.Context, gateway *entity.MerchantGateway, gatewayPaymentId string, payment *entity.Payment) (res *gateway_bean.GatewayPaymentRo, err error) {
+	err = Call(ctx, gateway, MethodGatewayPaymentDetail, &Request{GatewayPaymentId: gatewayPaymentId, Payment: pluginPayment(payment)}, &res)
+	return res, err
+}
+
+func (r Remote) GatewayRefundList(ctx context.Context, gateway *entity.MerchantGateway, gatewayPaymentId string) (res []*gateway_bean.GatewayPaymentRefundResp, err error) {
+	err = Call(ctx, gateway, MethodGatewayRefundList, &Request{GatewayPaymentId: gatewayPaymentId}, &res)
+	return res, err
+}
+
+func (r Remote) GatewayRefundDetail(ctx context.Context, gateway *entity.MerchantGateway, gatewayRefundId string, refund *entity.Refund) (res *gateway_bean.GatewayPaymentRefundResp, err error) {
+	err = Call(ctx, gateway, MethodGatewayRefundDetail, &Request{GatewayRefundId: gatewayRefundId, Refund: pluginRefund(refund)}, &res)
+	return res, err
+}
+
+func (r Remote) GatewayRefund(ctx context.Context, gateway *entity.MerchantGateway, createPaymentRefundContext *gateway_bean.GatewayNewPaymentRefundReq) (res *gateway_bean.GatewayPaymentRefundResp, err error) {
+	err = Call(ctx, gateway, MethodGatewayRefund, &Request{NewRefundReq: pluginNewRefundReq(createPaymentRefundContext)}, &res)
+	return res, err
+}
+
+func (r Remote) GatewayRefundCancel(ctx context.Context, gateway *entity.MerchantGateway, payment *entity.Payment, refund *entity.Refund) (res *gateway_bean.GatewayPaymentRefundResp, err error) {
+	err = Call(ctx, gateway, MethodGatewayRefundCancel, &Request{Payment: pluginPayment(payment), Refund: pluginRefund(refund)}, &res)
+	return res, err
+}
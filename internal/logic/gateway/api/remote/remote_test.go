@@ -0,0 +1,131 @@
+package remote_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+	"unibee/internal/logic/gateway/api/remote"
+	"unibee/internal/logic/gateway/api/remote/conformance"
+	"unibee/internal/logic/gateway/api/remote/reference"
+	"unibee/internal/logic/gateway/util"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignature(t *testing.T) {
+	t.Run("Test for Signature Verify", func(t *testing.T) {
+		body := []byte(`{"gateway":{"id":1}}`)
+		now := time.Now().Unix()
+		header := remote.Sign(body, now, "secret")
+		require.Nil(t, remote.VerifySignature(body, "secret", header, remote.SignatureTolerance, now))
+		require.NotNil(t, remote.VerifySignature(body, "other", header, remote.SignatureTolerance, now))
+		require.NotNil(t, remote.VerifySignature([]byte(`{"gateway":{"id":2}}`), "secret", header, remote.SignatureTolerance, now))
+		require.NotNil(t, remote.VerifySignature(body, "secret", header, remote.SignatureTolerance, now+remote.SignatureTolerance+1))
+	})
+}
+
+func TestEndpoint(t *testing.T) {
+	ctx := context.Background()
+	t.Run("Test for Https Required", func(t *testing.T) {
+		err := remote.Call(ctx, &entity.MerchantGateway{Id: 1, GatewayKey: "http://plugin.unibee.dev", GatewaySecret: "secret"}, remote.MethodGatewayMerchantBalancesQuery, nil, nil)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "https")
+	})
+	t.Run("Test for Internal Address Rejected", func(t *testing.T) {
+		for _, endpoint := range []string{"https://127.0.0.1:1", "https://10.0.0.1:1", "https://169.254.169.254", "https://[::1]:1"} {
+			err := remote.Call(ctx, &entity.MerchantGateway{Id: 1, GatewayKey: endpoint, GatewaySecret: "secret"}, remote.MethodGatewayMerchantBalancesQuery, nil, nil)
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), "not allowed", endpoint)
+		}
+	})
+	t.Run("Test for Unavailable Status", func(t *testing.T) {
+		remote.AllowLocalEndpoint = true
+		defer func() {
+			remote.AllowLocalEndpoint = false
+		}()
+		var status = http.StatusServiceUnavailable
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		defer server.Close()
+		gateway := &entity.MerchantGateway{Id: 1, GatewayKey: server.URL, GatewaySecret: "secret"}
+		err := remote.Call(ctx, gateway, remote.MethodGatewayMerchantBalancesQuery, nil, nil)
+		require.NotNil(t, err)
+		require.True(t, util.IsGatewayUnavailable(err))
+		status = http.StatusTooManyRequests
+		require.True(t, util.IsGatewayUnavailable(remote.Call(ctx, gateway, remote.MethodGatewayMerchantBalancesQuery, nil, nil)))
+		// the plugin may have accepted the request
+		status = http.StatusInternalServerError
+		err = remote.Call(ctx, gateway, remote.MethodGatewayMerchantBalancesQuery, nil, nil)
+		require.NotNil(t, err)
+		require.False(t, util.IsGatewayUnavailable(err))
+	})
+}
+
+func TestRequestData(t *testing.T) {
+	ctx := context.Background()
+	remote.AllowLocalEndpoint = true
+	defer func() {
+		remote.AllowLocalEndpoint = false
+	}()
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		_ = json.NewEncoder(w).Encode(&remote.Response{Code: remote.CodeSuccess, Data: json.RawMessage(`{"gatewayUserId":"ref_user_1"}`)})
+	}))
+	defer server.Close()
+	gateway := &entity.MerchantGateway{Id: 1, MerchantId: 2, GatewayName: "remote", GatewayKey: server.URL, GatewaySecret: "signing_secret", WebhookSecret: "webhook_secret", BankData: "bank_data"}
+	user := &entity.UserAccount{Id: 10, MerchantId: 2, Email: "user@unibee.dev", Password: "password_hash", Phone: "+100000", Address: "street 1"}
+	_, err := remote.Remote{}.GatewayUserCreate(ctx, gateway, user)
+	require.Nil(t, err)
+	require.Contains(t, string(body), "user@unibee.dev")
+	for _, one := range []string{"password_hash", "+100000", "street 1", "signing_secret", "webhook_secret", "bank_data", server.URL} {
+		require.NotContains(t, string(body), one)
+	}
+	payment := &entity.Payment{PaymentId: "pay_1", GatewayPaymentId: "ref_pay_1", PaymentData: "payment_data", Token: "payment_token"}
+	refund := &entity.Refund{RefundId: "re_1", GatewayRefundId: "ref_refund_1", MetaData: "refund_meta"}
+	_, _ = remote.Remote{}.GatewayRefundCancel(ctx, gateway, payment, refund)
+	require.Contains(t, string(body), "ref_pay_1")
+	require.Contains(t, string(body), "ref_refund_1")
+	for _, one := range []string{"payment_data", "payment_token", "refund_meta"} {
+		require.NotContains(t, string(body), one)
+	}
+}
+
+// TestConformance runs the conformance harness against the reference plugin,
+// set UNIBEE_REMOTE_PLUGIN_ENDPOINT and UNIBEE_REMOTE_PLUGIN_SECRET to run it against another plugin
+func TestConformance(t *testing.T) {
+	endpoint := os.Getenv("UNIBEE_REMOTE_PLUGIN_ENDPOINT")
+	secret := os.Getenv("UNIBEE_REMOTE_PLUGIN_SECRET")
+	var notification func(eventType string, gatewayId string) *remote.HttpRequest
+	if len(endpoint) == 0 {
+		secret = "reference_secret"
+		remote.AllowLocalEndpoint = true
+		defer func() {
+			remote.AllowLocalEndpoint = false
+		}()
+		server := httptest.NewServer(reference.NewPlugin(secret))
+		defer server.Close()
+		endpoint = server.URL
+		notification = func(eventType string, gatewayId string) *remote.HttpRequest {
+			body, _ := json.Marshal(&reference.Notification{Type: eventType, Id: gatewayId, Status: "success"})
+			return &remote.HttpRequest{
+				Method:  http.MethodPost,
+				Path:    "/payment/gateway_webhook_entry/1/notifications",
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    body,
+			}
+		}
+	}
+	conformance.Run(t, &conformance.Options{
+		Endpoint:     endpoint,
+		Secret:       secret,
+		Notification: notification,
+	})
+}
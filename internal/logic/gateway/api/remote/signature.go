@@ -0,0 +1,60 @@
+package remote
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func signPayload(body []byte, timestamp int64, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp)))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Sign returns the UniBee-Plugin-Signature header of the request body as t=<timestamp>,v1=<signature>
+func Sign(body []byte, timestamp int64, secret string) string {
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, signPayload(body, timestamp, secret))
+}
+
+// VerifySignature verifies the UniBee-Plugin-Signature header made by Sign, used by the plugin side
+func VerifySignature(body []byte, secret string, header string, tolerance int64, timeNow int64) error {
+	if header == "" {
+		return errors.New("missing signature header")
+	}
+	if secret == "" {
+		return errors.New("missing secret key")
+	}
+	var timestamp int64 = 0
+	var signature = ""
+	for _, part := range strings.Split(header, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		if key == "t" {
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return errors.New("invalid signature timestamp")
+			}
+			timestamp = parsed
+		} else if key == "v1" {
+			signature = value
+		}
+	}
+	if timestamp <= 0 {
+		return errors.New("missing signature timestamp")
+	}
+	if timeNow-timestamp > tolerance || timestamp-timeNow > tolerance {
+		return errors.New("signature timestamp out of tolerance")
+	}
+	if !hmac.Equal([]byte(signPayload(body, timestamp, secret)), []byte(signature)) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
@@ -33,6 +33,7 @@ type GatewayNewPaymentReq struct {
 	GatewayCurrencyExchange *GatewayCurrencyExchange `json:"gatewayCurrencyExchange"`
 	ExchangeAmount          int64                    `json:"exchangeAmount"           description:"exchange_amount, cent"`
 	ExchangeCurrency        string                   `json:"exchangeCurrency"         description:"exchange_currency"`
+	GatewayChosen           bool                     `json:"gatewayChosen"            description:"gateway chosen by the caller explicitly, never routed or failover"`
 }
 
 func (c *GatewayNewPaymentReq) GetInvoiceSingleProductNameAndDescription() (name string, description string) {
@@ -0,0 +1,222 @@
+package routing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	_interface "unibee/internal/interface"
+	"unibee/internal/logic/gateway/gateway_bean"
+	"unibee/internal/logic/merchant_config"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+	"unibee/utility/unibee"
+)
+
+const (
+	GatewayRouting = "GatewayRouting"
+)
+
+const (
+	RoutingMaxAttempts = 5
+	RoutingMaxRules    = 50
+)
+
+func defaultGatewayRouting() *bean.GatewayRouting {
+	return &bean.GatewayRouting{
+		Enable:      false,
+		Failover:    true,
+		MaxAttempts: 3,
+		Rules:       make([]*bean.GatewayRoutingRule, 0),
+	}
+}
+
+func GetMerchantGatewayRouting(ctx context.Context, merchantId uint64) *bean.GatewayRouting {
+	routing := defaultGatewayRouting()
+	routingConfig := merchant_config.GetMerchantConfig(ctx, merchantId, GatewayRouting)
+	if routingConfig != nil && len(routingConfig.ConfigValue) > 0 {
+		var one *bean.GatewayRouting
+		err := utility.UnmarshalFromJsonString(routingConfig.ConfigValue, &one)
+		if err == nil && one != nil {
+			routing = one
+		}
+	}
+	if routing.Rules == nil {
+		routing.Rules = make([]*bean.GatewayRoutingRule, 0)
+	}
+	return routing
+}
+
+// CheckGatewayRouting verifies the routing rules of merchant and fills the default of the unset fields
+func CheckGatewayRouting(ctx context.Context, merchantId uint64, routing *bean.GatewayRouting) {
+	utility.Assert(routing != nil, "routing is nil")
+	utility.Assert(routing.MaxAttempts >= 0 && routing.MaxAttempts <= RoutingMaxAttempts, fmt.Sprintf("maxAttempts should between 1 and %d", RoutingMaxAttempts))
+	utility.Assert(len(routing.Rules) <= RoutingMaxRules, fmt.Sprintf("rules should not more than %d", RoutingMaxRules))
+	if routing.MaxAttempts == 0 {
+		routing.MaxAttempts = defaultGatewayRouting().MaxAttempts
+	}
+	if routing.Rules == nil {
+		routing.Rules = make([]*bean.GatewayRoutingRule, 0)
+	}
+	for i, rule := range routing.Rules {
+		utility.Assert(rule != nil, fmt.Sprintf("rule %d is nil", i))
+		utility.Assert(rule.MinAmount >= 0 && rule.MaxAmount >= 0, fmt.Sprintf("rule %d amount should not less than 0", i))
+		utility.Assert(rule.MaxAmount == 0 || rule.MaxAmount >= rule.MinAmount, fmt.Sprintf("rule %d maxAmount should not less than minAmount", i))
+		utility.Assert(len(rule.GatewayIds) > 0, fmt.Sprintf("rule %d gatewayIds is empty", i))
+		for j, currency := range rule.Currencies {
+			rule.Currencies[j] = strings.ToUpper(strings.TrimSpace(currency))
+		}
+		for j, countryCode := range rule.CountryCodes {
+			rule.CountryCodes[j] = strings.ToUpper(strings.TrimSpace(countryCode))
+		}
+		for _, gatewayId := range rule.GatewayIds {
+			gateway := query.GetGatewayById(ctx, gatewayId)
+			utility.Assert(gateway != nil && gateway.MerchantId == merchantId, fmt.Sprintf("rule %d gateway not found:%d", i, gatewayId))
+			utility.Assert(IsGatewayRoutable(gateway), fmt.Sprintf("rule %d gateway not support routing:%d", i, gatewayId))
+		}
+	}
+}
+
+// IsGatewayRoutable tells whether payments can be routed to the gateway or failover from it, crypto, wire transfer and credit
+// gateway are chosen by user explicitly
+func IsGatewayRoutable(gateway *entity.MerchantGateway) bool {
+	if gateway == nil || gateway.IsDeleted > 0 {
+		return false
+	}
+	return gateway.GatewayType != consts.GatewayTypeCrypto &&
+		gateway.GatewayType != consts.GatewayTypeWireTransfer &&
+		gateway.GatewayType != consts.GatewayTypeCredit
+}
+
+type Payment struct {
+	Currency    string
+	CountryCode string
+	Amount      int64
+}
+
+func isGatewaySupportPayment(gateway *entity.MerchantGateway, payment *Payment) bool {
+	if !IsGatewayRoutable(gateway) {
+		return false
+	}
+	if gateway.MinimumAmount > 0 && payment.Amount < gateway.MinimumAmount {
+		return false
+	}
+	if len(gateway.CountryConfig) > 0 && len(payment.CountryCode) > 0 {
+		var countryConfig map[string]bool
+		_ = utility.UnmarshalFromJsonString(gateway.CountryConfig, &countryConfig)
+		if support, ok := countryConfig[payment.CountryCode]; ok && !support {
+			return false
+		}
+	}
+	return true
+}
+
+func isRuleMatch(rule *bean.GatewayRoutingRule, gatewayType int64, payment *Payment) bool {
+	if rule == nil {
+		return false
+	}
+	if len(rule.Currencies) > 0 && !utility.IsStringInArray(rule.Currencies, payment.Currency) {
+		return false
+	}
+	if len(rule.CountryCodes) > 0 && !utility.IsStringInArray(rule.CountryCodes, payment.CountryCode) {
+		return false
+	}
+	if rule.MinAmount > 0 && payment.Amount < rule.MinAmount {
+		return false
+	}
+	if rule.MaxAmount > 0 && payment.Amount > rule.MaxAmount {
+		return false
+	}
+	if len(rule.PaymentTypes) > 0 && !utility.IsInt64InArray(rule.PaymentTypes, gatewayType) {
+		return false
+	}
+	return true
+}
+
+// SortCandidateGateways returns the gateways to attempt the payment in order, the gateways of the first matched rule come
+// first, then the original gateway, then other gateways ordered by sort value(the KEY_MERCHANT_GATEWAY_SORT value of
+// gateway name if gateway has no sort) when failover enabled
+func SortCandidateGateways(routing *bean.GatewayRouting, original *entity.MerchantGateway, gateways []*entity.MerchantGateway, sortMap map[string]int64, payment *Payment) []*entity.MerchantGateway {
+	if routing == nil || !routing.Enable || !IsGatewayRoutable(original) {
+		return []*entity.MerchantGateway{original}
+	}
+	gatewayMap := make(map[uint64]*entity.MerchantGateway)
+	for _, one := range gateways {
+		if one != nil && one.MerchantId == original.MerchantId && isGatewaySupportPayment(one, payment) {
+			gatewayMap[one.Id] = one
+		}
+	}
+	var candidates []*entity.MerchantGateway
+	var added = make(map[uint64]bool)
+	var appendCandidate = func(one *entity.MerchantGateway) {
+		if one != nil && !added[one.Id] {
+			added[one.Id] = true
+			candidates = append(candidates, one)
+		}
+	}
+	for _, rule := range routing.Rules {
+		if isRuleMatch(rule, original.GatewayType, payment) {
+			for _, gatewayId := range rule.GatewayIds {
+				appendCandidate(gatewayMap[gatewayId])
+			}
+			break
+		}
+	}
+	appendCandidate(original)
+	if routing.Failover {
+		var others []*entity.MerchantGateway
+		for _, one := range gatewayMap {
+			others = append(others, one)
+		}
+		var sortValue = func(one *entity.MerchantGateway) int64 {
+			if one.EnumKey > 0 {
+				return one.EnumKey
+			}
+			return sortMap[one.GatewayName]
+		}
+		sort.Slice(others, func(i, j int) bool {
+			if sortValue(others[i]) == sortValue(others[j]) {
+				return others[i].Id < others[j].Id
+			}
+			return sortValue(others[i]) < sortValue(others[j])
+		})
+		for _, one := range others {
+			appendCandidate(one)
+		}
+	} else {
+		candidates = candidates[:1]
+	}
+	var maxAttempts = routing.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+	if len(candidates) > maxAttempts {
+		candidates = candidates[:maxAttempts]
+	}
+	return candidates
+}
+
+// GetPaymentCandidateGateways returns the gateways to attempt the new payment in order, the first one is the gateway
+// the payment chosen, only the gateway of the payment returned if routing disabled or the payment bound to a payment method
+func GetPaymentCandidateGateways(ctx context.Context, createPayContext *gateway_bean.GatewayNewPaymentReq) []*entity.MerchantGateway {
+	if len(createPayContext.GatewayPaymentMethod) > 0 || createPayContext.GatewayChosen {
+		return []*entity.MerchantGateway{createPayContext.Gateway}
+	}
+	routing := GetMerchantGatewayRouting(ctx, createPayContext.Pay.MerchantId)
+	if !routing.Enable {
+		return []*entity.MerchantGateway{createPayContext.Gateway}
+	}
+	var sortMap = make(map[string]int64)
+	sortConfig := merchant_config.GetMerchantConfig(ctx, createPayContext.Pay.MerchantId, _interface.KEY_MERCHANT_GATEWAY_SORT)
+	if sortConfig != nil {
+		_ = utility.UnmarshalFromJsonString(sortConfig.ConfigValue, &sortMap)
+	}
+	return SortCandidateGateways(routing, createPayContext.Gateway, query.GetMerchantGatewayList(ctx, createPayContext.Pay.MerchantId, unibee.Bool(false)), sortMap, &Payment{
+		Currency:    strings.ToUpper(createPayContext.Pay.Currency),
+		CountryCode: strings.ToUpper(createPayContext.Pay.CountryCode),
+		Amount:      createPayContext.Pay.TotalAmount,
+	})
+}
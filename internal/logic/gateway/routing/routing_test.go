@@ -0,0 +1,56 @@
+package routing
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+)
+
+func gatewayIds(list []*entity.MerchantGateway) []uint64 {
+	var ids []uint64
+	for _, one := range list {
+		ids = append(ids, one.Id)
+	}
+	return ids
+}
+
+func TestSortCandidateGateways(t *testing.T) {
+	stripe := &entity.MerchantGateway{Id: 1, MerchantId: 1, GatewayName: "stripe", GatewayType: consts.GatewayTypeCard, EnumKey: 10}
+	adyen := &entity.MerchantGateway{Id: 2, MerchantId: 1, GatewayName: "adyen", GatewayType: consts.GatewayTypeCard, CountryConfig: `{"CN":false}`}
+	paypal := &entity.MerchantGateway{Id: 3, MerchantId: 1, GatewayName: "paypal", GatewayType: consts.GatewayTypePaypal, EnumKey: 30, MinimumAmount: 500}
+	wire := &entity.MerchantGateway{Id: 4, MerchantId: 1, GatewayName: "wire_transfer", GatewayType: consts.GatewayTypeWireTransfer, EnumKey: 1}
+	gateways := []*entity.MerchantGateway{stripe, adyen, paypal, wire}
+	sortMap := map[string]int64{"adyen": 20}
+	payment := &Payment{Currency: "EUR", CountryCode: "DE", Amount: 1000}
+	routing := &bean.GatewayRouting{
+		Enable:      true,
+		Failover:    true,
+		MaxAttempts: 5,
+		Rules: []*bean.GatewayRoutingRule{
+			{Name: "big EUR", Currencies: []string{"EUR"}, MinAmount: 10000, GatewayIds: []uint64{3}},
+			{Name: "EUR card", Currencies: []string{"EUR"}, PaymentTypes: []int64{consts.GatewayTypeCard}, GatewayIds: []uint64{2}},
+		},
+	}
+	t.Run("Test for Routing Disabled", func(t *testing.T) {
+		require.Equal(t, []uint64{1}, gatewayIds(SortCandidateGateways(&bean.GatewayRouting{Enable: false, Failover: true, MaxAttempts: 3}, stripe, gateways, sortMap, payment)))
+	})
+	t.Run("Test for Rule Match And Failover", func(t *testing.T) {
+		require.Equal(t, []uint64{2, 1, 3}, gatewayIds(SortCandidateGateways(routing, stripe, gateways, sortMap, payment)))
+		require.Equal(t, []uint64{3, 1, 2}, gatewayIds(SortCandidateGateways(routing, stripe, gateways, sortMap, &Payment{Currency: "EUR", CountryCode: "DE", Amount: 20000})))
+	})
+	t.Run("Test for Fallback Sort", func(t *testing.T) {
+		require.Equal(t, []uint64{1, 2, 3}, gatewayIds(SortCandidateGateways(routing, stripe, gateways, sortMap, &Payment{Currency: "USD", CountryCode: "DE", Amount: 1000})))
+		require.Equal(t, []uint64{1}, gatewayIds(SortCandidateGateways(routing, stripe, gateways, sortMap, &Payment{Currency: "USD", CountryCode: "CN", Amount: 100})))
+	})
+	t.Run("Test for Failover Disabled And Max Attempts", func(t *testing.T) {
+		one := &bean.GatewayRouting{Enable: true, Failover: false, MaxAttempts: 5, Rules: routing.Rules}
+		require.Equal(t, []uint64{2}, gatewayIds(SortCandidateGateways(one, stripe, gateways, sortMap, payment)))
+		one = &bean.GatewayRouting{Enable: true, Failover: true, MaxAttempts: 2, Rules: routing.Rules}
+		require.Equal(t, []uint64{2, 1}, gatewayIds(SortCandidateGateways(one, stripe, gateways, sortMap, payment)))
+	})
+	t.Run("Test for Not Routable Gateway", func(t *testing.T) {
+		require.Equal(t, []uint64{4}, gatewayIds(SortCandidateGateways(routing, wire, gateways, sortMap, payment)))
+	})
+}
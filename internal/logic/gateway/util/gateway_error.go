@@ -1,7 +1,38 @@
 package util
 
-import "github.com/gogf/gf/v2/errors/gcode"
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
 
 var (
 	GatewayError = gcode.New(70, "Gateway Failed", nil)
+	// GatewayUnavailable the gateway not reached or refused the request before accepting it, the payment is safe to fail over
+	GatewayUnavailable = gcode.New(71, "Gateway Unavailable", nil)
 )
+
+// IsGatewayUnavailable checks the error happened before the gateway accepted the request, dns or connect failure, or the
+// gateway answered 429 or 503, timeout after connected is not the one since the gateway may have accepted the payment
+func IsGatewayUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	code := gerror.Code(err).Code()
+	return code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable
+}
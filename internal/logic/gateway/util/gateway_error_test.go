@@ -0,0 +1,26 @@
+package util
+
+import (
+	"fmt"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/stretchr/testify/require"
+	"net"
+	"syscall"
+	"testing"
+)
+
+func TestGatewayUnavailable(t *testing.T) {
+	t.Run("Test for Pre Acceptance Error", func(t *testing.T) {
+		require.True(t, IsGatewayUnavailable(&net.DNSError{Err: "no such host", Name: "api.gateway.dev"}))
+		require.True(t, IsGatewayUnavailable(fmt.Errorf("post: %w", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED})))
+		require.True(t, IsGatewayUnavailable(gerror.NewCode(gcode.New(503, "503 Service Unavailable", nil), "503 Service Unavailable")))
+		require.True(t, IsGatewayUnavailable(gerror.NewCode(gcode.New(429, "429 Too Many Requests", nil), "429 Too Many Requests")))
+	})
+	t.Run("Test for Accepted Or Declined Error", func(t *testing.T) {
+		require.False(t, IsGatewayUnavailable(nil))
+		require.False(t, IsGatewayUnavailable(gerror.New("card declined")))
+		require.False(t, IsGatewayUnavailable(&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}))
+		require.False(t, IsGatewayUnavailable(gerror.NewCode(gcode.New(500, "500 Internal Server Error", nil), "500")))
+	})
+}
@@ -5,12 +5,18 @@ import (
 	"fmt"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gtime"
 	"net/http"
+	"strconv"
+	"strings"
 	"unibee/internal/consts"
 	"unibee/internal/logic/gateway/api"
+	"unibee/internal/logic/gateway/api/alipay/api/model"
+	"unibee/internal/logic/gateway/api/alipay/api/request/notify"
 	"unibee/internal/logic/gateway/api/log"
 	"unibee/internal/logic/gateway/gateway_bean"
 	"unibee/internal/logic/gateway/util"
+	"unibee/internal/logic/payment/dispute"
 	handler2 "unibee/internal/logic/payment/handler"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
@@ -37,7 +43,39 @@ func (c AlipayWebhook) GatewayWebhook(r *ghttp.Request, gateway *entity.Merchant
 	}
 	g.Log().Info(r.Context(), "Receive_Webhook_Channel:", gateway.GatewayName, " hook:", jsonData.String())
 	var responseBack = http.StatusOK
-	if jsonData.Contains("paymentId") {
+	if jsonData.Contains("disputeId") {
+		var disputeNotify *notify.AlipayDisputeNotify
+		err = jsonData.Scan(&disputeNotify)
+		if err == nil && disputeNotify != nil {
+			var currency = ""
+			var amount int64 = 0
+			if disputeNotify.DisputeAmount != nil {
+				currency = disputeNotify.DisputeAmount.Currency
+				amount, _ = strconv.ParseInt(disputeNotify.DisputeAmount.Value, 10, 64)
+			}
+			var evidenceDueTime int64 = 0
+			if dueTime, parseErr := gtime.StrToTime(disputeNotify.DefenseDueTime); parseErr == nil && dueTime != nil {
+				evidenceDueTime = dueTime.Timestamp()
+			}
+			_, err = dispute.HandleGatewayDispute(r.Context(), &dispute.GatewayDisputeReq{
+				Gateway:          gateway,
+				GatewayDisputeId: disputeNotify.DisputeId,
+				PaymentId:        disputeNotify.PaymentRequestId,
+				GatewayPaymentId: disputeNotify.PaymentId,
+				Currency:         strings.ToUpper(currency),
+				DisputeAmount:    amount,
+				Status:           alipayDisputeStatus(disputeNotify),
+				GatewayStatus:    string(disputeNotify.DisputeNotificationType),
+				Reason:           disputeNotify.DisputeReasonMsg,
+				EvidenceDueTime:  evidenceDueTime,
+			})
+		}
+		if err != nil {
+			g.Log().Errorf(r.Context(), "Webhook Gateway:%s, Error HandleGatewayDispute: %s\n", gateway.GatewayName, err.Error())
+			r.Response.WriteHeader(http.StatusBadRequest)
+			responseBack = http.StatusBadRequest
+		}
+	} else if jsonData.Contains("paymentId") {
 		err = ProcessPaymentWebhook(r.Context(), jsonData.Get("paymentRequestId").String(), jsonData.Get("paymentId").String(), gateway)
 		if err != nil {
 			g.Log().Errorf(r.Context(), "Webhook Gateway:%s, Error ProcessPaymentWebhook: %s\n", gateway.GatewayName, err.Error())
@@ -135,3 +173,26 @@ func (c AlipayWebhook) GatewayRedirect(r *ghttp.Request, gateway *entity.Merchan
 		QueryPath: r.URL.RawQuery,
 	}, nil
 }
+
+func alipayDisputeStatus(disputeNotify *notify.AlipayDisputeNotify) int {
+	switch disputeNotify.DisputeNotificationType {
+	case model.DisputeNotificationType_DISPUTE_CREATED:
+		if disputeNotify.Defendable {
+			return consts.DisputeEvidenceNeeded
+		}
+		return consts.DisputeOpened
+	case model.DisputeNotificationType_DEFENSE_DUE_ALERT:
+		return consts.DisputeEvidenceNeeded
+	case model.DisputeNotificationType_DISPUTE_JUDGED:
+		if disputeNotify.DisputeJudgedResult == model.DisputeJudgedResult_ACCEPT_BY_CUSTOMER {
+			return consts.DisputeWon
+		}
+		return consts.DisputeLost
+	case model.DisputeNotificationType_DISPUTE_CANCELLED:
+		return consts.DisputeWon
+	case model.DisputeNotificationType_DISPUTE_ACCEPTED, model.RDR_RESOLVED:
+		return consts.DisputeLost
+	default:
+		return consts.DisputeOpened
+	}
+}
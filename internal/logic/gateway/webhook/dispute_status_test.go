@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v78"
+	"testing"
+	"unibee/internal/consts"
+	"unibee/internal/logic/gateway/api/alipay/api/model"
+	"unibee/internal/logic/gateway/api/alipay/api/request/notify"
+)
+
+func TestDisputeStatus(t *testing.T) {
+	t.Run("Test for Stripe Dispute Status", func(t *testing.T) {
+		require.Equal(t, consts.DisputeEvidenceNeeded, stripeDisputeStatus(stripe.DisputeStatusNeedsResponse))
+		require.Equal(t, consts.DisputeOpened, stripeDisputeStatus(stripe.DisputeStatusUnderReview))
+		require.Equal(t, consts.DisputeWon, stripeDisputeStatus(stripe.DisputeStatusWarningClosed))
+		require.Equal(t, consts.DisputeLost, stripeDisputeStatus(stripe.DisputeStatusLost))
+	})
+	t.Run("Test for Paypal Dispute Status", func(t *testing.T) {
+		require.Equal(t, consts.DisputeEvidenceNeeded, paypalDisputeStatus("WAITING_FOR_SELLER_RESPONSE", ""))
+		require.Equal(t, consts.DisputeOpened, paypalDisputeStatus("UNDER_REVIEW", ""))
+		require.Equal(t, consts.DisputeWon, paypalDisputeStatus("RESOLVED", "RESOLVED_SELLER_FAVOUR"))
+		require.Equal(t, consts.DisputeLost, paypalDisputeStatus("RESOLVED", "RESOLVED_BUYER_FAVOUR"))
+	})
+	t.Run("Test for Alipay Dispute Status", func(t *testing.T) {
+		require.Equal(t, consts.DisputeEvidenceNeeded, alipayDisputeStatus(&notify.AlipayDisputeNotify{DisputeNotificationType: model.DisputeNotificationType_DISPUTE_CREATED, Defendable: true}))
+		require.Equal(t, consts.DisputeOpened, alipayDisputeStatus(&notify.AlipayDisputeNotify{DisputeNotificationType: model.DisputeNotificationType_DEFENSE_SUPPLIED}))
+		require.Equal(t, consts.DisputeWon, alipayDisputeStatus(&notify.AlipayDisputeNotify{DisputeNotificationType: model.DisputeNotificationType_DISPUTE_JUDGED, DisputeJudgedResult: model.DisputeJudgedResult_ACCEPT_BY_CUSTOMER}))
+		require.Equal(t, consts.DisputeLost, alipayDisputeStatus(&notify.AlipayDisputeNotify{DisputeNotificationType: model.DisputeNotificationType_DISPUTE_ACCEPTED}))
+	})
+}
@@ -36,6 +36,7 @@ var GatewayWebhookNameMapping = map[string]_interface.GatewayWebhookInterface{
 	"alikassa":        &AliKassaWebhook{},
 	"blockonomics":    &BlockonomicsWebhook{},
 	"platega":         &PlategaWebhook{},
+	"remote":          &RemoteWebhook{},
 }
 
 type GatewayWebhookProxy struct {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gtime"
 	"net/http"
 	"strings"
 	"unibee/internal/cmd/config"
@@ -17,6 +18,7 @@ import (
 	"unibee/internal/logic/gateway/gateway_bean"
 	"unibee/internal/logic/gateway/util"
 	handler3 "unibee/internal/logic/invoice/handler"
+	"unibee/internal/logic/payment/dispute"
 	handler2 "unibee/internal/logic/payment/handler"
 	"unibee/internal/logic/payment/service"
 	"unibee/internal/logic/subscription/handler"
@@ -124,6 +126,9 @@ func (p PaypalWebhook) GatewayCheckAndSetupWebhook(ctx context.Context, gateway
 		{Name: "VAULT.PAYMENT-TOKEN.CREATED"},
 		{Name: "VAULT.PAYMENT-TOKEN.DELETED"},
 		{Name: "VAULT.PAYMENT-TOKEN.DELETION-INITIATED"},
+		{Name: "CUSTOMER.DISPUTE.CREATED"},
+		{Name: "CUSTOMER.DISPUTE.UPDATED"},
+		{Name: "CUSTOMER.DISPUTE.RESOLVED"},
 	}
 	var one *paypal.Webhook
 	for _, endpoint := range result.Webhooks {
@@ -289,7 +294,7 @@ func (p PaypalWebhook) GatewayWebhook(r *ghttp.Request, gateway *entity.Merchant
 				r.Response.WriteHeader(http.StatusBadRequest)
 				responseBack = http.StatusBadRequest
 			} else {
-				g.Log().Infof(r.Context(), "Webhook Gateway:%s-%d, Subscription updated for %d.", gateway.GatewayName, gateway.Id, resource.Get("id").String())
+				g.Log().Infof(r.Context(), "Webhook Gateway:%s-%d, Subscription updated for %s.", gateway.GatewayName, gateway.Id, resource.Get("id").String())
 				// Then define and call a func to handle the successful attachment of a PaymentMethod.
 				gatewayPaymentId := resource.Get("id").String()
 				payment := query.GetPaymentByGatewayPaymentId(r.Context(), gatewayPaymentId)
@@ -316,6 +321,37 @@ func (p PaypalWebhook) GatewayWebhook(r *ghttp.Request, gateway *entity.Merchant
 					responseBack = http.StatusBadRequest
 				}
 			}
+		case "CUSTOMER.DISPUTE.CREATED", "CUSTOMER.DISPUTE.UPDATED", "CUSTOMER.DISPUTE.RESOLVED":
+			resource := jsonData.GetJson("resource")
+			if resource == nil || !resource.Contains("dispute_id") {
+				g.Log().Errorf(r.Context(), "Webhook Gateway:%s-%d, Error parsing webhook resource is nil\n", gateway.GatewayName, gateway.Id)
+				r.Response.WriteHeader(http.StatusBadRequest)
+				responseBack = http.StatusBadRequest
+			} else {
+				g.Log().Infof(r.Context(), "Webhook Gateway:%s-%d, Event %s for Dispute %s\n", gateway.GatewayName, gateway.Id, eventType, resource.Get("dispute_id").String())
+				currency := resource.Get("dispute_amount.currency_code").String()
+				var evidenceDueTime int64 = 0
+				if dueTime, parseErr := gtime.StrToTime(resource.Get("seller_response_due_date").String()); parseErr == nil && dueTime != nil {
+					evidenceDueTime = dueTime.Timestamp()
+				}
+				_, err = dispute.HandleGatewayDispute(r.Context(), &dispute.GatewayDisputeReq{
+					Gateway:          gateway,
+					GatewayDisputeId: resource.Get("dispute_id").String(),
+					PaymentId:        resource.Get("disputed_transactions.0.custom").String(),
+					GatewayPaymentId: resource.Get("disputed_transactions.0.seller_transaction_id").String(),
+					Currency:         strings.ToUpper(currency),
+					DisputeAmount:    utility.ConvertDollarStrToCent(resource.Get("dispute_amount.value").String(), currency),
+					Status:           paypalDisputeStatus(resource.Get("status").String(), resource.Get("dispute_outcome.outcome_code").String()),
+					GatewayStatus:    resource.Get("status").String(),
+					Reason:           resource.Get("reason").String(),
+					EvidenceDueTime:  evidenceDueTime,
+				})
+				if err != nil {
+					g.Log().Errorf(r.Context(), "Webhook Gateway:%s-%d, HandleGatewayDispute error:%s\n", gateway.GatewayName, gateway.Id, err.Error())
+					r.Response.WriteHeader(http.StatusBadRequest)
+					responseBack = http.StatusBadRequest
+				}
+			}
 		case "VAULT.PAYMENT-TOKEN.CREATED", "VAULT.PAYMENT-TOKEN.DELETED", "VAULT.PAYMENT-TOKEN.DELETION-INITIATED":
 
 		default:
@@ -330,3 +366,17 @@ func (p PaypalWebhook) GatewayWebhook(r *ghttp.Request, gateway *entity.Merchant
 		return
 	}
 }
+
+func paypalDisputeStatus(status string, outcomeCode string) int {
+	switch status {
+	case "WAITING_FOR_SELLER_RESPONSE":
+		return consts.DisputeEvidenceNeeded
+	case "RESOLVED":
+		if outcomeCode == "RESOLVED_SELLER_FAVOUR" || outcomeCode == "CANCELED_BY_BUYER" || outcomeCode == "DENIED" {
+			return consts.DisputeWon
+		}
+		return consts.DisputeLost
+	default:
+		return consts.DisputeOpened
+	}
+}
@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	_gateway "unibee/internal/logic/gateway"
+	"unibee/internal/logic/gateway/api"
+	"unibee/internal/logic/gateway/api/remote"
+	"unibee/internal/logic/gateway/gateway_bean"
+	handler2 "unibee/internal/logic/payment/handler"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+// RemoteWebhook forwards the webhook and redirect of the gateway to the plugin service, the payment and refund
+// the plugin reports changed are handled as other gateways do
+type RemoteWebhook struct {
+}
+
+func (w RemoteWebhook) GatewayCheckAndSetupWebhook(ctx context.Context, gateway *entity.MerchantGateway) (err error) {
+	return remote.Call(ctx, gateway, remote.MethodGatewayCheckAndSetupWebhook, &remote.Request{WebhookUrl: _gateway.GetPaymentWebhookEntranceUrl(gateway.Id)}, nil)
+}
+
+func (w RemoteWebhook) GatewayWebhook(r *ghttp.Request, gateway *entity.MerchantGateway) {
+	ctx := r.Context()
+	var res *remote.WebhookResp
+	err := remote.Call(ctx, gateway, remote.MethodGatewayWebhook, &remote.Request{HttpRequest: remote.NewHttpRequest(r)}, &res)
+	if err != nil {
+		g.Log().Errorf(ctx, "Webhook Gateway:%s, plugin error:%s", gateway.GatewayName, err.Error())
+		r.Response.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	var responseBack = http.StatusOK
+	if res != nil {
+		for _, event := range res.Events {
+			if event == nil {
+				continue
+			}
+			if event.Type == remote.WebhookEventPayment {
+				if isGatewayPayment(query.GetPaymentByPaymentId(ctx, event.PaymentId), gateway) {
+					err = ProcessPaymentWebhook(ctx, event.PaymentId, event.GatewayPaymentId, gateway)
+				} else {
+					err = gerror.Newf("payment not found of gateway:%s", event.PaymentId)
+				}
+			} else if event.Type == remote.WebhookEventRefund {
+				err = processRemoteRefundWebhook(ctx, event.GatewayRefundId, gateway)
+			} else {
+				g.Log().Infof(ctx, "Webhook Gateway:%s, unhandled event type:%s", gateway.GatewayName, event.Type)
+				continue
+			}
+			if err != nil {
+				g.Log().Errorf(ctx, "Webhook Gateway:%s, Type:%s process error:%s", gateway.GatewayName, event.Type, err.Error())
+				responseBack = http.StatusInternalServerError
+			}
+		}
+	}
+	if responseBack == http.StatusOK && res != nil && res.Response != nil && res.Response.Status > 0 {
+		responseBack = res.Response.Status
+	}
+	if res != nil && res.Response != nil && len(res.Response.ContentType) > 0 {
+		r.Response.Header().Set("Content-Type", res.Response.ContentType)
+	}
+	r.Response.WriteHeader(responseBack)
+	if res != nil && res.Response != nil {
+		r.Response.Write(res.Response.Body)
+	}
+}
+
+func (w RemoteWebhook) GatewayRedirect(r *ghttp.Request, gateway *entity.MerchantGateway) (res *gateway_bean.GatewayRedirectResp, err error) {
+	ctx := r.Context()
+	var redirect *remote.RedirectResp
+	err = remote.Call(ctx, gateway, remote.MethodGatewayRedirect, &remote.Request{HttpRequest: remote.NewHttpRequest(r)}, &redirect)
+	if err != nil {
+		return nil, err
+	}
+	if redirect == nil {
+		return nil, gerror.New("invalid plugin response of redirect")
+	}
+	paymentId := redirect.PaymentId
+	if len(paymentId) == 0 {
+		paymentId = r.Get("paymentId").String()
+	}
+	payment := query.GetPaymentByPaymentId(ctx, paymentId)
+	if !isGatewayPayment(payment, gateway) {
+		return nil, gerror.Newf("payment not found:%s", paymentId)
+	}
+	if len(redirect.ReturnUrl) == 0 {
+		redirect.ReturnUrl = payment.ReturnUrl
+	}
+	return &gateway_bean.GatewayRedirectResp{
+		Payment:   payment,
+		Status:    redirect.Status,
+		Message:   redirect.Message,
+		ReturnUrl: redirect.ReturnUrl,
+		Success:   redirect.Success,
+		QueryPath: redirect.QueryPath,
+	}, nil
+}
+
+func (w RemoteWebhook) GatewayNewPaymentMethodRedirect(r *ghttp.Request, gateway *entity.MerchantGateway) (err error) {
+	return remote.Call(r.Context(), gateway, remote.MethodGatewayNewPaymentMethodRedirect, &remote.Request{HttpRequest: remote.NewHttpRequest(r)}, nil)
+}
+
+// isGatewayPayment checks the payment reported by the plugin is created by the gateway, the plugin is set up by the merchant
+// and never trusted with the payment of other gateway or merchant
+func isGatewayPayment(payment *entity.Payment, gateway *entity.MerchantGateway) bool {
+	return payment != nil && payment.GatewayId == gateway.Id && payment.MerchantId == gateway.MerchantId
+}
+
+func processRemoteRefundWebhook(ctx context.Context, gatewayRefundId string, gateway *entity.MerchantGateway) error {
+	refundDetail, err := api.GetGatewayServiceProvider(ctx, gateway.Id).GatewayRefundDetail(ctx, gateway, gatewayRefundId, nil)
+	if err != nil {
+		return err
+	}
+	refund := query.GetRefundByGatewayRefundId(ctx, refundDetail.GatewayRefundId)
+	if refund == nil || refund.GatewayId != gateway.Id || refund.MerchantId != gateway.MerchantId {
+		return gerror.Newf("refund not found of gateway:%s", refundDetail.GatewayRefundId)
+	}
+	return handler2.HandleRefundWebhookEvent(ctx, refundDetail)
+}
@@ -25,6 +25,7 @@ import (
 	"unibee/internal/logic/gateway/gateway_bean"
 	"unibee/internal/logic/gateway/util"
 	handler3 "unibee/internal/logic/invoice/handler"
+	"unibee/internal/logic/payment/dispute"
 	handler2 "unibee/internal/logic/payment/handler"
 	"unibee/internal/logic/subscription/handler"
 	"unibee/internal/logic/user/sub_update"
@@ -93,6 +94,9 @@ func (s StripeWebhook) GatewayCheckAndSetupWebhook(ctx context.Context, gateway
 				stripe.String("payment_intent.requires_action"),
 				stripe.String("checkout.session.completed"),
 				stripe.String("charge.refund.updated"),
+				stripe.String("charge.dispute.created"),
+				stripe.String("charge.dispute.updated"),
+				stripe.String("charge.dispute.closed"),
 			},
 			Metadata:   map[string]string{"MerchantId": strconv.FormatUint(gateway.MerchantId, 10)},
 			URL:        stripe.String(webhookUrl),
@@ -130,6 +134,9 @@ func (s StripeWebhook) GatewayCheckAndSetupWebhook(ctx context.Context, gateway
 				stripe.String("payment_intent.requires_action"),
 				stripe.String("checkout.session.completed"),
 				stripe.String("charge.refund.updated"),
+				stripe.String("charge.dispute.created"),
+				stripe.String("charge.dispute.updated"),
+				stripe.String("charge.dispute.closed"),
 			},
 			URL:      stripe.String(webhookUrl),
 			Metadata: map[string]string{"MerchantId": strconv.FormatUint(gateway.MerchantId, 10)},
@@ -238,6 +245,41 @@ func (s StripeWebhook) GatewayWebhook(r *ghttp.Request, gateway *entity.Merchant
 				}
 			}
 		}
+	case "charge.dispute.created", "charge.dispute.updated", "charge.dispute.closed":
+		var stripeDispute stripe.Dispute
+		err = json.Unmarshal(event.Data.Raw, &stripeDispute)
+		if err != nil {
+			g.Log().Errorf(r.Context(), "Webhook Gateway:%s, Error parsing webhook JSON: %s\n", gateway.GatewayName, err.Error())
+			r.Response.WriteHeader(http.StatusBadRequest)
+			responseBack = http.StatusBadRequest
+		} else {
+			g.Log().Infof(r.Context(), "Webhook Gateway:%s, Event %s for Dispute %s\n", gateway.GatewayName, string(event.Type), stripeDispute.ID)
+			requestId = stripeDispute.ID
+			var gatewayPaymentId = ""
+			if stripeDispute.PaymentIntent != nil {
+				gatewayPaymentId = stripeDispute.PaymentIntent.ID
+			}
+			var evidenceDueTime int64 = 0
+			if stripeDispute.EvidenceDetails != nil {
+				evidenceDueTime = stripeDispute.EvidenceDetails.DueBy
+			}
+			_, err = dispute.HandleGatewayDispute(r.Context(), &dispute.GatewayDisputeReq{
+				Gateway:          gateway,
+				GatewayDisputeId: stripeDispute.ID,
+				GatewayPaymentId: gatewayPaymentId,
+				Currency:         strings.ToUpper(string(stripeDispute.Currency)),
+				DisputeAmount:    stripeDispute.Amount,
+				Status:           stripeDisputeStatus(stripeDispute.Status),
+				GatewayStatus:    string(stripeDispute.Status),
+				Reason:           string(stripeDispute.Reason),
+				EvidenceDueTime:  evidenceDueTime,
+			})
+			if err != nil {
+				g.Log().Errorf(r.Context(), "Webhook Gateway:%s, Error HandleGatewayDispute: %s\n", gateway.GatewayName, err.Error())
+				r.Response.WriteHeader(http.StatusBadRequest)
+				responseBack = http.StatusBadRequest
+			}
+		}
 	case "checkout.session.completed":
 		var stripeCheckoutSession stripe.CheckoutSession
 		err = json.Unmarshal(event.Data.Raw, &stripeCheckoutSession)
@@ -600,3 +642,16 @@ type GatewayDetailInvoiceInternalResp struct {
 	CreateTime                     int64                       `json:"createTime"        `
 	CancelTime                     int64                       `json:"cancelTime"        `
 }
+
+func stripeDisputeStatus(status stripe.DisputeStatus) int {
+	switch status {
+	case stripe.DisputeStatusNeedsResponse, stripe.DisputeStatusWarningNeedsResponse:
+		return consts.DisputeEvidenceNeeded
+	case stripe.DisputeStatusWon, stripe.DisputeStatusWarningClosed:
+		return consts.DisputeWon
+	case stripe.DisputeStatusLost:
+		return consts.DisputeLost
+	default:
+		return consts.DisputeOpened
+	}
+}
@@ -21,9 +21,11 @@ import (
 	"unibee/internal/logic/gateway/api"
 	"unibee/internal/logic/gateway/gateway_bean"
 	discount2 "unibee/internal/logic/invoice/discount"
+	"unibee/internal/logic/invoice/numbering"
 	"unibee/internal/logic/multi_currencies/currency_exchange"
 	"unibee/internal/logic/subscription/config"
 	"unibee/internal/logic/user/sub_update"
+	"unibee/internal/logic/vat_gateway"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
 	"unibee/utility"
@@ -61,6 +63,7 @@ func CreateProcessInvoiceForNewPayment(ctx context.Context, invoice *bean.Invoic
 			CountryName:   user.CountryName,
 			VATNumber:     user.VATNumber,
 			TaxPercentage: user.TaxPercentage,
+			State:         user.State,
 			GatewayId:     user.GatewayId,
 			Type:          user.Type,
 			UserName:      user.UserName,
@@ -171,6 +174,7 @@ func CreateProcessInvoiceForNewPayment(ctx context.Context, invoice *bean.Invoic
 		PromoCreditDiscountAmount:      invoice.PromoCreditDiscountAmount,
 		PartialCreditPaidAmount:        invoice.PartialCreditPaidAmount,
 		MetricCharge:                   utility.MarshalToJsonString(invoice.UserMetricChargeForInvoice),
+		TaxLines:                       vat_gateway.BuildInvoiceTaxLines(ctx, payment.MerchantId, payment.UserId, payment.CountryCode, vat_gateway.InvoicePlanId(invoice), invoice),
 	}
 
 	result, err := dao.Invoice.Ctx(ctx).Data(one).OmitNil().Insert(one)
@@ -372,6 +376,7 @@ func CreateProcessInvoiceForNewPaymentRefund(ctx context.Context, invoice *bean.
 		DiscountCode:                   invoice.DiscountCode,
 		CreateFrom:                     refund.RefundComment,
 		Data:                           invoice.Data,
+		TaxLines:                       marshalTaxLines(invoice.TaxLines),
 	}
 
 	result, err := dao.Invoice.Ctx(ctx).Data(one).OmitNil().Insert(one)
@@ -715,7 +720,7 @@ func SendInvoiceEmailToUser(ctx context.Context, invoiceId string, manualSend bo
 				template = sendTemplate
 			}
 			err := email.SendTemplateEmail(ctx, merchant.Id, one.SendEmail, user.TimeZone, user.Language, template, pdfFileName, &bean.EmailTemplateVariable{
-				InvoiceId:             one.InvoiceId,
+				InvoiceId:             numbering.NumberOrInvoiceId(one),
 				UserName:              user.FirstName + " " + user.LastName,
 				MerchantProductName:   one.ProductName,
 				MerchantCustomerEmail: merchant.Email,
@@ -763,7 +768,7 @@ func SendInvoiceEmailToUser(ctx context.Context, invoiceId string, manualSend bo
 					return nil
 				}
 				err := email.SendTemplateEmail(ctx, merchant.Id, one.SendEmail, user.TimeZone, user.Language, template, pdfFileName, &bean.EmailTemplateVariable{
-					InvoiceId:             one.InvoiceId,
+					InvoiceId:             numbering.NumberOrInvoiceId(one),
 					UserName:              user.FirstName + " " + user.LastName,
 					MerchantProductName:   one.ProductName,
 					MerchantCustomerEmail: merchant.Email,
@@ -796,3 +801,10 @@ func SendInvoiceEmailToUser(ctx context.Context, invoiceId string, manualSend bo
 
 	return nil
 }
+
+func marshalTaxLines(lines []*bean.InvoiceTaxLine) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return utility.MarshalToJsonString(lines)
+}
@@ -7,10 +7,11 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unibee/api/bean"
 	"unibee/api/bean/detail"
 	"unibee/internal/consts"
-	"unibee/internal/logic/gateway/api"
 	generator2 "unibee/internal/logic/invoice/handler/generator"
+	"unibee/internal/logic/invoice/numbering"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
 	"unibee/utility"
@@ -26,6 +27,8 @@ func GenerateInvoicePdf(ctx context.Context, one *entity.Invoice) string {
 	merchantInfo := query.GetMerchantById(ctx, one.MerchantId)
 	user := query.GetUserAccountById(ctx, one.UserId)
 	var savePath = fmt.Sprintf("%s.pdf", one.InvoiceId)
+	// the legal number assigned once finalized, before the first pdf generated
+	_, _ = numbering.AssignInvoiceNumber(ctx, one)
 
 	err := createInvoicePdf(ctx, detail.ConvertInvoiceToDetail(ctx, one), merchantInfo, user, query.GetGatewayById(ctx, one.GatewayId), savePath)
 	utility.AssertError(err, "createInvoicePdf error:")
@@ -47,7 +50,7 @@ func createInvoicePdf(ctx context.Context, one *detail.InvoiceDetail, merchantIn
 	if gateway != nil {
 		invoiceGateway = gateway.GatewayName
 	}
-	doc.SetInvoiceNumber(fmt.Sprintf("%s%s", api.GatewayShortNameMapping[invoiceGateway], one.InvoiceId))
+	doc.SetInvoiceNumber(numbering.DisplayInvoiceNumber(one.InvoiceNumber, one.InvoiceId, invoiceGateway))
 	doc.SetInvoiceDate(one.GmtCreate.Layout("2006-01-02"))
 
 	hideDetailStatus := one.Metadata["hideDetailStatus"]
@@ -219,7 +222,11 @@ func createInvoicePdf(ctx context.Context, one *detail.InvoiceDetail, merchantIn
 	if len(one.RefundId) > 0 {
 		doc.IsRefund = true
 		//doc.SetOriginInvoiceNumber(one.SendNote)
-		doc.SetOriginInvoiceNumber(one.OriginalPaymentInvoice.InvoiceId)
+		if len(one.OriginalPaymentInvoice.InvoiceNumber) > 0 {
+			doc.SetOriginInvoiceNumber(one.OriginalPaymentInvoice.InvoiceNumber)
+		} else {
+			doc.SetOriginInvoiceNumber(one.OriginalPaymentInvoice.InvoiceId)
+		}
 		doc.Title = "TAX CREDIT NOTE"
 		refundDesc := ""
 		if strings.Contains(one.SendNote, "Partial Refund") {
@@ -253,7 +260,7 @@ func createInvoicePdf(ctx context.Context, one *detail.InvoiceDetail, merchantIn
 			doc.AppendItem(&generator2.Item{
 				Name:         fmt.Sprintf("%s #%d", description, i),
 				UnitCost:     fmt.Sprintf("%f", float64(line.UnitAmountExcludingTax)/100.0),
-				UnitCostStr:  fmt.Sprintf("%s%s%s", originalUnitAmountString, symbol, utility.ConvertCentToDollarStr(line.UnitAmountExcludingTax, one.Currency)),
+				UnitCostStr:  fmt.Sprintf("%s%s%s%s", originalUnitAmountString, symbol, utility.ConvertCentToDollarStr(line.UnitAmountExcludingTax, one.Currency), metricUnitCostSuffix(line)),
 				Quantity:     strconv.FormatInt(line.Quantity, 10),
 				TaxString:    taxString,
 				AmountString: amountString,
@@ -279,7 +286,7 @@ func createInvoicePdf(ctx context.Context, one *detail.InvoiceDetail, merchantIn
 			doc.AppendItem(&generator2.Item{
 				Name:         fmt.Sprintf("%s #%d", description, i),
 				UnitCost:     fmt.Sprintf("%f", float64(line.UnitAmountExcludingTax)/100.0),
-				UnitCostStr:  fmt.Sprintf("%s%s", symbol, utility.ConvertCentToDollarStr(line.UnitAmountExcludingTax, one.Currency)),
+				UnitCostStr:  fmt.Sprintf("%s%s%s", symbol, utility.ConvertCentToDollarStr(line.UnitAmountExcludingTax, one.Currency), metricUnitCostSuffix(line)),
 				Quantity:     strconv.FormatInt(line.Quantity, 10),
 				TaxString:    taxString,
 				AmountString: amountString,
@@ -353,3 +360,11 @@ func MustParseCurrencySymbolValue(currencyCode string, centAmount int64) string
 	dec := number.Decimal(amountInYuan, number.Scale(scale))
 	return fmt.Sprintf("%v%v", currency.Symbol(cur), dec)
 }
+
+// metricUnitCostSuffix describes the unit of metric charge line, the unit cost of package pricing is per package
+func metricUnitCostSuffix(line *bean.InvoiceItemSimplify) string {
+	if line.MetricCharge != nil && line.MetricCharge.ChargePricing != nil && line.MetricCharge.ChargePricing.ChargeType == bean.MetricChargeTypePackage {
+		return fmt.Sprintf(" / %d Units", line.MetricCharge.ChargePricing.PackageSize)
+	}
+	return ""
+}
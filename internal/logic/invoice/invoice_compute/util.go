@@ -10,7 +10,9 @@ import (
 	"unibee/internal/logic/credit/payment"
 	"unibee/internal/logic/discount"
 	"unibee/internal/logic/plan/period"
+	"unibee/internal/logic/plan/price"
 	addon2 "unibee/internal/logic/subscription/addon"
+	"unibee/internal/logic/vat_gateway/sales_tax"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
 	"unibee/utility"
@@ -25,6 +27,7 @@ type CalculateInvoiceReq struct {
 	DiscountCode               string                                  `json:"discountCode"`
 	TimeNow                    int64                                   `json:"TimeNow"`
 	PlanId                     uint64                                  `json:"planId"`
+	PlanPriceVersion           int                                     `json:"planPriceVersion" dc:"price version of plan, the current price of plan used if not specified"`
 	Quantity                   int64                                   `json:"quantity"`
 	AddonJsonData              string                                  `json:"addonJsonData"`
 	CountryCode                string                                  `json:"CountryCode"`
@@ -214,6 +217,10 @@ func CreateInvoiceSimplifyForRefund(ctx context.Context, payment *entity.Payment
 			fmt.Printf("Unmarshal Metadata error:%s", err.Error())
 		}
 	}
+	var originalTaxLines []*bean.InvoiceTaxLine
+	if len(originalInvoice.TaxLines) > 0 {
+		_ = utility.UnmarshalFromJsonString(originalInvoice.TaxLines, &originalTaxLines)
+	}
 
 	return &bean.Invoice{
 		InvoiceName:                    "Credit Note",
@@ -229,6 +236,7 @@ func CreateInvoiceSimplifyForRefund(ctx context.Context, payment *entity.Payment
 		CountryCode:                    originalInvoice.CountryCode,
 		VatNumber:                      originalInvoice.VatNumber,
 		TaxPercentage:                  originalInvoice.TaxPercentage,
+		TaxLines:                       sales_tax.ScaleTaxLines(originalTaxLines, totalAmount-refundTax, refundTax),
 		DiscountAmount:                 refundDiscountAmount,
 		DiscountCode:                   originalInvoice.DiscountCode,
 		SendStatus:                     consts.InvoiceSendStatusUnSend,
@@ -245,6 +253,7 @@ func CreateInvoiceSimplifyForRefund(ctx context.Context, payment *entity.Payment
 func ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx context.Context, req *CalculateInvoiceReq) *bean.Invoice {
 	plan := query.GetPlanById(ctx, req.PlanId)
 	utility.Assert(plan != nil, fmt.Sprintf("plan not found:%d", req.PlanId))
+	plan = price.PlanAtPriceVersion(ctx, plan, req.PlanPriceVersion)
 	addons := addon2.GetSubscriptionAddonsByAddonJson(ctx, req.AddonJsonData)
 	var totalAmountExcludingTax = plan.CurrencyAmount(ctx, req.Currency) * req.Quantity
 	for _, addon := range addons {
@@ -257,7 +266,7 @@ func ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx context.Context, r
 	}
 	if req.UserMetricChargeForInvoice != nil && len(req.UserMetricChargeForInvoice.RecurringChargeStats) > 0 {
 		for _, metricCharge := range req.UserMetricChargeForInvoice.RecurringChargeStats {
-			totalAmountExcludingTax = totalAmountExcludingTax + metricCharge.TotalChargeAmount
+			totalAmountExcludingTax = totalAmountExcludingTax + metricCharge.TotalChargeAmount + metricCharge.ProrationAmount
 		}
 	}
 
@@ -389,6 +398,24 @@ func ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx context.Context, r
 					})
 				}
 			}
+			// prorated charge of the increases within the past period
+			for _, line := range metricCharge.ProrationLines {
+				var prorationAmountExcludingTax = line.Amount
+				var prorationTaxAmount = int64(math.Round(float64(prorationAmountExcludingTax) * utility.ConvertTaxPercentageToInternalFloat(req.TaxPercentage)))
+				invoiceItems = append(invoiceItems, &bean.InvoiceItemSimplify{
+					Currency:               req.Currency,
+					OriginAmount:           prorationAmountExcludingTax + prorationTaxAmount,
+					Amount:                 prorationAmountExcludingTax + prorationTaxAmount,
+					Tax:                    prorationTaxAmount,
+					TaxPercentage:          req.TaxPercentage,
+					AmountExcludingTax:     prorationAmountExcludingTax,
+					UnitAmountExcludingTax: line.UnitAmount,
+					Quantity:               line.Quantity,
+					Name:                   metricCharge.Name,
+					Description:            fmt.Sprintf("%s,%s%s", metricCharge.Name, metricCharge.Description, line.Step),
+					MetricCharge:           metricCharge,
+				})
+			}
 		}
 	}
 
@@ -447,8 +474,14 @@ func ComputeSubscriptionBillingCycleInvoiceDetailSimplify(ctx context.Context, r
 }
 
 type ProrationPlanParam struct {
-	PlanId   uint64
-	Quantity int64
+	PlanId       uint64
+	Quantity     int64
+	PriceVersion int // price version of plan, the current price of plan used if not specified
+}
+
+type prorationPlanKey struct {
+	PlanId       uint64
+	PriceVersion int
 }
 
 type CalculateProrationInvoiceReq struct {
@@ -483,9 +516,9 @@ func ComputeSubscriptionProrationToFixedEndInvoiceDetailSimplify(ctx context.Con
 	if req.NewProrationPlans == nil {
 		req.NewProrationPlans = make([]*ProrationPlanParam, 0)
 	}
-	newMap := make(map[uint64]*ProrationPlanParam)
+	newMap := make(map[prorationPlanKey]*ProrationPlanParam)
 	for _, planSub := range req.NewProrationPlans {
-		newMap[planSub.PlanId] = planSub
+		newMap[prorationPlanKey{PlanId: planSub.PlanId, PriceVersion: planSub.PriceVersion}] = planSub
 	}
 
 	utility.Assert(req.ProrationDate > 0, "Invalid ProrationDate")
@@ -496,11 +529,11 @@ func ComputeSubscriptionProrationToFixedEndInvoiceDetailSimplify(ctx context.Con
 	var totalAmountExcludingTax int64
 	var merchantId uint64
 	for _, oldPlanSub := range req.OldProrationPlans {
-		plan := query.GetPlanById(ctx, oldPlanSub.PlanId)
+		plan := price.PlanAtPriceVersion(ctx, query.GetPlanById(ctx, oldPlanSub.PlanId), oldPlanSub.PriceVersion)
 		merchantId = plan.MerchantId
 		utility.Assert(plan != nil, "plan not found:"+strconv.FormatUint(oldPlanSub.PlanId, 10))
 		unitAmountExcludingTax := int64(math.Round(float64(plan.CurrencyAmount(ctx, req.Currency)) * utility.ConvertTaxPercentageToInternalFloat(timeScale)))
-		if newPlanSub, ok := newMap[oldPlanSub.PlanId]; ok {
+		if newPlanSub, ok := newMap[prorationPlanKey{PlanId: oldPlanSub.PlanId, PriceVersion: oldPlanSub.PriceVersion}]; ok {
 			//new plan contain old
 			quantityDiff := newPlanSub.Quantity - oldPlanSub.Quantity
 			if quantityDiff > 0 {
@@ -544,7 +577,7 @@ func ComputeSubscriptionProrationToFixedEndInvoiceDetailSimplify(ctx context.Con
 				})
 				totalAmountExcludingTax = totalAmountExcludingTax + amountExcludingTax
 			}
-			delete(newMap, newPlanSub.PlanId)
+			delete(newMap, prorationPlanKey{PlanId: newPlanSub.PlanId, PriceVersion: newPlanSub.PriceVersion})
 		} else {
 			//old removed
 			quantityDiff := oldPlanSub.Quantity
@@ -570,7 +603,7 @@ func ComputeSubscriptionProrationToFixedEndInvoiceDetailSimplify(ctx context.Con
 		}
 	}
 	for _, newPlanSub := range newMap {
-		plan := query.GetPlanById(ctx, newPlanSub.PlanId)
+		plan := price.PlanAtPriceVersion(ctx, query.GetPlanById(ctx, newPlanSub.PlanId), newPlanSub.PriceVersion)
 		utility.Assert(plan != nil, "plan not found:"+strconv.FormatUint(newPlanSub.PlanId, 10))
 		unitAmountExcludingTax := int64(math.Round(float64(plan.CurrencyAmount(ctx, req.Currency)) * utility.ConvertTaxPercentageToInternalFloat(timeScale)))
 		quantityDiff := newPlanSub.Quantity
@@ -0,0 +1,284 @@
+package numbering
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/gateway/api"
+	"unibee/internal/logic/merchant_config"
+	"unibee/internal/logic/merchant_config/update"
+	"unibee/internal/logic/operation_log"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const KeyMerchantInvoiceNumberConfig = "KeyMerchantInvoiceNumberConfig"
+
+func GetMerchantInvoiceNumberConfig(ctx context.Context, merchantId uint64) *bean.InvoiceNumberConfig {
+	var one *bean.InvoiceNumberConfig
+	config := merchant_config.GetMerchantConfig(ctx, merchantId, KeyMerchantInvoiceNumberConfig)
+	if config != nil && len(config.ConfigValue) > 0 {
+		_ = utility.UnmarshalFromJsonString(config.ConfigValue, &one)
+	}
+	if one == nil {
+		one = &bean.InvoiceNumberConfig{}
+	}
+	if len(one.InvoicePattern) == 0 {
+		one.InvoicePattern = DefaultInvoicePattern
+	}
+	if len(one.CreditNotePattern) == 0 {
+		one.CreditNotePattern = DefaultCreditNotePattern
+	}
+	return one
+}
+
+func seriesPattern(config *bean.InvoiceNumberConfig, series string) string {
+	if series == SeriesCreditNote {
+		return config.CreditNotePattern
+	}
+	return config.InvoicePattern
+}
+
+func InvoiceSeries(one *entity.Invoice) string {
+	if len(one.RefundId) > 0 || one.TotalAmount < 0 {
+		return SeriesCreditNote
+	}
+	return SeriesInvoice
+}
+
+// SetupMerchantInvoiceNumberConfig saves the patterns and moves the counters to the start numbers,
+// numbers already assigned never reused, the start number lower than the next counter rejected
+func SetupMerchantInvoiceNumberConfig(ctx context.Context, merchantId uint64, req *bean.InvoiceNumberConfig) (*bean.InvoiceNumberConfig, error) {
+	utility.Assert(merchantId > 0, "invalid merchantId")
+	utility.Assert(req != nil, "invalid config")
+	old := GetMerchantInvoiceNumberConfig(ctx, merchantId)
+	config := &bean.InvoiceNumberConfig{
+		Enable:            req.Enable,
+		InvoicePattern:    req.InvoicePattern,
+		CreditNotePattern: req.CreditNotePattern,
+		EnableTime:        old.EnableTime,
+	}
+	if len(config.InvoicePattern) == 0 {
+		config.InvoicePattern = old.InvoicePattern
+	}
+	if len(config.CreditNotePattern) == 0 {
+		config.CreditNotePattern = old.CreditNotePattern
+	}
+	utility.AssertError(ValidatePattern(config.InvoicePattern), "invalid invoicePattern")
+	utility.AssertError(ValidatePattern(config.CreditNotePattern), "invalid creditNotePattern")
+	utility.Assert(req.StartNumber >= 0 && req.CreditNoteStartNumber >= 0, "invalid start number")
+	if config.Enable && (!old.Enable || config.EnableTime == 0) {
+		// only the invoices created from now numbered, the ones finalized before keep their invoice id
+		config.EnableTime = gtime.Now().Timestamp()
+	}
+	now := gtime.Now().Time
+	utility.AssertError(ensureSequence(ctx, merchantId, SeriesInvoice, PatternPeriod(config.InvoicePattern, now)), "invoice sequence error")
+	utility.AssertError(ensureSequence(ctx, merchantId, SeriesCreditNote, PatternPeriod(config.CreditNotePattern, now)), "credit note sequence error")
+	err := dao.MerchantInvoiceSequence.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if req.StartNumber > 0 {
+			if err := moveCounter(ctx, merchantId, SeriesInvoice, PatternPeriod(config.InvoicePattern, now), req.StartNumber-1); err != nil {
+				return err
+			}
+		}
+		if req.CreditNoteStartNumber > 0 {
+			if err := moveCounter(ctx, merchantId, SeriesCreditNote, PatternPeriod(config.CreditNotePattern, now), req.CreditNoteStartNumber-1); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		err = update.SetMerchantConfig(ctx, merchantId, KeyMerchantInvoiceNumberConfig, utility.MarshalToJsonString(config))
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     merchantId,
+		Target:         "InvoiceNumber",
+		Content:        "SetupInvoiceNumber",
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+		Data:           utility.MarshalToJsonString(req),
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func getSequence(ctx context.Context, merchantId uint64, series string, period string, lock bool) (one *entity.MerchantInvoiceSequence, err error) {
+	q := dao.MerchantInvoiceSequence.Ctx(ctx).
+		Where(dao.MerchantInvoiceSequence.Columns().MerchantId, merchantId).
+		Where(dao.MerchantInvoiceSequence.Columns().SeriesType, series).
+		Where(dao.MerchantInvoiceSequence.Columns().Period, period)
+	if lock {
+		q = q.LockUpdate()
+	}
+	err = q.Scan(&one)
+	return
+}
+
+// ensureSequence creates the sequence row of period with counter zero if not exist, called out of transaction
+// so the row committed before locked
+func ensureSequence(ctx context.Context, merchantId uint64, series string, period string) error {
+	one, err := getSequence(ctx, merchantId, series, period, false)
+	if err != nil || one != nil {
+		return err
+	}
+	lockKey := fmt.Sprintf("InvoiceSequence_%d_%s_%s", merchantId, series, period)
+	for i := 0; !utility.TryLock(ctx, lockKey, 10); i++ {
+		if i >= 20 {
+			return gerror.Newf("lock invoice sequence %s timeout", lockKey)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	defer utility.ReleaseLock(ctx, lockKey)
+	one, err = getSequence(ctx, merchantId, series, period, false)
+	if err != nil || one != nil {
+		return err
+	}
+	_, err = dao.MerchantInvoiceSequence.Ctx(ctx).Data(&entity.MerchantInvoiceSequence{
+		MerchantId: merchantId,
+		SeriesType: series,
+		Period:     period,
+		Counter:    0,
+		CreateTime: gtime.Now().Timestamp(),
+	}).OmitNil().Insert()
+	return err
+}
+
+// lockSequence returns the sequence row locked in transaction
+func lockSequence(ctx context.Context, merchantId uint64, series string, period string) (*entity.MerchantInvoiceSequence, error) {
+	one, err := getSequence(ctx, merchantId, series, period, true)
+	if err != nil {
+		return nil, err
+	}
+	if one == nil {
+		return nil, gerror.Newf("invoice sequence of merchant %d %s %s not found", merchantId, series, period)
+	}
+	return one, nil
+}
+
+func moveCounter(ctx context.Context, merchantId uint64, series string, period string, counter int64) error {
+	one, err := lockSequence(ctx, merchantId, series, period)
+	if err != nil {
+		return err
+	}
+	if counter < one.Counter {
+		return gerror.Newf("start number of %s should greater than %d, the last number assigned", series, one.Counter)
+	}
+	_, err = dao.MerchantInvoiceSequence.Ctx(ctx).Data(g.Map{
+		dao.MerchantInvoiceSequence.Columns().Counter:   counter,
+		dao.MerchantInvoiceSequence.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.MerchantInvoiceSequence.Columns().Id, one.Id).Update()
+	return err
+}
+
+// AssignInvoiceNumber assigns the next number of series to the finalized invoice, the counter and the invoice updated in one transaction
+// so no gap left if failed, the number assigned before returned, empty if numbering not enabled or invoice not finalized
+func AssignInvoiceNumber(ctx context.Context, one *entity.Invoice) (string, error) {
+	if one == nil {
+		return "", nil
+	}
+	if len(one.InvoiceNumber) > 0 {
+		return one.InvoiceNumber, nil
+	}
+	if one.Status != consts.InvoiceStatusProcessing && one.Status != consts.InvoiceStatusPaid {
+		return "", nil
+	}
+	config := GetMerchantInvoiceNumberConfig(ctx, one.MerchantId)
+	if !config.Enable || one.CreateTime < config.EnableTime {
+		return "", nil
+	}
+	series := InvoiceSeries(one)
+	pattern := seriesPattern(config, series)
+	now := gtime.Now().Time
+	period := PatternPeriod(pattern, now)
+	var number = ""
+	err := ensureSequence(ctx, one.MerchantId, series, period)
+	if err != nil {
+		g.Log().Errorf(ctx, "AssignInvoiceNumber invoice %s err:%s", one.InvoiceId, err.Error())
+		return "", err
+	}
+	err = dao.MerchantInvoiceSequence.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		sequence, err := lockSequence(ctx, one.MerchantId, series, period)
+		if err != nil {
+			return err
+		}
+		number = FormatNumber(pattern, sequence.Counter+1, now)
+		result, err := dao.Invoice.Ctx(ctx).Data(g.Map{
+			dao.Invoice.Columns().InvoiceNumber: number,
+			dao.Invoice.Columns().GmtModify:     gtime.Now(),
+		}).Where(dao.Invoice.Columns().Id, one.Id).
+			Where(dao.Invoice.Columns().InvoiceNumber, "").
+			Update()
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected != 1 {
+			return gerror.Newf("invoice %s numbered by other", one.InvoiceId)
+		}
+		_, err = dao.MerchantInvoiceSequence.Ctx(ctx).Data(g.Map{
+			dao.MerchantInvoiceSequence.Columns().Counter:   sequence.Counter + 1,
+			dao.MerchantInvoiceSequence.Columns().GmtModify: gtime.Now(),
+		}).Where(dao.MerchantInvoiceSequence.Columns().Id, sequence.Id).Update()
+		return err
+	})
+	if err != nil {
+		latest := query.GetInvoiceByInvoiceId(ctx, one.InvoiceId)
+		if latest != nil && len(latest.InvoiceNumber) > 0 {
+			one.InvoiceNumber = latest.InvoiceNumber
+			return one.InvoiceNumber, nil
+		}
+		g.Log().Errorf(ctx, "AssignInvoiceNumber invoice %s err:%s", one.InvoiceId, err.Error())
+		return "", err
+	}
+	one.InvoiceNumber = number
+	return number, nil
+}
+
+// NextNumbers previews the numbers the next invoice and credit note would get
+func NextNumbers(ctx context.Context, merchantId uint64) (invoiceNumber string, creditNoteNumber string) {
+	config := GetMerchantInvoiceNumberConfig(ctx, merchantId)
+	now := gtime.Now().Time
+	next := func(series string) string {
+		pattern := seriesPattern(config, series)
+		one, _ := getSequence(ctx, merchantId, series, PatternPeriod(pattern, now), false)
+		var counter int64 = 0
+		if one != nil {
+			counter = one.Counter
+		}
+		return FormatNumber(pattern, counter+1, now)
+	}
+	return next(SeriesInvoice), next(SeriesCreditNote)
+}
+
+// DisplayInvoiceNumber returns the legal number if assigned, or the gateway short name with invoice id for the invoices not numbered
+func DisplayInvoiceNumber(invoiceNumber string, invoiceId string, gatewayName string) string {
+	if len(invoiceNumber) > 0 {
+		return invoiceNumber
+	}
+	return fmt.Sprintf("%s%s", api.GatewayShortNameMapping[gatewayName], invoiceId)
+}
+
+// NumberOrInvoiceId returns the legal number shown to user in emails, the invoice id if not numbered
+func NumberOrInvoiceId(one *entity.Invoice) string {
+	if len(one.InvoiceNumber) > 0 {
+		return one.InvoiceNumber
+	}
+	return one.InvoiceId
+}
@@ -0,0 +1,54 @@
+package numbering
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	SeriesInvoice    = "invoice"
+	SeriesCreditNote = "credit_note"
+
+	DefaultInvoicePattern    = "INV-{YYYY}-{NNNNNN}"
+	DefaultCreditNotePattern = "CN-{YYYY}-{NNNNNN}"
+
+	// the invoice number printed on pdf limited to 32 characters
+	maxNumberLength = 32
+)
+
+var counterToken = regexp.MustCompile(`\{N+}`)
+
+// ValidatePattern checks the pattern has one counter token and the number formatted fits the pdf
+func ValidatePattern(pattern string) error {
+	if len(counterToken.FindAllString(pattern, -1)) != 1 {
+		return gerror.Newf("pattern %s should contain one counter token like {NNNNNN}", pattern)
+	}
+	if strings.Contains(strings.ReplaceAll(strings.ReplaceAll(counterToken.ReplaceAllString(pattern, ""), "{YYYY}", ""), "{YY}", ""), "{") {
+		return gerror.Newf("pattern %s contains unknown token, only {YYYY}, {YY} and {NNNNNN} supported", pattern)
+	}
+	if len(FormatNumber(pattern, 1, time.Now())) > maxNumberLength {
+		return gerror.Newf("pattern %s too long, the number should not exceed %d characters", pattern, maxNumberLength)
+	}
+	return nil
+}
+
+// PatternPeriod returns the period the counter belongs to, the counter reset yearly if pattern contains year
+func PatternPeriod(pattern string, at time.Time) string {
+	if strings.Contains(pattern, "{YYYY}") || strings.Contains(pattern, "{YY}") {
+		return at.UTC().Format("2006")
+	}
+	return ""
+}
+
+// FormatNumber replaces the year tokens by the utc year of time and the counter token by the counter zero-padded to its width
+func FormatNumber(pattern string, counter int64, at time.Time) string {
+	number := strings.ReplaceAll(pattern, "{YYYY}", at.UTC().Format("2006"))
+	number = strings.ReplaceAll(number, "{YY}", at.UTC().Format("06"))
+	return counterToken.ReplaceAllStringFunc(number, func(token string) string {
+		return fmt.Sprintf("%0*d", len(token)-2, counter)
+	})
+}
@@ -0,0 +1,37 @@
+package numbering
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	entity "unibee/internal/model/entity/default"
+)
+
+func TestPattern(t *testing.T) {
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	t.Run("Test for FormatNumber", func(t *testing.T) {
+		require.Equal(t, "INV-2026-000001", FormatNumber(DefaultInvoicePattern, 1, at))
+		require.Equal(t, "CN-2026-000012", FormatNumber(DefaultCreditNotePattern, 12, at))
+		require.Equal(t, "A26/0123", FormatNumber("A{YY}/{NNNN}", 123, at))
+		require.Equal(t, "X-12345", FormatNumber("X-{NN}", 12345, at))
+	})
+	t.Run("Test for PatternPeriod", func(t *testing.T) {
+		require.Equal(t, "2026", PatternPeriod(DefaultInvoicePattern, at))
+		require.Equal(t, "2026", PatternPeriod("A{YY}{NNN}", at))
+		require.Equal(t, "", PatternPeriod("INV{NNNNNN}", at))
+	})
+	t.Run("Test for ValidatePattern", func(t *testing.T) {
+		require.Nil(t, ValidatePattern(DefaultInvoicePattern))
+		require.Nil(t, ValidatePattern("INV{NNNNNN}"))
+		require.NotNil(t, ValidatePattern("INV-{YYYY}"))
+		require.NotNil(t, ValidatePattern("INV-{NNN}-{NNN}"))
+		require.NotNil(t, ValidatePattern("INV-{MM}-{NNN}"))
+		require.NotNil(t, ValidatePattern("INVOICE-OF-THE-MERCHANT-{YYYY}-{NNNNNN}"))
+	})
+	t.Run("Test for InvoiceSeries", func(t *testing.T) {
+		require.Equal(t, SeriesInvoice, InvoiceSeries(&entity.Invoice{TotalAmount: 100}))
+		require.Equal(t, SeriesCreditNote, InvoiceSeries(&entity.Invoice{TotalAmount: -100, RefundId: "re1"}))
+		require.Equal(t, "INV-1", NumberOrInvoiceId(&entity.Invoice{InvoiceId: "8123", InvoiceNumber: "INV-1"}))
+		require.Equal(t, "8123", NumberOrInvoiceId(&entity.Invoice{InvoiceId: "8123"}))
+	})
+}
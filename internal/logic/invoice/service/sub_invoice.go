@@ -15,6 +15,7 @@ import (
 	discount2 "unibee/internal/logic/invoice/discount"
 	"unibee/internal/logic/invoice/handler"
 	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/vat_gateway"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
 	"unibee/utility"
@@ -61,6 +62,7 @@ func CreateProcessingInvoiceForSub(ctx context.Context, req *CreateProcessingInv
 			CountryName:   user.CountryName,
 			VATNumber:     user.VATNumber,
 			TaxPercentage: user.TaxPercentage,
+			State:         user.State,
 			GatewayId:     user.GatewayId,
 			Type:          user.Type,
 			UserName:      user.UserName,
@@ -168,6 +170,7 @@ func CreateProcessingInvoiceForSub(ctx context.Context, req *CreateProcessingInv
 		PromoCreditDiscountAmount:      req.Simplify.PromoCreditDiscountAmount,
 		PartialCreditPaidAmount:        req.Simplify.PartialCreditPaidAmount,
 		MetricCharge:                   utility.MarshalToJsonString(req.Simplify.UserMetricChargeForInvoice),
+		TaxLines:                       vat_gateway.BuildInvoiceTaxLines(ctx, req.Sub.MerchantId, req.Sub.UserId, req.Simplify.CountryCode, req.Sub.PlanId, req.Simplify),
 	}
 
 	result, err := dao.Invoice.Ctx(ctx).Data(one).OmitNil().Insert(one)
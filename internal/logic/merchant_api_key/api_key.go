@@ -0,0 +1,285 @@
+package merchant_api_key
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unibee/api/bean"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/merchant"
+	"unibee/internal/logic/operation_log"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const (
+	ApiKeyStatusActive  = 1
+	ApiKeyStatusRevoked = 2
+
+	MaxMerchantApiKeyCount       = 20
+	DefaultRotateOverlapSeconds  = 24 * 3600
+	MaxRotateOverlapSeconds      = 7 * 24 * 3600
+	lastUsedUpdateIntervalSecond = 60
+)
+
+type NewApiKeyInternalReq struct {
+	MerchantId uint64   `json:"merchantId"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+	ValidIps   []string `json:"validIps"`
+	ExpireTime int64    `json:"expireTime"`
+}
+
+func NewApiKey(ctx context.Context, req *NewApiKeyInternalReq) (one *entity.MerchantApiKey, apiKey string, err error) {
+	utility.Assert(req.MerchantId > 0, "invalid merchantId")
+	name := strings.TrimSpace(req.Name)
+	utility.Assert(len(name) > 0, "name required")
+	utility.AssertError(ValidateScopes(req.Scopes), "invalid scopes")
+	validIps, err := FormatValidIps(strings.Join(req.ValidIps, ","))
+	utility.AssertError(err, "invalid validIps")
+	utility.Assert(req.ExpireTime == 0 || req.ExpireTime > gtime.Now().Timestamp(), "expireTime should be future time")
+	count, err := dao.MerchantApiKey.Ctx(ctx).
+		Where(dao.MerchantApiKey.Columns().MerchantId, req.MerchantId).
+		Where(dao.MerchantApiKey.Columns().Status, ApiKeyStatusActive).
+		Where(dao.MerchantApiKey.Columns().IsDeleted, 0).
+		Count()
+	if err != nil {
+		return nil, "", err
+	}
+	utility.Assert(count < MaxMerchantApiKeyCount, fmt.Sprintf("the active api keys exceed the limit %d", MaxMerchantApiKeyCount))
+	apiKey = merchant.GenerateMerchantAPIKey()
+	one = &entity.MerchantApiKey{
+		MerchantId: req.MerchantId,
+		Name:       name,
+		ApiKey:     apiKey,
+		Scopes:     utility.MarshalToJsonString(normalizeScopes(req.Scopes)),
+		ValidIps:   validIps,
+		ExpireTime: req.ExpireTime,
+		Status:     ApiKeyStatusActive,
+		GmtCreate:  gtime.Now(),
+		GmtModify:  gtime.Now(),
+		CreateTime: gtime.Now().Timestamp(),
+	}
+	result, err := dao.MerchantApiKey.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	if err == nil {
+		id, _ := result.LastInsertId()
+		one.Id = uint64(id)
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: req.MerchantId,
+		Target:     fmt.Sprintf("ApiKey(%v)", one.Id),
+		Content:    fmt.Sprintf("New(%s,%s,Scopes:%s)", name, bean.MaskApiKey(apiKey), one.Scopes),
+	}, err)
+	if err != nil {
+		return nil, "", err
+	}
+	return one, apiKey, nil
+}
+
+type EditApiKeyInternalReq struct {
+	MerchantId uint64    `json:"merchantId"`
+	Id         uint64    `json:"id"`
+	Name       *string   `json:"name"`
+	Scopes     []string  `json:"scopes"`
+	ValidIps   *[]string `json:"validIps"`
+	ExpireTime *int64    `json:"expireTime"`
+}
+
+func EditApiKey(ctx context.Context, req *EditApiKeyInternalReq) (*entity.MerchantApiKey, error) {
+	one := getMerchantApiKey(ctx, req.MerchantId, req.Id)
+	utility.Assert(one.Status == ApiKeyStatusActive, "api key revoked")
+	var data = g.Map{
+		dao.MerchantApiKey.Columns().GmtModify: gtime.Now(),
+	}
+	var changes = make([]string, 0)
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		utility.Assert(len(name) > 0, "name required")
+		data[dao.MerchantApiKey.Columns().Name] = name
+		changes = append(changes, fmt.Sprintf("Name:%s", name))
+	}
+	if req.Scopes != nil {
+		utility.AssertError(ValidateScopes(req.Scopes), "invalid scopes")
+		scopes := utility.MarshalToJsonString(normalizeScopes(req.Scopes))
+		data[dao.MerchantApiKey.Columns().Scopes] = scopes
+		changes = append(changes, fmt.Sprintf("Scopes:%s", scopes))
+	}
+	if req.ValidIps != nil {
+		validIps, err := FormatValidIps(strings.Join(*req.ValidIps, ","))
+		utility.AssertError(err, "invalid validIps")
+		data[dao.MerchantApiKey.Columns().ValidIps] = validIps
+		changes = append(changes, fmt.Sprintf("ValidIps:%s", validIps))
+	}
+	if req.ExpireTime != nil {
+		utility.Assert(*req.ExpireTime == 0 || *req.ExpireTime > gtime.Now().Timestamp(), "expireTime should be future time")
+		data[dao.MerchantApiKey.Columns().ExpireTime] = *req.ExpireTime
+		changes = append(changes, fmt.Sprintf("ExpireTime:%d", *req.ExpireTime))
+	}
+	_, err := dao.MerchantApiKey.Ctx(ctx).Data(data).Where(dao.MerchantApiKey.Columns().Id, one.Id).Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: one.MerchantId,
+		Target:     fmt.Sprintf("ApiKey(%v)", one.Id),
+		Content:    fmt.Sprintf("Edit(%s)", strings.Join(changes, ",")),
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return query.GetMerchantApiKeyById(ctx, one.Id), nil
+}
+
+// RotateApiKey replaces the api key by a new one, the old one valid in the overlap window for integrations to switch
+func RotateApiKey(ctx context.Context, merchantId uint64, id uint64, overlapSeconds int64) (one *entity.MerchantApiKey, apiKey string, err error) {
+	one = getMerchantApiKey(ctx, merchantId, id)
+	utility.Assert(one.Status == ApiKeyStatusActive, "api key revoked")
+	utility.Assert(overlapSeconds >= 0 && overlapSeconds <= MaxRotateOverlapSeconds, fmt.Sprintf("overlapSeconds should between 0 and %d", MaxRotateOverlapSeconds))
+	apiKey = merchant.GenerateMerchantAPIKey()
+	var previousExpireTime int64 = 0
+	var previousApiKey = ""
+	if overlapSeconds > 0 {
+		previousApiKey = one.ApiKey
+		previousExpireTime = gtime.Now().Timestamp() + overlapSeconds
+	}
+	_, err = dao.MerchantApiKey.Ctx(ctx).Data(g.Map{
+		dao.MerchantApiKey.Columns().ApiKey:             apiKey,
+		dao.MerchantApiKey.Columns().PreviousApiKey:     previousApiKey,
+		dao.MerchantApiKey.Columns().PreviousExpireTime: previousExpireTime,
+		dao.MerchantApiKey.Columns().GmtModify:          gtime.Now(),
+	}).Where(dao.MerchantApiKey.Columns().Id, one.Id).Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: one.MerchantId,
+		Target:     fmt.Sprintf("ApiKey(%v)", one.Id),
+		Content:    fmt.Sprintf("Rotate(%s->%s,OverlapSeconds:%d)", bean.MaskApiKey(one.ApiKey), bean.MaskApiKey(apiKey), overlapSeconds),
+	}, err)
+	if err != nil {
+		return nil, "", err
+	}
+	return query.GetMerchantApiKeyById(ctx, one.Id), apiKey, nil
+}
+
+// RevokeApiKey revokes the api key and the previous one in overlap window immediately
+func RevokeApiKey(ctx context.Context, merchantId uint64, id uint64) (*entity.MerchantApiKey, error) {
+	one := getMerchantApiKey(ctx, merchantId, id)
+	if one.Status == ApiKeyStatusRevoked {
+		return one, nil
+	}
+	_, err := dao.MerchantApiKey.Ctx(ctx).Data(g.Map{
+		dao.MerchantApiKey.Columns().Status:             ApiKeyStatusRevoked,
+		dao.MerchantApiKey.Columns().PreviousApiKey:     "",
+		dao.MerchantApiKey.Columns().PreviousExpireTime: 0,
+		dao.MerchantApiKey.Columns().GmtModify:          gtime.Now(),
+	}).Where(dao.MerchantApiKey.Columns().Id, one.Id).Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId: one.MerchantId,
+		Target:     fmt.Sprintf("ApiKey(%v)", one.Id),
+		Content:    fmt.Sprintf("Revoke(%s)", bean.MaskApiKey(one.ApiKey)),
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return query.GetMerchantApiKeyById(ctx, one.Id), nil
+}
+
+type ApiKeyListInternalReq struct {
+	MerchantId uint64 `json:"merchantId"`
+	Status     []int  `json:"status"`
+	Page       int    `json:"page"`
+	Count      int    `json:"count"`
+}
+
+func ApiKeyList(ctx context.Context, req *ApiKeyListInternalReq) (list []*entity.MerchantApiKey, total int, err error) {
+	utility.Assert(req.MerchantId > 0, "invalid merchantId")
+	if req.Count <= 0 {
+		req.Count = 20
+	}
+	if req.Page < 0 {
+		req.Page = 0
+	}
+	columns := dao.MerchantApiKey.Columns()
+	q := dao.MerchantApiKey.Ctx(ctx).
+		Where(columns.MerchantId, req.MerchantId).
+		Where(columns.IsDeleted, 0)
+	if len(req.Status) > 0 {
+		q = q.WhereIn(columns.Status, req.Status)
+	}
+	err = q.OrderDesc(columns.Id).
+		Limit(req.Page*req.Count, req.Count).
+		ScanAndCount(&list, &total, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// Authenticate returns the active scoped api key of open api call, nil if the key not found,
+// error if the key revoked, expired or the ip not allowed, the last used time and ip tracked
+func Authenticate(ctx context.Context, apiKey string, ip string) (*entity.MerchantApiKey, error) {
+	one := query.GetMerchantApiKeyByKey(ctx, apiKey)
+	if one == nil {
+		return nil, nil
+	}
+	now := gtime.Now().Timestamp()
+	if one.Status != ApiKeyStatusActive {
+		return nil, fmt.Errorf("api key revoked")
+	}
+	if one.ExpireTime > 0 && one.ExpireTime <= now {
+		return nil, fmt.Errorf("api key expired")
+	}
+	if one.ApiKey != apiKey && one.PreviousExpireTime <= now {
+		return nil, fmt.Errorf("api key rotated")
+	}
+	if !IsIpAllowed(one.ValidIps, ip) {
+		return nil, fmt.Errorf("ip not allowed:%s", ip)
+	}
+	if now-one.LastUsedTime >= lastUsedUpdateIntervalSecond || one.LastUsedIp != ip {
+		_, err := dao.MerchantApiKey.Ctx(ctx).Data(g.Map{
+			dao.MerchantApiKey.Columns().LastUsedTime: now,
+			dao.MerchantApiKey.Columns().LastUsedIp:   ip,
+		}).Where(dao.MerchantApiKey.Columns().Id, one.Id).Update()
+		if err != nil {
+			g.Log().Errorf(ctx, "Authenticate update last used of api key %d error:%s", one.Id, err.Error())
+		}
+	}
+	return one, nil
+}
+
+func GetApiKeyScopes(one *entity.MerchantApiKey) []string {
+	var scopes = make([]string, 0)
+	if one != nil && len(one.Scopes) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.Scopes, &scopes)
+	}
+	return scopes
+}
+
+// MasterApiKeyDisplay returns the master api key of merchant shown in profile, always masked for the request of scoped api key,
+// the master key passes all scopes and never exposed to the scoped key
+func MasterApiKeyDisplay(masterApiKey string, scopedApiKeyId uint64, isProd bool) string {
+	if scopedApiKeyId > 0 {
+		return bean.MaskApiKey(masterApiKey)
+	}
+	if isProd {
+		return utility.HideStar(masterApiKey)
+	}
+	return masterApiKey
+}
+
+func getMerchantApiKey(ctx context.Context, merchantId uint64, id uint64) *entity.MerchantApiKey {
+	utility.Assert(merchantId > 0, "invalid merchantId")
+	one := query.GetMerchantApiKeyById(ctx, id)
+	utility.Assert(one != nil && one.MerchantId == merchantId, "api key not found")
+	return one
+}
+
+func normalizeScopes(scopes []string) []string {
+	var list = make([]string, 0)
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !utility.IsStringInArray(list, scope) {
+			list = append(list, scope)
+		}
+	}
+	return list
+}
@@ -0,0 +1,91 @@
+package merchant_api_key
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// splitValidIps splits the ip allowlist, the format of OpenApiConfig.Validips, ip or cidr separated by comma
+func splitValidIps(validIps string) []string {
+	var list = make([]string, 0)
+	for _, one := range strings.FieldsFunc(validIps, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\n'
+	}) {
+		if len(strings.TrimSpace(one)) > 0 {
+			list = append(list, strings.TrimSpace(one))
+		}
+	}
+	return list
+}
+
+// FormatValidIps validates the ip allowlist and returns it comma separated
+func FormatValidIps(validIps string) (string, error) {
+	list := splitValidIps(validIps)
+	for _, one := range list {
+		if strings.Contains(one, "/") {
+			if _, _, err := net.ParseCIDR(one); err != nil {
+				return "", fmt.Errorf("invalid cidr:%s", one)
+			}
+		} else if net.ParseIP(one) == nil {
+			return "", fmt.Errorf("invalid ip:%s", one)
+		}
+	}
+	return strings.Join(list, ","), nil
+}
+
+// IsIpAllowed checks the ip in allowlist, all allowed if the allowlist is empty
+func IsIpAllowed(validIps string, ip string) bool {
+	list := splitValidIps(validIps)
+	if len(list) == 0 {
+		return true
+	}
+	remote := net.ParseIP(strings.TrimSpace(ip))
+	if remote == nil {
+		return false
+	}
+	for _, one := range list {
+		if strings.Contains(one, "/") {
+			_, network, err := net.ParseCIDR(one)
+			if err == nil && network.Contains(remote) {
+				return true
+			}
+		} else if allowed := net.ParseIP(one); allowed != nil && allowed.Equal(remote) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTrustedProxy checks the ip in the trusted proxies, none trusted if blank
+func isTrustedProxy(trustedProxies string, ip string) bool {
+	if len(splitValidIps(trustedProxies)) == 0 {
+		return false
+	}
+	return IsIpAllowed(trustedProxies, ip)
+}
+
+// ClientIp returns the ip of api key caller, the peer of connection if it's not a trusted proxy,
+// otherwise the rightmost hop of X-Forwarded-For not a trusted proxy, the leftmost ones can be forged by the caller
+func ClientIp(r *http.Request, trustedProxies string) string {
+	peer, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		peer = r.RemoteAddr
+	}
+	if !isTrustedProxy(trustedProxies, peer) {
+		return peer
+	}
+	hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if len(hop) == 0 {
+			continue
+		}
+		if !isTrustedProxy(trustedProxies, hop) {
+			return hop
+		}
+		peer = hop
+	}
+	return peer
+}
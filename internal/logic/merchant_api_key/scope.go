@@ -0,0 +1,176 @@
+package merchant_api_key
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unibee/internal/consts"
+)
+
+// routeGroups maps the route group of merchant api to the permission group, the ones not listed are configuration
+var routeGroups = map[string]consts.PermissionTypeGroup{
+	"product":          consts.PermissionGroupPlan,
+	"plan":             consts.PermissionGroupPlan,
+	"metric":           consts.PermissionGroupBillableMetric,
+	"discount":         consts.PermissionGroupDiscountCode,
+	"subscription":     consts.PermissionGroupSubscription,
+	"invoice":          consts.PermissionGroupInvoice,
+	"payment":          consts.PermissionGroupTransaction,
+	"credit":           consts.PermissionGroupTransaction,
+	"ledger":           consts.PermissionGroupTransaction,
+	"reconciliation":   consts.PermissionGroupTransaction,
+	"user":             consts.PermissionGroupUser,
+	"session":          consts.PermissionGroupUser,
+	"search":           consts.PermissionGroupUser,
+	"member":           consts.PermissionGroupAdmin,
+	"role":             consts.PermissionGroupAdmin,
+	"apikey":           consts.PermissionGroupAdmin,
+	"task":             consts.PermissionGroupReport,
+	"revenue":          consts.PermissionGroupAnalytics,
+	"track":            consts.PermissionGroupAnalytics,
+	"analytics_portal": consts.PermissionGroupAnalytics,
+}
+
+// actionGroups overrides the permission group of action mixed in other route groups
+var actionGroups = map[string]consts.PermissionTypeGroup{
+	"operation_log_list": consts.PermissionGroupActivityLogs,
+	"new_apikey":         consts.PermissionGroupAdmin,
+	"endpoint_secret":    consts.PermissionGroupAdmin,
+	"get_webhook_secret": consts.PermissionGroupAdmin,
+}
+
+// readActions is the allowlist of side-effect free actions served by GET, the ones accepting get,post read by POST as well,
+// the GET actions not listed like get_authorization_url or disconnection of integration persist data and require write,
+// endpoint_secret and get_webhook_secret return the webhook signing secrets and require write as well
+var readActions = map[string]bool{
+	"list": true, "detail": true, "get": true, "count": true, "search": true, "key_search": true, "config": true,
+	"profile": true, "get_license": true, "get_setup": true, "get_promo_config": true, "get_promo_config_statistics": true,
+	"config_list": true, "credit_account_list": true, "credit_transaction_list": true, "user_discount_list": true,
+	"history_list": true, "template_list": true, "routing": true, "setup_list": true, "mapping": true,
+	"number_config": true, "account_codes": true, "entry_list": true, "operation_log_list": true, "event_list": true,
+	"metric": true, "file_signed_url": true, "method_get": true, "method_list": true, "timeline_list": true,
+	"price_migration_list": true, "price_version_list": true, "discrepancy_list": true, "churn": true, "cohort": true,
+	"mrr": true, "action_list": true, "execution_detail": true, "execution_list": true, "trigger_list": true,
+	"admin_note_list": true, "onetime_addon_purchase_list": true, "pending_update_detail": true,
+	"pending_update_list": true, "preview_subscription_next_invoice": true, "user_pending_crypto_subscription_detail": true,
+	"user_subscription_detail": true, "export_template_list": true, "country_list": true, "sales_tax_config": true,
+	"endpoint_list": true, "endpoint_log_attempt_list": true, "endpoint_log_list": true, "retry_policy": true,
+	"analytics_portal": true,
+}
+
+// postReadActions is the allowlist of side-effect free actions only served by POST
+var postReadActions = map[string]bool{
+	"current_value": true, "amount_multi_currencies_exchange": true, "country_config_list": true,
+	"export_column_list": true, "vat_number_validate_history": true, "sales_tax_preview": true,
+	"plan_apply_preview": true, "price_migration_preview": true, "create_preview": true, "update_preview": true,
+	"new_onetime_addon_preview": true, "template_preview": true,
+}
+
+func routeAction(path string) (segments []string, action string) {
+	path = strings.Trim(path, "/")
+	path = strings.TrimPrefix(path, "merchant/")
+	segments = strings.Split(path, "/")
+	return segments, segments[len(segments)-1]
+}
+
+// RoutePermission returns the permission group of merchant api request and whether the action only reads
+func RoutePermission(method string, path string) (group consts.PermissionTypeGroup, read bool) {
+	segments, action := routeAction(path)
+	group = consts.PermissionGroupConfiguration
+	if one, ok := actionGroups[action]; ok {
+		group = one
+	} else if one, ok := routeGroups[segments[0]]; ok {
+		group = one
+	}
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead:
+		read = readActions[action]
+	case http.MethodPost:
+		read = readActions[action] || postReadActions[action]
+	}
+	return group, read
+}
+
+// IsKeyManagementRoute checks the merchant api path manages the api keys, never allowed to scoped key,
+// otherwise a key with admin:write could mint keys of wider scopes or regenerate the master key
+func IsKeyManagementRoute(path string) bool {
+	segments, action := routeAction(path)
+	return segments[0] == "apikey" || action == "new_apikey"
+}
+
+// ParseScope returns the group and permission of scope, blank group for the scope of all groups
+func ParseScope(scope string) (group consts.PermissionTypeGroup, permission consts.PermissionType, err error) {
+	scope = strings.ToLower(strings.TrimSpace(scope))
+	parts := strings.Split(scope, ":")
+	if len(parts) > 2 {
+		return "", "", fmt.Errorf("invalid scope:%s", scope)
+	}
+	permission = consts.PermissionType(parts[len(parts)-1])
+	if permission != consts.PermissionRead && permission != consts.PermissionWrite {
+		return "", "", fmt.Errorf("invalid scope:%s, permission should be read|write", scope)
+	}
+	if len(parts) == 2 {
+		group = consts.PermissionTypeGroup(parts[0])
+		if _, ok := consts.PermissionGroupMap[group]; !ok {
+			return "", "", fmt.Errorf("invalid scope:%s, unknown group %s", scope, parts[0])
+		}
+	}
+	return group, permission, nil
+}
+
+func ValidateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return fmt.Errorf("scopes required")
+	}
+	for _, scope := range scopes {
+		if _, _, err := ParseScope(scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsScopeAllowed checks the scopes of key on the permission group, write grants read,
+// the group scope grants read to its dependency groups as well
+func IsScopeAllowed(scopes []string, group consts.PermissionTypeGroup, read bool) bool {
+	for _, scope := range scopes {
+		scopeGroup, permission, err := ParseScope(scope)
+		if err != nil {
+			continue
+		}
+		if !read && permission != consts.PermissionWrite {
+			continue
+		}
+		if len(scopeGroup) == 0 || scopeGroup == group {
+			return true
+		}
+		if read {
+			for _, dependency := range consts.PermissionGroupMap[scopeGroup].DependencyGroups {
+				if dependency == group {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// IsRouteAllowed checks the scopes of key on merchant api request
+func IsRouteAllowed(scopes []string, method string, path string) bool {
+	if IsKeyManagementRoute(path) {
+		return false
+	}
+	group, read := RoutePermission(method, path)
+	return IsScopeAllowed(scopes, group, read)
+}
+
+// IsFullReadAllowed checks the scopes of key read all groups, the merchant websocket pushes the events of all groups
+func IsFullReadAllowed(scopes []string) bool {
+	for _, scope := range scopes {
+		group, _, err := ParseScope(scope)
+		if err == nil && len(group) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package merchant_api_key
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+	"unibee/internal/consts"
+)
+
+func TestScope(t *testing.T) {
+	t.Run("Test for RoutePermission", func(t *testing.T) {
+		group, read := RoutePermission("GET", "/merchant/plan/list")
+		require.Equal(t, consts.PermissionGroupPlan, group)
+		require.True(t, read)
+		group, read = RoutePermission("POST", "/merchant/subscription/cancel")
+		require.Equal(t, consts.PermissionGroupSubscription, group)
+		require.False(t, read)
+		group, read = RoutePermission("GET", "/merchant/member/operation_log_list")
+		require.Equal(t, consts.PermissionGroupActivityLogs, group)
+		require.True(t, read)
+		group, read = RoutePermission("POST", "/merchant/new_apikey")
+		require.Equal(t, consts.PermissionGroupAdmin, group)
+		require.False(t, read)
+		group, read = RoutePermission("POST", "/merchant/gateway/setup")
+		require.Equal(t, consts.PermissionGroupConfiguration, group)
+		require.False(t, read)
+		// get actions persisting data
+		group, read = RoutePermission("GET", "/merchant/integration/xero/get_authorization_url")
+		require.Equal(t, consts.PermissionGroupConfiguration, group)
+		require.False(t, read)
+		_, read = RoutePermission("GET", "/merchant/integration/quickbooks/get_authorization_url")
+		require.False(t, read)
+		_, read = RoutePermission("GET", "/merchant/integration/xero/disconnection")
+		require.False(t, read)
+		_, read = RoutePermission("POST", "/merchant/plan/list")
+		require.True(t, read)
+		_, read = RoutePermission("POST", "/merchant/subscription/create_preview")
+		require.True(t, read)
+		_, read = RoutePermission("GET", "/merchant/subscription/create_preview")
+		require.False(t, read)
+		_, read = RoutePermission("DELETE", "/merchant/plan/list")
+		require.False(t, read)
+	})
+	t.Run("Test for ValidateScopes", func(t *testing.T) {
+		require.Nil(t, ValidateScopes([]string{"read", "plan:write", "Invoice:Read"}))
+		require.NotNil(t, ValidateScopes(nil))
+		require.NotNil(t, ValidateScopes([]string{"download"}))
+		require.NotNil(t, ValidateScopes([]string{"unknown:read"}))
+		require.NotNil(t, ValidateScopes([]string{"plan:read:write"}))
+	})
+	t.Run("Test for IsRouteAllowed", func(t *testing.T) {
+		require.True(t, IsRouteAllowed([]string{"read"}, "GET", "/merchant/invoice/list"))
+		require.False(t, IsRouteAllowed([]string{"read"}, "POST", "/merchant/invoice/new"))
+		require.True(t, IsRouteAllowed([]string{"write"}, "POST", "/merchant/invoice/new"))
+		require.True(t, IsRouteAllowed([]string{"plan:write"}, "POST", "/merchant/plan/new"))
+		require.True(t, IsRouteAllowed([]string{"plan:write"}, "GET", "/merchant/product/list"))
+		require.False(t, IsRouteAllowed([]string{"plan:read"}, "POST", "/merchant/plan/new"))
+		require.False(t, IsRouteAllowed([]string{"plan:write"}, "GET", "/merchant/subscription/list"))
+		// dependency groups of subscription readable
+		require.True(t, IsRouteAllowed([]string{"subscription:write"}, "GET", "/merchant/user/list"))
+		require.False(t, IsRouteAllowed([]string{"subscription:write"}, "POST", "/merchant/user/suspend_user"))
+		require.False(t, IsRouteAllowed(nil, "GET", "/merchant/plan/list"))
+		require.False(t, IsRouteAllowed([]string{"read"}, "GET", "/merchant/integration/xero/get_authorization_url"))
+		// webhook signing secrets not readable by read scope
+		require.False(t, IsRouteAllowed([]string{"read"}, "GET", "/merchant/webhook/endpoint_secret"))
+		require.False(t, IsRouteAllowed([]string{"read"}, "GET", "/merchant/webhook/get_webhook_secret"))
+		require.True(t, IsRouteAllowed([]string{"admin:write"}, "GET", "/merchant/webhook/endpoint_secret"))
+		require.False(t, IsRouteAllowed([]string{"configuration:write"}, "GET", "/merchant/webhook/get_webhook_secret"))
+		// api keys never managed by scoped key
+		require.False(t, IsRouteAllowed([]string{"write"}, "POST", "/merchant/apikey/new"))
+		require.False(t, IsRouteAllowed([]string{"admin:write"}, "POST", "/merchant/apikey/edit"))
+		require.False(t, IsRouteAllowed([]string{"admin:write"}, "GET", "/merchant/apikey/list"))
+		require.False(t, IsRouteAllowed([]string{"admin:write"}, "POST", "/merchant/new_apikey"))
+		require.True(t, IsRouteAllowed([]string{"admin:write"}, "POST", "/merchant/member/new_member"))
+	})
+	t.Run("Test for IsFullReadAllowed", func(t *testing.T) {
+		require.True(t, IsFullReadAllowed([]string{"read"}))
+		require.True(t, IsFullReadAllowed([]string{"plan:read", "write"}))
+		require.False(t, IsFullReadAllowed([]string{"plan:write"}))
+	})
+}
+
+func TestValidIps(t *testing.T) {
+	t.Run("Test for FormatValidIps", func(t *testing.T) {
+		ips, err := FormatValidIps(" 10.0.0.1, 192.168.0.0/24;2001:db8::/32 ")
+		require.Nil(t, err)
+		require.Equal(t, "10.0.0.1,192.168.0.0/24,2001:db8::/32", ips)
+		_, err = FormatValidIps("10.0.0.300")
+		require.NotNil(t, err)
+		_, err = FormatValidIps("10.0.0.0/33")
+		require.NotNil(t, err)
+	})
+	t.Run("Test for IsIpAllowed", func(t *testing.T) {
+		require.True(t, IsIpAllowed("", "1.2.3.4"))
+		require.True(t, IsIpAllowed("10.0.0.1,192.168.0.0/24", "10.0.0.1"))
+		require.True(t, IsIpAllowed("10.0.0.1,192.168.0.0/24", "192.168.0.99"))
+		require.False(t, IsIpAllowed("10.0.0.1,192.168.0.0/24", "192.168.1.1"))
+		require.False(t, IsIpAllowed("10.0.0.1", ""))
+		require.True(t, IsIpAllowed("2001:db8::/32", "2001:db8::1"))
+	})
+	t.Run("Test for ClientIp", func(t *testing.T) {
+		r := &http.Request{RemoteAddr: "1.2.3.4:5678", Header: http.Header{}}
+		r.Header.Set("X-Forwarded-For", "10.0.0.1")
+		require.Equal(t, "1.2.3.4", ClientIp(r, ""))
+		require.Equal(t, "1.2.3.4", ClientIp(r, "172.16.0.0/12"))
+		r.RemoteAddr = "172.16.0.2:5678"
+		r.Header.Set("X-Forwarded-For", "10.0.0.1, 5.6.7.8, 172.16.0.3")
+		require.Equal(t, "5.6.7.8", ClientIp(r, "172.16.0.0/12"))
+		require.Equal(t, "172.16.0.2", ClientIp(r, ""))
+		r.Header.Del("X-Forwarded-For")
+		require.Equal(t, "172.16.0.2", ClientIp(r, "172.16.0.0/12"))
+		r.RemoteAddr = "[2001:db8::1]:5678"
+		require.Equal(t, "2001:db8::1", ClientIp(r, ""))
+	})
+}
+
+func TestMasterApiKeyDisplay(t *testing.T) {
+	masterApiKey := "EUXAgwv3Vcr1PFWt2SgBumMHXn3ImBqM"
+	require.Equal(t, masterApiKey, MasterApiKeyDisplay(masterApiKey, 0, false))
+	require.NotEqual(t, masterApiKey, MasterApiKeyDisplay(masterApiKey, 0, true))
+	// scoped api key never obtains the master key, in prod or not
+	require.NotContains(t, MasterApiKeyDisplay(masterApiKey, 12, false), masterApiKey)
+	require.NotContains(t, MasterApiKeyDisplay(masterApiKey, 12, true), masterApiKey)
+	require.Equal(t, "EUXAgwv3****mBqM", MasterApiKeyDisplay(masterApiKey, 12, false))
+}
@@ -54,7 +54,7 @@ type NewMerchantMetricInternalReq struct {
 	MerchantId          uint64                 `json:"merchantId" dc:"MerchantId" v:"required"`
 	Code                string                 `json:"code" dc:"Code" v:"required"`
 	Name                string                 `json:"name" dc:"Name" v:"required"`
-	Type                *int                   `json:"type"                description:"1-limit_metered，2-charge_metered,3-charge_recurring(come later)"`
+	Type                *int                   `json:"type"                description:"1-limit_metered，2-charge_metered,3-charge_recurring"`
 	Description         string                 `json:"description" dc:"Description"`
 	AggregationType     int                    `json:"aggregationType" dc:"AggregationType,1-count，2-count unique, 3-latest, 4-max, 5-sum"`
 	AggregationProperty string                 `json:"aggregationProperty" dc:"AggregationProperty, Will Needed When AggregationType != count"`
@@ -74,6 +74,11 @@ func NewMerchantMetric(ctx context.Context, req *NewMerchantMetricInternalReq) (
 	if req.Type != nil {
 		metricType = *req.Type
 	}
+	utility.Assert(metricType >= MetricTypeLimitMetered && metricType <= MetricTypeChargeRecurring, "type should be one of 1-limit_metered，2-charge_metered,3-charge_recurring")
+	if metricType == MetricTypeChargeRecurring {
+		// recurring value persists across periods, only the latest or max of it makes sense
+		utility.Assert(req.AggregationType == MetricAggregationTypeLatest || req.AggregationType == MetricAggregationTypeMax, "aggregationType of charge_recurring metric should be one of 3-latest, 4-max")
+	}
 
 	one := query.GetMerchantMetricByCode(ctx, req.MerchantId, req.Code)
 	utility.Assert(one == nil, "metric already exist")
@@ -114,6 +119,12 @@ func EditMerchantMetric(ctx context.Context, merchantId uint64, metricId uint64,
 	utility.Assert(metricId > 0, "invalid metricId")
 	one := query.GetMerchantMetric(ctx, metricId)
 	utility.Assert(one != nil, "metric not found")
+	if metricType != nil {
+		utility.Assert(*metricType >= MetricTypeLimitMetered && *metricType <= MetricTypeChargeRecurring, "type should be one of 1-limit_metered，2-charge_metered,3-charge_recurring")
+		if *metricType == MetricTypeChargeRecurring {
+			utility.Assert(one.AggregationType == MetricAggregationTypeLatest || one.AggregationType == MetricAggregationTypeMax, "aggregationType of charge_recurring metric should be one of 3-latest, 4-max")
+		}
+	}
 	_, err := dao.MerchantMetric.Ctx(ctx).Data(g.Map{
 		dao.MerchantMetric.Columns().MetricName:        name,
 		dao.MerchantMetric.Columns().Type:              metricType,
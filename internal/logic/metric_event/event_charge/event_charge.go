@@ -9,6 +9,7 @@ import (
 	"unibee/internal/query"
 	"unibee/utility"
 
+	"github.com/gogf/gf/v2/os/gtime"
 	"golang.org/x/text/currency"
 )
 
@@ -49,7 +50,7 @@ func ComputeMetricUsedChargePrice(ctx context.Context, plan *entity.Plan, target
 		return 0, 0, nil, lines
 	}
 	totalChargeAmount = 0
-	if chargingPrice != nil && chargingPrice.ChargeType == 0 && usedValue > 0 && chargingPrice.StandardAmount > 0 {
+	if chargingPrice != nil && chargingPrice.ChargeType == bean.MetricChargeTypeStandard && usedValue > 0 && chargingPrice.StandardAmount > 0 {
 		unitAmount = plan.ExchangeAmountToCurrency(ctx, chargingPrice.StandardAmount, targetCurrency)
 		totalChargeAmount = utility.MaxInt64(usedValue-chargingPrice.StandardStartValue, 0) * unitAmount
 		lines = append(lines, &bean.MetricPlanChargeLine{
@@ -59,7 +60,7 @@ func ComputeMetricUsedChargePrice(ctx context.Context, plan *entity.Plan, target
 			FlatAmount: 0,
 			Step:       "",
 		})
-	} else if chargingPrice != nil && chargingPrice.ChargeType == 1 && usedValue > 0 {
+	} else if chargingPrice != nil && chargingPrice.ChargeType == bean.MetricChargeTypeGraduated && usedValue > 0 {
 		var lastEnd int64 = 0
 		for _, step := range chargingPrice.GraduatedAmounts {
 			// reach end
@@ -98,6 +99,86 @@ func ComputeMetricUsedChargePrice(ctx context.Context, plan *entity.Plan, target
 				lastEnd = step.EndValue
 			}
 		}
+	} else if chargingPrice != nil && chargingPrice.ChargeType == bean.MetricChargeTypeVolume && usedValue > 0 {
+		// whole used value charged at the tier reached
+		var lastEnd int64 = 0
+		for _, step := range chargingPrice.GraduatedAmounts {
+			if usedValue <= step.EndValue || step.EndValue < 0 {
+				unitAmount = plan.ExchangeAmountToCurrency(ctx, step.PerAmount, targetCurrency)
+				flatAmount := plan.ExchangeAmountToCurrency(ctx, step.FlatAmount, targetCurrency)
+				totalChargeAmount = usedValue*unitAmount + flatAmount
+				graduatedStep = step
+				flatDesc := ""
+				if flatAmount > 0 {
+					flatDesc = fmt.Sprintf(" FlatAmount: %s%s ", symbol, utility.ConvertCentToDollarStr(flatAmount, targetCurrency))
+				}
+				endDesc := "∞"
+				if step.EndValue >= 0 {
+					endDesc = fmt.Sprintf("%d", step.EndValue)
+				}
+				lines = append(lines, &bean.MetricPlanChargeLine{
+					UnitAmount: utility.MaxInt64(unitAmount, 0),
+					Quantity:   usedValue,
+					Amount:     utility.MaxInt64(totalChargeAmount, 0),
+					FlatAmount: flatAmount,
+					Step:       fmt.Sprintf(" Volume(%d - %s)%s", lastEnd, endDesc, flatDesc),
+				})
+				break
+			}
+			lastEnd = step.EndValue
+		}
+	} else if chargingPrice != nil && chargingPrice.ChargeType == bean.MetricChargeTypePackage && usedValue > 0 && chargingPrice.PackageSize > 0 {
+		// charged per package of units, rounded up, after the free units
+		billableValue := utility.MaxInt64(usedValue-chargingPrice.StandardStartValue, 0)
+		packages := (billableValue + chargingPrice.PackageSize - 1) / chargingPrice.PackageSize
+		unitAmount = plan.ExchangeAmountToCurrency(ctx, chargingPrice.StandardAmount, targetCurrency)
+		totalChargeAmount = packages * unitAmount
+		lines = append(lines, &bean.MetricPlanChargeLine{
+			UnitAmount: utility.MaxInt64(unitAmount, 0),
+			Quantity:   packages,
+			Amount:     utility.MaxInt64(totalChargeAmount, 0),
+			FlatAmount: 0,
+			Step:       fmt.Sprintf(" Package(%d Units, %d Per Package)", billableValue, chargingPrice.PackageSize),
+		})
 	}
 	return utility.MaxInt64(totalChargeAmount, 0), utility.MaxInt64(unitAmount, 0), graduatedStep, lines
 }
+
+type RecurringValueChange struct {
+	Time  int64
+	Value int64
+}
+
+// ComputeMetricRecurringProration prorates the increases of recurring metric within the period, billedValue is the value
+// billed in advance for the period, each change raising the value over the highest billed one is charged with the price
+// difference for the remaining part of the period
+func ComputeMetricRecurringProration(ctx context.Context, plan *entity.Plan, targetCurrency string, billedValue int64, changes []*RecurringValueChange, periodStart int64, periodEnd int64, chargingPrice *bean.PlanMetricMeteredChargeParam) (prorationAmount int64, lines []*bean.MetricPlanChargeLine) {
+	lines = make([]*bean.MetricPlanChargeLine, 0)
+	if plan == nil || chargingPrice == nil || periodEnd <= periodStart {
+		return 0, lines
+	}
+	var highValue = billedValue
+	highAmount, _, _, _ := ComputeMetricUsedChargePrice(ctx, plan, targetCurrency, highValue, chargingPrice)
+	for _, change := range changes {
+		if change == nil || change.Value <= highValue {
+			continue
+		}
+		changeTime := utility.MinInt64(utility.MaxInt64(change.Time, periodStart), periodEnd)
+		amount, _, _, _ := ComputeMetricUsedChargePrice(ctx, plan, targetCurrency, change.Value, chargingPrice)
+		prorated := (amount - highAmount) * (periodEnd - changeTime) / (periodEnd - periodStart)
+		if prorated > 0 {
+			quantity := change.Value - highValue
+			lines = append(lines, &bean.MetricPlanChargeLine{
+				UnitAmount: prorated / quantity,
+				Quantity:   quantity,
+				Amount:     prorated,
+				FlatAmount: 0,
+				Step:       fmt.Sprintf("(%d - %d) Proration From %s", highValue, change.Value, gtime.NewFromTimeStamp(changeTime).Layout("2006-01-02")),
+			})
+			prorationAmount = prorationAmount + prorated
+		}
+		highValue = change.Value
+		highAmount = amount
+	}
+	return prorationAmount, lines
+}
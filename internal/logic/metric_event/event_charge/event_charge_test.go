@@ -0,0 +1,89 @@
+package event_charge
+
+import (
+	"context"
+	"testing"
+	"unibee/api/bean"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeMetricUsedChargePrice(t *testing.T) {
+	ctx := context.Background()
+	plan := &entity.Plan{Id: 1, Currency: "USD"}
+	t.Run("Test for Volume Pricing", func(t *testing.T) {
+		price := &bean.PlanMetricMeteredChargeParam{MetricId: 1, ChargeType: bean.MetricChargeTypeVolume, GraduatedAmounts: []*bean.MetricPlanChargeGraduatedStep{
+			{PerAmount: 1000, StartValue: 0, EndValue: 10},
+			{PerAmount: 800, StartValue: 10, EndValue: 50, FlatAmount: 500},
+			{PerAmount: 600, StartValue: 50, EndValue: -1},
+		}}
+		total, unit, step, lines := ComputeMetricUsedChargePrice(ctx, plan, "USD", 10, price)
+		require.Equal(t, int64(10000), total)
+		require.Equal(t, int64(1000), unit)
+		require.Equal(t, int64(10), step.EndValue)
+		total, unit, _, lines = ComputeMetricUsedChargePrice(ctx, plan, "USD", 20, price)
+		require.Equal(t, int64(20*800+500), total)
+		require.Equal(t, int64(800), unit)
+		require.Equal(t, 1, len(lines))
+		require.Equal(t, int64(20), lines[0].Quantity)
+		total, _, _, _ = ComputeMetricUsedChargePrice(ctx, plan, "USD", 100, price)
+		require.Equal(t, int64(60000), total)
+	})
+	t.Run("Test for Package Pricing", func(t *testing.T) {
+		price := &bean.PlanMetricMeteredChargeParam{MetricId: 1, ChargeType: bean.MetricChargeTypePackage, StandardAmount: 500, StandardStartValue: 100, PackageSize: 1000}
+		total, _, _, _ := ComputeMetricUsedChargePrice(ctx, plan, "USD", 100, price)
+		require.Equal(t, int64(0), total)
+		total, unit, _, lines := ComputeMetricUsedChargePrice(ctx, plan, "USD", 101, price)
+		require.Equal(t, int64(500), total)
+		require.Equal(t, int64(500), unit)
+		require.Equal(t, int64(1), lines[0].Quantity)
+		total, _, _, lines = ComputeMetricUsedChargePrice(ctx, plan, "USD", 2100, price)
+		require.Equal(t, int64(1000), total)
+		require.Equal(t, int64(2), lines[0].Quantity)
+	})
+}
+
+func TestComputeMetricRecurringProration(t *testing.T) {
+	ctx := context.Background()
+	plan := &entity.Plan{Id: 1, Currency: "USD"}
+	var periodStart int64 = 1700000000
+	var periodEnd = periodStart + 30*86400
+	t.Run("Test for Standard Proration", func(t *testing.T) {
+		price := &bean.PlanMetricMeteredChargeParam{MetricId: 1, ChargeType: 0, StandardAmount: 1000}
+		amount, lines := ComputeMetricRecurringProration(ctx, plan, "USD", 5, []*RecurringValueChange{
+			{Time: periodStart + 86400, Value: 3},
+			{Time: periodStart + 15*86400, Value: 8},
+			{Time: periodStart + 20*86400, Value: 6},
+			{Time: periodStart + 24*86400, Value: 10},
+		}, periodStart, periodEnd, price)
+		// 3 seats for half period, 2 seats for 1/5 period
+		require.Equal(t, int64(1500+400), amount)
+		require.Equal(t, 2, len(lines))
+		require.Equal(t, int64(3), lines[0].Quantity)
+		require.Equal(t, int64(1500), lines[0].Amount)
+		require.Equal(t, int64(2), lines[1].Quantity)
+		require.Equal(t, int64(400), lines[1].Amount)
+	})
+	t.Run("Test for Graduated Proration", func(t *testing.T) {
+		price := &bean.PlanMetricMeteredChargeParam{MetricId: 1, ChargeType: 1, GraduatedAmounts: []*bean.MetricPlanChargeGraduatedStep{
+			{PerAmount: 1000, StartValue: 0, EndValue: 5},
+			{PerAmount: 500, StartValue: 5, EndValue: -1},
+		}}
+		amount, lines := ComputeMetricRecurringProration(ctx, plan, "USD", 4, []*RecurringValueChange{
+			{Time: periodStart, Value: 7},
+		}, periodStart, periodEnd, price)
+		// 5000+1000 - 4000
+		require.Equal(t, int64(2000), amount)
+		require.Equal(t, 1, len(lines))
+	})
+	t.Run("Test for No Increase", func(t *testing.T) {
+		price := &bean.PlanMetricMeteredChargeParam{MetricId: 1, ChargeType: 0, StandardAmount: 1000}
+		amount, lines := ComputeMetricRecurringProration(ctx, plan, "USD", 5, []*RecurringValueChange{
+			{Time: periodStart + 86400, Value: 2},
+			{Time: periodEnd + 86400, Value: 9},
+		}, periodStart, periodEnd, price)
+		require.Equal(t, int64(0), amount)
+		require.Equal(t, 0, len(lines))
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"unibee/api/bean"
+	"unibee/internal/consts"
 	dao "unibee/internal/dao/default"
 	"unibee/internal/logic/metric"
 	"unibee/internal/logic/metric_event/event_charge"
@@ -47,6 +48,10 @@ func MerchantMetricEventCurrentValue(ctx context.Context, req *MerchantMetricEve
 	utility.Assert(met.MerchantId == req.MerchantId, "code not match")
 	// check the only active subscription
 	sub := query.GetLatestActiveOrIncompleteSubscriptionByUserId(ctx, user.Id, req.MerchantId, req.ProductId)
+	if sub == nil {
+		latestSub := query.GetLatestSubscriptionByUserId(ctx, user.Id, req.MerchantId, req.ProductId)
+		utility.Assert(latestSub == nil || latestSub.Status != consts.SubStatusSuspended, "user's subscription is paused")
+	}
 	utility.Assert(sub != nil, "user has no active subscription")
 	planMetricBindingEntity := bean.ConvertMetricPlanBindingEntityFromPlan(query.GetPlanById(ctx, sub.PlanId))
 	if met.Type == metric.MetricTypeChargeMetered {
@@ -112,6 +112,8 @@ func GetUserMetricStatForAutoChargeInvoice(ctx context.Context, merchantId uint6
 			Name:              v.Metric.MetricName,
 			Description:       v.Metric.MetricDescription,
 			Lines:             v.Lines,
+			ProrationAmount:   v.ProrationAmount,
+			ProrationLines:    v.ProrationLines,
 		})
 	}
 	return &bean.UserMetricChargeInvoiceItemEntity{
@@ -164,6 +166,11 @@ func GetUserSubscriptionMetricStat(ctx context.Context, merchantId uint64, user
 			met := query.GetMerchantMetric(ctx, metricRecurringCharge.MetricId)
 			metricUserUsedValue := GetUserMetricCachedUseValue(ctx, merchantId, user.Id, met, one, reloadCache)
 			totalChargeAmount, _, graduateStep, lines := event_charge.ComputeMetricUsedChargePrice(ctx, query.GetPlanById(ctx, one.PlanId), one.Currency, metricUserUsedValue.UsedValue, metricRecurringCharge)
+			// recurring metric billed in advance, the increases within current period charged prorated
+			prorationAmount, prorationLines := event_charge.ComputeMetricRecurringProration(ctx, query.GetPlanById(ctx, one.PlanId), one.Currency,
+				getRecurringMetricBilledValue(ctx, one, metricRecurringCharge.MetricId),
+				getRecurringMetricPeriodChanges(ctx, merchantId, user.Id, metricRecurringCharge.MetricId, one),
+				one.CurrentPeriodStart, one.CurrentPeriodEnd, metricRecurringCharge)
 			recurringChargeStats = append(recurringChargeStats, &detail.UserMerchantMetricChargeStat{
 				MetricId:          metricRecurringCharge.MetricId,
 				Metric:            bean.SimplifyMerchantMetric(met),
@@ -174,6 +181,8 @@ func GetUserSubscriptionMetricStat(ctx context.Context, merchantId uint64, user
 				TotalChargeAmount: totalChargeAmount,
 				GraduatedStep:     graduateStep,
 				Lines:             lines,
+				ProrationAmount:   prorationAmount,
+				ProrationLines:    prorationLines,
 			})
 		}
 		return &detail.UserMetric{
@@ -313,12 +322,34 @@ func GetUserMetricCachedUseValue(ctx context.Context, merchantId uint64, userId
 					Where(dao.MerchantMetricEvent.Columns().SubscriptionPeriodEnd, sub.CurrentPeriodEnd)
 			} else if met.Type == metric.MetricTypeChargeMetered {
 				q = q.Where(dao.MerchantMetricEvent.Columns().ChargeStatus, 0)
+			} else if met.Type == metric.MetricTypeChargeRecurring {
+				q = q.Where(dao.MerchantMetricEvent.Columns().SubscriptionPeriodStart, sub.CurrentPeriodStart)
 			}
 			err := q.FieldMax(dao.MerchantMetricEvent.Columns().AggregationPropertyInt, "usedValue").
 				FieldMax(dao.MerchantMetricEvent.Columns().Id, "maxEventId").
 				FieldMin(dao.MerchantMetricEvent.Columns().Id, "minEventId").
 				Scan(&metricUserUsedValue)
 			utility.AssertError(err, "Server Error")
+			if met.Type == metric.MetricTypeChargeRecurring {
+				// the latest value of previous periods persists into current period
+				var carryOne *entity.MerchantMetricEvent
+				err = dao.MerchantMetricEvent.Ctx(ctx).
+					Where(dao.MerchantMetricEvent.Columns().MerchantId, merchantId).
+					Where(dao.MerchantMetricEvent.Columns().UserId, userId).
+					Where(dao.MerchantMetricEvent.Columns().MetricId, int64(met.Id)).
+					Where(dao.MerchantMetricEvent.Columns().SubscriptionIds, sub.SubscriptionId).
+					Where(dao.MerchantMetricEvent.Columns().IsDeleted, 0).
+					WhereLT(dao.MerchantMetricEvent.Columns().SubscriptionPeriodStart, sub.CurrentPeriodStart).
+					OrderDesc(dao.MerchantMetricEvent.Columns().Id).
+					Scan(&carryOne)
+				utility.AssertError(err, "Server Error")
+				if carryOne != nil {
+					if metricUserUsedValue == nil {
+						metricUserUsedValue = &MetricUserUsedValue{MaxEventId: carryOne.Id}
+					}
+					metricUserUsedValue.UsedValue = utility.MaxInt64(metricUserUsedValue.UsedValue, carryOne.AggregationPropertyInt)
+				}
+			}
 		} else {
 			q := dao.MerchantMetricEvent.Ctx(ctx).
 				Where(dao.MerchantMetricEvent.Columns().MerchantId, merchantId).
@@ -378,3 +409,55 @@ func metricUserCacheKey(merchantId uint64, userId uint64, met *entity.MerchantMe
 	cacheKey := fmt.Sprintf("%s_%d_%d_%d_%s_%d", UserMetricCacheKeyPrefix, merchantId, userId, met.Id, sub.SubscriptionId, sub.CurrentPeriodStart)
 	return cacheKey
 }
+
+// getRecurringMetricBilledValue returns the value of recurring metric billed in advance for the current period of subscription
+func getRecurringMetricBilledValue(ctx context.Context, sub *entity.Subscription, metricId uint64) int64 {
+	var one *entity.Invoice
+	err := dao.Invoice.Ctx(ctx).
+		Where(dao.Invoice.Columns().SubscriptionId, sub.SubscriptionId).
+		Where(dao.Invoice.Columns().PeriodStart, sub.CurrentPeriodStart).
+		Where(dao.Invoice.Columns().Status, consts.InvoiceStatusPaid).
+		Where(dao.Invoice.Columns().IsDeleted, 0).
+		OrderDesc(dao.Invoice.Columns().Id).
+		Scan(&one)
+	if err != nil || one == nil || len(one.MetricCharge) == 0 {
+		return 0
+	}
+	var metricCharge *bean.UserMetricChargeInvoiceItemEntity
+	_ = utility.UnmarshalFromJsonString(one.MetricCharge, &metricCharge)
+	if metricCharge == nil {
+		return 0
+	}
+	for _, item := range metricCharge.RecurringChargeStats {
+		if item != nil && item.MetricId == metricId {
+			return item.CurrentUsedValue
+		}
+	}
+	return 0
+}
+
+// getRecurringMetricPeriodChanges returns the used values of recurring metric reported within the current period of subscription
+func getRecurringMetricPeriodChanges(ctx context.Context, merchantId uint64, userId uint64, metricId uint64, sub *entity.Subscription) []*event_charge.RecurringValueChange {
+	var list []*entity.MerchantMetricEvent
+	err := dao.MerchantMetricEvent.Ctx(ctx).
+		Where(dao.MerchantMetricEvent.Columns().MerchantId, merchantId).
+		Where(dao.MerchantMetricEvent.Columns().UserId, userId).
+		Where(dao.MerchantMetricEvent.Columns().MetricId, int64(metricId)).
+		Where(dao.MerchantMetricEvent.Columns().SubscriptionIds, sub.SubscriptionId).
+		Where(dao.MerchantMetricEvent.Columns().SubscriptionPeriodStart, sub.CurrentPeriodStart).
+		Where(dao.MerchantMetricEvent.Columns().IsDeleted, 0).
+		OrderAsc(dao.MerchantMetricEvent.Columns().Id).
+		Scan(&list)
+	var changes = make([]*event_charge.RecurringValueChange, 0)
+	if err != nil {
+		g.Log().Errorf(ctx, "getRecurringMetricPeriodChanges metricId:%d subId:%s err:%s", metricId, sub.SubscriptionId, err.Error())
+		return changes
+	}
+	for _, one := range list {
+		changes = append(changes, &event_charge.RecurringValueChange{
+			Time:  one.CreateTime,
+			Value: one.Used,
+		})
+	}
+	return changes
+}
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"net/http"
+	"strings"
+	_interface "unibee/internal/interface"
+	"unibee/utility"
+)
+
+const (
+	IdempotencyKeyHeader        = "Idempotency-Key"
+	IdempotentReplayedHeader    = "Idempotent-Replayed"
+	idempotencyKeyMaxLength     = 255
+	idempotencyResponseTTL      = 24 * 60 * 60 // stored response replayed within 24 hours
+	idempotencyInFlightTTL      = 5 * 60       // in-flight marker released if the request never finishes
+	idempotencyStatusProcessing = "processing"
+	idempotencyStatusCompleted  = "completed"
+)
+
+type idempotencyRecord struct {
+	Status      string `json:"status"`
+	RequestHash string `json:"requestHash"`
+	Response    string `json:"response"`
+}
+
+// idempotencyStore keeps the records of Idempotency-Key, redis in server
+type idempotencyStore interface {
+	// SetNX saves the value if the key not exists, false returns if the key exists
+	SetNX(ctx context.Context, key string, value string, ttl int64) (bool, error)
+	// Get returns the value of key, blank if not exists
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value string, ttl int64) error
+	Delete(ctx context.Context, key string)
+}
+
+type redisIdempotencyStore struct{}
+
+func (s redisIdempotencyStore) SetNX(ctx context.Context, key string, value string, ttl int64) (bool, error) {
+	result, err := g.Redis().Do(ctx, "SET", key, value, "NX", "EX", ttl)
+	if err != nil {
+		return false, err
+	}
+	return result != nil && !result.IsNil(), nil
+}
+
+func (s redisIdempotencyStore) Get(ctx context.Context, key string) (string, error) {
+	result, err := g.Redis().Get(ctx, key)
+	if err != nil || result == nil || result.IsNil() {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+func (s redisIdempotencyStore) Set(ctx context.Context, key string, value string, ttl int64) error {
+	_, err := g.Redis().Do(ctx, "SET", key, value, "EX", ttl)
+	return err
+}
+
+func (s redisIdempotencyStore) Delete(ctx context.Context, key string) {
+	utility.ReleaseLock(ctx, key)
+}
+
+var idempotencyKeyStore idempotencyStore = redisIdempotencyStore{}
+
+// checkIdempotencyRecord decides whether the stored record of Idempotency-Key can be replayed to the request,
+// the conflict message returns if the key is used by a different request or the first one is still in flight
+func checkIdempotencyRecord(record *idempotencyRecord, requestHash string) (replay bool, conflict string) {
+	if record == nil {
+		return false, "A request with the same Idempotency-Key is in progress, please retry later"
+	}
+	if record.RequestHash != requestHash {
+		return false, "Idempotency-Key has already been used by a different request"
+	}
+	if record.Status != idempotencyStatusCompleted {
+		return false, "A request with the same Idempotency-Key is in progress, please retry later"
+	}
+	return true, ""
+}
+
+// idempotentNext runs the OpenAPI write request carrying Idempotency-Key at most once, the successful response is stored
+// and replayed to the retries with the same body, failed request is not stored and can be retried with the same key
+func idempotentNext(merchantId uint64, r *ghttp.Request) {
+	key := strings.TrimSpace(r.GetHeader(IdempotencyKeyHeader))
+	if r.Method != http.MethodPost || len(key) == 0 {
+		r.Middleware.Next()
+		return
+	}
+	utility.Assert(len(key) <= idempotencyKeyMaxLength, fmt.Sprintf("Idempotency-Key should not longer than %d", idempotencyKeyMaxLength))
+	ctx := r.Context()
+	redisKey := fmt.Sprintf("UniBee#IdempotencyKey#%d#%s", merchantId, key)
+	requestHash := utility.MD5(fmt.Sprintf("%s%s%s", r.Method, r.URL.Path, r.GetBodyString()))
+	inFlight := utility.MarshalToJsonString(&idempotencyRecord{Status: idempotencyStatusProcessing, RequestHash: requestHash})
+	saved, err := idempotencyKeyStore.SetNX(ctx, redisKey, inFlight, idempotencyInFlightTTL)
+	if err != nil {
+		g.Log().Errorf(ctx, "IdempotencyKey merchantId:%d key:%s error:%s", merchantId, key, err.Error())
+		r.Middleware.Next()
+		return
+	}
+	if !saved {
+		var record *idempotencyRecord
+		stored, err := idempotencyKeyStore.Get(ctx, redisKey)
+		if err == nil && len(stored) > 0 {
+			_ = utility.UnmarshalFromJsonString(stored, &record)
+		}
+		replay, conflict := checkIdempotencyRecord(record, requestHash)
+		if !replay {
+			g.Log().Infof(ctx, "IdempotencyKey merchantId:%d key:%s conflict:%s", merchantId, key, conflict)
+			r.Response.Status = http.StatusConflict
+			_interface.OpenApiJsonExit(r, http.StatusConflict, conflict)
+			return
+		}
+		g.Log().Infof(ctx, "IdempotencyKey merchantId:%d key:%s replayed", merchantId, key)
+		r.Response.Header().Set(IdempotentReplayedHeader, "true")
+		r.Response.Status = http.StatusOK
+		var data interface{} = nil
+		if len(record.Response) > 0 {
+			data = json.RawMessage(record.Response)
+		}
+		_interface.OpenApiJsonExit(r, 0, "", data)
+		return
+	}
+
+	var completed = false
+	defer func() {
+		if !completed {
+			// request failed or panic, release the key for retry
+			idempotencyKeyStore.Delete(ctx, redisKey)
+		}
+	}()
+	r.Middleware.Next()
+	if r.GetError() != nil {
+		return
+	}
+	// keep the in-flight marker even if the response fails to save, the request has been executed
+	completed = true
+	record := &idempotencyRecord{
+		Status:      idempotencyStatusCompleted,
+		RequestHash: requestHash,
+		Response:    utility.MarshalToJsonString(r.GetHandlerResponse()),
+	}
+	err = idempotencyKeyStore.Set(ctx, redisKey, utility.MarshalToJsonString(record), idempotencyResponseTTL)
+	if err != nil {
+		g.Log().Errorf(ctx, "IdempotencyKey merchantId:%d key:%s save response error:%s", merchantId, key, err.Error())
+	}
+}
@@ -0,0 +1,191 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/util/guid"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"unibee/internal/consts"
+	_interface "unibee/internal/interface"
+	_ "unibee/internal/logic/context"
+	"unibee/internal/model"
+)
+
+func TestCheckIdempotencyRecord(t *testing.T) {
+	completed := &idempotencyRecord{Status: idempotencyStatusCompleted, RequestHash: "hash", Response: "{}"}
+	t.Run("Test for Replay", func(t *testing.T) {
+		replay, conflict := checkIdempotencyRecord(completed, "hash")
+		require.True(t, replay)
+		require.Equal(t, "", conflict)
+	})
+	t.Run("Test for Mismatched Body", func(t *testing.T) {
+		replay, conflict := checkIdempotencyRecord(completed, "other")
+		require.False(t, replay)
+		require.Contains(t, conflict, "different request")
+	})
+	t.Run("Test for In Flight", func(t *testing.T) {
+		replay, conflict := checkIdempotencyRecord(&idempotencyRecord{Status: idempotencyStatusProcessing, RequestHash: "hash"}, "hash")
+		require.False(t, replay)
+		require.Contains(t, conflict, "in progress")
+		replay, _ = checkIdempotencyRecord(nil, "hash")
+		require.False(t, replay)
+	})
+}
+
+type memoryIdempotencyStore struct {
+	lock   sync.Mutex
+	values map[string]string
+}
+
+func (s *memoryIdempotencyStore) SetNX(ctx context.Context, key string, value string, ttl int64) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.values[key]; ok {
+		return false, nil
+	}
+	s.values[key] = value
+	return true, nil
+}
+
+func (s *memoryIdempotencyStore) Get(ctx context.Context, key string) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.values[key], nil
+}
+
+func (s *memoryIdempotencyStore) Set(ctx context.Context, key string, value string, ttl int64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.values[key] = value
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Delete(ctx context.Context, key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.values, key)
+}
+
+type idempotencyTestReq struct {
+	g.Meta `path:"/create" method:"post"`
+	Name   string `json:"name"`
+}
+
+type idempotencyTestRes struct {
+	Name  string `json:"name"`
+	Count int32  `json:"count"`
+}
+
+type idempotencyTestController struct {
+	count   int32
+	fail    int32
+	started chan bool
+	release chan bool
+}
+
+func (c *idempotencyTestController) Create(ctx context.Context, req *idempotencyTestReq) (res *idempotencyTestRes, err error) {
+	count := atomic.AddInt32(&c.count, 1)
+	if req.Name == "slow" {
+		c.started <- true
+		<-c.release
+	}
+	if atomic.CompareAndSwapInt32(&c.fail, 1, 0) {
+		return nil, gerror.New("handler failed")
+	}
+	return &idempotencyTestRes{Name: req.Name, Count: count}, nil
+}
+
+func postIdempotent(t *testing.T, url string, key string, body string) (int, string, http.Header) {
+	request, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	require.Nil(t, err)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(IdempotencyKeyHeader, key)
+	response, err := http.DefaultClient.Do(request)
+	require.Nil(t, err)
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	require.Nil(t, err)
+	return response.StatusCode, string(data), response.Header
+}
+
+func TestIdempotentNext(t *testing.T) {
+	store := &memoryIdempotencyStore{values: make(map[string]string)}
+	idempotencyKeyStore = store
+	defer func() {
+		idempotencyKeyStore = redisIdempotencyStore{}
+	}()
+	controller := &idempotencyTestController{started: make(chan bool), release: make(chan bool)}
+	s := g.Server(guid.S())
+	s.SetAddr("127.0.0.1:0")
+	s.SetDumpRouterMap(false)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(func(r *ghttp.Request) {
+			r.SetCtxVar(consts.ContextKey, &model.Context{Data: make(g.Map)})
+			idempotentNext(1, r)
+			if err := r.GetError(); err != nil {
+				r.Response.ClearBuffer()
+				r.Response.WriteStatus(http.StatusInternalServerError, err.Error())
+				return
+			}
+			_interface.OpenApiJsonExit(r, 0, "", r.GetHandlerResponse())
+		})
+		group.Bind(controller)
+	})
+	require.Nil(t, s.Start())
+	defer func() {
+		_ = s.Shutdown()
+	}()
+	url := fmt.Sprintf("http://127.0.0.1:%d/create", s.GetListenedPort())
+
+	t.Run("Test for Replay", func(t *testing.T) {
+		status, first, header := postIdempotent(t, url, "replay", `{"name":"a"}`)
+		require.Equal(t, http.StatusOK, status)
+		require.Contains(t, first, `"count":1`)
+		require.Equal(t, "", header.Get(IdempotentReplayedHeader))
+		status, second, header := postIdempotent(t, url, "replay", `{"name":"a"}`)
+		require.Equal(t, http.StatusOK, status)
+		require.Contains(t, second, `"count":1`)
+		require.Equal(t, "true", header.Get(IdempotentReplayedHeader))
+		require.Equal(t, int32(1), atomic.LoadInt32(&controller.count))
+	})
+	t.Run("Test for Mismatched Body", func(t *testing.T) {
+		status, body, _ := postIdempotent(t, url, "replay", `{"name":"b"}`)
+		require.Equal(t, http.StatusConflict, status)
+		require.Contains(t, body, "different request")
+		require.Equal(t, int32(1), atomic.LoadInt32(&controller.count))
+	})
+	t.Run("Test for In Flight", func(t *testing.T) {
+		done := make(chan int)
+		go func() {
+			status, _, _ := postIdempotent(t, url, "slow", `{"name":"slow"}`)
+			done <- status
+		}()
+		<-controller.started
+		status, body, _ := postIdempotent(t, url, "slow", `{"name":"slow"}`)
+		require.Equal(t, http.StatusConflict, status)
+		require.Contains(t, body, "in progress")
+		controller.release <- true
+		require.Equal(t, http.StatusOK, <-done)
+		require.Equal(t, int32(2), atomic.LoadInt32(&controller.count))
+	})
+	t.Run("Test for Failed Handler", func(t *testing.T) {
+		atomic.StoreInt32(&controller.fail, 1)
+		status, _, _ := postIdempotent(t, url, "failed", `{"name":"c"}`)
+		require.Equal(t, http.StatusInternalServerError, status)
+		stored, _ := store.Get(context.Background(), "UniBee#IdempotencyKey#1#failed")
+		require.Equal(t, "", stored)
+		status, body, header := postIdempotent(t, url, "failed", `{"name":"c"}`)
+		require.Equal(t, http.StatusOK, status)
+		require.Contains(t, body, `"count":4`)
+		require.Equal(t, "", header.Get(IdempotentReplayedHeader))
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"unibee/internal/logic/analysis/segment"
 	"unibee/internal/logic/jwt"
 	"unibee/internal/logic/merchant"
+	"unibee/internal/logic/merchant_api_key"
 	"unibee/internal/logic/middleware/license"
 	"unibee/internal/logic/middleware/rate_limit"
 	"unibee/internal/logic/totp/client_activity"
@@ -246,6 +247,23 @@ func (s *SMiddleware) MerchantHandler(r *ghttp.Request) {
 		if merchantInfo == nil {
 			merchantInfo = merchant.GetMerchantByOpenApiKeyFromCache(r.Context(), customCtx.TokenString)
 		}
+		if merchantInfo == nil {
+			// Scoped ApiKey
+			apiKey, err := merchant_api_key.Authenticate(r.Context(), customCtx.TokenString, merchant_api_key.ClientIp(r.Request, config.GetConfigInstance().Server.TrustedProxies))
+			if err != nil {
+				g.Log().Infof(r.Context(), "MerchantHandler scoped api key invalid:%s", err.Error())
+				r.Response.Status = 401
+				_interface.OpenApiJsonExit(r, 61, err.Error())
+			} else if apiKey != nil {
+				if !merchant_api_key.IsRouteAllowed(merchant_api_key.GetApiKeyScopes(apiKey), r.Method, r.URL.Path) {
+					g.Log().Infof(r.Context(), "MerchantHandler scoped api key %d not permitted:%s", apiKey.Id, r.URL.Path)
+					r.Response.Status = 403
+					_interface.OpenApiJsonExit(r, 61, "api key not permitted")
+				}
+				merchantInfo = query.GetMerchantById(r.Context(), apiKey.MerchantId)
+				customCtx.OpenApiKeyId = apiKey.Id
+			}
+		}
 		if merchantInfo == nil {
 			r.Response.Status = 401
 			_interface.OpenApiJsonExit(r, 61, "invalid token")
@@ -264,6 +282,10 @@ func (s *SMiddleware) MerchantHandler(r *ghttp.Request) {
 		if len(lang) > 0 && i18n.IsLangAvailable(lang) {
 			r.SetCtx(gi18n.WithLanguage(r.Context(), strings.ToLower(strings.TrimSpace(lang))))
 		}
+		if len(r.GetHeader(IdempotencyKeyHeader)) > 0 {
+			idempotentNext(customCtx.MerchantId, r)
+			return
+		}
 	}
 	r.Middleware.Next()
 }
@@ -60,6 +60,11 @@ func AppendOptLog(superCtx context.Context, req *OptLogRequest, optError error)
 		optAccount = fmt.Sprintf("OpenApi(%v)", _interface.Context().Get(superCtx).OpenApiKey)
 		optAccountType = 2
 		optAccountId = fmt.Sprintf("%s", _interface.Context().Get(superCtx).OpenApiKey)
+		if _interface.Context().Get(superCtx).OpenApiKeyId > 0 {
+			// scoped api key, logged by id
+			optAccount = fmt.Sprintf("OpenApi(ApiKey#%v)", _interface.Context().Get(superCtx).OpenApiKeyId)
+			optAccountId = fmt.Sprintf("ApiKey#%v", _interface.Context().Get(superCtx).OpenApiKeyId)
+		}
 		var targetUserId uint64 = 0
 		if req.UserId > 0 {
 			targetUserId = req.UserId
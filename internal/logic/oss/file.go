@@ -2,25 +2,37 @@ package oss
 
 import (
 	"context"
+	"fmt"
 	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/gogf/gf/v2/util/grand"
+	"mime"
 	"os"
+	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"unibee/internal/cmd/config"
 	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/oss/storage"
 	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
 )
 
+// TagGdprExport is the tag of user data archive, the archive holds personal data and only downloadable by signed url
+const TagGdprExport = "gdpr_export"
+
 type FileUploadInput struct {
 	File       *ghttp.UploadFile
 	Path       string
 	Name       string
 	UserId     string
 	RandomName bool
+	MerchantId uint64
 }
 
 type FileUploadOutput struct {
@@ -53,10 +65,10 @@ func Upload(ctx context.Context, in FileUploadInput) (*FileUploadOutput, error)
 		fileName = in.Name
 	}
 
-	return UploadLocalFile(ctx, tempFileName, path, fileName, userId)
+	return UploadLocalFile(ctx, tempFileName, path, fileName, userId, in.MerchantId)
 }
 
-func UploadLocalFile(ctx context.Context, localFilePath string, uploadPath string, uploadFileName string, uploadUserId string) (*FileUploadOutput, error) {
+func UploadLocalFile(ctx context.Context, localFilePath string, uploadPath string, uploadFileName string, uploadUserId string, merchantId uint64) (*FileUploadOutput, error) {
 	data, err := os.ReadFile(localFilePath)
 	if err != nil {
 		return nil, err
@@ -67,12 +79,23 @@ func UploadLocalFile(ctx context.Context, localFilePath string, uploadPath strin
 
 	toSave := entity.FileUpload{
 		UserId:     uploadUserId,
+		MerchantId: merchantId,
 		Url:        config.GetConfigInstance().Server.DomainPath + "/oss/file/" + uploadFileName,
 		FileName:   uploadFileName,
 		Tag:        uploadPath,
 		Data:       data,
 		CreateTime: gtime.Now().Timestamp(),
 	}
+	if one := storage.DefaultStorage(); one != nil {
+		key := storage.ObjectKey(uploadPath, uploadFileName)
+		err = one.Put(ctx, key, data, mime.TypeByExtension(filepath.Ext(uploadFileName)))
+		if err != nil {
+			return nil, err
+		}
+		toSave.StorageType = one.Type()
+		toSave.StorageKey = key
+		toSave.Data = nil
+	}
 	result, err := dao.FileUpload.Ctx(ctx).Data(toSave).OmitNil().Insert()
 	if err != nil {
 		return nil, err
@@ -86,3 +109,91 @@ func UploadLocalFile(ctx context.Context, localFilePath string, uploadPath strin
 		Url:  toSave.Url,
 	}, nil
 }
+
+// GetFileData returns the bytes of file, from database or the storage it saved in
+func GetFileData(ctx context.Context, one *entity.FileUpload) ([]byte, error) {
+	if one == nil {
+		return nil, gerror.New("file not found")
+	}
+	if one.StorageType == storage.TypeDatabase {
+		return one.Data, nil
+	}
+	backend := storage.GetStorage(one.StorageType)
+	if backend == nil {
+		return nil, gerror.Newf("storage %s not setup", one.StorageType)
+	}
+	return backend.Get(ctx, one.StorageKey)
+}
+
+// IsPublicFile checks the file served by its url without signature, the images like logo are public, files saved in database
+// or migrated from it keep their public url, other files uploaded to storage and the user data archive only downloadable by signed url
+func IsPublicFile(one *entity.FileUpload) bool {
+	if one == nil || one.Tag == TagGdprExport {
+		return false
+	}
+	if one.StorageType == storage.TypeDatabase || one.Migrated == 1 {
+		return true
+	}
+	switch strings.ToLower(filepath.Ext(one.FileName)) {
+	case ".jpg", ".jpeg", ".png", ".svg":
+		return true
+	default:
+		return false
+	}
+}
+
+// GetFileSignedUrl returns the expiring download url of file, file saved in database has no signed url and returns its url
+func GetFileSignedUrl(ctx context.Context, one *entity.FileUpload) (string, error) {
+	if one == nil {
+		return "", gerror.New("file not found")
+	}
+	if one.StorageType == storage.TypeDatabase {
+		if IsPublicFile(one) {
+			return one.Url, nil
+		}
+		return storage.Signer().SignedUrl(ctx, storage.DatabaseKeyPrefix+one.FileName, storage.SignedUrlExpire())
+	}
+	backend := storage.GetStorage(one.StorageType)
+	if backend == nil {
+		return "", gerror.Newf("storage %s not setup", one.StorageType)
+	}
+	return backend.SignedUrl(ctx, one.StorageKey, storage.SignedUrlExpire())
+}
+
+// DeleteFile removes the file from the storage it saved in and its record
+func DeleteFile(ctx context.Context, one *entity.FileUpload) error {
+	if one == nil {
+		return nil
+	}
+	if one.StorageType != storage.TypeDatabase {
+		backend := storage.GetStorage(one.StorageType)
+		if backend == nil {
+			return gerror.Newf("storage %s not setup", one.StorageType)
+		}
+		if err := backend.Delete(ctx, one.StorageKey); err != nil {
+			return err
+		}
+	}
+	_, err := dao.FileUpload.Ctx(ctx).Where(dao.FileUpload.Columns().Id, one.Id).Delete()
+	return err
+}
+
+// DownloadFile saves the file of url to local and returns the local path, the oss file of server read directly from the storage
+// since the one out of database only downloadable by signed url, blank if failed
+func DownloadFile(ctx context.Context, url string) string {
+	one := query.GetOssFileByFileName(ctx, path.Base(url))
+	if one == nil || !strings.HasSuffix(url, "/oss/file/"+one.FileName) {
+		return utility.DownloadFile(url)
+	}
+	data, err := GetFileData(ctx, one)
+	if err != nil {
+		g.Log().Errorf(ctx, "DownloadFile url:%s error:%s", url, err.Error())
+		return ""
+	}
+	fileName := fmt.Sprintf("down_%d_%s", gtime.Now().Timestamp(), one.FileName)
+	if err = os.WriteFile(fileName, data, 0644); err != nil {
+		g.Log().Errorf(ctx, "DownloadFile url:%s error:%s", url, err.Error())
+		return ""
+	}
+	return fileName
+}
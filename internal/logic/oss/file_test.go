@@ -0,0 +1,23 @@
+package oss
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"unibee/internal/logic/oss/storage"
+	entity "unibee/internal/model/entity/default"
+)
+
+func TestIsPublicFile(t *testing.T) {
+	t.Run("Test for Database File", func(t *testing.T) {
+		require.True(t, IsPublicFile(&entity.FileUpload{FileName: "invoice.pdf", StorageType: storage.TypeDatabase}))
+		require.False(t, IsPublicFile(&entity.FileUpload{FileName: "export.zip", Tag: TagGdprExport, StorageType: storage.TypeDatabase}))
+	})
+	t.Run("Test for Migrated File", func(t *testing.T) {
+		require.True(t, IsPublicFile(&entity.FileUpload{FileName: "invoice.pdf", StorageType: storage.TypeLocal, Migrated: 1}))
+		require.False(t, IsPublicFile(&entity.FileUpload{FileName: "export.zip", Tag: TagGdprExport, StorageType: storage.TypeLocal, Migrated: 1}))
+	})
+	t.Run("Test for Storage File", func(t *testing.T) {
+		require.False(t, IsPublicFile(&entity.FileUpload{FileName: "invoice.pdf", StorageType: storage.TypeLocal}))
+		require.True(t, IsPublicFile(&entity.FileUpload{FileName: "logo.PNG", StorageType: storage.TypeLocal}))
+	})
+}
@@ -0,0 +1,66 @@
+package oss
+
+import (
+	"context"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"mime"
+	"path/filepath"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/oss/storage"
+	entity "unibee/internal/model/entity/default"
+)
+
+// MigrateFilesToStorage moves the file bytes saved in database to the storage of config, the url of file keeps unchanged and
+// stays public as it was, see IsPublicFile,
+// returns the count of files migrated, it can be run again to continue after failure
+func MigrateFilesToStorage(ctx context.Context, batchSize int) (int, error) {
+	backend := storage.DefaultStorage()
+	if backend == nil {
+		return 0, gerror.New("storage not setup, config storage.type to local or s3")
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	var count = 0
+	var lastId int64 = 0
+	for {
+		var list []*entity.FileUpload
+		err := dao.FileUpload.Ctx(ctx).
+			Where(dao.FileUpload.Columns().StorageType, storage.TypeDatabase).
+			WhereGT(dao.FileUpload.Columns().Id, lastId).
+			OrderAsc(dao.FileUpload.Columns().Id).
+			Limit(batchSize).
+			Scan(&list)
+		if err != nil {
+			return count, err
+		}
+		if len(list) == 0 {
+			return count, nil
+		}
+		for _, one := range list {
+			lastId = one.Id
+			if len(one.Data) == 0 {
+				continue
+			}
+			key := storage.ObjectKey(one.Tag, one.FileName)
+			err = backend.Put(ctx, key, one.Data, mime.TypeByExtension(filepath.Ext(one.FileName)))
+			if err != nil {
+				g.Log().Errorf(ctx, "MigrateFilesToStorage put file:%d error:%s", one.Id, err.Error())
+				return count, err
+			}
+			_, err = dao.FileUpload.Ctx(ctx).Data(g.Map{
+				dao.FileUpload.Columns().StorageType: backend.Type(),
+				dao.FileUpload.Columns().StorageKey:  key,
+				dao.FileUpload.Columns().Data:        []byte{},
+				dao.FileUpload.Columns().Migrated:    1,
+			}).Where(dao.FileUpload.Columns().Id, one.Id).Where(dao.FileUpload.Columns().StorageType, storage.TypeDatabase).Update()
+			if err != nil {
+				g.Log().Errorf(ctx, "MigrateFilesToStorage update file:%d error:%s", one.Id, err.Error())
+				return count, err
+			}
+			count++
+		}
+		g.Log().Infof(ctx, "MigrateFilesToStorage migrated:%d lastId:%d", count, lastId)
+	}
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type LocalStorage struct {
+	root      string
+	serverUrl string
+	signKey   string
+}
+
+func NewLocalStorage(root string, serverUrl string, signKey string) *LocalStorage {
+	return &LocalStorage{root: root, serverUrl: strings.TrimSuffix(serverUrl, "/"), signKey: signKey}
+}
+
+func (l *LocalStorage) Type() string {
+	return TypeLocal
+}
+
+func (l *LocalStorage) path(key string) (string, error) {
+	path := filepath.Join(l.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, filepath.Clean(l.root)+string(os.PathSeparator)) {
+		return "", gerror.Newf("invalid key:%s", key)
+	}
+	return path, nil
+}
+
+func (l *LocalStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func (l *LocalStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+func (l *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// SignedUrl links to the signed file entry of server, which verifies the signature by VerifySignedUrl
+func (l *LocalStorage) SignedUrl(ctx context.Context, key string, expire time.Duration) (string, error) {
+	if len(l.signKey) == 0 {
+		return "", gerror.New("sign secret of local storage not setup")
+	}
+	expires := time.Now().Add(expire).Unix()
+	return fmt.Sprintf("%s/oss/signed/%s?expires=%d&signature=%s", l.serverUrl, key, expires, l.sign(key, expires)), nil
+}
+
+// VerifySignedUrl checks the signature of local signed url is valid and not expired
+func (l *LocalStorage) VerifySignedUrl(key string, expires string, signature string, timeNow int64) bool {
+	if len(l.signKey) == 0 {
+		return false
+	}
+	expireTime, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || expireTime < timeNow {
+		return false
+	}
+	return hmac.Equal([]byte(l.sign(key, expireTime)), []byte(signature))
+}
+
+func (l *LocalStorage) sign(key string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(l.signKey))
+	mac.Write([]byte(fmt.Sprintf("%s\n%d", key, expires)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,220 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3Service         = "s3"
+	s3DefaultRegion   = "us-east-1"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3TimeFormat      = "20060102T150405Z"
+	s3DateFormat      = "20060102"
+	s3RequestTimeout  = 60 * time.Second
+)
+
+// S3Storage talks to the S3 compatible service (AWS S3, MinIO, etc.) with signature version 4
+type S3Storage struct {
+	endpoint      *url.URL
+	region        string
+	bucket        string
+	accessKey     string
+	secretKey     string
+	virtualHosted bool
+	client        *http.Client
+}
+
+func NewS3Storage(endpoint string, region string, bucket string, accessKey string, secretKey string, virtualHosted bool) (*S3Storage, error) {
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		endpoint = "https://" + endpoint
+	}
+	parsed, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if len(region) == 0 {
+		region = s3DefaultRegion
+	}
+	return &S3Storage{
+		endpoint:      parsed,
+		region:        region,
+		bucket:        bucket,
+		accessKey:     accessKey,
+		secretKey:     secretKey,
+		virtualHosted: virtualHosted,
+		client:        &http.Client{Timeout: s3RequestTimeout},
+	}, nil
+}
+
+func (s *S3Storage) Type() string {
+	return TypeS3
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	headers := map[string]string{}
+	if len(contentType) > 0 {
+		headers["content-type"] = contentType
+	}
+	_, err := s.do(ctx, http.MethodPut, key, data, headers)
+	return err
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) ([]byte, error) {
+	return s.do(ctx, http.MethodGet, key, nil, nil)
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	_, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+	return err
+}
+
+func (s *S3Storage) SignedUrl(ctx context.Context, key string, expire time.Duration) (string, error) {
+	return s.presign(http.MethodGet, key, expire, time.Now().UTC()), nil
+}
+
+func (s *S3Storage) hostAndPath(key string) (string, string) {
+	if s.virtualHosted {
+		return s.bucket + "." + s.endpoint.Host, s.endpoint.Path + "/" + uriEncode(key, false)
+	}
+	return s.endpoint.Host, s.endpoint.Path + "/" + uriEncode(s.bucket, true) + "/" + uriEncode(key, false)
+}
+
+// presign generates the query string authenticated url, see AWS "Authenticating Requests: Using Query Parameters"
+func (s *S3Storage) presign(method string, key string, expire time.Duration, timeNow time.Time) string {
+	host, path := s.hostAndPath(key)
+	amzDate := timeNow.Format(s3TimeFormat)
+	scope := s.scope(timeNow)
+	query := map[string]string{
+		"X-Amz-Algorithm":     s3Algorithm,
+		"X-Amz-Credential":    s.accessKey + "/" + scope,
+		"X-Amz-Date":          amzDate,
+		"X-Amz-Expires":       strconv.FormatInt(int64(expire/time.Second), 10),
+		"X-Amz-SignedHeaders": "host",
+	}
+	canonicalQuery := canonicalQueryString(query)
+	canonicalRequest := strings.Join([]string{method, path, canonicalQuery, "host:" + host + "\n", "host", s3UnsignedPayload}, "\n")
+	signature := s.signature(timeNow, amzDate, scope, canonicalRequest)
+	return fmt.Sprintf("%s://%s%s?%s&X-Amz-Signature=%s", s.endpoint.Scheme, host, path, canonicalQuery, signature)
+}
+
+func (s *S3Storage) do(ctx context.Context, method string, key string, data []byte, headers map[string]string) ([]byte, error) {
+	host, path := s.hostAndPath(key)
+	timeNow := time.Now().UTC()
+	amzDate := timeNow.Format(s3TimeFormat)
+	payloadHash := sha256Hex(data)
+	signHeaders := map[string]string{
+		"host":                 host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	for k, v := range headers {
+		signHeaders[strings.ToLower(k)] = v
+	}
+	var names = make([]string, 0, len(signHeaders))
+	for k := range signHeaders {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(signHeaders[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+	scope := s.scope(timeNow)
+	canonicalRequest := strings.Join([]string{method, path, "", canonicalHeaders.String(), signedHeaders, payloadHash}, "\n")
+	signature := s.signature(timeNow, amzDate, scope, canonicalRequest)
+
+	request, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s://%s%s", s.endpoint.Scheme, host, path), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if name != "host" {
+			request.Header.Set(name, signHeaders[name])
+		}
+	}
+	request.Host = host
+	request.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s", s3Algorithm, s.accessKey, scope, signedHeaders, signature))
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, gerror.Newf("s3 %s %s error status:%d body:%s", method, key, response.StatusCode, string(body))
+	}
+	return body, nil
+}
+
+func (s *S3Storage) scope(timeNow time.Time) string {
+	return strings.Join([]string{timeNow.Format(s3DateFormat), s.region, s3Service, "aws4_request"}, "/")
+}
+
+func (s *S3Storage) signature(timeNow time.Time, amzDate string, scope string, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{s3Algorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretKey), timeNow.Format(s3DateFormat))
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, s3Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+}
+
+func canonicalQueryString(query map[string]string) string {
+	var keys = make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var pairs = make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, uriEncode(k, true)+"="+uriEncode(query[k], true))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode encodes every byte except the unreserved characters of RFC 3986, slash kept unless encodeSlash
+func uriEncode(s string, encodeSlash bool) string {
+	var builder strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' {
+			builder.WriteByte(c)
+		} else if c == '/' && !encodeSlash {
+			builder.WriteByte(c)
+		} else {
+			builder.WriteString(fmt.Sprintf("%%%02X", c))
+		}
+	}
+	return builder.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unibee/internal/cmd/config"
+)
+
+const (
+	TypeDatabase = ""
+	TypeLocal    = "local"
+	TypeS3       = "s3"
+
+	DefaultSignedUrlExpire = 3600
+
+	// DatabaseKeyPrefix prefixes the key of signed url to the file saved in database, served by the signed file entry as local storage
+	DatabaseKeyPrefix = "database/"
+)
+
+// Storage saves the file bytes out of database, the key is unique within backend
+type Storage interface {
+	Type() string
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	SignedUrl(ctx context.Context, key string, expire time.Duration) (string, error)
+}
+
+// GetStorage returns the backend of storage type, nil for database or backend not setup
+func GetStorage(storageType string) Storage {
+	switch strings.ToLower(storageType) {
+	case TypeLocal:
+		localPath := config.GetConfigInstance().Storage.LocalPath
+		if len(localPath) == 0 {
+			return nil
+		}
+		return NewLocalStorage(localPath, config.GetConfigInstance().Server.GetServerPath(), config.GetConfigInstance().Storage.SignSecret)
+	case TypeS3:
+		minio := config.GetConfigInstance().MinioConfig
+		if len(minio.Endpoint) == 0 || len(minio.BucketName) == 0 || len(minio.AccessKey) == 0 || len(minio.SecretKey) == 0 {
+			return nil
+		}
+		one, err := NewS3Storage(minio.Endpoint, config.GetConfigInstance().Storage.Region, minio.BucketName, minio.AccessKey, minio.SecretKey, config.GetConfigInstance().Storage.VirtualHosted)
+		if err != nil {
+			return nil
+		}
+		return one
+	default:
+		return nil
+	}
+}
+
+// DefaultStorage returns the backend of config to save new file, nil if files saved in database
+func DefaultStorage() Storage {
+	return GetStorage(config.GetConfigInstance().Storage.Type)
+}
+
+// Signer returns the signer of the signed url served by server, the file saved in database signed by it too
+func Signer() *LocalStorage {
+	return NewLocalStorage(config.GetConfigInstance().Storage.LocalPath, config.GetConfigInstance().Server.GetServerPath(), config.GetConfigInstance().Storage.SignSecret)
+}
+
+func SignedUrlExpire() time.Duration {
+	if config.GetConfigInstance().Storage.SignedUrlExpire > 0 {
+		return time.Duration(config.GetConfigInstance().Storage.SignedUrlExpire) * time.Second
+	}
+	return DefaultSignedUrlExpire * time.Second
+}
+
+func ObjectKey(tag string, fileName string) string {
+	if len(tag) == 0 {
+		return fileName
+	}
+	return fmt.Sprintf("%s/%s", strings.Trim(tag, "/"), fileName)
+}
@@ -0,0 +1,21 @@
+package detail
+
+import (
+	"context"
+	"unibee/api/bean"
+	"unibee/api/bean/detail"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+func GetPaymentDisputeDetail(ctx context.Context, merchantId uint64, disputeId string) *detail.PaymentDisputeDetail {
+	one := query.GetPaymentDisputeByDisputeId(ctx, disputeId)
+	utility.Assert(one != nil, "dispute not found")
+	utility.Assert(merchantId == one.MerchantId, "merchant not match")
+	return &detail.PaymentDisputeDetail{
+		User:    bean.SimplifyUserAccount(query.GetUserAccountById(ctx, one.UserId)),
+		Payment: bean.SimplifyPayment(query.GetPaymentByPaymentId(ctx, one.PaymentId)),
+		Invoice: bean.SimplifyInvoice(query.GetInvoiceByInvoiceId(ctx, one.InvoiceId)),
+		Dispute: bean.SimplifyPaymentDispute(one),
+	}
+}
@@ -0,0 +1,261 @@
+package dispute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unibee/api/bean"
+	redismqcmd "unibee/internal/cmd/redismq"
+	"unibee/internal/consts"
+	"unibee/internal/consumer/webhook/event"
+	payment2 "unibee/internal/consumer/webhook/payment"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/merchant_config"
+	"unibee/internal/logic/operation_log"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	redismq "github.com/jackyang-hk/go-redismq"
+)
+
+const (
+	DisputeConfig = "DisputeConfig"
+)
+
+func GetMerchantDisputeConfig(ctx context.Context, merchantId uint64) *bean.DisputeConfig {
+	disputeConfig := &bean.DisputeConfig{SuspendSubscriptionOnLost: false}
+	one := merchant_config.GetMerchantConfig(ctx, merchantId, DisputeConfig)
+	if one != nil && len(one.ConfigValue) > 0 {
+		var target *bean.DisputeConfig
+		err := utility.UnmarshalFromJsonString(one.ConfigValue, &target)
+		if err == nil && target != nil {
+			disputeConfig = target
+		}
+	}
+	return disputeConfig
+}
+
+// GatewayDisputeReq is the dispute gateway webhook reported, the status should be mapped into the dispute status of UniBee
+type GatewayDisputeReq struct {
+	Gateway          *entity.MerchantGateway
+	GatewayDisputeId string
+	PaymentId        string // payment id of UniBee, if gateway knows
+	GatewayPaymentId string // used to find the payment if PaymentId is empty
+	Currency         string
+	DisputeAmount    int64
+	Status           int
+	GatewayStatus    string
+	Reason           string
+	EvidenceDueTime  int64
+}
+
+func isDisputeClosed(status int) bool {
+	return status == consts.DisputeWon || status == consts.DisputeLost
+}
+
+func statusWebhookEvent(status int) event.WebhookEvent {
+	switch status {
+	case consts.DisputeEvidenceNeeded:
+		return event.UNIBEE_WEBHOOK_EVENT_DISPUTE_EVIDENCE_NEED
+	case consts.DisputeWon:
+		return event.UNIBEE_WEBHOOK_EVENT_DISPUTE_WON
+	case consts.DisputeLost:
+		return event.UNIBEE_WEBHOOK_EVENT_DISPUTE_LOST
+	default:
+		return ""
+	}
+}
+
+// HandleGatewayDispute creates or updates the dispute of payment from gateway webhook, sends the dispute webhook when status changed
+func HandleGatewayDispute(ctx context.Context, req *GatewayDisputeReq) (*entity.PaymentDispute, error) {
+	if req == nil || req.Gateway == nil || len(req.GatewayDisputeId) == 0 {
+		return nil, gerror.New("HandleGatewayDispute invalid request")
+	}
+	if req.Status != consts.DisputeOpened && req.Status != consts.DisputeEvidenceNeeded && !isDisputeClosed(req.Status) {
+		return nil, gerror.Newf("HandleGatewayDispute invalid status:%d", req.Status)
+	}
+	payment := query.GetPaymentByPaymentId(ctx, req.PaymentId)
+	if payment == nil {
+		payment = query.GetPaymentByGatewayPaymentId(ctx, req.GatewayPaymentId)
+	}
+	if payment == nil {
+		return nil, gerror.Newf("HandleGatewayDispute payment not found, gatewayDisputeId:%s paymentId:%s gatewayPaymentId:%s", req.GatewayDisputeId, req.PaymentId, req.GatewayPaymentId)
+	}
+	if payment.MerchantId != req.Gateway.MerchantId {
+		return nil, gerror.Newf("HandleGatewayDispute merchant not match, gatewayDisputeId:%s", req.GatewayDisputeId)
+	}
+	key := fmt.Sprintf("HandleGatewayDispute-%d-%s", req.Gateway.Id, req.GatewayDisputeId)
+	if !utility.TryLock(ctx, key, 10) {
+		return nil, gerror.Newf("HandleGatewayDispute too fast duplicate call, gatewayDisputeId:%s", req.GatewayDisputeId)
+	}
+	defer func() {
+		utility.ReleaseLock(ctx, key)
+	}()
+	if len(req.Currency) == 0 {
+		req.Currency = payment.Currency
+	}
+	if req.DisputeAmount <= 0 {
+		req.DisputeAmount = payment.TotalAmount
+	}
+	var closeTime int64 = 0
+	if isDisputeClosed(req.Status) {
+		closeTime = gtime.Now().Timestamp()
+	}
+	one := query.GetPaymentDisputeByGatewayDisputeId(ctx, req.Gateway.Id, req.GatewayDisputeId)
+	if one == nil {
+		one = &entity.PaymentDispute{
+			MerchantId:       payment.MerchantId,
+			UserId:           payment.UserId,
+			DisputeId:        utility.CreateDisputeId(),
+			GatewayId:        req.Gateway.Id,
+			GatewayDisputeId: req.GatewayDisputeId,
+			PaymentId:        payment.PaymentId,
+			InvoiceId:        payment.InvoiceId,
+			SubscriptionId:   payment.SubscriptionId,
+			Currency:         strings.ToUpper(req.Currency),
+			DisputeAmount:    req.DisputeAmount,
+			Status:           req.Status,
+			GatewayStatus:    req.GatewayStatus,
+			Reason:           req.Reason,
+			EvidenceDueTime:  req.EvidenceDueTime,
+			CloseTime:        closeTime,
+			CreateTime:       gtime.Now().Timestamp(),
+		}
+		result, err := dao.PaymentDispute.Ctx(ctx).Data(one).OmitNil().Insert(one)
+		if err != nil {
+			return nil, gerror.Newf(`HandleGatewayDispute record insert failure %s`, err.Error())
+		}
+		id, _ := result.LastInsertId()
+		one.Id = uint64(id)
+		g.Log().Infof(ctx, "HandleGatewayDispute created disputeId:%s paymentId:%s status:%d", one.DisputeId, one.PaymentId, one.Status)
+		payment2.SendDisputeWebhookBackground(one.DisputeId, event.UNIBEE_WEBHOOK_EVENT_DISPUTE_CREATED)
+		onDisputeStatusChanged(ctx, one)
+		return one, nil
+	}
+	if one.Status == req.Status && one.GatewayStatus == req.GatewayStatus && one.EvidenceDueTime == req.EvidenceDueTime {
+		return one, nil
+	}
+	var statusChanged = one.Status != req.Status
+	if statusChanged && isDisputeClosed(one.Status) {
+		// won or lost is final, the late or replayed event of gateway never reopens the dispute
+		g.Log().Infof(ctx, "HandleGatewayDispute ignored disputeId:%s closed status:%d->%d", one.DisputeId, one.Status, req.Status)
+		return one, nil
+	}
+	if !statusChanged || !isDisputeClosed(req.Status) {
+		closeTime = one.CloseTime
+	}
+	update, err := dao.PaymentDispute.Ctx(ctx).Data(g.Map{
+		dao.PaymentDispute.Columns().Status:          req.Status,
+		dao.PaymentDispute.Columns().GatewayStatus:   req.GatewayStatus,
+		dao.PaymentDispute.Columns().DisputeAmount:   req.DisputeAmount,
+		dao.PaymentDispute.Columns().EvidenceDueTime: req.EvidenceDueTime,
+		dao.PaymentDispute.Columns().CloseTime:       closeTime,
+		dao.PaymentDispute.Columns().GmtModify:       gtime.Now(),
+	}).Where(dao.PaymentDispute.Columns().Id, one.Id).
+		Where(dao.PaymentDispute.Columns().Status, one.Status).
+		Update()
+	if err != nil {
+		return nil, gerror.Newf(`HandleGatewayDispute record update failure %s`, err.Error())
+	}
+	if affected, _ := update.RowsAffected(); affected != 1 {
+		return nil, gerror.Newf(`HandleGatewayDispute disputeId:%s status changed, please retry`, one.DisputeId)
+	}
+	g.Log().Infof(ctx, "HandleGatewayDispute updated disputeId:%s status:%d->%d", one.DisputeId, one.Status, req.Status)
+	one = query.GetPaymentDisputeByDisputeId(ctx, one.DisputeId)
+	if statusChanged {
+		onDisputeStatusChanged(ctx, one)
+	}
+	return one, nil
+}
+
+func onDisputeStatusChanged(ctx context.Context, one *entity.PaymentDispute) {
+	if webhookEvent := statusWebhookEvent(one.Status); len(webhookEvent) > 0 {
+		payment2.SendDisputeWebhookBackground(one.DisputeId, webhookEvent)
+	}
+	if one.Status == consts.DisputeLost && len(one.SubscriptionId) > 0 {
+		_, _ = redismq.Send(&redismq.Message{
+			Topic:      redismqcmd.TopicDisputeLost.Topic,
+			Tag:        redismqcmd.TopicDisputeLost.Tag,
+			Body:       one.DisputeId,
+			CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+		})
+	}
+}
+
+// AppendDisputeEvidence attaches the evidence file uploaded to oss to the dispute
+func AppendDisputeEvidence(ctx context.Context, merchantId uint64, disputeId string, evidence *bean.DisputeEvidence) (*entity.PaymentDispute, error) {
+	utility.Assert(evidence != nil && len(evidence.Url) > 0, "evidence file not found")
+	one := query.GetPaymentDisputeByDisputeId(ctx, disputeId)
+	utility.Assert(one != nil, "dispute not found")
+	utility.Assert(one.MerchantId == merchantId, "merchant not match")
+	utility.Assert(!isDisputeClosed(one.Status), "dispute already closed")
+	var evidences = make([]*bean.DisputeEvidence, 0)
+	if len(one.Evidence) > 0 {
+		_ = utility.UnmarshalFromJsonString(one.Evidence, &evidences)
+	}
+	if evidence.UploadTime == 0 {
+		evidence.UploadTime = gtime.Now().Timestamp()
+	}
+	evidences = append(evidences, evidence)
+	_, err := dao.PaymentDispute.Ctx(ctx).Data(g.Map{
+		dao.PaymentDispute.Columns().Evidence:  utility.MarshalToJsonString(evidences),
+		dao.PaymentDispute.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.PaymentDispute.Columns().Id, one.Id).Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("Dispute(%s)", one.DisputeId),
+		Content:        fmt.Sprintf("UploadEvidence(%s)", evidence.FileName),
+		UserId:         one.UserId,
+		SubscriptionId: one.SubscriptionId,
+		InvoiceId:      one.InvoiceId,
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return query.GetPaymentDisputeByDisputeId(ctx, one.DisputeId), nil
+}
+
+type DisputeListInternalReq struct {
+	MerchantId     uint64 `json:"merchantId" dc:"MerchantId" v:"required"`
+	UserId         uint64 `json:"userId" dc:"Filter UserId, Default All"`
+	PaymentId      string `json:"paymentId" dc:"Filter PaymentId"`
+	InvoiceId      string `json:"invoiceId" dc:"Filter InvoiceId"`
+	SubscriptionId string `json:"subscriptionId" dc:"Filter SubscriptionId"`
+	Status         []int  `json:"status" dc:"Filter Status, 10-opened, 20-evidence needed, 30-won, 40-lost"`
+	Page           int    `json:"page"  dc:"Page, Start With 0"`
+	Count          int    `json:"count" dc:"Count Of Page"`
+}
+
+func DisputeList(ctx context.Context, req *DisputeListInternalReq) (list []*entity.PaymentDispute, total int) {
+	if req.Count <= 0 {
+		req.Count = 20
+	}
+	if req.Page < 0 {
+		req.Page = 0
+	}
+	utility.Assert(req.MerchantId > 0, "merchantId not found")
+	q := dao.PaymentDispute.Ctx(ctx).
+		Where(dao.PaymentDispute.Columns().MerchantId, req.MerchantId).
+		Where(dao.PaymentDispute.Columns().UserId, req.UserId).
+		Where(dao.PaymentDispute.Columns().PaymentId, req.PaymentId).
+		Where(dao.PaymentDispute.Columns().InvoiceId, req.InvoiceId).
+		Where(dao.PaymentDispute.Columns().SubscriptionId, req.SubscriptionId).
+		Where(dao.PaymentDispute.Columns().IsDeleted, 0)
+	if len(req.Status) > 0 {
+		q = q.WhereIn(dao.PaymentDispute.Columns().Status, req.Status)
+	}
+	err := q.Order("gmt_create desc").
+		Limit(req.Page*req.Count, req.Count).
+		OmitEmpty().ScanAndCount(&list, &total, true)
+	if err != nil {
+		g.Log().Errorf(ctx, "DisputeList error:%s", err.Error())
+		return make([]*entity.PaymentDispute, 0), 0
+	}
+	return list, total
+}
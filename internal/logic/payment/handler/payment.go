@@ -631,6 +631,34 @@ func CreateOrUpdatePaymentTimelineForPayment(ctx context.Context, payment *entit
 	return nil
 }
 
+// CreatePaymentTimelineForGatewayAttempt records the failed attempt of the payment on its current gateway
+func CreatePaymentTimelineForGatewayAttempt(ctx context.Context, payment *entity.Payment, attempt int, reason string) error {
+	uniqueId := fmt.Sprintf("%s_attempt_%d", payment.PaymentId, attempt)
+	if query.GetPaymentTimeLineByUniqueId(ctx, uniqueId) != nil {
+		return nil
+	}
+	one := &entity.PaymentTimeline{
+		MerchantId:     payment.MerchantId,
+		UserId:         payment.UserId,
+		SubscriptionId: payment.SubscriptionId,
+		InvoiceId:      payment.InvoiceId,
+		UniqueId:       uniqueId,
+		Currency:       payment.Currency,
+		TotalAmount:    payment.TotalAmount,
+		GatewayId:      payment.GatewayId,
+		PaymentId:      payment.PaymentId,
+		Status:         2,
+		TimelineType:   consts.TimelineTypeGatewayAttempt,
+		Reason:         reason,
+		CreateTime:     gtime.Now().Timestamp(),
+	}
+	_, err := dao.PaymentTimeline.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	if err != nil {
+		return gerror.Newf(`CreatePaymentTimelineForGatewayAttempt record insert failure %s`, err.Error())
+	}
+	return nil
+}
+
 func CreateOrUpdatePaymentItemForPaymentInvoice(ctx context.Context, invoice *entity.Invoice, paymentStatus int) error {
 	if invoice == nil {
 		return gerror.Newf(`invoice is nil`)
@@ -0,0 +1,210 @@
+package reconciliation
+
+import (
+	"fmt"
+	"strings"
+	"unibee/internal/consts"
+	"unibee/internal/logic/gateway/gateway_bean"
+	entity "unibee/internal/model/entity/default"
+)
+
+const (
+	SourcePayment = "payment"
+	SourceRefund  = "refund"
+
+	TypeMissingLocal   = "missing_local"
+	TypeMissingGateway = "missing_gateway"
+	TypeAmountDrift    = "amount_drift"
+	TypeStatusDrift    = "status_drift"
+	// TypeLookupFailed the gateway object not queried for timeout, auth failure or the like, nothing compared
+	TypeLookupFailed = "lookup_failed"
+
+	ResolveStatusOpen     = 0
+	ResolveStatusResolved = 1
+	ResolveStatusIgnored  = 2
+)
+
+// ComparePayment returns the discrepancies between the local payment and the gateway one, remote nil means not found at gateway
+func ComparePayment(local *entity.Payment, remote *gateway_bean.GatewayPaymentRo) []*entity.MerchantGatewayDiscrepancy {
+	var list []*entity.MerchantGatewayDiscrepancy
+	if local == nil {
+		return list
+	}
+	if remote == nil {
+		list = append(list, paymentDiscrepancy(local, nil, TypeMissingGateway, "payment not found at gateway"))
+		return list
+	}
+	if remote.TotalAmount > 0 && remote.TotalAmount != local.TotalAmount {
+		list = append(list, paymentDiscrepancy(local, remote, TypeAmountDrift, fmt.Sprintf("local amount %d, gateway amount %d", local.TotalAmount, remote.TotalAmount)))
+	} else if len(remote.Currency) > 0 && !strings.EqualFold(remote.Currency, local.Currency) {
+		list = append(list, paymentDiscrepancy(local, remote, TypeAmountDrift, fmt.Sprintf("local currency %s, gateway currency %s", local.Currency, remote.Currency)))
+	}
+	if paymentStatusDrift(local.Status, remote.Status) {
+		list = append(list, paymentDiscrepancy(local, remote, TypeStatusDrift, fmt.Sprintf("local status %d, gateway status %d", local.Status, remote.Status)))
+	}
+	return list
+}
+
+// paymentStatusDrift ignores the gateway intent left pending after the local payment failed or cancelled, nothing charged
+func paymentStatusDrift(localStatus int, gatewayStatus int) bool {
+	if localStatus == gatewayStatus {
+		return false
+	}
+	if gatewayStatus == consts.PaymentCreated && (localStatus == consts.PaymentFailed || localStatus == consts.PaymentCancelled) {
+		return false
+	}
+	return true
+}
+
+// IsSafePaymentFix reports the local status can be moved to the gateway one, only pending payments moved to a final status,
+// the payments already final locally, or succeeded without the exact amount and currency reported by gateway left to review
+func IsSafePaymentFix(local *entity.Payment, remote *gateway_bean.GatewayPaymentRo) bool {
+	if local == nil || remote == nil || local.Status != consts.PaymentCreated {
+		return false
+	}
+	if remote.Status == consts.PaymentSuccess {
+		return remote.TotalAmount == local.TotalAmount && len(remote.Currency) > 0 && strings.EqualFold(remote.Currency, local.Currency)
+	}
+	return remote.Status == consts.PaymentFailed || remote.Status == consts.PaymentCancelled
+}
+
+// PaymentLookupFailed returns the discrepancy of the local payment failed to query at gateway
+func PaymentLookupFailed(local *entity.Payment, err error) *entity.MerchantGatewayDiscrepancy {
+	return paymentDiscrepancy(local, nil, TypeLookupFailed, fmt.Sprintf("payment lookup at gateway failed, %s", err.Error()))
+}
+
+// MissingLocalPayment returns the discrepancy of the gateway payment not found locally
+func MissingLocalPayment(gateway *entity.MerchantGateway, userId uint64, remote *gateway_bean.GatewayPaymentRo) *entity.MerchantGatewayDiscrepancy {
+	return &entity.MerchantGatewayDiscrepancy{
+		MerchantId:       gateway.MerchantId,
+		GatewayId:        gateway.Id,
+		GatewayName:      gateway.GatewayName,
+		SourceType:       SourcePayment,
+		DiscrepancyType:  TypeMissingLocal,
+		GatewayPaymentId: remote.GatewayPaymentId,
+		UserId:           userId,
+		Currency:         strings.ToUpper(remote.Currency),
+		GatewayAmount:    remote.TotalAmount,
+		GatewayStatus:    remote.Status,
+		Detail:           "payment not found locally",
+	}
+}
+
+func paymentDiscrepancy(local *entity.Payment, remote *gateway_bean.GatewayPaymentRo, discrepancyType string, detail string) *entity.MerchantGatewayDiscrepancy {
+	one := &entity.MerchantGatewayDiscrepancy{
+		MerchantId:       local.MerchantId,
+		GatewayId:        local.GatewayId,
+		SourceType:       SourcePayment,
+		DiscrepancyType:  discrepancyType,
+		PaymentId:        local.PaymentId,
+		GatewayPaymentId: local.GatewayPaymentId,
+		UserId:           local.UserId,
+		InvoiceId:        local.InvoiceId,
+		Currency:         local.Currency,
+		LocalAmount:      local.TotalAmount,
+		LocalStatus:      local.Status,
+		Detail:           detail,
+	}
+	if remote != nil {
+		one.GatewayAmount = remote.TotalAmount
+		one.GatewayStatus = remote.Status
+	}
+	return one
+}
+
+// CompareRefund returns the discrepancies between the local refund and the gateway one, remote nil means not found at gateway
+func CompareRefund(local *entity.Refund, remote *gateway_bean.GatewayPaymentRefundResp) []*entity.MerchantGatewayDiscrepancy {
+	var list []*entity.MerchantGatewayDiscrepancy
+	if local == nil {
+		return list
+	}
+	if remote == nil {
+		list = append(list, refundDiscrepancy(local, nil, TypeMissingGateway, "refund not found at gateway"))
+		return list
+	}
+	if remote.RefundAmount > 0 && remote.RefundAmount != local.RefundAmount {
+		list = append(list, refundDiscrepancy(local, remote, TypeAmountDrift, fmt.Sprintf("local amount %d, gateway amount %d", local.RefundAmount, remote.RefundAmount)))
+	}
+	if int(remote.Status) != local.Status && !(int(remote.Status) == consts.RefundCreated && local.Status == consts.RefundCancelled) {
+		list = append(list, refundDiscrepancy(local, remote, TypeStatusDrift, fmt.Sprintf("local status %d, gateway status %d", local.Status, remote.Status)))
+	}
+	return list
+}
+
+// IsSafeRefundFix reports the local status can be moved to the gateway one, only pending refunds moved to a final status,
+// succeeded without the exact amount reported by gateway left to review
+func IsSafeRefundFix(local *entity.Refund, remote *gateway_bean.GatewayPaymentRefundResp) bool {
+	if local == nil || remote == nil || local.Status != consts.RefundCreated {
+		return false
+	}
+	switch int(remote.Status) {
+	case consts.RefundSuccess:
+		return remote.RefundAmount == local.RefundAmount
+	case consts.RefundFailed, consts.RefundCancelled, consts.RefundReverse:
+		return true
+	default:
+		return false
+	}
+}
+
+// RefundLookupFailed returns the discrepancy of the local refund failed to query at gateway
+func RefundLookupFailed(local *entity.Refund, err error) *entity.MerchantGatewayDiscrepancy {
+	return refundDiscrepancy(local, nil, TypeLookupFailed, fmt.Sprintf("refund lookup at gateway failed, %s", err.Error()))
+}
+
+// MissingLocalRefund returns the discrepancy of the gateway refund not found locally
+func MissingLocalRefund(payment *entity.Payment, remote *gateway_bean.GatewayPaymentRefundResp) *entity.MerchantGatewayDiscrepancy {
+	return &entity.MerchantGatewayDiscrepancy{
+		MerchantId:       payment.MerchantId,
+		GatewayId:        payment.GatewayId,
+		SourceType:       SourceRefund,
+		DiscrepancyType:  TypeMissingLocal,
+		PaymentId:        payment.PaymentId,
+		GatewayPaymentId: payment.GatewayPaymentId,
+		GatewayRefundId:  remote.GatewayRefundId,
+		UserId:           payment.UserId,
+		InvoiceId:        payment.InvoiceId,
+		Currency:         payment.Currency,
+		GatewayAmount:    remote.RefundAmount,
+		GatewayStatus:    int(remote.Status),
+		Detail:           "refund not found locally",
+	}
+}
+
+func refundDiscrepancy(local *entity.Refund, remote *gateway_bean.GatewayPaymentRefundResp, discrepancyType string, detail string) *entity.MerchantGatewayDiscrepancy {
+	one := &entity.MerchantGatewayDiscrepancy{
+		MerchantId:      local.MerchantId,
+		GatewayId:       local.GatewayId,
+		SourceType:      SourceRefund,
+		DiscrepancyType: discrepancyType,
+		PaymentId:       local.PaymentId,
+		RefundId:        local.RefundId,
+		GatewayRefundId: local.GatewayRefundId,
+		UserId:          local.UserId,
+		InvoiceId:       local.InvoiceId,
+		Currency:        local.Currency,
+		LocalAmount:     local.RefundAmount,
+		LocalStatus:     local.Status,
+		Detail:          detail,
+	}
+	if remote != nil {
+		one.GatewayAmount = remote.RefundAmount
+		one.GatewayStatus = int(remote.Status)
+	}
+	return one
+}
+
+// DiscrepancyUniqueKey keeps one discrepancy row per gateway object and type, the ones found again by overlapped windows updated
+func DiscrepancyUniqueKey(one *entity.MerchantGatewayDiscrepancy) string {
+	id := one.GatewayPaymentId
+	if one.SourceType == SourceRefund {
+		id = one.GatewayRefundId
+		if len(id) == 0 {
+			id = one.RefundId
+		}
+	}
+	if len(id) == 0 {
+		id = one.PaymentId
+	}
+	return fmt.Sprintf("%d_%s_%s_%s", one.GatewayId, one.SourceType, id, one.DiscrepancyType)
+}
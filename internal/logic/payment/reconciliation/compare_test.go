@@ -0,0 +1,76 @@
+package reconciliation
+
+import (
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v78"
+	"testing"
+	"unibee/internal/consts"
+	"unibee/internal/logic/gateway/gateway_bean"
+	entity "unibee/internal/model/entity/default"
+)
+
+func TestCompare(t *testing.T) {
+	payment := &entity.Payment{MerchantId: 1, GatewayId: 2, PaymentId: "pay1", GatewayPaymentId: "pi_1", Currency: "USD", TotalAmount: 1000, Status: consts.PaymentCreated}
+	t.Run("Test for ComparePayment", func(t *testing.T) {
+		list := ComparePayment(payment, nil)
+		require.Equal(t, 1, len(list))
+		require.Equal(t, TypeMissingGateway, list[0].DiscrepancyType)
+		list = ComparePayment(payment, &gateway_bean.GatewayPaymentRo{Currency: "usd", TotalAmount: 1000, Status: consts.PaymentCreated})
+		require.Equal(t, 0, len(list))
+		list = ComparePayment(payment, &gateway_bean.GatewayPaymentRo{Currency: "USD", TotalAmount: 900, Status: consts.PaymentSuccess})
+		require.Equal(t, 2, len(list))
+		require.Equal(t, TypeAmountDrift, list[0].DiscrepancyType)
+		require.Equal(t, TypeStatusDrift, list[1].DiscrepancyType)
+		require.Equal(t, int64(900), list[0].GatewayAmount)
+		// gateway intent left pending after local cancelled
+		cancelled := &entity.Payment{PaymentId: "pay2", GatewayPaymentId: "pi_2", Currency: "USD", TotalAmount: 1000, Status: consts.PaymentCancelled}
+		require.Equal(t, 0, len(ComparePayment(cancelled, &gateway_bean.GatewayPaymentRo{TotalAmount: 1000, Status: consts.PaymentCreated})))
+	})
+	t.Run("Test for IsSafePaymentFix", func(t *testing.T) {
+		require.True(t, IsSafePaymentFix(payment, &gateway_bean.GatewayPaymentRo{TotalAmount: 1000, Currency: "usd", Status: consts.PaymentSuccess}))
+		require.True(t, IsSafePaymentFix(payment, &gateway_bean.GatewayPaymentRo{TotalAmount: 1000, Status: consts.PaymentFailed}))
+		require.False(t, IsSafePaymentFix(payment, &gateway_bean.GatewayPaymentRo{TotalAmount: 900, Currency: "USD", Status: consts.PaymentSuccess}))
+		require.False(t, IsSafePaymentFix(payment, &gateway_bean.GatewayPaymentRo{TotalAmount: 0, Currency: "USD", Status: consts.PaymentSuccess}))
+		require.False(t, IsSafePaymentFix(payment, &gateway_bean.GatewayPaymentRo{TotalAmount: 1000, Status: consts.PaymentSuccess}))
+		require.False(t, IsSafePaymentFix(payment, &gateway_bean.GatewayPaymentRo{TotalAmount: 1000, Currency: "EUR", Status: consts.PaymentSuccess}))
+		require.False(t, IsSafePaymentFix(payment, &gateway_bean.GatewayPaymentRo{TotalAmount: 1000, Status: consts.PaymentCreated}))
+		success := &entity.Payment{TotalAmount: 1000, Status: consts.PaymentSuccess}
+		require.False(t, IsSafePaymentFix(success, &gateway_bean.GatewayPaymentRo{TotalAmount: 1000, Status: consts.PaymentFailed}))
+	})
+	t.Run("Test for CompareRefund", func(t *testing.T) {
+		refund := &entity.Refund{MerchantId: 1, GatewayId: 2, PaymentId: "pay1", RefundId: "re1", GatewayRefundId: "re_1", RefundAmount: 500, Status: consts.RefundCreated}
+		require.Equal(t, TypeMissingGateway, CompareRefund(refund, nil)[0].DiscrepancyType)
+		remote := &gateway_bean.GatewayPaymentRefundResp{GatewayRefundId: "re_1", RefundAmount: 500, Status: consts.RefundSuccess}
+		list := CompareRefund(refund, remote)
+		require.Equal(t, 1, len(list))
+		require.Equal(t, TypeStatusDrift, list[0].DiscrepancyType)
+		require.True(t, IsSafeRefundFix(refund, remote))
+		require.False(t, IsSafeRefundFix(refund, &gateway_bean.GatewayPaymentRefundResp{RefundAmount: 400, Status: consts.RefundSuccess}))
+		require.False(t, IsSafeRefundFix(refund, &gateway_bean.GatewayPaymentRefundResp{Status: consts.RefundSuccess}))
+		require.True(t, IsSafeRefundFix(refund, &gateway_bean.GatewayPaymentRefundResp{RefundAmount: 500, Status: consts.RefundReverse}))
+	})
+	t.Run("Test for DiscrepancyUniqueKey", func(t *testing.T) {
+		list := ComparePayment(payment, nil)
+		require.Equal(t, "2_payment_pi_1_missing_gateway", DiscrepancyUniqueKey(list[0]))
+		missing := MissingLocalRefund(payment, &gateway_bean.GatewayPaymentRefundResp{GatewayRefundId: "re_9", RefundAmount: 100, Status: consts.RefundSuccess})
+		require.Equal(t, "2_refund_re_9_missing_local", DiscrepancyUniqueKey(missing))
+		list = ComparePayment(payment, &gateway_bean.GatewayPaymentRo{TotalAmount: 1000, Status: consts.PaymentSuccess})
+		markFixed(list)
+		require.Equal(t, 1, list[0].AutoFixed)
+		require.Equal(t, ResolveStatusResolved, list[0].ResolveStatus)
+	})
+	t.Run("Test for LookupFailed", func(t *testing.T) {
+		failed := PaymentLookupFailed(payment, gerror.New("timeout"))
+		require.Equal(t, TypeLookupFailed, failed.DiscrepancyType)
+		require.Equal(t, "2_payment_pi_1_lookup_failed", DiscrepancyUniqueKey(failed))
+		require.False(t, isNotFoundAtGateway(nil))
+		require.False(t, isNotFoundAtGateway(gerror.New("Not Support")))
+		require.True(t, isNotFoundAtGateway(gerror.NewCode(gcode.CodeNotFound, "payment not found")))
+		require.True(t, isNotFoundAtGateway(&stripe.Error{Code: stripe.ErrorCodeResourceMissing}))
+		require.False(t, isNotFoundAtGateway(&stripe.Error{Code: stripe.ErrorCodeRateLimit}))
+		require.False(t, SupportReconciliation(&entity.MerchantGateway{GatewayName: "coinbase", GatewayType: consts.GatewayTypeCrypto}))
+		require.True(t, SupportReconciliation(&entity.MerchantGateway{GatewayName: "stripe", GatewayType: consts.GatewayTypeCard}))
+	})
+}
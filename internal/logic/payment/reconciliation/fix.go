@@ -0,0 +1,72 @@
+package reconciliation
+
+import (
+	"context"
+	"unibee/internal/consts"
+	"unibee/internal/logic/gateway/gateway_bean"
+	handler2 "unibee/internal/logic/payment/handler"
+	entity "unibee/internal/model/entity/default"
+)
+
+// fixPayment moves the pending payment to the final status of gateway by the same handlers the webhook uses
+func fixPayment(ctx context.Context, one *entity.Payment, remote *gateway_bean.GatewayPaymentRo) error {
+	switch remote.Status {
+	case consts.PaymentSuccess:
+		return handler2.HandlePaySuccess(ctx, &handler2.HandlePayReq{
+			PaymentId:              one.PaymentId,
+			GatewayPaymentIntentId: remote.GatewayPaymentId,
+			GatewayPaymentId:       remote.GatewayPaymentId,
+			GatewayUserId:          remote.GatewayUserId,
+			TotalAmount:            remote.TotalAmount,
+			PayStatusEnum:          consts.PaymentSuccess,
+			PaidTime:               remote.PaidTime,
+			PaymentAmount:          remote.PaymentAmount,
+			CaptureAmount:          0,
+			Reason:                 remote.Reason,
+			GatewayPaymentMethod:   remote.GatewayPaymentMethod,
+			PaymentCode:            remote.PaymentCode,
+		})
+	case consts.PaymentFailed:
+		return handler2.HandlePayFailure(ctx, &handler2.HandlePayReq{
+			PaymentId:              one.PaymentId,
+			GatewayPaymentIntentId: remote.GatewayPaymentId,
+			GatewayPaymentId:       remote.GatewayPaymentId,
+			PayStatusEnum:          consts.PaymentFailed,
+			Reason:                 remote.Reason,
+			PaymentCode:            remote.PaymentCode,
+		})
+	case consts.PaymentCancelled:
+		return handler2.HandlePayCancel(ctx, &handler2.HandlePayReq{
+			PaymentId:              one.PaymentId,
+			GatewayPaymentIntentId: remote.GatewayPaymentId,
+			GatewayPaymentId:       remote.GatewayPaymentId,
+			PayStatusEnum:          consts.PaymentCancelled,
+			Reason:                 remote.Reason,
+			PaymentCode:            remote.PaymentCode,
+		})
+	}
+	return nil
+}
+
+// fixRefund moves the pending refund to the final status of gateway by the same handlers the refund checker uses
+func fixRefund(ctx context.Context, one *entity.Refund, remote *gateway_bean.GatewayPaymentRefundResp) error {
+	req := &handler2.HandleRefundReq{
+		RefundId:         one.RefundId,
+		GatewayRefundId:  remote.GatewayRefundId,
+		RefundAmount:     remote.RefundAmount,
+		RefundStatusEnum: remote.Status,
+		RefundTime:       remote.RefundTime,
+		Reason:           remote.Reason,
+	}
+	switch int(remote.Status) {
+	case consts.RefundSuccess:
+		return handler2.HandleRefundSuccess(ctx, req)
+	case consts.RefundFailed:
+		return handler2.HandleRefundFailure(ctx, req)
+	case consts.RefundCancelled:
+		return handler2.HandleRefundCancelled(ctx, req)
+	case consts.RefundReverse:
+		return handler2.HandleRefundReversed(ctx, req)
+	}
+	return nil
+}
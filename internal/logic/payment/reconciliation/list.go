@@ -0,0 +1,140 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+type ReconciliationListInternalReq struct {
+	MerchantId uint64 `json:"merchantId"`
+	GatewayId  uint64 `json:"gatewayId"`
+	Page       int    `json:"page"`
+	Count      int    `json:"count"`
+}
+
+func ReconciliationList(ctx context.Context, req *ReconciliationListInternalReq) (list []*entity.MerchantGatewayReconciliation, total int, err error) {
+	utility.Assert(req.MerchantId > 0, "invalid merchantId")
+	if req.Count <= 0 {
+		req.Count = 20
+	}
+	if req.Page < 0 {
+		req.Page = 0
+	}
+	columns := dao.MerchantGatewayReconciliation.Columns()
+	q := dao.MerchantGatewayReconciliation.Ctx(ctx).
+		Where(columns.MerchantId, req.MerchantId).
+		Where(columns.IsDeleted, 0)
+	if req.GatewayId > 0 {
+		q = q.Where(columns.GatewayId, req.GatewayId)
+	}
+	err = q.OrderDesc(columns.Id).
+		Limit(req.Page*req.Count, req.Count).
+		ScanAndCount(&list, &total, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+type DiscrepancyListInternalReq struct {
+	MerchantId       uint64   `json:"merchantId"`
+	ReconciliationId uint64   `json:"reconciliationId"`
+	GatewayId        uint64   `json:"gatewayId"`
+	SourceType       string   `json:"sourceType"`
+	DiscrepancyTypes []string `json:"discrepancyTypes"`
+	ResolveStatus    []int    `json:"resolveStatus"`
+	CreateTimeStart  int64    `json:"createTimeStart"`
+	CreateTimeEnd    int64    `json:"createTimeEnd"`
+	Page             int      `json:"page"`
+	Count            int      `json:"count"`
+	SkipTotal        bool     `json:"skipTotal"`
+}
+
+func DiscrepancyList(ctx context.Context, req *DiscrepancyListInternalReq) (list []*entity.MerchantGatewayDiscrepancy, total int, err error) {
+	utility.Assert(req.MerchantId > 0, "invalid merchantId")
+	if req.Count <= 0 {
+		req.Count = 20
+	}
+	if req.Page < 0 {
+		req.Page = 0
+	}
+	columns := dao.MerchantGatewayDiscrepancy.Columns()
+	q := dao.MerchantGatewayDiscrepancy.Ctx(ctx).
+		Where(columns.MerchantId, req.MerchantId).
+		Where(columns.IsDeleted, 0)
+	if req.ReconciliationId > 0 {
+		q = q.Where(columns.ReconciliationId, req.ReconciliationId)
+	}
+	if req.GatewayId > 0 {
+		q = q.Where(columns.GatewayId, req.GatewayId)
+	}
+	if len(req.SourceType) > 0 {
+		q = q.Where(columns.SourceType, req.SourceType)
+	}
+	if len(req.DiscrepancyTypes) > 0 {
+		q = q.WhereIn(columns.DiscrepancyType, req.DiscrepancyTypes)
+	}
+	if len(req.ResolveStatus) > 0 {
+		q = q.WhereIn(columns.ResolveStatus, req.ResolveStatus)
+	}
+	if req.CreateTimeStart > 0 {
+		q = q.WhereGTE(columns.CreateTime, req.CreateTimeStart)
+	}
+	if req.CreateTimeEnd > 0 {
+		q = q.WhereLTE(columns.CreateTime, req.CreateTimeEnd)
+	}
+	q = q.OrderDesc(columns.Id).
+		Limit(req.Page*req.Count, req.Count)
+	if req.SkipTotal {
+		err = q.Scan(&list)
+	} else {
+		err = q.ScanAndCount(&list, &total, true)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// ResolveDiscrepancy marks the discrepancy reviewed, resolved or ignored, the ones reviewed not reopened by later runs
+func ResolveDiscrepancy(ctx context.Context, merchantId uint64, id uint64, resolveStatus int, note string) (*entity.MerchantGatewayDiscrepancy, error) {
+	utility.Assert(merchantId > 0, "invalid merchantId")
+	utility.Assert(resolveStatus == ResolveStatusResolved || resolveStatus == ResolveStatusIgnored, "invalid resolveStatus, should be 1 or 2")
+	var one *entity.MerchantGatewayDiscrepancy
+	err := dao.MerchantGatewayDiscrepancy.Ctx(ctx).
+		Where(dao.MerchantGatewayDiscrepancy.Columns().MerchantId, merchantId).
+		Where(dao.MerchantGatewayDiscrepancy.Columns().Id, id).
+		Scan(&one)
+	if err != nil {
+		return nil, err
+	}
+	utility.Assert(one != nil, "discrepancy not found")
+	_, err = dao.MerchantGatewayDiscrepancy.Ctx(ctx).Data(g.Map{
+		dao.MerchantGatewayDiscrepancy.Columns().ResolveStatus: resolveStatus,
+		dao.MerchantGatewayDiscrepancy.Columns().ResolveNote:   note,
+		dao.MerchantGatewayDiscrepancy.Columns().GmtModify:     gtime.Now(),
+	}).Where(dao.MerchantGatewayDiscrepancy.Columns().Id, one.Id).Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     merchantId,
+		Target:         fmt.Sprintf("GatewayDiscrepancy(%d)", one.Id),
+		Content:        "Resolve",
+		UserId:         one.UserId,
+		SubscriptionId: "",
+		InvoiceId:      one.InvoiceId,
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	one.ResolveStatus = resolveStatus
+	one.ResolveNote = note
+	return one, nil
+}
@@ -0,0 +1,471 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unibee/internal/consts"
+	log2 "unibee/internal/consumer/webhook/log"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/gateway/api"
+	"unibee/internal/logic/gateway/gateway_bean"
+	"unibee/internal/logic/gateway/util"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/stripe/stripe-go/v78"
+)
+
+const (
+	StatusRunning  = 1
+	StatusFinished = 2
+	StatusFailed   = 3
+
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+
+	// the scheduled window overlaps the previous days, the payments finished late at gateway caught
+	DefaultWindowDays = 3
+	MaxWindowDays     = 31
+
+	pageSize = 50
+)
+
+type reconciler struct {
+	gateway          *entity.MerchantGateway
+	run              *entity.MerchantGatewayReconciliation
+	windowStart      int64
+	windowEnd        int64
+	paymentCount     int64
+	refundCount      int64
+	discrepancyCount int64
+	fixedCount       int64
+}
+
+// noPaymentDetailGateways not support the payment detail lookup, nothing to reconcile
+var noPaymentDetailGateways = map[string]bool{
+	"coinbase": true,
+}
+
+// noRefundDetailGateways not support the refund detail lookup, their refunds not reconciled
+var noRefundDetailGateways = map[string]bool{
+	"coinbase":     true,
+	"changelly":    true,
+	"cryptadium":   true,
+	"blockonomics": true,
+}
+
+// SupportReconciliation reports the gateway has payments at gateway side to reconcile
+func SupportReconciliation(gateway *entity.MerchantGateway) bool {
+	return gateway != nil && gateway.GatewayType != consts.GatewayTypeWireTransfer && gateway.GatewayType != consts.GatewayTypeCredit &&
+		!noPaymentDetailGateways[gateway.GatewayName]
+}
+
+// isNotFoundAtGateway reports the lookup error is a definite not found of gateway object, the other errors like timeout
+// or auth failure tell nothing of the object
+func isNotFoundAtGateway(err error) bool {
+	if err == nil {
+		return false
+	}
+	var stripeErr *stripe.Error
+	if errors.As(err, &stripeErr) {
+		return stripeErr.Code == stripe.ErrorCodeResourceMissing
+	}
+	return gerror.Code(err) == gcode.CodeNotFound
+}
+
+func lockKey(gatewayId uint64) string {
+	return fmt.Sprintf("GatewayReconciliation_%d", gatewayId)
+}
+
+func createRun(ctx context.Context, gateway *entity.MerchantGateway, windowStart int64, windowEnd int64, trigger string) (*entity.MerchantGatewayReconciliation, error) {
+	utility.Assert(gateway != nil, "gateway not found")
+	utility.Assert(SupportReconciliation(gateway), "gateway not support reconciliation")
+	utility.Assert(windowStart > 0 && windowEnd > windowStart, "invalid window")
+	utility.Assert(windowEnd-windowStart <= MaxWindowDays*86400, fmt.Sprintf("window should not exceed %d days", MaxWindowDays))
+	if !utility.TryLock(ctx, lockKey(gateway.Id), 3600) {
+		return nil, gerror.Newf("reconciliation of gateway %d is running", gateway.Id)
+	}
+	one := &entity.MerchantGatewayReconciliation{
+		MerchantId:  gateway.MerchantId,
+		GatewayId:   gateway.Id,
+		GatewayName: gateway.GatewayName,
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		Status:      StatusRunning,
+		Trigger:     trigger,
+		StartTime:   gtime.Now().Timestamp(),
+		CreateTime:  gtime.Now().Timestamp(),
+	}
+	id, err := dao.MerchantGatewayReconciliation.Ctx(ctx).Data(one).OmitNil().InsertAndGetId(one)
+	if err != nil {
+		utility.ReleaseLock(ctx, lockKey(gateway.Id))
+		return nil, err
+	}
+	one.Id = uint64(id)
+	return one, nil
+}
+
+// Reconcile matches the payments of gateway created in window and their refunds to the gateway side,
+// the safe status fixes applied and the discrepancies saved to the report of run
+func Reconcile(ctx context.Context, gateway *entity.MerchantGateway, windowStart int64, windowEnd int64, trigger string) (*entity.MerchantGatewayReconciliation, error) {
+	run, err := createRun(ctx, gateway, windowStart, windowEnd, trigger)
+	if err != nil {
+		return nil, err
+	}
+	execute(ctx, gateway, run)
+	return run, nil
+}
+
+// StartReconciliation creates the run and reconciles in background
+func StartReconciliation(ctx context.Context, gateway *entity.MerchantGateway, windowStart int64, windowEnd int64, trigger string) (*entity.MerchantGatewayReconciliation, error) {
+	run, err := createRun(ctx, gateway, windowStart, windowEnd, trigger)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		backgroundCtx := context.Background()
+		execute(backgroundCtx, gateway, run)
+	}()
+	return run, nil
+}
+
+func execute(ctx context.Context, gateway *entity.MerchantGateway, run *entity.MerchantGatewayReconciliation) {
+	r := &reconciler{
+		gateway:     gateway,
+		run:         run,
+		windowStart: run.WindowStart,
+		windowEnd:   run.WindowEnd,
+	}
+	defer utility.ReleaseLock(ctx, lockKey(gateway.Id))
+	defer func() {
+		if exception := recover(); exception != nil {
+			var err error
+			if v, ok := exception.(error); ok && gerror.HasStack(v) {
+				err = v
+			} else {
+				err = gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception)
+			}
+			log2.PrintPanic(ctx, err)
+			r.finish(ctx, err)
+		}
+	}()
+	r.finish(ctx, r.reconcileWindow(ctx))
+}
+
+func (r *reconciler) finish(ctx context.Context, err error) {
+	status := StatusFinished
+	failureReason := ""
+	if err != nil {
+		status = StatusFailed
+		failureReason = err.Error()
+		g.Log().Errorf(ctx, "Reconcile gateway %d run %d err:%s", r.gateway.Id, r.run.Id, err.Error())
+	}
+	r.run.Status = status
+	r.run.PaymentCount = r.paymentCount
+	r.run.RefundCount = r.refundCount
+	r.run.DiscrepancyCount = r.discrepancyCount
+	r.run.FixedCount = r.fixedCount
+	r.run.FailureReason = failureReason
+	r.run.FinishTime = gtime.Now().Timestamp()
+	_, updateErr := dao.MerchantGatewayReconciliation.Ctx(ctx).Data(g.Map{
+		dao.MerchantGatewayReconciliation.Columns().Status:           r.run.Status,
+		dao.MerchantGatewayReconciliation.Columns().PaymentCount:     r.run.PaymentCount,
+		dao.MerchantGatewayReconciliation.Columns().RefundCount:      r.run.RefundCount,
+		dao.MerchantGatewayReconciliation.Columns().DiscrepancyCount: r.run.DiscrepancyCount,
+		dao.MerchantGatewayReconciliation.Columns().FixedCount:       r.run.FixedCount,
+		dao.MerchantGatewayReconciliation.Columns().FailureReason:    r.run.FailureReason,
+		dao.MerchantGatewayReconciliation.Columns().FinishTime:       r.run.FinishTime,
+		dao.MerchantGatewayReconciliation.Columns().GmtModify:        gtime.Now(),
+	}).Where(dao.MerchantGatewayReconciliation.Columns().Id, r.run.Id).Update()
+	if updateErr != nil {
+		g.Log().Errorf(ctx, "Reconcile update run %d err:%s", r.run.Id, updateErr.Error())
+	}
+}
+
+func (r *reconciler) reconcileWindow(ctx context.Context) error {
+	var lastId int64 = 0
+	var userIds = make(map[uint64]bool)
+	for {
+		var list []*entity.Payment
+		err := dao.Payment.Ctx(ctx).
+			Where(dao.Payment.Columns().MerchantId, r.gateway.MerchantId).
+			Where(dao.Payment.Columns().GatewayId, r.gateway.Id).
+			WhereGTE(dao.Payment.Columns().CreateTime, r.windowStart).
+			WhereLT(dao.Payment.Columns().CreateTime, r.windowEnd).
+			WhereNot(dao.Payment.Columns().GatewayPaymentId, "").
+			WhereGT(dao.Payment.Columns().Id, lastId).
+			OrderAsc(dao.Payment.Columns().Id).
+			Limit(pageSize).
+			Scan(&list)
+		if err != nil {
+			return err
+		}
+		for _, one := range list {
+			lastId = one.Id
+			if one.UserId > 0 {
+				userIds[one.UserId] = true
+			}
+			r.reconcilePayment(ctx, one)
+		}
+		if len(list) < pageSize {
+			break
+		}
+	}
+	// gateway payments listed by customer only, the ones of users without local payment in window not discovered
+	for userId := range userIds {
+		r.reconcileMissingPayments(ctx, userId)
+	}
+	return nil
+}
+
+func (r *reconciler) reconcilePayment(ctx context.Context, one *entity.Payment) []*entity.MerchantGatewayDiscrepancy {
+	r.paymentCount++
+	remote, err := api.GetGatewayServiceProvider(ctx, r.gateway.Id).GatewayPaymentDetail(ctx, r.gateway, one.GatewayPaymentId, one)
+	if err != nil {
+		g.Log().Errorf(ctx, "Reconcile GatewayPaymentDetail paymentId:%s err:%s", one.PaymentId, err.Error())
+		if !isNotFoundAtGateway(err) {
+			// the open discrepancies kept, not cleared by a failed lookup
+			discrepancy := PaymentLookupFailed(one, err)
+			r.save(ctx, discrepancy)
+			return append([]*entity.MerchantGatewayDiscrepancy{discrepancy}, r.reconcileRefunds(ctx, one)...)
+		}
+		remote = nil
+	}
+	list := ComparePayment(one, remote)
+	if IsSafePaymentFix(one, remote) {
+		err = fixPayment(ctx, one, remote)
+		if err != nil {
+			g.Log().Errorf(ctx, "Reconcile fixPayment paymentId:%s err:%s", one.PaymentId, err.Error())
+		} else {
+			markFixed(list)
+		}
+	}
+	for _, discrepancy := range list {
+		if discrepancy.DiscrepancyType == TypeMissingGateway && err != nil {
+			discrepancy.Detail = fmt.Sprintf("%s, %s", discrepancy.Detail, err.Error())
+		}
+		r.save(ctx, discrepancy)
+	}
+	r.resolveCleared(ctx, SourcePayment, one.GatewayPaymentId, list)
+	return append(list, r.reconcileRefunds(ctx, one)...)
+}
+
+func (r *reconciler) reconcileRefunds(ctx context.Context, payment *entity.Payment) []*entity.MerchantGatewayDiscrepancy {
+	var result []*entity.MerchantGatewayDiscrepancy
+	var refunds []*entity.Refund
+	err := dao.Refund.Ctx(ctx).
+		Where(dao.Refund.Columns().PaymentId, payment.PaymentId).
+		WhereNot(dao.Refund.Columns().Type, consts.RefundTypeMarked).
+		Scan(&refunds)
+	if err != nil {
+		g.Log().Errorf(ctx, "Reconcile refund list paymentId:%s err:%s", payment.PaymentId, err.Error())
+		return result
+	}
+	var localRefunds = make(map[string]bool)
+	for _, one := range refunds {
+		if len(one.GatewayRefundId) == 0 {
+			// not reached gateway yet
+			continue
+		}
+		localRefunds[one.GatewayRefundId] = true
+		if noRefundDetailGateways[r.gateway.GatewayName] {
+			continue
+		}
+		r.refundCount++
+		remote, err := api.GetGatewayServiceProvider(ctx, r.gateway.Id).GatewayRefundDetail(ctx, r.gateway, one.GatewayRefundId, one)
+		if err != nil {
+			g.Log().Errorf(ctx, "Reconcile GatewayRefundDetail refundId:%s err:%s", one.RefundId, err.Error())
+			if !isNotFoundAtGateway(err) {
+				discrepancy := RefundLookupFailed(one, err)
+				r.save(ctx, discrepancy)
+				result = append(result, discrepancy)
+				continue
+			}
+			remote = nil
+		}
+		list := CompareRefund(one, remote)
+		if IsSafeRefundFix(one, remote) {
+			if fixErr := fixRefund(ctx, one, remote); fixErr != nil {
+				g.Log().Errorf(ctx, "Reconcile fixRefund refundId:%s err:%s", one.RefundId, fixErr.Error())
+			} else {
+				markFixed(list)
+			}
+		}
+		for _, discrepancy := range list {
+			if discrepancy.DiscrepancyType == TypeMissingGateway && err != nil {
+				discrepancy.Detail = fmt.Sprintf("%s, %s", discrepancy.Detail, err.Error())
+			}
+			r.save(ctx, discrepancy)
+		}
+		r.resolveCleared(ctx, SourceRefund, one.GatewayRefundId, list)
+		result = append(result, list...)
+	}
+	if payment.Status != consts.PaymentSuccess {
+		return result
+	}
+	// the empty list returned by the gateways not support refund list, only the refunds listed checked
+	remoteList, err := api.GetGatewayServiceProvider(ctx, r.gateway.Id).GatewayRefundList(ctx, r.gateway, payment.GatewayPaymentId)
+	if err != nil {
+		return result
+	}
+	for _, remote := range remoteList {
+		if remote == nil || len(remote.GatewayRefundId) == 0 || localRefunds[remote.GatewayRefundId] {
+			continue
+		}
+		if query.GetRefundByGatewayRefundId(ctx, remote.GatewayRefundId) != nil {
+			continue
+		}
+		discrepancy := MissingLocalRefund(payment, remote)
+		r.save(ctx, discrepancy)
+		result = append(result, discrepancy)
+	}
+	return result
+}
+
+func (r *reconciler) reconcileMissingPayments(ctx context.Context, userId uint64) {
+	if util.GetGatewayUser(ctx, userId, r.gateway.Id) == nil {
+		return
+	}
+	remoteList, err := api.GetGatewayServiceProvider(ctx, r.gateway.Id).GatewayPaymentList(ctx, r.gateway, &gateway_bean.GatewayPaymentListReq{UserId: userId})
+	if err != nil {
+		return
+	}
+	for _, remote := range remoteList {
+		if remote == nil || len(remote.GatewayPaymentId) == 0 || remote.CreateTime == nil {
+			continue
+		}
+		if remote.CreateTime.Timestamp() < r.windowStart || remote.CreateTime.Timestamp() >= r.windowEnd {
+			continue
+		}
+		if query.GetPaymentByGatewayPaymentId(ctx, remote.GatewayPaymentId) != nil {
+			continue
+		}
+		r.save(ctx, MissingLocalPayment(r.gateway, userId, remote))
+	}
+}
+
+func markFixed(list []*entity.MerchantGatewayDiscrepancy) {
+	for _, one := range list {
+		if one.DiscrepancyType == TypeStatusDrift {
+			one.AutoFixed = 1
+			one.ResolveStatus = ResolveStatusResolved
+			one.ResolveNote = "local status fixed from gateway"
+		}
+	}
+}
+
+// save inserts the discrepancy, the open one found again updated to the latest run, the ones reviewed kept
+func (r *reconciler) save(ctx context.Context, one *entity.MerchantGatewayDiscrepancy) {
+	one.MerchantId = r.gateway.MerchantId
+	one.GatewayId = r.gateway.Id
+	one.GatewayName = r.gateway.GatewayName
+	if r.run != nil {
+		one.ReconciliationId = r.run.Id
+	}
+	one.UniqueKey = DiscrepancyUniqueKey(one)
+	one.CreateTime = gtime.Now().Timestamp()
+	r.discrepancyCount++
+	if one.AutoFixed == 1 {
+		r.fixedCount++
+	}
+	var exist *entity.MerchantGatewayDiscrepancy
+	err := dao.MerchantGatewayDiscrepancy.Ctx(ctx).
+		Where(dao.MerchantGatewayDiscrepancy.Columns().MerchantId, one.MerchantId).
+		Where(dao.MerchantGatewayDiscrepancy.Columns().UniqueKey, one.UniqueKey).
+		Scan(&exist)
+	if err != nil {
+		g.Log().Errorf(ctx, "Reconcile save discrepancy %s err:%s", one.UniqueKey, err.Error())
+		return
+	}
+	if exist == nil {
+		_, err = dao.MerchantGatewayDiscrepancy.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	} else if exist.ResolveStatus == ResolveStatusOpen {
+		_, err = dao.MerchantGatewayDiscrepancy.Ctx(ctx).Data(g.Map{
+			dao.MerchantGatewayDiscrepancy.Columns().ReconciliationId: one.ReconciliationId,
+			dao.MerchantGatewayDiscrepancy.Columns().LocalAmount:      one.LocalAmount,
+			dao.MerchantGatewayDiscrepancy.Columns().GatewayAmount:    one.GatewayAmount,
+			dao.MerchantGatewayDiscrepancy.Columns().LocalStatus:      one.LocalStatus,
+			dao.MerchantGatewayDiscrepancy.Columns().GatewayStatus:    one.GatewayStatus,
+			dao.MerchantGatewayDiscrepancy.Columns().AutoFixed:        one.AutoFixed,
+			dao.MerchantGatewayDiscrepancy.Columns().ResolveStatus:    one.ResolveStatus,
+			dao.MerchantGatewayDiscrepancy.Columns().ResolveNote:      one.ResolveNote,
+			dao.MerchantGatewayDiscrepancy.Columns().Detail:           one.Detail,
+			dao.MerchantGatewayDiscrepancy.Columns().GmtModify:        gtime.Now(),
+		}).Where(dao.MerchantGatewayDiscrepancy.Columns().Id, exist.Id).Update()
+	}
+	if err != nil {
+		g.Log().Errorf(ctx, "Reconcile save discrepancy %s err:%s", one.UniqueKey, err.Error())
+	}
+}
+
+// resolveCleared resolves the open discrepancies of the object not found again, fixed by webhook or manually after reported
+func (r *reconciler) resolveCleared(ctx context.Context, sourceType string, gatewayObjectId string, found []*entity.MerchantGatewayDiscrepancy) {
+	if len(gatewayObjectId) == 0 {
+		return
+	}
+	var foundTypes = make(map[string]bool)
+	for _, one := range found {
+		foundTypes[one.DiscrepancyType] = true
+	}
+	var keys []string
+	for _, discrepancyType := range []string{TypeMissingGateway, TypeAmountDrift, TypeStatusDrift, TypeLookupFailed} {
+		if !foundTypes[discrepancyType] {
+			keys = append(keys, fmt.Sprintf("%d_%s_%s_%s", r.gateway.Id, sourceType, gatewayObjectId, discrepancyType))
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	_, err := dao.MerchantGatewayDiscrepancy.Ctx(ctx).Data(g.Map{
+		dao.MerchantGatewayDiscrepancy.Columns().ResolveStatus: ResolveStatusResolved,
+		dao.MerchantGatewayDiscrepancy.Columns().ResolveNote:   "cleared by reconciliation",
+		dao.MerchantGatewayDiscrepancy.Columns().GmtModify:     gtime.Now(),
+	}).Where(dao.MerchantGatewayDiscrepancy.Columns().MerchantId, r.gateway.MerchantId).
+		WhereIn(dao.MerchantGatewayDiscrepancy.Columns().UniqueKey, keys).
+		Where(dao.MerchantGatewayDiscrepancy.Columns().ResolveStatus, ResolveStatusOpen).
+		Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "Reconcile resolveCleared %s %s err:%s", sourceType, gatewayObjectId, err.Error())
+	}
+}
+
+// ReconcilePayment reconciles one payment and its refunds out of any run, used by the admin sync
+func ReconcilePayment(ctx context.Context, one *entity.Payment) ([]*entity.MerchantGatewayDiscrepancy, error) {
+	utility.Assert(one != nil, "payment not found")
+	utility.Assert(len(one.GatewayPaymentId) > 0, "payment not reach gateway")
+	gateway := query.GetGatewayById(ctx, one.GatewayId)
+	utility.Assert(gateway != nil, "gateway not found")
+	utility.Assert(SupportReconciliation(gateway), "gateway not support reconciliation")
+	r := &reconciler{
+		gateway:     gateway,
+		windowStart: one.CreateTime,
+		windowEnd:   one.CreateTime + 1,
+	}
+	return r.reconcilePayment(ctx, one), nil
+}
+
+// ReconcileAllMerchantGateways reconciles the gateways of all merchants for the latest days
+func ReconcileAllMerchantGateways(ctx context.Context) {
+	windowEnd := gtime.Now().Timestamp()
+	windowStart := windowEnd - DefaultWindowDays*86400
+	merchants, _ := query.GetMerchantList(ctx)
+	for _, merchant := range merchants {
+		for _, gateway := range query.GetMerchantGatewayList(ctx, merchant.Id, nil) {
+			if !SupportReconciliation(gateway) {
+				continue
+			}
+			run, err := Reconcile(ctx, gateway, windowStart, windowEnd, TriggerSchedule)
+			if err != nil {
+				g.Log().Errorf(ctx, "ReconcileAllMerchantGateways merchantId:%d gatewayId:%d error:%s", merchant.Id, gateway.Id, err.Error())
+				continue
+			}
+			g.Log().Infof(ctx, "ReconcileAllMerchantGateways merchantId:%d gatewayId:%d discrepancies:%d fixed:%d", merchant.Id, gateway.Id, run.DiscrepancyCount, run.FixedCount)
+		}
+	}
+}
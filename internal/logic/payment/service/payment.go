@@ -23,6 +23,8 @@ import (
 	email2 "unibee/internal/logic/email"
 	"unibee/internal/logic/gateway/api"
 	"unibee/internal/logic/gateway/gateway_bean"
+	"unibee/internal/logic/gateway/routing"
+	"unibee/internal/logic/gateway/util"
 	"unibee/internal/logic/invoice/handler"
 	"unibee/internal/logic/multi_currencies/currency_exchange"
 	"unibee/internal/logic/payment/callback"
@@ -74,56 +76,14 @@ func GatewayPaymentCreate(ctx context.Context, createPayContext *gateway_bean.Ga
 		return nil, gerror.Newf(`too fast duplicate call %s`, createPayContext.Pay.ExternalPaymentId)
 	}
 
-	// currency exchange
-	if len(createPayContext.Gateway.Custom) > 0 && createPayContext.Gateway.GatewayType != consts.GatewayTypeCrypto {
-		var currencyExchanges []*detail.GatewayCurrencyExchange
-		_ = utility.UnmarshalFromJsonString(createPayContext.Gateway.Custom, &currencyExchanges)
-		if currencyExchanges != nil && len(currencyExchanges) > 0 {
-			for _, exchange := range currencyExchanges {
-				if strings.ToUpper(exchange.FromCurrency) == createPayContext.Pay.Currency && exchange.ExchangeRate >= 0 {
-					if exchange.ExchangeRate > 0 {
-						//createPayContext.ExchangeAmount = int64(float64(createPayContext.Pay.TotalAmount) * exchange.ExchangeRate)
-						createPayContext.ExchangeAmount = utility.ExchangeCurrencyConvert(createPayContext.Pay.TotalAmount, exchange.FromCurrency, exchange.ToCurrency, exchange.ExchangeRate)
-						createPayContext.ExchangeCurrency = strings.ToUpper(exchange.ToCurrency)
-						createPayContext.Pay.CryptoAmount = createPayContext.ExchangeAmount
-						createPayContext.Pay.CryptoCurrency = createPayContext.ExchangeCurrency
-						createPayContext.GatewayCurrencyExchange = &gateway_bean.GatewayCurrencyExchange{
-							FromCurrency: strings.ToUpper(exchange.FromCurrency),
-							ToCurrency:   strings.ToUpper(exchange.ToCurrency),
-							ExchangeRate: exchange.ExchangeRate,
-						}
-						createPayContext.Metadata[gateway_bean.GatewayCurrencyExchangeKey] = utility.MarshalToJsonString(createPayContext.GatewayCurrencyExchange)
-					} else if exchange.ExchangeRate == 0 {
-						//exchangeApiKeyConfig := merchant_config.GetMerchantConfig(ctx, createPayContext.Gateway.MerchantId, multi_currencies.FiatExchangeApiKey)
-						//var rate *float64
-						//if config.GetConfigInstance().Mode != "cloud" {
-						//	utility.Assert(exchangeApiKeyConfig != nil && len(exchangeApiKeyConfig.ConfigValue) > 0, "ExchangeApi Need Setup")
-						//}
-						//if exchangeApiKeyConfig != nil && len(exchangeApiKeyConfig.ConfigValue) > 0 {
-						//	rate, err = multi_currencies.GetExchangeConversionRates(ctx, exchangeApiKeyConfig.ConfigValue, createPayContext.Pay.Currency, strings.ToUpper(exchange.ToCurrency))
-						//} else {
-						//	rate, err = multi_currencies.GetExchangeConversionRateFromClusterCloud(ctx, createPayContext.Pay.Currency, strings.ToUpper(exchange.ToCurrency))
-						//}
-						//utility.AssertError(err, "transfer currency exchange error")
-						//utility.Assert(rate != nil, "transfer currency error, exchange rate is nil")
-						//exchange.ExchangeRate = *rate
-						exchange.ExchangeRate = currency_exchange.GetMerchantExchangeCurrencyRate(ctx, createPayContext.Pay.MerchantId, createPayContext.Pay.Currency, exchange.ToCurrency)
-						//createPayContext.ExchangeAmount = int64(float64(createPayContext.Pay.TotalAmount) * *rate)
-						createPayContext.ExchangeAmount = utility.ExchangeCurrencyConvert(createPayContext.Pay.TotalAmount, exchange.FromCurrency, exchange.ToCurrency, exchange.ExchangeRate)
-						createPayContext.ExchangeCurrency = strings.ToUpper(exchange.ToCurrency)
-						createPayContext.Pay.CryptoAmount = createPayContext.ExchangeAmount
-						createPayContext.Pay.CryptoCurrency = createPayContext.ExchangeCurrency
-						createPayContext.GatewayCurrencyExchange = &gateway_bean.GatewayCurrencyExchange{
-							FromCurrency: strings.ToUpper(exchange.FromCurrency),
-							ToCurrency:   strings.ToUpper(exchange.ToCurrency),
-							ExchangeRate: exchange.ExchangeRate,
-						}
-						createPayContext.Metadata[gateway_bean.GatewayCurrencyExchangeKey] = utility.MarshalToJsonString(createPayContext.GatewayCurrencyExchange)
-					}
-					break
-				}
-			}
-		}
+	// gateway routing
+	originalGatewayId := createPayContext.Pay.GatewayId
+	candidateGateways := routing.GetPaymentCandidateGateways(ctx, createPayContext)
+	if candidateGateways[0].Id != createPayContext.Gateway.Id {
+		g.Log().Infof(ctx, "GatewayPaymentCreate externalPaymentId:%s routed from gateway:%d to gateway:%d", createPayContext.Pay.ExternalPaymentId, createPayContext.Gateway.Id, candidateGateways[0].Id)
+		switchPaymentGateway(ctx, createPayContext, candidateGateways[0])
+	} else {
+		setupPaymentCurrencyExchange(ctx, createPayContext)
 	}
 
 	if createPayContext.Gateway.GatewayType == consts.GatewayTypeCrypto {
@@ -245,7 +205,36 @@ func GatewayPaymentCreate(ctx context.Context, createPayContext *gateway_bean.Ga
 		return nil, err
 	}
 
-	gatewayInternalPayResult, err = api.GetGatewayServiceProvider(ctx, createPayContext.Pay.GatewayId).GatewayNewPayment(ctx, createPayContext.Gateway, createPayContext)
+	if createPayContext.Pay.GatewayId != originalGatewayId {
+		err = updateInvoicePaymentGateway(ctx, createPayContext.Pay)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var attempt = 1
+	for {
+		gatewayInternalPayResult, err = api.GetGatewayServiceProvider(ctx, createPayContext.Pay.GatewayId).GatewayNewPayment(ctx, createPayContext.Gateway, createPayContext)
+		// only the gateway unavailable before accepting the payment fails over, the declined or timeout one may be charged
+		if err == nil || len(candidateGateways) <= 1 || gerror.Code(err) != util.GatewayUnavailable {
+			break
+		}
+		if attempt >= len(candidateGateways) {
+			// all eligible gateways failed
+			timelineErr := handler2.CreatePaymentTimelineForGatewayAttempt(ctx, createPayContext.Pay, attempt, err.Error())
+			if timelineErr != nil {
+				g.Log().Errorf(ctx, `CreatePaymentTimelineForGatewayAttempt error %s`, timelineErr.Error())
+			}
+			break
+		}
+		// gateway unavailable, failover to the next eligible gateway
+		failoverErr := failoverPaymentGateway(ctx, createPayContext, candidateGateways[attempt], attempt, err)
+		if failoverErr != nil {
+			g.Log().Errorf(ctx, `GatewayPaymentCreate paymentId:%s failover error:%s`, createPayContext.Pay.PaymentId, failoverErr.Error())
+			break
+		}
+		attempt++
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -349,6 +338,104 @@ func GatewayPaymentCreate(ctx context.Context, createPayContext *gateway_bean.Ga
 	return gatewayInternalPayResult, nil
 }
 
+// setupPaymentCurrencyExchange converts the payment amount into the currency the gateway configured in its currency exchange
+func setupPaymentCurrencyExchange(ctx context.Context, createPayContext *gateway_bean.GatewayNewPaymentReq) {
+	if len(createPayContext.Gateway.Custom) > 0 && createPayContext.Gateway.GatewayType != consts.GatewayTypeCrypto {
+		var currencyExchanges []*detail.GatewayCurrencyExchange
+		_ = utility.UnmarshalFromJsonString(createPayContext.Gateway.Custom, &currencyExchanges)
+		if currencyExchanges != nil && len(currencyExchanges) > 0 {
+			for _, exchange := range currencyExchanges {
+				if strings.ToUpper(exchange.FromCurrency) == createPayContext.Pay.Currency && exchange.ExchangeRate >= 0 {
+					if exchange.ExchangeRate > 0 {
+						//createPayContext.ExchangeAmount = int64(float64(createPayContext.Pay.TotalAmount) * exchange.ExchangeRate)
+						createPayContext.ExchangeAmount = utility.ExchangeCurrencyConvert(createPayContext.Pay.TotalAmount, exchange.FromCurrency, exchange.ToCurrency, exchange.ExchangeRate)
+						createPayContext.ExchangeCurrency = strings.ToUpper(exchange.ToCurrency)
+						createPayContext.Pay.CryptoAmount = createPayContext.ExchangeAmount
+						createPayContext.Pay.CryptoCurrency = createPayContext.ExchangeCurrency
+						createPayContext.GatewayCurrencyExchange = &gateway_bean.GatewayCurrencyExchange{
+							FromCurrency: strings.ToUpper(exchange.FromCurrency),
+							ToCurrency:   strings.ToUpper(exchange.ToCurrency),
+							ExchangeRate: exchange.ExchangeRate,
+						}
+						createPayContext.Metadata[gateway_bean.GatewayCurrencyExchangeKey] = utility.MarshalToJsonString(createPayContext.GatewayCurrencyExchange)
+					} else if exchange.ExchangeRate == 0 {
+						//exchangeApiKeyConfig := merchant_config.GetMerchantConfig(ctx, createPayContext.Gateway.MerchantId, multi_currencies.FiatExchangeApiKey)
+						//var rate *float64
+						//if config.GetConfigInstance().Mode != "cloud" {
+						//	utility.Assert(exchangeApiKeyConfig != nil && len(exchangeApiKeyConfig.ConfigValue) > 0, "ExchangeApi Need Setup")
+						//}
+						//if exchangeApiKeyConfig != nil && len(exchangeApiKeyConfig.ConfigValue) > 0 {
+						//	rate, err = multi_currencies.GetExchangeConversionRates(ctx, exchangeApiKeyConfig.ConfigValue, createPayContext.Pay.Currency, strings.ToUpper(exchange.ToCurrency))
+						//} else {
+						//	rate, err = multi_currencies.GetExchangeConversionRateFromClusterCloud(ctx, createPayContext.Pay.Currency, strings.ToUpper(exchange.ToCurrency))
+						//}
+						//utility.AssertError(err, "transfer currency exchange error")
+						//utility.Assert(rate != nil, "transfer currency error, exchange rate is nil")
+						//exchange.ExchangeRate = *rate
+						exchange.ExchangeRate = currency_exchange.GetMerchantExchangeCurrencyRate(ctx, createPayContext.Pay.MerchantId, createPayContext.Pay.Currency, exchange.ToCurrency)
+						//createPayContext.ExchangeAmount = int64(float64(createPayContext.Pay.TotalAmount) * *rate)
+						createPayContext.ExchangeAmount = utility.ExchangeCurrencyConvert(createPayContext.Pay.TotalAmount, exchange.FromCurrency, exchange.ToCurrency, exchange.ExchangeRate)
+						createPayContext.ExchangeCurrency = strings.ToUpper(exchange.ToCurrency)
+						createPayContext.Pay.CryptoAmount = createPayContext.ExchangeAmount
+						createPayContext.Pay.CryptoCurrency = createPayContext.ExchangeCurrency
+						createPayContext.GatewayCurrencyExchange = &gateway_bean.GatewayCurrencyExchange{
+							FromCurrency: strings.ToUpper(exchange.FromCurrency),
+							ToCurrency:   strings.ToUpper(exchange.ToCurrency),
+							ExchangeRate: exchange.ExchangeRate,
+						}
+						createPayContext.Metadata[gateway_bean.GatewayCurrencyExchangeKey] = utility.MarshalToJsonString(createPayContext.GatewayCurrencyExchange)
+					}
+					break
+				}
+			}
+		}
+	}
+}
+
+// switchPaymentGateway moves the new payment to another gateway, the currency exchange is recalculated with the gateway
+func switchPaymentGateway(ctx context.Context, createPayContext *gateway_bean.GatewayNewPaymentReq, gateway *entity.MerchantGateway) {
+	createPayContext.Gateway = gateway
+	createPayContext.Pay.GatewayId = gateway.Id
+	createPayContext.GatewayPaymentType = ""
+	createPayContext.ExchangeAmount = 0
+	createPayContext.ExchangeCurrency = ""
+	createPayContext.GatewayCurrencyExchange = nil
+	createPayContext.Pay.CryptoAmount = 0
+	createPayContext.Pay.CryptoCurrency = ""
+	delete(createPayContext.Metadata, gateway_bean.GatewayCurrencyExchangeKey)
+	setupPaymentCurrencyExchange(ctx, createPayContext)
+}
+
+// failoverPaymentGateway records the failed gateway attempt in payment timeline, then moves the created payment and its invoice to the next gateway
+func failoverPaymentGateway(ctx context.Context, createPayContext *gateway_bean.GatewayNewPaymentReq, next *entity.MerchantGateway, attempt int, gatewayErr error) error {
+	g.Log().Infof(ctx, "GatewayPaymentCreate paymentId:%s gateway:%d attempt:%d failed, failover to gateway:%d error:%s", createPayContext.Pay.PaymentId, createPayContext.Pay.GatewayId, attempt, next.Id, gatewayErr.Error())
+	err := handler2.CreatePaymentTimelineForGatewayAttempt(ctx, createPayContext.Pay, attempt, gatewayErr.Error())
+	if err != nil {
+		g.Log().Errorf(ctx, `CreatePaymentTimelineForGatewayAttempt error %s`, err.Error())
+	}
+	switchPaymentGateway(ctx, createPayContext, next)
+	createPayContext.Pay.MetaData = utility.MarshalToJsonString(createPayContext.Metadata)
+	_, err = dao.Payment.Ctx(ctx).Data(g.Map{
+		dao.Payment.Columns().GatewayId:      createPayContext.Pay.GatewayId,
+		dao.Payment.Columns().CryptoAmount:   createPayContext.Pay.CryptoAmount,
+		dao.Payment.Columns().CryptoCurrency: createPayContext.Pay.CryptoCurrency,
+		dao.Payment.Columns().MetaData:       createPayContext.Pay.MetaData,
+		dao.Payment.Columns().GmtModify:      gtime.Now()}).
+		Where(dao.Payment.Columns().Id, createPayContext.Pay.Id).Update()
+	if err != nil {
+		return err
+	}
+	return updateInvoicePaymentGateway(ctx, createPayContext.Pay)
+}
+
+func updateInvoicePaymentGateway(ctx context.Context, payment *entity.Payment) error {
+	_, err := dao.Invoice.Ctx(ctx).Data(g.Map{
+		dao.Invoice.Columns().GatewayId: payment.GatewayId,
+		dao.Invoice.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.Invoice.Columns().InvoiceId, payment.InvoiceId).Update()
+	return err
+}
+
 func ClearInvoicePayment(ctx context.Context, invoice *entity.Invoice) (*entity.Payment, error) {
 	if len(invoice.PaymentId) > 0 {
 		lastPayment := query.GetPaymentByPaymentId(ctx, invoice.PaymentId)
@@ -379,6 +466,7 @@ type CreateSubInvoicePaymentDefaultAutomaticReq struct {
 	CancelUrl     string
 	Source        string
 	TimeNow       int64
+	GatewayChosen bool // gateway of invoice chosen by the caller explicitly, never routed
 }
 
 func CreateSubInvoicePaymentDefaultAutomatic(ctx context.Context, req *CreateSubInvoicePaymentDefaultAutomaticReq) (gatewayInternalPayResult *gateway_bean.GatewayNewPaymentResp, err error) {
@@ -444,6 +532,7 @@ func CreateSubInvoicePaymentDefaultAutomatic(ctx context.Context, req *CreateSub
 		Metadata:             map[string]interface{}{"BillingReason": req.Invoice.InvoiceName, "Source": req.Source, "manualPayment": req.ManualPayment, "CancelUrl": req.CancelUrl},
 		GatewayPaymentMethod: req.Invoice.GatewayPaymentMethod,
 		GatewayPaymentType:   req.Invoice.GatewayInvoiceId,
+		GatewayChosen:        req.GatewayChosen,
 	})
 
 	if err == nil && res.Payment != nil {
@@ -117,17 +117,20 @@ func MetricPlanChargeValidation(metricPlanCharges []*bean.PlanMetricMeteredCharg
 		if metricPlanCharge.MetricId <= 0 {
 			return gerror.New("metric id should not less than 0")
 		}
-		if metricPlanCharge.ChargeType != 0 && metricPlanCharge.ChargeType != 1 {
-			return gerror.New("charge type should be one of 0-standard pricing, 1-graduated pricing")
+		if metricPlanCharge.ChargeType < bean.MetricChargeTypeStandard || metricPlanCharge.ChargeType > bean.MetricChargeTypePackage {
+			return gerror.New("charge type should be one of 0-standard pricing, 1-graduated pricing, 2-volume pricing, 3-package pricing")
 		}
-		if metricPlanCharge.ChargeType == 0 {
+		if metricPlanCharge.ChargeType == bean.MetricChargeTypeStandard {
 			if metricPlanCharge.StandardAmount < 0 {
 				return gerror.New("standard amount should not be negative")
 			}
 			if metricPlanCharge.StandardStartValue < 0 {
 				return gerror.New("standard start value should not be negative")
 			}
-		} else if metricPlanCharge.ChargeType == 1 {
+		} else if metricPlanCharge.ChargeType == bean.MetricChargeTypeGraduated || metricPlanCharge.ChargeType == bean.MetricChargeTypeVolume {
+			if metricPlanCharge.ChargeType == bean.MetricChargeTypeVolume && len(metricPlanCharge.GraduatedAmounts) == 0 {
+				return gerror.New("volume pricing should have at least one tier")
+			}
 			var lastEnd int64 = 0
 			for _, step := range metricPlanCharge.GraduatedAmounts {
 				if step.EndValue > 0 && step.EndValue <= lastEnd {
@@ -144,6 +147,16 @@ func MetricPlanChargeValidation(metricPlanCharges []*bean.PlanMetricMeteredCharg
 			if lastEnd > 0 {
 				return gerror.New("The last EndValue should the infinity value")
 			}
+		} else if metricPlanCharge.ChargeType == bean.MetricChargeTypePackage {
+			if metricPlanCharge.PackageSize <= 0 {
+				return gerror.New("package size should be greater than 0")
+			}
+			if metricPlanCharge.StandardAmount < 0 {
+				return gerror.New("package amount should not be negative")
+			}
+			if metricPlanCharge.StandardStartValue < 0 {
+				return gerror.New("free units should not be negative")
+			}
 		}
 	}
 	return nil
@@ -400,7 +413,7 @@ func PlanEdit(ctx context.Context, req *EditInternalReq) (one *entity.Plan, err
 	}
 
 	if one.Status == consts.PlanStatusActive {
-		utility.Assert(req.Amount == nil, "Amount is not editable as plan is active")
+		utility.Assert(req.Amount == nil, "Amount is not editable as plan is active, create a new price version instead")
 		utility.Assert(req.Currency == nil, "Currency is not editable as plan is active")
 		utility.Assert(req.IntervalUnit == nil, "IntervalUint is not editable as plan is active")
 		utility.Assert(req.IntervalCount == nil, "IntervalCount is not editable as plan is active")
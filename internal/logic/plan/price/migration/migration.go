@@ -0,0 +1,551 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	"unibee/internal/consumer/webhook/event"
+	"unibee/internal/consumer/webhook/log"
+	subscription3 "unibee/internal/consumer/webhook/subscription"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/email"
+	handler2 "unibee/internal/logic/invoice/handler"
+	"unibee/internal/logic/invoice/invoice_compute"
+	service3 "unibee/internal/logic/invoice/service"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/payment/service"
+	"unibee/internal/logic/plan/price"
+	"unibee/internal/logic/user/sub_update"
+	"unibee/internal/logic/user/vat"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const (
+	// MinNoticeSeconds is the lead time users notified of price change before the migration effects
+	MinNoticeSeconds = 7 * 86400
+	// processingLeaseSeconds is the time the running migration renews, the migration not renewed longer is taken over by next round
+	processingLeaseSeconds = 30 * 60
+	processingRenewEvery   = 20
+)
+
+type PlanPriceMigrationInternalReq struct {
+	MerchantId    uint64 `json:"merchantId"`
+	PlanId        uint64 `json:"planId"`
+	FromVersion   int    `json:"fromVersion"`
+	ToVersion     int    `json:"toVersion"`
+	Mode          int    `json:"mode"`
+	EffectTime    int64  `json:"effectTime"`
+	AdminMemberId uint64 `json:"adminMemberId"`
+}
+
+func checkPlanPriceMigrationReq(ctx context.Context, req *PlanPriceMigrationInternalReq) *entity.Plan {
+	utility.Assert(req != nil, "req not found")
+	utility.Assert(req.PlanId > 0, "invalid planId")
+	plan := query.GetPlanById(ctx, req.PlanId)
+	utility.Assert(plan != nil, fmt.Sprintf("plan not found, id:%d", req.PlanId))
+	utility.Assert(plan.MerchantId == req.MerchantId, "Merchant not match")
+	utility.Assert(plan.Type == consts.PlanTypeMain, "Price version only available for main plan")
+	utility.Assert(req.FromVersion != req.ToVersion, "FromVersion and ToVersion should be different")
+	utility.Assert(priceVersionExist(ctx, plan, req.FromVersion), fmt.Sprintf("price version not found:%d", req.FromVersion))
+	utility.Assert(priceVersionExist(ctx, plan, req.ToVersion), fmt.Sprintf("price version not found:%d", req.ToVersion))
+	if req.Mode == 0 {
+		req.Mode = consts.PlanPriceMigrationNextRenewal
+	}
+	utility.Assert(req.Mode == consts.PlanPriceMigrationNextRenewal || req.Mode == consts.PlanPriceMigrationImmediate, "Mode should be 1 or 2")
+	return plan
+}
+
+func priceVersionExist(ctx context.Context, plan *entity.Plan, version int) bool {
+	if version <= 0 {
+		return false
+	}
+	return version == price.CurrentPriceVersion(plan) || query.GetPlanPriceVersion(ctx, plan.Id, version) != nil
+}
+
+// getSubscriptionListOnPriceVersion returns the subscriptions billed on the price version of plan,
+// the subscriptions not grandfathered are on the current version
+func getSubscriptionListOnPriceVersion(ctx context.Context, plan *entity.Plan, version int) []*entity.Subscription {
+	var list = make([]*entity.Subscription, 0)
+	q := dao.Subscription.Ctx(ctx).
+		Where(dao.Subscription.Columns().PlanId, plan.Id).
+		WhereIn(dao.Subscription.Columns().Status, []int{consts.SubStatusActive, consts.SubStatusIncomplete, consts.SubStatusSuspended})
+	if version == price.CurrentPriceVersion(plan) {
+		q = q.WhereIn(dao.Subscription.Columns().PlanPriceVersion, []int{0, version})
+	} else {
+		q = q.Where(dao.Subscription.Columns().PlanPriceVersion, version)
+	}
+	err := q.Scan(&list)
+	if err != nil {
+		g.Log().Errorf(ctx, "getSubscriptionListOnPriceVersion planId:%d version:%d error:%s", plan.Id, version, err.Error())
+	}
+	return list
+}
+
+// computeMigrationProrationInvoice computes the proration of price change for the rest of current period
+func computeMigrationProrationInvoice(ctx context.Context, plan *entity.Plan, sub *entity.Subscription, fromVersion int, toVersion int, prorationDate int64) (*bean.Invoice, string) {
+	if sub.Status != consts.SubStatusActive {
+		return nil, "Subscription not active, new price effects at next renewal"
+	}
+	if sub.TrialEnd >= prorationDate {
+		return nil, "Subscription in trial, new price effects at next renewal"
+	}
+	if prorationDate < sub.CurrentPeriodStart || prorationDate > sub.CurrentPeriodEnd {
+		return nil, "Effect time out of current period, new price effects at next renewal"
+	}
+	var taxPercentage = sub.TaxPercentage
+	percentage, countryCode, vatNumber, err := vat.GetUserPlanTaxPercentage(ctx, sub.UserId, plan.Id)
+	if err == nil {
+		taxPercentage = percentage
+	}
+	oldCode := ""
+	latestPaidInvoice := query.GetSubLatestPaidInvoice(ctx, sub.SubscriptionId)
+	if latestPaidInvoice != nil {
+		oldCode = latestPaidInvoice.DiscountCode
+	}
+	invoice := invoice_compute.ComputeSubscriptionProrationToFixedEndInvoiceDetailSimplify(ctx, &invoice_compute.CalculateProrationInvoiceReq{
+		UserId:             sub.UserId,
+		MerchantId:         sub.MerchantId,
+		InvoiceName:        "SubscriptionPriceMigration",
+		ProductName:        plan.PlanName,
+		Currency:           sub.Currency,
+		DiscountCode:       sub.DiscountCode,
+		TimeNow:            prorationDate,
+		CountryCode:        countryCode,
+		VatNumber:          vatNumber,
+		TaxPercentage:      taxPercentage,
+		ProrationDate:      prorationDate,
+		OldProrationPlans:  []*invoice_compute.ProrationPlanParam{{PlanId: plan.Id, Quantity: sub.Quantity, PriceVersion: fromVersion}},
+		NewProrationPlans:  []*invoice_compute.ProrationPlanParam{{PlanId: plan.Id, Quantity: sub.Quantity, PriceVersion: toVersion}},
+		PeriodStart:        sub.CurrentPeriodStart,
+		PeriodEnd:          sub.CurrentPeriodEnd,
+		FinishTime:         prorationDate,
+		BillingCycleAnchor: sub.BillingCycleAnchor,
+		Metadata:           map[string]interface{}{"PlanPriceMigration": true},
+		OldDiscountCode:    oldCode,
+		OldTaxPercentage:   sub.TaxPercentage,
+	})
+	if invoice.TotalAmount <= 0 {
+		return invoice, "New price is lower, no proration charged, new price effects at next renewal"
+	}
+	return invoice, ""
+}
+
+func migrationReportItem(ctx context.Context, plan *entity.Plan, sub *entity.Subscription, req *PlanPriceMigrationInternalReq, effectTime int64) *bean.PlanPriceMigrationReportItem {
+	item := &bean.PlanPriceMigrationReportItem{
+		SubscriptionId:   sub.SubscriptionId,
+		UserId:           sub.UserId,
+		Status:           sub.Status,
+		Currency:         sub.Currency,
+		Quantity:         sub.Quantity,
+		CurrentAmount:    price.PlanAtPriceVersion(ctx, plan, req.FromVersion).CurrencyAmount(ctx, sub.Currency) * sub.Quantity,
+		NewAmount:        price.PlanAtPriceVersion(ctx, plan, req.ToVersion).CurrencyAmount(ctx, sub.Currency) * sub.Quantity,
+		CurrentPeriodEnd: sub.CurrentPeriodEnd,
+	}
+	if user := query.GetUserAccountById(ctx, sub.UserId); user != nil {
+		item.Email = user.Email
+	}
+	if req.Mode == consts.PlanPriceMigrationImmediate {
+		invoice, message := computeMigrationProrationInvoice(ctx, plan, sub, req.FromVersion, req.ToVersion, utility.MaxInt64(effectTime, sub.TestClock))
+		if invoice != nil && invoice.TotalAmount > 0 {
+			item.ProrationAmount = invoice.TotalAmount
+		}
+		item.Message = message
+	}
+	return item
+}
+
+// PlanPriceMigrationPreview is the dry run of migration, reports the subscriptions affected and their price change
+func PlanPriceMigrationPreview(ctx context.Context, req *PlanPriceMigrationInternalReq) []*bean.PlanPriceMigrationReportItem {
+	plan := checkPlanPriceMigrationReq(ctx, req)
+	var effectTime = req.EffectTime
+	if effectTime <= 0 {
+		effectTime = gtime.Now().Timestamp() + MinNoticeSeconds
+	}
+	var report = make([]*bean.PlanPriceMigrationReportItem, 0)
+	for _, sub := range getSubscriptionListOnPriceVersion(ctx, plan, req.FromVersion) {
+		report = append(report, migrationReportItem(ctx, plan, sub, req, effectTime))
+	}
+	return report
+}
+
+// SchedulePlanPriceMigration schedules the migration at effect time and notifies the affected users immediately
+func SchedulePlanPriceMigration(ctx context.Context, req *PlanPriceMigrationInternalReq) (*entity.PlanPriceMigration, []*bean.PlanPriceMigrationReportItem, error) {
+	plan := checkPlanPriceMigrationReq(ctx, req)
+	if req.EffectTime <= 0 {
+		req.EffectTime = gtime.Now().Timestamp() + MinNoticeSeconds
+	}
+	utility.Assert(req.EffectTime >= gtime.Now().Timestamp()+MinNoticeSeconds-60, fmt.Sprintf("EffectTime should be at least %d days later, users need the notice of price change", MinNoticeSeconds/86400))
+	count, err := dao.PlanPriceMigration.Ctx(ctx).
+		Where(dao.PlanPriceMigration.Columns().PlanId, plan.Id).
+		WhereIn(dao.PlanPriceMigration.Columns().Status, []int{consts.PlanPriceMigrationScheduled, consts.PlanPriceMigrationProcessing}).
+		Count()
+	if err != nil {
+		return nil, nil, err
+	}
+	utility.Assert(count == 0, "Another price migration of plan is scheduled, cancel it first")
+	report := PlanPriceMigrationPreview(ctx, req)
+	one := &entity.PlanPriceMigration{
+		MerchantId:    plan.MerchantId,
+		PlanId:        plan.Id,
+		MigrationId:   utility.CreatePlanPriceMigrationId(),
+		FromVersion:   req.FromVersion,
+		ToVersion:     req.ToVersion,
+		Mode:          req.Mode,
+		Status:        consts.PlanPriceMigrationScheduled,
+		EffectTime:    req.EffectTime,
+		AffectedCount: len(report),
+		AdminMemberId: req.AdminMemberId,
+		CreateTime:    gtime.Now().Timestamp(),
+	}
+	result, err := dao.PlanPriceMigration.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	if err != nil {
+		return nil, nil, gerror.Newf(`SchedulePlanPriceMigration record insert failure %s`, err.Error())
+	}
+	id, _ := result.LastInsertId()
+	one.Id = uint64(id)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     plan.MerchantId,
+		Target:         fmt.Sprintf("PlanPriceMigration(%s)", one.MigrationId),
+		Content:        fmt.Sprintf("Schedule(v%d->v%d,%s,%d subscriptions)", one.FromVersion, one.ToVersion, consts.PlanPriceMigrationMode(one.Mode).Description(), one.AffectedCount),
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         plan.Id,
+		DiscountCode:   "",
+	}, err)
+	notifyPriceChangeBackground(one.MigrationId, report)
+	return one, report, nil
+}
+
+// notifyPriceChangeBackground sends the price change notice to affected users and webhook to merchant
+func notifyPriceChangeBackground(migrationId string, report []*bean.PlanPriceMigrationReportItem) {
+	go func() {
+		ctx := context.Background()
+		var err error
+		defer func() {
+			if exception := recover(); exception != nil {
+				if v, ok := exception.(error); ok && gerror.HasStack(v) {
+					err = v
+				} else {
+					err = gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception)
+				}
+				log.PrintPanic(ctx, err)
+				return
+			}
+		}()
+		one := query.GetPlanPriceMigrationByMigrationId(ctx, migrationId)
+		utility.Assert(one != nil, "notifyPriceChangeBackground migration not found")
+		plan := query.GetPlanById(ctx, one.PlanId)
+		utility.Assert(plan != nil, "notifyPriceChangeBackground plan not found")
+		merchant := query.GetMerchantById(ctx, one.MerchantId)
+		utility.Assert(merchant != nil, "notifyPriceChangeBackground merchant not found")
+		for _, item := range report {
+			sub := query.GetSubscriptionBySubscriptionId(ctx, item.SubscriptionId)
+			if sub == nil {
+				continue
+			}
+			subscription3.SendMerchantSubscriptionWebhookBackground(sub, -10000, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PRICE_CHANGE_SCHEDULED, map[string]interface{}{
+				"MigrationId":     one.MigrationId,
+				"FromVersion":     one.FromVersion,
+				"ToVersion":       one.ToVersion,
+				"Mode":            one.Mode,
+				"EffectTime":      one.EffectTime,
+				"CurrentAmount":   item.CurrentAmount,
+				"NewAmount":       item.NewAmount,
+				"ProrationAmount": item.ProrationAmount,
+			})
+			user := query.GetUserAccountById(ctx, sub.UserId)
+			if user == nil {
+				continue
+			}
+			err = email.SendTemplateEmail(ctx, merchant.Id, user.Email, user.TimeZone, user.Language, email.TemplateSubscriptionPriceChange, "", &bean.EmailTemplateVariable{
+				UserName:              user.FirstName + " " + user.LastName,
+				MerchantProductName:   plan.PlanName,
+				MerchantCustomerEmail: merchant.Email,
+				MerchantName:          query.GetMerchantCountryConfigName(ctx, merchant.Id, user.CountryCode),
+				DateNow:               gtime.Now(),
+				PeriodEnd:             gtime.NewFromTimeStamp(sub.CurrentPeriodEnd),
+				Currency:              sub.Currency,
+				PreviousPrice:         utility.ConvertCentToDollarStr(item.CurrentAmount, sub.Currency),
+				NewPrice:              utility.ConvertCentToDollarStr(item.NewAmount, sub.Currency),
+				EffectiveDate:         gtime.NewFromTimeStamp(one.EffectTime),
+			})
+			if err != nil {
+				g.Log().Errorf(ctx, "SendTemplateEmail notifyPriceChangeBackground subscriptionId:%s error:%s", sub.SubscriptionId, err.Error())
+			}
+		}
+	}()
+}
+
+func CancelPlanPriceMigration(ctx context.Context, merchantId uint64, migrationId string) error {
+	one := query.GetPlanPriceMigrationByMigrationId(ctx, migrationId)
+	utility.Assert(one != nil, "migration not found")
+	utility.Assert(one.MerchantId == merchantId, "Merchant not match")
+	utility.Assert(one.Status == consts.PlanPriceMigrationScheduled, "Only scheduled migration can be cancelled")
+	result, err := dao.PlanPriceMigration.Ctx(ctx).Data(g.Map{
+		dao.PlanPriceMigration.Columns().Status:    consts.PlanPriceMigrationCancelled,
+		dao.PlanPriceMigration.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.PlanPriceMigration.Columns().Id, one.Id).
+		Where(dao.PlanPriceMigration.Columns().Status, consts.PlanPriceMigrationScheduled).
+		Update()
+	if err == nil {
+		if affected, _ := result.RowsAffected(); affected != 1 {
+			err = gerror.New("migration already started")
+		}
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("PlanPriceMigration(%s)", one.MigrationId),
+		Content:        "Cancel",
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         one.PlanId,
+		DiscountCode:   "",
+	}, err)
+	return err
+}
+
+type PlanPriceMigrationListInternalReq struct {
+	MerchantId uint64 `json:"merchantId"`
+	PlanId     uint64 `json:"planId"`
+	Status     []int  `json:"status"`
+	Page       int    `json:"page"`
+	Count      int    `json:"count"`
+}
+
+func PlanPriceMigrationList(ctx context.Context, req *PlanPriceMigrationListInternalReq) (list []*bean.PlanPriceMigration, total int) {
+	var mainList []*entity.PlanPriceMigration
+	if req.Count <= 0 {
+		req.Count = 20
+	}
+	if req.Page < 0 {
+		req.Page = 0
+	}
+	q := dao.PlanPriceMigration.Ctx(ctx).
+		Where(dao.PlanPriceMigration.Columns().MerchantId, req.MerchantId).
+		Where(dao.PlanPriceMigration.Columns().IsDeleted, 0)
+	if req.PlanId > 0 {
+		q = q.Where(dao.PlanPriceMigration.Columns().PlanId, req.PlanId)
+	}
+	if len(req.Status) > 0 {
+		q = q.WhereIn(dao.PlanPriceMigration.Columns().Status, req.Status)
+	}
+	err := q.OrderDesc(dao.PlanPriceMigration.Columns().Id).
+		Limit(req.Page*req.Count, req.Count).
+		ScanAndCount(&mainList, &total, true)
+	if err != nil {
+		g.Log().Errorf(ctx, "PlanPriceMigrationList error:%s", err.Error())
+		return make([]*bean.PlanPriceMigration, 0), 0
+	}
+	return bean.SimplifyPlanPriceMigrationList(mainList), total
+}
+
+// ExecuteDuePlanPriceMigrations executes the scheduled migrations reached effect time, and takes over the processing ones
+// whose lease expired as the process running it was stopped, the subscriptions already migrated are skipped in next round
+func ExecuteDuePlanPriceMigrations(ctx context.Context) {
+	var list []*entity.PlanPriceMigration
+	err := dao.PlanPriceMigration.Ctx(ctx).
+		Where(dao.PlanPriceMigration.Columns().Status, consts.PlanPriceMigrationScheduled).
+		WhereLTE(dao.PlanPriceMigration.Columns().EffectTime, gtime.Now().Timestamp()).
+		Where(dao.PlanPriceMigration.Columns().IsDeleted, 0).
+		Limit(0, 10).
+		Scan(&list)
+	if err != nil {
+		g.Log().Errorf(ctx, "ExecuteDuePlanPriceMigrations error:%s", err.Error())
+		return
+	}
+	var stuckList []*entity.PlanPriceMigration
+	err = dao.PlanPriceMigration.Ctx(ctx).
+		Where(dao.PlanPriceMigration.Columns().Status, consts.PlanPriceMigrationProcessing).
+		WhereLT(dao.PlanPriceMigration.Columns().GmtModify, gtime.Now().Add(-processingLeaseSeconds*time.Second)).
+		Where(dao.PlanPriceMigration.Columns().IsDeleted, 0).
+		Limit(0, 10).
+		Scan(&stuckList)
+	if err != nil {
+		g.Log().Errorf(ctx, "ExecuteDuePlanPriceMigrations processing error:%s", err.Error())
+	} else {
+		list = append(list, stuckList...)
+	}
+	for _, one := range list {
+		q := dao.PlanPriceMigration.Ctx(ctx).Data(g.Map{
+			dao.PlanPriceMigration.Columns().Status:    consts.PlanPriceMigrationProcessing,
+			dao.PlanPriceMigration.Columns().GmtModify: gtime.Now(),
+		}).Where(dao.PlanPriceMigration.Columns().Id, one.Id).
+			Where(dao.PlanPriceMigration.Columns().Status, one.Status)
+		if one.Status == consts.PlanPriceMigrationProcessing {
+			g.Log().Infof(ctx, "ExecuteDuePlanPriceMigrations take over processing migrationId:%s", one.MigrationId)
+			q = q.WhereLT(dao.PlanPriceMigration.Columns().GmtModify, gtime.Now().Add(-processingLeaseSeconds*time.Second))
+		}
+		result, err := q.Update()
+		if err != nil {
+			g.Log().Errorf(ctx, "ExecuteDuePlanPriceMigrations migrationId:%s error:%s", one.MigrationId, err.Error())
+			continue
+		}
+		if affected, _ := result.RowsAffected(); affected != 1 {
+			continue
+		}
+		executePlanPriceMigration(ctx, one)
+	}
+}
+
+// renewPlanPriceMigrationLease keeps the migration processing by the current round
+func renewPlanPriceMigrationLease(ctx context.Context, one *entity.PlanPriceMigration) {
+	_, err := dao.PlanPriceMigration.Ctx(ctx).Data(g.Map{
+		dao.PlanPriceMigration.Columns().GmtModify: gtime.Now(),
+	}).Where(dao.PlanPriceMigration.Columns().Id, one.Id).
+		Where(dao.PlanPriceMigration.Columns().Status, consts.PlanPriceMigrationProcessing).
+		Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "renewPlanPriceMigrationLease migrationId:%s error:%s", one.MigrationId, err.Error())
+	}
+}
+
+func executePlanPriceMigration(ctx context.Context, one *entity.PlanPriceMigration) {
+	var migrated = 0
+	var failed = 0
+	var message = ""
+	plan := query.GetPlanById(ctx, one.PlanId)
+	if plan == nil {
+		message = "plan not found"
+	} else {
+		for i, sub := range getSubscriptionListOnPriceVersion(ctx, plan, one.FromVersion) {
+			if i > 0 && i%processingRenewEvery == 0 {
+				renewPlanPriceMigrationLease(ctx, one)
+			}
+			ok, err := migrateSubscription(ctx, plan, sub, one)
+			if err != nil {
+				failed++
+				message = fmt.Sprintf("%s:%s", sub.SubscriptionId, err.Error())
+				g.Log().Errorf(ctx, "executePlanPriceMigration migrationId:%s subscriptionId:%s error:%s", one.MigrationId, sub.SubscriptionId, err.Error())
+			} else if ok {
+				migrated++
+			}
+		}
+	}
+	_, err := dao.PlanPriceMigration.Ctx(ctx).Data(g.Map{
+		dao.PlanPriceMigration.Columns().Status:        consts.PlanPriceMigrationFinished,
+		dao.PlanPriceMigration.Columns().MigratedCount: migrated,
+		dao.PlanPriceMigration.Columns().FailedCount:   failed,
+		dao.PlanPriceMigration.Columns().Message:       message,
+		dao.PlanPriceMigration.Columns().GmtModify:     gtime.Now(),
+	}).Where(dao.PlanPriceMigration.Columns().Id, one.Id).Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("PlanPriceMigration(%s)", one.MigrationId),
+		Content:        fmt.Sprintf("Finish(migrated:%d,failed:%d)", migrated, failed),
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         one.PlanId,
+		DiscountCode:   "",
+	}, err)
+}
+
+// migrateSubscription moves the subscription to the target price version, the current period charged the proration in immediate mode
+func migrateSubscription(ctx context.Context, plan *entity.Plan, sub *entity.Subscription, one *entity.PlanPriceMigration) (migrated bool, err error) {
+	defer func() {
+		if exception := recover(); exception != nil {
+			if v, ok := exception.(error); ok && gerror.HasStack(v) {
+				err = v
+			} else {
+				err = gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception)
+			}
+		}
+	}()
+	var targetVersion = one.ToVersion
+	if targetVersion == price.CurrentPriceVersion(plan) {
+		// follow the current price of plan
+		targetVersion = 0
+	}
+	result, err := dao.Subscription.Ctx(ctx).Data(g.Map{
+		dao.Subscription.Columns().PlanPriceVersion: targetVersion,
+		dao.Subscription.Columns().GmtModify:        gtime.Now(),
+		dao.Subscription.Columns().LastUpdateTime:   gtime.Now().Timestamp(),
+	}).Where(dao.Subscription.Columns().SubscriptionId, sub.SubscriptionId).
+		Where(dao.Subscription.Columns().PlanId, plan.Id).
+		Where(dao.Subscription.Columns().PlanPriceVersion, sub.PlanPriceVersion).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	if affected, _ := result.RowsAffected(); affected != 1 {
+		// subscription changed during migration
+		return false, nil
+	}
+	// renewal invoice created before migration charges the previous price
+	service3.TryCancelSubscriptionLatestAutoChargeInvoice(ctx, sub)
+	if one.Mode == consts.PlanPriceMigrationImmediate {
+		timeNow := utility.MaxInt64(gtime.Now().Timestamp(), sub.TestClock)
+		invoice, _ := computeMigrationProrationInvoice(ctx, plan, sub, one.FromVersion, one.ToVersion, timeNow)
+		if invoice != nil && invoice.TotalAmount > 0 {
+			err = createMigrationProrationInvoice(ctx, sub, invoice, timeNow)
+			if err != nil {
+				g.Log().Errorf(ctx, "migrateSubscription createMigrationProrationInvoice subscriptionId:%s error:%s", sub.SubscriptionId, err.Error())
+			}
+		}
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("Subscription(%s)", sub.SubscriptionId),
+		Content:        fmt.Sprintf("PlanPriceMigration(%s,v%d->v%d)", one.MigrationId, one.FromVersion, one.ToVersion),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         plan.Id,
+		DiscountCode:   "",
+	}, err)
+	subscription3.SendMerchantSubscriptionWebhookBackground(sub, -10000, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_PRICE_CHANGED, map[string]interface{}{
+		"MigrationId": one.MigrationId,
+		"FromVersion": one.FromVersion,
+		"ToVersion":   one.ToVersion,
+		"Mode":        one.Mode,
+	})
+	return true, nil
+}
+
+// createMigrationProrationInvoice charges the price difference for the rest of current period,
+// the invoice is not the latest of subscription, the period is not changed after paid
+func createMigrationProrationInvoice(ctx context.Context, sub *entity.Subscription, simplify *bean.Invoice, timeNow int64) error {
+	gatewayId, paymentType, paymentMethodId := sub_update.VerifyPaymentGatewayMethod(ctx, sub.UserId, nil, "", "", sub.SubscriptionId)
+	if gatewayId <= 0 {
+		gatewayId = sub.GatewayId
+		paymentMethodId = sub.GatewayDefaultPaymentMethod
+	}
+	invoice, err := service3.CreateProcessingInvoiceForSub(ctx, &service3.CreateProcessingInvoiceForSubReq{
+		PlanId:             sub.PlanId,
+		Simplify:           simplify,
+		Sub:                sub,
+		GatewayId:          gatewayId,
+		GatewayPaymentType: paymentType,
+		PaymentMethodId:    paymentMethodId,
+		IsSubLatestInvoice: false,
+		TimeNow:            timeNow,
+	})
+	if err != nil {
+		return err
+	}
+	if invoice.TotalAmount == 0 {
+		_, err = handler2.MarkInvoiceAsPaidForZeroPayment(ctx, invoice.InvoiceId)
+		return err
+	}
+	if invoice.GatewayId <= 0 {
+		// left to user to pay
+		return nil
+	}
+	_, err = service.CreateSubInvoicePaymentDefaultAutomatic(ctx, &service.CreateSubInvoicePaymentDefaultAutomaticReq{
+		Invoice: invoice,
+		Source:  "SubscriptionPriceMigration",
+		TimeNow: timeNow,
+	})
+	return err
+}
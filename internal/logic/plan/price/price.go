@@ -0,0 +1,161 @@
+package price
+
+import (
+	"context"
+	"fmt"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// CurrentPriceVersion returns the price version of plan's current amount, plans created before versioning are on version 1
+func CurrentPriceVersion(plan *entity.Plan) int {
+	if plan == nil {
+		return 0
+	}
+	return utility.MaxInt(plan.PriceVersion, 1)
+}
+
+// SubscriptionPriceVersion returns the price version the subscription billed on,
+// a subscription not grandfathered follows the current price of plan
+func SubscriptionPriceVersion(sub *entity.Subscription, plan *entity.Plan) int {
+	if sub != nil && plan != nil && sub.PlanId == plan.Id && sub.PlanPriceVersion > 0 {
+		return sub.PlanPriceVersion
+	}
+	return CurrentPriceVersion(plan)
+}
+
+// PlanAtPriceVersion returns a copy of plan carrying the amount of the specified version,
+// the plan itself returned if version is current or not found
+func PlanAtPriceVersion(ctx context.Context, plan *entity.Plan, version int) *entity.Plan {
+	if plan == nil || version <= 0 || version == CurrentPriceVersion(plan) {
+		return plan
+	}
+	one := query.GetPlanPriceVersion(ctx, plan.Id, version)
+	if one == nil {
+		g.Log().Errorf(ctx, "PlanAtPriceVersion price version not found, planId:%d version:%d", plan.Id, version)
+		return plan
+	}
+	target := *plan
+	target.Amount = one.Amount
+	return &target
+}
+
+type NewPlanPriceVersionInternalReq struct {
+	MerchantId    uint64 `json:"merchantId"`
+	PlanId        uint64 `json:"planId"`
+	Amount        int64  `json:"amount"`
+	Description   string `json:"description"`
+	AdminMemberId uint64 `json:"adminMemberId"`
+}
+
+// NewPlanPriceVersion changes the price of an active plan, the subscriptions on plan are grandfathered on their previous
+// version until migrated, new subscriptions use the new version
+func NewPlanPriceVersion(ctx context.Context, req *NewPlanPriceVersionInternalReq) (*entity.PlanPriceVersion, error) {
+	utility.Assert(req != nil, "req not found")
+	utility.Assert(req.PlanId > 0, "invalid planId")
+	utility.Assert(req.Amount >= 0, "Amount value should >= 0")
+	plan := query.GetPlanById(ctx, req.PlanId)
+	utility.Assert(plan != nil, fmt.Sprintf("plan not found, id:%d", req.PlanId))
+	utility.Assert(plan.MerchantId == req.MerchantId, "Merchant not match")
+	utility.Assert(plan.Status == consts.PlanStatusActive, "Plan not active, edit amount of plan directly")
+	utility.Assert(plan.Type == consts.PlanTypeMain, "Price version only available for main plan")
+	utility.Assert(plan.Amount != req.Amount, "Amount not changed")
+
+	currentVersion := CurrentPriceVersion(plan)
+	one := &entity.PlanPriceVersion{
+		MerchantId:    plan.MerchantId,
+		PlanId:        plan.Id,
+		Version:       currentVersion + 1,
+		Amount:        req.Amount,
+		Currency:      plan.Currency,
+		Description:   req.Description,
+		AdminMemberId: req.AdminMemberId,
+		CreateTime:    gtime.Now().Timestamp(),
+	}
+	err := dao.PlanPriceVersion.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if query.GetPlanPriceVersion(ctx, plan.Id, currentVersion) == nil {
+			_, err := dao.PlanPriceVersion.Ctx(ctx).Data(&entity.PlanPriceVersion{
+				MerchantId:  plan.MerchantId,
+				PlanId:      plan.Id,
+				Version:     currentVersion,
+				Amount:      plan.Amount,
+				Currency:    plan.Currency,
+				Description: "Initial",
+				CreateTime:  gtime.Now().Timestamp(),
+			}).OmitNil().Insert()
+			if err != nil {
+				return err
+			}
+		}
+		// grandfather subscriptions following the current price
+		_, err := dao.Subscription.Ctx(ctx).Data(g.Map{
+			dao.Subscription.Columns().PlanPriceVersion: currentVersion,
+			dao.Subscription.Columns().GmtModify:        gtime.Now(),
+		}).Where(dao.Subscription.Columns().PlanId, plan.Id).
+			Where(dao.Subscription.Columns().PlanPriceVersion, 0).
+			Update()
+		if err != nil {
+			return err
+		}
+		result, err := dao.PlanPriceVersion.Ctx(ctx).Data(one).OmitNil().Insert(one)
+		if err != nil {
+			return err
+		}
+		id, _ := result.LastInsertId()
+		one.Id = uint64(id)
+		result, err = dao.Plan.Ctx(ctx).Data(g.Map{
+			dao.Plan.Columns().Amount:       req.Amount,
+			dao.Plan.Columns().PriceVersion: one.Version,
+			dao.Plan.Columns().GmtModify:    gtime.Now(),
+		}).Where(dao.Plan.Columns().Id, plan.Id).
+			Where(dao.Plan.Columns().PriceVersion, plan.PriceVersion).
+			Update()
+		if err != nil {
+			return err
+		}
+		if affected, _ := result.RowsAffected(); affected != 1 {
+			return gerror.New("plan price changed by others, try again")
+		}
+		return nil
+	})
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     plan.MerchantId,
+		Target:         fmt.Sprintf("Plan(%v)", plan.Id),
+		Content:        fmt.Sprintf("NewPriceVersion(%d,%d->%d)", one.Version, plan.Amount, req.Amount),
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         plan.Id,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return one, nil
+}
+
+// GetPlanPriceVersionList returns the price versions of plan, newest first
+func GetPlanPriceVersionList(ctx context.Context, plan *entity.Plan) []*entity.PlanPriceVersion {
+	list := query.GetPlanPriceVersionList(ctx, plan.Id)
+	if len(list) == 0 {
+		// plan never changed price
+		list = append(list, &entity.PlanPriceVersion{
+			MerchantId: plan.MerchantId,
+			PlanId:     plan.Id,
+			Version:    CurrentPriceVersion(plan),
+			Amount:     plan.Amount,
+			Currency:   plan.Currency,
+			CreateTime: plan.CreateTime,
+		})
+	}
+	return list
+}
@@ -0,0 +1,28 @@
+package price
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	entity "unibee/internal/model/entity/default"
+)
+
+func TestPriceVersion(t *testing.T) {
+	ctx := context.Background()
+	plan := &entity.Plan{Id: 1, Amount: 200, PriceVersion: 3}
+	t.Run("Test for CurrentPriceVersion", func(t *testing.T) {
+		require.Equal(t, 0, CurrentPriceVersion(nil))
+		require.Equal(t, 1, CurrentPriceVersion(&entity.Plan{Id: 1}))
+		require.Equal(t, 3, CurrentPriceVersion(plan))
+	})
+	t.Run("Test for SubscriptionPriceVersion", func(t *testing.T) {
+		require.Equal(t, 3, SubscriptionPriceVersion(&entity.Subscription{PlanId: 1}, plan))
+		require.Equal(t, 2, SubscriptionPriceVersion(&entity.Subscription{PlanId: 1, PlanPriceVersion: 2}, plan))
+		require.Equal(t, 3, SubscriptionPriceVersion(&entity.Subscription{PlanId: 2, PlanPriceVersion: 2}, plan))
+	})
+	t.Run("Test for PlanAtPriceVersion Current", func(t *testing.T) {
+		require.Equal(t, plan, PlanAtPriceVersion(ctx, plan, 0))
+		require.Equal(t, plan, PlanAtPriceVersion(ctx, plan, 3))
+		require.Nil(t, PlanAtPriceVersion(ctx, nil, 2))
+	})
+}
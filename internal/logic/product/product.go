@@ -8,6 +8,7 @@ import (
 	"github.com/gogf/gf/v2/os/gtime"
 	dao "unibee/internal/dao/default"
 	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/vat_gateway/sales_tax"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
 	"unibee/utility"
@@ -22,6 +23,7 @@ type NewInternalReq struct {
 	HomeUrl     string                  `json:"homeUrl"     description:"home_url"`                                   // home_url
 	Status      int                     `json:"status"      description:"status，1-active，2-inactive, default active"` // status，1-active，2-inactive, default active
 	Metadata    *map[string]interface{} `json:"metadata" dc:"Metadata，Map"`
+	TaxCode     string                  `json:"taxCode" dc:"general|digital|exempt"`
 }
 
 func ProductNew(ctx context.Context, req *NewInternalReq) (one *entity.Product, err error) {
@@ -41,6 +43,7 @@ func ProductNew(ctx context.Context, req *NewInternalReq) (one *entity.Product,
 		req.Status = 1
 	}
 	utility.Assert(req.Status == 1 || req.Status == 2, "status should be 1|2")
+	utility.Assert(sales_tax.IsValidTaxCode(req.TaxCode), "taxCode should be "+sales_tax.TAX_CODES)
 	if len(req.ProductName) == 0 {
 		req.ProductName = req.ProductName
 	}
@@ -55,6 +58,7 @@ func ProductNew(ctx context.Context, req *NewInternalReq) (one *entity.Product,
 		Status:      req.Status,
 		CreateTime:  gtime.Now().Timestamp(),
 		MetaData:    utility.MarshalToJsonString(req.Metadata),
+		TaxCode:     req.TaxCode,
 	}
 	result, err := dao.Product.Ctx(ctx).Data(one).OmitNil().Insert(one)
 	if err != nil {
@@ -85,10 +89,14 @@ type EditInternalReq struct {
 	HomeUrl     *string                 `json:"homeUrl"     description:"home_url"`                                   // home_url
 	Status      *int                    `json:"status"      description:"status，1-active，2-inactive, default active"` // status，1-active，2-inactive, default active
 	Metadata    *map[string]interface{} `json:"metadata" dc:"Metadata，Map"`
+	TaxCode     *string                 `json:"taxCode" dc:"general|digital|exempt"`
 }
 
 func ProductEdit(ctx context.Context, req *EditInternalReq) (one *entity.Product, err error) {
 	utility.Assert(req != nil, "Req not found")
+	if req.TaxCode != nil {
+		utility.Assert(sales_tax.IsValidTaxCode(*req.TaxCode), "taxCode should be "+sales_tax.TAX_CODES)
+	}
 	if req.ProductId == 0 {
 		//utility.Assert(false, "Can't edit default product")
 		one = &entity.Product{
@@ -102,6 +110,7 @@ func ProductEdit(ctx context.Context, req *EditInternalReq) (one *entity.Product
 			IsDeleted:   0,
 			CreateTime:  gtime.Now().Timestamp(),
 			MetaData:    utility.MarshalToJsonString(req.Metadata),
+			TaxCode:     unibee.StringValue(req.TaxCode),
 		}
 		err = UpdateDefaultProd(ctx, one)
 		if err == nil {
@@ -137,6 +146,7 @@ func ProductEdit(ctx context.Context, req *EditInternalReq) (one *entity.Product
 		dao.Product.Columns().ImageUrl:    req.ImageUrl,
 		dao.Product.Columns().HomeUrl:     req.HomeUrl,
 		dao.Product.Columns().Status:      req.Status,
+		dao.Product.Columns().TaxCode:     req.TaxCode,
 		dao.Product.Columns().IsDeleted:   0,
 	}).Where(dao.Product.Columns().Id, req.ProductId).OmitNil().Update()
 	if err != nil {
@@ -0,0 +1,293 @@
+package scenario
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ──────────────────────────────────────────
+// Cron expressions
+// ──────────────────────────────────────────
+
+// CronSchedule is a parsed cron expression bound to a time zone.
+// Supported formats:
+//   - 5 fields: minute hour day-of-month month day-of-week
+//   - 6 fields: second minute hour day-of-month month day-of-week
+//   - descriptors: @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly
+//
+// Each field accepts *, ?, lists (1,2), ranges (1-5), steps (*/15, 10-40/5)
+// and month/weekday names (JAN, MON).
+type CronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	Location                              *time.Location
+}
+
+type cronBounds struct {
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	cronSeconds = cronBounds{0, 59, nil}
+	cronMinutes = cronBounds{0, 59, nil}
+	cronHours   = cronBounds{0, 23, nil}
+	cronDom     = cronBounds{1, 31, nil}
+	cronMonths  = cronBounds{1, 12, map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	cronDow = cronBounds{0, 7, map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// starBit marks a field written as * or ?, needed for the day-of-month/day-of-week rule.
+const starBit = 1 << 63
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses a cron expression; a nil location means UTC.
+func ParseCron(expr string, loc *time.Location) (*CronSchedule, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("empty cron expression")
+	}
+	if strings.HasPrefix(expr, "@") {
+		spec, ok := cronDescriptors[strings.ToLower(expr)]
+		if !ok {
+			return nil, fmt.Errorf("unknown cron descriptor: %s", expr)
+		}
+		expr = spec
+	}
+
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron expression must have 5 or 6 fields, got %d: %s", len(fields), expr)
+	}
+
+	s := &CronSchedule{Location: loc}
+	var err error
+	if s.second, err = parseCronField(fields[0], cronSeconds); err != nil {
+		return nil, fmt.Errorf("second: %w", err)
+	}
+	if s.minute, err = parseCronField(fields[1], cronMinutes); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if s.hour, err = parseCronField(fields[2], cronHours); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if s.dom, err = parseCronField(fields[3], cronDom); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if s.month, err = parseCronField(fields[4], cronMonths); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if s.dow, err = parseCronField(fields[5], cronDow); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+	// 7 is accepted as an alias for Sunday
+	if s.dow&(1<<7) > 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	return s, nil
+}
+
+// parseCronField parses a comma-separated list of ranges into a bit set.
+func parseCronField(field string, b cronBounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		r, err := parseCronRange(part, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= r
+	}
+	return bits, nil
+}
+
+func parseCronRange(expr string, b cronBounds) (uint64, error) {
+	var (
+		start, end, step uint
+		extra            uint64
+		err              error
+	)
+	rangeAndStep := strings.Split(expr, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, fmt.Errorf("invalid step expression: %s", expr)
+	}
+	lowAndHigh := strings.Split(rangeAndStep[0], "-")
+	if lowAndHigh[0] == "*" || lowAndHigh[0] == "?" {
+		if len(lowAndHigh) > 1 {
+			return 0, fmt.Errorf("invalid range: %s", expr)
+		}
+		start, end = b.min, b.max
+		extra = starBit
+	} else {
+		if start, err = parseCronValue(lowAndHigh[0], b); err != nil {
+			return 0, err
+		}
+		switch len(lowAndHigh) {
+		case 1:
+			end = start
+		case 2:
+			if end, err = parseCronValue(lowAndHigh[1], b); err != nil {
+				return 0, err
+			}
+		default:
+			return 0, fmt.Errorf("invalid range: %s", expr)
+		}
+	}
+
+	step = 1
+	if len(rangeAndStep) == 2 {
+		n, err := strconv.Atoi(rangeAndStep[1])
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid step: %s", expr)
+		}
+		step = uint(n)
+		// N/step means N through the end of the range
+		if len(lowAndHigh) == 1 && extra == 0 {
+			end = b.max
+		}
+		if step > 1 {
+			extra = 0
+		}
+	}
+
+	if start < b.min || end > b.max {
+		return 0, fmt.Errorf("value out of range [%d-%d]: %s", b.min, b.max, expr)
+	}
+	if start > end {
+		return 0, fmt.Errorf("range start is after end: %s", expr)
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << i
+	}
+	return bits | extra, nil
+}
+
+func parseCronValue(s string, b cronBounds) (uint, error) {
+	if b.names != nil {
+		if v, ok := b.names[strings.ToLower(s)]; ok {
+			return v, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid value: %s", s)
+	}
+	return uint(n), nil
+}
+
+// Next returns the first activation time strictly after t, or the zero time
+// when the expression can never fire (e.g. 30 February).
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	origLoc := t.Location()
+	t = t.In(s.Location)
+
+	// Start at the next whole second
+	t = t.Add(time.Second - time.Duration(t.Nanosecond())*time.Nanosecond)
+
+	added := false
+	yearLimit := t.Year() + 5
+
+WRAP:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+
+	for 1<<uint(t.Month())&s.month == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, s.Location)
+		}
+		t = t.AddDate(0, 1, 0)
+		if t.Month() == time.January {
+			goto WRAP
+		}
+	}
+
+	for !s.dayMatches(t) {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.Location)
+		}
+		t = t.AddDate(0, 0, 1)
+		// Guard against DST transitions that skip midnight
+		if t.Hour() != 0 {
+			if t.Hour() > 12 {
+				t = t.Add(time.Duration(24-t.Hour()) * time.Hour)
+			} else {
+				t = t.Add(time.Duration(-t.Hour()) * time.Hour)
+			}
+		}
+		if t.Day() == 1 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Hour())&s.hour == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, s.Location)
+		}
+		t = t.Add(time.Hour)
+		if t.Hour() == 0 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Minute())&s.minute == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Minute)
+		}
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Second())&s.second == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Second)
+		}
+		t = t.Add(time.Second)
+		if t.Second() == 0 {
+			goto WRAP
+		}
+	}
+
+	return t.In(origLoc)
+}
+
+// dayMatches applies the standard cron rule: when both day-of-month and
+// day-of-week are restricted, either one matching is enough.
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := 1<<uint(t.Day())&s.dom > 0
+	dowMatch := 1<<uint(t.Weekday())&s.dow > 0
+	if s.dom&starBit > 0 || s.dow&starBit > 0 {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,84 @@
+package scenario
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronNext(t *testing.T) {
+	base := time.Date(2024, 1, 31, 10, 30, 0, 0, time.UTC)
+	t.Run("Test for Cron Fields", func(t *testing.T) {
+		cases := []struct {
+			expr string
+			want time.Time
+		}{
+			{"*/15 * * * *", time.Date(2024, 1, 31, 10, 45, 0, 0, time.UTC)},
+			{"0 9 * * *", time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)},
+			{"0 0 1 * *", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+			{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+			{"0 12 * * MON-FRI", time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)},
+			{"0 0 * * 7", time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC)},
+			{"30 10 * * *", time.Date(2024, 2, 1, 10, 30, 0, 0, time.UTC)},
+			{"@hourly", time.Date(2024, 1, 31, 11, 0, 0, 0, time.UTC)},
+			{"15 30 10 * * *", time.Date(2024, 1, 31, 10, 30, 15, 0, time.UTC)},
+		}
+		for _, c := range cases {
+			sched, err := ParseCron(c.expr, nil)
+			require.Nil(t, err, c.expr)
+			require.Equal(t, c.want, sched.Next(base), c.expr)
+		}
+	})
+	t.Run("Test for Cron Day Of Month Or Week", func(t *testing.T) {
+		// both restricted: 15th of the month or any Friday
+		sched, err := ParseCron("0 0 15 * FRI", nil)
+		require.Nil(t, err)
+		require.Equal(t, time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC), sched.Next(base))
+	})
+	t.Run("Test for Cron Time Zone", func(t *testing.T) {
+		loc, err := time.LoadLocation("Europe/Moscow")
+		require.Nil(t, err)
+		sched, err := ParseCron("0 9 * * *", loc)
+		require.Nil(t, err)
+		require.Equal(t, time.Date(2024, 2, 1, 6, 0, 0, 0, time.UTC), sched.Next(base).UTC())
+	})
+	t.Run("Test for Cron Invalid", func(t *testing.T) {
+		for _, expr := range []string{"", "* * *", "60 * * * *", "* 24 * * *", "5-1 * * * *", "*/0 * * * *", "@every", "0 0 * JANUARY *"} {
+			_, err := ParseCron(expr, nil)
+			require.NotNil(t, err, expr)
+		}
+		sched, err := ParseCron("0 0 30 2 *", nil)
+		require.Nil(t, err)
+		require.True(t, sched.Next(base).IsZero())
+	})
+}
+
+func TestPlanScheduledRuns(t *testing.T) {
+	sched, err := ParseCron("@hourly", nil)
+	require.Nil(t, err)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 1, 1, 5, 1, 0, 0, time.UTC)
+
+	t.Run("Test for Skip Missed Runs", func(t *testing.T) {
+		plan := PlanScheduledRuns(sched, from, now, MissedRunsSkip, 0)
+		require.Equal(t, 5, len(plan.Missed))
+		require.Equal(t, []time.Time{time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC)}, plan.Run)
+		require.Equal(t, time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), plan.Next)
+	})
+	t.Run("Test for Catch Up Missed Runs", func(t *testing.T) {
+		plan := PlanScheduledRuns(sched, from, now, MissedRunsCatchUp, 2)
+		require.Equal(t, 3, len(plan.Missed))
+		require.Equal(t, 3, len(plan.Run))
+		require.Equal(t, time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC), plan.Run[0])
+	})
+	t.Run("Test for Long Downtime", func(t *testing.T) {
+		plan := PlanScheduledRuns(sched, from.AddDate(-1, 0, 0), now, MissedRunsSkip, 0)
+		require.Equal(t, scheduleMaxOccurrences, len(plan.Missed))
+		require.Equal(t, 8765-scheduleMaxOccurrences, plan.Dropped)
+		require.False(t, plan.DropCapped)
+		require.Equal(t, time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC), plan.Missed[len(plan.Missed)-1])
+		require.Equal(t, 1, len(plan.Run))
+		require.Equal(t, time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), plan.Next)
+	})
+}
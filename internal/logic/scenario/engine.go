@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/gogf/gf/v2/frame/g"
+
+	entity "unibee/internal/model/entity/default"
 )
 
 // ──────────────────────────────────────────
@@ -267,12 +269,36 @@ func RunScenario(ctx context.Context, merchantId uint64, scenarioRow interface{
 
 // RunScenarioByIds starts a scenario execution given IDs.
 func RunScenarioByIds(ctx context.Context, merchantId, scenarioId uint64, scenarioJson string, triggerData map[string]interface{}) {
-	dsl, err := ParseDSL(scenarioJson)
+	execCtx, dsl, err := prepareExecution(ctx, merchantId, scenarioId, scenarioJson, triggerData)
 	if err != nil {
-		g.Log().Errorf(ctx, "scenario: failed to parse DSL for scenario %d: %v", scenarioId, err)
+		g.Log().Errorf(ctx, "scenario: failed to start scenario %d: %v", scenarioId, err)
 		return
 	}
 
+	// Execute steps sequentially
+	executeSteps(ctx, execCtx, dsl.Steps, 0)
+}
+
+// StartScenarioExecution creates the execution record synchronously and runs the
+// steps in the background, so the caller gets the execution ID right away.
+func StartScenarioExecution(ctx context.Context, merchantId, scenarioId uint64, scenarioJson string, triggerData map[string]interface{}) (uint64, error) {
+	execCtx, dsl, err := prepareExecution(ctx, merchantId, scenarioId, scenarioJson, triggerData)
+	if err != nil {
+		return 0, err
+	}
+
+	// Steps outlive the request that started them
+	go executeSteps(context.WithoutCancel(ctx), execCtx, dsl.Steps, 0)
+	return execCtx.ExecutionID, nil
+}
+
+// prepareExecution parses the DSL, initializes variables and creates the execution record.
+func prepareExecution(ctx context.Context, merchantId, scenarioId uint64, scenarioJson string, triggerData map[string]interface{}) (*ExecutionContext, *ScenarioDSL, error) {
+	dsl, err := ParseDSL(scenarioJson)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Initialize variables from trigger data
 	vars := make(map[string]string)
 	if dsl.Variables != nil {
@@ -290,20 +316,39 @@ func RunScenarioByIds(ctx context.Context, merchantId, scenarioId uint64, scenar
 	// Create execution record
 	execId, err := CreateExecution(ctx, merchantId, scenarioId, triggerData, vars)
 	if err != nil {
-		g.Log().Errorf(ctx, "scenario: failed to create execution for scenario %d: %v", scenarioId, err)
-		return
+		return nil, nil, fmt.Errorf("create execution: %w", err)
 	}
 
-	execCtx := &ExecutionContext{
+	return &ExecutionContext{
 		ExecutionID: execId,
 		MerchantID:  merchantId,
 		ScenarioID:  scenarioId,
 		Variables:   vars,
 		TriggerData: triggerData,
-	}
+	}, dsl, nil
+}
 
-	// Execute steps sequentially
-	executeSteps(ctx, execCtx, dsl.Steps, 0)
+// WaitExecution polls an execution until it completes, fails, pauses on a delay
+// step or the timeout elapses, and returns its latest state.
+func WaitExecution(ctx context.Context, executionId uint64, timeout time.Duration) (*entity.MerchantScenarioExecution, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		exec, err := GetExecution(ctx, executionId)
+		if err != nil {
+			return nil, err
+		}
+		if exec == nil {
+			return nil, fmt.Errorf("execution %d not found", executionId)
+		}
+		if exec.Status == StatusCompleted || exec.Status == StatusFailed || exec.Status == StatusWaiting || !time.Now().Before(deadline) {
+			return exec, nil
+		}
+		select {
+		case <-ctx.Done():
+			return exec, nil
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
 }
 
 // executeSteps runs steps starting from the given index.
@@ -684,6 +729,10 @@ func ValidateDSL(dsl *ScenarioDSL) []string {
 	if !validTriggers[dsl.Trigger.Type] {
 		errors = append(errors, fmt.Sprintf("unknown trigger type: %s", dsl.Trigger.Type))
 	}
+	if dsl.Trigger.Type == TriggerSchedule {
+		errors = append(errors, validateScheduleTrigger(&dsl.Trigger)...)
+	}
+	errors = append(errors, validateInputSchema(dsl.Inputs)...)
 
 	if len(dsl.Steps) == 0 {
 		errors = append(errors, "at least one step is required")
@@ -717,3 +766,31 @@ func ValidateDSL(dsl *ScenarioDSL) []string {
 
 	return errors
 }
+
+// validateScheduleTrigger checks the cron expression, time zone and missed runs policy.
+func validateScheduleTrigger(trigger *TriggerDSL) []string {
+	var errors []string
+	loc := time.UTC
+	if trigger.Timezone != "" {
+		l, err := time.LoadLocation(trigger.Timezone)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("invalid trigger.timezone: %s", trigger.Timezone))
+		} else {
+			loc = l
+		}
+	}
+	if trigger.Event == "" {
+		errors = append(errors, "trigger.event must be a cron expression for schedule trigger")
+	} else if sched, err := ParseCron(trigger.Event, loc); err != nil {
+		errors = append(errors, fmt.Sprintf("invalid cron expression: %v", err))
+	} else if sched.Next(time.Now()).IsZero() {
+		errors = append(errors, fmt.Sprintf("cron expression never fires: %s", trigger.Event))
+	}
+	if trigger.MissedRuns != "" && trigger.MissedRuns != MissedRunsSkip && trigger.MissedRuns != MissedRunsCatchUp {
+		errors = append(errors, fmt.Sprintf("unknown trigger.missedRuns policy: %s", trigger.MissedRuns))
+	}
+	if trigger.MaxCatchUp < 0 {
+		errors = append(errors, "trigger.maxCatchUp must not be negative")
+	}
+	return errors
+}
@@ -0,0 +1,183 @@
+package scenario
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ──────────────────────────────────────────
+// Manual run inputs
+// ──────────────────────────────────────────
+
+var inputNamePattern = regexp.MustCompile(`^\w+$`)
+
+// reservedInputNames are the trigger data keys set by the manual run itself,
+// an input must not shadow the verified user or subscription the run targets
+var reservedInputNames = map[string]bool{
+	"trigger":        true,
+	"userId":         true,
+	"email":          true,
+	"subscriptionId": true,
+	"planId":         true,
+}
+
+// ValidateInputs checks input values against the scenario input schema.
+// It returns the normalized values with defaults applied, or the list of
+// validation errors. Inputs not declared in the schema are rejected.
+func ValidateInputs(schema []InputDSL, input map[string]interface{}) (map[string]interface{}, []string) {
+	var errors []string
+	result := make(map[string]interface{}, len(schema))
+	declared := make(map[string]bool, len(schema))
+
+	for _, field := range schema {
+		declared[field.Name] = true
+		raw, ok := input[field.Name]
+		if !ok || raw == nil {
+			if field.Default != nil {
+				raw = field.Default
+			} else if field.Required {
+				errors = append(errors, fmt.Sprintf("input %s is required", field.Name))
+				continue
+			} else {
+				continue
+			}
+		}
+		value, err := coerceInput(field, raw)
+		if err != nil {
+			errors = append(errors, err.Error())
+			continue
+		}
+		result[field.Name] = value
+	}
+
+	var unknown []string
+	for name := range input {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errors = append(errors, fmt.Sprintf("unknown input: %s", name))
+	}
+
+	if len(errors) > 0 {
+		return nil, errors
+	}
+	return result, nil
+}
+
+// coerceInput converts a JSON value to the declared input type.
+func coerceInput(field InputDSL, raw interface{}) (interface{}, error) {
+	var value interface{}
+	switch field.Type {
+	case InputTypeString, "":
+		switch v := raw.(type) {
+		case string:
+			value = v
+		case float64, bool, int, int64:
+			value = fmt.Sprintf("%v", v)
+		default:
+			return nil, fmt.Errorf("input %s must be a string", field.Name)
+		}
+	case InputTypeInteger:
+		switch v := raw.(type) {
+		case float64:
+			if v != math.Trunc(v) {
+				return nil, fmt.Errorf("input %s must be an integer", field.Name)
+			}
+			value = int64(v)
+		case int:
+			value = int64(v)
+		case int64:
+			value = v
+		case string:
+			n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("input %s must be an integer", field.Name)
+			}
+			value = n
+		default:
+			return nil, fmt.Errorf("input %s must be an integer", field.Name)
+		}
+	case InputTypeNumber:
+		switch v := raw.(type) {
+		case float64:
+			value = v
+		case int:
+			value = float64(v)
+		case int64:
+			value = float64(v)
+		case string:
+			n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, fmt.Errorf("input %s must be a number", field.Name)
+			}
+			value = n
+		default:
+			return nil, fmt.Errorf("input %s must be a number", field.Name)
+		}
+	case InputTypeBoolean:
+		switch v := raw.(type) {
+		case bool:
+			value = v
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("input %s must be a boolean", field.Name)
+			}
+			value = b
+		default:
+			return nil, fmt.Errorf("input %s must be a boolean", field.Name)
+		}
+	default:
+		return nil, fmt.Errorf("input %s has unknown type: %s", field.Name, field.Type)
+	}
+
+	if len(field.Enum) > 0 {
+		str := fmt.Sprintf("%v", value)
+		for _, allowed := range field.Enum {
+			if allowed == str {
+				return value, nil
+			}
+		}
+		return nil, fmt.Errorf("input %s must be one of [%s]", field.Name, strings.Join(field.Enum, ", "))
+	}
+	return value, nil
+}
+
+// validateInputSchema checks the input declarations of a scenario.
+func validateInputSchema(schema []InputDSL) []string {
+	var errors []string
+	names := map[string]bool{}
+	for _, field := range schema {
+		if !inputNamePattern.MatchString(field.Name) {
+			errors = append(errors, fmt.Sprintf("invalid input name: %q", field.Name))
+			continue
+		}
+		if reservedInputNames[field.Name] {
+			errors = append(errors, fmt.Sprintf("reserved input name: %s", field.Name))
+			continue
+		}
+		if names[field.Name] {
+			errors = append(errors, fmt.Sprintf("duplicate input: %s", field.Name))
+		}
+		names[field.Name] = true
+		switch field.Type {
+		case InputTypeString, InputTypeInteger, InputTypeNumber, InputTypeBoolean:
+		default:
+			errors = append(errors, fmt.Sprintf("unknown type %q for input %s", field.Type, field.Name))
+			continue
+		}
+		if field.Default != nil {
+			if _, err := coerceInput(field, field.Default); err != nil {
+				errors = append(errors, fmt.Sprintf("invalid default: %v", err))
+			}
+		}
+	}
+	return errors
+}
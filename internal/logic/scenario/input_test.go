@@ -0,0 +1,47 @@
+package scenario
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateInputs(t *testing.T) {
+	schema := []InputDSL{
+		{Name: "days", Type: InputTypeInteger, Required: true},
+		{Name: "notify", Type: InputTypeBoolean, Default: true},
+		{Name: "reason", Type: InputTypeString, Enum: []string{"support", "promo"}},
+		{Name: "amount", Type: InputTypeNumber},
+	}
+	t.Run("Test for Valid Inputs", func(t *testing.T) {
+		values, errs := ValidateInputs(schema, map[string]interface{}{"days": float64(7), "reason": "promo", "amount": "9.5"})
+		require.Nil(t, errs)
+		require.Equal(t, int64(7), values["days"])
+		require.Equal(t, true, values["notify"])
+		require.Equal(t, "promo", values["reason"])
+		require.Equal(t, 9.5, values["amount"])
+		_, ok := values["missing"]
+		require.False(t, ok)
+	})
+	t.Run("Test for Invalid Inputs", func(t *testing.T) {
+		_, errs := ValidateInputs(schema, map[string]interface{}{"days": 1.5, "reason": "other", "extra": 1})
+		require.Equal(t, []string{
+			"input days must be an integer",
+			"input reason must be one of [support, promo]",
+			"unknown input: extra",
+		}, errs)
+		_, errs = ValidateInputs(schema, nil)
+		require.Equal(t, []string{"input days is required"}, errs)
+	})
+	t.Run("Test for Input Schema", func(t *testing.T) {
+		errs := validateInputSchema([]InputDSL{
+			{Name: "a", Type: InputTypeString},
+			{Name: "a", Type: InputTypeString},
+			{Name: "b-c", Type: InputTypeString},
+			{Name: "d", Type: "date"},
+			{Name: "e", Type: InputTypeInteger, Default: "x"},
+			{Name: "userId", Type: InputTypeInteger},
+		})
+		require.Equal(t, 5, len(errs))
+	})
+}
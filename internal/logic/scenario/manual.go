@@ -0,0 +1,79 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"unibee/internal/query"
+)
+
+// ManualRunRequest describes a manual or API-driven scenario run.
+type ManualRunRequest struct {
+	MerchantId     uint64
+	ScenarioId     uint64
+	UserId         uint64
+	SubscriptionId string
+	Input          map[string]interface{}
+}
+
+// StartManualScenario validates a manual run against the scenario input schema and
+// starts it. The resolved user/subscription and the inputs are exposed as variables:
+// {{userId}}, {{email}}, {{subscriptionId}}, {{planId}} and {{<input name>}}.
+func StartManualScenario(ctx context.Context, req *ManualRunRequest) (uint64, error) {
+	sc, err := GetScenario(ctx, req.MerchantId, req.ScenarioId)
+	if err != nil {
+		return 0, err
+	}
+	if sc == nil {
+		return 0, fmt.Errorf("scenario not found")
+	}
+	if sc.TriggerType != TriggerManual {
+		return 0, fmt.Errorf("scenario %d is not a manual scenario", sc.Id)
+	}
+	if sc.Enabled != 1 {
+		return 0, fmt.Errorf("scenario %d is disabled", sc.Id)
+	}
+	dsl, err := ParseDSL(sc.ScenarioJson)
+	if err != nil {
+		return 0, err
+	}
+
+	inputs, errs := ValidateInputs(dsl.Inputs, req.Input)
+	if len(errs) > 0 {
+		return 0, fmt.Errorf("invalid input: %s", strings.Join(errs, "; "))
+	}
+
+	// inputs first, the verified identity below always wins
+	triggerData := make(map[string]interface{})
+	for k, v := range inputs {
+		if !reservedInputNames[k] {
+			triggerData[k] = v
+		}
+	}
+	triggerData["trigger"] = TriggerManual
+	userId := req.UserId
+	if len(req.SubscriptionId) > 0 {
+		sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+		if sub == nil || sub.MerchantId != req.MerchantId {
+			return 0, fmt.Errorf("subscription not found")
+		}
+		if userId > 0 && sub.UserId != userId {
+			return 0, fmt.Errorf("subscription %s does not belong to user %d", sub.SubscriptionId, userId)
+		}
+		userId = sub.UserId
+		triggerData["subscriptionId"] = sub.SubscriptionId
+		triggerData["planId"] = strconv.FormatUint(sub.PlanId, 10)
+	}
+	if userId > 0 {
+		user := query.GetUserAccountById(ctx, userId)
+		if user == nil || user.MerchantId != req.MerchantId {
+			return 0, fmt.Errorf("user not found")
+		}
+		triggerData["userId"] = strconv.FormatUint(user.Id, 10)
+		triggerData["email"] = user.Email
+	}
+
+	return StartScenarioExecution(ctx, req.MerchantId, sc.Id, sc.ScenarioJson, triggerData)
+}
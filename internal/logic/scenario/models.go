@@ -7,13 +7,29 @@ type ScenarioDSL struct {
 	Enabled   bool              `json:"enabled"`
 	Trigger   TriggerDSL        `json:"trigger"`
 	Variables map[string]string `json:"variables,omitempty"`
+	Inputs    []InputDSL        `json:"inputs,omitempty"` // input schema for manual runs
 	Steps     []StepDSL         `json:"steps"`
 }
 
+// InputDSL declares one typed input variable accepted by a manual run.
+type InputDSL struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type"` // string, integer, number, boolean
+	Required    bool        `json:"required,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	Enum        []string    `json:"enum,omitempty"`
+	Description string      `json:"description,omitempty"`
+}
+
 // TriggerDSL describes what starts the scenario.
 type TriggerDSL struct {
 	Type  string `json:"type"`  // webhook_event, bot_command, button_click, schedule, manual
 	Event string `json:"event"` // event name, command, cron expression
+
+	// Schedule-only options
+	Timezone   string `json:"timezone,omitempty"`   // IANA zone, defaults to the merchant time zone
+	MissedRuns string `json:"missedRuns,omitempty"` // skip (default), catch_up
+	MaxCatchUp int    `json:"maxCatchUp,omitempty"` // max missed runs replayed by catch_up, default 10
 }
 
 // StepDSL describes a single step in the scenario.
@@ -38,6 +54,20 @@ const (
 	TriggerManual       = "manual"
 )
 
+// Input types for manual run inputs
+const (
+	InputTypeString  = "string"
+	InputTypeInteger = "integer"
+	InputTypeNumber  = "number"
+	InputTypeBoolean = "boolean"
+)
+
+// Missed run policies for schedule triggers
+const (
+	MissedRunsSkip    = "skip"
+	MissedRunsCatchUp = "catch_up"
+)
+
 // Step types
 const (
 	StepSendTelegram = "send_telegram"
@@ -57,6 +87,7 @@ const (
 	StatusCompleted = "completed"
 	StatusFailed    = "failed"
 	StatusWaiting   = "waiting" // waiting for delayed task / callback
+	StatusMissed    = "missed"  // scheduled run skipped after downtime
 )
 
 // Step log statuses
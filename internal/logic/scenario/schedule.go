@@ -0,0 +1,217 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+const (
+	scheduleTickInterval = 20 * time.Second
+	// a run later than this is treated as missed and handled by the missed runs policy
+	scheduleMisfireGrace = 2 * time.Minute
+	// scheduleMaxOccurrences bounds the missed runs recorded one by one after long downtime,
+	// the older ones are collapsed into the dropped count
+	scheduleMaxOccurrences = 200
+	// scheduleMaxDropCount bounds the walk counting the collapsed runs
+	scheduleMaxDropCount = 100000
+	defaultMaxCatchUp    = 10
+	scheduleLockSeconds  = 60
+)
+
+// StartScheduleWorker starts a background goroutine that fires enabled
+// schedule-triggered scenarios on time. Several instances can run it at once:
+// each scenario is guarded by a redis lock and the run is claimed through a
+// compare-and-set on next_run_at.
+func StartScheduleWorker(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(scheduleTickInterval)
+		defer ticker.Stop()
+
+		g.Log().Infof(ctx, "scenario: schedule worker started (interval: %s)", scheduleTickInterval)
+
+		for {
+			select {
+			case <-ctx.Done():
+				g.Log().Infof(ctx, "scenario: schedule worker stopped")
+				return
+			case <-ticker.C:
+				processScheduledScenarios(ctx, time.Now())
+			}
+		}
+	}()
+}
+
+// processScheduledScenarios checks every enabled schedule scenario once.
+func processScheduledScenarios(ctx context.Context, now time.Time) {
+	scenarios, err := GetAllScenariosByTriggerType(ctx, TriggerSchedule)
+	if err != nil {
+		g.Log().Errorf(ctx, "scenario: failed to fetch schedule scenarios: %v", err)
+		return
+	}
+
+	for _, sc := range scenarios {
+		if err := processScheduledScenario(ctx, sc.MerchantId, sc.Id, sc.ScenarioJson, now); err != nil {
+			g.Log().Errorf(ctx, "scenario: schedule for scenario %d failed: %v", sc.Id, err)
+		}
+	}
+}
+
+func processScheduledScenario(ctx context.Context, merchantId, scenarioId uint64, scenarioJson string, now time.Time) error {
+	dsl, err := ParseDSL(scenarioJson)
+	if err != nil {
+		return err
+	}
+	timezone := ResolveScheduleTimezone(ctx, merchantId, dsl.Trigger.Timezone)
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %s: %w", timezone, err)
+	}
+	sched, err := ParseCron(dsl.Trigger.Event, loc)
+	if err != nil {
+		return err
+	}
+
+	lockKey := fmt.Sprintf("scenario_schedule_%d", scenarioId)
+	if !utility.TryLock(ctx, lockKey, scheduleLockSeconds) {
+		return nil
+	}
+	defer utility.ReleaseLock(ctx, lockKey)
+
+	state, err := GetScheduleState(ctx, scenarioId)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		// First time seen: start from now, there is nothing to catch up
+		return CreateScheduleState(ctx, merchantId, scenarioId, dsl.Trigger.Event, timezone, nextRunUnix(sched, now))
+	}
+	if state.CronExpr != dsl.Trigger.Event || state.Timezone != timezone {
+		return ResetScheduleState(ctx, state.Id, dsl.Trigger.Event, timezone, nextRunUnix(sched, now))
+	}
+	if state.NextRunAt <= 0 || state.NextRunAt > now.Unix() {
+		return nil
+	}
+
+	plan := PlanScheduledRuns(sched, time.Unix(state.NextRunAt, 0), now, dsl.Trigger.MissedRuns, dsl.Trigger.MaxCatchUp)
+	var nextRunAt int64
+	if !plan.Next.IsZero() {
+		nextRunAt = plan.Next.Unix()
+	}
+	claimed, err := AdvanceScheduleState(ctx, state.Id, state.NextRunAt, nextRunAt, now.Unix(), len(plan.Missed)+plan.Dropped)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		// another instance already fired this run
+		return nil
+	}
+
+	for _, t := range plan.Missed {
+		if err := CreateMissedExecution(ctx, merchantId, scenarioId, scheduleTriggerData(dsl.Trigger.Event, timezone, t, false)); err != nil {
+			g.Log().Errorf(ctx, "scenario: failed to record missed run for scenario %d at %d: %v", scenarioId, t.Unix(), err)
+		}
+	}
+	if len(plan.Missed) > 0 {
+		g.Log().Warningf(ctx, "scenario: scenario %d missed %d scheduled runs", scenarioId, len(plan.Missed))
+	}
+	if plan.Dropped > 0 {
+		if plan.DropCapped {
+			g.Log().Warningf(ctx, "scenario: scenario %d collapsed at least %d older missed runs without recording them", scenarioId, plan.Dropped)
+		} else {
+			g.Log().Warningf(ctx, "scenario: scenario %d collapsed %d older missed runs without recording them", scenarioId, plan.Dropped)
+		}
+	}
+	for _, t := range plan.Run {
+		go RunScenarioByIds(ctx, merchantId, scenarioId, scenarioJson, scheduleTriggerData(dsl.Trigger.Event, timezone, t, now.Sub(t) > scheduleMisfireGrace))
+	}
+	return nil
+}
+
+// ScheduledRuns is the outcome of walking the due occurrences of a schedule.
+type ScheduledRuns struct {
+	Run        []time.Time // occurrences to execute, oldest first
+	Missed     []time.Time // occurrences recorded as missed and not executed
+	Dropped    int         // older missed occurrences collapsed beyond the recording bound, counted only
+	DropCapped bool        // the dropped count stopped at scheduleMaxDropCount, more runs collapsed
+	Next       time.Time   // first occurrence after now
+}
+
+// PlanScheduledRuns splits all occurrences in [from, now] into runs and missed runs.
+// An occurrence within the misfire grace window always runs. Older ones are missed:
+// the skip policy records them only, catch_up replays up to maxCatchUp of the most
+// recent ones and records the rest. After long downtime only the latest scheduleMaxOccurrences
+// late occurrences are kept, the older ones are collapsed and reported in Dropped.
+func PlanScheduledRuns(sched *CronSchedule, from, now time.Time, policy string, maxCatchUp int) *ScheduledRuns {
+	if maxCatchUp <= 0 {
+		maxCatchUp = defaultMaxCatchUp
+	}
+	var late []time.Time
+	plan := &ScheduledRuns{}
+	t := from
+	for !t.IsZero() && !t.After(now) {
+		if now.Sub(t) <= scheduleMisfireGrace {
+			plan.Run = append(plan.Run, t)
+		} else {
+			late = append(late, t)
+			if len(late) > scheduleMaxOccurrences {
+				// too far behind, collapse the oldest one
+				late = late[1:]
+				plan.Dropped++
+				if plan.Dropped >= scheduleMaxDropCount {
+					plan.DropCapped = true
+					t = sched.Next(now.Add(-scheduleMisfireGrace))
+					continue
+				}
+			}
+		}
+		t = sched.Next(t)
+	}
+	plan.Next = t
+
+	if policy == MissedRunsCatchUp {
+		if len(late) > maxCatchUp {
+			plan.Missed = late[:len(late)-maxCatchUp]
+			late = late[len(late)-maxCatchUp:]
+		}
+		plan.Run = append(late, plan.Run...)
+	} else {
+		plan.Missed = late
+	}
+	return plan
+}
+
+// ResolveScheduleTimezone returns the trigger time zone, falling back to the merchant's, then UTC.
+func ResolveScheduleTimezone(ctx context.Context, merchantId uint64, timezone string) string {
+	if len(timezone) > 0 {
+		return timezone
+	}
+	if merchant := query.GetMerchantById(ctx, merchantId); merchant != nil && len(merchant.TimeZone) > 0 {
+		if _, err := time.LoadLocation(merchant.TimeZone); err == nil {
+			return merchant.TimeZone
+		}
+	}
+	return "UTC"
+}
+
+func nextRunUnix(sched *CronSchedule, after time.Time) int64 {
+	next := sched.Next(after)
+	if next.IsZero() {
+		return 0
+	}
+	return next.Unix()
+}
+
+func scheduleTriggerData(cronExpr, timezone string, scheduledAt time.Time, catchUp bool) map[string]interface{} {
+	return map[string]interface{}{
+		"cron":         cronExpr,
+		"timezone":     timezone,
+		"scheduled_at": scheduledAt.Unix(),
+		"catch_up":     catchUp,
+	}
+}
@@ -10,6 +10,7 @@ import (
 	dao "unibee/internal/dao/default"
 	entity "unibee/internal/model/entity/default"
 
+	"github.com/gogf/gf/v2/database/gdb"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gtime"
 )
@@ -144,6 +145,18 @@ func GetScenariosByTrigger(ctx context.Context, merchantId uint64, triggerType,
 	return list, err
 }
 
+// GetAllScenariosByTriggerType finds enabled scenarios of a trigger type across all merchants.
+func GetAllScenariosByTriggerType(ctx context.Context, triggerType string) ([]*entity.MerchantScenario, error) {
+	var list []*entity.MerchantScenario
+	err := dao.MerchantScenario.Ctx(ctx).
+		Where(dao.MerchantScenario.Columns().Enabled, 1).
+		Where(dao.MerchantScenario.Columns().IsDeleted, 0).
+		Where(dao.MerchantScenario.Columns().TriggerType, triggerType).
+		OrderAsc(dao.MerchantScenario.Columns().Id).
+		Scan(&list)
+	return list, err
+}
+
 // ParseDSL parses scenario_json into ScenarioDSL.
 func ParseDSL(jsonStr string) (*ScenarioDSL, error) {
 	var dsl ScenarioDSL
@@ -181,6 +194,26 @@ func CreateExecution(ctx context.Context, merchantId, scenarioId uint64, trigger
 	return uint64(id), nil
 }
 
+// CreateMissedExecution records a scheduled run that was skipped after downtime.
+func CreateMissedExecution(ctx context.Context, merchantId, scenarioId uint64, triggerData map[string]interface{}) error {
+	triggerJSON, _ := json.Marshal(triggerData)
+	now := gtime.Now()
+
+	_, err := dao.MerchantScenarioExecution.Ctx(ctx).Data(&entity.MerchantScenarioExecution{
+		MerchantId:   merchantId,
+		ScenarioId:   scenarioId,
+		TriggerData:  string(triggerJSON),
+		Status:       StatusMissed,
+		StartedAt:    time.Now().Unix(),
+		FinishedAt:   time.Now().Unix(),
+		ErrorMessage: "scheduled run missed, skipped by policy",
+		GmtCreate:    now,
+		GmtModify:    now,
+		CreateTime:   time.Now().Unix(),
+	}).OmitEmpty().Insert()
+	return err
+}
+
 // UpdateExecutionStatus updates the status of an execution.
 func UpdateExecutionStatus(ctx context.Context, executionId uint64, status, currentStep, errorMsg string, variables map[string]string) error {
 	data := g.Map{
@@ -315,6 +348,84 @@ func MarkDelayedTaskExecuted(ctx context.Context, taskId uint64) error {
 	return err
 }
 
+// ──────────────────────────────────────────
+// Schedule State
+// ──────────────────────────────────────────
+
+// GetScheduleState returns the persisted schedule state of a scenario.
+func GetScheduleState(ctx context.Context, scenarioId uint64) (*entity.MerchantScenarioSchedule, error) {
+	var row entity.MerchantScenarioSchedule
+	err := dao.MerchantScenarioSchedule.Ctx(ctx).
+		Where(dao.MerchantScenarioSchedule.Columns().ScenarioId, scenarioId).
+		Scan(&row)
+	if err != nil {
+		return nil, err
+	}
+	if row.Id == 0 {
+		return nil, nil
+	}
+	return &row, nil
+}
+
+// CreateScheduleState stores the first computed run time of a scenario.
+func CreateScheduleState(ctx context.Context, merchantId, scenarioId uint64, cronExpr, timezone string, nextRunAt int64) error {
+	now := gtime.Now()
+	_, err := dao.MerchantScenarioSchedule.Ctx(ctx).Data(&entity.MerchantScenarioSchedule{
+		MerchantId: merchantId,
+		ScenarioId: scenarioId,
+		CronExpr:   cronExpr,
+		Timezone:   timezone,
+		NextRunAt:  nextRunAt,
+		GmtCreate:  now,
+		GmtModify:  now,
+		CreateTime: time.Now().Unix(),
+	}).OmitEmpty().Insert()
+	return err
+}
+
+// ResetScheduleState recomputes the state after the cron expression or time zone changed.
+func ResetScheduleState(ctx context.Context, stateId uint64, cronExpr, timezone string, nextRunAt int64) error {
+	_, err := dao.MerchantScenarioSchedule.Ctx(ctx).
+		Where(dao.MerchantScenarioSchedule.Columns().Id, stateId).
+		Data(g.Map{
+			dao.MerchantScenarioSchedule.Columns().CronExpr:  cronExpr,
+			dao.MerchantScenarioSchedule.Columns().Timezone:  timezone,
+			dao.MerchantScenarioSchedule.Columns().NextRunAt: nextRunAt,
+			dao.MerchantScenarioSchedule.Columns().GmtModify: gtime.Now(),
+		}).Update()
+	return err
+}
+
+// AdvanceScheduleState moves next_run_at forward only if it still equals expectedNextRunAt,
+// so that exactly one instance claims a run. Returns false if another instance won.
+func AdvanceScheduleState(ctx context.Context, stateId uint64, expectedNextRunAt, nextRunAt, lastRunAt int64, missed int) (bool, error) {
+	result, err := dao.MerchantScenarioSchedule.Ctx(ctx).
+		Where(dao.MerchantScenarioSchedule.Columns().Id, stateId).
+		Where(dao.MerchantScenarioSchedule.Columns().NextRunAt, expectedNextRunAt).
+		Data(g.Map{
+			dao.MerchantScenarioSchedule.Columns().NextRunAt:   nextRunAt,
+			dao.MerchantScenarioSchedule.Columns().LastRunAt:   lastRunAt,
+			dao.MerchantScenarioSchedule.Columns().MissedCount: gdb.Raw(fmt.Sprintf("%s+%d", dao.MerchantScenarioSchedule.Columns().MissedCount, missed)),
+			dao.MerchantScenarioSchedule.Columns().GmtModify:   gtime.Now(),
+		}).Update()
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// DeleteScheduleState drops the schedule state so the next tick starts over from now.
+func DeleteScheduleState(ctx context.Context, scenarioId uint64) error {
+	_, err := dao.MerchantScenarioSchedule.Ctx(ctx).
+		Where(dao.MerchantScenarioSchedule.Columns().ScenarioId, scenarioId).
+		Delete()
+	return err
+}
+
 // ──────────────────────────────────────────
 // Telegram User Mapping
 // ──────────────────────────────────────────
@@ -21,6 +21,7 @@ import (
 	"unibee/internal/logic/metric_event"
 	"unibee/internal/logic/payment/service"
 	"unibee/internal/logic/plan/period"
+	"unibee/internal/logic/subscription/billingcycle/dunning"
 	"unibee/internal/logic/subscription/billingcycle/expire"
 	"unibee/internal/logic/subscription/config"
 	"unibee/internal/logic/subscription/handler"
@@ -131,6 +132,7 @@ func SubPipeBillingCycleWalk(ctx context.Context, subId string, timeNow int64, s
 			needTryInvoiceAutomaticPayment = true
 		}
 
+		dunningPolicy := config.GetMerchantSubscriptionConfig(ctx, sub.MerchantId).DunningPolicy
 		if sub.Status == consts.SubStatusExpired || sub.Status == consts.SubStatusFailed || sub.Status == consts.SubStatusCancelled {
 			return &BillingCycleWalkRes{WalkUnfinished: false, Message: "Nothing Todo As Sub Cancelled Or Expired Or Failed"}, nil
 		} else if sub.Status == consts.SubStatusPending || sub.Status == consts.SubStatusProcessing {
@@ -173,6 +175,34 @@ func SubPipeBillingCycleWalk(ctx context.Context, subId string, timeNow int64, s
 			} else {
 				return &BillingCycleWalkRes{WalkUnfinished: true, Message: "SubscriptionCancel At Billing Cycle End By CurrentPeriodEnd Set"}, nil
 			}
+		} else if sub.Status == consts.SubStatusSuspended {
+			return &BillingCycleWalkRes{WalkUnfinished: false, Message: "Nothing Todo As Sub Paused"}, nil
+		} else if timeNow > utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd) && sub.PauseAtPeriodEnd == 1 {
+			// sub set pauseAtPeriodEnd, need pause by system
+			err = service2.SubscriptionPauseAtPeriodEndBySystem(ctx, sub)
+			if err != nil {
+				g.Log().Errorf(ctx, source, "SubscriptionBillingCycleDunningInvoice SubscriptionPause err:", err.Error())
+				return nil, err
+			} else {
+				return &BillingCycleWalkRes{WalkUnfinished: true, Message: "SubscriptionPause At Billing Cycle End By PausePeriodEnd Set"}, nil
+			}
+		} else if dunningPolicy != nil && latestInvoice != nil && latestInvoice.Status == consts.InvoiceStatusProcessing &&
+			latestInvoice.TotalAmount > 0 && timeNow > utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd) {
+			// merchant's dunning policy takes over the payment retry and expiration after period end
+			if sub.Status != consts.SubStatusIncomplete {
+				err = handler.HandleSubscriptionIncomplete(ctx, sub.SubscriptionId, timeNow, "OutOfPeriod")
+				if err != nil {
+					g.Log().Errorf(ctx, source, "SubscriptionBillingCycleDunningInvoice HandleSubscriptionIncomplete err:", err.Error())
+					return nil, err
+				}
+				sub.Status = consts.SubStatusIncomplete
+			}
+			walk, err := dunning.SubscriptionDunningWalk(ctx, sub, latestInvoice, dunningPolicy, plan.DisableAutoCharge == 0 && latestInvoice.GatewayId > 0, timeNow)
+			if err != nil {
+				g.Log().Errorf(ctx, source, "SubscriptionBillingCycleDunningInvoice SubscriptionDunningWalk err:", err.Error())
+				return nil, err
+			}
+			return &BillingCycleWalkRes{WalkUnfinished: walk.WalkUnfinished, Message: walk.Message}, nil
 		} else if !needInvoiceGenerate && !needTryInvoiceAutomaticPayment && isSubscriptionExpireExcludePending(ctx, sub, timeNow) {
 			// invoice not generate and sub out of time, need expired by system
 			err = expire.SubscriptionExpire(ctx, sub, "AutoRenewFailure")
@@ -209,6 +239,9 @@ func SubPipeBillingCycleWalk(ctx context.Context, subId string, timeNow int64, s
 				//}
 				return &BillingCycleWalkRes{WalkUnfinished: false, Message: "Nothing Todo As CancelPeriodEnd Set"}, nil
 			}
+			if sub.PauseAtPeriodEnd == 1 {
+				return &BillingCycleWalkRes{WalkUnfinished: false, Message: "Nothing Todo As PausePeriodEnd Set"}, nil
+			}
 			// Unpaid after period end or trial end
 			if utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd) < timeNow && sub.Status != consts.SubStatusIncomplete && config.GetMerchantSubscriptionConfig(ctx, sub.MerchantId).IncompleteExpireTime > 30 {
 				err = handler.HandleSubscriptionIncomplete(ctx, sub.SubscriptionId, timeNow, "OutOfPeriod")
@@ -266,6 +299,9 @@ func SubPipeBillingCycleWalk(ctx context.Context, subId string, timeNow int64, s
 				if sub.CancelAtPeriodEnd == 1 {
 					return &BillingCycleWalkRes{WalkUnfinished: false, Message: "Nothing Todo As CancelPeriodEnd Set"}, nil
 				}
+				if sub.PauseAtPeriodEnd == 1 {
+					return &BillingCycleWalkRes{WalkUnfinished: false, Message: "Nothing Todo As PausePeriodEnd Set"}, nil
+				}
 				if latestInvoice == nil {
 					return &BillingCycleWalkRes{WalkUnfinished: false, Message: "Nothing Todo As invalid latestInvoice"}, nil
 				}
@@ -348,7 +384,7 @@ func PreviewSubscriptionNextInvoice(ctx context.Context, sub *entity.Subscriptio
 	plan := query.GetPlanById(ctx, sub.PlanId)
 	utility.Assert(plan != nil, "plan not found")
 	var taxPercentage = sub.TaxPercentage
-	percentage, countryCode, vatNumber, err := vat.GetUserTaxPercentage(ctx, sub.UserId)
+	percentage, countryCode, vatNumber, err := vat.GetUserPlanTaxPercentage(ctx, sub.UserId, plan.Id)
 	if err == nil {
 		taxPercentage = percentage
 	}
@@ -430,6 +466,7 @@ func PreviewSubscriptionNextInvoice(ctx context.Context, sub *entity.Subscriptio
 			DiscountCode:               discountCode,
 			TimeNow:                    timeNow,
 			PlanId:                     sub.PlanId,
+			PlanPriceVersion:           sub.PlanPriceVersion,
 			Quantity:                   sub.Quantity,
 			AddonJsonData:              sub.AddonData,
 			VatNumber:                  vatNumber,
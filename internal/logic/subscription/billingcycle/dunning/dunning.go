@@ -0,0 +1,235 @@
+package dunning
+
+import (
+	"context"
+	"fmt"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"unibee/api/bean"
+	"unibee/internal/consts"
+	"unibee/internal/consumer/webhook/event"
+	subscription3 "unibee/internal/consumer/webhook/subscription"
+	dao "unibee/internal/dao/default"
+	handler3 "unibee/internal/logic/invoice/handler"
+	"unibee/internal/logic/operation_log"
+	handler2 "unibee/internal/logic/payment/handler"
+	"unibee/internal/logic/payment/service"
+	"unibee/internal/logic/subscription/config"
+	"unibee/internal/logic/subscription/handler"
+	service2 "unibee/internal/logic/subscription/service"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+)
+
+// finalActionDelay leaves time for the async result of the last attempt before the final action
+const finalActionDelay = 86400
+
+type step int
+
+const (
+	stepWait step = iota
+	stepAttempt
+	stepFinalAction
+	stepFinished
+)
+
+type WalkRes struct {
+	WalkUnfinished bool
+	Message        string
+}
+
+// nextStep decides what to do with an unpaid invoice, attempted is the attempt count already made,
+// and the count over the length of attempts marks the final action done. Missed attempts are skipped,
+// only the latest due one is made
+func nextStep(policy *bean.DunningPolicy, periodEnd int64, attempted int, timeNow int64) (step, int) {
+	if attempted > len(policy.Attempts) {
+		return stepFinished, attempted
+	}
+	next := 0
+	for i := attempted; i < len(policy.Attempts); i++ {
+		if timeNow >= periodEnd+policy.Attempts[i].Day*86400 {
+			next = i + 1
+		}
+	}
+	if next > 0 {
+		return stepAttempt, next
+	}
+	if attempted == len(policy.Attempts) {
+		var lastDue = periodEnd
+		if attempted > 0 {
+			lastDue = periodEnd + policy.Attempts[attempted-1].Day*86400
+		}
+		if timeNow >= lastDue+finalActionDelay {
+			return stepFinalAction, attempted + 1
+		}
+	}
+	return stepWait, attempted
+}
+
+// SubscriptionDunningWalk runs merchant's dunning policy on the unpaid latest invoice of subscription after
+// its period end, retries the automatic payment on each scheduled attempt, and takes the final action once
+// all attempts failed
+func SubscriptionDunningWalk(ctx context.Context, sub *entity.Subscription, invoice *entity.Invoice, policy *bean.DunningPolicy, autoCharge bool, timeNow int64) (*WalkRes, error) {
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(invoice != nil, "invoice not found")
+	utility.Assert(policy != nil, "dunningPolicy not found")
+	periodEnd := utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd)
+	action, target := nextStep(policy, periodEnd, invoice.DunningAttempt, timeNow)
+	switch action {
+	case stepFinished:
+		return &WalkRes{WalkUnfinished: false, Message: "Nothing Todo As Dunning Finished"}, nil
+	case stepWait:
+		return &WalkRes{WalkUnfinished: false, Message: "Nothing Todo, Wait For Next Dunning Attempt"}, nil
+	}
+	// mark the attempt first, the walk of other instance will skip it
+	result, err := dao.Invoice.Ctx(ctx).Data(g.Map{
+		dao.Invoice.Columns().DunningAttempt: target,
+		dao.Invoice.Columns().LastTrackTime:  timeNow,
+		dao.Invoice.Columns().GmtModify:      gtime.Now(),
+	}).Where(dao.Invoice.Columns().Id, invoice.Id).
+		Where(dao.Invoice.Columns().DunningAttempt, invoice.DunningAttempt).OmitNil().Update()
+	if err != nil {
+		return nil, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected != 1 {
+		return &WalkRes{WalkUnfinished: false, Message: "Nothing Todo As Dunning Attempt Taken"}, nil
+	}
+	if action == stepFinalAction {
+		return finalAction(ctx, sub, invoice, policy)
+	}
+	return attempt(ctx, sub, invoice, policy, target, autoCharge, timeNow)
+}
+
+func attempt(ctx context.Context, sub *entity.Subscription, invoice *entity.Invoice, policy *bean.DunningPolicy, target int, autoCharge bool, timeNow int64) (*WalkRes, error) {
+	g.Log().Infof(ctx, "SubscriptionDunningWalk attempt:%d/%d subId:%s invoiceId:%s", target, len(policy.Attempts), sub.SubscriptionId, invoice.InvoiceId)
+	var paymentId = ""
+	var paymentStatus = consts.PaymentFailed
+	var message = "AutoChargeDisabled"
+	if autoCharge {
+		createRes, err := service.CreateSubInvoicePaymentDefaultAutomatic(ctx, &service.CreateSubInvoicePaymentDefaultAutomaticReq{
+			Invoice:       invoice,
+			ManualPayment: false,
+			ReturnUrl:     "",
+			CancelUrl:     "",
+			Source:        "SubscriptionDunning",
+			TimeNow:       timeNow,
+		})
+		if err != nil {
+			g.Log().Errorf(ctx, "SubscriptionDunningWalk CreateSubInvoicePaymentDefaultAutomatic err:%s", err.Error())
+			message = err.Error()
+		} else if createRes.Payment != nil {
+			paymentId = createRes.Payment.PaymentId
+			paymentStatus = int(createRes.Status)
+			message = fmt.Sprintf("PaymentStatus:%d", paymentStatus)
+			err = handler2.CreateOrUpdatePaymentTimelineForPayment(ctx, createRes.Payment, createRes.Payment.PaymentId)
+			if err != nil {
+				g.Log().Errorf(ctx, "SubscriptionDunningWalk CreateOrUpdatePaymentTimelineForPayment err:%s", err.Error())
+			}
+		}
+	}
+	if len(paymentId) == 0 {
+		// no payment created, record the failed attempt to payment timeline
+		createAttemptFailureTimeline(ctx, invoice, target)
+	}
+	if paymentStatus == consts.PaymentSuccess {
+		latestInvoice := query.GetInvoiceByInvoiceId(ctx, invoice.InvoiceId)
+		pendingUpdate := query.GetSubscriptionPendingUpdateByInvoiceId(ctx, invoice.InvoiceId)
+		var err error
+		if pendingUpdate != nil {
+			_, err = handler.HandlePendingUpdatePaymentSuccess(ctx, sub, pendingUpdate.PendingUpdateId, latestInvoice)
+		} else {
+			err = handler.HandleSubscriptionNextBillingCyclePaymentSuccess(ctx, sub, latestInvoice)
+		}
+		if err != nil {
+			g.Log().Errorf(ctx, "SubscriptionDunningWalk handle payment success err:%s", err.Error())
+		}
+	} else {
+		err := handler3.SendInvoiceEmailToUser(ctx, invoice.InvoiceId, false, policy.Attempts[target-1].EmailTemplate)
+		if err != nil {
+			g.Log().Errorf(ctx, "SubscriptionDunningWalk SendInvoiceEmailToUser err:%s", err.Error())
+		}
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("Subscription(%s)", sub.SubscriptionId),
+		Content:        fmt.Sprintf("DunningAttempt(%d/%d)-%s", target, len(policy.Attempts), message),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      invoice.InvoiceId,
+		PlanId:         0,
+		DiscountCode:   "",
+	}, nil)
+	subscription3.SendMerchantSubscriptionWebhookBackground(sub, -10000, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_DUNNING_ATTEMPT, map[string]interface{}{
+		"InvoiceId":           invoice.InvoiceId,
+		"DunningAttempt":      target,
+		"DunningAttemptCount": len(policy.Attempts),
+		"PaymentId":           paymentId,
+		"PaymentStatus":       paymentStatus,
+		"Note":                fmt.Sprintf("DunningAttempt(%d/%d)", target, len(policy.Attempts)),
+	})
+	return &WalkRes{WalkUnfinished: true, Message: fmt.Sprintf("Dunning Attempt %d/%d Result:%s", target, len(policy.Attempts), message)}, nil
+}
+
+func finalAction(ctx context.Context, sub *entity.Subscription, invoice *entity.Invoice, policy *bean.DunningPolicy) (*WalkRes, error) {
+	g.Log().Infof(ctx, "SubscriptionDunningWalk finalAction:%s subId:%s invoiceId:%s", policy.FinalAction, sub.SubscriptionId, invoice.InvoiceId)
+	var err error
+	switch policy.FinalAction {
+	case config.DunningFinalActionSuspend:
+		err = service2.SubscriptionSuspendByDunning(ctx, sub)
+	case config.DunningFinalActionMarkUnpaid:
+		// subscription stays incomplete, the invoice is kept open for manual payment
+		operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+			MerchantId:     sub.MerchantId,
+			Target:         fmt.Sprintf("Subscription(%s)", sub.SubscriptionId),
+			Content:        "MarkUnpaidByDunningPolicy",
+			UserId:         sub.UserId,
+			SubscriptionId: sub.SubscriptionId,
+			InvoiceId:      invoice.InvoiceId,
+			PlanId:         0,
+			DiscountCode:   "",
+		}, nil)
+	default:
+		err = service2.SubscriptionCancel(ctx, sub.SubscriptionId, false, false, "CancelByDunningPolicy")
+	}
+	if err != nil {
+		g.Log().Errorf(ctx, "SubscriptionDunningWalk finalAction:%s err:%s", policy.FinalAction, err.Error())
+		return nil, err
+	}
+	subscription3.SendMerchantSubscriptionWebhookBackground(sub, -10000, event.UNIBEE_WEBHOOK_EVENT_SUBSCRIPTION_DUNNING_ATTEMPT, map[string]interface{}{
+		"InvoiceId":           invoice.InvoiceId,
+		"DunningAttempt":      len(policy.Attempts),
+		"DunningAttemptCount": len(policy.Attempts),
+		"FinalAction":         policy.FinalAction,
+		"Note":                fmt.Sprintf("DunningFinalAction(%s)", policy.FinalAction),
+	})
+	return &WalkRes{WalkUnfinished: true, Message: fmt.Sprintf("Dunning Final Action:%s", policy.FinalAction)}, nil
+}
+
+func createAttemptFailureTimeline(ctx context.Context, invoice *entity.Invoice, target int) {
+	uniqueId := fmt.Sprintf("%s-Dunning-%d", invoice.InvoiceId, target)
+	if query.GetPaymentTimeLineByUniqueId(ctx, uniqueId) != nil {
+		return
+	}
+	one := &entity.PaymentTimeline{
+		MerchantId:     invoice.MerchantId,
+		UserId:         invoice.UserId,
+		SubscriptionId: invoice.SubscriptionId,
+		InvoiceId:      invoice.InvoiceId,
+		UniqueId:       uniqueId,
+		Currency:       invoice.Currency,
+		TotalAmount:    invoice.TotalAmount,
+		GatewayId:      invoice.GatewayId,
+		Status:         2,
+		TimelineType:   consts.TimelineTypePayment,
+		CreateTime:     gtime.Now().Timestamp(),
+	}
+	_, err := dao.PaymentTimeline.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	if err != nil {
+		g.Log().Errorf(ctx, "SubscriptionDunningWalk createAttemptFailureTimeline err:%s", err.Error())
+	}
+}
@@ -0,0 +1,43 @@
+package dunning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"unibee/api/bean"
+)
+
+func TestNextStep(t *testing.T) {
+	var periodEnd int64 = 1000000
+	var day int64 = 86400
+	policy := &bean.DunningPolicy{
+		Attempts: []*bean.DunningAttempt{{Day: 1}, {Day: 3}, {Day: 5}, {Day: 7}},
+	}
+	t.Run("Test for Dunning Attempt", func(t *testing.T) {
+		action, target := nextStep(policy, periodEnd, 0, periodEnd+10)
+		require.Equal(t, stepWait, action)
+		require.Equal(t, 0, target)
+		action, target = nextStep(policy, periodEnd, 0, periodEnd+day)
+		require.Equal(t, stepAttempt, action)
+		require.Equal(t, 1, target)
+		action, _ = nextStep(policy, periodEnd, 1, periodEnd+2*day)
+		require.Equal(t, stepWait, action)
+		action, target = nextStep(policy, periodEnd, 1, periodEnd+3*day)
+		require.Equal(t, stepAttempt, action)
+		require.Equal(t, 2, target)
+	})
+	t.Run("Test for Dunning Missed Attempt", func(t *testing.T) {
+		action, target := nextStep(policy, periodEnd, 1, periodEnd+6*day)
+		require.Equal(t, stepAttempt, action)
+		require.Equal(t, 3, target)
+	})
+	t.Run("Test for Dunning Final Action", func(t *testing.T) {
+		action, _ := nextStep(policy, periodEnd, 4, periodEnd+7*day+10)
+		require.Equal(t, stepWait, action)
+		action, target := nextStep(policy, periodEnd, 4, periodEnd+8*day)
+		require.Equal(t, stepFinalAction, action)
+		require.Equal(t, 5, target)
+		action, _ = nextStep(policy, periodEnd, 5, periodEnd+30*day)
+		require.Equal(t, stepFinished, action)
+	})
+}
@@ -2,9 +2,11 @@ package config
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"unibee/api/bean"
 	"unibee/internal/logic/merchant_config"
+	"unibee/utility"
 )
 
 const (
@@ -15,6 +17,21 @@ const (
 	TryAutomaticPaymentBeforePeriodEnd = "TryAutomaticPaymentBeforePeriodEnd"
 	GatewayVATRule                     = "GatewayVATRule"
 	ShowZeroInvoice                    = "ShowZeroInvoice"
+	PauseResumeBehavior                = "PauseResumeBehavior"
+	DunningPolicy                      = "DunningPolicy"
+)
+
+const (
+	PauseResumeBehaviorShift   = "shift"   // period end moves forward by the paused duration
+	PauseResumeBehaviorProrate = "prorate" // billing anchor is kept, a prorated period is charged on resume
+)
+
+const (
+	DunningFinalActionCancel     = "cancel"      // cancel the subscription
+	DunningFinalActionSuspend    = "suspend"     // pause the subscription until resumed
+	DunningFinalActionMarkUnpaid = "mark_unpaid" // keep the subscription incomplete and the invoice open for manual payment
+	DunningMaxAttempts           = 10
+	DunningMaxDays               = 60
 )
 
 func GetMerchantSubscriptionConfig(ctx context.Context, merchantId uint64) (config *bean.SubscriptionConfig) {
@@ -27,6 +44,7 @@ func GetMerchantSubscriptionConfig(ctx context.Context, merchantId uint64) (conf
 		TryAutomaticPaymentBeforePeriodEnd: 2 * 60 * 60, // default 2 hours before period
 		GatewayVATRule:                     "",
 		ShowZeroInvoice:                    true, // default false
+		PauseResumeBehavior:                PauseResumeBehaviorShift,
 	}
 	downgradeEffectImmediatelyConfig := merchant_config.GetMerchantConfig(ctx, merchantId, DowngradeEffectImmediately)
 	if downgradeEffectImmediatelyConfig != nil && downgradeEffectImmediatelyConfig.ConfigValue == "true" {
@@ -62,5 +80,34 @@ func GetMerchantSubscriptionConfig(ctx context.Context, merchantId uint64) (conf
 	if showZeroInvoice != nil && showZeroInvoice.ConfigValue == "false" {
 		config.ShowZeroInvoice = false
 	}
+	pauseResumeBehavior := merchant_config.GetMerchantConfig(ctx, merchantId, PauseResumeBehavior)
+	if pauseResumeBehavior != nil && pauseResumeBehavior.ConfigValue == PauseResumeBehaviorProrate {
+		config.PauseResumeBehavior = PauseResumeBehaviorProrate
+	}
+	dunningPolicy := merchant_config.GetMerchantConfig(ctx, merchantId, DunningPolicy)
+	if dunningPolicy != nil && len(dunningPolicy.ConfigValue) > 0 {
+		var policy *bean.DunningPolicy
+		err := utility.UnmarshalFromJsonString(dunningPolicy.ConfigValue, &policy)
+		if err == nil && policy != nil && len(policy.Attempts) > 0 {
+			config.DunningPolicy = policy
+		}
+	}
 	return config
 }
+
+// CheckDunningPolicy verifies the retry schedule of dunning policy and fills the default final action
+func CheckDunningPolicy(policy *bean.DunningPolicy) {
+	utility.Assert(policy != nil, "dunningPolicy is nil")
+	utility.Assert(len(policy.Attempts) <= DunningMaxAttempts, fmt.Sprintf("attempts should not more than %d", DunningMaxAttempts))
+	var lastDay int64 = -1
+	for _, attempt := range policy.Attempts {
+		utility.Assert(attempt != nil, "attempt is nil")
+		utility.Assert(attempt.Day > lastDay, "day of attempts should be in ascending order and not less than 0")
+		utility.Assert(attempt.Day <= DunningMaxDays, fmt.Sprintf("day of attempts should not greater than %d", DunningMaxDays))
+		lastDay = attempt.Day
+	}
+	if len(policy.FinalAction) == 0 {
+		policy.FinalAction = DunningFinalActionCancel
+	}
+	utility.Assert(policy.FinalAction == DunningFinalActionCancel || policy.FinalAction == DunningFinalActionSuspend || policy.FinalAction == DunningFinalActionMarkUnpaid, "finalAction should be cancel, suspend or mark_unpaid")
+}
@@ -86,6 +86,11 @@ func HandlePendingUpdatePaymentSuccess(ctx context.Context, sub *entity.Subscrip
 		periodEnd = invoice.PeriodEnd
 	}
 	var dunningTime = period.GetDunningTimeFromEnd(ctx, periodEnd, one.UpdatePlanId)
+	// the grandfathered price version stays while the plan unchanged, see SubscriptionUpdatePreview
+	var planPriceVersion = 0
+	if one.UpdatePlanId == sub.PlanId {
+		planPriceVersion = sub.PlanPriceVersion
+	}
 
 	_, err = dao.Subscription.Ctx(ctx).Data(g.Map{
 		dao.Subscription.Columns().Status:                 consts.SubStatusActive,
@@ -97,6 +102,7 @@ func HandlePendingUpdatePaymentSuccess(ctx context.Context, sub *entity.Subscrip
 		dao.Subscription.Columns().CurrentPeriodEndTime:   gtime.NewFromTimeStamp(periodEnd),
 		dao.Subscription.Columns().DunningTime:            dunningTime,
 		dao.Subscription.Columns().PlanId:                 one.UpdatePlanId,
+		dao.Subscription.Columns().PlanPriceVersion:       planPriceVersion,
 		dao.Subscription.Columns().Quantity:               one.UpdateQuantity,
 		dao.Subscription.Columns().AddonData:              one.UpdateAddonData,
 		dao.Subscription.Columns().Amount:                 invoice.TotalAmount,
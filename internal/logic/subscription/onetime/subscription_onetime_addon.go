@@ -99,7 +99,8 @@ func CreateSubOneTimeAddonPreview(ctx context.Context, req *SubscriptionCreateOn
 	gateway := query.GetGatewayById(ctx, gatewayId)
 	utility.Assert(gateway != nil, "Gateway not found")
 	var taxPercentage = sub.TaxPercentage
-	percentage, countryCode, vatNumber, err := vat.GetUserTaxPercentage(ctx, sub.UserId)
+	// the onetime addon charged in its own invoice, taxed by the tax code of addon product
+	percentage, countryCode, vatNumber, err := vat.GetUserPlanTaxPercentage(ctx, sub.UserId, addon.Id)
 	if err == nil {
 		taxPercentage = percentage
 	}
@@ -321,6 +322,7 @@ func CreateSubOneTimeAddon(ctx context.Context, req *SubscriptionCreateOnetimeAd
 			PayImmediate:         true,
 			GatewayPaymentType:   preview.GatewayPaymentType,
 			GatewayPaymentMethod: preview.GatewayPaymentMethod,
+			GatewayChosen:        req.GatewayId != nil,
 		})
 		utility.Assert(err == nil, fmt.Sprintf("%+v", err))
 		paymentId = createRes.Payment.PaymentId
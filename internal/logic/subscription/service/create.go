@@ -241,7 +241,7 @@ func SubscriptionCreatePreview(ctx context.Context, req *CreatePreviewInternalRe
 		subscriptionTaxPercentage = *req.TaxPercentage
 	} else if len(vatCountryCode) > 0 && gateway != nil {
 		utility.Assert(service2.IsGatewaySupportCountryCode(ctx, gateway, req.VatCountryCode), "gateway not support countryCode:"+vatCountryCode)
-		taxPercentage, _ := vat_gateway.ComputeMerchantVatPercentage(ctx, req.MerchantId, vatCountryCode, gateway.Id, validVatNumber)
+		taxPercentage, _ := vat_gateway.ComputeUserTaxPercentage(ctx, req.MerchantId, user, vatCountryCode, gateway.Id, validVatNumber, plan.Id)
 		subscriptionTaxPercentage = taxPercentage
 	}
 
@@ -258,6 +258,9 @@ func SubscriptionCreatePreview(ctx context.Context, req *CreatePreviewInternalRe
 		utility.Assert(addon.AddonPlan.IntervalCount == plan.IntervalCount, "update addon must have same recurring interval to plan")
 		TotalAmountExcludingTax = TotalAmountExcludingTax + addon.AddonPlan.CurrencyAmount(ctx, currency)*addon.Quantity
 	}
+	if req.TaxPercentage == nil && len(vatCountryCode) > 0 && gateway != nil {
+		utility.AssertError(vat_gateway.CheckAddonSalesTaxPercentage(ctx, req.MerchantId, user, vatCountryCode, plan.Id, addonPlanIds(addons)), "Addon sales tax not match plan")
+	}
 
 	promoCreditDiscountCodeExclusive := config.CheckCreditConfigDiscountCodeExclusive(ctx, _interface.GetMerchantId(ctx), consts.CreditAccountTypePromo, currency)
 	if len(req.DiscountCode) > 0 {
@@ -665,6 +668,7 @@ func SubscriptionCreate(ctx context.Context, req *CreateInternalReq) (*CreateInt
 			CancelUrl:     req.CancelUrl,
 			Source:        "SubscriptionCreate",
 			TimeNow:       0,
+			GatewayChosen: req.GatewayId != nil,
 		})
 		if err != nil {
 			// todo mark use method
@@ -68,6 +68,16 @@ func checkAndListAddonsFromParams(ctx context.Context, addonParams []*bean.PlanA
 	return addons
 }
 
+func addonPlanIds(addons []*bean.PlanAddonDetail) []uint64 {
+	var list = make([]uint64, 0)
+	for _, addon := range addons {
+		if addon != nil && addon.AddonPlan != nil {
+			list = append(list, addon.AddonPlan.Id)
+		}
+	}
+	return list
+}
+
 func VatNumberValidate(ctx context.Context, req *vat.NumberValidateReq) (*vat.NumberValidateRes, error) {
 	utility.Assert(req != nil, "req not found")
 	utility.Assert(len(req.VatNumber) > 0, "vatNumber invalid")
@@ -483,6 +493,7 @@ func EndTrialManual(ctx context.Context, subscriptionId string) (err error) {
 			UserId:             sub.UserId,
 			Currency:           sub.Currency,
 			PlanId:             sub.PlanId,
+			PlanPriceVersion:   sub.PlanPriceVersion,
 			Quantity:           sub.Quantity,
 			AddonJsonData:      sub.AddonData,
 			TaxPercentage:      sub.TaxPercentage,
@@ -0,0 +1,452 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"unibee/api/bean"
+	config2 "unibee/internal/cmd/config"
+	redismq2 "unibee/internal/cmd/redismq"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	handler2 "unibee/internal/logic/invoice/handler"
+	"unibee/internal/logic/invoice/invoice_compute"
+	service3 "unibee/internal/logic/invoice/service"
+	metric2 "unibee/internal/logic/metric"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/payment/service"
+	"unibee/internal/logic/plan/period"
+	"unibee/internal/logic/subscription/config"
+	"unibee/internal/logic/subscription/handler"
+	"unibee/internal/logic/subscription/timeline"
+	"unibee/internal/logic/user/sub_update"
+	"unibee/internal/logic/user/vat"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	redismq "github.com/jackyang-hk/go-redismq"
+)
+
+type PauseInternalReq struct {
+	SubscriptionId string `json:"subscriptionId" dc:"SubscriptionId" v:"required"`
+	AtPeriodEnd    bool   `json:"atPeriodEnd" dc:"AtPeriodEnd, pause at period end instead of immediately"`
+	AutoResumeTime int64  `json:"autoResumeTime" dc:"AutoResumeTime, utc time to resume automatically, 0 to resume manually"`
+	Reason         string `json:"reason" dc:"Reason"`
+}
+
+func subscriptionTimeNow(sub *entity.Subscription) int64 {
+	var timeNow = gtime.Now().Timestamp()
+	if sub.TestClock > timeNow && !config2.GetConfigInstance().IsProd() {
+		timeNow = sub.TestClock
+	}
+	return timeNow
+}
+
+// SubscriptionPause suspends an active subscription immediately or at period end. While paused, the
+// billing cycle generates no invoice and metric events are not accepted
+func SubscriptionPause(ctx context.Context, req *PauseInternalReq) error {
+	utility.Assert(req != nil, "req not found")
+	utility.Assert(len(req.SubscriptionId) > 0, "subscriptionId not found")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, req.SubscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	if sub.Status == consts.SubStatusSuspended {
+		g.Log().Infof(ctx, "SubscriptionPause, subscription already paused")
+		return nil
+	}
+	utility.Assert(sub.Status == consts.SubStatusActive, "subscription not in active status")
+	utility.Assert(sub.Type == consts.SubTypeUniBeeControl, "subscription not support pause")
+	utility.Assert(sub.CancelAtPeriodEnd == 0, "subscription will cancel at period end")
+	if len(sub.PendingUpdateId) > 0 {
+		pendingUpdate := query.GetSubscriptionPendingUpdateByPendingUpdateId(ctx, sub.PendingUpdateId)
+		utility.Assert(pendingUpdate == nil || pendingUpdate.Status >= consts.PendingSubStatusFinished, "subscription has a pending update")
+	}
+	timeNow := subscriptionTimeNow(sub)
+	periodEnd := utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd)
+	if req.AutoResumeTime > 0 {
+		utility.Assert(req.AutoResumeTime > timeNow, "autoResumeTime should be later than now")
+		if req.AtPeriodEnd {
+			utility.Assert(req.AutoResumeTime > periodEnd, "autoResumeTime should be later than period end")
+		}
+	}
+	if len(req.Reason) == 0 {
+		req.Reason = "Paused"
+	}
+
+	if req.AtPeriodEnd {
+		if sub.PauseAtPeriodEnd == 1 && sub.AutoResumeTime == req.AutoResumeTime {
+			return nil
+		}
+		_, err := dao.Subscription.Ctx(ctx).Data(g.Map{
+			dao.Subscription.Columns().PauseAtPeriodEnd: 1,
+			dao.Subscription.Columns().AutoResumeTime:   req.AutoResumeTime,
+			dao.Subscription.Columns().GmtModify:        gtime.Now(),
+			dao.Subscription.Columns().LastUpdateTime:   gtime.Now().Timestamp(),
+		}).Where(dao.Subscription.Columns().SubscriptionId, sub.SubscriptionId).OmitNil().Update()
+		operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+			MerchantId:     sub.MerchantId,
+			Target:         fmt.Sprintf("Subscription(%v)", sub.SubscriptionId),
+			Content:        fmt.Sprintf("%sAtPeriodEnd", req.Reason),
+			UserId:         sub.UserId,
+			SubscriptionId: sub.SubscriptionId,
+			InvoiceId:      "",
+			PlanId:         0,
+			DiscountCode:   "",
+		}, err)
+		if err != nil {
+			return err
+		}
+		_, _ = redismq.Send(&redismq.Message{
+			Topic:      redismq2.TopicSubscriptionUpdate.Topic,
+			Tag:        redismq2.TopicSubscriptionUpdate.Tag,
+			Body:       sub.SubscriptionId,
+			CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName(), "Note": "PauseAtPeriodEnd"},
+		})
+		return nil
+	}
+	// a subscription past its period end but not renewed yet is paused from the period end
+	return pauseSubscription(ctx, sub, utility.MinInt64(timeNow, periodEnd), req.AutoResumeTime, consts.SubPauseSourceManual, req.Reason)
+}
+
+// SubscriptionPauseAtPeriodEndBySystem is called by the billing cycle once a subscription with
+// the pauseAtPeriodEnd flag reaches its period end, the pause starts from the period end
+func SubscriptionPauseAtPeriodEndBySystem(ctx context.Context, sub *entity.Subscription) error {
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.PauseAtPeriodEnd == 1, "subscription not pause at period end")
+	utility.Assert(sub.Status == consts.SubStatusActive, "subscription not in active status")
+	return pauseSubscription(ctx, sub, utility.MinInt64(subscriptionTimeNow(sub), utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd)), sub.AutoResumeTime, consts.SubPauseSourceManual, "PauseAtPeriodEndBySystem")
+}
+
+// SubscriptionSuspendByDunning is called as the final action of merchant's dunning policy, the unpaid
+// subscription is paused from the period end until resumed by merchant
+func SubscriptionSuspendByDunning(ctx context.Context, sub *entity.Subscription) error {
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.Status == consts.SubStatusActive || sub.Status == consts.SubStatusIncomplete, "subscription not in active or incomplete status")
+	return pauseSubscription(ctx, sub, utility.MinInt64(subscriptionTimeNow(sub), utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd)), 0, consts.SubPauseSourceDunning, "SuspendByDunningPolicy")
+}
+
+// SubscriptionSuspendByDispute is called once a dispute of the subscription's payment is lost and the merchant
+// enabled the suspension in dispute config, the subscription is paused immediately until resumed by merchant
+func SubscriptionSuspendByDispute(ctx context.Context, sub *entity.Subscription) error {
+	utility.Assert(sub != nil, "subscription not found")
+	utility.Assert(sub.Status == consts.SubStatusActive || sub.Status == consts.SubStatusIncomplete, "subscription not in active or incomplete status")
+	return pauseSubscription(ctx, sub, subscriptionTimeNow(sub), 0, consts.SubPauseSourceDispute, "SuspendByDisputeLost")
+}
+
+func pauseSubscription(ctx context.Context, sub *entity.Subscription, pausedTime int64, autoResumeTime int64, source string, reason string) error {
+	var nextStatus = consts.SubStatusSuspended
+	result, err := dao.Subscription.Ctx(ctx).Data(g.Map{
+		dao.Subscription.Columns().Status:           nextStatus,
+		dao.Subscription.Columns().PauseAtPeriodEnd: 0,
+		dao.Subscription.Columns().PausedTime:       pausedTime,
+		dao.Subscription.Columns().AutoResumeTime:   autoResumeTime,
+		dao.Subscription.Columns().PauseSource:      source,
+		dao.Subscription.Columns().GmtModify:        gtime.Now(),
+		dao.Subscription.Columns().LastUpdateTime:   gtime.Now().Timestamp(),
+	}).Where(dao.Subscription.Columns().SubscriptionId, sub.SubscriptionId).
+		Where(dao.Subscription.Columns().Status, sub.Status).OmitNil().Update()
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected != 1 {
+		g.Log().Infof(ctx, "pauseSubscription, subscription status changed concurrently:%s", sub.SubscriptionId)
+		return fmt.Errorf("subscription status changed, please retry")
+	}
+	// the renewal invoice of next period should not be charged while paused
+	service3.TryCancelSubscriptionLatestInvoice(ctx, sub)
+	timeline.SubscriptionPausedTimeline(ctx, sub, pausedTime)
+	_, _ = redismq.Send(&redismq.Message{
+		Topic:      redismq2.TopicSubscriptionPaused.Topic,
+		Tag:        redismq2.TopicSubscriptionPaused.Tag,
+		Body:       sub.SubscriptionId,
+		CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+	})
+	_, _ = redismq.Send(&redismq.Message{
+		Topic: redismq2.TopicUserMetricUpdate.Topic,
+		Tag:   redismq2.TopicUserMetricUpdate.Tag,
+		Body: utility.MarshalToJsonString(&metric2.UserMetricUpdateMessage{
+			UserId:         sub.UserId,
+			SubscriptionId: sub.SubscriptionId,
+			Description:    "SubscriptionPaused",
+		}),
+		CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+	})
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("Subscription(%v)", sub.SubscriptionId),
+		Content:        fmt.Sprintf("%s(%s->%s)", reason, consts.SubStatusToEnum(sub.Status).Description(), consts.SubStatusToEnum(nextStatus).Description()),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	return nil
+}
+
+// IsUserResumable reports the user can resume the subscription from user portal, the pause at period end withdrawn or
+// the subscription paused manually, the suspensions by dunning or lost dispute only lifted by merchant
+func IsUserResumable(sub *entity.Subscription) bool {
+	if sub == nil {
+		return false
+	}
+	if sub.Status != consts.SubStatusSuspended {
+		return sub.PauseAtPeriodEnd == 1
+	}
+	return sub.PauseSource == consts.SubPauseSourceManual
+}
+
+// SubscriptionResume resumes a paused subscription, or withdraws the pause at period end if the
+// subscription is not paused yet. Depends on merchant's PauseResumeBehavior config, the period
+// end is shifted by the paused duration, or the billing anchor is kept and a prorated period is charged
+func SubscriptionResume(ctx context.Context, subscriptionId string, reason string) error {
+	utility.Assert(len(subscriptionId) > 0, "subscriptionId not found")
+	sub := query.GetSubscriptionBySubscriptionId(ctx, subscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	if len(reason) == 0 {
+		reason = "Resumed"
+	}
+	if sub.Status != consts.SubStatusSuspended {
+		utility.Assert(sub.PauseAtPeriodEnd == 1, "subscription not paused")
+		_, err := dao.Subscription.Ctx(ctx).Data(g.Map{
+			dao.Subscription.Columns().PauseAtPeriodEnd: 0,
+			dao.Subscription.Columns().AutoResumeTime:   0,
+			dao.Subscription.Columns().GmtModify:        gtime.Now(),
+			dao.Subscription.Columns().LastUpdateTime:   gtime.Now().Timestamp(),
+		}).Where(dao.Subscription.Columns().SubscriptionId, sub.SubscriptionId).OmitNil().Update()
+		operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+			MerchantId:     sub.MerchantId,
+			Target:         fmt.Sprintf("Subscription(%v)", sub.SubscriptionId),
+			Content:        "CancelPauseAtPeriodEnd",
+			UserId:         sub.UserId,
+			SubscriptionId: sub.SubscriptionId,
+			InvoiceId:      "",
+			PlanId:         0,
+			DiscountCode:   "",
+		}, err)
+		if err != nil {
+			return err
+		}
+		_, _ = redismq.Send(&redismq.Message{
+			Topic:      redismq2.TopicSubscriptionUpdate.Topic,
+			Tag:        redismq2.TopicSubscriptionUpdate.Tag,
+			Body:       sub.SubscriptionId,
+			CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName(), "Note": "CancelPauseAtPeriodEnd"},
+		})
+		return nil
+	}
+
+	timeNow := subscriptionTimeNow(sub)
+	periodEnd := utility.MaxInt64(sub.CurrentPeriodEnd, sub.TrialEnd)
+	var nextStatus = consts.SubStatusActive
+	var data g.Map
+	var prorationStart, prorationEnd int64
+	if config.GetMerchantSubscriptionConfig(ctx, sub.MerchantId).PauseResumeBehavior == config.PauseResumeBehaviorProrate {
+		if periodEnd > timeNow {
+			// still in the paid period, nothing to charge
+			data = g.Map{}
+		} else {
+			prorationStart, prorationEnd = anchoredPeriodAt(sub.CurrentPeriodEnd, timeNow, func(start int64) int64 {
+				return period.GetPeriodEndFromStart(ctx, start, sub.BillingCycleAnchor, sub.PlanId)
+			})
+			utility.Assert(prorationEnd > timeNow, "invalid plan period")
+			// the period is unpaid until the prorated invoice paid, expire and incomplete count from resume time
+			data = g.Map{
+				dao.Subscription.Columns().CurrentPeriodStart:     timeNow,
+				dao.Subscription.Columns().CurrentPeriodEnd:       timeNow,
+				dao.Subscription.Columns().CurrentPeriodStartTime: gtime.NewFromTimeStamp(timeNow),
+				dao.Subscription.Columns().CurrentPeriodEndTime:   gtime.NewFromTimeStamp(timeNow),
+				dao.Subscription.Columns().DunningTime:            timeNow,
+				dao.Subscription.Columns().CurrentPeriodPaid:      timeNow,
+			}
+		}
+	} else {
+		newPeriodEnd, newTrialEnd := shiftPausedPeriod(sub.CurrentPeriodEnd, sub.TrialEnd, sub.PausedTime, timeNow)
+		data = g.Map{
+			dao.Subscription.Columns().CurrentPeriodEnd:     newPeriodEnd,
+			dao.Subscription.Columns().CurrentPeriodEndTime: gtime.NewFromTimeStamp(newPeriodEnd),
+			dao.Subscription.Columns().TrialEnd:             newTrialEnd,
+			dao.Subscription.Columns().BillingCycleAnchor:   newPeriodEnd,
+			dao.Subscription.Columns().DunningTime:          period.GetDunningTimeFromEnd(ctx, utility.MaxInt64(newPeriodEnd, newTrialEnd), sub.PlanId),
+		}
+		if newPeriodEnd != sub.CurrentPeriodEnd {
+			// keep the metric usage of the paused period, limits continue after resume
+			_, err := dao.MerchantMetricEvent.Ctx(ctx).Data(g.Map{
+				dao.MerchantMetricEvent.Columns().SubscriptionPeriodEnd: newPeriodEnd,
+				dao.MerchantMetricEvent.Columns().GmtModify:             gtime.Now(),
+			}).Where(dao.MerchantMetricEvent.Columns().MerchantId, sub.MerchantId).
+				Where(dao.MerchantMetricEvent.Columns().SubscriptionIds, sub.SubscriptionId).
+				Where(dao.MerchantMetricEvent.Columns().SubscriptionPeriodStart, sub.CurrentPeriodStart).
+				Where(dao.MerchantMetricEvent.Columns().SubscriptionPeriodEnd, sub.CurrentPeriodEnd).
+				Update()
+			if err != nil {
+				g.Log().Errorf(ctx, "SubscriptionResume update metric event period err:%s", err.Error())
+			}
+		}
+		periodEnd = utility.MaxInt64(newPeriodEnd, newTrialEnd)
+	}
+	data[dao.Subscription.Columns().Status] = nextStatus
+	data[dao.Subscription.Columns().PausedTime] = 0
+	data[dao.Subscription.Columns().AutoResumeTime] = 0
+	data[dao.Subscription.Columns().PauseAtPeriodEnd] = 0
+	data[dao.Subscription.Columns().PauseSource] = ""
+	data[dao.Subscription.Columns().GmtModify] = gtime.Now()
+	data[dao.Subscription.Columns().LastUpdateTime] = gtime.Now().Timestamp()
+	result, err := dao.Subscription.Ctx(ctx).Data(data).
+		Where(dao.Subscription.Columns().SubscriptionId, sub.SubscriptionId).
+		Where(dao.Subscription.Columns().Status, consts.SubStatusSuspended).OmitNil().Update()
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		g.Log().Infof(ctx, "SubscriptionResume, subscription already resumed")
+		return nil
+	}
+	timeline.SubscriptionResumedTimeline(ctx, sub, timeNow, periodEnd)
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     sub.MerchantId,
+		Target:         fmt.Sprintf("Subscription(%v)", sub.SubscriptionId),
+		Content:        fmt.Sprintf("%s(%s->%s)", reason, consts.SubStatusToEnum(sub.Status).Description(), consts.SubStatusToEnum(nextStatus).Description()),
+		UserId:         sub.UserId,
+		SubscriptionId: sub.SubscriptionId,
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	if prorationEnd > 0 {
+		err = createResumeProrationInvoice(ctx, sub.SubscriptionId, prorationStart, timeNow, prorationEnd)
+		if err != nil {
+			g.Log().Errorf(ctx, "SubscriptionResume createResumeProrationInvoice err:%s", err.Error())
+		}
+	}
+	_, _ = redismq.Send(&redismq.Message{
+		Topic:      redismq2.TopicSubscriptionResumed.Topic,
+		Tag:        redismq2.TopicSubscriptionResumed.Tag,
+		Body:       sub.SubscriptionId,
+		CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+	})
+	_, _ = redismq.Send(&redismq.Message{
+		Topic: redismq2.TopicUserMetricUpdate.Topic,
+		Tag:   redismq2.TopicUserMetricUpdate.Tag,
+		Body: utility.MarshalToJsonString(&metric2.UserMetricUpdateMessage{
+			UserId:         sub.UserId,
+			SubscriptionId: sub.SubscriptionId,
+			Description:    "SubscriptionResumed",
+		}),
+		CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+	})
+	return nil
+}
+
+// createResumeProrationInvoice charges the part of the billing period [periodStart, periodEnd] left after resume,
+// the subscription period will move to [resumeTime, periodEnd] once the invoice paid
+func createResumeProrationInvoice(ctx context.Context, subscriptionId string, periodStart int64, resumeTime int64, periodEnd int64) error {
+	sub := query.GetSubscriptionBySubscriptionId(ctx, subscriptionId)
+	utility.Assert(sub != nil, "subscription not found")
+	plan := query.GetPlanById(ctx, sub.PlanId)
+	utility.Assert(plan != nil, "plan not found")
+	var subscriptionTaxPercentage = sub.TaxPercentage
+	percentage, countryCode, vatNumber, err := vat.GetUserPlanTaxPercentage(ctx, sub.UserId, plan.Id)
+	if err == nil {
+		subscriptionTaxPercentage = percentage
+	}
+	var addonParams []*bean.PlanAddonParam
+	if len(sub.AddonData) > 0 {
+		err = utility.UnmarshalFromJsonString(sub.AddonData, &addonParams)
+		if err != nil {
+			g.Log().Errorf(ctx, "SubscriptionResume Unmarshal addon param:%s", err.Error())
+		}
+	}
+	var prorationPlanParams = []*invoice_compute.ProrationPlanParam{{PlanId: sub.PlanId, Quantity: sub.Quantity}}
+	for _, addonParam := range addonParams {
+		prorationPlanParams = append(prorationPlanParams, &invoice_compute.ProrationPlanParam{
+			PlanId:   addonParam.AddonPlanId,
+			Quantity: addonParam.Quantity,
+		})
+	}
+	currentInvoice := invoice_compute.ComputeSubscriptionProrationToFixedEndInvoiceDetailSimplify(ctx, &invoice_compute.CalculateProrationInvoiceReq{
+		UserId:             sub.UserId,
+		MerchantId:         sub.MerchantId,
+		InvoiceName:        "SubscriptionResume",
+		ProductName:        plan.PlanName,
+		Currency:           sub.Currency,
+		DiscountCode:       sub.DiscountCode,
+		TimeNow:            resumeTime,
+		CountryCode:        countryCode,
+		VatNumber:          vatNumber,
+		TaxPercentage:      subscriptionTaxPercentage,
+		ProrationDate:      resumeTime,
+		NewProrationPlans:  prorationPlanParams,
+		PeriodStart:        periodStart,
+		PeriodEnd:          periodEnd,
+		FinishTime:         resumeTime,
+		BillingCycleAnchor: sub.BillingCycleAnchor,
+		Metadata:           map[string]interface{}{"SubscriptionResume": true},
+	})
+	gatewayId, paymentType, paymentMethodId := sub_update.VerifyPaymentGatewayMethod(ctx, sub.UserId, nil, "", "", sub.SubscriptionId)
+	if gatewayId <= 0 {
+		gatewayId = sub.GatewayId
+		paymentMethodId = sub.GatewayDefaultPaymentMethod
+	}
+	invoice, err := service3.CreateProcessingInvoiceForSub(ctx, &service3.CreateProcessingInvoiceForSubReq{
+		PlanId:             sub.PlanId,
+		Simplify:           currentInvoice,
+		Sub:                sub,
+		GatewayId:          gatewayId,
+		GatewayPaymentType: paymentType,
+		PaymentMethodId:    paymentMethodId,
+		IsSubLatestInvoice: true,
+		TimeNow:            resumeTime,
+	})
+	if err != nil {
+		return err
+	}
+	if invoice.TotalAmount == 0 {
+		invoice, err = handler2.MarkInvoiceAsPaidForZeroPayment(ctx, invoice.InvoiceId)
+		if err != nil {
+			return err
+		}
+		return handler.HandleSubscriptionNextBillingCyclePaymentSuccess(ctx, sub, invoice)
+	}
+	if invoice.GatewayId <= 0 {
+		// left to user or billing cycle to pay
+		return nil
+	}
+	createRes, err := service.CreateSubInvoicePaymentDefaultAutomatic(ctx, &service.CreateSubInvoicePaymentDefaultAutomaticReq{
+		Invoice: invoice,
+		Source:  "SubscriptionResume",
+		TimeNow: resumeTime,
+	})
+	if err != nil {
+		return err
+	}
+	if createRes.Status == consts.PaymentSuccess {
+		return handler.HandleSubscriptionNextBillingCyclePaymentSuccess(ctx, sub, invoice)
+	}
+	return nil
+}
+
+// shiftPausedPeriod moves the period end, and the trial end if the pause started in trial,
+// forward by the paused duration
+func shiftPausedPeriod(periodEnd int64, trialEnd int64, pausedTime int64, resumeTime int64) (int64, int64) {
+	if pausedTime <= 0 || resumeTime <= pausedTime {
+		return periodEnd, trialEnd
+	}
+	paused := resumeTime - pausedTime
+	if trialEnd > pausedTime {
+		trialEnd = trialEnd + paused
+	}
+	return periodEnd + paused, trialEnd
+}
+
+// anchoredPeriodAt walks the billing periods from periodEnd and returns the one contains timeNow
+func anchoredPeriodAt(periodEnd int64, timeNow int64, nextPeriodEnd func(start int64) int64) (int64, int64) {
+	start := periodEnd
+	end := nextPeriodEnd(start)
+	for end <= timeNow && end > start {
+		start = end
+		end = nextPeriodEnd(start)
+	}
+	return start, end
+}
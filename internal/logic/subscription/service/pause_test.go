@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+	"unibee/internal/consts"
+	entity "unibee/internal/model/entity/default"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseResumePeriod(t *testing.T) {
+	t.Run("Test for Shift Paused Period", func(t *testing.T) {
+		periodEnd, trialEnd := shiftPausedPeriod(1000, 0, 600, 900)
+		require.Equal(t, int64(1300), periodEnd)
+		require.Equal(t, int64(0), trialEnd)
+		periodEnd, trialEnd = shiftPausedPeriod(1000, 800, 600, 900)
+		require.Equal(t, int64(1300), periodEnd)
+		require.Equal(t, int64(1100), trialEnd)
+		periodEnd, trialEnd = shiftPausedPeriod(1000, 500, 600, 600)
+		require.Equal(t, int64(1000), periodEnd)
+		require.Equal(t, int64(500), trialEnd)
+	})
+	t.Run("Test for User Resumable", func(t *testing.T) {
+		require.True(t, IsUserResumable(&entity.Subscription{Status: consts.SubStatusSuspended, PauseSource: consts.SubPauseSourceManual}))
+		require.False(t, IsUserResumable(&entity.Subscription{Status: consts.SubStatusSuspended, PauseSource: consts.SubPauseSourceDunning}))
+		require.False(t, IsUserResumable(&entity.Subscription{Status: consts.SubStatusSuspended, PauseSource: consts.SubPauseSourceDispute}))
+		require.False(t, IsUserResumable(&entity.Subscription{Status: consts.SubStatusSuspended}))
+		require.True(t, IsUserResumable(&entity.Subscription{Status: consts.SubStatusActive, PauseAtPeriodEnd: 1}))
+		require.False(t, IsUserResumable(&entity.Subscription{Status: consts.SubStatusActive}))
+		require.False(t, IsUserResumable(nil))
+	})
+	t.Run("Test for Anchored Period", func(t *testing.T) {
+		next := func(start int64) int64 { return start + 100 }
+		start, end := anchoredPeriodAt(1000, 1050, next)
+		require.Equal(t, int64(1000), start)
+		require.Equal(t, int64(1100), end)
+		start, end = anchoredPeriodAt(1000, 1350, next)
+		require.Equal(t, int64(1300), start)
+		require.Equal(t, int64(1400), end)
+		start, end = anchoredPeriodAt(1000, 1200, next)
+		require.Equal(t, int64(1200), start)
+		require.Equal(t, int64(1300), end)
+	})
+}
@@ -63,7 +63,7 @@ func SubscriptionRenew(ctx context.Context, req *RenewInternalReq) (*CreateInter
 	// todo mark renew for all status
 	//utility.Assert(sub.Status == consts.SubStatusExpired || sub.Status == consts.SubStatusCancelled, "subscription not cancel or expire status")
 	var subscriptionTaxPercentage = sub.TaxPercentage
-	percentage, countryCode, vatNumber, err := vat.GetUserTaxPercentage(ctx, sub.UserId)
+	percentage, countryCode, vatNumber, err := vat.GetUserPlanTaxPercentage(ctx, sub.UserId, plan.Id)
 	if err == nil {
 		subscriptionTaxPercentage = percentage
 	}
@@ -132,6 +132,7 @@ func SubscriptionRenew(ctx context.Context, req *RenewInternalReq) (*CreateInter
 		DiscountCode:           req.DiscountCode,
 		TimeNow:                timeNow,
 		PlanId:                 sub.PlanId,
+		PlanPriceVersion:       sub.PlanPriceVersion,
 		Quantity:               sub.Quantity,
 		AddonJsonData:          utility.MarshalToJsonString(addonParams),
 		CountryCode:            countryCode,
@@ -174,6 +175,7 @@ func SubscriptionRenew(ctx context.Context, req *RenewInternalReq) (*CreateInter
 			CancelUrl:     req.CancelUrl,
 			Source:        "SubscriptionRenew",
 			TimeNow:       0,
+			GatewayChosen: req.GatewayId != nil,
 		})
 		if err != nil {
 			g.Log().Print(ctx, "SubscriptionRenew CreateSubInvoicePaymentDefaultAutomatic err:", err.Error())
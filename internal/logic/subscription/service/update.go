@@ -32,6 +32,7 @@ import (
 	"unibee/internal/logic/subscription/service/next"
 	"unibee/internal/logic/user/sub_update"
 	"unibee/internal/logic/user/vat"
+	"unibee/internal/logic/vat_gateway"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
 	"unibee/utility"
@@ -205,12 +206,14 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 	}
 	addons := checkAndListAddonsFromParams(ctx, req.AddonParams)
 	var subscriptionTaxPercentage = sub.TaxPercentage
-	percentage, countryCode, vatNumber, err := vat.GetUserTaxPercentage(ctx, sub.UserId)
+	percentage, countryCode, vatNumber, err := vat.GetUserPlanTaxPercentage(ctx, sub.UserId, plan.Id)
 	if err == nil {
 		subscriptionTaxPercentage = percentage
 	}
 	if req.TaxPercentage != nil {
 		subscriptionTaxPercentage = *req.TaxPercentage
+	} else if err == nil {
+		utility.AssertError(vat_gateway.CheckAddonSalesTaxPercentage(ctx, plan.MerchantId, user, countryCode, plan.Id, addonPlanIds(addons)), "Addon sales tax not match plan")
 	}
 
 	for _, addon := range addons {
@@ -224,6 +227,11 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 	oldPlan := query.GetPlanById(ctx, sub.PlanId)
 	utility.Assert(oldPlan != nil, "oldPlan not found")
 	utility.Assert(plan.ProductId == oldPlan.ProductId, "New plan product not equal to sub's product")
+	// the grandfathered price version stays while the plan unchanged, changing to other plan follows its current price
+	var planPriceVersion = 0
+	if req.NewPlanId == sub.PlanId {
+		planPriceVersion = sub.PlanPriceVersion
+	}
 
 	var hasIntervalChange = false
 	if req.NewPlanId != sub.PlanId {
@@ -345,6 +353,7 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 				DiscountCode:           req.DiscountCode,
 				TimeNow:                prorationDate,
 				PlanId:                 req.NewPlanId,
+				PlanPriceVersion:       planPriceVersion,
 				Quantity:               req.Quantity,
 				AddonJsonData:          utility.MarshalToJsonString(req.AddonParams),
 				CountryCode:            countryCode,
@@ -392,6 +401,7 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 				DiscountCode:           req.DiscountCode,
 				TimeNow:                prorationDate,
 				PlanId:                 req.NewPlanId,
+				PlanPriceVersion:       planPriceVersion,
 				Quantity:               req.Quantity,
 				AddonJsonData:          utility.MarshalToJsonString(req.AddonParams),
 				CountryCode:            countryCode,
@@ -415,8 +425,9 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 			}
 			var oldProrationPlanParams []*invoice_compute.ProrationPlanParam
 			oldProrationPlanParams = append(oldProrationPlanParams, &invoice_compute.ProrationPlanParam{
-				PlanId:   sub.PlanId,
-				Quantity: sub.Quantity,
+				PlanId:       sub.PlanId,
+				Quantity:     sub.Quantity,
+				PriceVersion: sub.PlanPriceVersion,
 			})
 			for _, addonParam := range oldAddonParams {
 				oldProrationPlanParams = append(oldProrationPlanParams, &invoice_compute.ProrationPlanParam{
@@ -426,8 +437,9 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 			}
 			var newProrationPlanParams []*invoice_compute.ProrationPlanParam
 			newProrationPlanParams = append(newProrationPlanParams, &invoice_compute.ProrationPlanParam{
-				PlanId:   req.NewPlanId,
-				Quantity: req.Quantity,
+				PlanId:       req.NewPlanId,
+				Quantity:     req.Quantity,
+				PriceVersion: planPriceVersion,
 			})
 			for _, addonParam := range req.AddonParams {
 				newProrationPlanParams = append(newProrationPlanParams, &invoice_compute.ProrationPlanParam{
@@ -546,6 +558,7 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 			DiscountCode:           nextCode,
 			TimeNow:                prorationDate,
 			PlanId:                 req.NewPlanId,
+			PlanPriceVersion:       planPriceVersion,
 			Quantity:               req.Quantity,
 			AddonJsonData:          utility.MarshalToJsonString(req.AddonParams),
 			CountryCode:            countryCode,
@@ -571,6 +584,7 @@ func SubscriptionUpdatePreview(ctx context.Context, req *UpdatePreviewInternalRe
 			DiscountCode:           nextCode,
 			TimeNow:                prorationDate,
 			PlanId:                 req.NewPlanId,
+			PlanPriceVersion:       planPriceVersion,
 			Quantity:               req.Quantity,
 			AddonJsonData:          utility.MarshalToJsonString(req.AddonParams),
 			CountryCode:            countryCode,
@@ -813,6 +827,7 @@ func SubscriptionUpdate(ctx context.Context, req *UpdateInternalReq, merchantMem
 			CancelUrl:     req.CancelUrl,
 			Source:        "SubscriptionUpdate",
 			TimeNow:       0,
+			GatewayChosen: req.GatewayId != nil,
 		})
 		if err != nil {
 			g.Log().Errorf(ctx, "SubscriptionUpdate CreateSubInvoicePaymentDefaultAutomatic err:%s", err.Error())
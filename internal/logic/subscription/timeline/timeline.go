@@ -2,6 +2,7 @@ package timeline
 
 import (
 	"context"
+	"fmt"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/nacos-group/nacos-sdk-go/util"
@@ -206,3 +207,101 @@ func SubscriptionNewTimeline(ctx context.Context, invoice *entity.Invoice) {
 		}
 	}
 }
+
+func SubscriptionPausedTimeline(ctx context.Context, sub *entity.Subscription, pausedTime int64) {
+	utility.Assert(sub != nil, "sub not found")
+	g.Log().Infof(ctx, "SubscriptionTimeLine-PausedTimeline-%s pausedTime:%d", sub.SubscriptionId, pausedTime)
+	//finish the processing one at pause time
+	var oldOne *entity.SubscriptionTimeline
+	_ = dao.SubscriptionTimeline.Ctx(ctx).
+		Where(dao.SubscriptionTimeline.Columns().MerchantId, sub.MerchantId).
+		Where(dao.SubscriptionTimeline.Columns().Status, consts.SubTimeLineStatusProcessing).
+		Where(dao.SubscriptionTimeline.Columns().SubscriptionId, sub.SubscriptionId).
+		OmitEmpty().Scan(&oldOne)
+	if oldOne != nil {
+		_, err := dao.SubscriptionTimeline.Ctx(ctx).Data(g.Map{
+			dao.SubscriptionTimeline.Columns().Status:        consts.SubTimeLineStatusFinished,
+			dao.SubscriptionTimeline.Columns().PeriodEnd:     utility.MinInt64(oldOne.PeriodEnd, pausedTime),
+			dao.SubscriptionTimeline.Columns().PeriodEndTime: gtime.NewFromTimeStamp(utility.MinInt64(oldOne.PeriodEnd, pausedTime)),
+		}).Where(dao.SubscriptionTimeline.Columns().Id, oldOne.Id).OmitNil().Update()
+		if err != nil {
+			g.Log().Errorf(ctx, `SubscriptionTimeLine-SubscriptionPausedTimeline update old one failure %s`, err.Error())
+		}
+	}
+	//create paused one, period end stays open until resumed
+	uniqueId := fmt.Sprintf("%s-Paused-%d", sub.SubscriptionId, pausedTime)
+	if query.GetSubscriptionTimeLineByUniqueId(ctx, uniqueId) != nil {
+		return
+	}
+	one := &entity.SubscriptionTimeline{
+		MerchantId:      sub.MerchantId,
+		UserId:          sub.UserId,
+		SubscriptionId:  sub.SubscriptionId,
+		InvoiceId:       sub.LatestInvoiceId,
+		UniqueId:        uniqueId,
+		UniqueKey:       util.Md5(uniqueId),
+		Currency:        sub.Currency,
+		PlanId:          sub.PlanId,
+		Quantity:        sub.Quantity,
+		AddonData:       sub.AddonData,
+		Status:          consts.SubTimeLineStatusPaused,
+		GatewayId:       sub.GatewayId,
+		PeriodStart:     pausedTime,
+		PeriodEnd:       0,
+		PeriodStartTime: gtime.NewFromTimeStamp(pausedTime),
+		CreateTime:      gtime.Now().Timestamp(),
+	}
+	_, err := dao.SubscriptionTimeline.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	if err != nil {
+		g.Log().Errorf(ctx, `SubscriptionTimeLine-SubscriptionPausedTimeline record insert failure %s`, err.Error())
+	}
+}
+
+// SubscriptionResumedTimeline closes the open paused timeline at resume time. If the resumed
+// subscription still has a paid period left (periodEnd > resumeTime), a processing timeline is
+// created for it, otherwise the next one will be created by the paid invoice
+func SubscriptionResumedTimeline(ctx context.Context, sub *entity.Subscription, resumeTime int64, periodEnd int64) {
+	utility.Assert(sub != nil, "sub not found")
+	g.Log().Infof(ctx, "SubscriptionTimeLine-ResumedTimeline-%s resumeTime:%d periodEnd:%d", sub.SubscriptionId, resumeTime, periodEnd)
+	_, err := dao.SubscriptionTimeline.Ctx(ctx).Data(g.Map{
+		dao.SubscriptionTimeline.Columns().PeriodEnd:     resumeTime,
+		dao.SubscriptionTimeline.Columns().PeriodEndTime: gtime.NewFromTimeStamp(resumeTime),
+	}).Where(dao.SubscriptionTimeline.Columns().MerchantId, sub.MerchantId).
+		Where(dao.SubscriptionTimeline.Columns().SubscriptionId, sub.SubscriptionId).
+		Where(dao.SubscriptionTimeline.Columns().Status, consts.SubTimeLineStatusPaused).
+		Where(dao.SubscriptionTimeline.Columns().PeriodEnd, 0).
+		Update()
+	if err != nil {
+		g.Log().Errorf(ctx, `SubscriptionTimeLine-SubscriptionResumedTimeline update paused one failure %s`, err.Error())
+	}
+	if periodEnd <= resumeTime {
+		return
+	}
+	uniqueId := fmt.Sprintf("%s-Resumed-%d", sub.SubscriptionId, resumeTime)
+	if query.GetSubscriptionTimeLineByUniqueId(ctx, uniqueId) != nil {
+		return
+	}
+	one := &entity.SubscriptionTimeline{
+		MerchantId:      sub.MerchantId,
+		UserId:          sub.UserId,
+		SubscriptionId:  sub.SubscriptionId,
+		InvoiceId:       sub.LatestInvoiceId,
+		UniqueId:        uniqueId,
+		UniqueKey:       util.Md5(uniqueId),
+		Currency:        sub.Currency,
+		PlanId:          sub.PlanId,
+		Quantity:        sub.Quantity,
+		AddonData:       sub.AddonData,
+		Status:          consts.SubTimeLineStatusProcessing,
+		GatewayId:       sub.GatewayId,
+		PeriodStart:     resumeTime,
+		PeriodEnd:       periodEnd,
+		PeriodStartTime: gtime.NewFromTimeStamp(resumeTime),
+		PeriodEndTime:   gtime.NewFromTimeStamp(periodEnd),
+		CreateTime:      gtime.Now().Timestamp(),
+	}
+	_, err = dao.SubscriptionTimeline.Ctx(ctx).Data(one).OmitNil().Insert(one)
+	if err != nil {
+		g.Log().Errorf(ctx, `SubscriptionTimeLine-SubscriptionResumedTimeline record insert failure %s`, err.Error())
+	}
+}
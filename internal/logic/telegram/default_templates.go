@@ -10,10 +10,13 @@ var DefaultTemplates = map[string]string{
 	"subscription.cancelled": "❌ Subscription cancelled\nPlan: {{planName}}\nUser: {{userEmail}}",
 	"subscription.expired":   "⏰ Subscription expired\nPlan: {{planName}}\nUser: {{userEmail}}",
 	"subscription.failed":    "🚫 Subscription failed\nPlan: {{planName}}\nUser: {{userEmail}}",
+	"subscription.paused":    "⏸ Subscription paused\nPlan: {{planName}}\nUser: {{userEmail}}",
+	"subscription.resumed":   "▶️ Subscription resumed\nPlan: {{planName}}\nUser: {{userEmail}}",
 
 	// Auto-renewal
 	"subscription.auto_renew.success": "🔄 Auto-renewal successful\nPlan: {{planName}}\nUser: {{userEmail}}\nAmount: {{amountFormatted}}",
 	"subscription.auto_renew.failure": "⚠️ Auto-renewal failed\nPlan: {{planName}}\nUser: {{userEmail}}",
+	"subscription.dunning.attempt":    "🔁 Payment retry attempted\nPlan: {{planName}}\nUser: {{userEmail}}",
 
 	// Payments
 	"payment.created":   "💳 Payment created\nAmount: {{amountFormatted}}\nUser: {{userEmail}}",
@@ -43,6 +46,9 @@ var DefaultTemplates = map[string]string{
 	"subscription.onetime_addon.success":   "✅ One-time addon paid\nUser: {{userEmail}}\nAmount: {{amountFormatted}}",
 	"subscription.onetime_addon.cancelled": "❌ One-time addon cancelled\nUser: {{userEmail}}",
 	"subscription.onetime_addon.expired":   "⏰ One-time addon expired\nUser: {{userEmail}}",
+
+	// Webhooks
+	"webhook.endpoint.disabled": "🔕 Webhook endpoint disabled\nEndpoint: {{description}}\nReason: {{reason}}",
 }
 
 // AvailableVariables lists all template variables that can be used.
@@ -0,0 +1,236 @@
+package gdpr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"unibee/api/bean"
+	redismq2 "unibee/internal/cmd/redismq"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/oss"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	redismq "github.com/jackyang-hk/go-redismq"
+)
+
+const AnonymizedName = "Anonymized"
+
+func AnonymizedEmail(userId uint64) string {
+	return fmt.Sprintf("anonymized_%d@anonymized.invalid", userId)
+}
+
+// AnonymizeUserSnapshot scrubs the user snapshot saved in invoice data, only country and tax kept for the tax record
+func AnonymizeUserSnapshot(userId uint64, data string) string {
+	if len(data) == 0 {
+		return data
+	}
+	var snapshot *entity.UserAccount
+	if err := utility.UnmarshalFromJsonString(data, &snapshot); err != nil || snapshot == nil {
+		return ""
+	}
+	return utility.MarshalToJsonString(&entity.UserAccount{
+		Email:         AnonymizedEmail(userId),
+		CountryCode:   snapshot.CountryCode,
+		CountryName:   snapshot.CountryName,
+		TaxPercentage: snapshot.TaxPercentage,
+		GatewayId:     snapshot.GatewayId,
+		Type:          snapshot.Type,
+		FirstName:     AnonymizedName,
+	})
+}
+
+// AnonymizePaymentInvoiceData scrubs the invoice serialized in payment the same as the anonymized invoice,
+// the lines and amounts kept for the payment record
+func AnonymizePaymentInvoiceData(userId uint64, data string) string {
+	if len(data) == 0 {
+		return data
+	}
+	var invoice *bean.Invoice
+	if err := utility.UnmarshalFromJsonString(data, &invoice); err != nil || invoice == nil {
+		return ""
+	}
+	invoice.VatNumber = ""
+	invoice.SendNote = ""
+	invoice.Metadata = nil
+	invoice.Data = AnonymizeUserSnapshot(userId, invoice.Data)
+	return utility.MarshalToJsonString(invoice)
+}
+
+// AnonymizeUser scrubs the personal data of user across the billing tables,
+// the amounts, currencies and invoice numbers are kept for accounting and tax retention
+func AnonymizeUser(ctx context.Context, merchantId uint64, userId uint64) (err error) {
+	user := query.GetUserAccountById(ctx, userId)
+	utility.Assert(user != nil && user.MerchantId == merchantId, "user not found")
+	utility.Assert(user.Email != AnonymizedEmail(user.Id), "user already anonymized")
+	subs := query.GetLatestActiveOrIncompleteOrCreateSubscriptionsByUserId(ctx, user.Id, user.MerchantId)
+	utility.Assert(len(subs) == 0, "user has active subscription, cancel it first")
+
+	email := AnonymizedEmail(user.Id)
+	var invoices []*entity.Invoice
+	err = dao.Invoice.Ctx(ctx).
+		Where(dao.Invoice.Columns().MerchantId, merchantId).
+		Where(dao.Invoice.Columns().UserId, userId).
+		Fields(dao.Invoice.Columns().Id, dao.Invoice.Columns().InvoiceId, dao.Invoice.Columns().Data).
+		Scan(&invoices)
+	if err == nil {
+		err = dao.UserAccount.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+			_, err = dao.UserAccount.Ctx(ctx).Data(g.Map{
+				dao.UserAccount.Columns().Email:                  email,
+				dao.UserAccount.Columns().ExternalUserId:         "",
+				dao.UserAccount.Columns().Password:               "",
+				dao.UserAccount.Columns().UserName:               "",
+				dao.UserAccount.Columns().FirstName:              AnonymizedName,
+				dao.UserAccount.Columns().LastName:               "",
+				dao.UserAccount.Columns().CompanyName:            "",
+				dao.UserAccount.Columns().Mobile:                 "",
+				dao.UserAccount.Columns().Phone:                  "",
+				dao.UserAccount.Columns().Address:                "",
+				dao.UserAccount.Columns().City:                   "",
+				dao.UserAccount.Columns().ZipCode:                "",
+				dao.UserAccount.Columns().State:                  "",
+				dao.UserAccount.Columns().TaxExemptCertificateId: "",
+				dao.UserAccount.Columns().VATNumber:              "",
+				dao.UserAccount.Columns().RegistrationNumber:     "",
+				dao.UserAccount.Columns().Gender:                 "",
+				dao.UserAccount.Columns().AvatarUrl:              "",
+				dao.UserAccount.Columns().ReMark:                 "",
+				dao.UserAccount.Columns().Birthday:               "",
+				dao.UserAccount.Columns().Profession:             "",
+				dao.UserAccount.Columns().School:                 "",
+				dao.UserAccount.Columns().Custom:                 "",
+				dao.UserAccount.Columns().Telegram:               "",
+				dao.UserAccount.Columns().WhatsAPP:               "",
+				dao.UserAccount.Columns().WeChat:                 "",
+				dao.UserAccount.Columns().TikTok:                 "",
+				dao.UserAccount.Columns().LinkedIn:               "",
+				dao.UserAccount.Columns().Facebook:               "",
+				dao.UserAccount.Columns().OtherSocialInfo:        "",
+				dao.UserAccount.Columns().MetaData:               "",
+				dao.UserAccount.Columns().Status:                 2,
+				dao.UserAccount.Columns().GmtModify:              gtime.Now(),
+			}).Where(dao.UserAccount.Columns().Id, userId).Update()
+			if err != nil {
+				return err
+			}
+			_, err = dao.Subscription.Ctx(ctx).Data(g.Map{
+				dao.Subscription.Columns().CustomerName:  "",
+				dao.Subscription.Columns().CustomerEmail: "",
+				dao.Subscription.Columns().VatNumber:     "",
+				dao.Subscription.Columns().VatVerifyData: "",
+				dao.Subscription.Columns().GmtModify:     gtime.Now(),
+			}).Where(dao.Subscription.Columns().MerchantId, merchantId).Where(dao.Subscription.Columns().UserId, userId).Update()
+			if err != nil {
+				return err
+			}
+			for _, one := range invoices {
+				_, err = dao.Invoice.Ctx(ctx).Data(g.Map{
+					dao.Invoice.Columns().SendEmail: email,
+					dao.Invoice.Columns().VatNumber: "",
+					dao.Invoice.Columns().Data:      AnonymizeUserSnapshot(userId, one.Data),
+					dao.Invoice.Columns().SendPdf:   "",
+					dao.Invoice.Columns().GmtModify: gtime.Now(),
+				}).Where(dao.Invoice.Columns().Id, one.Id).Update()
+				if err != nil {
+					return err
+				}
+			}
+			var payments []*entity.Payment
+			err = dao.Payment.Ctx(ctx).
+				Where(dao.Payment.Columns().MerchantId, merchantId).
+				Where(dao.Payment.Columns().UserId, userId).
+				Fields(dao.Payment.Columns().Id, dao.Payment.Columns().InvoiceData).
+				Scan(&payments)
+			if err != nil {
+				return err
+			}
+			// the raw gateway payloads hold the billing details of payer, cleared
+			for _, one := range payments {
+				_, err = dao.Payment.Ctx(ctx).Data(g.Map{
+					dao.Payment.Columns().TerminalIp:  "",
+					dao.Payment.Columns().InvoiceData: AnonymizePaymentInvoiceData(userId, one.InvoiceData),
+					dao.Payment.Columns().PaymentData: "",
+					dao.Payment.Columns().MetaData:    "",
+					dao.Payment.Columns().GmtModify:   gtime.Now(),
+				}).Where(dao.Payment.Columns().Id, one.Id).Update()
+				if err != nil {
+					return err
+				}
+			}
+			_, err = dao.Refund.Ctx(ctx).Data(g.Map{
+				dao.Refund.Columns().RefundComment:        "",
+				dao.Refund.Columns().RefundCommentExplain: "",
+				dao.Refund.Columns().GmtModify:            gtime.Now(),
+			}).Where(dao.Refund.Columns().MerchantId, merchantId).Where(dao.Refund.Columns().UserId, userId).Update()
+			if err != nil {
+				return err
+			}
+			_, err = dao.CreditTransaction.Ctx(ctx).Data(g.Map{
+				dao.CreditTransaction.Columns().Description: "",
+				dao.CreditTransaction.Columns().GmtModify:   gtime.Now(),
+			}).Where(dao.CreditTransaction.Columns().MerchantId, merchantId).Where(dao.CreditTransaction.Columns().UserId, userId).Update()
+			if err != nil {
+				return err
+			}
+			if len(user.Email) > 0 {
+				_, err = dao.MerchantEmailHistory.Ctx(ctx).Data(g.Map{
+					dao.MerchantEmailHistory.Columns().Email:      email,
+					dao.MerchantEmailHistory.Columns().Content:    "",
+					dao.MerchantEmailHistory.Columns().AttachFile: "",
+					dao.MerchantEmailHistory.Columns().GmtModify:  gtime.Now(),
+				}).Where(dao.MerchantEmailHistory.Columns().MerchantId, merchantId).Where(dao.MerchantEmailHistory.Columns().Email, user.Email).Update()
+				if err != nil {
+					return err
+				}
+			}
+			_, err = dao.MerchantMetricEvent.Ctx(ctx).Data(g.Map{
+				dao.MerchantMetricEvent.Columns().AggregationPropertyData: "",
+				dao.MerchantMetricEvent.Columns().GmtModify:               gtime.Now(),
+			}).Where(dao.MerchantMetricEvent.Columns().MerchantId, merchantId).Where(dao.MerchantMetricEvent.Columns().UserId, userId).Update()
+			return err
+		})
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     merchantId,
+		Target:         fmt.Sprintf("User(%v)", userId),
+		Content:        "Anonymize",
+		UserId:         userId,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	if err == nil {
+		// the pdf generated before holds the personal data, regenerated from the scrubbed invoice on next download
+		for _, one := range invoices {
+			if removeErr := os.Remove(fmt.Sprintf("%s.pdf", one.InvoiceId)); removeErr != nil && !os.IsNotExist(removeErr) {
+				g.Log().Errorf(ctx, "AnonymizeUser remove invoice pdf invoiceId:%s error:%s", one.InvoiceId, removeErr.Error())
+			}
+		}
+		var exports []*entity.FileUpload
+		err = dao.FileUpload.Ctx(ctx).
+			Where(dao.FileUpload.Columns().MerchantId, merchantId).
+			Where(dao.FileUpload.Columns().UserId, strconv.FormatUint(userId, 10)).
+			Where(dao.FileUpload.Columns().Tag, oss.TagGdprExport).
+			FieldsEx(dao.FileUpload.Columns().Data).
+			Scan(&exports)
+		if err != nil {
+			g.Log().Errorf(ctx, "AnonymizeUser load data exports userId:%d error:%s", userId, err.Error())
+			err = nil
+		}
+		deleteUserDataExports(ctx, exports)
+		_, _ = redismq.Send(&redismq.Message{
+			Topic:      redismq2.TopicUserAccountUpdate.Topic,
+			Tag:        redismq2.TopicUserAccountUpdate.Tag,
+			Body:       fmt.Sprintf("%d", userId),
+			CustomData: map[string]interface{}{"CreateFrom": utility.ReflectCurrentFunctionName()},
+		})
+	}
+	return err
+}
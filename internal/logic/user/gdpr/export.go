@@ -0,0 +1,251 @@
+package gdpr
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/operation_log"
+	"unibee/internal/logic/oss"
+	"unibee/internal/logic/oss/storage"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+type UserDataExportInternalReq struct {
+	MerchantId uint64 `json:"merchantId"`
+	UserId     uint64 `json:"userId"`
+	ByUser     bool   `json:"byUser"`
+}
+
+type UserDataExportResult struct {
+	FileName    string `json:"fileName"`
+	DownloadUrl string `json:"downloadUrl"`
+}
+
+// UserDataArchive holds everything stored about the user, each field written as one json file of the archive
+type UserDataArchive struct {
+	Profile            *entity.UserAccount            `json:"profile"`
+	Subscriptions      []*entity.Subscription         `json:"subscriptions"`
+	Invoices           []*entity.Invoice              `json:"invoices"`
+	Payments           []*entity.Payment              `json:"payments"`
+	Refunds            []*entity.Refund               `json:"refunds"`
+	CreditAccounts     []*entity.CreditAccount        `json:"creditAccounts"`
+	CreditTransactions []*entity.CreditTransaction    `json:"creditTransactions"`
+	EmailHistories     []*entity.MerchantEmailHistory `json:"emailHistories"`
+	MetricEvents       []*entity.MerchantMetricEvent  `json:"metricEvents"`
+}
+
+func LoadUserDataArchive(ctx context.Context, merchantId uint64, userId uint64) (*UserDataArchive, error) {
+	user := query.GetUserAccountById(ctx, userId)
+	if user == nil || user.MerchantId != merchantId {
+		return nil, gerror.New("user not found")
+	}
+	// credentials are not personal data of user, never exported
+	user.Password = ""
+	archive := &UserDataArchive{Profile: user}
+	err := dao.Subscription.Ctx(ctx).
+		Where(dao.Subscription.Columns().MerchantId, merchantId).
+		Where(dao.Subscription.Columns().UserId, userId).
+		OrderAsc(dao.Subscription.Columns().Id).
+		Scan(&archive.Subscriptions)
+	if err != nil {
+		return nil, err
+	}
+	err = dao.Invoice.Ctx(ctx).
+		Where(dao.Invoice.Columns().MerchantId, merchantId).
+		Where(dao.Invoice.Columns().UserId, userId).
+		OrderAsc(dao.Invoice.Columns().Id).
+		Scan(&archive.Invoices)
+	if err != nil {
+		return nil, err
+	}
+	err = dao.Payment.Ctx(ctx).
+		Where(dao.Payment.Columns().MerchantId, merchantId).
+		Where(dao.Payment.Columns().UserId, userId).
+		OrderAsc(dao.Payment.Columns().Id).
+		Scan(&archive.Payments)
+	if err != nil {
+		return nil, err
+	}
+	for _, one := range archive.Payments {
+		one.Token = ""
+		one.Verify = ""
+	}
+	err = dao.Refund.Ctx(ctx).
+		Where(dao.Refund.Columns().MerchantId, merchantId).
+		Where(dao.Refund.Columns().UserId, userId).
+		OrderAsc(dao.Refund.Columns().Id).
+		Scan(&archive.Refunds)
+	if err != nil {
+		return nil, err
+	}
+	err = dao.CreditAccount.Ctx(ctx).
+		Where(dao.CreditAccount.Columns().MerchantId, merchantId).
+		Where(dao.CreditAccount.Columns().UserId, userId).
+		OrderAsc(dao.CreditAccount.Columns().Id).
+		Scan(&archive.CreditAccounts)
+	if err != nil {
+		return nil, err
+	}
+	err = dao.CreditTransaction.Ctx(ctx).
+		Where(dao.CreditTransaction.Columns().MerchantId, merchantId).
+		Where(dao.CreditTransaction.Columns().UserId, userId).
+		OrderAsc(dao.CreditTransaction.Columns().Id).
+		Scan(&archive.CreditTransactions)
+	if err != nil {
+		return nil, err
+	}
+	if len(user.Email) > 0 {
+		err = dao.MerchantEmailHistory.Ctx(ctx).
+			Where(dao.MerchantEmailHistory.Columns().MerchantId, merchantId).
+			Where(dao.MerchantEmailHistory.Columns().Email, user.Email).
+			OrderAsc(dao.MerchantEmailHistory.Columns().Id).
+			Scan(&archive.EmailHistories)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = dao.MerchantMetricEvent.Ctx(ctx).
+		Where(dao.MerchantMetricEvent.Columns().MerchantId, merchantId).
+		Where(dao.MerchantMetricEvent.Columns().UserId, userId).
+		OrderAsc(dao.MerchantMetricEvent.Columns().Id).
+		Scan(&archive.MetricEvents)
+	if err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// ArchiveFiles maps the archive to the json files of the zip, file name as key
+func ArchiveFiles(archive *UserDataArchive) map[string]string {
+	return map[string]string{
+		"profile.json":             utility.FormatToJsonString(archive.Profile),
+		"subscriptions.json":       utility.FormatToJsonString(nonNil(archive.Subscriptions)),
+		"invoices.json":            utility.FormatToJsonString(nonNil(archive.Invoices)),
+		"payments.json":            utility.FormatToJsonString(nonNil(archive.Payments)),
+		"refunds.json":             utility.FormatToJsonString(nonNil(archive.Refunds)),
+		"credit_accounts.json":     utility.FormatToJsonString(nonNil(archive.CreditAccounts)),
+		"credit_transactions.json": utility.FormatToJsonString(nonNil(archive.CreditTransactions)),
+		"email_histories.json":     utility.FormatToJsonString(nonNil(archive.EmailHistories)),
+		"metric_events.json":       utility.FormatToJsonString(nonNil(archive.MetricEvents)),
+	}
+}
+
+func nonNil[T any](list []T) []T {
+	if list == nil {
+		return make([]T, 0)
+	}
+	return list
+}
+
+func sortedKeys(files map[string]string) []string {
+	var keys = make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeArchiveZip(filePath string, files map[string]string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	writer := zip.NewWriter(file)
+	for _, name := range sortedKeys(files) {
+		w, err := writer.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err = w.Write([]byte(files[name])); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+func ExportUserData(ctx context.Context, req *UserDataExportInternalReq) (result *UserDataExportResult, err error) {
+	utility.Assert(req.MerchantId > 0, "invalid merchantId")
+	utility.Assert(req.UserId > 0, "invalid userId")
+	archive, err := LoadUserDataArchive(ctx, req.MerchantId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	// the archive only downloadable by the signed url, and deleted by ExpireUserDataExports after the url expired
+	fileName := fmt.Sprintf("user_data_%d_%s_%s.zip", req.UserId, gtime.Now().Format("YmdHis"), strings.ToLower(grand.S(16)))
+	err = writeArchiveZip(fileName, ArchiveFiles(archive))
+	defer func() {
+		_ = os.Remove(fileName)
+	}()
+	if err == nil {
+		var upload *oss.FileUploadOutput
+		upload, err = oss.UploadLocalFile(ctx, fileName, oss.TagGdprExport, fileName, strconv.FormatUint(req.UserId, 10), req.MerchantId)
+		if err == nil {
+			var signedUrl string
+			signedUrl, err = oss.GetFileSignedUrl(ctx, query.GetOssFileByFileName(ctx, upload.Name))
+			if err == nil {
+				result = &UserDataExportResult{FileName: upload.Name, DownloadUrl: signedUrl}
+			} else {
+				_ = oss.DeleteFile(ctx, query.GetOssFileByFileName(ctx, upload.Name))
+			}
+		}
+	}
+	content := "ExportData"
+	if req.ByUser {
+		content = "ExportDataByUser"
+	}
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     req.MerchantId,
+		Target:         fmt.Sprintf("User(%v)", req.UserId),
+		Content:        content,
+		UserId:         req.UserId,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ExpireUserDataExports deletes the user data archives whose signed url expired
+func ExpireUserDataExports(ctx context.Context) {
+	var list []*entity.FileUpload
+	err := dao.FileUpload.Ctx(ctx).
+		Where(dao.FileUpload.Columns().Tag, oss.TagGdprExport).
+		WhereLT(dao.FileUpload.Columns().CreateTime, gtime.Now().Timestamp()-int64(storage.SignedUrlExpire().Seconds())).
+		FieldsEx(dao.FileUpload.Columns().Data).
+		Limit(0, 100).
+		Scan(&list)
+	if err != nil {
+		g.Log().Errorf(ctx, "ExpireUserDataExports error:%s", err.Error())
+		return
+	}
+	deleteUserDataExports(ctx, list)
+}
+
+// deleteUserDataExports deletes the user data archives, the one failed left to next round
+func deleteUserDataExports(ctx context.Context, list []*entity.FileUpload) {
+	for _, one := range list {
+		if err := oss.DeleteFile(ctx, one); err != nil {
+			g.Log().Errorf(ctx, "deleteUserDataExports fileName:%s error:%s", one.FileName, err.Error())
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package gdpr
+
+import (
+	"strings"
+	"testing"
+	"unibee/api/bean"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGdpr(t *testing.T) {
+	t.Run("Test for anonymize user snapshot", func(t *testing.T) {
+		data := utility.MarshalToJsonString(&entity.UserAccount{
+			Email:         "user@example.com",
+			FirstName:     "Jane",
+			LastName:      "Doe",
+			Address:       "1 Main Street",
+			Phone:         "123456",
+			CountryCode:   "DE",
+			TaxPercentage: 1900,
+		})
+		result := AnonymizeUserSnapshot(10, data)
+		require.False(t, strings.Contains(result, "user@example.com"))
+		require.False(t, strings.Contains(result, "Doe"))
+		require.False(t, strings.Contains(result, "Main Street"))
+		var snapshot *entity.UserAccount
+		require.Nil(t, utility.UnmarshalFromJsonString(result, &snapshot))
+		require.Equal(t, AnonymizedEmail(10), snapshot.Email)
+		require.Equal(t, "DE", snapshot.CountryCode)
+		require.Equal(t, int64(1900), snapshot.TaxPercentage)
+		require.Equal(t, "", AnonymizeUserSnapshot(10, ""))
+	})
+	t.Run("Test for anonymize payment invoice data", func(t *testing.T) {
+		data := utility.MarshalToJsonString(&bean.Invoice{
+			InvoiceId:   "iv1",
+			TotalAmount: 1000,
+			VatNumber:   "DE123456789",
+			CountryCode: "DE",
+			Data:        utility.MarshalToJsonString(&entity.UserAccount{Email: "user@example.com", CountryCode: "DE"}),
+		})
+		result := AnonymizePaymentInvoiceData(10, data)
+		require.False(t, strings.Contains(result, "DE123456789"))
+		require.False(t, strings.Contains(result, "user@example.com"))
+		var invoice *bean.Invoice
+		require.Nil(t, utility.UnmarshalFromJsonString(result, &invoice))
+		require.Equal(t, "iv1", invoice.InvoiceId)
+		require.Equal(t, int64(1000), invoice.TotalAmount)
+		require.Equal(t, "DE", invoice.CountryCode)
+		require.Equal(t, "", AnonymizePaymentInvoiceData(10, ""))
+		require.Equal(t, "", AnonymizePaymentInvoiceData(10, "invalid"))
+	})
+	t.Run("Test for archive files", func(t *testing.T) {
+		files := ArchiveFiles(&UserDataArchive{Profile: &entity.UserAccount{Id: 10}})
+		require.Equal(t, 9, len(files))
+		require.Equal(t, "[]", files["invoices.json"])
+		require.Equal(t, "profile.json", sortedKeys(files)[6])
+	})
+}
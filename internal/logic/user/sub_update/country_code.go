@@ -23,6 +23,19 @@ func UpdateUserTaxPercentageOnly(ctx context.Context, userId uint64, taxPercenta
 	}).Where(dao.UserAccount.Columns().Id, userId).Update()
 }
 
+// RefreshUserTaxPercentage recomputes the tax percentage of user after the billing address or exemption changed
+func RefreshUserTaxPercentage(ctx context.Context, userId uint64) {
+	user := query.GetUserAccountById(ctx, userId)
+	if user == nil || len(user.CountryCode) == 0 || !vat_gateway.GetDefaultVatGateway(ctx, user.MerchantId).VatRatesEnabled() {
+		return
+	}
+	gatewayId, _ := strconv.ParseUint(user.GatewayId, 10, 64)
+	taxPercentage, _ := vat_gateway.ComputeUserTaxPercentage(ctx, user.MerchantId, user, user.CountryCode, gatewayId, user.VATNumber, 0)
+	if taxPercentage != user.TaxPercentage {
+		UpdateUserTaxPercentageOnly(ctx, user.Id, taxPercentage)
+	}
+}
+
 func UpdateUserCountryCode(ctx context.Context, userId uint64, countryCode string) {
 	utility.Assert(userId > 0, "userId is nil")
 	user := query.GetUserAccountById(ctx, userId)
@@ -35,7 +48,7 @@ func UpdateUserCountryCode(ctx context.Context, userId uint64, countryCode strin
 		countryName := user.CountryName
 		gatewayId, _ := strconv.ParseUint(user.GatewayId, 10, 64)
 		if vat_gateway.GetDefaultVatGateway(ctx, user.MerchantId).VatRatesEnabled() {
-			taxPercentage, countryName = vat_gateway.ComputeMerchantVatPercentage(ctx, user.MerchantId, countryCode, gatewayId, user.VATNumber)
+			taxPercentage, countryName = vat_gateway.ComputeUserTaxPercentage(ctx, user.MerchantId, user, countryCode, gatewayId, user.VATNumber, 0)
 		} else {
 			countryOne, _ := vat_gateway.QueryVatCountryRateByMerchant(ctx, user.MerchantId, countryCode)
 			if countryOne != nil {
@@ -224,21 +224,6 @@ func CreateUser(ctx context.Context, req *NewUserInternalReq) (one *entity.UserA
 	emailOne := query.GetUserAccountByEmail(ctx, req.MerchantId, req.Email)
 	utility.Assert(emailOne == nil, "email exist")
 
-	var taxPercentage int64 = 0
-	var countryName = ""
-	if len(req.CountryCode) > 0 && vat_gateway.GetDefaultVatGateway(ctx, req.MerchantId).VatRatesEnabled() {
-		//utility.Assert(vat_gateway.GetDefaultVatGateway(ctx, req.MerchantId).VatRatesEnabled(), "vat gateway need setup while countryCode is not blank")
-		if len(req.VATNumber) > 0 {
-			vatNumberValidate, err := vat_gateway.ValidateVatNumberByDefaultGateway(ctx, _interface.GetMerchantId(ctx), 0, req.VATNumber, "")
-			utility.AssertError(err, "Validate vatNumber error")
-			utility.Assert(vatNumberValidate.Valid, i18n.LocalizationFormat(ctx, "{#VatValidateError}", req.VATNumber))
-			utility.Assert(req.CountryCode == vatNumberValidate.CountryCode, i18n.LocalizationFormat(ctx, "{#CountryCodeVatNumberNotMatch}", vatNumberValidate.CountryCode))
-		}
-		taxPercentage, countryName = vat_gateway.ComputeMerchantVatPercentage(ctx, req.MerchantId, req.CountryCode, 0, req.VATNumber)
-	} else if len(req.VATNumber) > 0 {
-		utility.Assert(false, "countryCode is blank while vatNumber provided")
-	}
-
 	one = &entity.UserAccount{
 		FirstName:          req.FirstName,
 		LastName:           req.LastName,
@@ -249,7 +234,7 @@ func CreateUser(ctx context.Context, req *NewUserInternalReq) (one *entity.UserA
 		ExternalUserId:     req.ExternalUserId,
 		CountryCode:        req.CountryCode,
 		ReMark:             req.State,
-		CountryName:        countryName,
+		State:              req.State,
 		UserName:           req.UserName,
 		MerchantId:         req.MerchantId,
 		Type:               req.Type,
@@ -258,11 +243,24 @@ func CreateUser(ctx context.Context, req *NewUserInternalReq) (one *entity.UserA
 		City:               req.City,
 		ZipCode:            req.ZipCode,
 		Custom:             req.Custom,
-		TaxPercentage:      taxPercentage,
 		Language:           req.Language,
 		RegistrationNumber: req.RegistrationNumber,
 		CreateTime:         gtime.Now().Timestamp(),
 	}
+	if len(req.CountryCode) > 0 && vat_gateway.GetDefaultVatGateway(ctx, req.MerchantId).VatRatesEnabled() {
+		//utility.Assert(vat_gateway.GetDefaultVatGateway(ctx, req.MerchantId).VatRatesEnabled(), "vat gateway need setup while countryCode is not blank")
+		if len(req.VATNumber) > 0 {
+			vatNumberValidate, err := vat_gateway.ValidateVatNumberByDefaultGateway(ctx, _interface.GetMerchantId(ctx), 0, req.VATNumber, "")
+			utility.AssertError(err, "Validate vatNumber error")
+			utility.Assert(vatNumberValidate.Valid, i18n.LocalizationFormat(ctx, "{#VatValidateError}", req.VATNumber))
+			utility.Assert(req.CountryCode == vatNumberValidate.CountryCode, i18n.LocalizationFormat(ctx, "{#CountryCodeVatNumberNotMatch}", vatNumberValidate.CountryCode))
+		}
+		// the state and zip of user resolve the sales tax of us and ca
+		one.TaxPercentage, one.CountryName = vat_gateway.ComputeUserTaxPercentage(ctx, req.MerchantId, one, req.CountryCode, 0, req.VATNumber, 0)
+	} else if len(req.VATNumber) > 0 {
+		utility.Assert(false, "countryCode is blank while vatNumber provided")
+	}
+
 	result, err := dao.UserAccount.Ctx(ctx).Data(one).OmitNil().Insert(one)
 	utility.AssertError(err, "Server Error")
 	id, err := result.LastInsertId()
@@ -356,6 +354,7 @@ func QueryOrCreateUser(ctx context.Context, req *NewUserInternalReq) (one *entit
 			dao.UserAccount.Columns().Address:            req.Address,
 			dao.UserAccount.Columns().CompanyName:        req.CompanyName,
 			dao.UserAccount.Columns().ReMark:             req.State,
+			dao.UserAccount.Columns().State:              req.State,
 			dao.UserAccount.Columns().RegistrationNumber: req.RegistrationNumber,
 			dao.UserAccount.Columns().GmtModify:          gtime.Now(),
 		}).Where(dao.UserAccount.Columns().Id, one.Id).OmitEmpty().Update()
@@ -8,18 +8,25 @@ import (
 	"strconv"
 	dao "unibee/internal/dao/default"
 	"unibee/internal/logic/vat_gateway"
+	"unibee/internal/logic/vat_gateway/sales_tax"
 	"unibee/internal/query"
 	"unibee/utility"
 )
 
 func GetUserTaxPercentage(ctx context.Context, userId uint64) (taxPercentage int64, countryCode string, vatNumber string, err error) {
+	return GetUserPlanTaxPercentage(ctx, userId, 0)
+}
+
+// GetUserPlanTaxPercentage returns the tax percentage of user for the plan, the tax code of plan product used by sales tax engine,
+// only the percentage of general tax code saved to user
+func GetUserPlanTaxPercentage(ctx context.Context, userId uint64, planId uint64) (taxPercentage int64, countryCode string, vatNumber string, err error) {
 	utility.Assert(userId > 0, "userId is nil")
 	user := query.GetUserAccountById(ctx, userId)
 	utility.Assert(user != nil, fmt.Sprintf("GetUserCountryCode user not found:%v", userId))
 	gatewayId, _ := strconv.ParseUint(user.GatewayId, 10, 64)
 	if vat_gateway.GetDefaultVatGateway(ctx, user.MerchantId).VatRatesEnabled() {
-		taxPercentage, _ = vat_gateway.ComputeMerchantVatPercentage(ctx, user.MerchantId, user.CountryCode, gatewayId, user.VATNumber)
-		if taxPercentage != user.TaxPercentage && taxPercentage > 0 {
+		taxPercentage, _ = vat_gateway.ComputeUserTaxPercentage(ctx, user.MerchantId, user, user.CountryCode, gatewayId, user.VATNumber, planId)
+		if taxPercentage != user.TaxPercentage && taxPercentage > 0 && vat_gateway.ProductTaxCode(ctx, user.MerchantId, planId) == sales_tax.TaxCodeGeneral {
 			_, _ = dao.UserAccount.Ctx(ctx).Data(g.Map{
 				dao.UserAccount.Columns().TaxPercentage: taxPercentage,
 				dao.UserAccount.Columns().GmtModify:     gtime.Now(),
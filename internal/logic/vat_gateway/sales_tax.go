@@ -0,0 +1,215 @@
+package vat_gateway
+
+import (
+	"context"
+	"strings"
+	"unibee/api/bean"
+	"unibee/internal/logic/merchant_config"
+	"unibee/internal/logic/vat_gateway/sales_tax"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+	"unibee/utility"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+const (
+	KeyMerchantSalesTaxConfig = "KEY_MERCHANT_SALES_TAX_CONFIG"
+	SALES_TAX_PROVIDER_NAMES  = "rate_table"
+)
+
+func GetMerchantSalesTaxConfig(ctx context.Context, merchantId uint64) *bean.SalesTaxConfig {
+	var one *bean.SalesTaxConfig
+	config := merchant_config.GetMerchantConfig(ctx, merchantId, KeyMerchantSalesTaxConfig)
+	if config != nil && len(config.ConfigValue) > 0 {
+		_ = utility.UnmarshalFromJsonString(config.ConfigValue, &one)
+	}
+	if one == nil {
+		one = &bean.SalesTaxConfig{}
+	}
+	if len(one.Provider) == 0 {
+		one.Provider = sales_tax.RateTableProviderName
+	}
+	if one.NexusRegions == nil {
+		one.NexusRegions = make([]string, 0)
+	}
+	if one.Rules == nil {
+		one.Rules = make([]*bean.SalesTaxRateRule, 0)
+	}
+	return one
+}
+
+func GetSalesTaxProvider(providerName string) sales_tax.Provider {
+	if len(providerName) == 0 || strings.Compare(providerName, sales_tax.RateTableProviderName) == 0 {
+		return &sales_tax.RateTable{Name: sales_tax.RateTableProviderName}
+	}
+	return nil
+}
+
+// ProductTaxCode returns the tax code of plan product, general if not set
+func ProductTaxCode(ctx context.Context, merchantId uint64, planId uint64) string {
+	if planId <= 0 {
+		return sales_tax.TaxCodeGeneral
+	}
+	plan := query.GetPlanById(ctx, planId)
+	if plan == nil {
+		return sales_tax.TaxCodeGeneral
+	}
+	product := query.GetProductById(ctx, uint64(plan.ProductId), merchantId)
+	if product == nil || len(product.TaxCode) == 0 {
+		return sales_tax.TaxCodeGeneral
+	}
+	return product.TaxCode
+}
+
+// ResolveUserSalesTax resolves tax by the billing address of user and the tax code of plan product,
+// nil returned if sales tax not enabled, the country not supported by provider or the address incomplete, vat rates used then
+func ResolveUserSalesTax(ctx context.Context, merchantId uint64, user *entity.UserAccount, countryCode string, planId uint64) *sales_tax.TaxResult {
+	return resolveUserSalesTaxByCode(ctx, merchantId, user, countryCode, ProductTaxCode(ctx, merchantId, planId))
+}
+
+func resolveUserSalesTaxByCode(ctx context.Context, merchantId uint64, user *entity.UserAccount, countryCode string, taxCode string) *sales_tax.TaxResult {
+	if user == nil || len(countryCode) == 0 {
+		return nil
+	}
+	config := GetMerchantSalesTaxConfig(ctx, merchantId)
+	if !config.Enable {
+		return nil
+	}
+	provider := GetSalesTaxProvider(config.Provider)
+	if provider == nil || !provider.SupportCountry(countryCode) {
+		return nil
+	}
+	if user.TaxExempt == 1 {
+		return &sales_tax.TaxResult{
+			Provider:            provider.GetProviderName(),
+			Components:          make([]*sales_tax.TaxComponent, 0),
+			Exempt:              true,
+			ExemptCertificateId: user.TaxExemptCertificateId,
+		}
+	}
+	result, err := provider.ResolveTax(ctx, &sales_tax.TaxResolveReq{
+		Address: &sales_tax.TaxAddress{
+			CountryCode: countryCode,
+			State:       user.State,
+			City:        user.City,
+			ZipCode:     user.ZipCode,
+		},
+		TaxCode: taxCode,
+		Rules:   config.Rules,
+	})
+	if err != nil {
+		g.Log().Infof(ctx, "ResolveUserSalesTax merchantId:%d userId:%d provider:%s err:%s", merchantId, user.Id, provider.GetProviderName(), err.Error())
+		return nil
+	}
+	if !sales_tax.InNexus(config.NexusRegions, countryCode, user.State) {
+		return &sales_tax.TaxResult{
+			Provider:   provider.GetProviderName(),
+			Components: make([]*sales_tax.TaxComponent, 0),
+		}
+	}
+	return result
+}
+
+// ComputeUserTaxPercentage resolves tax percentage by sales tax engine if applied, others by vat rates of country
+func ComputeUserTaxPercentage(ctx context.Context, merchantId uint64, user *entity.UserAccount, countryCode string, gatewayId uint64, validVatNumber string, planId uint64) (taxPercentage int64, countryName string) {
+	result := ResolveUserSalesTax(ctx, merchantId, user, countryCode, planId)
+	if result == nil {
+		return ComputeMerchantVatPercentage(ctx, merchantId, countryCode, gatewayId, validVatNumber)
+	}
+	vatCountryRate, _ := QueryVatCountryRateByMerchant(ctx, merchantId, countryCode)
+	if vatCountryRate != nil {
+		countryName = vatCountryRate.CountryName
+	}
+	return result.TaxPercentage, countryName
+}
+
+// CheckAddonSalesTaxPercentage returns error if the product tax code of any addon resolves to a sales tax percentage other than the plan,
+// one subscription invoice taxed by one percentage, the addon of other rate rejected instead of taxed by the percentage of plan
+func CheckAddonSalesTaxPercentage(ctx context.Context, merchantId uint64, user *entity.UserAccount, countryCode string, planId uint64, addonPlanIds []uint64) error {
+	planTaxCode := ProductTaxCode(ctx, merchantId, planId)
+	result := resolveUserSalesTaxByCode(ctx, merchantId, user, countryCode, planTaxCode)
+	if result == nil {
+		return nil
+	}
+	for _, addonPlanId := range addonPlanIds {
+		taxCode := ProductTaxCode(ctx, merchantId, addonPlanId)
+		if taxCode == planTaxCode {
+			continue
+		}
+		addonResult := resolveUserSalesTaxByCode(ctx, merchantId, user, countryCode, taxCode)
+		if addonResult != nil && addonResult.TaxPercentage != result.TaxPercentage {
+			return gerror.Newf("addon %d of tax code %s taxed at %d differs from %d of plan tax code %s", addonPlanId, taxCode, addonResult.TaxPercentage, result.TaxPercentage, planTaxCode)
+		}
+	}
+	return nil
+}
+
+// BuildInvoiceTaxLines returns the json of invoice tax lines split by jurisdiction, the tax code resolved by the product of each
+// invoice line, the lines without plan like metered charges follow the plan of invoice. Blank if sales tax not applied or the tax
+// percentage of any line differs from the one resolved
+func BuildInvoiceTaxLines(ctx context.Context, merchantId uint64, userId uint64, countryCode string, planId uint64, invoice *bean.Invoice) string {
+	if userId <= 0 || invoice == nil {
+		return ""
+	}
+	user := query.GetUserAccountById(ctx, userId)
+	var groups [][]*bean.InvoiceTaxLine
+	var taxCodeLines = make(map[string][]*bean.InvoiceItemSimplify)
+	var taxCodes []string
+	for _, line := range invoice.Lines {
+		if line == nil {
+			continue
+		}
+		linePlanId := planId
+		if line.Plan != nil && line.Plan.Id > 0 {
+			linePlanId = line.Plan.Id
+		}
+		taxCode := ProductTaxCode(ctx, merchantId, linePlanId)
+		if _, ok := taxCodeLines[taxCode]; !ok {
+			taxCodes = append(taxCodes, taxCode)
+		}
+		taxCodeLines[taxCode] = append(taxCodeLines[taxCode], line)
+	}
+	if len(taxCodes) == 0 {
+		result := resolveUserSalesTaxByCode(ctx, merchantId, user, countryCode, ProductTaxCode(ctx, merchantId, planId))
+		groups = append(groups, sales_tax.SplitTaxLines(result, invoice.TaxPercentage, invoice.TotalAmountExcludingTax, invoice.TaxAmount))
+	}
+	for _, taxCode := range taxCodes {
+		result := resolveUserSalesTaxByCode(ctx, merchantId, user, countryCode, taxCode)
+		var taxableAmount int64 = 0
+		var taxAmount int64 = 0
+		var taxPercentage = invoice.TaxPercentage
+		for _, line := range taxCodeLines[taxCode] {
+			taxableAmount = taxableAmount + line.AmountExcludingTax - line.DiscountAmount
+			taxAmount = taxAmount + line.Tax
+			taxPercentage = line.TaxPercentage
+		}
+		lines := sales_tax.SplitTaxLines(result, taxPercentage, taxableAmount, taxAmount)
+		if len(lines) == 0 {
+			return ""
+		}
+		groups = append(groups, lines)
+	}
+	lines := sales_tax.MergeTaxLines(groups...)
+	if len(lines) == 0 {
+		return ""
+	}
+	return utility.MarshalToJsonString(lines)
+}
+
+// InvoicePlanId returns the plan of invoice, the tax code of lines without plan resolved from, the plan of snapshot or the first line with plan
+func InvoicePlanId(invoice *bean.Invoice) uint64 {
+	if invoice == nil {
+		return 0
+	}
+	if invoice.PlanSnapshot != nil && invoice.PlanSnapshot.Plan != nil {
+		return invoice.PlanSnapshot.Plan.Id
+	}
+	for _, line := range invoice.Lines {
+		if line != nil && line.Plan != nil {
+			return line.Plan.Id
+		}
+	}
+	return 0
+}
@@ -0,0 +1,103 @@
+package sales_tax
+
+import (
+	"fmt"
+	"unibee/api/bean"
+)
+
+// SplitTaxLines splits the invoice tax to the lines by jurisdiction in proportion to the component rates, the remainder to the last line,
+// nil returned if the invoice tax percentage not resolved from the components
+func SplitTaxLines(result *TaxResult, taxPercentage int64, taxableAmount int64, taxAmount int64) []*bean.InvoiceTaxLine {
+	if result == nil {
+		return nil
+	}
+	if result.Exempt {
+		if taxPercentage != 0 {
+			return nil
+		}
+		return []*bean.InvoiceTaxLine{{
+			JurisdictionType:    JurisdictionCountry,
+			TaxName:             "Tax Exempt",
+			TaxPercentage:       0,
+			TaxableAmount:       taxableAmount,
+			TaxAmount:           0,
+			ExemptCertificateId: result.ExemptCertificateId,
+		}}
+	}
+	total := sumComponents(result.Components)
+	if len(result.Components) == 0 || total <= 0 || total != taxPercentage {
+		return nil
+	}
+	var lines = make([]*bean.InvoiceTaxLine, 0)
+	var leftTaxAmount = taxAmount
+	for i, one := range result.Components {
+		lineTaxAmount := leftTaxAmount
+		if i < len(result.Components)-1 {
+			lineTaxAmount = taxAmount * one.TaxPercentage / total
+			leftTaxAmount = leftTaxAmount - lineTaxAmount
+		}
+		lines = append(lines, &bean.InvoiceTaxLine{
+			Jurisdiction:     one.Jurisdiction,
+			JurisdictionType: one.JurisdictionType,
+			TaxName:          one.TaxName,
+			TaxPercentage:    one.TaxPercentage,
+			TaxableAmount:    taxableAmount,
+			TaxAmount:        lineTaxAmount,
+		})
+	}
+	return lines
+}
+
+// MergeTaxLines merges the tax lines of invoice lines split by tax code, the lines of the same jurisdiction and rate summed up
+func MergeTaxLines(groups ...[]*bean.InvoiceTaxLine) []*bean.InvoiceTaxLine {
+	var list = make([]*bean.InvoiceTaxLine, 0)
+	var merged = make(map[string]*bean.InvoiceTaxLine)
+	for _, group := range groups {
+		for _, one := range group {
+			key := fmt.Sprintf("%s|%s|%s|%d|%s", one.Jurisdiction, one.JurisdictionType, one.TaxName, one.TaxPercentage, one.ExemptCertificateId)
+			if target, ok := merged[key]; ok {
+				target.TaxableAmount = target.TaxableAmount + one.TaxableAmount
+				target.TaxAmount = target.TaxAmount + one.TaxAmount
+				continue
+			}
+			target := *one
+			merged[key] = &target
+			list = append(list, &target)
+		}
+	}
+	return list
+}
+
+// ScaleTaxLines returns the tax lines of credit note, the tax of original invoice lines scaled to the refund tax, the remainder to the last line
+func ScaleTaxLines(lines []*bean.InvoiceTaxLine, taxableAmount int64, taxAmount int64) []*bean.InvoiceTaxLine {
+	if len(lines) == 0 {
+		return nil
+	}
+	var originTaxAmount int64 = 0
+	for _, one := range lines {
+		originTaxAmount = originTaxAmount + one.TaxAmount
+	}
+	var list = make([]*bean.InvoiceTaxLine, 0)
+	var leftTaxAmount = taxAmount
+	for i, one := range lines {
+		lineTaxAmount := leftTaxAmount
+		if i < len(lines)-1 {
+			if originTaxAmount != 0 {
+				lineTaxAmount = taxAmount * one.TaxAmount / originTaxAmount
+			} else {
+				lineTaxAmount = 0
+			}
+			leftTaxAmount = leftTaxAmount - lineTaxAmount
+		}
+		list = append(list, &bean.InvoiceTaxLine{
+			Jurisdiction:        one.Jurisdiction,
+			JurisdictionType:    one.JurisdictionType,
+			TaxName:             one.TaxName,
+			TaxPercentage:       one.TaxPercentage,
+			TaxableAmount:       taxableAmount,
+			TaxAmount:           lineTaxAmount,
+			ExemptCertificateId: one.ExemptCertificateId,
+		})
+	}
+	return list
+}
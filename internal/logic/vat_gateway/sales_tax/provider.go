@@ -0,0 +1,77 @@
+package sales_tax
+
+import (
+	"context"
+	"strings"
+	"unibee/api/bean"
+)
+
+const (
+	TaxCodeGeneral = "general"
+	TaxCodeDigital = "digital"
+	TaxCodeExempt  = "exempt"
+	TAX_CODES      = "general|digital|exempt"
+
+	JurisdictionCountry = "country"
+	JurisdictionState   = "state"
+	JurisdictionLocal   = "local"
+)
+
+type Provider interface {
+	GetProviderName() string
+	SupportCountry(countryCode string) bool
+	ResolveTax(ctx context.Context, req *TaxResolveReq) (*TaxResult, error)
+}
+
+type TaxAddress struct {
+	CountryCode string `json:"countryCode"`
+	State       string `json:"state"`
+	City        string `json:"city"`
+	ZipCode     string `json:"zipCode"`
+}
+
+type TaxResolveReq struct {
+	Address *TaxAddress              `json:"address"`
+	TaxCode string                   `json:"taxCode"`
+	Rules   []*bean.SalesTaxRateRule `json:"rules"`
+}
+
+type TaxComponent struct {
+	Jurisdiction     string `json:"jurisdiction"`
+	JurisdictionType string `json:"jurisdictionType"`
+	TaxName          string `json:"taxName"`
+	TaxPercentage    int64  `json:"taxPercentage"`
+}
+
+type TaxResult struct {
+	Provider            string          `json:"provider"`
+	TaxPercentage       int64           `json:"taxPercentage"`
+	Components          []*TaxComponent `json:"components"`
+	Exempt              bool            `json:"exempt"`
+	ExemptCertificateId string          `json:"exemptCertificateId"`
+}
+
+func IsValidTaxCode(taxCode string) bool {
+	return len(taxCode) == 0 || taxCode == TaxCodeGeneral || taxCode == TaxCodeDigital || taxCode == TaxCodeExempt
+}
+
+// InNexus reports whether merchant collects tax in the region, no region collected until the nexus regions set up
+func InNexus(nexusRegions []string, countryCode string, state string) bool {
+	countryCode = strings.ToUpper(strings.TrimSpace(countryCode))
+	state = strings.ToUpper(strings.TrimSpace(state))
+	for _, region := range nexusRegions {
+		region = strings.ToUpper(strings.TrimSpace(region))
+		if region == countryCode || (len(state) > 0 && region == countryCode+"-"+state) {
+			return true
+		}
+	}
+	return false
+}
+
+func sumComponents(components []*TaxComponent) int64 {
+	var total int64 = 0
+	for _, one := range components {
+		total = total + one.TaxPercentage
+	}
+	return total
+}
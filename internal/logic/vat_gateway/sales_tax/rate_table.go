@@ -0,0 +1,210 @@
+package sales_tax
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unibee/api/bean"
+)
+
+const RateTableProviderName = "rate_table"
+
+// usStateRates is the base state sales tax, 725 = 7.25%, the fractional rates rounded to basis point
+var usStateRates = map[string]int64{
+	"AL": 400, "AK": 0, "AZ": 560, "AR": 650, "CA": 725, "CO": 290, "CT": 635, "DE": 0, "DC": 600, "FL": 600,
+	"GA": 400, "HI": 400, "ID": 600, "IL": 625, "IN": 700, "IA": 600, "KS": 650, "KY": 600, "LA": 500, "ME": 550,
+	"MD": 600, "MA": 625, "MI": 600, "MN": 688, "MS": 700, "MO": 423, "MT": 0, "NE": 550, "NV": 685, "NH": 0,
+	"NJ": 663, "NM": 488, "NY": 400, "NC": 475, "ND": 500, "OH": 575, "OK": 450, "OR": 0, "PA": 600, "RI": 700,
+	"SC": 600, "SD": 420, "TN": 700, "TX": 625, "UT": 610, "VT": 600, "VA": 530, "WA": 650, "WV": 600, "WI": 500,
+	"WY": 400,
+}
+
+// usDigitalTaxableStates taxes digital goods and saas at the state rate, others not taxed
+var usDigitalTaxableStates = map[string]bool{
+	"AZ": true, "CT": true, "DC": true, "HI": true, "IA": true, "KY": true, "LA": true, "MA": true, "MD": true, "MS": true, "NM": true,
+	"NY": true, "OH": true, "PA": true, "RI": true, "SC": true, "SD": true, "TN": true, "TX": true, "UT": true, "WA": true, "WV": true,
+}
+
+// usDigitalReducedRates is the reduced rate of digital goods and saas
+var usDigitalReducedRates = map[string]int64{"CT": 100, "MD": 300, "TX": 500}
+
+const caGSTRate int64 = 500
+
+// caHSTRates is the harmonized sales tax replaces gst
+var caHSTRates = map[string]int64{"ON": 1300, "NS": 1400, "NB": 1500, "NL": 1500, "PE": 1500}
+
+// caGSTOnlyProvinces is the provinces and territories not harmonized
+var caGSTOnlyProvinces = map[string]bool{"AB": true, "BC": true, "MB": true, "NT": true, "NU": true, "QC": true, "SK": true, "YT": true}
+
+// caProvincialRates is the provincial tax added to gst
+var caProvincialRates = map[string]*TaxComponent{
+	"BC": {TaxName: "PST", TaxPercentage: 700},
+	"SK": {TaxName: "PST", TaxPercentage: 600},
+	"MB": {TaxName: "RST", TaxPercentage: 700},
+	"QC": {TaxName: "QST", TaxPercentage: 998},
+}
+
+// RateTable resolves tax offline by the build-in rates of US and Canada and the custom rules of merchant
+type RateTable struct {
+	Name string
+}
+
+func (r *RateTable) GetProviderName() string {
+	return r.Name
+}
+
+func (r *RateTable) SupportCountry(countryCode string) bool {
+	countryCode = strings.ToUpper(countryCode)
+	return countryCode == "US" || countryCode == "CA"
+}
+
+func (r *RateTable) ResolveTax(ctx context.Context, req *TaxResolveReq) (*TaxResult, error) {
+	if req == nil || req.Address == nil {
+		return nil, fmt.Errorf("invalid address")
+	}
+	countryCode := strings.ToUpper(strings.TrimSpace(req.Address.CountryCode))
+	state := strings.ToUpper(strings.TrimSpace(req.Address.State))
+	if !r.SupportCountry(countryCode) {
+		return nil, fmt.Errorf("country not supported:%s", countryCode)
+	}
+	if len(state) == 0 {
+		return nil, fmt.Errorf("state required for country:%s", countryCode)
+	}
+	taxCode := req.TaxCode
+	if len(taxCode) == 0 {
+		taxCode = TaxCodeGeneral
+	}
+	result := &TaxResult{Provider: r.Name, Components: make([]*TaxComponent, 0)}
+	if taxCode == TaxCodeExempt {
+		return result, nil
+	}
+	var components []*TaxComponent
+	var err error
+	if countryCode == "US" {
+		components, err = usComponents(state, taxCode)
+	} else {
+		components, err = caComponents(state)
+	}
+	if err != nil {
+		return nil, err
+	}
+	components = applyRules(components, req.Rules, countryCode, state, req.Address.ZipCode, taxCode)
+	for _, one := range components {
+		if one.TaxPercentage > 0 {
+			result.Components = append(result.Components, one)
+		}
+	}
+	result.TaxPercentage = sumComponents(result.Components)
+	return result, nil
+}
+
+func usComponents(state string, taxCode string) ([]*TaxComponent, error) {
+	rate, ok := usStateRates[state]
+	if !ok {
+		return nil, fmt.Errorf("unknown state:US-%s", state)
+	}
+	if taxCode == TaxCodeDigital {
+		if !usDigitalTaxableStates[state] {
+			rate = 0
+		} else if reduced, ok := usDigitalReducedRates[state]; ok {
+			rate = reduced
+		}
+	}
+	return []*TaxComponent{{
+		Jurisdiction:     "US-" + state,
+		JurisdictionType: JurisdictionState,
+		TaxName:          "State Sales Tax",
+		TaxPercentage:    rate,
+	}}, nil
+}
+
+func caComponents(province string) ([]*TaxComponent, error) {
+	if rate, ok := caHSTRates[province]; ok {
+		return []*TaxComponent{{
+			Jurisdiction:     "CA-" + province,
+			JurisdictionType: JurisdictionState,
+			TaxName:          "HST",
+			TaxPercentage:    rate,
+		}}, nil
+	}
+	if _, ok := caGSTOnlyProvinces[province]; !ok {
+		return nil, fmt.Errorf("unknown province:CA-%s", province)
+	}
+	components := []*TaxComponent{{
+		Jurisdiction:     "CA",
+		JurisdictionType: JurisdictionCountry,
+		TaxName:          "GST",
+		TaxPercentage:    caGSTRate,
+	}}
+	if provincial, ok := caProvincialRates[province]; ok {
+		components = append(components, &TaxComponent{
+			Jurisdiction:     "CA-" + province,
+			JurisdictionType: JurisdictionState,
+			TaxName:          provincial.TaxName,
+			TaxPercentage:    provincial.TaxPercentage,
+		})
+	}
+	return components, nil
+}
+
+// applyRules replaces the state component by the state rule of merchant and adds the local tax of the longest zip prefix rule
+func applyRules(components []*TaxComponent, rules []*bean.SalesTaxRateRule, countryCode string, state string, zipCode string, taxCode string) []*TaxComponent {
+	var stateRule *bean.SalesTaxRateRule
+	var zipRule *bean.SalesTaxRateRule
+	for _, rule := range rules {
+		if rule == nil || !strings.EqualFold(rule.CountryCode, countryCode) || !strings.EqualFold(rule.State, state) {
+			continue
+		}
+		if len(rule.TaxCode) > 0 && rule.TaxCode != taxCode {
+			continue
+		}
+		if len(rule.ZipPrefix) == 0 {
+			if stateRule == nil || len(rule.TaxCode) > 0 {
+				stateRule = rule
+			}
+		} else if strings.HasPrefix(zipCode, rule.ZipPrefix) {
+			if zipRule == nil || len(rule.ZipPrefix) > len(zipRule.ZipPrefix) {
+				zipRule = rule
+			}
+		}
+	}
+	jurisdiction := countryCode + "-" + state
+	if stateRule != nil {
+		var list = make([]*TaxComponent, 0)
+		for _, one := range components {
+			if one.JurisdictionType != JurisdictionState {
+				list = append(list, one)
+			}
+		}
+		list = append(list, &TaxComponent{
+			Jurisdiction:     jurisdiction,
+			JurisdictionType: ruleJurisdictionType(stateRule, JurisdictionState),
+			TaxName:          ruleTaxName(stateRule, "State Sales Tax"),
+			TaxPercentage:    stateRule.TaxPercentage,
+		})
+		components = list
+	}
+	if zipRule != nil {
+		components = append(components, &TaxComponent{
+			Jurisdiction:     jurisdiction + "-" + zipRule.ZipPrefix,
+			JurisdictionType: ruleJurisdictionType(zipRule, JurisdictionLocal),
+			TaxName:          ruleTaxName(zipRule, "Local Sales Tax"),
+			TaxPercentage:    zipRule.TaxPercentage,
+		})
+	}
+	return components
+}
+
+func ruleJurisdictionType(rule *bean.SalesTaxRateRule, defaultType string) string {
+	if len(rule.JurisdictionType) > 0 {
+		return rule.JurisdictionType
+	}
+	return defaultType
+}
+
+func ruleTaxName(rule *bean.SalesTaxRateRule, defaultName string) string {
+	if len(rule.TaxName) > 0 {
+		return rule.TaxName
+	}
+	return defaultName
+}
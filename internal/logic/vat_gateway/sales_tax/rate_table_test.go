@@ -0,0 +1,107 @@
+package sales_tax
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"unibee/api/bean"
+)
+
+func TestRateTable(t *testing.T) {
+	ctx := context.Background()
+	table := &RateTable{Name: RateTableProviderName}
+	t.Run("Test for US State Rate", func(t *testing.T) {
+		result, err := table.ResolveTax(ctx, &TaxResolveReq{Address: &TaxAddress{CountryCode: "us", State: "ca"}})
+		require.Nil(t, err)
+		require.Equal(t, int64(725), result.TaxPercentage)
+		require.Equal(t, "US-CA", result.Components[0].Jurisdiction)
+		result, err = table.ResolveTax(ctx, &TaxResolveReq{Address: &TaxAddress{CountryCode: "US", State: "OR"}})
+		require.Nil(t, err)
+		require.Equal(t, int64(0), result.TaxPercentage)
+		require.Equal(t, 0, len(result.Components))
+		_, err = table.ResolveTax(ctx, &TaxResolveReq{Address: &TaxAddress{CountryCode: "US"}})
+		require.NotNil(t, err)
+		_, err = table.ResolveTax(ctx, &TaxResolveReq{Address: &TaxAddress{CountryCode: "DE", State: "BY"}})
+		require.NotNil(t, err)
+	})
+	t.Run("Test for US Digital Rate", func(t *testing.T) {
+		result, err := table.ResolveTax(ctx, &TaxResolveReq{Address: &TaxAddress{CountryCode: "US", State: "CA"}, TaxCode: TaxCodeDigital})
+		require.Nil(t, err)
+		require.Equal(t, int64(0), result.TaxPercentage)
+		result, err = table.ResolveTax(ctx, &TaxResolveReq{Address: &TaxAddress{CountryCode: "US", State: "TX"}, TaxCode: TaxCodeDigital})
+		require.Nil(t, err)
+		require.Equal(t, int64(500), result.TaxPercentage)
+		result, err = table.ResolveTax(ctx, &TaxResolveReq{Address: &TaxAddress{CountryCode: "US", State: "NY"}, TaxCode: TaxCodeExempt})
+		require.Nil(t, err)
+		require.Equal(t, int64(0), result.TaxPercentage)
+	})
+	t.Run("Test for Canada Rate", func(t *testing.T) {
+		result, err := table.ResolveTax(ctx, &TaxResolveReq{Address: &TaxAddress{CountryCode: "CA", State: "ON"}})
+		require.Nil(t, err)
+		require.Equal(t, int64(1300), result.TaxPercentage)
+		require.Equal(t, "HST", result.Components[0].TaxName)
+		result, err = table.ResolveTax(ctx, &TaxResolveReq{Address: &TaxAddress{CountryCode: "CA", State: "QC"}})
+		require.Nil(t, err)
+		require.Equal(t, int64(1498), result.TaxPercentage)
+		require.Equal(t, 2, len(result.Components))
+		require.Equal(t, "GST", result.Components[0].TaxName)
+		require.Equal(t, "QST", result.Components[1].TaxName)
+		result, err = table.ResolveTax(ctx, &TaxResolveReq{Address: &TaxAddress{CountryCode: "CA", State: "AB"}})
+		require.Nil(t, err)
+		require.Equal(t, int64(500), result.TaxPercentage)
+	})
+	t.Run("Test for Merchant Rules", func(t *testing.T) {
+		rules := []*bean.SalesTaxRateRule{
+			{CountryCode: "US", State: "CA", TaxPercentage: 700},
+			{CountryCode: "US", State: "CA", ZipPrefix: "9", TaxName: "District Tax", TaxPercentage: 100},
+			{CountryCode: "US", State: "CA", ZipPrefix: "900", TaxName: "Los Angeles Tax", TaxPercentage: 250},
+		}
+		result, err := table.ResolveTax(ctx, &TaxResolveReq{Address: &TaxAddress{CountryCode: "US", State: "CA", ZipCode: "90012"}, Rules: rules})
+		require.Nil(t, err)
+		require.Equal(t, int64(950), result.TaxPercentage)
+		require.Equal(t, 2, len(result.Components))
+		require.Equal(t, "Los Angeles Tax", result.Components[1].TaxName)
+		require.Equal(t, JurisdictionLocal, result.Components[1].JurisdictionType)
+	})
+	t.Run("Test for InNexus", func(t *testing.T) {
+		require.False(t, InNexus(nil, "US", "CA"))
+		require.True(t, InNexus([]string{"US-CA", "CA"}, "us", "ca"))
+		require.True(t, InNexus([]string{"US-CA", "CA"}, "CA", "QC"))
+		require.False(t, InNexus([]string{"US-CA", "CA"}, "US", "NY"))
+	})
+}
+
+func TestTaxLines(t *testing.T) {
+	result := &TaxResult{Components: []*TaxComponent{
+		{Jurisdiction: "CA", JurisdictionType: JurisdictionCountry, TaxName: "GST", TaxPercentage: 500},
+		{Jurisdiction: "CA-QC", JurisdictionType: JurisdictionState, TaxName: "QST", TaxPercentage: 998},
+	}}
+	t.Run("Test for SplitTaxLines", func(t *testing.T) {
+		lines := SplitTaxLines(result, 1498, 10000, 1498)
+		require.Equal(t, 2, len(lines))
+		require.Equal(t, int64(500), lines[0].TaxAmount)
+		require.Equal(t, int64(998), lines[1].TaxAmount)
+		lines = SplitTaxLines(result, 1498, 333, 50)
+		require.Equal(t, int64(16), lines[0].TaxAmount)
+		require.Equal(t, int64(34), lines[1].TaxAmount)
+		require.Nil(t, SplitTaxLines(result, 1000, 10000, 1000))
+		exempt := SplitTaxLines(&TaxResult{Exempt: true, ExemptCertificateId: "cert_1"}, 0, 10000, 0)
+		require.Equal(t, 1, len(exempt))
+		require.Equal(t, "cert_1", exempt[0].ExemptCertificateId)
+	})
+	t.Run("Test for ScaleTaxLines", func(t *testing.T) {
+		lines := ScaleTaxLines(SplitTaxLines(result, 1498, 10000, 1498), -5000, -749)
+		require.Equal(t, 2, len(lines))
+		require.Equal(t, int64(-250), lines[0].TaxAmount)
+		require.Equal(t, int64(-499), lines[1].TaxAmount)
+		require.Equal(t, int64(-5000), lines[1].TaxableAmount)
+	})
+	t.Run("Test for MergeTaxLines", func(t *testing.T) {
+		lines := MergeTaxLines(SplitTaxLines(result, 1498, 10000, 1498), SplitTaxLines(result, 1498, 2000, 300))
+		require.Equal(t, 2, len(lines))
+		require.Equal(t, int64(12000), lines[0].TaxableAmount)
+		require.Equal(t, int64(1798), lines[0].TaxAmount+lines[1].TaxAmount)
+		exempt := SplitTaxLines(&TaxResult{Exempt: true}, 0, 500, 0)
+		require.Equal(t, 3, len(MergeTaxLines(lines, exempt)))
+	})
+}
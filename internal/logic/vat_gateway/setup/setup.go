@@ -7,10 +7,12 @@ import (
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gtime"
 	"strings"
+	"unibee/api/bean"
 	dao "unibee/internal/dao/default"
 	"unibee/internal/logic/merchant_config/update"
 	"unibee/internal/logic/operation_log"
 	"unibee/internal/logic/vat_gateway"
+	"unibee/internal/logic/vat_gateway/sales_tax"
 	entity "unibee/internal/model/entity/default"
 	"unibee/utility"
 )
@@ -137,3 +139,54 @@ func InitMerchantDefaultVatGateway(ctx context.Context, merchantId uint64) error
 
 	return nil
 }
+
+func SetupMerchantSalesTaxConfig(ctx context.Context, merchantId uint64, req *bean.SalesTaxConfig) (*bean.SalesTaxConfig, error) {
+	utility.Assert(merchantId > 0, "invalid merchantId")
+	utility.Assert(req != nil, "invalid config")
+	if len(req.Provider) == 0 {
+		req.Provider = sales_tax.RateTableProviderName
+	}
+	utility.Assert(vat_gateway.GetSalesTaxProvider(req.Provider) != nil, "provider not support, should be "+vat_gateway.SALES_TAX_PROVIDER_NAMES)
+	var nexusRegions = make([]string, 0)
+	for _, region := range req.NexusRegions {
+		region = strings.ToUpper(strings.TrimSpace(region))
+		utility.Assert(len(region) == 2 || (len(region) > 3 && region[2] == '-'), fmt.Sprintf("invalid nexus region:%s, should be country code or country-state code, em. US-CA", region))
+		nexusRegions = append(nexusRegions, region)
+	}
+	var rules = make([]*bean.SalesTaxRateRule, 0)
+	for _, rule := range req.Rules {
+		if rule == nil {
+			continue
+		}
+		rule.CountryCode = strings.ToUpper(strings.TrimSpace(rule.CountryCode))
+		rule.State = strings.ToUpper(strings.TrimSpace(rule.State))
+		rule.ZipPrefix = strings.TrimSpace(rule.ZipPrefix)
+		utility.Assert(len(rule.CountryCode) == 2 && len(rule.State) > 0, "countryCode and state of rule required")
+		utility.Assert(sales_tax.IsValidTaxCode(rule.TaxCode), "invalid taxCode of rule, should be "+sales_tax.TAX_CODES)
+		utility.Assert(rule.TaxPercentage >= 0 && rule.TaxPercentage < 10000, "invalid taxPercentage of rule")
+		utility.Assert(len(rule.JurisdictionType) == 0 || rule.JurisdictionType == sales_tax.JurisdictionCountry || rule.JurisdictionType == sales_tax.JurisdictionState || rule.JurisdictionType == sales_tax.JurisdictionLocal, "invalid jurisdictionType of rule, should be country|state|local")
+		rules = append(rules, rule)
+	}
+	config := &bean.SalesTaxConfig{
+		Enable:       req.Enable,
+		Provider:     req.Provider,
+		NexusRegions: nexusRegions,
+		Rules:        rules,
+	}
+	err := update.SetMerchantConfig(ctx, merchantId, vat_gateway.KeyMerchantSalesTaxConfig, utility.MarshalToJsonString(config))
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     merchantId,
+		Target:         fmt.Sprintf("SalesTax(%s)", config.Provider),
+		Content:        "SetupSalesTax",
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+		Data:           utility.MarshalToJsonString(config),
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
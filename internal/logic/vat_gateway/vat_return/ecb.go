@@ -0,0 +1,101 @@
+package vat_return
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ecbRateUrl = "https://data-api.ecb.europa.eu/service/data/EXR/D.%s.EUR.SP00.A?startPeriod=%s&endPeriod=%s&format=csvdata"
+	// ecbRateSearchDays is the days after quarter end searched, the rate of next publication used if ecb published none on the last day
+	ecbRateSearchDays = 7
+)
+
+var ecbHttpClient = &http.Client{Timeout: 30 * time.Second}
+
+// QuarterLastDay returns the utc last day of quarter, oss return converts by the ecb rate published on the day
+func QuarterLastDay(year int, quarter int) (time.Time, error) {
+	_, end, err := QuarterRange(year, quarter)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(end, 0).UTC().AddDate(0, 0, -1), nil
+}
+
+// GetEcbQuarterEndRate returns the rate of currency to EUR, the ecb reference rate of the quarter last day or the next publication
+func GetEcbQuarterEndRate(ctx context.Context, currency string, year int, quarter int) (float64, error) {
+	lastDay, err := QuarterLastDay(year, quarter)
+	if err != nil {
+		return 0, err
+	}
+	url := fmt.Sprintf(ecbRateUrl, strings.ToUpper(currency), lastDay.Format("2006-01-02"), lastDay.AddDate(0, 0, ecbRateSearchDays).Format("2006-01-02"))
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	response, err := ecbHttpClient.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("ecb rate of %s error:%s", currency, err.Error())
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	data, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return 0, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("ecb rate of %s not published, status:%d", currency, response.StatusCode)
+	}
+	return ParseEcbRate(data)
+}
+
+// ParseEcbRate returns the rate of currency to EUR from the earliest observation of ecb csv data, ecb quotes the currency per EUR
+func ParseEcbRate(data []byte) (float64, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return 0, err
+	}
+	if len(records) < 2 {
+		return 0, fmt.Errorf("ecb rate not published")
+	}
+	var periodIndex = -1
+	var valueIndex = -1
+	for i, column := range records[0] {
+		switch column {
+		case "TIME_PERIOD":
+			periodIndex = i
+		case "OBS_VALUE":
+			valueIndex = i
+		}
+	}
+	if periodIndex < 0 || valueIndex < 0 {
+		return 0, fmt.Errorf("invalid ecb rate data")
+	}
+	var period = ""
+	var rate float64 = 0
+	for _, record := range records[1:] {
+		if len(record) <= periodIndex || len(record) <= valueIndex {
+			continue
+		}
+		value, err := strconv.ParseFloat(record[valueIndex], 64)
+		if err != nil || value <= 0 {
+			continue
+		}
+		if len(period) == 0 || record[periodIndex] < period {
+			period = record[periodIndex]
+			rate = 1 / value
+		}
+	}
+	if rate <= 0 {
+		return 0, fmt.Errorf("ecb rate not published")
+	}
+	return rate, nil
+}
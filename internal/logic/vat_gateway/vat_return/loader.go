@@ -0,0 +1,157 @@
+package vat_return
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+	"unibee/internal/query"
+)
+
+const (
+	RateSourceEcb    = "ecb"
+	RateSourceCustom = "custom"
+
+	loadPageSize = 200
+)
+
+type ReturnReportReq struct {
+	MerchantId uint64
+	Year       int
+	Quarter    int
+	RateSource string
+	// ExchangeRates is the rates of currency to EUR used by custom rate source
+	ExchangeRates map[string]float64
+}
+
+// BuildReturnReport builds the oss vat return lines of quarter, the invoices paid and the credit notes refunded in the quarter included
+func BuildReturnReport(ctx context.Context, req *ReturnReportReq) ([]*ReturnLine, error) {
+	if req == nil || req.MerchantId <= 0 {
+		return nil, fmt.Errorf("invalid merchantId")
+	}
+	start, end, err := QuarterRange(req.Year, req.Quarter)
+	if err != nil {
+		return nil, err
+	}
+	merchant := query.GetMerchantById(ctx, req.MerchantId)
+	if merchant == nil {
+		return nil, fmt.Errorf("merchant not found")
+	}
+	aggregator := NewAggregator(merchant.CountryCode)
+	err = loadPaidInvoices(ctx, req.MerchantId, start, end, aggregator)
+	if err != nil {
+		return nil, err
+	}
+	err = loadCreditNotes(ctx, req.MerchantId, start, end, aggregator)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := loadExchangeRates(ctx, req, aggregator.Currencies())
+	if err != nil {
+		return nil, err
+	}
+	return aggregator.Lines(rates)
+}
+
+func loadPaidInvoices(ctx context.Context, merchantId uint64, start int64, end int64, aggregator *Aggregator) error {
+	var lastId int64 = 0
+	var exist = make(map[string]bool)
+	for {
+		var list []*entity.Payment
+		err := dao.Payment.Ctx(ctx).
+			Where(dao.Payment.Columns().MerchantId, merchantId).
+			Where(dao.Payment.Columns().Status, consts.PaymentSuccess).
+			WhereGTE(dao.Payment.Columns().PaidTime, start).
+			WhereLT(dao.Payment.Columns().PaidTime, end).
+			WhereNot(dao.Payment.Columns().InvoiceId, "").
+			WhereGT(dao.Payment.Columns().Id, lastId).
+			OrderAsc(dao.Payment.Columns().Id).
+			Limit(loadPageSize).
+			Scan(&list)
+		if err != nil {
+			return err
+		}
+		for _, one := range list {
+			lastId = one.Id
+			if exist[one.InvoiceId] {
+				continue
+			}
+			exist[one.InvoiceId] = true
+			invoice := query.GetInvoiceByInvoiceId(ctx, one.InvoiceId)
+			if invoice == nil || invoice.MerchantId != merchantId || invoice.Status != consts.InvoiceStatusPaid || len(invoice.RefundId) > 0 {
+				continue
+			}
+			aggregator.Add(invoice)
+		}
+		if len(list) < loadPageSize {
+			break
+		}
+	}
+	return nil
+}
+
+func loadCreditNotes(ctx context.Context, merchantId uint64, start int64, end int64, aggregator *Aggregator) error {
+	var lastId int64 = 0
+	for {
+		var list []*entity.Refund
+		err := dao.Refund.Ctx(ctx).
+			Where(dao.Refund.Columns().MerchantId, merchantId).
+			Where(dao.Refund.Columns().Status, consts.RefundSuccess).
+			WhereGTE(dao.Refund.Columns().RefundTime, start).
+			WhereLT(dao.Refund.Columns().RefundTime, end).
+			WhereGT(dao.Refund.Columns().Id, lastId).
+			OrderAsc(dao.Refund.Columns().Id).
+			Limit(loadPageSize).
+			Scan(&list)
+		if err != nil {
+			return err
+		}
+		for _, one := range list {
+			lastId = one.Id
+			creditNote := query.GetInvoiceByRefundId(ctx, one.RefundId)
+			if creditNote == nil || creditNote.MerchantId != merchantId {
+				continue
+			}
+			aggregator.Add(creditNote)
+		}
+		if len(list) < loadPageSize {
+			break
+		}
+	}
+	return nil
+}
+
+// loadExchangeRates returns the rates of currencies to EUR, the ecb rates of quarter last day or the custom rates,
+// the exchange api of merchant is not used since it only provides the rate of today
+func loadExchangeRates(ctx context.Context, req *ReturnReportReq, currencies []string) (map[string]float64, error) {
+	var rates = make(map[string]float64)
+	var customRates = make(map[string]float64)
+	for currency, rate := range req.ExchangeRates {
+		customRates[strings.ToUpper(currency)] = rate
+	}
+	for _, currency := range currencies {
+		if currency == ReportCurrency {
+			rates[currency] = 1
+			continue
+		}
+		switch req.RateSource {
+		case RateSourceCustom:
+			rate, ok := customRates[currency]
+			if !ok || rate <= 0 {
+				return nil, fmt.Errorf("custom exchange rate of %s to %s required", currency, ReportCurrency)
+			}
+			rates[currency] = rate
+		case "", RateSourceEcb:
+			rate, err := GetEcbQuarterEndRate(ctx, currency, req.Year, req.Quarter)
+			if err != nil {
+				return nil, fmt.Errorf("%s, use custom rate source instead", err.Error())
+			}
+			rates[currency] = rate
+		default:
+			return nil, fmt.Errorf("invalid rate source:%s, %s|%s", req.RateSource, RateSourceEcb, RateSourceCustom)
+		}
+	}
+	return rates, nil
+}
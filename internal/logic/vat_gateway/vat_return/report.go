@@ -0,0 +1,169 @@
+package vat_return
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	entity "unibee/internal/model/entity/default"
+	"unibee/utility"
+)
+
+const (
+	SchemeOSS           = "oss"
+	SchemeReverseCharge = "reverse_charge"
+	SchemeDomestic      = "domestic"
+
+	ReportCurrency = "EUR"
+)
+
+// euCountries is the member states of the one-stop-shop, EL is the vat prefix of Greece
+var euCountries = map[string]bool{
+	"AT": true, "BE": true, "BG": true, "HR": true, "CY": true, "CZ": true, "DK": true, "EE": true, "FI": true,
+	"FR": true, "DE": true, "GR": true, "EL": true, "HU": true, "IE": true, "IT": true, "LV": true, "LT": true,
+	"LU": true, "MT": true, "NL": true, "PL": true, "PT": true, "RO": true, "SK": true, "SI": true, "ES": true,
+	"SE": true,
+}
+
+func IsEUCountry(countryCode string) bool {
+	return euCountries[strings.ToUpper(countryCode)]
+}
+
+// QuarterRange returns the utc time range of the quarter, end exclusive
+func QuarterRange(year int, quarter int) (start int64, end int64, err error) {
+	if year < 2000 || year > 9999 || quarter < 1 || quarter > 4 {
+		return 0, 0, fmt.Errorf("invalid year or quarter:%d Q%d", year, quarter)
+	}
+	startTime := time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, time.UTC)
+	return startTime.Unix(), startTime.AddDate(0, 3, 0).Unix(), nil
+}
+
+// InvoiceScheme returns the scheme the invoice reported in, blank for the ones outside eu,
+// the b2b invoices with vat number and no tax are reverse charged, the supplies to the merchant country are domestic
+func InvoiceScheme(one *entity.Invoice, merchantCountryCode string) string {
+	countryCode := strings.ToUpper(one.CountryCode)
+	if !IsEUCountry(countryCode) {
+		return ""
+	}
+	if len(one.VatNumber) > 0 && one.TaxPercentage == 0 {
+		return SchemeReverseCharge
+	}
+	if len(merchantCountryCode) > 0 && strings.EqualFold(countryCode, merchantCountryCode) {
+		return SchemeDomestic
+	}
+	return SchemeOSS
+}
+
+type ReturnLine struct {
+	Scheme           string
+	CountryCode      string
+	TaxPercentage    int64
+	Currency         string
+	InvoiceCount     int64
+	CreditNoteCount  int64
+	TaxableAmount    int64
+	TaxAmount        int64
+	ExchangeRate     float64
+	TaxableAmountEUR int64
+	TaxAmountEUR     int64
+}
+
+// Aggregator groups the taxable amount and tax of paid invoices and credit notes by scheme, country, rate and currency,
+// the credit notes with negative amounts netted
+type Aggregator struct {
+	MerchantCountryCode string
+	lines               map[string]*ReturnLine
+}
+
+func NewAggregator(merchantCountryCode string) *Aggregator {
+	return &Aggregator{
+		MerchantCountryCode: strings.ToUpper(merchantCountryCode),
+		lines:               make(map[string]*ReturnLine),
+	}
+}
+
+func (a *Aggregator) Add(one *entity.Invoice) {
+	if one == nil {
+		return
+	}
+	scheme := InvoiceScheme(one, a.MerchantCountryCode)
+	if len(scheme) == 0 {
+		return
+	}
+	countryCode := strings.ToUpper(one.CountryCode)
+	currency := strings.ToUpper(one.Currency)
+	key := fmt.Sprintf("%s_%s_%d_%s", scheme, countryCode, one.TaxPercentage, currency)
+	line, ok := a.lines[key]
+	if !ok {
+		line = &ReturnLine{
+			Scheme:        scheme,
+			CountryCode:   countryCode,
+			TaxPercentage: one.TaxPercentage,
+			Currency:      currency,
+		}
+		a.lines[key] = line
+	}
+	if len(one.RefundId) > 0 || one.TotalAmount < 0 {
+		line.CreditNoteCount++
+	} else {
+		line.InvoiceCount++
+	}
+	line.TaxableAmount = line.TaxableAmount + one.TotalAmountExcludingTax
+	line.TaxAmount = line.TaxAmount + one.TaxAmount
+}
+
+func (a *Aggregator) Currencies() []string {
+	var exist = make(map[string]bool)
+	var list = make([]string, 0)
+	for _, line := range a.lines {
+		if !exist[line.Currency] {
+			exist[line.Currency] = true
+			list = append(list, line.Currency)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Lines returns the lines converted to EUR by the rates of currency to EUR, sorted by scheme, country, rate and currency
+func (a *Aggregator) Lines(rates map[string]float64) ([]*ReturnLine, error) {
+	var list = make([]*ReturnLine, 0)
+	for _, line := range a.lines {
+		rate := 1.0
+		if line.Currency != ReportCurrency {
+			var ok bool
+			rate, ok = rates[line.Currency]
+			if !ok || rate <= 0 {
+				return nil, fmt.Errorf("exchange rate of %s to %s not found", line.Currency, ReportCurrency)
+			}
+		}
+		line.ExchangeRate = rate
+		line.TaxableAmountEUR = utility.ExchangeCurrencyConvert(line.TaxableAmount, line.Currency, ReportCurrency, rate)
+		line.TaxAmountEUR = utility.ExchangeCurrencyConvert(line.TaxAmount, line.Currency, ReportCurrency, rate)
+		list = append(list, line)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Scheme != list[j].Scheme {
+			return schemeOrder(list[i].Scheme) < schemeOrder(list[j].Scheme)
+		}
+		if list[i].CountryCode != list[j].CountryCode {
+			return list[i].CountryCode < list[j].CountryCode
+		}
+		if list[i].TaxPercentage != list[j].TaxPercentage {
+			return list[i].TaxPercentage > list[j].TaxPercentage
+		}
+		return list[i].Currency < list[j].Currency
+	})
+	return list, nil
+}
+
+func schemeOrder(scheme string) int {
+	switch scheme {
+	case SchemeOSS:
+		return 0
+	case SchemeReverseCharge:
+		return 1
+	default:
+		return 2
+	}
+}
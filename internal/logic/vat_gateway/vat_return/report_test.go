@@ -0,0 +1,77 @@
+package vat_return
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	entity "unibee/internal/model/entity/default"
+)
+
+func TestQuarterRange(t *testing.T) {
+	t.Run("Test for QuarterRange", func(t *testing.T) {
+		start, end, err := QuarterRange(2024, 1)
+		require.Nil(t, err)
+		require.Equal(t, int64(1704067200), start)
+		require.Equal(t, int64(1711929600), end)
+		start, end, err = QuarterRange(2024, 4)
+		require.Nil(t, err)
+		require.Equal(t, int64(1727740800), start)
+		require.Equal(t, int64(1735689600), end)
+		_, _, err = QuarterRange(2024, 5)
+		require.NotNil(t, err)
+	})
+}
+
+func TestAggregator(t *testing.T) {
+	aggregator := NewAggregator("ie")
+	aggregator.Add(&entity.Invoice{CountryCode: "DE", Currency: "EUR", TaxPercentage: 1900, TotalAmount: 11900, TotalAmountExcludingTax: 10000, TaxAmount: 1900})
+	aggregator.Add(&entity.Invoice{CountryCode: "de", Currency: "eur", TaxPercentage: 1900, TotalAmount: 5950, TotalAmountExcludingTax: 5000, TaxAmount: 950})
+	aggregator.Add(&entity.Invoice{CountryCode: "DE", Currency: "EUR", TaxPercentage: 1900, RefundId: "refund_1", TotalAmount: -5950, TotalAmountExcludingTax: -5000, TaxAmount: -950})
+	aggregator.Add(&entity.Invoice{CountryCode: "FR", Currency: "USD", TaxPercentage: 2000, TotalAmount: 1200, TotalAmountExcludingTax: 1000, TaxAmount: 200})
+	aggregator.Add(&entity.Invoice{CountryCode: "FR", Currency: "EUR", VatNumber: "FR123", TaxPercentage: 0, TotalAmount: 3000, TotalAmountExcludingTax: 3000})
+	aggregator.Add(&entity.Invoice{CountryCode: "IE", Currency: "EUR", TaxPercentage: 2300, TotalAmount: 1230, TotalAmountExcludingTax: 1000, TaxAmount: 230})
+	aggregator.Add(&entity.Invoice{CountryCode: "US", Currency: "USD", TaxPercentage: 0, TotalAmount: 1000, TotalAmountExcludingTax: 1000})
+	t.Run("Test for Scheme Split", func(t *testing.T) {
+		require.Equal(t, []string{"EUR", "USD"}, aggregator.Currencies())
+		_, err := aggregator.Lines(map[string]float64{})
+		require.NotNil(t, err)
+		lines, err := aggregator.Lines(map[string]float64{"USD": 0.9})
+		require.Nil(t, err)
+		require.Equal(t, 4, len(lines))
+		require.Equal(t, SchemeOSS, lines[0].Scheme)
+		require.Equal(t, "DE", lines[0].CountryCode)
+		require.Equal(t, int64(2), lines[0].InvoiceCount)
+		require.Equal(t, int64(1), lines[0].CreditNoteCount)
+		require.Equal(t, int64(10000), lines[0].TaxableAmount)
+		require.Equal(t, int64(1900), lines[0].TaxAmountEUR)
+		require.Equal(t, SchemeOSS, lines[1].Scheme)
+		require.Equal(t, "USD", lines[1].Currency)
+		require.Equal(t, int64(900), lines[1].TaxableAmountEUR)
+		require.Equal(t, int64(180), lines[1].TaxAmountEUR)
+		require.Equal(t, SchemeReverseCharge, lines[2].Scheme)
+		require.Equal(t, int64(3000), lines[2].TaxableAmountEUR)
+		require.Equal(t, int64(0), lines[2].TaxAmountEUR)
+		require.Equal(t, SchemeDomestic, lines[3].Scheme)
+		require.Equal(t, "IE", lines[3].CountryCode)
+	})
+}
+
+func TestEcbRate(t *testing.T) {
+	t.Run("Test for QuarterLastDay", func(t *testing.T) {
+		lastDay, err := QuarterLastDay(2024, 1)
+		require.Nil(t, err)
+		require.Equal(t, "2024-03-31", lastDay.Format("2006-01-02"))
+		lastDay, err = QuarterLastDay(2024, 4)
+		require.Nil(t, err)
+		require.Equal(t, "2024-12-31", lastDay.Format("2006-01-02"))
+	})
+	t.Run("Test for ParseEcbRate", func(t *testing.T) {
+		data := "KEY,FREQ,CURRENCY,CURRENCY_DENOM,EXR_TYPE,EXR_SUFFIX,TIME_PERIOD,OBS_VALUE\n" +
+			"EXR.D.USD.EUR.SP00.A,D,USD,EUR,SP00,A,2024-04-02,1.0772\n" +
+			"EXR.D.USD.EUR.SP00.A,D,USD,EUR,SP00,A,2024-04-03,1.0800\n"
+		rate, err := ParseEcbRate([]byte(data))
+		require.Nil(t, err)
+		require.InDelta(t, 1/1.0772, rate, 0.0000001)
+		_, err = ParseEcbRate([]byte("KEY,TIME_PERIOD,OBS_VALUE\n"))
+		require.NotNil(t, err)
+	})
+}
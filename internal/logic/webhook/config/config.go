@@ -0,0 +1,80 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"unibee/api/bean"
+	"unibee/internal/logic/merchant_config"
+	"unibee/utility"
+)
+
+const (
+	WebhookRetryPolicy = "WebhookRetryPolicy"
+)
+
+const (
+	RetryMaxAttempts      = 20
+	RetryMaxMultiplier    = 10
+	RetryMaxIntervalLimit = 7 * 24 * 60 * 60
+)
+
+func defaultWebhookRetryPolicy() *bean.WebhookRetryPolicy {
+	return &bean.WebhookRetryPolicy{
+		MaxAttempts:            8,
+		InitialIntervalSeconds: 5 * 60, // first redelivery 5 minutes after
+		BackoffMultiplier:      3,
+		MaxIntervalSeconds:     24 * 60 * 60,
+		DisableAfterFailures:   100,
+	}
+}
+
+func GetMerchantWebhookRetryPolicy(ctx context.Context, merchantId uint64) *bean.WebhookRetryPolicy {
+	policy := defaultWebhookRetryPolicy()
+	retryPolicy := merchant_config.GetMerchantConfig(ctx, merchantId, WebhookRetryPolicy)
+	if retryPolicy != nil && len(retryPolicy.ConfigValue) > 0 {
+		var one *bean.WebhookRetryPolicy
+		err := utility.UnmarshalFromJsonString(retryPolicy.ConfigValue, &one)
+		if err == nil && one != nil && one.MaxAttempts > 0 {
+			policy = one
+		}
+	}
+	return policy
+}
+
+// CheckWebhookRetryPolicy verifies the retry policy and fills the default of the unset fields
+func CheckWebhookRetryPolicy(policy *bean.WebhookRetryPolicy) {
+	utility.Assert(policy != nil, "retryPolicy is nil")
+	utility.Assert(policy.MaxAttempts > 0 && policy.MaxAttempts <= RetryMaxAttempts, fmt.Sprintf("maxAttempts should between 1 and %d", RetryMaxAttempts))
+	utility.Assert(policy.InitialIntervalSeconds >= 0, "initialIntervalSeconds should not less than 0")
+	utility.Assert(policy.BackoffMultiplier >= 0 && policy.BackoffMultiplier <= RetryMaxMultiplier, fmt.Sprintf("backoffMultiplier should not greater than %d", RetryMaxMultiplier))
+	utility.Assert(policy.MaxIntervalSeconds >= 0 && policy.MaxIntervalSeconds <= RetryMaxIntervalLimit, fmt.Sprintf("maxIntervalSeconds should not greater than %d", RetryMaxIntervalLimit))
+	utility.Assert(policy.DisableAfterFailures >= 0, "disableAfterFailures should not less than 0")
+	defaultPolicy := defaultWebhookRetryPolicy()
+	if policy.InitialIntervalSeconds == 0 {
+		policy.InitialIntervalSeconds = defaultPolicy.InitialIntervalSeconds
+	}
+	if policy.BackoffMultiplier == 0 {
+		policy.BackoffMultiplier = 1
+	}
+	if policy.MaxIntervalSeconds == 0 {
+		policy.MaxIntervalSeconds = defaultPolicy.MaxIntervalSeconds
+	}
+	if policy.MaxIntervalSeconds < policy.InitialIntervalSeconds {
+		policy.MaxIntervalSeconds = policy.InitialIntervalSeconds
+	}
+}
+
+// NextRetryInterval returns the seconds to wait before redelivery after the attempt (start with 1) failed
+func NextRetryInterval(policy *bean.WebhookRetryPolicy, attempt int) int64 {
+	interval := policy.InitialIntervalSeconds
+	for i := 1; i < attempt; i++ {
+		interval = interval * policy.BackoffMultiplier
+		if interval >= policy.MaxIntervalSeconds {
+			return policy.MaxIntervalSeconds
+		}
+	}
+	if interval > policy.MaxIntervalSeconds {
+		return policy.MaxIntervalSeconds
+	}
+	return interval
+}
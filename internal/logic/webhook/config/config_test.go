@@ -0,0 +1,33 @@
+package config
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"unibee/api/bean"
+)
+
+func TestNextRetryInterval(t *testing.T) {
+	policy := &bean.WebhookRetryPolicy{
+		MaxAttempts:            8,
+		InitialIntervalSeconds: 300,
+		BackoffMultiplier:      3,
+		MaxIntervalSeconds:     86400,
+	}
+	t.Run("Test for Exponential Backoff", func(t *testing.T) {
+		require.Equal(t, int64(300), NextRetryInterval(policy, 1))
+		require.Equal(t, int64(900), NextRetryInterval(policy, 2))
+		require.Equal(t, int64(2700), NextRetryInterval(policy, 3))
+		require.Equal(t, int64(72900), NextRetryInterval(policy, 6))
+	})
+	t.Run("Test for Max Interval", func(t *testing.T) {
+		require.Equal(t, int64(86400), NextRetryInterval(policy, 7))
+		require.Equal(t, int64(86400), NextRetryInterval(policy, 100))
+	})
+	t.Run("Test for Policy Default", func(t *testing.T) {
+		one := &bean.WebhookRetryPolicy{MaxAttempts: 3}
+		CheckWebhookRetryPolicy(one)
+		require.Equal(t, int64(300), one.InitialIntervalSeconds)
+		require.Equal(t, int64(1), one.BackoffMultiplier)
+		require.Equal(t, int64(300), NextRetryInterval(one, 2))
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"unibee/internal/cmd/config"
 	"unibee/internal/consumer/webhook/event"
 	dao "unibee/internal/dao/default"
+	"unibee/internal/logic/merchant"
 	"unibee/internal/logic/operation_log"
 	entity "unibee/internal/model/entity/default"
 	"unibee/internal/query"
@@ -36,12 +37,16 @@ func MerchantWebhookEndpointList(ctx context.Context, merchantId uint64) []*bean
 					events = strings.Split(one.WebhookEvents, SplitSep)
 				}
 				list = append(list, &bean.MerchantWebhookEndpoint{
-					Id:            one.Id,
-					MerchantId:    one.MerchantId,
-					WebhookUrl:    one.WebhookUrl,
-					WebhookEvents: events,
-					UpdateTime:    one.GmtModify.Timestamp(),
-					CreateTime:    one.CreateTime,
+					Id:                  one.Id,
+					MerchantId:          one.MerchantId,
+					WebhookUrl:          one.WebhookUrl,
+					WebhookEvents:       events,
+					UpdateTime:          one.GmtModify.Timestamp(),
+					CreateTime:          one.CreateTime,
+					ConsecutiveFailures: one.ConsecutiveFailures,
+					LastSuccessTime:     one.LastSuccessTime,
+					LastFailureTime:     one.LastFailureTime,
+					DisableTime:         one.DisableTime,
 				})
 			}
 		}
@@ -77,6 +82,24 @@ func MerchantWebhookEndpointLogList(ctx context.Context, req *EndpointLogListInt
 	return mainList, total
 }
 
+func MerchantWebhookEndpointLogAttemptList(ctx context.Context, merchantId uint64, logId uint64) []*bean.MerchantWebhookAttempt {
+	utility.Assert(merchantId > 0, "merchantId not found")
+	utility.Assert(logId > 0, "logId not found")
+	var list = make([]*bean.MerchantWebhookAttempt, 0)
+	var entities []*entity.MerchantWebhookAttempt
+	err := dao.MerchantWebhookAttempt.Ctx(ctx).
+		Where(dao.MerchantWebhookAttempt.Columns().MerchantId, merchantId).
+		Where(dao.MerchantWebhookAttempt.Columns().LogId, logId).
+		OrderAsc(dao.MerchantWebhookAttempt.Columns().Attempt).
+		Scan(&entities)
+	if err == nil {
+		for _, one := range entities {
+			list = append(list, bean.SimplifyMerchantWebhookAttempt(one))
+		}
+	}
+	return list
+}
+
 func NewMerchantWebhookEndpoint(ctx context.Context, merchantId uint64, url string, events []string) (*entity.MerchantWebhook, error) {
 	utility.Assert(merchantId > 0, "invalid merchantId")
 	utility.Assert(len(url) > 0, "url is nil")
@@ -96,6 +119,7 @@ func NewMerchantWebhookEndpoint(ctx context.Context, merchantId uint64, url stri
 			MerchantId:    merchantId,
 			WebhookUrl:    url,
 			WebhookEvents: strings.Join(events, SplitSep),
+			WebhookSecret: merchant.GenerateMerchantWebHookSecret(),
 			CreateTime:    gtime.Now().Timestamp(),
 		}
 		result, err := dao.MerchantWebhook.Ctx(ctx).Data(one).OmitNil().Insert(one)
@@ -118,13 +142,17 @@ func NewMerchantWebhookEndpoint(ctx context.Context, merchantId uint64, url stri
 		return one, nil
 	} else {
 		utility.Assert(one.IsDeleted != 0, "endpoint already exist")
-		_, err := dao.MerchantWebhook.Ctx(ctx).Data(g.Map{
+		var data = g.Map{
 			dao.MerchantWebhook.Columns().MerchantId:    merchantId,
 			dao.MerchantWebhook.Columns().WebhookUrl:    url,
 			dao.MerchantWebhook.Columns().WebhookEvents: strings.Join(events, SplitSep),
 			dao.MerchantWebhook.Columns().GmtModify:     gtime.Now(),
 			dao.MerchantWebhook.Columns().IsDeleted:     0,
-		}).Where(dao.MerchantWebhook.Columns().Id, one.Id).Update()
+		}
+		if len(one.WebhookSecret) == 0 {
+			data[dao.MerchantWebhook.Columns().WebhookSecret] = merchant.GenerateMerchantWebHookSecret()
+		}
+		_, err := dao.MerchantWebhook.Ctx(ctx).Data(data).Where(dao.MerchantWebhook.Columns().Id, one.Id).Update()
 		if err != nil {
 			g.Log().Errorf(ctx, "UpdateMerchantWebhookEndpoint Update err:%s", err.Error())
 			return nil, gerror.NewCode(gcode.New(500, "server error", nil))
@@ -204,6 +232,39 @@ func DeleteMerchantWebhookEndpoint(ctx context.Context, merchantId uint64, endpo
 	return err
 }
 
+// EnableMerchantWebhookEndpoint enables the endpoint disabled by consecutive failures, failed webhooks during disabled
+// can be replayed by ReplayFailedWebhooks
+func EnableMerchantWebhookEndpoint(ctx context.Context, merchantId uint64, endpointId uint64) error {
+	utility.Assert(merchantId > 0, "invalid merchantId")
+	utility.Assert(endpointId > 0, "invalid endpointId")
+	one := query.GetMerchantWebhook(ctx, endpointId)
+	utility.Assert(one != nil && one.MerchantId == merchantId && one.IsDeleted == 0, "endpoint not found")
+	if one.DisableTime == 0 {
+		// already enabled
+		return nil
+	}
+	_, err := dao.MerchantWebhook.Ctx(ctx).Data(g.Map{
+		dao.MerchantWebhook.Columns().DisableTime:         0,
+		dao.MerchantWebhook.Columns().ConsecutiveFailures: 0,
+		dao.MerchantWebhook.Columns().GmtModify:           gtime.Now(),
+	}).Where(dao.MerchantWebhook.Columns().Id, one.Id).Where(dao.MerchantWebhook.Columns().MerchantId, merchantId).Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("WebhookEndpoint(%v)", one.Id),
+		Content:        "Enable",
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		g.Log().Errorf(ctx, "EnableMerchantWebhookEndpoint Update err:%s", err.Error())
+		return gerror.NewCode(gcode.New(500, "server error", nil))
+	}
+	return nil
+}
+
 func HardDeleteMerchantWebhookEndpoint(ctx context.Context, merchantId uint64, endpointId uint64) error {
 	utility.Assert(merchantId > 0, "invalid merchantId")
 	utility.Assert(endpointId > 0, "invalid endpointId")
@@ -212,3 +273,122 @@ func HardDeleteMerchantWebhookEndpoint(ctx context.Context, merchantId uint64, e
 	_, err := dao.MerchantWebhook.Ctx(ctx).Where(dao.MerchantWebhook.Columns().Id, one.Id).Where(dao.MerchantWebhook.Columns().MerchantId, merchantId).OmitNil().Delete()
 	return err
 }
+
+const (
+	DefaultSecretOverlapSeconds = 86400
+	MaxSecretOverlapSeconds     = 7 * 86400
+)
+
+// EnsureMerchantWebhookEndpointSecret backfills the secret of endpoint created before the secret generated on create,
+// the update only applies to the blank one, concurrent backfill keeps the first generated secret
+func EnsureMerchantWebhookEndpointSecret(ctx context.Context, one *entity.MerchantWebhook) (*entity.MerchantWebhook, error) {
+	if one == nil || len(one.WebhookSecret) > 0 {
+		return one, nil
+	}
+	_, err := dao.MerchantWebhook.Ctx(ctx).Data(g.Map{
+		dao.MerchantWebhook.Columns().WebhookSecret: merchant.GenerateMerchantWebHookSecret(),
+		dao.MerchantWebhook.Columns().GmtModify:     gtime.Now(),
+	}).Where(dao.MerchantWebhook.Columns().Id, one.Id).Where(dao.MerchantWebhook.Columns().WebhookSecret, "").Update()
+	if err != nil {
+		g.Log().Errorf(ctx, "EnsureMerchantWebhookEndpointSecret Update endpointId:%d err:%s", one.Id, err.Error())
+		return one, err
+	}
+	latest := query.GetMerchantWebhook(ctx, one.Id)
+	if latest == nil || len(latest.WebhookSecret) == 0 {
+		return one, gerror.Newf("endpoint secret not generated, endpointId:%d", one.Id)
+	}
+	return latest, nil
+}
+
+const backfillSecretPageSize = 100
+
+// BackfillMerchantWebhookEndpointSecretsBackground generates the secret for all endpoints created without one
+func BackfillMerchantWebhookEndpointSecretsBackground() {
+	go func() {
+		ctx := context.Background()
+		defer func() {
+			if exception := recover(); exception != nil {
+				g.Log().Errorf(ctx, "BackfillMerchantWebhookEndpointSecretsBackground panic error:%+v", exception)
+			}
+		}()
+		var lastId uint64 = 0
+		for {
+			var list []*entity.MerchantWebhook
+			err := dao.MerchantWebhook.Ctx(ctx).
+				Where(dao.MerchantWebhook.Columns().WebhookSecret, "").
+				WhereGT(dao.MerchantWebhook.Columns().Id, lastId).
+				OrderAsc(dao.MerchantWebhook.Columns().Id).
+				Limit(backfillSecretPageSize).
+				Scan(&list)
+			if err != nil {
+				g.Log().Errorf(ctx, "BackfillMerchantWebhookEndpointSecretsBackground error:%s", err.Error())
+				return
+			}
+			for _, one := range list {
+				_, _ = EnsureMerchantWebhookEndpointSecret(ctx, one)
+				lastId = one.Id
+			}
+			if len(list) < backfillSecretPageSize {
+				return
+			}
+		}
+	}()
+}
+
+// GetMerchantWebhookEndpointSecret returns the secrets signing the Webhook-Signature of endpoint,
+// the secret of endpoint created before the secret generated on create is backfilled here
+func GetMerchantWebhookEndpointSecret(ctx context.Context, merchantId uint64, endpointId uint64) *bean.MerchantWebhookEndpointSecret {
+	utility.Assert(merchantId > 0, "invalid merchantId")
+	utility.Assert(endpointId > 0, "invalid endpointId")
+	one := query.GetMerchantWebhook(ctx, endpointId)
+	utility.Assert(one != nil && one.MerchantId == merchantId && one.IsDeleted == 0, "endpoint not found")
+	one, err := EnsureMerchantWebhookEndpointSecret(ctx, one)
+	utility.AssertError(err, "server error")
+	secret := &bean.MerchantWebhookEndpointSecret{Secret: one.WebhookSecret}
+	if len(one.PreviousWebhookSecret) > 0 && one.PreviousSecretExpireTime > gtime.Now().Timestamp() {
+		secret.PreviousSecret = one.PreviousWebhookSecret
+		secret.PreviousSecretExpireTime = one.PreviousSecretExpireTime
+	}
+	return secret
+}
+
+// RotateMerchantWebhookEndpointSecret generates a new secret for endpoint,
+// the current one stays valid for overlapSeconds, webhooks sent within the overlap window carry the signatures of both
+func RotateMerchantWebhookEndpointSecret(ctx context.Context, merchantId uint64, endpointId uint64, overlapSeconds int64) (*bean.MerchantWebhookEndpointSecret, error) {
+	utility.Assert(merchantId > 0, "invalid merchantId")
+	utility.Assert(endpointId > 0, "invalid endpointId")
+	utility.Assert(overlapSeconds <= MaxSecretOverlapSeconds, fmt.Sprintf("overlapSeconds should not greater than %d", MaxSecretOverlapSeconds))
+	one := query.GetMerchantWebhook(ctx, endpointId)
+	utility.Assert(one != nil && one.MerchantId == merchantId && one.IsDeleted == 0, "endpoint not found")
+	if overlapSeconds <= 0 {
+		overlapSeconds = DefaultSecretOverlapSeconds
+	}
+	secret := &bean.MerchantWebhookEndpointSecret{
+		Secret: merchant.GenerateMerchantWebHookSecret(),
+	}
+	if len(one.WebhookSecret) > 0 {
+		secret.PreviousSecret = one.WebhookSecret
+		secret.PreviousSecretExpireTime = gtime.Now().Timestamp() + overlapSeconds
+	}
+	_, err := dao.MerchantWebhook.Ctx(ctx).Data(g.Map{
+		dao.MerchantWebhook.Columns().WebhookSecret:            secret.Secret,
+		dao.MerchantWebhook.Columns().PreviousWebhookSecret:    secret.PreviousSecret,
+		dao.MerchantWebhook.Columns().PreviousSecretExpireTime: secret.PreviousSecretExpireTime,
+		dao.MerchantWebhook.Columns().GmtModify:                gtime.Now(),
+	}).Where(dao.MerchantWebhook.Columns().Id, one.Id).Where(dao.MerchantWebhook.Columns().MerchantId, merchantId).Update()
+	operation_log.AppendOptLog(ctx, &operation_log.OptLogRequest{
+		MerchantId:     one.MerchantId,
+		Target:         fmt.Sprintf("WebhookEndpoint(%v)", one.Id),
+		Content:        fmt.Sprintf("RotateSecret(%v)", utility.HideStar(secret.Secret)),
+		UserId:         0,
+		SubscriptionId: "",
+		InvoiceId:      "",
+		PlanId:         0,
+		DiscountCode:   "",
+	}, err)
+	if err != nil {
+		g.Log().Errorf(ctx, "RotateMerchantWebhookEndpointSecret Update err:%s", err.Error())
+		return nil, gerror.NewCode(gcode.New(500, "server error", nil))
+	}
+	return secret, nil
+}
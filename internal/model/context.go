@@ -42,6 +42,7 @@ type Context struct {
 	Data              g.Map
 	OpenApiConfig     *OpenApiConfig
 	OpenApiKey        string
+	OpenApiKeyId      uint64
 	IsOpenApiCall     bool
 	IsAdminPortalCall bool
 	Language          string
@@ -32,4 +32,5 @@ type CreditTransaction struct {
 	AccountType        interface{} // type of credit account, 1-main recharge account, 2-promo credit account
 	AdminMemberId      interface{} // admin_member_id
 	ExchangeRate       interface{} // keep two decimal places，multiply by 100 saved, 1 currency = 1 credit * (exchange_rate/100), main account fixed rate to 100
+	GrantDetail        interface{} // the credit grants increased or consumed by transaction, json
 }
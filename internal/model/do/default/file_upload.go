@@ -11,15 +11,19 @@ import (
 
 // FileUpload is the golang structure of table file_upload for DAO operations like Where/Data.
 type FileUpload struct {
-	g.Meta     `orm:"table:file_upload, do:true"`
-	Id         interface{} //
-	UserId     interface{} //
-	Url        interface{} //
-	FileName   interface{} //
-	Tag        interface{} //
-	GmtCreate  *gtime.Time // create time
-	GmtModify  *gtime.Time //
-	IsDeleted  interface{} // 0-UnDeleted，1-Deleted
-	CreateTime interface{} // create utc time
-	Data       []byte      // file data
+	g.Meta      `orm:"table:file_upload, do:true"`
+	Id          interface{} //
+	UserId      interface{} //
+	Url         interface{} //
+	FileName    interface{} //
+	Tag         interface{} //
+	GmtCreate   *gtime.Time // create time
+	GmtModify   *gtime.Time //
+	IsDeleted   interface{} // 0-UnDeleted，1-Deleted
+	CreateTime  interface{} // create utc time
+	Data        []byte      // file data
+	StorageType interface{} // blank-database,local,s3
+	StorageKey  interface{} // object key in storage
+	MerchantId  interface{} // merchant id
+	Migrated    interface{} // 1-migrated from database, served by its url without signature
 }
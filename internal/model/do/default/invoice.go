@@ -71,4 +71,7 @@ type Invoice struct {
 	PromoCreditDiscountAmount      interface{} // promo credit discount amount
 	PartialCreditPaidAmount        interface{} // partial credit paid amount
 	MetricCharge                   interface{} // invoice metric charge data
+	DunningAttempt                 interface{} // dunning payment retry attempt count
+	InvoiceNumber                  interface{} // legal invoice number, sequential per merchant, assigned when finalized
+	TaxLines                       interface{} // tax lines by jurisdiction (json)
 }
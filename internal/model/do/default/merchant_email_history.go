@@ -11,16 +11,17 @@ import (
 
 // MerchantEmailHistory is the golang structure of table merchant_email_history for DAO operations like Where/Data.
 type MerchantEmailHistory struct {
-	g.Meta     `orm:"table:merchant_email_history, do:true"`
-	Id         interface{} //
-	MerchantId interface{} //
-	Email      interface{} //
-	Title      interface{} //
-	Content    interface{} //
-	AttachFile interface{} //
-	GmtCreate  *gtime.Time // create time
-	GmtModify  *gtime.Time // update time
-	Response   interface{} //
-	CreateTime interface{} // create utc time
-	Status     interface{} // 0-pending,1-success,2-failure
+	g.Meta      `orm:"table:merchant_email_history, do:true"`
+	Id          interface{} //
+	MerchantId  interface{} //
+	Email       interface{} //
+	Title       interface{} //
+	Content     interface{} //
+	AttachFile  interface{} //
+	GmtCreate   *gtime.Time // create time
+	GmtModify   *gtime.Time // update time
+	Response    interface{} //
+	CreateTime  interface{} // create utc time
+	Status      interface{} // 0-pending,1-success,2-failure
+	GatewayName interface{} // name of email gateway delivered by
 }
@@ -11,13 +11,20 @@ import (
 
 // MerchantWebhook is the golang structure of table merchant_webhook for DAO operations like Where/Data.
 type MerchantWebhook struct {
-	g.Meta        `orm:"table:merchant_webhook, do:true"`
-	Id            interface{} // id
-	MerchantId    interface{} // webhook url
-	WebhookUrl    interface{} // webhook url
-	WebhookEvents interface{} // webhook_events,split dot
-	GmtCreate     *gtime.Time // create time
-	GmtModify     *gtime.Time // update time
-	CreateTime    interface{} // create utc time
-	IsDeleted     interface{} // 0-UnDeleted，1-Deleted
+	g.Meta                   `orm:"table:merchant_webhook, do:true"`
+	Id                       interface{} // id
+	MerchantId               interface{} // webhook url
+	WebhookUrl               interface{} // webhook url
+	WebhookEvents            interface{} // webhook_events,split dot
+	GmtCreate                *gtime.Time // create time
+	GmtModify                *gtime.Time // update time
+	CreateTime               interface{} // create utc time
+	IsDeleted                interface{} // 0-UnDeleted，1-Deleted
+	WebhookSecret            interface{} // secret to sign webhook
+	PreviousWebhookSecret    interface{} // rotated secret, still valid until previous_secret_expire_time
+	PreviousSecretExpireTime interface{} // utc time the rotated secret expires
+	ConsecutiveFailures      interface{} // consecutive failed delivery attempts
+	LastSuccessTime          interface{} // utc time of last success delivery
+	LastFailureTime          interface{} // utc time of last failed delivery
+	DisableTime              interface{} // utc time endpoint disabled by failures, 0-enabled
 }
@@ -11,19 +11,24 @@ import (
 
 // MerchantWebhookLog is the golang structure of table merchant_webhook_log for DAO operations like Where/Data.
 type MerchantWebhookLog struct {
-	g.Meta         `orm:"table:merchant_webhook_log, do:true"`
-	Id             interface{} // id
-	MerchantId     interface{} // webhook url
-	EndpointId     interface{} //
-	ReconsumeCount interface{} //
-	WebhookUrl     interface{} // webhook url
-	WebhookEvent   interface{} // webhook_event
-	RequestId      interface{} // request_id
-	Body           interface{} // body(json)
-	Response       interface{} // response
-	Mamo           interface{} // mamo
-	GmtCreate      *gtime.Time // create time
-	GmtModify      *gtime.Time // update time
-	CreateTime     interface{} // create utc time
-	WebhookEventId interface{} // webhook_event_id
+	g.Meta          `orm:"table:merchant_webhook_log, do:true"`
+	Id              interface{} // id
+	MerchantId      interface{} // webhook url
+	EndpointId      interface{} //
+	ReconsumeCount  interface{} //
+	WebhookUrl      interface{} // webhook url
+	WebhookEvent    interface{} // webhook_event
+	RequestId       interface{} // request_id
+	Body            interface{} // body(json)
+	Response        interface{} // response
+	Mamo            interface{} // mamo
+	GmtCreate       *gtime.Time // create time
+	GmtModify       *gtime.Time // update time
+	CreateTime      interface{} // create utc time
+	WebhookEventId  interface{} // webhook_event_id
+	Status          interface{} // 0-legacy,1-success,2-retrying,3-failed
+	AttemptCount    interface{} // delivery attempt count
+	NextRetryTime   interface{} // utc time of next redelivery
+	ResponseStatus  interface{} // http status of last attempt
+	LastAttemptTime interface{} // utc time of last attempt
 }
@@ -26,8 +26,9 @@ type PaymentTimeline struct {
 	IsDeleted      interface{} // 0-UnDeleted，1-Deleted
 	PaymentId      interface{} // PaymentId
 	Status         interface{} // 0-pending, 1-success, 2-failure
-	TimelineType   interface{} // 0-pay, 1-refund
+	TimelineType   interface{} // 0-pay, 1-refund, 2-gateway attempt
 	CreateTime     interface{} // create utc time
 	RefundId       interface{} // refund id
 	FullRefund     interface{} // 0-no, 1-yes
+	Reason         interface{} // reason of the timeline, e.g. the failure of gateway attempt
 }
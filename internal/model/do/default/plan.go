@@ -48,4 +48,5 @@ type Plan struct {
 	DisableAutoCharge         interface{} // disable auto-charge, 0-false,1-true
 	MetricCharge              interface{} // metric charge(json)
 	InternalName              interface{} //
+	PriceVersion              interface{} // current price version of plan, 0 or 1 is the initial price
 }
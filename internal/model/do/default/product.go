@@ -25,4 +25,5 @@ type Product struct {
 	IsDeleted   interface{} // 0-UnDeleted，1-Deleted
 	CreateTime  interface{} // create utc time
 	MetaData    interface{} // meta_data(json)
+	TaxCode     interface{} // tax code of product, general|digital|exempt
 }
@@ -64,4 +64,9 @@ type Subscription struct {
 	LastTrackTime               interface{} // last subscription track time
 	ExternalSubscriptionId      interface{} // external_subscription_id
 	NextInvoiceData             interface{} // next_invoice_data
+	PauseAtPeriodEnd            interface{} // whether pause at period end，0-false | 1-true
+	PausedTime                  interface{} // paused utc time, 0 if not paused
+	AutoResumeTime              interface{} // auto resume utc time, 0 if resume manually
+	PauseSource                 interface{} // pause source, manual|dunning|dispute, blank if not paused
+	PlanPriceVersion            interface{} // grandfathered price version of plan, 0-follow the current price of plan
 }
@@ -30,4 +30,5 @@ type CreditTransaction struct {
 	AccountType        int         `json:"accountType"        description:"type of credit account, 1-main recharge account, 2-promo credit account"`                                                                        // type of credit account, 1-main recharge account, 2-promo credit account
 	AdminMemberId      uint64      `json:"adminMemberId"      description:"admin_member_id"`                                                                                                                                // admin_member_id
 	ExchangeRate       int64       `json:"exchangeRate"       description:"keep two decimal places，multiply by 100 saved, 1 currency = 1 credit * (exchange_rate/100), main account fixed rate to 100"`                     // keep two decimal places，multiply by 100 saved, 1 currency = 1 credit * (exchange_rate/100), main account fixed rate to 100
+	GrantDetail        string      `json:"grantDetail"        description:"the credit grants increased or consumed by transaction, json"`                                                                                   // the credit grants increased or consumed by transaction, json
 }
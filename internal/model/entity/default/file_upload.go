@@ -10,14 +10,18 @@ import (
 
 // FileUpload is the golang structure for table file_upload.
 type FileUpload struct {
-	Id         int64       `json:"id"         description:""`                      //
-	UserId     string      `json:"userId"     description:""`                      //
-	Url        string      `json:"url"        description:""`                      //
-	FileName   string      `json:"fileName"   description:""`                      //
-	Tag        string      `json:"tag"        description:""`                      //
-	GmtCreate  *gtime.Time `json:"gmtCreate"  description:"create time"`           // create time
-	GmtModify  *gtime.Time `json:"gmtModify"  description:""`                      //
-	IsDeleted  int         `json:"isDeleted"  description:"0-UnDeleted，1-Deleted"` // 0-UnDeleted，1-Deleted
-	CreateTime int64       `json:"createTime" description:"create utc time"`       // create utc time
-	Data       []byte      `json:"data"       description:"file data"`             // file data
+	Id          int64       `json:"id"          description:""`                                                              //
+	UserId      string      `json:"userId"      description:""`                                                              //
+	Url         string      `json:"url"         description:""`                                                              //
+	FileName    string      `json:"fileName"    description:""`                                                              //
+	Tag         string      `json:"tag"         description:""`                                                              //
+	GmtCreate   *gtime.Time `json:"gmtCreate"   description:"create time"`                                                   // create time
+	GmtModify   *gtime.Time `json:"gmtModify"   description:""`                                                              //
+	IsDeleted   int         `json:"isDeleted"   description:"0-UnDeleted，1-Deleted"`                                         // 0-UnDeleted，1-Deleted
+	CreateTime  int64       `json:"createTime"  description:"create utc time"`                                               // create utc time
+	Data        []byte      `json:"data"        description:"file data"`                                                     // file data
+	StorageType string      `json:"storageType" description:"blank-database,local,s3"`                                       // blank-database,local,s3
+	StorageKey  string      `json:"storageKey"  description:"object key in storage"`                                         // object key in storage
+	MerchantId  uint64      `json:"merchantId"  description:"merchant id"`                                                   // merchant id
+	Migrated    int         `json:"migrated"    description:"1-migrated from database, served by its url without signature"` // 1-migrated from database, served by its url without signature
 }
@@ -69,4 +69,7 @@ type Invoice struct {
 	PromoCreditDiscountAmount      int64       `json:"promoCreditDiscountAmount"      description:"promo credit discount amount"`                                           // promo credit discount amount
 	PartialCreditPaidAmount        int64       `json:"partialCreditPaidAmount"        description:"partial credit paid amount"`                                             // partial credit paid amount
 	MetricCharge                   string      `json:"metricCharge"                   description:"invoice metric charge data"`                                             // invoice metric charge data
+	DunningAttempt                 int         `json:"dunningAttempt"                 description:"dunning payment retry attempt count"`                                    // dunning payment retry attempt count
+	InvoiceNumber                  string      `json:"invoiceNumber"                  description:"legal invoice number, sequential per merchant, assigned when finalized"` // legal invoice number, sequential per merchant, assigned when finalized
+	TaxLines                       string      `json:"taxLines"                       description:"tax lines by jurisdiction (json)"`                                       // tax lines by jurisdiction (json)
 }
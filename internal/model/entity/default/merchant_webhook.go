@@ -10,12 +10,19 @@ import (
 
 // MerchantWebhook is the golang structure for table merchant_webhook.
 type MerchantWebhook struct {
-	Id            uint64      `json:"id"            description:"id"`                       // id
-	MerchantId    uint64      `json:"merchantId"    description:"webhook url"`              // webhook url
-	WebhookUrl    string      `json:"webhookUrl"    description:"webhook url"`              // webhook url
-	WebhookEvents string      `json:"webhookEvents" description:"webhook_events,split dot"` // webhook_events,split dot
-	GmtCreate     *gtime.Time `json:"gmtCreate"     description:"create time"`              // create time
-	GmtModify     *gtime.Time `json:"gmtModify"     description:"update time"`              // update time
-	CreateTime    int64       `json:"createTime"    description:"create utc time"`          // create utc time
-	IsDeleted     int         `json:"isDeleted"     description:"0-UnDeleted，1-Deleted"`    // 0-UnDeleted，1-Deleted
+	Id                       uint64      `json:"id"                       description:"id"`                                                            // id
+	MerchantId               uint64      `json:"merchantId"               description:"webhook url"`                                                   // webhook url
+	WebhookUrl               string      `json:"webhookUrl"               description:"webhook url"`                                                   // webhook url
+	WebhookEvents            string      `json:"webhookEvents"            description:"webhook_events,split dot"`                                      // webhook_events,split dot
+	GmtCreate                *gtime.Time `json:"gmtCreate"                description:"create time"`                                                   // create time
+	GmtModify                *gtime.Time `json:"gmtModify"                description:"update time"`                                                   // update time
+	CreateTime               int64       `json:"createTime"               description:"create utc time"`                                               // create utc time
+	IsDeleted                int         `json:"isDeleted"                description:"0-UnDeleted，1-Deleted"`                                         // 0-UnDeleted，1-Deleted
+	WebhookSecret            string      `json:"webhookSecret"            description:"secret to sign webhook"`                                        // secret to sign webhook
+	PreviousWebhookSecret    string      `json:"previousWebhookSecret"    description:"rotated secret, still valid until previous_secret_expire_time"` // rotated secret, still valid until previous_secret_expire_time
+	PreviousSecretExpireTime int64       `json:"previousSecretExpireTime" description:"utc time the rotated secret expires"`                           // utc time the rotated secret expires
+	ConsecutiveFailures      int         `json:"consecutiveFailures"      description:"consecutive failed delivery attempts"`                          // consecutive failed delivery attempts
+	LastSuccessTime          int64       `json:"lastSuccessTime"          description:"utc time of last success delivery"`                             // utc time of last success delivery
+	LastFailureTime          int64       `json:"lastFailureTime"          description:"utc time of last failed delivery"`                              // utc time of last failed delivery
+	DisableTime              int64       `json:"disableTime"              description:"utc time endpoint disabled by failures, 0-enabled"`             // utc time endpoint disabled by failures, 0-enabled
 }
@@ -10,18 +10,23 @@ import (
 
 // MerchantWebhookLog is the golang structure for table merchant_webhook_log.
 type MerchantWebhookLog struct {
-	Id             uint64      `json:"id"             description:"id"`               // id
-	MerchantId     uint64      `json:"merchantId"     description:"webhook url"`      // webhook url
-	EndpointId     int64       `json:"endpointId"     description:""`                 //
-	ReconsumeCount int         `json:"reconsumeCount" description:""`                 //
-	WebhookUrl     string      `json:"webhookUrl"     description:"webhook url"`      // webhook url
-	WebhookEvent   string      `json:"webhookEvent"   description:"webhook_event"`    // webhook_event
-	RequestId      string      `json:"requestId"      description:"request_id"`       // request_id
-	Body           string      `json:"body"           description:"body(json)"`       // body(json)
-	Response       string      `json:"response"       description:"response"`         // response
-	Mamo           string      `json:"mamo"           description:"mamo"`             // mamo
-	GmtCreate      *gtime.Time `json:"gmtCreate"      description:"create time"`      // create time
-	GmtModify      *gtime.Time `json:"gmtModify"      description:"update time"`      // update time
-	CreateTime     int64       `json:"createTime"     description:"create utc time"`  // create utc time
-	WebhookEventId string      `json:"webhookEventId" description:"webhook_event_id"` // webhook_event_id
+	Id              uint64      `json:"id"              description:"id"`                                     // id
+	MerchantId      uint64      `json:"merchantId"      description:"webhook url"`                            // webhook url
+	EndpointId      int64       `json:"endpointId"      description:""`                                       //
+	ReconsumeCount  int         `json:"reconsumeCount"  description:""`                                       //
+	WebhookUrl      string      `json:"webhookUrl"      description:"webhook url"`                            // webhook url
+	WebhookEvent    string      `json:"webhookEvent"    description:"webhook_event"`                          // webhook_event
+	RequestId       string      `json:"requestId"       description:"request_id"`                             // request_id
+	Body            string      `json:"body"            description:"body(json)"`                             // body(json)
+	Response        string      `json:"response"        description:"response"`                               // response
+	Mamo            string      `json:"mamo"            description:"mamo"`                                   // mamo
+	GmtCreate       *gtime.Time `json:"gmtCreate"       description:"create time"`                            // create time
+	GmtModify       *gtime.Time `json:"gmtModify"       description:"update time"`                            // update time
+	CreateTime      int64       `json:"createTime"      description:"create utc time"`                        // create utc time
+	WebhookEventId  string      `json:"webhookEventId"  description:"webhook_event_id"`                       // webhook_event_id
+	Status          int         `json:"status"          description:"0-legacy,1-success,2-retrying,3-failed"` // 0-legacy,1-success,2-retrying,3-failed
+	AttemptCount    int         `json:"attemptCount"    description:"delivery attempt count"`                 // delivery attempt count
+	NextRetryTime   int64       `json:"nextRetryTime"   description:"utc time of next redelivery"`            // utc time of next redelivery
+	ResponseStatus  int         `json:"responseStatus"  description:"http status of last attempt"`            // http status of last attempt
+	LastAttemptTime int64       `json:"lastAttemptTime" description:"utc time of last attempt"`               // utc time of last attempt
 }
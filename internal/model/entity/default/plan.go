@@ -46,4 +46,5 @@ type Plan struct {
 	DisableAutoCharge         int         `json:"disableAutoCharge"         description:"disable auto-charge, 0-false,1-true"`                                                                             // disable auto-charge, 0-false,1-true
 	MetricCharge              string      `json:"metricCharge"              description:"metric charge(json)"`                                                                                             // metric charge(json)
 	InternalName              string      `json:"internalName"              description:""`                                                                                                                //
+	PriceVersion              int         `json:"priceVersion"              description:"current price version of plan, 0 or 1 is the initial price"`                                                      // current price version of plan, 0 or 1 is the initial price
 }
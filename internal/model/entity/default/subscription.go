@@ -62,4 +62,9 @@ type Subscription struct {
 	LastTrackTime               int64       `json:"lastTrackTime"               description:"last subscription track time"`                                                                                                                                   // last subscription track time
 	ExternalSubscriptionId      string      `json:"externalSubscriptionId"      description:"external_subscription_id"`                                                                                                                                       // external_subscription_id
 	NextInvoiceData             string      `json:"nextInvoiceData"             description:"next_invoice_data"`                                                                                                                                              // next_invoice_data
+	PauseAtPeriodEnd            int         `json:"pauseAtPeriodEnd"            description:"whether pause at period end，0-false | 1-true"`                                                                                                                   // whether pause at period end，0-false | 1-true
+	PausedTime                  int64       `json:"pausedTime"                  description:"paused utc time, 0 if not paused"`                                                                                                                               // paused utc time, 0 if not paused
+	AutoResumeTime              int64       `json:"autoResumeTime"              description:"auto resume utc time, 0 if resume manually"`                                                                                                                     // auto resume utc time, 0 if resume manually
+	PauseSource                 string      `json:"pauseSource"                 description:"pause source, manual|dunning|dispute, blank if not paused"`                                                                                                      // pause source, manual|dunning|dispute, blank if not paused
+	PlanPriceVersion            int         `json:"planPriceVersion"            description:"grandfathered price version of plan, 0-follow the current price of plan"`                                                                                        // grandfathered price version of plan, 0-follow the current price of plan
 }
@@ -0,0 +1,46 @@
+package query
+
+import (
+	"context"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+func GetMerchantApiKeyById(ctx context.Context, id uint64) (one *entity.MerchantApiKey) {
+	if id <= 0 {
+		return nil
+	}
+	err := dao.MerchantApiKey.Ctx(ctx).
+		Where(dao.MerchantApiKey.Columns().Id, id).
+		Where(dao.MerchantApiKey.Columns().IsDeleted, 0).
+		Scan(&one)
+	if err != nil {
+		return nil
+	}
+	return one
+}
+
+// GetMerchantApiKeyByKey returns the scoped api key matched by the current key or the previous one before rotation
+func GetMerchantApiKeyByKey(ctx context.Context, apiKey string) (one *entity.MerchantApiKey) {
+	if len(apiKey) <= 0 {
+		return nil
+	}
+	err := dao.MerchantApiKey.Ctx(ctx).
+		Where(dao.MerchantApiKey.Columns().ApiKey, apiKey).
+		Where(dao.MerchantApiKey.Columns().IsDeleted, 0).
+		Scan(&one)
+	if err != nil {
+		return nil
+	}
+	if one != nil {
+		return one
+	}
+	err = dao.MerchantApiKey.Ctx(ctx).
+		Where(dao.MerchantApiKey.Columns().PreviousApiKey, apiKey).
+		Where(dao.MerchantApiKey.Columns().IsDeleted, 0).
+		Scan(&one)
+	if err != nil {
+		return nil
+	}
+	return one
+}
@@ -0,0 +1,32 @@
+package query
+
+import (
+	"context"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+func GetPaymentDisputeByDisputeId(ctx context.Context, disputeId string) (one *entity.PaymentDispute) {
+	if len(disputeId) == 0 {
+		return nil
+	}
+	err := dao.PaymentDispute.Ctx(ctx).Where(dao.PaymentDispute.Columns().DisputeId, disputeId).OmitEmpty().Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetPaymentDisputeByGatewayDisputeId(ctx context.Context, gatewayId uint64, gatewayDisputeId string) (one *entity.PaymentDispute) {
+	if gatewayId <= 0 || len(gatewayDisputeId) == 0 {
+		return nil
+	}
+	err := dao.PaymentDispute.Ctx(ctx).
+		Where(dao.PaymentDispute.Columns().GatewayId, gatewayId).
+		Where(dao.PaymentDispute.Columns().GatewayDisputeId, gatewayDisputeId).
+		OmitEmpty().Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
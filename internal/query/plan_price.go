@@ -0,0 +1,68 @@
+package query
+
+import (
+	"context"
+	"unibee/internal/consts"
+	dao "unibee/internal/dao/default"
+	entity "unibee/internal/model/entity/default"
+)
+
+func GetPlanPriceVersion(ctx context.Context, planId uint64, version int) (one *entity.PlanPriceVersion) {
+	if planId <= 0 || version <= 0 {
+		return nil
+	}
+	err := dao.PlanPriceVersion.Ctx(ctx).
+		Where(dao.PlanPriceVersion.Columns().PlanId, planId).
+		Where(dao.PlanPriceVersion.Columns().Version, version).
+		Where(dao.PlanPriceVersion.Columns().IsDeleted, 0).
+		Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+func GetPlanPriceVersionList(ctx context.Context, planId uint64) (list []*entity.PlanPriceVersion) {
+	list = make([]*entity.PlanPriceVersion, 0)
+	if planId <= 0 {
+		return list
+	}
+	err := dao.PlanPriceVersion.Ctx(ctx).
+		Where(dao.PlanPriceVersion.Columns().PlanId, planId).
+		Where(dao.PlanPriceVersion.Columns().IsDeleted, 0).
+		OrderDesc(dao.PlanPriceVersion.Columns().Version).
+		Scan(&list)
+	if err != nil {
+		list = make([]*entity.PlanPriceVersion, 0)
+	}
+	return
+}
+
+func GetPlanPriceMigrationByMigrationId(ctx context.Context, migrationId string) (one *entity.PlanPriceMigration) {
+	if len(migrationId) == 0 {
+		return nil
+	}
+	err := dao.PlanPriceMigration.Ctx(ctx).Where(dao.PlanPriceMigration.Columns().MigrationId, migrationId).OmitEmpty().Scan(&one)
+	if err != nil {
+		one = nil
+	}
+	return
+}
+
+// GetFinishedPlanPriceMigrationList returns the finished price migrations of plan, latest effected first
+func GetFinishedPlanPriceMigrationList(ctx context.Context, planId uint64) (list []*entity.PlanPriceMigration) {
+	list = make([]*entity.PlanPriceMigration, 0)
+	if planId <= 0 {
+		return list
+	}
+	err := dao.PlanPriceMigration.Ctx(ctx).
+		Where(dao.PlanPriceMigration.Columns().PlanId, planId).
+		Where(dao.PlanPriceMigration.Columns().Status, consts.PlanPriceMigrationFinished).
+		Where(dao.PlanPriceMigration.Columns().IsDeleted, 0).
+		OrderDesc(dao.PlanPriceMigration.Columns().EffectTime).
+		Scan(&list)
+	if err != nil {
+		list = make([]*entity.PlanPriceMigration, 0)
+	}
+	return
+}
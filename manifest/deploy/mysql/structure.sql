@@ -395,6 +395,38 @@ CREATE TABLE `merchant_gateway` (
                                     UNIQUE KEY `merchant_gateway_unique` (`merchant_id`,`gateway_name`)
 ) ENGINE=InnoDB AUTO_INCREMENT=52 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Merchant Channel Config   Grab：https://developer.grab.com/docs/payment-otc/api/v2/#tag/otc-api   Klarna：https://docs.adyen.com/api-explorer/Checkout/latest/post/payments';
 
+-- ----------------------------
+-- Table structure for merchant_ledger_entry
+-- ----------------------------
+DROP TABLE IF EXISTS `merchant_ledger_entry`;
+CREATE TABLE `merchant_ledger_entry` (
+                                     `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                     `merchant_id` bigint(20) unsigned NOT NULL COMMENT 'merchant id',
+                                     `journal_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT 'journal id, lines of one journal balanced',
+                                     `entry_type` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT 'entry type, invoice_finalized|promo_credit_used|payment_success|credit_note_finalized|refund_success|invoice_voided|revenue_recognition',
+                                     `source_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'source id, invoice id, payment id or refund id',
+                                     `line_no` int(11) NOT NULL DEFAULT '0' COMMENT 'line no of journal',
+                                     `account` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT 'account, ar|revenue|deferred_revenue|tax_payable|cash|promo_credit',
+                                     `debit` bigint(20) NOT NULL DEFAULT '0' COMMENT 'debit amount, cent',
+                                     `credit` bigint(20) NOT NULL DEFAULT '0' COMMENT 'credit amount, cent',
+                                     `currency` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'currency',
+                                     `gateway_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'gateway id of cash account',
+                                     `gateway_name` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'gateway name of cash account',
+                                     `user_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'user id',
+                                     `subscription_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'subscription id',
+                                     `invoice_id` varchar(200) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'invoice id',
+                                     `description` varchar(500) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'description',
+                                     `entry_time` bigint(20) NOT NULL DEFAULT '0' COMMENT 'utc time of entry',
+                                     `unique_key` varchar(300) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT 'unique key, journal id and line no',
+                                     `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                     `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                     `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                     `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                     PRIMARY KEY (`id`) USING BTREE,
+                                     UNIQUE KEY `merchant_ledger_entry_unique` (`unique_key`),
+                                     KEY `merchant_ledger_entry_invoice` (`merchant_id`,`invoice_id`,`account`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Merchant Ledger Entry';
+
 -- ----------------------------
 -- Table structure for merchant_member
 -- ----------------------------
@@ -513,6 +545,37 @@ CREATE TABLE `merchant_operation_log` (
                                           PRIMARY KEY (`id`)
 ) ENGINE=InnoDB AUTO_INCREMENT=3915898 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Merchant Operation Log';
 
+-- ----------------------------
+-- Table structure for merchant_revenue_snapshot
+-- ----------------------------
+DROP TABLE IF EXISTS `merchant_revenue_snapshot`;
+CREATE TABLE `merchant_revenue_snapshot` (
+                                             `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'id',
+                                             `merchant_id` bigint(20) unsigned NOT NULL COMMENT 'merchant id',
+                                             `snapshot_time` bigint(20) NOT NULL COMMENT 'utc start time of the snapshot day',
+                                             `snapshot_date` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT 'snapshot day, yyyy-MM-dd',
+                                             `plan_id` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT 'plan id, 0 for revenue not from subscription',
+                                             `currency` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT 'currency',
+                                             `mrr` bigint(20) NOT NULL DEFAULT '0' COMMENT 'monthly recurring revenue at end of day, cent',
+                                             `new_mrr` bigint(20) NOT NULL DEFAULT '0' COMMENT 'mrr from new subscriptions, cent',
+                                             `expansion_mrr` bigint(20) NOT NULL DEFAULT '0' COMMENT 'mrr increased by upgrade, cent',
+                                             `contraction_mrr` bigint(20) NOT NULL DEFAULT '0' COMMENT 'mrr decreased by downgrade, cent',
+                                             `churn_mrr` bigint(20) NOT NULL DEFAULT '0' COMMENT 'mrr lost by churned subscriptions, cent',
+                                             `reactivation_mrr` bigint(20) NOT NULL DEFAULT '0' COMMENT 'mrr from reactivated subscriptions, cent',
+                                             `active_subscriptions` bigint(20) NOT NULL DEFAULT '0' COMMENT 'count of subscriptions contributing mrr at end of day',
+                                             `new_subscriptions` bigint(20) NOT NULL DEFAULT '0' COMMENT 'count of new subscriptions',
+                                             `churned_subscriptions` bigint(20) NOT NULL DEFAULT '0' COMMENT 'count of churned subscriptions',
+                                             `reactivated_subscriptions` bigint(20) NOT NULL DEFAULT '0' COMMENT 'count of reactivated subscriptions',
+                                             `revenue` bigint(20) NOT NULL DEFAULT '0' COMMENT 'paid invoice amount of day, cent',
+                                             `refund_amount` bigint(20) NOT NULL DEFAULT '0' COMMENT 'refund amount of day, cent',
+                                             `gmt_create` timestamp NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'create time',
+                                             `gmt_modify` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'update time',
+                                             `is_deleted` int(11) NOT NULL DEFAULT '0' COMMENT '0-UnDeleted，1-Deleted',
+                                             `create_time` bigint(20) DEFAULT NULL COMMENT 'create utc time',
+                                             PRIMARY KEY (`id`) USING BTREE,
+                                             UNIQUE KEY `merchant_revenue_snapshot_unique` (`merchant_id`,`snapshot_time`,`plan_id`,`currency`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Merchant Revenue Snapshot';
+
 -- ----------------------------
 -- Table structure for merchant_role
 -- ----------------------------
@@ -8,6 +8,7 @@ import (
 	"github.com/gogf/gf/v2/errors/gerror"
 	"io"
 	"net/http"
+	"time"
 )
 
 func SendRequest(url string, method string, data []byte, headers map[string]string) ([]byte, error) {
@@ -45,3 +46,39 @@ func SendRequest(url string, method string, data []byte, headers map[string]stri
 	}
 	return responseBody, nil
 }
+
+// SendRequestWithStatus sends the request within timeout and returns the http status, any 2xx status is treated as success
+func SendRequestWithStatus(url string, method string, data []byte, headers map[string]string, timeout time.Duration) (int, []byte, error) {
+	request, err := http.NewRequest(method, url, bytes.NewReader(data))
+	if err != nil {
+		return 0, nil, err
+	}
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			fmt.Println(err)
+		}
+	}(response.Body)
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return response.StatusCode, nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, responseBody, gerror.NewCode(gcode.New(response.StatusCode, response.Status, response.Status+" "+string(responseBody)), response.Status+" "+string(responseBody))
+	}
+	return response.StatusCode, responseBody, nil
+}
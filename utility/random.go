@@ -67,6 +67,10 @@ func CreateCreditRefundId() string {
 	return fmt.Sprintf("crrefund%d%03v", gtime.Now().Timestamp(), rand.New(rand.NewSource(time.Now().UnixNano())).Int31n(1000))
 }
 
+func CreateCreditGrantId() string {
+	return fmt.Sprintf("crgrant%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(12))
+}
+
 func CreateSubscriptionId() string {
 	return fmt.Sprintf("sub%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
 }
@@ -92,6 +96,14 @@ func CreateRefundId() string {
 	return fmt.Sprintf("ref%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
 }
 
+func CreateDisputeId() string {
+	return fmt.Sprintf("dis%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
+}
+
+func CreatePlanPriceMigrationId() string {
+	return fmt.Sprintf("ppm%s%s", JodaTimePrefix(), GenerateRandomAlphanumeric(15))
+}
+
 const charset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
 
 var seededRand = rand.New(rand.NewSource(time.Now().UnixNano()))